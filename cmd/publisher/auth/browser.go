@@ -0,0 +1,391 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	// browserLoginTimeout is how long to wait for the login to be completed in the browser
+	browserLoginTimeout = 5 * time.Minute
+	// browserCallbackPath is the path of the local server the identity provider redirects back to
+	browserCallbackPath = "/callback"
+)
+
+// OpenBrowser opens a URL in the user's default browser. Tests replace it to follow the URL themselves.
+var OpenBrowser = openBrowser
+
+// BrowserProvider implements the Provider interface with the OAuth authorization code flow of the
+// registry's OIDC issuer: the login happens in the user's browser, which is redirected back to a server
+// on a localhost port with the code, and the resulting ID token is exchanged for a registry token
+type BrowserProvider struct {
+	registryURL  string
+	port         int
+	issuer       string
+	clientID     string
+	token        string
+	refreshToken string
+}
+
+// browserHealthResponse holds the browser login settings of the registry's health endpoint
+type browserHealthResponse struct {
+	OIDCIssuer   string `json:"oidc_issuer"`
+	OIDCClientID string `json:"oidc_client_id"`
+}
+
+// oidcDiscovery holds the endpoints of an issuer's OpenID configuration
+type oidcDiscovery struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// oidcTokenResponse is the response of an issuer's token endpoint
+type oidcTokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// browserCallback is what the identity provider redirected back with
+type browserCallback struct {
+	code string
+	err  error
+}
+
+// NewBrowserProvider creates a new browser login provider. The callback server listens on port, or on
+// any free port if it is 0.
+func NewBrowserProvider(registryURL string, port int) Provider {
+	return &BrowserProvider{
+		registryURL: strings.TrimSuffix(registryURL, "/"),
+		port:        port,
+	}
+}
+
+// GetToken returns the registry JWT obtained by Login
+func (b *BrowserProvider) GetToken(_ context.Context) (string, error) {
+	if b.token == "" {
+		return "", fmt.Errorf("not logged in")
+	}
+	return b.token, nil
+}
+
+// NeedsLogin always returns true, as browser logins are not stored between runs
+func (b *BrowserProvider) NeedsLogin() bool {
+	return true
+}
+
+// Login opens the issuer's login page in the browser, waits for it to redirect back to the local
+// callback server, and exchanges the ID token it obtains for a registry token
+func (b *BrowserProvider) Login(ctx context.Context) error {
+	if err := b.loadSettings(ctx); err != nil {
+		return fmt.Errorf("error getting browser login settings: %w", err)
+	}
+	endpoints, err := b.discover(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading the OpenID configuration of %s: %w", b.issuer, err)
+	}
+
+	// Loopback redirects go to an IP address rather than localhost, which could resolve elsewhere (RFC 8252)
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", b.port))
+	if err != nil {
+		return fmt.Errorf("failed to start the local callback server: %w", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	redirectURI := fmt.Sprintf("http://127.0.0.1:%d%s", port, browserCallbackPath)
+
+	verifier, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+	state, err := randomToken(16)
+	if err != nil {
+		return err
+	}
+	nonce, err := randomToken(16)
+	if err != nil {
+		return err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	authURL, err := url.Parse(endpoints.AuthorizationEndpoint)
+	if err != nil {
+		return fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", b.clientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", "openid email profile")
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	callbacks := make(chan browserCallback, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(browserCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		// Requests without our state did not come from this login, so they are turned away
+		if params.Get("state") != state {
+			http.Error(w, "Invalid login state. Start the login again from mcp-publisher.", http.StatusBadRequest)
+			return
+		}
+		callback := browserCallback{code: params.Get("code")}
+		switch {
+		case params.Get("error") != "":
+			callback.err = fmt.Errorf("the identity provider returned %s: %s", params.Get("error"), params.Get("error_description"))
+		case callback.code == "":
+			callback.err = errors.New("the identity provider returned no authorization code")
+		}
+		writeCallbackPage(w, callback.err)
+		select {
+		case callbacks <- callback:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	_, _ = fmt.Fprintln(Output, "\nOpening your browser to log in. If it does not open, go to:")
+	_, _ = fmt.Fprintln(Output, " ", authURL.String())
+	if err := OpenBrowser(authURL.String()); err != nil {
+		_, _ = fmt.Fprintf(Output, "Could not open a browser: %v\n", err)
+	}
+	_, _ = fmt.Fprintln(Output, "Waiting for the login to complete in the browser...")
+
+	waitCtx, cancel := context.WithTimeout(ctx, browserLoginTimeout)
+	defer cancel()
+	var callback browserCallback
+	select {
+	case callback = <-callbacks:
+	case <-waitCtx.Done():
+		return errors.New("timed out waiting for the login to complete in the browser")
+	}
+	if callback.err != nil {
+		return callback.err
+	}
+
+	idToken, err := b.exchangeCode(ctx, endpoints.TokenEndpoint, callback.code, verifier, redirectURI)
+	if err != nil {
+		return fmt.Errorf("error exchanging the authorization code: %w", err)
+	}
+	if err := checkNonce(idToken, nonce); err != nil {
+		return err
+	}
+
+	tokenResp, err := b.exchangeTokenForRegistry(ctx, idToken)
+	if err != nil {
+		return err
+	}
+	b.token = tokenResp.RegistryToken
+	b.refreshToken = tokenResp.RefreshToken
+
+	_, _ = fmt.Fprintln(Output, "Successfully authenticated!")
+	return nil
+}
+
+// Name returns the name of this auth provider
+func (b *BrowserProvider) Name() string {
+	return "browser"
+}
+
+// RefreshToken returns the refresh token from the token exchange, if any
+func (b *BrowserProvider) RefreshToken() string {
+	return b.refreshToken
+}
+
+// loadSettings retrieves the OIDC issuer and client ID from the registry's health endpoint
+func (b *BrowserProvider) loadSettings(ctx context.Context) error {
+	var health browserHealthResponse
+	if err := getJSON(ctx, b.registryURL+"/v0/health", &health); err != nil {
+		return err
+	}
+	if health.OIDCIssuer == "" || health.OIDCClientID == "" {
+		return fmt.Errorf("browser login is not enabled on this registry")
+	}
+	b.issuer = strings.TrimSuffix(health.OIDCIssuer, "/")
+	b.clientID = health.OIDCClientID
+	return nil
+}
+
+// discover reads the authorization and token endpoints of the issuer
+func (b *BrowserProvider) discover(ctx context.Context) (*oidcDiscovery, error) {
+	var endpoints oidcDiscovery
+	if err := getJSON(ctx, b.issuer+"/.well-known/openid-configuration", &endpoints); err != nil {
+		return nil, err
+	}
+	if endpoints.AuthorizationEndpoint == "" || endpoints.TokenEndpoint == "" {
+		return nil, errors.New("authorization_endpoint or token_endpoint is missing")
+	}
+	return &endpoints, nil
+}
+
+// exchangeCode redeems the authorization code, with the PKCE verifier, for an ID token
+func (b *BrowserProvider) exchangeCode(ctx context.Context, tokenEndpoint, code, verifier, redirectURI string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {b.clientID},
+		"code":          {code},
+		"code_verifier": {verifier},
+		"redirect_uri":  {redirectURI},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var tokenResp oidcTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("token endpoint returned status %d with an unreadable body: %w", resp.StatusCode, err)
+	}
+	if tokenResp.Error != "" {
+		return "", fmt.Errorf("%s: %s", tokenResp.Error, tokenResp.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK || tokenResp.IDToken == "" {
+		return "", fmt.Errorf("token endpoint returned status %d without an ID token", resp.StatusCode)
+	}
+	return tokenResp.IDToken, nil
+}
+
+// exchangeTokenForRegistry exchanges the ID token for a registry token
+func (b *BrowserProvider) exchangeTokenForRegistry(ctx context.Context, idToken string) (*RegistryTokenResponse, error) {
+	payload, err := json.Marshal(map[string]string{"oidc_token": idToken})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.registryURL+"/v0/auth/oidc", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var tokenResp RegistryTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &tokenResp, nil
+}
+
+// checkNonce checks that the ID token was issued for this login. Its signature is checked by the
+// registry when it is exchanged.
+func checkNonce(idToken, nonce string) error {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return errors.New("the identity provider returned an invalid ID token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("the identity provider returned an invalid ID token: %w", err)
+	}
+	var claims struct {
+		Nonce string `json:"nonce"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return fmt.Errorf("the identity provider returned an invalid ID token: %w", err)
+	}
+	if claims.Nonce != nonce {
+		return errors.New("the ID token was not issued for this login")
+	}
+	return nil
+}
+
+// getJSON decodes the JSON response of a GET request
+func getJSON(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s returned status %d: %s", url, resp.StatusCode, body)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// writeCallbackPage tells the user in the browser whether the login worked
+func writeCallbackPage(w http.ResponseWriter, loginErr error) {
+	message := "You are logged in to the MCP Registry. You can close this window and return to the terminal."
+	status := http.StatusOK
+	if loginErr != nil {
+		message = "Login failed: " + loginErr.Error()
+		status = http.StatusBadRequest
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, "<!DOCTYPE html>\n<html><head><title>mcp-publisher</title></head><body><p>%s</p></body></html>\n", html.EscapeString(message))
+}
+
+// randomToken returns a random URL-safe string of n bytes of entropy
+func randomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// openBrowser opens a URL with the platform's handler for web pages
+func openBrowser(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Release the process, as the browser may keep running long after the login
+	return cmd.Process.Release()
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TokenPermission is a permission granted by a registry token: an action on the servers matching a
+// resource pattern such as "io.github.username/*"
+type TokenPermission struct {
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+}
+
+// RegistryTokenClaims are the claims of a registry token that matter to publishers
+type RegistryTokenClaims struct {
+	ExpiresAt         int64             `json:"exp"`
+	IssuedAt          int64             `json:"iat"`
+	AuthMethod        string            `json:"auth_method"`
+	AuthMethodSubject string            `json:"auth_method_sub"`
+	Permissions       []TokenPermission `json:"permissions"`
+	TokenType         string            `json:"token_type"`
+	StepUp            bool              `json:"step_up"`
+}
+
+// Expiry returns when the token expires, or the zero time if it does not say
+func (c *RegistryTokenClaims) Expiry() time.Time {
+	if c.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// DecodeRegistryToken reads the claims of a registry token. The token is not verified, so the claims
+// are only fit for showing to the user and deciding when to renew it; the registry checks them itself.
+func DecodeRegistryToken(token string) (*RegistryTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT payload: %w", err)
+	}
+
+	var claims RegistryTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("invalid JWT claims: %w", err)
+	}
+	return &claims, nil
+}
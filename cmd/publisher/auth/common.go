@@ -8,12 +8,16 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha512"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -35,6 +39,7 @@ type CryptoProvider struct {
 	privateKey      string
 	cryptoAlgorithm CryptoAlgorithm
 	authMethod      string
+	refreshToken    string
 }
 
 // GetToken retrieves the registry JWT token using cryptographic authentication
@@ -44,13 +49,12 @@ func (c *CryptoProvider) GetToken(ctx context.Context) (string, error) {
 	}
 
 	if c.privateKey == "" {
-		return "", fmt.Errorf("%s private key (hex) is required", c.authMethod)
+		return "", fmt.Errorf("%s private key is required", c.authMethod)
 	}
 
-	// Decode private key from hex
-	privateKeyBytes, err := hex.DecodeString(c.privateKey)
+	privateKeyBytes, err := parsePrivateKey(c.privateKey, c.cryptoAlgorithm)
 	if err != nil {
-		return "", fmt.Errorf("invalid hex private key format: %w", err)
+		return "", err
 	}
 
 	// Generate current timestamp
@@ -71,7 +75,12 @@ func (c *CryptoProvider) GetToken(ctx context.Context) (string, error) {
 }
 
 func (c *CryptoProvider) signMessage(privateKeyBytes []byte, message []byte) ([]byte, error) {
-	switch c.cryptoAlgorithm {
+	return signMessage(c.cryptoAlgorithm, privateKeyBytes, message)
+}
+
+// signMessage signs message with a raw private key of algorithm
+func signMessage(algorithm CryptoAlgorithm, privateKeyBytes []byte, message []byte) ([]byte, error) {
+	switch algorithm {
 	case AlgorithmEd25519:
 		if len(privateKeyBytes) != ed25519.SeedSize {
 			return nil, fmt.Errorf("invalid seed length: expected %d bytes, got %d", ed25519.SeedSize, len(privateKeyBytes))
@@ -98,11 +107,87 @@ func (c *CryptoProvider) signMessage(privateKeyBytes []byte, message []byte) ([]
 		if err != nil {
 			return nil, fmt.Errorf("failed to sign message: %w", err)
 		}
-		signature := append(r.Bytes(), s.Bytes()...)
+		// R and S are padded to 48 bytes each, as the registry expects exactly 96
+		signature := make([]byte, 96)
+		r.FillBytes(signature[:48])
+		s.FillBytes(signature[48:])
 		return signature, nil
 	default:
-		return nil, fmt.Errorf("unsupported crypto algorithm: %s", c.cryptoAlgorithm)
+		return nil, fmt.Errorf("unsupported crypto algorithm: %s", algorithm)
+	}
+}
+
+// parsePrivateKey returns the raw private key signMessage takes, from either hex or PEM, checking a
+// PEM key is of algorithm
+func parsePrivateKey(key string, algorithm CryptoAlgorithm) ([]byte, error) {
+	privateKeyBytes, keyAlgorithm, err := decodePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if keyAlgorithm != "" && keyAlgorithm != algorithm {
+		return nil, fmt.Errorf("the private key is an %s key, so use --algorithm %s", algorithmName(keyAlgorithm), keyAlgorithm)
+	}
+	return privateKeyBytes, nil
+}
+
+// decodePrivateKey returns the raw private key signMessage takes, from either hex or PEM, along with
+// its algorithm if it is PEM. PEM keys can be PKCS#8, as written by 'openssl genpkey', or SEC1 EC keys,
+// as written by 'openssl ecparam'.
+func decodePrivateKey(key string) ([]byte, CryptoAlgorithm, error) {
+	key = strings.TrimSpace(key)
+	if !strings.HasPrefix(key, "-----BEGIN") {
+		privateKeyBytes, err := hex.DecodeString(key)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid private key: expected hex or PEM: %w", err)
+		}
+		return privateKeyBytes, "", nil
+	}
+
+	rest := []byte(key)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, "", errors.New("invalid private key: no PEM private key found")
+		}
+
+		var parsed any
+		var err error
+		switch block.Type {
+		case "PRIVATE KEY":
+			parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			parsed, err = x509.ParseECPrivateKey(block.Bytes)
+		case "ENCRYPTED PRIVATE KEY":
+			return nil, "", errors.New("encrypted private keys are not supported; decrypt it with 'openssl pkey' first")
+		default:
+			// Such as the EC PARAMETERS 'openssl ecparam' writes before the key
+			continue
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid PEM private key: %w", err)
+		}
+
+		switch privateKey := parsed.(type) {
+		case ed25519.PrivateKey:
+			return privateKey.Seed(), AlgorithmEd25519, nil
+		case *ecdsa.PrivateKey:
+			if privateKey.Curve != elliptic.P384() {
+				return nil, "", fmt.Errorf("unsupported ECDSA curve %s: only P-384 is supported", privateKey.Curve.Params().Name)
+			}
+			return privateKey.D.FillBytes(make([]byte, 48)), AlgorithmECDSAP384, nil
+		default:
+			return nil, "", fmt.Errorf("unsupported private key type %T: use an Ed25519 or ECDSA P-384 key", parsed)
+		}
+	}
+}
+
+// algorithmName is how error messages refer to an algorithm
+func algorithmName(algorithm CryptoAlgorithm) string {
+	if algorithm == AlgorithmECDSAP384 {
+		return "ECDSA P-384"
 	}
+	return "Ed25519"
 }
 
 // parseRawPrivateKey parses a raw ECDSA private key from bytes.
@@ -196,5 +281,11 @@ func (c *CryptoProvider) exchangeTokenForRegistry(ctx context.Context, domain, t
 		return "", fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	c.refreshToken = tokenResp.RefreshToken
 	return tokenResp.RegistryToken, nil
 }
+
+// RefreshToken returns the refresh token from the last token exchange, if any
+func (c *CryptoProvider) RefreshToken() string {
+	return c.refreshToken
+}
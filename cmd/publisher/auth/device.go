@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RegistryDeviceCodeResponse represents the response from the registry's device code endpoint
+type RegistryDeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// deviceTokenError represents an error from the registry's device token endpoint
+type deviceTokenError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// DeviceProvider implements the Provider interface by having a login on another machine approve this one
+type DeviceProvider struct {
+	registryURL  string
+	token        string
+	refreshToken string
+}
+
+// NewDeviceProvider creates a new device login provider
+func NewDeviceProvider(registryURL string) Provider {
+	return &DeviceProvider{
+		registryURL: strings.TrimSuffix(registryURL, "/"),
+	}
+}
+
+// GetToken returns the registry JWT obtained by Login
+func (d *DeviceProvider) GetToken(_ context.Context) (string, error) {
+	if d.token == "" {
+		return "", fmt.Errorf("not logged in")
+	}
+	return d.token, nil
+}
+
+// NeedsLogin always returns true, as device logins are not stored between runs
+func (d *DeviceProvider) NeedsLogin() bool {
+	return true
+}
+
+// Login requests a user code and waits for it to be approved from another machine
+func (d *DeviceProvider) Login(ctx context.Context) error {
+	var deviceCode RegistryDeviceCodeResponse
+	status, body, err := d.post(ctx, "/v0/auth/device/code", nil)
+	if err != nil {
+		return fmt.Errorf("error requesting device code: %w", err)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("request device code failed with status %d: %s", status, body)
+	}
+	if err := json.Unmarshal(body, &deviceCode); err != nil {
+		return fmt.Errorf("error decoding device code response: %w", err)
+	}
+
+	_, _ = fmt.Fprintln(Output, "\nTo authenticate, on a machine where you are logged in, run:")
+	_, _ = fmt.Fprintln(Output, "  mcp-publisher approve", deviceCode.UserCode)
+	_, _ = fmt.Fprintln(Output, "For instructions, see:", deviceCode.VerificationURIComplete)
+
+	_, _ = fmt.Fprintln(Output, "Waiting for approval...")
+	tokenResp, err := d.pollForToken(ctx, &deviceCode)
+	if err != nil {
+		return err
+	}
+
+	d.token = tokenResp.RegistryToken
+	d.refreshToken = tokenResp.RefreshToken
+	return nil
+}
+
+// RefreshToken returns the refresh token obtained by Login, if any
+func (d *DeviceProvider) RefreshToken() string {
+	return d.refreshToken
+}
+
+// Name returns the name of this auth provider
+func (d *DeviceProvider) Name() string {
+	return "device"
+}
+
+// pollForToken polls for the registry JWT until the login is approved, denied or expires
+func (d *DeviceProvider) pollForToken(ctx context.Context, deviceCode *RegistryDeviceCodeResponse) (*RegistryTokenResponse, error) {
+	payload, err := json.Marshal(map[string]string{"device_code": deviceCode.DeviceCode})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	interval := time.Duration(max(deviceCode.Interval, 5)) * time.Second
+	deadline := time.Now().Add(time.Duration(deviceCode.ExpiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		status, body, err := d.post(ctx, "/v0/auth/device/token", payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if status == http.StatusOK {
+			var tokenResp RegistryTokenResponse
+			if err := json.Unmarshal(body, &tokenResp); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+			}
+			return &tokenResp, nil
+		}
+
+		var tokenErr deviceTokenError
+		if err := json.Unmarshal(body, &tokenErr); err != nil || tokenErr.Error == "" {
+			return nil, fmt.Errorf("token request failed with status %d: %s", status, body)
+		}
+
+		switch tokenErr.Error {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return nil, fmt.Errorf("the login was denied")
+		case "expired_token":
+			return nil, fmt.Errorf("the code expired before it was approved")
+		default:
+			return nil, fmt.Errorf("token request failed: %s", tokenErr.Error)
+		}
+	}
+
+	return nil, fmt.Errorf("the code expired before it was approved")
+}
+
+// post sends a JSON request to the registry and returns the status code and body of the response
+func (d *DeviceProvider) post(ctx context.Context, path string, payload []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.registryURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return resp.StatusCode, body, nil
+}
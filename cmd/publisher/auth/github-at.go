@@ -40,6 +40,7 @@ type AccessTokenResponse struct {
 type RegistryTokenResponse struct {
 	RegistryToken string `json:"registry_token"`
 	ExpiresAt     int64  `json:"expires_at"`
+	RefreshToken  string `json:"refresh_token,omitempty"`
 }
 
 // StoredRegistryToken represents the registry token with expiration stored locally
@@ -50,9 +51,10 @@ type StoredRegistryToken struct {
 
 // GitHubATProvider implements the Provider interface using GitHub's device flow
 type GitHubATProvider struct {
-	clientID    string
-	forceLogin  bool
-	registryURL string
+	clientID     string
+	forceLogin   bool
+	registryURL  string
+	refreshToken string
 }
 
 // ServerHealthResponse represents the response from the health endpoint
@@ -140,13 +142,13 @@ func (g *GitHubATProvider) Login(ctx context.Context) error {
 	}
 
 	// Display instructions to the user
-	_, _ = fmt.Fprintln(os.Stdout, "\nTo authenticate, please:")
-	_, _ = fmt.Fprintln(os.Stdout, "1. Go to:", verificationURI)
-	_, _ = fmt.Fprintln(os.Stdout, "2. Enter code:", userCode)
-	_, _ = fmt.Fprintln(os.Stdout, "3. Authorize this application")
+	_, _ = fmt.Fprintln(Output, "\nTo authenticate, please:")
+	_, _ = fmt.Fprintln(Output, "1. Go to:", verificationURI)
+	_, _ = fmt.Fprintln(Output, "2. Enter code:", userCode)
+	_, _ = fmt.Fprintln(Output, "3. Authorize this application")
 
 	// Poll for the token
-	_, _ = fmt.Fprintln(os.Stdout, "Waiting for authorization...")
+	_, _ = fmt.Fprintln(Output, "Waiting for authorization...")
 	token, err := g.pollForToken(ctx, deviceCode)
 	if err != nil {
 		return fmt.Errorf("error polling for token: %w", err)
@@ -158,7 +160,7 @@ func (g *GitHubATProvider) Login(ctx context.Context) error {
 		return fmt.Errorf("error saving token: %w", err)
 	}
 
-	_, _ = fmt.Fprintln(os.Stdout, "Successfully authenticated!")
+	_, _ = fmt.Fprintln(Output, "Successfully authenticated!")
 	return nil
 }
 
@@ -384,9 +386,15 @@ func (g *GitHubATProvider) exchangeTokenForRegistry(ctx context.Context, githubT
 		return "", 0, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	g.refreshToken = tokenResp.RefreshToken
 	return tokenResp.RegistryToken, tokenResp.ExpiresAt, nil
 }
 
+// RefreshToken returns the refresh token from the last token exchange, if any
+func (g *GitHubATProvider) RefreshToken() string {
+	return g.refreshToken
+}
+
 // saveRegistryToken saves the registry JWT token to a local file with expiration
 func saveRegistryToken(token string, expiresAt int64) error {
 	storedToken := StoredRegistryToken{
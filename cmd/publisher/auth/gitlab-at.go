@@ -0,0 +1,281 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	gitLabTokenFilePath = ".mcpregistry_gitlab_token" // #nosec:G101
+)
+
+// GitLabATProvider implements the Provider interface using GitLab's device authorization flow
+type GitLabATProvider struct {
+	clientID     string
+	gitlabURL    string
+	forceLogin   bool
+	registryURL  string
+	refreshToken string
+}
+
+// gitLabHealthResponse represents the GitLab login settings in the response from the health endpoint
+type gitLabHealthResponse struct {
+	GitLabClientID string `json:"gitlab_client_id"`
+	GitLabURL      string `json:"gitlab_url"`
+}
+
+// NewGitLabATProvider creates a new GitLab OAuth provider
+func NewGitLabATProvider(forceLogin bool, registryURL string) Provider {
+	return &GitLabATProvider{
+		forceLogin:  forceLogin,
+		registryURL: registryURL,
+	}
+}
+
+// GetToken retrieves the registry JWT token (exchanges GitLab token if needed)
+func (g *GitLabATProvider) GetToken(ctx context.Context) (string, error) {
+	registryToken, err := readRegistryToken()
+	if err == nil && registryToken != "" {
+		return registryToken, nil
+	}
+
+	gitlabToken, err := os.ReadFile(gitLabTokenFilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read GitLab token: %w", err)
+	}
+
+	registryToken, expiresAt, err := g.exchangeTokenForRegistry(ctx, string(gitlabToken))
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange token: %w", err)
+	}
+
+	if err := saveRegistryToken(registryToken, expiresAt); err != nil {
+		return "", fmt.Errorf("failed to save registry token: %w", err)
+	}
+
+	return registryToken, nil
+}
+
+// NeedsLogin checks if a new login is required
+func (g *GitLabATProvider) NeedsLogin() bool {
+	if g.forceLogin {
+		return true
+	}
+
+	_, statErr := os.Stat(gitLabTokenFilePath)
+	return os.IsNotExist(statErr)
+}
+
+// Login performs the GitLab device authorization flow
+func (g *GitLabATProvider) Login(ctx context.Context) error {
+	if g.clientID == "" {
+		if err := g.loadSettings(ctx); err != nil {
+			return fmt.Errorf("error getting GitLab login settings: %w", err)
+		}
+	}
+
+	deviceCode, err := g.requestDeviceCode(ctx)
+	if err != nil {
+		return fmt.Errorf("error requesting device code: %w", err)
+	}
+
+	_, _ = fmt.Fprintln(Output, "\nTo authenticate, please:")
+	_, _ = fmt.Fprintln(Output, "1. Go to:", deviceCode.VerificationURI)
+	_, _ = fmt.Fprintln(Output, "2. Enter code:", deviceCode.UserCode)
+	_, _ = fmt.Fprintln(Output, "3. Authorize this application")
+
+	_, _ = fmt.Fprintln(Output, "Waiting for authorization...")
+	token, err := g.pollForToken(ctx, deviceCode)
+	if err != nil {
+		return fmt.Errorf("error polling for token: %w", err)
+	}
+
+	if err := os.WriteFile(gitLabTokenFilePath, []byte(token), 0600); err != nil {
+		return fmt.Errorf("error saving token: %w", err)
+	}
+
+	_, _ = fmt.Fprintln(Output, "Successfully authenticated!")
+	return nil
+}
+
+// Name returns the name of this auth provider
+func (g *GitLabATProvider) Name() string {
+	return "gitlab"
+}
+
+// loadSettings retrieves the GitLab instance and OAuth client ID from the registry's health endpoint
+func (g *GitLabATProvider) loadSettings(ctx context.Context) error {
+	if g.registryURL == "" {
+		return fmt.Errorf("registry URL is required to get GitLab login settings")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.registryURL+"/v0/health", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("health endpoint returned status %d: %s", resp.StatusCode, body)
+	}
+
+	var health gitLabHealthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return err
+	}
+	if health.GitLabClientID == "" || health.GitLabURL == "" {
+		return fmt.Errorf("GitLab login is not configured on this registry")
+	}
+
+	g.clientID = health.GitLabClientID
+	g.gitlabURL = strings.TrimSuffix(health.GitLabURL, "/")
+	return nil
+}
+
+// requestDeviceCode initiates the device authorization flow
+func (g *GitLabATProvider) requestDeviceCode(ctx context.Context) (*DeviceCodeResponse, error) {
+	form := url.Values{
+		"client_id": {g.clientID},
+		"scope":     {"read_api"},
+	}
+
+	var deviceCode DeviceCodeResponse
+	status, err := g.postForm(ctx, "/oauth/authorize_device", form, &deviceCode)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("request device code failed with status %d", status)
+	}
+
+	return &deviceCode, nil
+}
+
+// pollForToken polls for an access token until the user completes authorization or the code expires
+func (g *GitLabATProvider) pollForToken(ctx context.Context, deviceCode *DeviceCodeResponse) (string, error) {
+	form := url.Values{
+		"client_id":   {g.clientID},
+		"device_code": {deviceCode.DeviceCode},
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+	}
+
+	interval := time.Duration(max(deviceCode.Interval, 5)) * time.Second
+	expiresIn := deviceCode.ExpiresIn
+	if expiresIn <= 0 {
+		expiresIn = 300
+	}
+	deadline := time.Now().Add(time.Duration(expiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		var tokenResp AccessTokenResponse
+		if _, err := g.postForm(ctx, "/oauth/token", form, &tokenResp); err != nil {
+			return "", err
+		}
+
+		switch tokenResp.Error {
+		case "":
+			if tokenResp.AccessToken == "" {
+				return "", fmt.Errorf("failed to obtain access token")
+			}
+			return tokenResp.AccessToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return "", fmt.Errorf("token request failed: %s", tokenResp.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+
+	return "", fmt.Errorf("device code authorization timed out")
+}
+
+// postForm posts a form to the GitLab instance and decodes the JSON response, returning its status code
+func (g *GitLabATProvider) postForm(ctx context.Context, path string, form url.Values, out any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.gitlabURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return resp.StatusCode, fmt.Errorf("unexpected response from GitLab (status %d): %s", resp.StatusCode, body)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// exchangeTokenForRegistry exchanges a GitLab token for a registry JWT token
+func (g *GitLabATProvider) exchangeTokenForRegistry(ctx context.Context, gitlabToken string) (string, int64, error) {
+	if g.registryURL == "" {
+		return "", 0, fmt.Errorf("registry URL is required for token exchange")
+	}
+
+	jsonData, err := json.Marshal(map[string]string{"gitlab_token": gitlabToken})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.registryURL+"/v0/auth/gitlab-at", strings.NewReader(string(jsonData)))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("token exchange failed with status %d (GitLab tokens expire after 2 hours; run 'mcp-publisher login gitlab' again if it has expired): %s", resp.StatusCode, body)
+	}
+
+	var tokenResp RegistryTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	g.refreshToken = tokenResp.RefreshToken
+	return tokenResp.RegistryToken, tokenResp.ExpiresAt, nil
+}
+
+// RefreshToken returns the refresh token from the last token exchange, if any
+func (g *GitLabATProvider) RefreshToken() string {
+	return g.refreshToken
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// GitLabIDTokenEnvVar is the variable GitLab CI jobs should request their ID token in, with id_tokens
+// and the mcp-registry audience
+const GitLabIDTokenEnvVar = "MCP_ID_TOKEN"
+
+// gitLabLegacyIDTokenEnvVar holds the ID token of jobs on GitLab versions before id_tokens
+const gitLabLegacyIDTokenEnvVar = "CI_JOB_JWT_V2"
+
+type GitLabOIDCProvider struct {
+	registryURL string
+}
+
+// NewGitLabOIDCProvider creates a new GitLab CI OIDC provider
+func NewGitLabOIDCProvider(registryURL string) Provider {
+	return &GitLabOIDCProvider{
+		registryURL: registryURL,
+	}
+}
+
+// GetToken exchanges the ID token of the GitLab CI job for a registry JWT token
+func (o *GitLabOIDCProvider) GetToken(ctx context.Context) (string, error) {
+	idToken := os.Getenv(GitLabIDTokenEnvVar)
+	if idToken == "" {
+		idToken = os.Getenv(gitLabLegacyIDTokenEnvVar)
+	}
+	if idToken == "" {
+		return "", fmt.Errorf("%s environment variable not found - are you running in GitLab CI with id_tokens: %s: aud: mcp-registry?", GitLabIDTokenEnvVar, GitLabIDTokenEnvVar)
+	}
+
+	payload, err := json.Marshal(map[string]string{"oidc_token": idToken})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	exchangeURL := strings.TrimSuffix(o.registryURL, "/") + "/v0/auth/gitlab-oidc"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exchangeURL, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var tokenResp RegistryTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return tokenResp.RegistryToken, nil
+}
+
+// NeedsLogin always returns false, as the ID token is provided by the GitLab CI job
+func (o *GitLabOIDCProvider) NeedsLogin() bool {
+	return false
+}
+
+// Login is not needed, as the ID token is provided by the GitLab CI job
+func (o *GitLabOIDCProvider) Login(_ context.Context) error {
+	return nil
+}
+
+// Name returns the name of this auth provider
+func (o *GitLabOIDCProvider) Name() string {
+	return "gitlab-oidc"
+}
@@ -1,6 +1,14 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"io"
+	"os"
+)
+
+// Output is where providers print instructions for the user, such as a code to enter. Commands set it to
+// stderr when stdout is kept for their JSON result.
+var Output io.Writer = os.Stdout
 
 // Provider defines the interface for authentication mechanisms
 type Provider interface {
@@ -19,3 +27,10 @@ type Provider interface {
 	// Name returns the name of the authentication provider
 	Name() string
 }
+
+// RefreshTokenProvider is implemented by providers whose token exchange can also return a refresh
+// token, which lets the registry token be renewed without logging in again
+type RefreshTokenProvider interface {
+	// RefreshToken returns the refresh token, or "" if the registry did not issue one
+	RefreshToken() string
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RefreshRegistryToken exchanges a refresh token for a new registry token and refresh token
+func RefreshRegistryToken(ctx context.Context, registryURL, refreshToken string) (*RegistryTokenResponse, error) {
+	jsonData, err := json.Marshal(map[string]string{"refresh_token": refreshToken})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	refreshURL := strings.TrimSuffix(registryURL, "/") + "/v0/auth/refresh"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, refreshURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token refresh failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var tokenResp RegistryTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &tokenResp, nil
+}
+
+// TokenExpiresWithin reports whether a registry token expires within d. The token is not verified;
+// tokens whose expiry cannot be read are treated as expiring.
+func TokenExpiresWithin(token string, d time.Duration) bool {
+	claims, err := DecodeRegistryToken(token)
+	if err != nil || claims.ExpiresAt == 0 {
+		return true
+	}
+
+	return time.Until(claims.Expiry()) < d
+}
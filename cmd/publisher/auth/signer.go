@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/base64"
+	"fmt"
+)
+
+// Signer signs with an Ed25519 or ECDSA P-384 private key, the same keys DNS and HTTP authentication use
+type Signer struct {
+	algorithm  CryptoAlgorithm
+	privateKey []byte
+}
+
+// NewSigner reads a private key in hex or PEM. The algorithm of a hex key is told by its length.
+func NewSigner(key string) (*Signer, error) {
+	privateKey, algorithm, err := decodePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if algorithm == "" {
+		switch len(privateKey) {
+		case ed25519.SeedSize:
+			algorithm = AlgorithmEd25519
+		case 48:
+			algorithm = AlgorithmECDSAP384
+		default:
+			return nil, fmt.Errorf("invalid private key: expected 32 bytes for Ed25519 or 48 for ECDSA P-384, got %d", len(privateKey))
+		}
+	}
+	return &Signer{algorithm: algorithm, privateKey: privateKey}, nil
+}
+
+// Algorithm returns the algorithm of the signer's key
+func (s *Signer) Algorithm() CryptoAlgorithm {
+	return s.algorithm
+}
+
+// PublicKey returns the base64 public key of the signer, as published in DNS and HTTP key records
+func (s *Signer) PublicKey() (string, error) {
+	var publicKey []byte
+	switch s.algorithm {
+	case AlgorithmEd25519:
+		publicKey = ed25519.NewKeyFromSeed(s.privateKey).Public().(ed25519.PublicKey)
+	case AlgorithmECDSAP384:
+		privateKey, err := parseRawPrivateKey(elliptic.P384(), s.privateKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse ECDSA private key: %w", err)
+		}
+		publicKey = elliptic.MarshalCompressed(privateKey.Curve, privateKey.X, privateKey.Y)
+	default:
+		return "", fmt.Errorf("unsupported crypto algorithm: %s", s.algorithm)
+	}
+	return base64.StdEncoding.EncodeToString(publicKey), nil
+}
+
+// Sign signs message: with Ed25519, or as R || S over its SHA-384 digest with ECDSA P-384
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	return signMessage(s.algorithm, s.privateKey, message)
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+func ApproveCommand(args []string) error {
+	approveFlags := flag.NewFlagSet("approve", flag.ExitOnError)
+	deny := approveFlags.Bool("deny", false, "Deny the login instead of approving it")
+	profile := addProfileFlag(approveFlags)
+	addCACertFlag(approveFlags)
+	addDebugFlag(approveFlags)
+	addOutputFlag(approveFlags)
+	approveFlags.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: mcp-publisher approve [--deny] [--profile <name>] [--output <format>] <user-code>")
+		_, _ = fmt.Fprintln(os.Stderr)
+		_, _ = fmt.Fprintln(os.Stderr, "Approve a device waiting in 'mcp-publisher login device', giving it your current login")
+		approveFlags.PrintDefaults()
+	}
+	if err := parseFlags(approveFlags, args); err != nil {
+		return err
+	}
+	if approveFlags.NArg() != 1 {
+		approveFlags.Usage()
+		return withExitCode(ExitUsage, errors.New("user code required"))
+	}
+	userCode := approveFlags.Arg(0)
+
+	token, registryURL, err := loadSavedToken(*profile)
+	if err != nil {
+		return err
+	}
+
+	err = approveDevice(registryURL, token, userCode, *deny)
+	if errors.Is(err, errTokenRejected) {
+		// The saved token expired early or was revoked: renew the login once and retry
+		if token, err = renewSavedToken(*profile); err == nil {
+			err = approveDevice(registryURL, token, userCode, *deny)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if *deny {
+		_, _ = fmt.Fprintln(logOutput(), "✓ Device login denied")
+	} else {
+		_, _ = fmt.Fprintln(logOutput(), "✓ Device login approved")
+	}
+	return printResult(approveResult{UserCode: userCode, Approved: !*deny})
+}
+
+// approveResult is the JSON result of 'mcp-publisher approve'
+type approveResult struct {
+	UserCode string `json:"userCode"`
+	Approved bool   `json:"approved"`
+}
+
+func approveDevice(registryURL, token, userCode string, deny bool) error {
+	jsonData, err := json.Marshal(map[string]any{"user_code": userCode, "deny": deny})
+	if err != nil {
+		return fmt.Errorf("error serializing request: %w", err)
+	}
+
+	approveURL := strings.TrimSuffix(registryURL, "/") + "/v0/auth/device/approve"
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, approveURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errRegistryUnreachable, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("no device is waiting with code %s. It may have expired", userCode)
+	case http.StatusUnauthorized:
+		return errTokenRejected
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	return registryStatusError(resp.StatusCode, body)
+}
@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// semverPattern matches a semantic version, optionally prefixed with v, capturing the prefix, the
+// major, minor and patch numbers and the prerelease
+var semverPattern = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// BumpCommand raises the version in server.json, and with --packages the versions of its packages
+// that match it, then optionally publishes the result. Nothing is committed.
+func BumpCommand(args []string) error {
+	bumpFlags := flag.NewFlagSet("bump", flag.ExitOnError)
+	file := bumpFlags.String("file", "server.json", "Path to server.json or server.yaml")
+	packages := bumpFlags.Bool("packages", false, "Also bump package versions and OCI image tags that match the server version")
+	publish := bumpFlags.Bool("publish", false, "Publish server.json once bumped")
+	profile := addProfileFlag(bumpFlags)
+	addCACertFlag(bumpFlags)
+	addDebugFlag(bumpFlags)
+	addOutputFlag(bumpFlags)
+	bumpFlags.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: mcp-publisher bump <patch|minor|major|version> [--packages] [--publish] [--file <path>]")
+		bumpFlags.PrintDefaults()
+	}
+
+	// The bump comes first, but may also follow the flags
+	var bump string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		bump = args[0]
+		args = args[1:]
+	}
+	if err := parseFlags(bumpFlags, args); err != nil {
+		return err
+	}
+	if bump == "" && bumpFlags.NArg() == 1 {
+		bump = bumpFlags.Arg(0)
+	} else if bump == "" || bumpFlags.NArg() > 0 {
+		bumpFlags.Usage()
+		return withExitCode(ExitUsage, errors.New("patch, minor, major or a version required"))
+	}
+
+	*file = findServerFile(*file)
+	serverData, err := readServerFile(*file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found. Run 'mcp-publisher init' to create one", *file)
+		}
+		return fmt.Errorf("failed to read %s: %w", *file, err)
+	}
+	var server apiv0.ServerJSON
+	if err := json.Unmarshal(serverData, &server); err != nil {
+		return fmt.Errorf("invalid %s: %w", *file, err)
+	}
+
+	oldVersion := server.Version
+	newVersion, err := bumpVersion(oldVersion, bump)
+	if err != nil {
+		return err
+	}
+	server.Version = newVersion
+	_, _ = fmt.Fprintf(logOutput(), "✓ %s: %s → %s\n", server.Name, oldVersion, newVersion)
+
+	result := bumpResult{File: *file, Name: server.Name, OldVersion: oldVersion, NewVersion: newVersion, Packages: []string{}}
+	if *packages {
+		for i := range server.Packages {
+			change := bumpPackage(&server.Packages[i], oldVersion, newVersion)
+			_, _ = fmt.Fprintf(logOutput(), "  %s\n", change)
+			result.Packages = append(result.Packages, change)
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(server, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %w", err)
+	}
+	if err := writeServerFile(*file, jsonData); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+
+	if !*publish {
+		_, _ = fmt.Fprintf(logOutput(), "\nUpdated %s. Run 'mcp-publisher publish' to publish it.\n", *file)
+		return printResult(result)
+	}
+	// The result is then that of publishing, which has the new version
+	_, _ = fmt.Fprintln(logOutput())
+	return PublishCommand([]string{"--file", *file, "--profile", *profile, "--output", outputFlag{}.String(), "--debug=" + debugFlag{}.String()})
+}
+
+// bumpResult is the JSON result of 'mcp-publisher bump'
+type bumpResult struct {
+	File       string `json:"file"`
+	Name       string `json:"name"`
+	OldVersion string `json:"oldVersion"`
+	NewVersion string `json:"newVersion"`
+	// Packages describes what --packages did to each package
+	Packages []string `json:"packages"`
+}
+
+// bumpVersion returns the version after current for a patch, minor or major bump, following npm: a
+// prerelease is released rather than skipped, so a patch bump of 1.3.0-rc.1 gives 1.3.0. Any other
+// bump is taken as the new version itself.
+func bumpVersion(current, bump string) (string, error) {
+	switch bump {
+	case "patch", "minor", "major":
+	default:
+		version := strings.TrimSpace(bump)
+		if version == "" || version == "latest" || strings.ContainsAny(version, "^~<>=*| ") {
+			return "", fmt.Errorf("%q is not a specific version", bump)
+		}
+		if version == current {
+			return "", fmt.Errorf("%s is already the version", version)
+		}
+		return version, nil
+	}
+
+	match := semverPattern.FindStringSubmatch(current)
+	if match == nil {
+		return "", fmt.Errorf("the current version %q is not a semantic version, so give the new version instead of %s", current, bump)
+	}
+	prefix, prerelease := match[1], match[5]
+	var parts [3]int
+	for i := range parts {
+		// The pattern only matches digits, so these cannot fail but for overflow
+		n, err := strconv.Atoi(match[i+2])
+		if err != nil {
+			return "", fmt.Errorf("invalid version %q: %w", current, err)
+		}
+		parts[i] = n
+	}
+	major, minor, patch := parts[0], parts[1], parts[2]
+
+	switch bump {
+	case "major":
+		if prerelease == "" || minor != 0 || patch != 0 {
+			major, minor, patch = major+1, 0, 0
+		}
+	case "minor":
+		if prerelease == "" || patch != 0 {
+			minor, patch = minor+1, 0
+		}
+	case "patch":
+		if prerelease == "" {
+			patch++
+		}
+	}
+	return fmt.Sprintf("%s%d.%d.%d", prefix, major, minor, patch), nil
+}
+
+// bumpPackage moves a package from oldVersion to newVersion where it matches the server version, and
+// describes what it did
+func bumpPackage(pkg *model.Package, oldVersion, newVersion string) string {
+	if pkg.RegistryType == model.RegistryTypeOCI {
+		repository, tag, ok := splitImageTag(pkg.Identifier)
+		if !ok {
+			return fmt.Sprintf("%s left as is: the image has no tag", pkg.Identifier)
+		}
+		newTag, ok := matchVersion(tag, oldVersion, newVersion)
+		if !ok {
+			return fmt.Sprintf("%s left as is: its tag does not match %s", pkg.Identifier, oldVersion)
+		}
+		pkg.Identifier = repository + ":" + newTag
+		return fmt.Sprintf("%s:%s → %s", repository, tag, newTag)
+	}
+
+	newPackageVersion, ok := matchVersion(pkg.Version, oldVersion, newVersion)
+	if !ok {
+		return fmt.Sprintf("%s left at %s: it does not match %s", pkg.Identifier, pkg.Version, oldVersion)
+	}
+	description := fmt.Sprintf("%s: %s → %s", pkg.Identifier, pkg.Version, newPackageVersion)
+	pkg.Version = newPackageVersion
+
+	// Download URLs of MCPB packages usually name the release, and the file's hash changes with it
+	if pkg.RegistryType == model.RegistryTypeMCPB {
+		pkg.Identifier = strings.ReplaceAll(pkg.Identifier, oldVersion, newVersion)
+		if pkg.FileSHA256 != "" {
+			description += " (update fileSha256 for the new file)"
+		}
+	}
+	return description
+}
+
+// matchVersion returns newVersion for a package version or image tag that is oldVersion, with or
+// without a v prefix, keeping the package's own prefix
+func matchVersion(version, oldVersion, newVersion string) (string, bool) {
+	if version == "" {
+		return "", false
+	}
+	if version == oldVersion {
+		return newVersion, true
+	}
+	if strings.TrimPrefix(version, "v") == strings.TrimPrefix(oldVersion, "v") {
+		if strings.HasPrefix(version, "v") {
+			return "v" + strings.TrimPrefix(newVersion, "v"), true
+		}
+		return strings.TrimPrefix(newVersion, "v"), true
+	}
+	return "", false
+}
+
+// splitImageTag splits an OCI image reference into the repository and its tag. References pinned
+// by digest are not split, as a digest cannot be bumped.
+func splitImageTag(reference string) (string, string, bool) {
+	if strings.Contains(reference, "@") {
+		return "", "", false
+	}
+	i := strings.LastIndex(reference, ":")
+	if i == -1 || strings.Contains(reference[i:], "/") {
+		return "", "", false
+	}
+	return reference[:i], reference[i+1:], true
+}
@@ -0,0 +1,141 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// writeBumpServer writes a server.json at version with an npm package and an OCI image
+func writeBumpServer(t *testing.T, version, packageVersion, imageTag string) {
+	t.Helper()
+	serverData, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.octocat/weather",
+		Description: "Weather",
+		Version:     version,
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeNPM, Identifier: "@octocat/weather", Version: packageVersion, Transport: model.Transport{Type: "stdio"}},
+			{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/octocat/weather:" + imageTag, Transport: model.Transport{Type: "stdio"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal test JSON: %v", err)
+	}
+	if err := os.WriteFile("server.json", serverData, 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+}
+
+func readBumpServer(t *testing.T) apiv0.ServerJSON {
+	t.Helper()
+	data, err := os.ReadFile("server.json")
+	if err != nil {
+		t.Fatalf("Failed to read server.json: %v", err)
+	}
+	var server apiv0.ServerJSON
+	if err := json.Unmarshal(data, &server); err != nil {
+		t.Fatalf("Failed to parse server.json: %v", err)
+	}
+	return server
+}
+
+func TestBumpCommand(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		current, bump, expected string
+	}{
+		{"1.2.3", "patch", "1.2.4"},
+		{"1.2.3", "minor", "1.3.0"},
+		{"1.2.3", "major", "2.0.0"},
+		{"v0.9.9", "minor", "v0.10.0"},
+		{"1.3.0-rc.1", "patch", "1.3.0"},
+		{"1.3.0-rc.1", "minor", "1.3.0"},
+		{"1.3.1-rc.1", "minor", "1.4.0"},
+		{"2.0.0-beta", "major", "2.0.0"},
+		{"2025.10.1", "2025.11.0", "2025.11.0"},
+		{"nightly", "2.0.0-alpha.1", "2.0.0-alpha.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.current+" "+tt.bump, func(t *testing.T) {
+			writeBumpServer(t, tt.current, tt.current, tt.current)
+			if err := commands.BumpCommand([]string{tt.bump}); err != nil {
+				t.Fatalf("bump failed: %v", err)
+			}
+			server := readBumpServer(t)
+			if server.Version != tt.expected {
+				t.Errorf("Expected version %s, got %s", tt.expected, server.Version)
+			}
+			if server.Packages[0].Version != tt.current {
+				t.Errorf("Expected packages to be left alone without --packages, got %s", server.Packages[0].Version)
+			}
+		})
+	}
+
+	t.Run("packages matching the server version are bumped with it", func(t *testing.T) {
+		writeBumpServer(t, "1.2.3", "1.2.3", "v1.2.3")
+		if err := commands.BumpCommand([]string{"minor", "--packages"}); err != nil {
+			t.Fatalf("bump failed: %v", err)
+		}
+		server := readBumpServer(t)
+		if server.Packages[0].Version != "1.3.0" || server.Packages[1].Identifier != "ghcr.io/octocat/weather:v1.3.0" {
+			t.Errorf("Unexpected packages: %+v", server.Packages)
+		}
+
+		writeBumpServer(t, "1.2.3", "0.4.0", "latest")
+		if err := commands.BumpCommand([]string{"--packages", "patch"}); err != nil {
+			t.Fatalf("bump failed: %v", err)
+		}
+		server = readBumpServer(t)
+		if server.Packages[0].Version != "0.4.0" || server.Packages[1].Identifier != "ghcr.io/octocat/weather:latest" {
+			t.Errorf("Expected packages with other versions to be left alone, got: %+v", server.Packages)
+		}
+	})
+
+	t.Run("--publish publishes the bumped server", func(t *testing.T) {
+		var published string
+		registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var serverJSON apiv0.ServerJSON
+			_ = json.NewDecoder(r.Body).Decode(&serverJSON)
+			published = serverJSON.Version
+			_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{Server: serverJSON})
+		}))
+		defer registry.Close()
+		t.Setenv("HOME", t.TempDir())
+		t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+		t.Setenv(commands.RegistryURLEnvVar, registry.URL)
+
+		writeBumpServer(t, "1.2.3", "1.2.3", "1.2.3")
+		if err := commands.BumpCommand([]string{"patch", "--publish"}); err != nil {
+			t.Fatalf("bump failed: %v", err)
+		}
+		if published != "1.2.4" {
+			t.Errorf("Expected 1.2.4 to be published, got %q", published)
+		}
+	})
+
+	t.Run("invalid bumps are rejected", func(t *testing.T) {
+		for _, tt := range []struct{ current, bump, errorSubstr string }{
+			{"nightly", "patch", "not a semantic version"},
+			{"1.2.3", "^1.3.0", "not a specific version"},
+			{"1.2.3", "1.2.3", "already the version"},
+		} {
+			writeBumpServer(t, tt.current, tt.current, "latest")
+			err := commands.BumpCommand([]string{tt.bump})
+			if err == nil || !strings.Contains(err.Error(), tt.errorSubstr) {
+				t.Errorf("bump %s of %s: expected an error containing %q, got: %v", tt.bump, tt.current, tt.errorSubstr, err)
+			}
+			if server := readBumpServer(t); server.Version != tt.current {
+				t.Errorf("Expected server.json to be left at %s, got %s", tt.current, server.Version)
+			}
+		}
+	})
+}
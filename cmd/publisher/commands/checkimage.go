@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Where check-image read the labels of an image
+const (
+	imageSourceLocal  = "local"
+	imageSourceRemote = "remote"
+)
+
+// CheckImageCommand checks that OCI images carry the io.modelcontextprotocol.server.name label the
+// registry requires, with the name in server.json, before they are published. Images built locally are
+// read with docker, others from their registry the way the registry reads them.
+func CheckImageCommand(args []string) error {
+	checkFlags := flag.NewFlagSet("check-image", flag.ExitOnError)
+	file := checkFlags.String("file", "server.json", "Path to the server.json or server.yaml naming the server")
+	remote := checkFlags.Bool("remote", false, "Read images from their registry even if they were built locally")
+	values := templateValues{}
+	checkFlags.Var(values, "set", "Value for a ${NAME} placeholder in server.json, as NAME=VALUE (repeatable)")
+	addOutputFlag(checkFlags)
+	addCACertFlag(checkFlags)
+	addDebugFlag(checkFlags)
+	checkFlags.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: mcp-publisher check-image [--file <path>] [--remote] [image...]")
+		_, _ = fmt.Fprintln(os.Stderr)
+		_, _ = fmt.Fprintln(os.Stderr, "Check the server name label of the given images, or of the OCI packages in server.json.")
+		checkFlags.PrintDefaults()
+	}
+	if err := parseFlags(checkFlags, args); err != nil {
+		return err
+	}
+
+	*file = findServerFile(*file)
+	_, server, err := readServerToPublish(*file, values)
+	if err != nil {
+		return err
+	}
+
+	images := checkFlags.Args()
+	if len(images) == 0 {
+		for _, pkg := range server.Packages {
+			if pkg.RegistryType == model.RegistryTypeOCI {
+				images = append(images, pkg.Identifier)
+			}
+		}
+	}
+	if len(images) == 0 {
+		return withExitCode(ExitUsage, fmt.Errorf("%s has no OCI packages; name the images to check", *file))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), packageValidationTimeout)
+	defer cancel()
+
+	result := checkImageResult{File: *file, ServerName: server.Name, Images: []imageCheck{}}
+	failed := 0
+	for _, image := range images {
+		check := checkImage(ctx, image, server.Name, *remote)
+		if !check.Valid {
+			failed++
+		}
+		result.Images = append(result.Images, check)
+	}
+	result.Valid = failed == 0
+	if err := printResult(result); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return withExitCode(ExitValidation, fmt.Errorf("%d of %d image(s) cannot be published as %s", failed, len(images), server.Name))
+	}
+	return nil
+}
+
+// checkImageResult is the JSON result of 'mcp-publisher check-image'
+type checkImageResult struct {
+	File       string       `json:"file"`
+	ServerName string       `json:"serverName"`
+	Valid      bool         `json:"valid"`
+	Images     []imageCheck `json:"images"`
+}
+
+type imageCheck struct {
+	Image string `json:"image"`
+	// Source is "local" or "remote"
+	Source string `json:"source,omitempty"`
+	// Label is the value of the server name label, if the image has one
+	Label string `json:"label,omitempty"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+	// Fix is the Dockerfile instruction that gives the image the expected label
+	Fix string `json:"fix,omitempty"`
+}
+
+// checkImage reads the labels of an image and compares its server name label with serverName, printing
+// the outcome and, if the label is missing or wrong, the LABEL instruction to add
+func checkImage(ctx context.Context, image, serverName string, remoteOnly bool) imageCheck {
+	check := imageCheck{Image: image}
+	labels, source, err := imageLabels(ctx, image, remoteOnly)
+	if err != nil {
+		check.Error = err.Error()
+		_, _ = fmt.Fprintf(logOutput(), "✗ %s: %v\n", image, err)
+		return check
+	}
+	check.Source = source
+	check.Label = labels[registries.ServerNameLabel]
+
+	switch check.Label {
+	case serverName:
+		check.Valid = true
+		_, _ = fmt.Fprintf(logOutput(), "✓ %s (%s): %s=%s\n", image, source, registries.ServerNameLabel, serverName)
+		if source == imageSourceLocal {
+			_, _ = fmt.Fprintln(logOutput(), "    Push this build before publishing, as the registry reads the pushed image")
+		}
+		return check
+	case "":
+		check.Error = fmt.Sprintf("missing the %s label", registries.ServerNameLabel)
+	default:
+		check.Error = fmt.Sprintf("%s is %q, but server.json names %q", registries.ServerNameLabel, check.Label, serverName)
+	}
+	check.Fix = fmt.Sprintf("LABEL %s=%q", registries.ServerNameLabel, serverName)
+	_, _ = fmt.Fprintf(logOutput(), "✗ %s (%s): %s\n", image, source, check.Error)
+	_, _ = fmt.Fprintf(logOutput(), "    Add this line to your Dockerfile, then build and push the image again:\n\n    %s\n\n", check.Fix)
+	return check
+}
+
+// imageLabels returns the labels of an image and where they were read: from the local image of that
+// name, if docker has one, or else from its registry
+func imageLabels(ctx context.Context, image string, remoteOnly bool) (map[string]string, string, error) {
+	if !remoteOnly {
+		labels, err := localImageLabels(ctx, image)
+		if err == nil {
+			return labels, imageSourceLocal, nil
+		}
+		debugf("Reading %s from its registry, as docker could not inspect it: %v", image, err)
+	}
+
+	labels, err := registries.FetchOCIImageLabels(ctx, image)
+	if err != nil {
+		return nil, "", err
+	}
+	return labels, imageSourceRemote, nil
+}
+
+// localImageLabels returns the labels of a locally built or pulled image, using docker
+func localImageLabels(ctx context.Context, image string) (map[string]string, error) {
+	output, err := exec.CommandContext(ctx, "docker", "image", "inspect", "--format", "{{json .Config.Labels}}", image).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+
+	var labels map[string]string
+	if err := json.Unmarshal(output, &labels); err != nil {
+		return nil, fmt.Errorf("failed to parse the labels docker printed: %w", err)
+	}
+	return labels, nil
+}
@@ -0,0 +1,106 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestCheckImageCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake docker is a shell script")
+	}
+
+	// A docker that has every image locally, with the labels in FAKE_DOCKER_LABELS
+	binDir := t.TempDir()
+	fakeDocker := "#!/bin/sh\nprintf '%s\\n' \"$FAKE_DOCKER_LABELS\"\n"
+	if err := os.WriteFile(filepath.Join(binDir, "docker"), []byte(fakeDocker), 0o700); err != nil {
+		t.Fatalf("Failed to write fake docker: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	t.Chdir(t.TempDir())
+	serverJSON := `{
+		"$schema": "` + model.CurrentSchemaURL + `",
+		"name": "io.github.acme/weather",
+		"description": "Weather forecasts",
+		"version": "1.0.0",
+		"packages": [{"registryType": "oci", "identifier": "ghcr.io/acme/weather:1.0.0", "transport": {"type": "stdio"}}]
+	}`
+	if err := os.WriteFile("server.json", []byte(serverJSON), 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		labels    string
+		wantValid bool
+		wantError string
+	}{
+		{name: "matching label", labels: `{"io.modelcontextprotocol.server.name":"io.github.acme/weather"}`, wantValid: true},
+		{name: "missing label", labels: `{"org.opencontainers.image.source":"https://github.com/acme/weather"}`, wantError: "missing"},
+		{name: "no labels at all", labels: `null`, wantError: "missing"},
+		{name: "label of another server", labels: `{"io.modelcontextprotocol.server.name":"io.github.other/weather"}`, wantError: "io.github.other/weather"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("FAKE_DOCKER_LABELS", tt.labels)
+			stdout, err := captureStdout(t, func() error {
+				return commands.CheckImageCommand([]string{"--output", "json"})
+			})
+
+			var result struct {
+				Valid  bool `json:"valid"`
+				Images []struct {
+					Image  string `json:"image"`
+					Source string `json:"source"`
+					Valid  bool   `json:"valid"`
+					Error  string `json:"error"`
+					Fix    string `json:"fix"`
+				} `json:"images"`
+			}
+			if jsonErr := json.Unmarshal([]byte(stdout), &result); jsonErr != nil {
+				t.Fatalf("Expected a JSON result, got %q: %v", stdout, jsonErr)
+			}
+			if len(result.Images) != 1 || result.Images[0].Image != "ghcr.io/acme/weather:1.0.0" || result.Images[0].Source != "local" {
+				t.Fatalf("Expected the local OCI package of server.json to be checked, got %+v", result.Images)
+			}
+			image := result.Images[0]
+
+			if tt.wantValid {
+				if err != nil || !result.Valid || !image.Valid {
+					t.Fatalf("Expected the image to pass, got %+v: %v", result, err)
+				}
+				return
+			}
+			if commands.ExitCode(err) != commands.ExitValidation {
+				t.Errorf("Expected a validation failure, got %v", err)
+			}
+			if result.Valid || image.Valid || !strings.Contains(image.Error, tt.wantError) {
+				t.Errorf("Expected an error containing %q, got %+v", tt.wantError, image)
+			}
+			if image.Fix != `LABEL io.modelcontextprotocol.server.name="io.github.acme/weather"` {
+				t.Errorf("Expected the LABEL line to add, got %q", image.Fix)
+			}
+		})
+	}
+
+	t.Run("images named on the command line", func(t *testing.T) {
+		t.Setenv("FAKE_DOCKER_LABELS", `{"io.modelcontextprotocol.server.name":"io.github.acme/weather"}`)
+		stdout, err := captureStdout(t, func() error {
+			return commands.CheckImageCommand([]string{"weather:dev", "weather:next"})
+		})
+		if err != nil {
+			t.Fatalf("check-image failed: %v", err)
+		}
+		if !strings.Contains(stdout, "✓ weather:dev (local)") || !strings.Contains(stdout, "✓ weather:next (local)") {
+			t.Errorf("Expected both images to be checked, got %q", stdout)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+)
+
+// Command is an mcp-publisher command
+type Command struct {
+	Name    string
+	Summary string
+	Run     func(args []string) error
+}
+
+// All returns the commands of mcp-publisher, in the order its usage lists them
+func All() []Command {
+	return []Command{
+		{"init", "Create a server.json file template", InitCommand},
+		{"generate", "Create server.json from npm, PyPI or Docker metadata", GenerateCommand},
+		{"login", "Authenticate with the registry", LoginCommand},
+		{"logout", "Clear saved authentication", LogoutCommand},
+		{"approve", "Approve a device login with your saved authentication", ApproveCommand},
+		{"status", "Show the saved login, its expiry and permissions", StatusCommand},
+		{"list", "List the servers published under your namespaces", ListCommand},
+		{"deprecate", "Mark versions of a server as deprecated", DeprecateCommand},
+		{"delete", "Mark versions of a server as deleted", DeleteCommand},
+		{"publish", "Publish server.json to the registry", PublishCommand},
+		{"bump", "Raise the version in server.json", BumpCommand},
+		{"validate", "Check server.json for errors before publishing", ValidateCommand},
+		{"check-image", "Check the server name label of OCI images before publishing", CheckImageCommand},
+		{"convert", "Convert server.json to server.yaml or back", ConvertCommand},
+		{"migrate", "Upgrade server.json from an older schema to the current one", MigrateCommand},
+		{"self-update", "Update mcp-publisher to the latest release", SelfUpdateCommand},
+		{"completion", "Print a shell completion script", CompletionCommand},
+	}
+}
+
+// errFlagsListed stops a command whose flags were handed to flagLister
+var errFlagsListed = errors.New("flags listed")
+
+// flagLister, while set, is handed the flags of a command instead of the command parsing its
+// arguments and running, so the flags of every command can be listed without running any
+var flagLister func(flags *flag.FlagSet)
+
+// parseFlags parses a command's arguments, unless its flags are being listed
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if flagLister != nil {
+		flagLister(flags)
+		return errFlagsListed
+	}
+	return flags.Parse(args)
+}
+
+// commandFlags returns the flags of a command run with args, in alphabetical order, without running it
+func commandFlags(run func(args []string) error, args []string) []*flag.Flag {
+	var listed []*flag.Flag
+	flagLister = func(flags *flag.FlagSet) {
+		flags.VisitAll(func(f *flag.Flag) { listed = append(listed, f) })
+	}
+	defer func() { flagLister = nil }()
+
+	_ = run(args)
+	return listed
+}
@@ -0,0 +1,455 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// completionShells are the shells 'mcp-publisher completion' writes scripts for
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// flagChoices are the values completed for flags that take one of a few
+var flagChoices = map[string][]string{
+	"output":    {OutputText, OutputJSON},
+	"algorithm": {"ed25519", "ecdsap384"},
+	"from":      {"npm", "pypi", "docker"},
+}
+
+// CompletionCommand prints a script that completes the commands of mcp-publisher, their flags, and
+// the methods of 'mcp-publisher login', for bash, zsh, fish or PowerShell
+func CompletionCommand(args []string) error {
+	completionFlags := flag.NewFlagSet("completion", flag.ExitOnError)
+	completionFlags.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: mcp-publisher completion <bash|zsh|fish|powershell>")
+		_, _ = fmt.Fprintln(os.Stderr)
+		_, _ = fmt.Fprintln(os.Stderr, "Print a shell completion script. Load it with:")
+		_, _ = fmt.Fprintln(os.Stderr, "  bash:        source <(mcp-publisher completion bash)")
+		_, _ = fmt.Fprintln(os.Stderr, "  zsh:         source <(mcp-publisher completion zsh)")
+		_, _ = fmt.Fprintln(os.Stderr, "  fish:        mcp-publisher completion fish | source")
+		_, _ = fmt.Fprintln(os.Stderr, "  powershell:  mcp-publisher completion powershell | Out-String | Invoke-Expression")
+	}
+	if err := parseFlags(completionFlags, args); err != nil {
+		return err
+	}
+	if completionFlags.NArg() != 1 {
+		completionFlags.Usage()
+		return withExitCode(ExitUsage, errors.New("shell required: bash, zsh, fish or powershell"))
+	}
+
+	commands := completionCommands()
+	switch completionFlags.Arg(0) {
+	case "bash":
+		writeBashCompletion(os.Stdout, commands)
+	case "zsh":
+		writeZshCompletion(os.Stdout, commands)
+	case "fish":
+		writeFishCompletion(os.Stdout, commands)
+	case "powershell":
+		writePowerShellCompletion(os.Stdout, commands)
+	default:
+		completionFlags.Usage()
+		return withExitCode(ExitUsage, fmt.Errorf("unsupported shell %q: use bash, zsh, fish or powershell", completionFlags.Arg(0)))
+	}
+	return nil
+}
+
+// completedCommand is what is completed after the name of a command
+type completedCommand struct {
+	Name    string
+	Summary string
+	Flags   []completedFlag
+	// Choices are the values of the command's first argument, such as the methods of login
+	Choices []completedChoice
+	// ChoiceFlags are the flags after each choice, for commands whose flags depend on it
+	ChoiceFlags map[string][]completedFlag
+}
+
+type completedChoice struct {
+	Name    string
+	Summary string
+}
+
+type completedFlag struct {
+	Name       string
+	Usage      string
+	TakesValue bool
+	Values     []string
+}
+
+// Spelling returns the flag as completed: --name, or -n for single letters
+func (f completedFlag) Spelling() string {
+	if len(f.Name) == 1 {
+		return "-" + f.Name
+	}
+	return "--" + f.Name
+}
+
+// completionCommands lists what is completed for each command, reading the flags of each
+func completionCommands() []completedCommand {
+	var commands []completedCommand
+	for _, command := range All() {
+		completed := completedCommand{
+			Name:    command.Name,
+			Summary: command.Summary,
+			Flags:   completedFlags(commandFlags(command.Run, nil)),
+		}
+		switch command.Name {
+		case "login":
+			completed.ChoiceFlags = map[string][]completedFlag{}
+			for _, method := range loginMethods {
+				completed.Choices = append(completed.Choices, completedChoice{method.Name, method.Summary})
+				completed.ChoiceFlags[method.Name] = completedFlags(commandFlags(command.Run, []string{method.Name}))
+			}
+		case "bump":
+			completed.Choices = []completedChoice{
+				{"patch", "Raise the patch version"},
+				{"minor", "Raise the minor version"},
+				{"major", "Raise the major version"},
+			}
+		case "completion":
+			for _, shell := range completionShells {
+				completed.Choices = append(completed.Choices, completedChoice{shell, "Completion script for " + shell})
+			}
+		}
+		commands = append(commands, completed)
+	}
+	return commands
+}
+
+// completedFlags describes flags for completion
+func completedFlags(flags []*flag.Flag) []completedFlag {
+	completed := make([]completedFlag, 0, len(flags))
+	for _, f := range flags {
+		isBool := false
+		if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); ok {
+			isBool = boolFlag.IsBoolFlag()
+		}
+		completed = append(completed, completedFlag{
+			Name:       f.Name,
+			Usage:      strings.SplitN(f.Usage, "\n", 2)[0],
+			TakesValue: !isBool,
+			Values:     flagChoices[f.Name],
+		})
+	}
+	return completed
+}
+
+func writeBashCompletion(w io.Writer, commands []completedCommand) {
+	var names []string
+	valueFlags := map[string]bool{}
+	for _, command := range commands {
+		names = append(names, command.Name)
+		for _, flags := range append([][]completedFlag{command.Flags}, mapValues(command.ChoiceFlags)...) {
+			for _, f := range flags {
+				if f.TakesValue && len(f.Values) == 0 {
+					valueFlags[f.Spelling()] = true
+				}
+			}
+		}
+	}
+
+	p := func(format string, args ...any) { _, _ = fmt.Fprintf(w, format+"\n", args...) }
+	p("# bash completion for mcp-publisher")
+	p("# Load it with: source <(mcp-publisher completion bash)")
+	p("_mcp_publisher() {")
+	p(`    local cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]}`)
+	p("    local flags choices")
+	p("    COMPREPLY=()")
+	p("    if [[ $COMP_CWORD -eq 1 ]]; then")
+	p(`        COMPREPLY=($(compgen -W %s -- "$cur"))`, shellQuote(strings.Join(names, " ")))
+	p("        return")
+	p("    fi")
+	p("")
+	p("    # Values of flags: a few choices, or else files")
+	p(`    case "$prev" in`)
+	for _, name := range sortedKeys(flagChoices) {
+		p(`        --%s) COMPREPLY=($(compgen -W %s -- "$cur")); return ;;`, name, shellQuote(strings.Join(flagChoices[name], " ")))
+	}
+	p("        %s) return ;;", strings.Join(sortedKeys(valueFlags), "|"))
+	p("    esac")
+	p("")
+	p(`    case "${COMP_WORDS[1]}" in`)
+	for _, command := range commands {
+		p("        %s)", command.Name)
+		if len(command.ChoiceFlags) > 0 {
+			p(`            case "${COMP_WORDS[2]}" in`)
+			for _, choice := range command.Choices {
+				p("                %s) flags=%s ;;", choice.Name, shellQuote(bashFlags(command.ChoiceFlags[choice.Name])))
+			}
+			p("                *) flags=%s ;;", shellQuote(bashFlags(command.Flags)))
+			p("            esac")
+		} else {
+			p("            flags=%s", shellQuote(bashFlags(command.Flags)))
+		}
+		if len(command.Choices) > 0 {
+			var choices []string
+			for _, choice := range command.Choices {
+				choices = append(choices, choice.Name)
+			}
+			p("            [[ $COMP_CWORD -eq 2 ]] && choices=%s", shellQuote(strings.Join(choices, " ")))
+		}
+		p("            ;;")
+	}
+	p("    esac")
+	p("")
+	p("    # Anything else, such as the path to server.json, is completed as a file")
+	p(`    if [[ $cur == -* ]]; then`)
+	p(`        COMPREPLY=($(compgen -W "$flags" -- "$cur"))`)
+	p(`    elif [[ -n $choices ]]; then`)
+	p(`        COMPREPLY=($(compgen -W "$choices" -- "$cur"))`)
+	p("    fi")
+	p("}")
+	p("complete -o default -F _mcp_publisher mcp-publisher")
+}
+
+// bashFlags returns the spellings of flags, separated by spaces
+func bashFlags(flags []completedFlag) string {
+	spellings := make([]string, 0, len(flags))
+	for _, f := range flags {
+		spellings = append(spellings, f.Spelling())
+	}
+	return strings.Join(spellings, " ")
+}
+
+func writeZshCompletion(w io.Writer, commands []completedCommand) {
+	p := func(format string, args ...any) { _, _ = fmt.Fprintf(w, format+"\n", args...) }
+	p("#compdef mcp-publisher")
+	p("# zsh completion for mcp-publisher")
+	p("# Load it with: source <(mcp-publisher completion zsh)")
+	p("")
+	p("_mcp_publisher() {")
+	p("    local -a commands choices")
+	p("    commands=(")
+	for _, command := range commands {
+		p("        %s", shellQuote(command.Name+":"+command.Summary))
+	}
+	p("    )")
+	p("    if (( CURRENT == 2 )); then")
+	p("        _describe -t commands 'mcp-publisher command' commands")
+	p("        return")
+	p("    fi")
+	p("")
+	p("    local command=$words[2]")
+	p("    shift words")
+	p("    (( CURRENT-- ))")
+	p("    case $command in")
+	for _, command := range commands {
+		p("        %s)", command.Name)
+		if len(command.Choices) > 0 {
+			p("            if (( CURRENT == 2 )); then")
+			p("                choices=(")
+			for _, choice := range command.Choices {
+				p("                    %s", shellQuote(strings.ReplaceAll(choice.Name, ":", `\:`)+":"+choice.Summary))
+			}
+			p("                )")
+			p("                _describe -t choices %s choices", shellQuote(command.Name))
+			p("                return")
+			p("            fi")
+		}
+		if len(command.ChoiceFlags) > 0 {
+			p("            local choice=$words[2]")
+			p("            shift words")
+			p("            (( CURRENT-- ))")
+			p("            case $choice in")
+			for _, choice := range command.Choices {
+				p("                %s)", choice.Name)
+				writeZshArguments(p, "                    ", command.ChoiceFlags[choice.Name])
+				p("                    ;;")
+			}
+			p("            esac")
+		} else {
+			writeZshArguments(p, "            ", command.Flags)
+		}
+		p("            ;;")
+	}
+	p("    esac")
+	p("}")
+	p("")
+	p(`if [[ $zsh_eval_context[-1] == loadautofunc ]]; then`)
+	p(`    _mcp_publisher "$@"`)
+	p("else")
+	p("    compdef _mcp_publisher mcp-publisher")
+	p("fi")
+}
+
+// writeZshArguments writes the _arguments call completing flags, and files for any other argument
+func writeZshArguments(p func(string, ...any), indent string, flags []completedFlag) {
+	p("%s_arguments -S \\", indent)
+	for _, f := range flags {
+		description := strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`).Replace(f.Usage)
+		switch {
+		case len(f.Values) > 0:
+			p("%s    %s \\", indent, shellQuote(fmt.Sprintf("%s=[%s]:%s:(%s)", f.Spelling(), description, f.Name, strings.Join(f.Values, " "))))
+		case f.TakesValue:
+			p("%s    %s \\", indent, shellQuote(fmt.Sprintf("%s=[%s]:%s:_files", f.Spelling(), description, f.Name)))
+		default:
+			p("%s    %s \\", indent, shellQuote(fmt.Sprintf("%s[%s]", f.Spelling(), description)))
+		}
+	}
+	p("%s    '*:file:_files'", indent)
+}
+
+func writeFishCompletion(w io.Writer, commands []completedCommand) {
+	p := func(format string, args ...any) { _, _ = fmt.Fprintf(w, format+"\n", args...) }
+	p("# fish completion for mcp-publisher")
+	p("# Load it with: mcp-publisher completion fish | source")
+	for _, command := range commands {
+		p("complete -c mcp-publisher -n __fish_use_subcommand -f -a %s -d %s", fishQuote(command.Name), fishQuote(command.Summary))
+	}
+	for _, command := range commands {
+		seen := "__fish_seen_subcommand_from " + command.Name
+		for _, choice := range command.Choices {
+			p("complete -c mcp-publisher -n %s -f -a %s -d %s",
+				fishQuote(seen+"; and test (count (commandline -opc)) -eq 2"), fishQuote(choice.Name), fishQuote(choice.Summary))
+		}
+		if len(command.ChoiceFlags) > 0 {
+			for _, choice := range command.Choices {
+				writeFishFlags(p, seen+"; and __fish_seen_subcommand_from "+choice.Name, command.ChoiceFlags[choice.Name])
+			}
+		} else {
+			writeFishFlags(p, seen, command.Flags)
+		}
+	}
+}
+
+// writeFishFlags writes the completions of flags, when condition holds
+func writeFishFlags(p func(string, ...any), condition string, flags []completedFlag) {
+	for _, f := range flags {
+		option := "-l " + f.Name
+		if len(f.Name) == 1 {
+			option = "-s " + f.Name
+		}
+		switch {
+		case len(f.Values) > 0:
+			option += " -x -a " + fishQuote(strings.Join(f.Values, " "))
+		case f.TakesValue:
+			option += " -r"
+		}
+		p("complete -c mcp-publisher -n %s %s -d %s", fishQuote(condition), option, fishQuote(f.Usage))
+	}
+}
+
+func writePowerShellCompletion(w io.Writer, commands []completedCommand) {
+	p := func(format string, args ...any) { _, _ = fmt.Fprintf(w, format+"\n", args...) }
+	p("# PowerShell completion for mcp-publisher")
+	p("# Load it with: mcp-publisher completion powershell | Out-String | Invoke-Expression")
+	p("Register-ArgumentCompleter -Native -CommandName 'mcp-publisher' -ScriptBlock {")
+	p("    param($wordToComplete, $commandAst, $cursorPosition)")
+	p("")
+	p("    $commands = [ordered]@{")
+	for _, command := range commands {
+		p("        %s = %s", powerShellQuote(command.Name), powerShellQuote(command.Summary))
+	}
+	p("    }")
+	p("    $choices = @{")
+	for _, command := range commands {
+		if len(command.Choices) == 0 {
+			continue
+		}
+		p("        %s = [ordered]@{", powerShellQuote(command.Name))
+		for _, choice := range command.Choices {
+			p("            %s = %s", powerShellQuote(choice.Name), powerShellQuote(choice.Summary))
+		}
+		p("        }")
+	}
+	p("    }")
+	p("    # Flags by command, or by command and choice for commands whose flags depend on it")
+	p("    $flags = @{")
+	for _, command := range commands {
+		writePowerShellFlags(p, command.Name, command.Flags)
+		for _, choice := range command.Choices {
+			if flags, ok := command.ChoiceFlags[choice.Name]; ok {
+				writePowerShellFlags(p, command.Name+" "+choice.Name, flags)
+			}
+		}
+	}
+	p("    }")
+	p("    $values = @{")
+	for _, name := range sortedKeys(flagChoices) {
+		quoted := make([]string, 0, len(flagChoices[name]))
+		for _, value := range flagChoices[name] {
+			quoted = append(quoted, powerShellQuote(value))
+		}
+		p("        %s = @(%s)", powerShellQuote("--"+name), strings.Join(quoted, ", "))
+	}
+	p("    }")
+	p("")
+	p("    # The words before the one being completed, after the command name")
+	p("    $words = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })")
+	p("    if ($wordToComplete -ne '') {")
+	p("        $words = @($words | Select-Object -SkipLast 1)")
+	p("    }")
+	p("")
+	p("    $candidates = [ordered]@{}")
+	p("    if ($words.Count -eq 0) {")
+	p("        $candidates = $commands")
+	p("    } elseif ($values.Contains($words[-1])) {")
+	p("        foreach ($value in $values[$words[-1]]) { $candidates[$value] = $value }")
+	p("    } elseif ($words.Count -eq 1 -and -not $wordToComplete.StartsWith('-') -and $choices.Contains($words[0])) {")
+	p("        $candidates = $choices[$words[0]]")
+	p("    } else {")
+	p("        $key = $words[0]")
+	p(`        if ($words.Count -ge 2 -and $flags.Contains("$key $($words[1])")) { $key = "$key $($words[1])" }`)
+	p("        if ($flags.Contains($key)) { $candidates = $flags[$key] }")
+	p("    }")
+	p("")
+	p("    foreach ($name in $candidates.Keys) {")
+	p(`        if ($name -like "$wordToComplete*") {`)
+	p("            [System.Management.Automation.CompletionResult]::new($name, $name, 'ParameterValue', $candidates[$name])")
+	p("        }")
+	p("    }")
+	p("}")
+}
+
+// writePowerShellFlags writes an entry of the $flags table
+func writePowerShellFlags(p func(string, ...any), key string, flags []completedFlag) {
+	p("        %s = [ordered]@{", powerShellQuote(key))
+	for _, f := range flags {
+		// A completion's tooltip cannot be empty
+		usage := f.Usage
+		if usage == "" {
+			usage = f.Spelling()
+		}
+		p("            %s = %s", powerShellQuote(f.Spelling()), powerShellQuote(usage))
+	}
+	p("        }")
+}
+
+// shellQuote quotes a string for bash and zsh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote quotes a string for fish
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+// powerShellQuote quotes a string for PowerShell
+func powerShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mapValues returns the values of a map in the order of its keys
+func mapValues[V any](m map[string]V) []V {
+	values := make([]V, 0, len(m))
+	for _, key := range sortedKeys(m) {
+		values = append(values, m[key])
+	}
+	return values
+}
@@ -0,0 +1,48 @@
+package commands_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+)
+
+func TestCompletionCommand(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			script, err := captureStdout(t, func() error { return commands.CompletionCommand([]string{shell}) })
+			if err != nil {
+				t.Fatalf("completion %s failed: %v", shell, err)
+			}
+			// Commands, their flags, and the flags of login methods are all listed
+			for _, want := range []string{"publish", "completion", "signing-key", "github-oidc", "private-key-file", "cert"} {
+				if !strings.Contains(script, want) {
+					t.Errorf("Expected the %s script to complete %s", shell, want)
+				}
+			}
+
+			// The script is valid for the shell, where it is installed
+			path, err := exec.LookPath(shell)
+			if err != nil || shell == "powershell" {
+				return
+			}
+			file := filepath.Join(t.TempDir(), "completion")
+			if err := os.WriteFile(file, []byte(script), 0o600); err != nil {
+				t.Fatalf("Failed to write script: %v", err)
+			}
+			if output, err := exec.Command(path, "-n", file).CombinedOutput(); err != nil {
+				t.Errorf("Invalid %s script: %v\n%s", shell, err, output)
+			}
+		})
+	}
+
+	t.Run("unsupported shell", func(t *testing.T) {
+		err := commands.CompletionCommand([]string{"tcsh"})
+		if commands.ExitCode(err) != commands.ExitUsage {
+			t.Errorf("Expected a usage error, got %v", err)
+		}
+	})
+}
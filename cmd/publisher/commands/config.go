@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigEnvVar names the configuration file to read instead of ~/.config/mcp-publisher/config.yaml
+const ConfigEnvVar = "MCP_PUBLISHER_CONFIG"
+
+// publisherConfig holds the defaults of the configuration file. Flags and environment variables take
+// precedence over them.
+type publisherConfig struct {
+	// Registry is the registry to log in to, and to publish to with an API token
+	Registry string `yaml:"registry"`
+	// Profile is the saved login to use when neither --profile nor MCP_PUBLISHER_PROFILE is given
+	Profile string `yaml:"profile"`
+	// Output is the default of --output
+	Output string `yaml:"output"`
+	// Proxy is the proxy requests go through, unless HTTPS_PROXY or HTTP_PROXY is set
+	Proxy string `yaml:"proxy"`
+	// NoProxy lists the hosts to reach without the proxy, unless NO_PROXY is set
+	NoProxy string `yaml:"no_proxy"`
+	// CACert is the PEM bundle of CA certificates to trust, unless --ca-cert or MCP_PUBLISHER_CA_CERT is given
+	CACert string `yaml:"ca_cert"`
+}
+
+// config is the configuration file read by LoadConfig
+var config publisherConfig
+
+// configPath returns the path of the configuration file: MCP_PUBLISHER_CONFIG, or else config.yaml in
+// the mcp-publisher directory of $XDG_CONFIG_HOME or ~/.config
+func configPath() (string, error) {
+	if path := os.Getenv(ConfigEnvVar); path != "" {
+		return path, nil
+	}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		configHome = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configHome, "mcp-publisher", "config.yaml"), nil
+}
+
+// LoadConfig reads the configuration file, if there is one, so its defaults apply to the command about
+// to run. A file named by MCP_PUBLISHER_CONFIG must exist.
+func LoadConfig() error {
+	config = publisherConfig{}
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && os.Getenv(ConfigEnvVar) == "" {
+			return nil
+		}
+		return withExitCode(ExitUsage, fmt.Errorf("failed to read configuration file: %w", err))
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var loaded publisherConfig
+	if err := decoder.Decode(&loaded); err != nil && !errors.Is(err, io.EOF) {
+		return withExitCode(ExitUsage, fmt.Errorf("invalid configuration file %s: %w", path, err))
+	}
+	if err := loaded.validate(); err != nil {
+		return withExitCode(ExitUsage, fmt.Errorf("invalid configuration file %s: %w", path, err))
+	}
+	config = loaded
+	return nil
+}
+
+// validate checks the values that flags would check when given on the command line
+func (c publisherConfig) validate() error {
+	if c.Output != "" && c.Output != OutputText && c.Output != OutputJSON {
+		return fmt.Errorf("output: invalid output format %q (allowed: text, json)", c.Output)
+	}
+	if c.Profile != "" && !profileNamePattern.MatchString(c.Profile) {
+		return fmt.Errorf("profile: invalid profile name %q", c.Profile)
+	}
+	return nil
+}
+
+// defaultRegistryURL returns the registry of the configuration file, or else DefaultRegistryURL
+func defaultRegistryURL() string {
+	if config.Registry != "" {
+		return config.Registry
+	}
+	return DefaultRegistryURL
+}
+
+// applyProxyConfig sets the proxy of the configuration file for the requests of this process, unless
+// the environment already chooses one. The default transport reads the proxy variables when it sends
+// its first request, so this must run before any is sent.
+func applyProxyConfig() error {
+	if config.Proxy != "" && !anyEnvSet("HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy") {
+		if err := os.Setenv("HTTPS_PROXY", config.Proxy); err != nil {
+			return err
+		}
+		if err := os.Setenv("HTTP_PROXY", config.Proxy); err != nil {
+			return err
+		}
+	}
+	if config.NoProxy != "" && !anyEnvSet("NO_PROXY", "no_proxy") {
+		if err := os.Setenv("NO_PROXY", config.NoProxy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// anyEnvSet reports whether any of the environment variables is set
+func anyEnvSet(names ...string) bool {
+	for _, name := range names {
+		if _, ok := os.LookupEnv(name); ok {
+			return true
+		}
+	}
+	return false
+}
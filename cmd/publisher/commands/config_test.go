@@ -0,0 +1,114 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+)
+
+// loadConfig makes commands read a configuration file with content, until the test ends
+func loadConfig(t *testing.T, content string) error {
+	t.Helper()
+	// Runs after the environment is restored, so later tests see no configuration file
+	t.Cleanup(func() { _ = commands.LoadConfig() })
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	t.Setenv(commands.ConfigEnvVar, path)
+	return commands.LoadConfig()
+}
+
+func TestConfigFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/auth/none" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"registry_token": "registry-token"})
+	}))
+	defer server.Close()
+
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv(commands.ProfileEnvVar, "")
+
+	if err := loadConfig(t, "registry: "+server.URL+"\nprofile: staging\noutput: json\n"); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Login goes to the configured registry, saves the configured profile and prints JSON
+	stdout, loginErr := captureStdout(t, func() error {
+		return commands.LoginCommand([]string{"none", "--insecure-token-file"})
+	})
+	if loginErr != nil {
+		t.Fatalf("Failed to log in: %v", loginErr)
+	}
+	var result struct {
+		Profile  string `json:"profile"`
+		Registry string `json:"registry"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("Expected a JSON result, got %q: %v", stdout, err)
+	}
+	if result.Profile != "staging" || result.Registry != server.URL {
+		t.Errorf("Expected the configured profile and registry, got %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(homeDir, commands.TokenFileName+".staging")); err != nil {
+		t.Errorf("Expected the login saved for the staging profile: %v", err)
+	}
+
+	// Flags and environment variables take precedence
+	t.Setenv(commands.ProfileEnvVar, "other")
+	stdout, loginErr = captureStdout(t, func() error {
+		return commands.LoginCommand([]string{"none", "--insecure-token-file", "--output", "text", "--registry", server.URL + "/"})
+	})
+	if loginErr != nil {
+		t.Fatalf("Failed to log in: %v", loginErr)
+	}
+	if !strings.Contains(stdout, "as profile other") {
+		t.Errorf("Expected text output for the profile of the environment, got %q", stdout)
+	}
+}
+
+func TestConfigFile_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantError string
+	}{
+		{name: "unknown setting", content: "registry_url: https://example.com\n", wantError: "registry_url"},
+		{name: "invalid output", content: "output: yaml\n", wantError: "invalid output format"},
+		{name: "invalid profile", content: "profile: ../prod\n", wantError: "invalid profile name"},
+		{name: "empty file", content: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loadConfig(t, tt.content)
+			if tt.wantError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Fatalf("Expected an error containing %q, got: %v", tt.wantError, err)
+			}
+			if commands.ExitCode(err) != commands.ExitUsage {
+				t.Errorf("Expected exit code %d, got %d", commands.ExitUsage, commands.ExitCode(err))
+			}
+		})
+	}
+
+	t.Setenv(commands.ConfigEnvVar, filepath.Join(t.TempDir(), "missing.yaml"))
+	if err := commands.LoadConfig(); err == nil {
+		t.Errorf("Expected an error for a configuration file that does not exist")
+	}
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConvertCommand converts a server manifest between JSON and YAML, the direction given by the
+// extension of the input
+func ConvertCommand(args []string) error {
+	convertFlags := flag.NewFlagSet("convert", flag.ExitOnError)
+	force := convertFlags.Bool("force", false, "Overwrite the output file if it exists")
+	addOutputFlag(convertFlags)
+	convertFlags.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: mcp-publisher convert [--force] [--output <format>] <input> [output]")
+		_, _ = fmt.Fprintln(os.Stderr)
+		_, _ = fmt.Fprintln(os.Stderr, "Convert server.json to server.yaml or back. The output defaults to the input with the other extension; '-' writes to stdout.")
+		convertFlags.PrintDefaults()
+	}
+	if err := parseFlags(convertFlags, args); err != nil {
+		return err
+	}
+	if convertFlags.NArg() < 1 || convertFlags.NArg() > 2 {
+		convertFlags.Usage()
+		return withExitCode(ExitUsage, errors.New("input file required"))
+	}
+	input := convertFlags.Arg(0)
+
+	toYAML := !isYAMLFile(input)
+	output := convertFlags.Arg(1)
+	if output == "" {
+		output = strings.TrimSuffix(input, filepath.Ext(input)) + ".json"
+		if toYAML {
+			output = strings.TrimSuffix(input, filepath.Ext(input)) + ".yaml"
+		}
+	}
+	if output == "-" && jsonOutput {
+		return errors.New("the converted file cannot be written to stdout with --output json")
+	}
+
+	data, err := os.ReadFile(input)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", input, err)
+	}
+	var converted []byte
+	if toYAML {
+		converted, err = jsonToYAML(data)
+	} else {
+		converted, err = yamlToJSON(data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to convert %s: %w", input, err)
+	}
+
+	if output == "-" {
+		_, err := os.Stdout.Write(converted)
+		return err
+	}
+	if _, err := os.Stat(output); err == nil && !*force {
+		return fmt.Errorf("%s already exists. Pass --force to overwrite it", output)
+	}
+	if err := os.WriteFile(output, converted, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	_, _ = fmt.Fprintf(logOutput(), "✓ Converted %s to %s\n", input, output)
+	return printResult(convertResult{Input: input, Output: output})
+}
+
+// convertResult is the JSON result of 'mcp-publisher convert'
+type convertResult struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+}
@@ -0,0 +1,108 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+)
+
+const convertServerJSON = `{
+  "$schema": "https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json",
+  "name": "io.github.acme/weather",
+  "description": "Weather forecasts",
+  "version": "1.0",
+  "packages": [
+    {
+      "registryType": "npm",
+      "identifier": "@acme/weather",
+      "version": "1.0",
+      "transport": {
+        "type": "stdio"
+      }
+    }
+  ]
+}
+`
+
+func TestConvertCommand(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("server.json", []byte(convertServerJSON), 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	if err := commands.ConvertCommand([]string{"server.json"}); err != nil {
+		t.Fatalf("Failed to convert to YAML: %v", err)
+	}
+	yamlData, err := os.ReadFile("server.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read server.yaml: %v", err)
+	}
+	yamlText := string(yamlData)
+	// Keys keep their order, and versions that would read as numbers stay strings
+	if !strings.HasPrefix(yamlText, "$schema: ") || !strings.Contains(yamlText, `version: "1.0"`) || strings.Contains(yamlText, "{") {
+		t.Errorf("Unexpected YAML:\n%s", yamlText)
+	}
+
+	if err := commands.ConvertCommand([]string{"server.yaml", "server.json"}); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("Expected converting over an existing file to fail, got: %v", err)
+	}
+	if err := commands.ConvertCommand([]string{"--force", "server.yaml", "server.json"}); err != nil {
+		t.Fatalf("Failed to convert back to JSON: %v", err)
+	}
+	jsonData, err := os.ReadFile("server.json")
+	if err != nil {
+		t.Fatalf("Failed to read server.json: %v", err)
+	}
+	if string(jsonData) != convertServerJSON {
+		t.Errorf("Expected the round trip to give back the original JSON, got:\n%s", jsonData)
+	}
+}
+
+func TestValidateCommand_YAML(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	// Without server.json, server.yaml is used
+	serverYAML := `$schema: https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json
+name: io.github.acme/weather
+description: Weather forecasts
+version: 1.0.0
+packages:
+  - registryType: npm
+    identifier: "@acme/weather"
+    version: 1.0.0
+    transport:
+      type: stdio
+`
+	if err := os.WriteFile("server.yaml", []byte(serverYAML), 0o600); err != nil {
+		t.Fatalf("Failed to write server.yaml: %v", err)
+	}
+	if err := commands.ValidateCommand([]string{"--skip-packages"}); err != nil {
+		t.Errorf("Expected server.yaml to be valid, got: %v", err)
+	}
+
+	// An unquoted 1.0 is a number in YAML, which the schema rejects
+	unquoted := strings.Replace(serverYAML, "version: 1.0.0\npackages", "version: 1.0\npackages", 1)
+	if err := os.WriteFile("server.yml", []byte(unquoted), 0o600); err != nil {
+		t.Fatalf("Failed to write server.yml: %v", err)
+	}
+	err := commands.ValidateCommand([]string{"--file", "server.yml", "--skip-packages"})
+	if err == nil || !strings.Contains(err.Error(), "problem(s)") {
+		t.Errorf("Expected the numeric version to be reported, got: %v", err)
+	}
+
+	// bump keeps the file YAML
+	if err := commands.BumpCommand([]string{"minor", "--file", "server.yaml"}); err != nil {
+		t.Fatalf("bump failed: %v", err)
+	}
+	bumped, err := os.ReadFile("server.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read server.yaml: %v", err)
+	}
+	var parsed map[string]any
+	if json.Unmarshal(bumped, &parsed) == nil || !strings.Contains(string(bumped), "version: 1.1.0") {
+		t.Errorf("Expected server.yaml to stay YAML with the bumped version, got:\n%s", bumped)
+	}
+}
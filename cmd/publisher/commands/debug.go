@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DebugEnvVar turns on --debug for every command when set to true or 1
+const DebugEnvVar = "MCP_PUBLISHER_DEBUG"
+
+// maxDebugBody is how much of a JSON request or response body --debug prints
+const maxDebugBody = 4096
+
+// debugEnabled is set by --debug. Requests are then traced to stderr, with credentials redacted, along
+// with the steps of validating server.json.
+var debugEnabled bool
+
+// sensitiveHeaders are the headers whose values --debug never prints
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// sensitiveFieldPattern matches the JSON fields whose values --debug never prints, such as the tokens
+// of login exchanges and the signed timestamps of DNS and HTTP logins
+var sensitiveFieldPattern = regexp.MustCompile(`(?i)token|secret|password|private|signed|code|credential|cookie`)
+
+// debugFlag is the --debug flag, which sets debugEnabled
+type debugFlag struct{}
+
+func (debugFlag) String() string { return strconv.FormatBool(debugEnabled) }
+
+func (debugFlag) Set(v string) error {
+	on, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("invalid boolean value %q", v)
+	}
+	setDebug(on)
+	return nil
+}
+
+func (debugFlag) IsBoolFlag() bool { return true }
+
+// addDebugFlag adds the --debug flag and its -v shorthand to a command's flags, starting from MCP_PUBLISHER_DEBUG
+func addDebugFlag(flags *flag.FlagSet) {
+	on, _ := strconv.ParseBool(os.Getenv(DebugEnvVar))
+	setDebug(on)
+	flags.Var(debugFlag{}, "debug", "Trace requests, with credentials redacted, and validation steps to stderr, for bug reports (default: $"+DebugEnvVar+")")
+	flags.Var(debugFlag{}, "v", "Shorthand for --debug")
+}
+
+// setDebug turns debugging on or off, tracing requests through the default transport while it is on
+func setDebug(on bool) {
+	debugEnabled = on
+	switch transport := http.DefaultTransport.(type) {
+	case *http.Transport:
+		if on {
+			http.DefaultTransport = &debugTransport{base: transport}
+		}
+	case *debugTransport:
+		if !on {
+			http.DefaultTransport = transport.base
+		}
+	}
+}
+
+// debugf prints a line of the --debug trace
+func debugf(format string, args ...any) {
+	if debugEnabled {
+		_, _ = fmt.Fprintf(os.Stderr, "[debug] "+format+"\n", args...)
+	}
+}
+
+// debugTransport traces each request and its response: the method and URL, the headers, JSON bodies
+// and how long the response took
+type debugTransport struct {
+	base *http.Transport
+}
+
+// Base returns the transport requests are sent with, for clients that customize a copy of it
+func (t *debugTransport) Base() *http.Transport {
+	return t.base
+}
+
+// Wrap traces requests sent with another transport
+func (t *debugTransport) Wrap(transport http.RoundTripper) http.RoundTripper {
+	return &debugRoundTripper{base: transport}
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return traceRoundTrip(t.base, req)
+}
+
+// debugRoundTripper traces requests sent with a transport other than the default
+type debugRoundTripper struct {
+	base http.RoundTripper
+}
+
+func (t *debugRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return traceRoundTrip(t.base, req)
+}
+
+// traceRoundTrip sends a request with base, tracing it and its response
+func traceRoundTrip(base http.RoundTripper, req *http.Request) (*http.Response, error) {
+	if !debugEnabled {
+		return base.RoundTrip(req)
+	}
+
+	debugf("→ %s %s", req.Method, req.URL.Redacted())
+	debugHeaders(req.Header)
+	if req.GetBody != nil && isJSON(req.Header.Get("Content-Type")) {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxDebugBody+1))
+			_ = body.Close()
+			debugf("  body: %s", debugBody(data))
+		}
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		debugf("✗ %s %s failed after %s: %v", req.Method, req.URL.Redacted(), elapsed, err)
+		return nil, err
+	}
+
+	debugf("← %s (%s)", resp.Status, elapsed)
+	debugHeaders(resp.Header)
+	if isJSON(resp.Header.Get("Content-Type")) {
+		// Only the start of the body is read here; the caller reads the rest as usual
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxDebugBody+1))
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+		debugf("  body: %s", debugBody(data))
+	}
+	return resp, nil
+}
+
+// debugHeaders prints headers in order, redacting credentials
+func debugHeaders(header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+				value = redactHeader(value)
+			}
+			debugf("  %s: %s", name, value)
+		}
+	}
+}
+
+// redactHeader hides a credential, keeping the scheme of an Authorization header such as Bearer
+func redactHeader(value string) string {
+	if scheme, _, ok := strings.Cut(value, " "); ok && !strings.ContainsAny(scheme, "=;") {
+		return scheme + " [redacted]"
+	}
+	return "[redacted]"
+}
+
+// debugBody returns a JSON body to print, with credentials redacted, or its size when it is too long
+// or cannot be redacted
+func debugBody(data []byte) string {
+	if len(data) > maxDebugBody {
+		return fmt.Sprintf("(more than %d bytes)", maxDebugBody)
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Sprintf("(%d bytes)", len(data))
+	}
+	redacted, err := json.Marshal(redactJSON(value))
+	if err != nil {
+		return fmt.Sprintf("(%d bytes)", len(data))
+	}
+	return string(redacted)
+}
+
+// redactJSON replaces the values of sensitive fields, at any depth
+func redactJSON(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if sensitiveFieldPattern.MatchString(key) {
+				v[key] = "[redacted]"
+			} else {
+				v[key] = redactJSON(field)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+	}
+	return value
+}
+
+// isJSON reports whether a Content-Type is JSON, such as application/json or application/problem+json
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
@@ -0,0 +1,112 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// captureStderr returns what fn prints to stderr
+func captureStderr(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	originalStderr := os.Stderr
+	os.Stderr = writer
+	defer func() { os.Stderr = originalStderr }()
+
+	fnErr := fn()
+	_ = writer.Close()
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	return string(output), fnErr
+}
+
+func TestDebugFlag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var published apiv0.ServerJSON
+		_ = json.NewDecoder(r.Body).Decode(&published)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=hunter2")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"server": published, "refresh_token": "hunter2"})
+	}))
+	defer server.Close()
+
+	originalTransport := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = originalTransport })
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_secret")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+	t.Setenv(commands.DebugEnvVar, "")
+	t.Chdir(t.TempDir())
+	serverData, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal test JSON: %v", err)
+	}
+	if err := os.WriteFile("server.json", serverData, 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	t.Run("traces requests without credentials", func(t *testing.T) {
+		output, err := captureStderr(t, func() error { return commands.PublishCommand([]string{"--debug"}) })
+		if err != nil {
+			t.Fatalf("Expected the publish to succeed, got: %v", err)
+		}
+		for _, want := range []string{
+			"[debug] → POST " + server.URL + "/v0/publish",
+			"Authorization: Bearer [redacted]",
+			`"name":"com.example/test-server"`,
+			"[debug] ← 201 Created",
+			"Set-Cookie: [redacted]",
+			`"refresh_token":"[redacted]"`,
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected the trace to contain %q, got:\n%s", want, output)
+			}
+		}
+		for _, secret := range []string{"mcpr_secret", "hunter2"} {
+			if strings.Contains(output, secret) {
+				t.Errorf("Expected %q to be redacted, got:\n%s", secret, output)
+			}
+		}
+	})
+
+	t.Run("off by default", func(t *testing.T) {
+		output, err := captureStderr(t, func() error { return commands.PublishCommand(nil) })
+		if err != nil {
+			t.Fatalf("Expected the publish to succeed, got: %v", err)
+		}
+		if strings.Contains(output, "[debug]") {
+			t.Errorf("Expected no trace, got:\n%s", output)
+		}
+	})
+
+	t.Run("turned on by the environment", func(t *testing.T) {
+		t.Setenv(commands.DebugEnvVar, "1")
+		output, err := captureStderr(t, func() error { return commands.PublishCommand(nil) })
+		if err != nil {
+			t.Fatalf("Expected the publish to succeed, got: %v", err)
+		}
+		if !strings.Contains(output, "[debug] Using the API token in "+commands.APIKeyEnvVar) {
+			t.Errorf("Expected a trace, got:\n%s", output)
+		}
+	})
+}
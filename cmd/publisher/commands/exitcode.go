@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Exit codes of mcp-publisher, so pipelines can tell failures apart
+const (
+	// ExitFailure is for failures without a more specific exit code
+	ExitFailure = 1
+	// ExitUsage is for invalid arguments, as the flag package exits with, and for prompts in non-interactive mode
+	ExitUsage = 2
+	// ExitAuth is for missing, expired or rejected logins, and for lacking permission
+	ExitAuth = 3
+	// ExitValidation is for a server.json that is invalid, or that the registry rejected
+	ExitValidation = 4
+	// ExitConflict is for a version that is already published, or a conflicting request in progress
+	ExitConflict = 5
+	// ExitServer is for a registry that could not be reached or failed
+	ExitServer = 6
+)
+
+// NonInteractiveEnvVar turns on --non-interactive for every command, when set to true or 1
+const NonInteractiveEnvVar = "MCP_PUBLISHER_NON_INTERACTIVE"
+
+// duplicateVersionMessage is how the registry rejects a version that is already published
+const duplicateVersionMessage = "cannot publish duplicate version"
+
+// Errors that say the login is missing or can no longer be used
+var (
+	errNotAuthenticated = errors.New("not authenticated")
+	errLoginExpired     = errors.New("your login has expired")
+)
+
+// nonInteractive is set by --non-interactive: commands then fail rather than wait for someone to answer
+var nonInteractive bool
+
+// addNonInteractiveFlag adds the --non-interactive flag to the flags of a command that can prompt
+func addNonInteractiveFlag(flags *flag.FlagSet) {
+	defaultValue, _ := strconv.ParseBool(os.Getenv(NonInteractiveEnvVar))
+	flags.BoolVar(&nonInteractive, "non-interactive", defaultValue, "Never prompt, failing instead if an answer is needed (default: $"+NonInteractiveEnvVar+")")
+}
+
+// exitError is an error with the exit code mcp-publisher exits with for it
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode gives err the exit code mcp-publisher exits with for it
+func withExitCode(code int, err error) error {
+	return &exitError{code: code, err: err}
+}
+
+// registryStatusError describes an unexpected response of the registry, with the exit code for its status
+func registryStatusError(statusCode int, body []byte) error {
+	err := fmt.Errorf("server returned status %d: %s", statusCode, body)
+	switch {
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return withExitCode(ExitAuth, err)
+	case statusCode == http.StatusConflict:
+		return withExitCode(ExitConflict, err)
+	// The registry rejects duplicate versions as bad requests
+	case statusCode == http.StatusBadRequest && strings.Contains(string(body), duplicateVersionMessage):
+		return withExitCode(ExitConflict, err)
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusUnprocessableEntity:
+		return withExitCode(ExitValidation, err)
+	case statusCode >= http.StatusInternalServerError:
+		return withExitCode(ExitServer, err)
+	}
+	return err
+}
+
+// ExitCode returns the exit code mcp-publisher exits with when a command fails with err
+func ExitCode(err error) int {
+	var exitErr *exitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr):
+		return exitErr.code
+	case errors.Is(err, errNotAuthenticated), errors.Is(err, errLoginExpired),
+		errors.Is(err, errTokenRejected), errors.Is(err, errPermissionDenied):
+		return ExitAuth
+	case errors.Is(err, errRegistryUnreachable):
+		return ExitServer
+	}
+	return ExitFailure
+}
@@ -0,0 +1,120 @@
+package commands_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestExitCode(t *testing.T) {
+	var status int
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+	t.Setenv(commands.NonInteractiveEnvVar, "")
+	t.Chdir(t.TempDir())
+	serverData := `{"$schema": "` + model.CurrentSchemaURL + `", "name": "io.github.example/test-server", "description": "A test server", "version": "1.0.0"}`
+	if err := os.WriteFile("server.json", []byte(serverData), 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected int
+	}{
+		{"permission denied", http.StatusForbidden, `{"detail": "You do not have permission to publish this server"}`, commands.ExitAuth},
+		{"invalid server", http.StatusUnprocessableEntity, `{"detail": "validation failed"}`, commands.ExitValidation},
+		{"duplicate version", http.StatusBadRequest, `{"detail": "Failed to publish server", "errors": [{"message": "invalid version: cannot publish duplicate version"}]}`, commands.ExitConflict},
+		{"request in progress", http.StatusConflict, `{"detail": "A request with this Idempotency-Key is still in progress"}`, commands.ExitConflict},
+		{"server error", http.StatusBadGateway, "", commands.ExitServer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body = tt.status, tt.body
+			// Failures are not retried, so the test does not wait out the backoff
+			if code := commands.ExitCode(commands.PublishCommand([]string{"--max-retries", "0"})); code != tt.expected {
+				t.Errorf("Expected exit code %d, got %d", tt.expected, code)
+			}
+		})
+	}
+
+	t.Run("registry unreachable", func(t *testing.T) {
+		t.Setenv(commands.RegistryURLEnvVar, "http://127.0.0.1:1")
+		if code := commands.ExitCode(commands.PublishCommand([]string{"--max-retries", "0"})); code != commands.ExitServer {
+			t.Errorf("Expected exit code %d, got %d", commands.ExitServer, code)
+		}
+	})
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Setenv(commands.APIKeyEnvVar, "")
+		if code := commands.ExitCode(commands.PublishCommand(nil)); code != commands.ExitAuth {
+			t.Errorf("Expected exit code %d, got %d", commands.ExitAuth, code)
+		}
+	})
+
+	t.Run("invalid server.json", func(t *testing.T) {
+		if err := os.WriteFile("invalid.json", []byte(`{"name": "test-server"}`), 0o600); err != nil {
+			t.Fatalf("Failed to write invalid.json: %v", err)
+		}
+		if code := commands.ExitCode(commands.ValidateCommand([]string{"--file", "invalid.json"})); code != commands.ExitValidation {
+			t.Errorf("Expected exit code %d, got %d", commands.ExitValidation, code)
+		}
+	})
+}
+
+func TestNonInteractive(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "")
+	t.Setenv(commands.NonInteractiveEnvVar, "")
+
+	t.Run("logins that need a browser are refused", func(t *testing.T) {
+		err := commands.LoginCommand([]string{"github", "--non-interactive"})
+		if commands.ExitCode(err) != commands.ExitUsage {
+			t.Errorf("Expected a usage error, got %v", err)
+		}
+	})
+
+	t.Run("init takes the detected values", func(t *testing.T) {
+		t.Setenv(commands.NonInteractiveEnvVar, "true")
+		// With nothing to answer, init would otherwise fail for want of a description
+		server, err := runInit(t, nil, "")
+		if err != nil {
+			t.Fatalf("init failed: %v", err)
+		}
+		if server.Version != "1.0.0" {
+			t.Errorf("Expected the default version, got %s", server.Version)
+		}
+	})
+
+	t.Run("deletions need --yes", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Errorf("Expected nothing to be changed, got %s %s", r.Method, r.URL)
+			}
+			_, _ = w.Write([]byte(`{"servers": [{"server": {"name": "io.github.example/test-server", "version": "1.0.0"}, "_meta": {}}], "metadata": {"count": 1}}`))
+		}))
+		defer server.Close()
+		t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+		t.Setenv(commands.RegistryURLEnvVar, server.URL)
+
+		err := withStdin(t, "y\n", func() error {
+			return commands.DeleteCommand([]string{"io.github.example/test-server", "--non-interactive"})
+		})
+		if commands.ExitCode(err) != commands.ExitUsage {
+			t.Errorf("Expected a usage error, got %v", err)
+		}
+	})
+}
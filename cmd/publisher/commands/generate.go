@@ -0,0 +1,357 @@
+package commands
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// mcpNamePattern matches the mcp-name line that proves ownership of PyPI and NuGet packages
+var mcpNamePattern = regexp.MustCompile(`mcp-name:\s*([a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+)`)
+
+// Labels of OCI images that generate reads
+const (
+	serverNameLabel  = "io.modelcontextprotocol.server.name"
+	descriptionLabel = "org.opencontainers.image.description"
+	sourceLabel      = "org.opencontainers.image.source"
+	versionLabel     = "org.opencontainers.image.version"
+)
+
+// generatedServer is what generate learns about a server from its package's metadata
+type generatedServer struct {
+	PackageType string
+	Name        string
+	Description string
+	Version     string
+	RepoURL     string
+	Identifier  string
+	// Notes are what should be checked by hand before publishing
+	Notes []string
+}
+
+// GenerateCommand writes a best-effort server.json from the metadata of an existing npm package,
+// Python project or Docker image, noting what could not be worked out
+func GenerateCommand(args []string) error {
+	generateFlags := flag.NewFlagSet("generate", flag.ExitOnError)
+	from := generateFlags.String("from", "", "Package type to read: npm (package.json), pypi (pyproject.toml) or docker (Dockerfile)")
+	file := generateFlags.String("file", "server.json", "Path to write, as server.json or server.yaml")
+	image := generateFlags.String("image", "", "Image reference, such as ghcr.io/owner/repo:1.0.0 (docker only)")
+	force := generateFlags.Bool("force", false, "Overwrite the file if it exists")
+	addOutputFlag(generateFlags)
+	addDebugFlag(generateFlags)
+	generateFlags.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: mcp-publisher generate --from <npm|pypi|docker> [--file <path>] [--force] [metadata-file]")
+		_, _ = fmt.Fprintln(os.Stderr)
+		_, _ = fmt.Fprintln(os.Stderr, "Write server.json from package.json, pyproject.toml or the labels in a Dockerfile.")
+		generateFlags.PrintDefaults()
+	}
+	if err := parseFlags(generateFlags, args); err != nil {
+		return err
+	}
+	if generateFlags.NArg() > 1 {
+		generateFlags.Usage()
+		return withExitCode(ExitUsage, errors.New("at most one metadata file can be given"))
+	}
+
+	var server *generatedServer
+	var err error
+	switch *from {
+	case "npm":
+		server, err = generateFromNPM(cmp.Or(generateFlags.Arg(0), "package.json"))
+	case "pypi":
+		server, err = generateFromPyPI(cmp.Or(generateFlags.Arg(0), "pyproject.toml"))
+	case "docker":
+		server, err = generateFromDocker(cmp.Or(generateFlags.Arg(0), "Dockerfile"), *image)
+	default:
+		generateFlags.Usage()
+		return withExitCode(ExitUsage, errors.New("--from must be npm, pypi or docker"))
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(*file); err == nil && !*force {
+		return fmt.Errorf("%s already exists. Pass --force to overwrite it", *file)
+	}
+
+	// What could not be read is left to the usual detection, as for init
+	if server.Name == "" {
+		server.Name = detectServerName()
+		server.Notes = append(server.Notes, fmt.Sprintf("The server name %s was guessed; check you can publish under its namespace", server.Name))
+	}
+	server.RepoURL = normalizeRepoURL(cmp.Or(server.RepoURL, gitRemoteURL()))
+	server.Version = cmp.Or(server.Version, "1.0.0")
+
+	serverJSON := createServerJSON(
+		server.Name, server.Description, server.Version, server.RepoURL, repositorySource(server.RepoURL),
+		server.PackageType, server.Identifier, server.Version, nil,
+	)
+	if server.PackageType == model.RegistryTypeOCI {
+		// The image reference is complete, with its tag
+		serverJSON.Packages[0].Identifier = server.Identifier
+	}
+	if server.RepoURL == "" {
+		serverJSON.Repository = model.Repository{}
+	}
+
+	jsonData, err := json.MarshalIndent(serverJSON, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %w", err)
+	}
+	// The registry's own checks find what is still missing, such as a description
+	checks, err := validateLocally(jsonData, false)
+	if err != nil {
+		return fmt.Errorf("generated an invalid server.json: %w", err)
+	}
+	for _, check := range checks {
+		for _, checkErr := range check.Errors {
+			server.Notes = append(server.Notes, fmt.Sprintf("%s: %v", check.Name, checkErr))
+		}
+	}
+
+	if err := writeServerFile(*file, append(jsonData, '\n')); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+	_, _ = fmt.Fprintf(logOutput(), "✓ Generated %s for %s %s from %s\n", *file, serverJSON.Name, serverJSON.Version, *from)
+	if len(server.Notes) > 0 {
+		_, _ = fmt.Fprintln(logOutput(), "\nCheck before publishing:")
+		for _, note := range server.Notes {
+			_, _ = fmt.Fprintf(logOutput(), "  • %s\n", note)
+		}
+	}
+	_, _ = fmt.Fprintf(logOutput(), "\nThen run 'mcp-publisher validate --file %s'.\n", *file)
+
+	return printResult(generateResult{File: *file, Name: serverJSON.Name, Version: serverJSON.Version, Notes: append([]string{}, server.Notes...)})
+}
+
+// generateResult is the JSON result of 'mcp-publisher generate'
+type generateResult struct {
+	File    string   `json:"file"`
+	Name    string   `json:"name"`
+	Version string   `json:"version"`
+	Notes   []string `json:"notes"`
+}
+
+// generateFromNPM reads package.json. Its mcpName is the server name, as the registry requires.
+func generateFromNPM(path string) (*generatedServer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var pkg struct {
+		Name        string `json:"name"`
+		MCPName     string `json:"mcpName"`
+		Description string `json:"description"`
+		Version     string `json:"version"`
+		Repository  any    `json:"repository"`
+		Bin         any    `json:"bin"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	if pkg.Name == "" {
+		return nil, fmt.Errorf("%s has no name", path)
+	}
+
+	server := &generatedServer{
+		PackageType: model.RegistryTypeNPM,
+		Name:        pkg.MCPName,
+		Description: pkg.Description,
+		Version:     pkg.Version,
+		Identifier:  pkg.Name,
+	}
+	switch repo := pkg.Repository.(type) {
+	case string:
+		server.RepoURL = repo
+	case map[string]any:
+		server.RepoURL, _ = repo["url"].(string)
+	}
+
+	if server.Name == "" {
+		if name := npmServerName(pkg.Name); !strings.Contains(name, "<") {
+			server.Name = name
+		}
+		server.Notes = append(server.Notes, fmt.Sprintf("Add \"mcpName\": \"<server name>\" to %s and publish the package again, as the registry requires", path))
+	}
+
+	// npx runs the only bin entry, or the one named like the package
+	switch bin := pkg.Bin.(type) {
+	case nil:
+		server.Notes = append(server.Notes, fmt.Sprintf("%s has no bin entry, so npx cannot run the package", path))
+	case map[string]any:
+		command := pkg.Name[strings.LastIndex(pkg.Name, "/")+1:]
+		if _, ok := bin[command]; !ok && len(bin) > 1 {
+			server.Notes = append(server.Notes, fmt.Sprintf("%s has several bin entries and none is named %s, so npx cannot tell which to run", path, command))
+		}
+	}
+	return server, nil
+}
+
+// npmServerName converts an npm package name to a server name: @org/package becomes io.github.org/package
+func npmServerName(packageName string) string {
+	if strings.HasPrefix(packageName, "@") {
+		parts := strings.Split(packageName[1:], "/")
+		if len(parts) == 2 {
+			return fmt.Sprintf("io.github.%s/%s", parts[0], parts[1])
+		}
+	}
+	return fmt.Sprintf("io.github.<your-username>/%s", packageName)
+}
+
+// generateFromPyPI reads pyproject.toml. The server name is the mcp-name in the README, as the
+// registry requires.
+func generateFromPyPI(path string) (*generatedServer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var pyproject struct {
+		Project struct {
+			Name        string            `toml:"name"`
+			Description string            `toml:"description"`
+			Version     string            `toml:"version"`
+			Readme      any               `toml:"readme"`
+			URLs        map[string]string `toml:"urls"`
+			Scripts     map[string]string `toml:"scripts"`
+		} `toml:"project"`
+	}
+	if err := toml.Unmarshal(data, &pyproject); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	project := pyproject.Project
+	if project.Name == "" {
+		return nil, fmt.Errorf("%s has no [project] name", path)
+	}
+
+	server := &generatedServer{
+		PackageType: model.RegistryTypePyPI,
+		Description: project.Description,
+		Version:     project.Version,
+		RepoURL:     pyprojectRepoURL(project.URLs),
+		Identifier:  project.Name,
+	}
+	if project.Version == "" {
+		server.Notes = append(server.Notes, fmt.Sprintf("%s has no static version; set the version in server.json and its package", path))
+	}
+
+	readme, _ := project.Readme.(string)
+	if table, ok := project.Readme.(map[string]any); ok {
+		readme, _ = table["file"].(string)
+	}
+	readme = filepath.Join(filepath.Dir(path), cmp.Or(readme, "README.md"))
+	if content, err := os.ReadFile(readme); err == nil {
+		if match := mcpNamePattern.FindSubmatch(content); match != nil {
+			server.Name = string(match[1])
+		}
+	}
+	if server.Name == "" {
+		server.Notes = append(server.Notes, fmt.Sprintf("Add 'mcp-name: <server name>' to %s and publish the package again, as the registry requires", readme))
+	}
+
+	// uvx runs the script named like the package
+	if len(project.Scripts) == 0 {
+		server.Notes = append(server.Notes, fmt.Sprintf("%s has no [project.scripts], so uvx cannot run the package", path))
+	} else if _, ok := project.Scripts[project.Name]; !ok {
+		server.Notes = append(server.Notes, fmt.Sprintf("None of the scripts in %s (%s) is named %s, which uvx runs", path,
+			strings.Join(slices.Sorted(maps.Keys(project.Scripts)), ", "), project.Name))
+	}
+	return server, nil
+}
+
+// generateFromDocker reads the labels of the image a Dockerfile builds. The server name is its
+// io.modelcontextprotocol.server.name label, as the registry requires.
+func generateFromDocker(path, image string) (*generatedServer, error) {
+	labels, err := dockerfileLabels(path)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &generatedServer{
+		PackageType: model.RegistryTypeOCI,
+		Name:        labels[serverNameLabel],
+		Description: labels[descriptionLabel],
+		Version:     labels[versionLabel],
+		RepoURL:     labels[sourceLabel],
+		Identifier:  image,
+	}
+	if server.Name == "" {
+		server.Notes = append(server.Notes, fmt.Sprintf("Add LABEL %s=\"<server name>\" to %s and push the image again, as the registry requires", serverNameLabel, path))
+	}
+
+	if server.Identifier == "" {
+		// Images built from GitHub repositories are commonly pushed to the GitHub Container Registry
+		repository := "ghcr.io/OWNER/IMAGE"
+		if repoURL := normalizeRepoURL(server.RepoURL); githubRepoPattern.MatchString(repoURL) {
+			_, repoPath, _ := strings.Cut(repoURL, "github.com/")
+			repository = "ghcr.io/" + strings.ToLower(repoPath)
+		}
+		server.Identifier = repository + ":" + cmp.Or(server.Version, "1.0.0")
+		server.Notes = append(server.Notes, fmt.Sprintf("The image %s was guessed; pass --image with the image you push", server.Identifier))
+	}
+	if _, tag, ok := splitImageTag(server.Identifier); ok && server.Version == "" && tag != "latest" {
+		server.Version = tag
+	}
+	return server, nil
+}
+
+// dockerfileLabels reads the LABEL instructions of a Dockerfile, following line continuations
+func dockerfileLabels(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	labels := map[string]string{}
+	var instruction strings.Builder
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if continued, ok := strings.CutSuffix(line, "\\"); ok {
+			instruction.WriteString(continued + " ")
+			continue
+		}
+		instruction.WriteString(line)
+		parseLabelInstruction(instruction.String(), labels)
+		instruction.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return labels, nil
+}
+
+// labelPairPattern matches the key=value pairs of a LABEL instruction, either of which may be quoted
+var labelPairPattern = regexp.MustCompile(`("(?:[^"\\]|\\.)*"|[^\s=]+)=("(?:[^"\\]|\\.)*"|\S*)`)
+
+// parseLabelInstruction adds the labels of a LABEL instruction
+func parseLabelInstruction(instruction string, labels map[string]string) {
+	keyword, rest, _ := strings.Cut(instruction, " ")
+	if !strings.EqualFold(keyword, "LABEL") {
+		return
+	}
+	for _, pair := range labelPairPattern.FindAllStringSubmatch(rest, -1) {
+		labels[unquoteLabel(pair[1])] = unquoteLabel(pair[2])
+	}
+}
+
+func unquoteLabel(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(s[1 : len(s)-1])
+	}
+	return s
+}
@@ -0,0 +1,181 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestGenerateCommand(t *testing.T) {
+	tests := []struct {
+		name           string
+		files          map[string]string
+		args           []string
+		wantName       string
+		wantVersion    string
+		wantRepo       string
+		wantType       string
+		wantIdentifier string
+		wantNote       string
+	}{
+		{
+			name: "npm",
+			files: map[string]string{"package.json": `{
+				"name": "@acme/weather",
+				"mcpName": "io.github.acme/weather",
+				"description": "Weather forecasts for anywhere",
+				"version": "2.1.0",
+				"repository": {"type": "git", "url": "git+https://github.com/acme/weather.git"},
+				"bin": {"weather": "dist/index.js", "weather-admin": "dist/admin.js"}
+			}`},
+			args:           []string{"--from", "npm"},
+			wantName:       "io.github.acme/weather",
+			wantVersion:    "2.1.0",
+			wantRepo:       "https://github.com/acme/weather",
+			wantType:       model.RegistryTypeNPM,
+			wantIdentifier: "@acme/weather",
+		},
+		{
+			name: "npm without mcpName or bin",
+			files: map[string]string{"package.json": `{
+				"name": "@acme/weather",
+				"description": "Weather forecasts for anywhere",
+				"version": "2.1.0"
+			}`},
+			args:           []string{"--from", "npm"},
+			wantName:       "io.github.acme/weather",
+			wantVersion:    "2.1.0",
+			wantType:       model.RegistryTypeNPM,
+			wantIdentifier: "@acme/weather",
+			wantNote:       "mcpName",
+		},
+		{
+			name: "pypi",
+			files: map[string]string{
+				"pyproject.toml": `
+[project]
+name = "weather-mcp"
+description = "Weather forecasts for anywhere"
+version = "0.3.0"
+readme = "README.md"
+
+[project.urls]
+"Source Code" = "https://gitlab.com/acme/weather"
+
+[project.scripts]
+weather = "weather:main"
+`,
+				"README.md": "# Weather\n\n<!-- mcp-name: com.acme/weather -->\n",
+			},
+			args:           []string{"--from", "pypi"},
+			wantName:       "com.acme/weather",
+			wantVersion:    "0.3.0",
+			wantRepo:       "https://gitlab.com/acme/weather",
+			wantType:       model.RegistryTypePyPI,
+			wantIdentifier: "weather-mcp",
+			wantNote:       "uvx",
+		},
+		{
+			name: "docker",
+			files: map[string]string{"Dockerfile": `FROM node:22
+# Labels for the registry
+LABEL io.modelcontextprotocol.server.name="io.github.acme/weather" \
+      org.opencontainers.image.description="Weather forecasts for anywhere" \
+      org.opencontainers.image.source=https://github.com/Acme/weather
+LABEL org.opencontainers.image.version="2.1.0"
+CMD ["node", "index.js"]
+`},
+			args:           []string{"--from", "docker"},
+			wantName:       "io.github.acme/weather",
+			wantVersion:    "2.1.0",
+			wantRepo:       "https://github.com/Acme/weather",
+			wantType:       model.RegistryTypeOCI,
+			wantIdentifier: "ghcr.io/acme/weather:2.1.0",
+			wantNote:       "--image",
+		},
+		{
+			name:           "docker with image",
+			files:          map[string]string{"build/Dockerfile": `LABEL io.modelcontextprotocol.server.name=io.github.acme/weather`},
+			args:           []string{"--from", "docker", "--image", "docker.io/acme/weather:3.0.0", "build/Dockerfile"},
+			wantName:       "io.github.acme/weather",
+			wantVersion:    "3.0.0",
+			wantType:       model.RegistryTypeOCI,
+			wantIdentifier: "docker.io/acme/weather:3.0.0",
+			wantNote:       "description",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			for name, content := range tt.files {
+				if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+					t.Fatalf("Failed to create directory for %s: %v", name, err)
+				}
+				if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+
+			output, err := captureStdout(t, func() error {
+				return commands.GenerateCommand(append([]string{"--output", "json"}, tt.args...))
+			})
+			if err != nil {
+				t.Fatalf("generate failed: %v", err)
+			}
+			var result struct {
+				Name  string   `json:"name"`
+				Notes []string `json:"notes"`
+			}
+			if err := json.Unmarshal([]byte(output), &result); err != nil {
+				t.Fatalf("Failed to parse result %q: %v", output, err)
+			}
+			notes := strings.Join(result.Notes, "\n")
+			if tt.wantNote == "" && notes != "" {
+				t.Errorf("Expected no notes, got %s", notes)
+			}
+			if !strings.Contains(notes, tt.wantNote) {
+				t.Errorf("Expected a note about %s, got %s", tt.wantNote, notes)
+			}
+
+			data, err := os.ReadFile("server.json")
+			if err != nil {
+				t.Fatalf("Failed to read server.json: %v", err)
+			}
+			var server apiv0.ServerJSON
+			if err := json.Unmarshal(data, &server); err != nil {
+				t.Fatalf("Failed to parse server.json: %v", err)
+			}
+			if server.Name != tt.wantName || server.Version != tt.wantVersion || result.Name != tt.wantName {
+				t.Errorf("Unexpected server details: %s %s", server.Name, server.Version)
+			}
+			if server.Repository.URL != tt.wantRepo {
+				t.Errorf("Expected repository %q, got %q", tt.wantRepo, server.Repository.URL)
+			}
+			if len(server.Packages) != 1 {
+				t.Fatalf("Expected one package, got %d", len(server.Packages))
+			}
+			if pkg := server.Packages[0]; pkg.RegistryType != tt.wantType || pkg.Identifier != tt.wantIdentifier {
+				t.Errorf("Unexpected package: %+v", pkg)
+			}
+		})
+	}
+
+	t.Run("refuses to overwrite", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		for name, content := range map[string]string{"package.json": `{"name": "weather"}`, "server.json": "{}"} {
+			if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+				t.Fatalf("Failed to write %s: %v", name, err)
+			}
+		}
+		if err := commands.GenerateCommand([]string{"--from", "npm"}); err == nil || !strings.Contains(err.Error(), "--force") {
+			t.Errorf("Expected an error suggesting --force, got %v", err)
+		}
+	})
+}
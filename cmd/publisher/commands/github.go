@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Files GitHub Actions reads step outputs and the job summary from
+const (
+	GitHubOutputEnvVar  = "GITHUB_OUTPUT"
+	GitHubSummaryEnvVar = "GITHUB_STEP_SUMMARY"
+)
+
+// githubReport is what --github-actions writes: step outputs, for later steps to use as
+// steps.<id>.outputs.<name>, and Markdown appended to the job summary
+type githubReport struct {
+	outputs []githubOutput
+	summary strings.Builder
+}
+
+type githubOutput struct {
+	name, value string
+}
+
+// checkGitHubActions fails unless the files --github-actions writes are known, so a misconfigured
+// job fails before anything is published
+func checkGitHubActions() error {
+	if os.Getenv(GitHubOutputEnvVar) == "" || os.Getenv(GitHubSummaryEnvVar) == "" {
+		return withExitCode(ExitUsage, fmt.Errorf("--github-actions needs %s and %s, which GitHub Actions sets for each step", GitHubOutputEnvVar, GitHubSummaryEnvVar))
+	}
+	return nil
+}
+
+// output adds a step output. Lists are given as JSON, for fromJSON in workflows.
+func (r *githubReport) output(name string, value any) {
+	text, ok := value.(string)
+	if !ok {
+		data, _ := json.Marshal(value)
+		text = string(data)
+	}
+	r.outputs = append(r.outputs, githubOutput{name: name, value: text})
+}
+
+// summaryf adds a line to the job summary
+func (r *githubReport) summaryf(format string, args ...any) {
+	_, _ = fmt.Fprintf(&r.summary, format+"\n", args...)
+}
+
+// write appends the report to the step outputs and the job summary
+func (r *githubReport) write() error {
+	var outputs strings.Builder
+	for _, output := range r.outputs {
+		if !strings.ContainsAny(output.value, "\r\n") {
+			_, _ = fmt.Fprintf(&outputs, "%s=%s\n", output.name, output.value)
+			continue
+		}
+		// Values over several lines are written between a delimiter that cannot be in them
+		delimiter := make([]byte, 16)
+		if _, err := rand.Read(delimiter); err != nil {
+			return fmt.Errorf("failed to generate output delimiter: %w", err)
+		}
+		_, _ = fmt.Fprintf(&outputs, "%s<<ghadelimiter_%s\n%s\nghadelimiter_%[2]s\n", output.name, hex.EncodeToString(delimiter), output.value)
+	}
+
+	return errors.Join(
+		appendToFile(os.Getenv(GitHubOutputEnvVar), outputs.String()),
+		appendToFile(os.Getenv(GitHubSummaryEnvVar), r.summary.String()+"\n"),
+	)
+}
+
+func appendToFile(path, content string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	if _, err := file.WriteString(content); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+// publishReport reports a published server
+func publishReport(result publishResult) *githubReport {
+	report := &githubReport{}
+	report.output("name", result.Name)
+	report.output("version", result.Version)
+	report.output("registry", result.Registry)
+	report.output("url", result.URL)
+	report.output("is-latest", fmt.Sprint(result.IsLatest))
+
+	report.summaryf("### ✅ Published %s %s", result.Name, result.Version)
+	report.summaryf("")
+	report.summaryf("| Registry | Version | Latest |")
+	report.summaryf("|---|---|---|")
+	latest := "yes"
+	if !result.IsLatest {
+		latest = "no, a higher version is already published"
+	}
+	report.summaryf("| %s | [%s](%s) | %s |", result.Registry, result.Version, result.URL, latest)
+	return report
+}
+
+// dryRunReport reports what a dry run found
+func dryRunReport(result *dryRunResult) *githubReport {
+	report := &githubReport{}
+	report.output("name", result.Name)
+	report.output("version", result.Version)
+	report.output("registry", result.Registry)
+	report.output("valid", fmt.Sprint(result.Valid))
+	report.output("errors", result.Errors)
+	report.output("warnings", result.Warnings)
+
+	if result.Valid {
+		report.summaryf("### ✅ %s %s would be published", result.Name, result.Version)
+	} else {
+		report.summaryf("### ❌ %s %s would not be published", result.Name, result.Version)
+	}
+	report.summaryf("")
+	if result.CheckedBy == "local" {
+		report.summaryf("Checked locally, as %s could not be asked: namespace permissions, publish policies and existing versions were not checked.", result.Registry)
+	} else {
+		report.summaryf("Checked by %s.", result.Registry)
+	}
+	summarizeProblems(report, result.Errors, result.Warnings)
+	return report
+}
+
+// publishFilesReport reports how publishing each of several files went
+func publishFilesReport(result publishFilesResult, dryRun bool) *githubReport {
+	type publishedServer struct {
+		File    string `json:"file"`
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		URL     string `json:"url,omitempty"`
+	}
+	type failedServer struct {
+		File  string `json:"file"`
+		Error string `json:"error"`
+	}
+	published, failed := []publishedServer{}, []failedServer{}
+
+	report := &githubReport{}
+	verb := "Published"
+	if dryRun {
+		verb = "Would publish"
+	}
+	report.summaryf("### %s %d of %d server(s)", verb, len(result.Servers)-result.Failed, len(result.Servers))
+	report.summaryf("")
+	report.summaryf("| File | Server | Version | Result |")
+	report.summaryf("|---|---|---|---|")
+	for _, server := range result.Servers {
+		name, version := server.server()
+		if server.Error != "" {
+			failed = append(failed, failedServer{File: server.File, Error: server.Error})
+			// Errors can span lines, which tables cannot
+			report.summaryf("| %s | %s | %s | ❌ %s |", server.File, name, version, strings.ReplaceAll(server.Error, "\n", " "))
+			continue
+		}
+		entry := publishedServer{File: server.File, Name: name, Version: version}
+		if server.Published != nil {
+			entry.URL = server.Published.URL
+		}
+		published = append(published, entry)
+		report.summaryf("| %s | %s | %s | ✅ |", server.File, name, version)
+	}
+
+	report.output("published", published)
+	report.output("failed", failed)
+	return report
+}
+
+// validateReport reports the checks of 'mcp-publisher validate'
+func validateReport(result validateResult) *githubReport {
+	report := &githubReport{}
+	var problems []string
+	for _, check := range result.Checks {
+		for _, problem := range check.Errors {
+			problems = append(problems, check.Name+": "+problem)
+		}
+	}
+	report.output("valid", fmt.Sprint(result.Valid))
+	report.output("errors", append([]string{}, problems...))
+
+	if result.Valid {
+		report.summaryf("### ✅ %s is valid", result.File)
+	} else {
+		report.summaryf("### ❌ %s has %d problem(s)", result.File, len(problems))
+	}
+	if !result.PackagesChecked {
+		report.summaryf("")
+		report.summaryf("The packages were not checked in their registries.")
+	}
+	summarizeProblems(report, problems, nil)
+	return report
+}
+
+// failureReport reports a command that failed
+func failureReport(action string, err error) *githubReport {
+	report := &githubReport{}
+	report.output("error", err.Error())
+	report.summaryf("### ❌ %s failed", action)
+	report.summaryf("")
+	report.summaryf("```")
+	report.summaryf("%s", err)
+	report.summaryf("```")
+	return report
+}
+
+func summarizeProblems(report *githubReport, errs, warnings []string) {
+	if len(errs) == 0 && len(warnings) == 0 {
+		return
+	}
+	report.summaryf("")
+	for _, problem := range errs {
+		report.summaryf("- ❌ %s", problem)
+	}
+	for _, warning := range warnings {
+		report.summaryf("- ⚠️ %s", warning)
+	}
+}
@@ -0,0 +1,117 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestGitHubActions(t *testing.T) {
+	var failure string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failure != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(failure))
+			return
+		}
+		var serverJSON apiv0.ServerJSON
+		_ = json.NewDecoder(r.Body).Decode(&serverJSON)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiv0.PublishResponse{ServerResponse: apiv0.ServerResponse{
+			Server: serverJSON,
+			Meta:   apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{Status: model.StatusActive, IsLatest: true}},
+		}})
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+	t.Chdir(t.TempDir())
+	serverData := `{"$schema": "` + model.CurrentSchemaURL + `", "name": "io.github.example/test-server", "description": "A test server", "version": "1.0.0"}`
+	if err := os.WriteFile("server.json", []byte(serverData), 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	// readReport returns what a command wrote to the step outputs and job summary, and clears them
+	githubDir := t.TempDir()
+	outputPath, summaryPath := filepath.Join(githubDir, "output"), filepath.Join(githubDir, "summary")
+	readReport := func(t *testing.T) (string, string) {
+		t.Helper()
+		output, _ := os.ReadFile(outputPath)
+		summary, _ := os.ReadFile(summaryPath)
+		_ = os.Remove(outputPath)
+		_ = os.Remove(summaryPath)
+		return string(output), string(summary)
+	}
+
+	t.Run("requires GitHub Actions", func(t *testing.T) {
+		t.Setenv(commands.GitHubOutputEnvVar, "")
+		err := commands.PublishCommand([]string{"--github-actions"})
+		if commands.ExitCode(err) != commands.ExitUsage {
+			t.Errorf("Expected a usage error, got %v", err)
+		}
+	})
+
+	t.Setenv(commands.GitHubOutputEnvVar, outputPath)
+	t.Setenv(commands.GitHubSummaryEnvVar, summaryPath)
+
+	t.Run("publish", func(t *testing.T) {
+		if err := commands.PublishCommand([]string{"--github-actions"}); err != nil {
+			t.Fatalf("publish failed: %v", err)
+		}
+		output, summary := readReport(t)
+		expected := "name=io.github.example/test-server\nversion=1.0.0\nregistry=" + server.URL +
+			"\nurl=" + server.URL + "/v0/servers/io.github.example%2Ftest-server/versions/1.0.0\nis-latest=true\n"
+		if output != expected {
+			t.Errorf("Expected outputs:\n%s\ngot:\n%s", expected, output)
+		}
+		if !strings.Contains(summary, "### ✅ Published io.github.example/test-server 1.0.0") {
+			t.Errorf("Unexpected summary:\n%s", summary)
+		}
+	})
+
+	t.Run("failures are reported", func(t *testing.T) {
+		failure = "{\n  \"detail\": \"Failed to publish server\"\n}"
+		defer func() { failure = "" }()
+		if err := commands.PublishCommand([]string{"--github-actions"}); err == nil {
+			t.Fatal("Expected publish to fail")
+		}
+		output, summary := readReport(t)
+		// The error is over several lines, so it is written between delimiters
+		lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+		name, delimiter, _ := strings.Cut(lines[0], "<<")
+		if name != "error" || !strings.HasPrefix(delimiter, "ghadelimiter_") || lines[len(lines)-1] != delimiter ||
+			strings.Join(lines[1:len(lines)-1], "\n") != "publish failed: server returned status 400: "+failure {
+			t.Errorf("Unexpected outputs:\n%s", output)
+		}
+		if !strings.Contains(summary, "### ❌ Publishing io.github.example/test-server 1.0.0 failed") {
+			t.Errorf("Unexpected summary:\n%s", summary)
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		invalid := strings.Replace(serverData, `"1.0.0"`, `"^1.0.0"`, 1)
+		if err := os.WriteFile("server.json", []byte(invalid), 0o600); err != nil {
+			t.Fatalf("Failed to write server.json: %v", err)
+		}
+		if err := commands.ValidateCommand([]string{"--github-actions", "--skip-packages"}); err == nil {
+			t.Fatal("Expected validate to fail")
+		}
+		output, summary := readReport(t)
+		if !strings.HasPrefix(output, "valid=false\nerrors=[\"Registry rules: ") {
+			t.Errorf("Unexpected outputs:\n%s", output)
+		}
+		if !strings.Contains(summary, "### ❌ server.json has 1 problem(s)") || !strings.Contains(summary, "- ❌ Registry rules: ") {
+			t.Errorf("Unexpected summary:\n%s", summary)
+		}
+	})
+}
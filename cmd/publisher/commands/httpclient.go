@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// CACertEnvVar names a PEM bundle of CA certificates to trust as well as the system's, when --ca-cert is not given
+const CACertEnvVar = "MCP_PUBLISHER_CA_CERT"
+
+// ConfigureHTTP sets up the transport that requests to the registry, login providers and package
+// registries share. Proxies are taken from HTTPS_PROXY, HTTP_PROXY and NO_PROXY by the default
+// transport, or else from the configuration file, so only the CA bundle of MCP_PUBLISHER_CA_CERT or
+// the configuration file needs adding.
+func ConfigureHTTP() error {
+	if err := applyProxyConfig(); err != nil {
+		return fmt.Errorf("failed to set the proxy of the configuration file: %w", err)
+	}
+	if path := os.Getenv(CACertEnvVar); path != "" {
+		if err := trustCACerts(path); err != nil {
+			return withExitCode(ExitUsage, fmt.Errorf("%s: %w", CACertEnvVar, err))
+		}
+	} else if config.CACert != "" {
+		if err := trustCACerts(config.CACert); err != nil {
+			return withExitCode(ExitUsage, fmt.Errorf("ca_cert in the configuration file: %w", err))
+		}
+	}
+	return nil
+}
+
+// trustCACerts makes every request trust the CA certificates in a PEM bundle, for registries and
+// proxies signed by a private CA
+func trustCACerts(path string) error {
+	bundle, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM(bundle) {
+		return fmt.Errorf("%s contains no PEM certificates", path)
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if traced, isTraced := http.DefaultTransport.(*debugTransport); isTraced {
+		transport, ok = traced.Base(), true
+	}
+	if !ok {
+		return errors.New("cannot configure the HTTP transport")
+	}
+	transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	// Connections already made trusted the previous CAs
+	transport.CloseIdleConnections()
+	return nil
+}
+
+// caCertFlag is the --ca-cert flag, which takes effect as soon as it is parsed
+type caCertFlag struct{}
+
+func (caCertFlag) String() string { return "" }
+
+func (caCertFlag) Set(path string) error { return trustCACerts(path) }
+
+// addCACertFlag adds the --ca-cert flag to a command's flags
+func addCACertFlag(flags *flag.FlagSet) {
+	flags.Var(caCertFlag{}, "ca-cert", "PEM bundle of CA certificates to trust as well as the system's (default: $"+CACertEnvVar+")")
+}
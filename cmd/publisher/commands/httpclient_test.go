@@ -0,0 +1,86 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestCACert(t *testing.T) {
+	// The server's certificate is signed by no CA the system trusts
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var published apiv0.ServerJSON
+		_ = json.NewDecoder(r.Body).Decode(&published)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiv0.PublishResponse{ServerResponse: apiv0.ServerResponse{Server: published}})
+	}))
+	defer server.Close()
+
+	transport := http.DefaultTransport.(*http.Transport)
+	originalTLSConfig := transport.TLSClientConfig
+	t.Cleanup(func() { transport.TLSClientConfig = originalTLSConfig })
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+	t.Chdir(t.TempDir())
+
+	serverData, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal test JSON: %v", err)
+	}
+	if err := os.WriteFile("server.json", serverData, 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile("ca.pem", caCert, 0o600); err != nil {
+		t.Fatalf("Failed to write ca.pem: %v", err)
+	}
+	if err := os.WriteFile("empty.pem", nil, 0o600); err != nil {
+		t.Fatalf("Failed to write empty.pem: %v", err)
+	}
+
+	err = commands.PublishCommand(nil)
+	if err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Fatalf("Expected the untrusted certificate to be refused, got: %v", err)
+	}
+
+	t.Run("flag", func(t *testing.T) {
+		transport.TLSClientConfig = originalTLSConfig
+		if err := commands.PublishCommand([]string{"--ca-cert", "ca.pem"}); err != nil {
+			t.Errorf("Expected the publish to succeed, got: %v", err)
+		}
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		transport.TLSClientConfig = originalTLSConfig
+		t.Setenv(commands.CACertEnvVar, "ca.pem")
+		if err := commands.ConfigureHTTP(); err != nil {
+			t.Fatalf("ConfigureHTTP failed: %v", err)
+		}
+		if err := commands.PublishCommand(nil); err != nil {
+			t.Errorf("Expected the publish to succeed, got: %v", err)
+		}
+	})
+
+	t.Run("no certificates", func(t *testing.T) {
+		t.Setenv(commands.CACertEnvVar, "empty.pem")
+		err := commands.ConfigureHTTP()
+		if err == nil || !strings.Contains(err.Error(), "no PEM certificates") || commands.ExitCode(err) != commands.ExitUsage {
+			t.Errorf("Expected a usage error about the bundle, got: %v", err)
+		}
+	})
+}
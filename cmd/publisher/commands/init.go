@@ -1,64 +1,127 @@
 package commands
 
 import (
+	"bufio"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/BurntSushi/toml"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
-func InitCommand() error {
+// initPackageTypes are the package types init can set up
+var initPackageTypes = []string{model.RegistryTypeNPM, model.RegistryTypePyPI, model.RegistryTypeOCI, model.RegistryTypeNuGet}
+
+// serverNamePattern matches valid server names, as in the server.json schema
+var serverNamePattern = regexp.MustCompile(`^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$`)
+
+// projectInfo is what init learns about the server from package.json or pyproject.toml
+type projectInfo struct {
+	PackageName string
+	Description string
+	Version     string
+	RepoURL     string
+}
+
+// initAnswers are the values init writes to server.json
+type initAnswers struct {
+	Name              string
+	Description       string
+	PackageType       string
+	PackageIdentifier string
+	RepoURL           string
+}
+
+// InitCommand creates server.json, asking for the server's name, description, package and repository
+// with the values detected from the project as defaults. With --yes it writes the detected values, and
+// placeholders for those it could not detect, without asking.
+func InitCommand(args []string) error {
+	initFlags := flag.NewFlagSet("init", flag.ExitOnError)
+	var yes bool
+	initFlags.BoolVar(&yes, "yes", false, "Write the detected values without asking")
+	initFlags.BoolVar(&yes, "y", false, "Shorthand for --yes")
+	addOutputFlag(initFlags)
+	addNonInteractiveFlag(initFlags)
+	if err := parseFlags(initFlags, args); err != nil {
+		return err
+	}
+	// Without anyone to answer the questions, the detected values are used as with --yes
+	yes = yes || nonInteractive
+
 	// Check if server.json already exists
 	if _, err := os.Stat("server.json"); err == nil {
 		return errors.New("server.json already exists")
 	}
 
-	// Try to detect values from environment
+	// Try to detect values from the project
+	project := detectProject()
 	name := detectServerName()
-	description := detectDescription()
-	version := "1.0.0"
-	repoURL := detectRepoURL()
-	repoSource := "github"
-	if repoURL != "" && !strings.Contains(repoURL, "github.com") {
-		if strings.Contains(repoURL, "gitlab.com") {
-			repoSource = "gitlab"
-		} else {
-			repoSource = "git"
-		}
+	packageType := detectPackageType()
+	detected := initAnswers{
+		Name:              name,
+		Description:       project.Description,
+		PackageType:       packageType,
+		PackageIdentifier: detectPackageIdentifier(name, packageType),
+		RepoURL:           detectRepoURL(project),
+	}
+	version := project.Version
+	if version == "" {
+		version = "1.0.0"
 	}
 
-	packageType := detectPackageType()
-	packageIdentifier := detectPackageIdentifier(name, packageType)
-
-	// Create example environment variables
-	envVars := []model.KeyValueInput{
-		{
-			Name: "YOUR_API_KEY",
-			InputWithVariables: model.InputWithVariables{
-				Input: model.Input{
-					Description: "Your API key for the service",
-					IsRequired:  true,
-					IsSecret:    true,
-					Format:      model.FormatString,
+	var server apiv0.ServerJSON
+	if yes {
+		if detected.Description == "" {
+			detected.Description = "An MCP server that provides [describe what your server does]"
+		}
+		if detected.RepoURL == "" {
+			detected.RepoURL = "https://github.com/YOUR_USERNAME/YOUR_REPO"
+		}
+
+		// Create example environment variables
+		envVars := []model.KeyValueInput{
+			{
+				Name: "YOUR_API_KEY",
+				InputWithVariables: model.InputWithVariables{
+					Input: model.Input{
+						Description: "Your API key for the service",
+						IsRequired:  true,
+						IsSecret:    true,
+						Format:      model.FormatString,
+					},
 				},
 			},
-		},
+		}
+		server = createServerJSON(
+			detected.Name, detected.Description, version, detected.RepoURL, repositorySource(detected.RepoURL),
+			detected.PackageType, detected.PackageIdentifier, version, envVars,
+		)
+	} else {
+		answers, err := askInitQuestions(&prompter{in: bufio.NewReader(os.Stdin), out: logOutput()}, detected)
+		if err != nil {
+			return err
+		}
+		server = createServerJSON(
+			answers.Name, answers.Description, version, answers.RepoURL, repositorySource(answers.RepoURL),
+			answers.PackageType, answers.PackageIdentifier, version, nil,
+		)
 	}
 
-	// Create the server structure
-	server := createServerJSON(
-		name, description, version, repoURL, repoSource,
-		packageType, packageIdentifier, version, envVars,
-	)
-
 	// Write to file
 	jsonData, err := json.MarshalIndent(server, "", "  ")
 	if err != nil {
@@ -70,36 +133,226 @@ func InitCommand() error {
 		return fmt.Errorf("error writing file: %w", err)
 	}
 
-	_, _ = fmt.Fprintln(os.Stdout, "Created server.json")
-	_, _ = fmt.Fprintln(os.Stdout, "\nEdit server.json to update:")
-	_, _ = fmt.Fprintln(os.Stdout, "  • Server name and description")
-	_, _ = fmt.Fprintln(os.Stdout, "  • Package details")
-	_, _ = fmt.Fprintln(os.Stdout, "  • Environment variables")
-	_, _ = fmt.Fprintln(os.Stdout, "\nThen publish with:")
-	_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher login github  # or your preferred auth method")
-	_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher publish")
+	_, _ = fmt.Fprintln(logOutput(), "Created server.json")
+	if yes {
+		_, _ = fmt.Fprintln(logOutput(), "\nEdit server.json to update:")
+		_, _ = fmt.Fprintln(logOutput(), "  • Server name and description")
+		_, _ = fmt.Fprintln(logOutput(), "  • Package details")
+		_, _ = fmt.Fprintln(logOutput(), "  • Environment variables")
+	} else {
+		_, _ = fmt.Fprintln(logOutput(), "\nAdd any environment variables or arguments your server needs to its package in server.json.")
+	}
+	_, _ = fmt.Fprintln(logOutput(), "\nThen publish with:")
+	_, _ = fmt.Fprintln(logOutput(), "  mcp-publisher login github  # or your preferred auth method")
+	_, _ = fmt.Fprintln(logOutput(), "  mcp-publisher publish")
 
-	return nil
+	return printResult(initResult{File: "server.json", Name: server.Name, Version: server.Version})
 }
 
-func getNameFromPackageJSON() string {
-	data, err := os.ReadFile("package.json")
+// initResult is the JSON result of 'mcp-publisher init'
+type initResult struct {
+	File    string `json:"file"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// askInitQuestions asks for the values to write to server.json, offering the detected ones as defaults
+func askInitQuestions(p *prompter, detected initAnswers) (initAnswers, error) {
+	var answers initAnswers
+	var err error
+
+	answers.Name, err = p.ask("Server name", detected.Name, func(name string) error {
+		if len(name) > 200 || !serverNamePattern.MatchString(name) {
+			return errors.New("use the form namespace/name, such as io.github.username/weather")
+		}
+		return nil
+	})
 	if err != nil {
-		return ""
+		return answers, err
 	}
 
-	var pkg map[string]any
-	if err := json.Unmarshal(data, &pkg); err != nil {
-		return ""
+	answers.Description, err = p.ask("Description", detected.Description, func(description string) error {
+		if description == "" || utf8.RuneCountInString(description) > 100 {
+			return errors.New("describe what your server does in at most 100 characters")
+		}
+		return nil
+	})
+	if err != nil {
+		return answers, err
+	}
+
+	answers.PackageType, err = p.ask("Package type ("+strings.Join(initPackageTypes, ", ")+")", detected.PackageType, func(packageType string) error {
+		if !slices.Contains(initPackageTypes, packageType) {
+			return fmt.Errorf("choose one of %s", strings.Join(initPackageTypes, ", "))
+		}
+		return nil
+	})
+	if err != nil {
+		return answers, err
 	}
 
-	name, ok := pkg["name"].(string)
-	if !ok || name == "" {
+	// The detected identifier is for the detected package type
+	packageIdentifier := detected.PackageIdentifier
+	if answers.PackageType != detected.PackageType {
+		packageIdentifier = detectPackageIdentifier(answers.Name, answers.PackageType)
+	}
+	answers.PackageIdentifier, err = p.ask("Package identifier", packageIdentifier, func(identifier string) error {
+		if identifier == "" || strings.ContainsAny(identifier, " \t") {
+			return errors.New("enter the package's name in its registry, without spaces")
+		}
+		return nil
+	})
+	if err != nil {
+		return answers, err
+	}
+
+	answers.RepoURL, err = p.ask("Repository URL (leave empty for none)", detected.RepoURL, func(repoURL string) error {
+		if repoURL != "" && repositorySource(repoURL) == "" {
+			return errors.New("enter a GitHub or GitLab repository URL, such as https://github.com/username/weather")
+		}
+		return nil
+	})
+	if err != nil {
+		return answers, err
+	}
+	answers.RepoURL = normalizeRepoURL(answers.RepoURL)
+
+	return answers, nil
+}
+
+// prompter asks questions on the terminal, where an empty answer accepts the default
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	eof bool
+}
+
+// ask asks a question until the answer passes validate. Once the input has ended, the default is
+// taken without asking, and is an error if it is not valid.
+func (p *prompter) ask(question, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		if p.eof {
+			if err := validate(defaultValue); err != nil {
+				return "", fmt.Errorf("%s: %w (run 'mcp-publisher init --yes' to write a template instead)", strings.ToLower(question[:1])+question[1:], err)
+			}
+			return defaultValue, nil
+		}
+
+		if defaultValue != "" {
+			_, _ = fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+		} else {
+			_, _ = fmt.Fprintf(p.out, "%s: ", question)
+		}
+		line, err := p.in.ReadString('\n')
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return "", fmt.Errorf("failed to read answer: %w", err)
+			}
+			_, _ = fmt.Fprintln(p.out)
+			p.eof = true
+		}
+
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			if p.eof {
+				continue
+			}
+			answer = defaultValue
+		}
+		if err := validate(answer); err != nil {
+			_, _ = fmt.Fprintf(p.out, "  %v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// detectProject reads the package name, description, version and repository from package.json or,
+// failing that, pyproject.toml
+func detectProject() projectInfo {
+	if data, err := os.ReadFile("package.json"); err == nil {
+		var pkg struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			Version     string `json:"version"`
+			Repository  any    `json:"repository"`
+		}
+		if json.Unmarshal(data, &pkg) == nil {
+			project := projectInfo{PackageName: pkg.Name, Description: pkg.Description, Version: pkg.Version}
+			switch repo := pkg.Repository.(type) {
+			case string:
+				project.RepoURL = repo
+			case map[string]any:
+				project.RepoURL, _ = repo["url"].(string)
+			}
+			return project
+		}
+	}
+
+	if data, err := os.ReadFile("pyproject.toml"); err == nil {
+		var pyproject struct {
+			Project struct {
+				Name        string            `toml:"name"`
+				Description string            `toml:"description"`
+				Version     string            `toml:"version"`
+				URLs        map[string]string `toml:"urls"`
+			} `toml:"project"`
+			Tool struct {
+				Poetry struct {
+					Name        string `toml:"name"`
+					Description string `toml:"description"`
+					Version     string `toml:"version"`
+					Repository  string `toml:"repository"`
+				} `toml:"poetry"`
+			} `toml:"tool"`
+		}
+		if toml.Unmarshal(data, &pyproject) == nil {
+			project := projectInfo{
+				PackageName: pyproject.Project.Name,
+				Description: pyproject.Project.Description,
+				Version:     pyproject.Project.Version,
+				RepoURL:     pyprojectRepoURL(pyproject.Project.URLs),
+			}
+
+			// Poetry projects may keep their metadata in its own table instead
+			poetry := pyproject.Tool.Poetry
+			project.PackageName = cmp.Or(project.PackageName, poetry.Name)
+			project.Description = cmp.Or(project.Description, poetry.Description)
+			project.Version = cmp.Or(project.Version, poetry.Version)
+			project.RepoURL = cmp.Or(project.RepoURL, poetry.Repository)
+			return project
+		}
+	}
+
+	return projectInfo{}
+}
+
+// pyprojectRepoURL picks the GitHub or GitLab repository from a pyproject.toml's project URLs,
+// preferring those labelled as the repository or source code
+func pyprojectRepoURL(urls map[string]string) string {
+	labels := slices.Sorted(maps.Keys(urls))
+	for _, preferred := range []bool{true, false} {
+		for _, label := range labels {
+			lower := strings.ToLower(label)
+			if preferred && !strings.Contains(lower, "repo") && !strings.Contains(lower, "source") {
+				continue
+			}
+			if repositorySource(urls[label]) != "" {
+				return urls[label]
+			}
+		}
+	}
+	return ""
+}
+
+func getNameFromPackageJSON() string {
+	name := detectProject().PackageName
+	if name == "" {
 		return ""
 	}
 
 	// Convert npm package name to MCP server name
-	// @org/package -> io.npm.org/package
+	// @org/package -> io.github.org/package
 	if strings.HasPrefix(name, "@") {
 		parts := strings.Split(name[1:], "/")
 		if len(parts) == 2 {
@@ -111,23 +364,27 @@ func getNameFromPackageJSON() string {
 
 func detectServerName() string {
 	// Try to get from git remote
-	repoURL := detectRepoURL()
+	repoURL := gitRemoteURL()
 	if repoURL != "" {
-		// Extract owner/repo from GitHub URL
-		if strings.Contains(repoURL, "github.com") {
-			parts := strings.Split(repoURL, "/")
-			if len(parts) >= 5 {
-				owner := parts[3]
-				repo := strings.TrimSuffix(parts[4], ".git")
-				return fmt.Sprintf("io.github.%s/%s", owner, repo)
+		// Extract owner/repo from GitHub or GitLab URL
+		for host, namespace := range map[string]string{"github.com": "io.github", "gitlab.com": "io.gitlab"} {
+			if strings.Contains(repoURL, host) {
+				parts := strings.Split(repoURL, "/")
+				if len(parts) >= 5 {
+					owner := parts[3]
+					repo := strings.TrimSuffix(parts[4], ".git")
+					return fmt.Sprintf("%s.%s/%s", namespace, owner, repo)
+				}
 			}
 		}
 	}
 
 	// Try to get from package.json
-	name := getNameFromPackageJSON()
-	if name != "" {
-		return name
+	if _, err := os.Stat("package.json"); err == nil {
+		name := getNameFromPackageJSON()
+		if name != "" {
+			return name
+		}
 	}
 
 	// Use current directory name as fallback
@@ -138,53 +395,57 @@ func detectServerName() string {
 	return "com.example/my-mcp-server"
 }
 
-func detectDescription() string {
-	// Try to get from package.json
-	if data, err := os.ReadFile("package.json"); err == nil {
-		var pkg map[string]any
-		if json.Unmarshal(data, &pkg) == nil {
-			if desc, ok := pkg["description"].(string); ok && desc != "" {
-				return desc
-			}
-		}
-	}
-
-	return "An MCP server that provides [describe what your server does]"
-}
-
-func detectRepoURL() string {
-	// Try git remote
+// gitRemoteURL returns the URL of the git remote origin in HTTPS form, or "" if there is none
+func gitRemoteURL() string {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", "origin")
-	if output, err := cmd.Output(); err == nil {
-		url := strings.TrimSpace(string(output))
-		// Convert SSH URL to HTTPS if needed
-		if strings.HasPrefix(url, "git@github.com:") {
-			url = strings.Replace(url, "git@github.com:", "https://github.com/", 1)
-		}
-		url = strings.TrimSuffix(url, ".git")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return normalizeRepoURL(strings.TrimSpace(string(output)))
+}
+
+// detectRepoURL returns the git remote origin, or else the repository in the project's metadata
+func detectRepoURL(project projectInfo) string {
+	if url := gitRemoteURL(); url != "" {
 		return url
 	}
+	return normalizeRepoURL(project.RepoURL)
+}
 
-	// Try package.json repository field
-	if data, err := os.ReadFile("package.json"); err == nil {
-		var pkg map[string]any
-		if json.Unmarshal(data, &pkg) == nil {
-			if repo, ok := pkg["repository"].(map[string]any); ok {
-				if url, ok := repo["url"].(string); ok {
-					return strings.TrimSuffix(url, ".git")
-				}
-			}
-			if repo, ok := pkg["repository"].(string); ok {
-				return strings.TrimSuffix(repo, ".git")
-			}
+// normalizeRepoURL converts the repository URLs found in git remotes and package metadata, such as
+// git@github.com:owner/repo.git or git+https://github.com/owner/repo.git, to browsable HTTPS URLs
+func normalizeRepoURL(url string) string {
+	url = strings.TrimPrefix(url, "git+")
+	for _, host := range []string{"github.com", "gitlab.com"} {
+		if strings.HasPrefix(url, "git@"+host+":") {
+			url = strings.Replace(url, "git@"+host+":", "https://"+host+"/", 1)
+		}
+		if strings.HasPrefix(url, "ssh://git@"+host+"/") {
+			url = strings.Replace(url, "ssh://git@"+host+"/", "https://"+host+"/", 1)
 		}
 	}
+	return strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+}
 
-	return "https://github.com/YOUR_USERNAME/YOUR_REPO"
+// repositorySource returns the repository source of a GitHub or GitLab repository URL, or "" for
+// other URLs, which the registry does not accept
+func repositorySource(url string) string {
+	for source, pattern := range map[string]*regexp.Regexp{"github": githubRepoPattern, "gitlab": gitlabRepoPattern} {
+		if pattern.MatchString(normalizeRepoURL(url)) {
+			return source
+		}
+	}
+	return ""
 }
 
+var (
+	githubRepoPattern = regexp.MustCompile(`^https?://(www\.)?github\.com/[\w.-]+/[\w.-]+$`)
+	gitlabRepoPattern = regexp.MustCompile(`^https?://(www\.)?gitlab\.com/[\w.-]+/[\w.-]+$`)
+)
+
 func detectPackageType() string {
 	// Check for package.json
 	if _, err := os.Stat("package.json"); err == nil {
@@ -212,12 +473,9 @@ func detectPackageIdentifier(serverName string, packageType string) string {
 	switch packageType {
 	case model.RegistryTypeNPM:
 		// Try to get from package.json
-		if data, err := os.ReadFile("package.json"); err == nil {
-			var pkg map[string]any
-			if json.Unmarshal(data, &pkg) == nil {
-				if name, ok := pkg["name"].(string); ok && name != "" {
-					return name
-				}
+		if _, err := os.Stat("package.json"); err == nil {
+			if name := detectProject().PackageName; name != "" {
+				return name
 			}
 		}
 		// Convert server name to npm package name
@@ -231,20 +489,10 @@ func detectPackageIdentifier(serverName string, packageType string) string {
 		return "@your-org/your-package"
 
 	case model.RegistryTypePyPI:
-		// Try to get from pyproject.toml or setup.py
-		if data, err := os.ReadFile("pyproject.toml"); err == nil {
-			// Simple extraction - could be improved with proper TOML parser
-			lines := strings.Split(string(data), "\n")
-			for _, line := range lines {
-				if strings.HasPrefix(line, "name") && strings.Contains(line, "=") {
-					parts := strings.Split(line, "=")
-					if len(parts) >= 2 {
-						name := strings.Trim(parts[1], " \"'")
-						if name != "" {
-							return name
-						}
-					}
-				}
+		// Try to get from pyproject.toml
+		if _, err := os.Stat("pyproject.toml"); err == nil {
+			if name := detectProject().PackageName; name != "" {
+				return name
 			}
 		}
 		return "your-package"
@@ -0,0 +1,154 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// runInit runs 'mcp-publisher init' in a new directory holding files, answering its questions with input,
+// and returns the server.json it wrote
+func runInit(t *testing.T, files map[string]string, input string, args ...string) (*apiv0.ServerJSON, error) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current dir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(originalDir) })
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	inputPath := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(inputPath, []byte(input), 0600); err != nil {
+		t.Fatalf("Failed to write input: %v", err)
+	}
+	stdin, err := os.Open(inputPath)
+	if err != nil {
+		t.Fatalf("Failed to open input: %v", err)
+	}
+	defer stdin.Close()
+	originalStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = originalStdin }()
+
+	if err := commands.InitCommand(args); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile("server.json")
+	if err != nil {
+		t.Fatalf("Failed to read server.json: %v", err)
+	}
+	var server apiv0.ServerJSON
+	if err := json.Unmarshal(data, &server); err != nil {
+		t.Fatalf("Failed to parse server.json: %v", err)
+	}
+	return &server, nil
+}
+
+func TestInitCommand_PackageJSON(t *testing.T) {
+	packageJSON := `{
+		"name": "@acme/weather",
+		"description": "Weather forecasts for anywhere",
+		"version": "2.1.0",
+		"repository": {"type": "git", "url": "git+https://github.com/acme/weather.git"}
+	}`
+
+	// Accept every default, after an unknown package type is rejected
+	server, err := runInit(t, map[string]string{"package.json": packageJSON}, "\n\nrubygems\n\n\n\n")
+	if err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	if server.Schema != model.CurrentSchemaURL {
+		t.Errorf("Expected schema %s, got %s", model.CurrentSchemaURL, server.Schema)
+	}
+	if server.Name != "io.github.acme/weather" || server.Description != "Weather forecasts for anywhere" || server.Version != "2.1.0" {
+		t.Errorf("Unexpected server details: %s %q %s", server.Name, server.Description, server.Version)
+	}
+	if server.Repository.URL != "https://github.com/acme/weather" || server.Repository.Source != "github" {
+		t.Errorf("Unexpected repository: %+v", server.Repository)
+	}
+	if len(server.Packages) != 1 {
+		t.Fatalf("Expected one package, got %d", len(server.Packages))
+	}
+	pkg := server.Packages[0]
+	if pkg.RegistryType != model.RegistryTypeNPM || pkg.Identifier != "@acme/weather" || pkg.Version != "2.1.0" {
+		t.Errorf("Unexpected package: %+v", pkg)
+	}
+	if len(pkg.EnvironmentVariables) != 0 {
+		t.Errorf("Expected no placeholder environment variables, got %+v", pkg.EnvironmentVariables)
+	}
+}
+
+func TestInitCommand_Pyproject(t *testing.T) {
+	pyproject := `
+[project]
+name = "weather-mcp"
+description = "Weather forecasts for anywhere"
+version = "0.3.0"
+
+[project.urls]
+Homepage = "https://weather.example.com"
+"Source Code" = "https://gitlab.com/acme/weather"
+`
+
+	// Answer the first question, then let the input end so the remaining defaults are taken
+	server, err := runInit(t, map[string]string{"pyproject.toml": pyproject}, "com.acme/weather\n")
+	if err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	if server.Name != "com.acme/weather" || server.Version != "0.3.0" {
+		t.Errorf("Unexpected server details: %s %s", server.Name, server.Version)
+	}
+	if server.Repository.URL != "https://gitlab.com/acme/weather" || server.Repository.Source != "gitlab" {
+		t.Errorf("Unexpected repository: %+v", server.Repository)
+	}
+	pkg := server.Packages[0]
+	if pkg.RegistryType != model.RegistryTypePyPI || pkg.Identifier != "weather-mcp" || pkg.Version != "0.3.0" {
+		t.Errorf("Unexpected package: %+v", pkg)
+	}
+}
+
+func TestInitCommand_RequiresAnswers(t *testing.T) {
+	// Nothing is detected for the description, so it cannot be left to a default
+	_, err := runInit(t, nil, "com.acme/weather\n")
+	if err == nil || !strings.Contains(err.Error(), "description") || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("Expected an error about the missing description, got %v", err)
+	}
+	if _, statErr := os.Stat("server.json"); !os.IsNotExist(statErr) {
+		t.Errorf("Expected no server.json to be written")
+	}
+}
+
+func TestInitCommand_Yes(t *testing.T) {
+	server, err := runInit(t, nil, "", "--yes")
+	if err != nil {
+		t.Fatalf("init failed: %v", err)
+	}
+
+	if !strings.HasPrefix(server.Name, "com.example/") || server.Version != "1.0.0" {
+		t.Errorf("Unexpected server details: %s %s", server.Name, server.Version)
+	}
+	if !strings.Contains(server.Description, "[describe what your server does]") {
+		t.Errorf("Expected a placeholder description, got %q", server.Description)
+	}
+	if len(server.Packages) != 1 || len(server.Packages[0].EnvironmentVariables) != 1 {
+		t.Errorf("Expected the template package with an example environment variable, got %+v", server.Packages)
+	}
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// KeychainService is the service the registry tokens of saved logins are stored under in the OS
+// keychain: the macOS Keychain, Windows Credential Manager, or the Secret Service on Linux
+const KeychainService = "mcp-publisher"
+
+// keychainUsers returns the keychain accounts of a profile's registry token and refresh token. They
+// are stored apart because Windows Credential Manager limits the size of each secret.
+func keychainUsers(profile string) (string, string) {
+	return profile, profile + "/refresh"
+}
+
+// writeKeychainTokens stores the tokens of a profile's login in the OS keychain
+func writeKeychainTokens(profile, token, refreshToken string) error {
+	tokenUser, refreshUser := keychainUsers(profile)
+	if err := keyring.Set(KeychainService, tokenUser, token); err != nil {
+		return fmt.Errorf("failed to store token in the OS keychain: %w. Run 'mcp-publisher login' with --insecure-token-file to store it in a file instead", err)
+	}
+
+	if refreshToken == "" {
+		if err := keyring.Delete(KeychainService, refreshUser); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+			return fmt.Errorf("failed to remove refresh token from the OS keychain: %w", err)
+		}
+		return nil
+	}
+	if err := keyring.Set(KeychainService, refreshUser, refreshToken); err != nil {
+		return fmt.Errorf("failed to store refresh token in the OS keychain: %w", err)
+	}
+	return nil
+}
+
+// readKeychainTokens reads the tokens of a profile's login from the OS keychain
+func readKeychainTokens(profile string) (string, string, error) {
+	tokenUser, refreshUser := keychainUsers(profile)
+	token, err := keyring.Get(KeychainService, tokenUser)
+	if err != nil {
+		if errors.Is(err, keyring.ErrNotFound) {
+			return "", "", errors.New("saved token not found in the OS keychain. Run 'mcp-publisher login <method>' again")
+		}
+		return "", "", fmt.Errorf("failed to read token from the OS keychain: %w", err)
+	}
+
+	refreshToken, err := keyring.Get(KeychainService, refreshUser)
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return "", "", fmt.Errorf("failed to read refresh token from the OS keychain: %w", err)
+	}
+	return token, refreshToken, nil
+}
+
+// deleteKeychainTokens removes the tokens of a profile's login from the OS keychain, if there are any
+func deleteKeychainTokens(profile string) error {
+	tokenUser, refreshUser := keychainUsers(profile)
+	for _, user := range []string{tokenUser, refreshUser} {
+		if err := keyring.Delete(KeychainService, user); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+			return fmt.Errorf("failed to remove token from the OS keychain: %w", err)
+		}
+	}
+	return nil
+}
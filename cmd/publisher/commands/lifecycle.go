@@ -0,0 +1,267 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Errors of requests to the registry that callers describe in their own terms
+var (
+	errServerNotFound   = errors.New("server not found")
+	errPermissionDenied = errors.New("permission denied")
+)
+
+// DeleteCommand marks versions of a server as deleted, which hides them from clients for good
+func DeleteCommand(args []string) error {
+	return changeStatusCommand("delete", model.StatusDeleted, args)
+}
+
+// DeprecateCommand marks versions of a server as deprecated, or active again with --undo
+func DeprecateCommand(args []string) error {
+	return changeStatusCommand("deprecate", model.StatusDeprecated, args)
+}
+
+// changeStatusCommand sets the status of one version of a server, or of all its versions, after
+// asking for confirmation
+func changeStatusCommand(command string, status model.Status, args []string) error {
+	statusFlags := flag.NewFlagSet(command, flag.ExitOnError)
+	version := statusFlags.String("version", "", "Version to "+command+" (default: every version)")
+	yes := statusFlags.Bool("yes", false, "Do not ask for confirmation")
+	statusFlags.BoolVar(yes, "y", false, "Do not ask for confirmation (shorthand)")
+	profile := addProfileFlag(statusFlags)
+	addCACertFlag(statusFlags)
+	addDebugFlag(statusFlags)
+	addOutputFlag(statusFlags)
+	addNonInteractiveFlag(statusFlags)
+	var undo *bool
+	if status == model.StatusDeprecated {
+		undo = statusFlags.Bool("undo", false, "Make deprecated versions active again")
+	}
+	statusFlags.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage: mcp-publisher %s <server-name> [--version <version>] [--yes] [--profile <name>]\n", command)
+		statusFlags.PrintDefaults()
+	}
+
+	// The server name comes first, but may also follow the flags
+	var serverName string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		serverName = args[0]
+		args = args[1:]
+	}
+	if err := parseFlags(statusFlags, args); err != nil {
+		return err
+	}
+	if serverName == "" && statusFlags.NArg() == 1 {
+		serverName = statusFlags.Arg(0)
+	} else if serverName == "" || statusFlags.NArg() > 0 {
+		statusFlags.Usage()
+		return withExitCode(ExitUsage, errors.New("server name required"))
+	}
+
+	verb := command
+	if undo != nil && *undo {
+		status, verb = model.StatusActive, "undeprecate"
+	}
+
+	token, registryURL, err := loadPublishToken(*profile)
+	if err != nil {
+		return err
+	}
+	client := &lifecycleClient{registryURL: strings.TrimSuffix(registryURL, "/"), token: token, profile: *profile}
+
+	versions, err := client.serverVersions(serverName, *version)
+	if err != nil {
+		return err
+	}
+	var targets []apiv0.ServerResponse
+	skipped := []string{}
+	for _, server := range versions {
+		current := model.StatusActive
+		if server.Meta.Official != nil {
+			current = server.Meta.Official.Status
+		}
+		// Deleted versions stay deleted, and versions already in the status need no change
+		if current == status || current == model.StatusDeleted || (status == model.StatusActive && current != model.StatusDeprecated) {
+			skipped = append(skipped, fmt.Sprintf("%s (%s)", server.Server.Version, current))
+			continue
+		}
+		targets = append(targets, server)
+	}
+	result := statusChangeResult{Name: serverName, Status: status, Changed: []string{}, Skipped: skipped}
+	if len(targets) == 0 {
+		_, _ = fmt.Fprintf(logOutput(), "Nothing to %s: %s\n", verb, strings.Join(skipped, ", "))
+		return printResult(result)
+	}
+
+	targetVersions := make([]string, len(targets))
+	for i, server := range targets {
+		targetVersions[i] = server.Server.Version
+	}
+	_, _ = fmt.Fprintf(logOutput(), "This will %s %d version(s) of %s on %s: %s\n", verb, len(targets), serverName, registryURL, strings.Join(targetVersions, ", "))
+	if status == model.StatusDeleted {
+		_, _ = fmt.Fprintln(logOutput(), "Deleted versions are hidden from clients and cannot be restored or published again.")
+	}
+	if !*yes && nonInteractive {
+		return withExitCode(ExitUsage, fmt.Errorf("confirmation required: pass --yes to %s with --non-interactive", verb))
+	}
+	if !*yes {
+		confirmed, err := confirm(bufio.NewReader(os.Stdin), logOutput(), "Continue?")
+		if err != nil {
+			return fmt.Errorf("%w (pass --yes to %s without asking)", err, verb)
+		}
+		if !confirmed {
+			return errors.New("cancelled")
+		}
+	}
+
+	for _, server := range targets {
+		if err := client.setStatus(server.Server, status); err != nil {
+			return fmt.Errorf("failed to %s %s %s: %w", verb, serverName, server.Server.Version, err)
+		}
+		_, _ = fmt.Fprintf(logOutput(), "✓ %s %s is now %s\n", serverName, server.Server.Version, status)
+		result.Changed = append(result.Changed, server.Server.Version)
+	}
+	return printResult(result)
+}
+
+// statusChangeResult is the JSON result of 'mcp-publisher deprecate' and 'mcp-publisher delete'
+type statusChangeResult struct {
+	Name   string       `json:"name"`
+	Status model.Status `json:"status"`
+	// Changed are the versions now in the status, and Skipped the versions left as they were, with their status
+	Changed []string `json:"changed"`
+	Skipped []string `json:"skipped"`
+}
+
+// confirm asks a yes/no question, where anything but yes is no. It fails if the input ends before an answer.
+func confirm(in *bufio.Reader, out io.Writer, question string) (bool, error) {
+	_, _ = fmt.Fprintf(out, "%s [y/N]: ", question)
+	line, err := in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		_, _ = fmt.Fprintln(out)
+		return false, errors.New("confirmation required")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+// lifecycleClient changes the status of a server's versions with the edit endpoint, renewing the saved
+// login once if the registry rejects its token
+type lifecycleClient struct {
+	registryURL string
+	token       string
+	profile     string
+	renewed     bool
+}
+
+// serverVersions returns the given version of a server, or all its versions
+func (c *lifecycleClient) serverVersions(serverName, version string) ([]apiv0.ServerResponse, error) {
+	path := "/v0/servers/" + url.PathEscape(serverName) + "/versions"
+	if version != "" {
+		path += "/" + url.PathEscape(version)
+	}
+	body, err := c.do(http.MethodGet, path, nil)
+	if err != nil {
+		if errors.Is(err, errServerNotFound) {
+			if version != "" {
+				return nil, fmt.Errorf("%s version %s not found", serverName, version)
+			}
+			return nil, fmt.Errorf("%s not found", serverName)
+		}
+		return nil, err
+	}
+
+	if version != "" {
+		var server apiv0.ServerResponse
+		if err := json.Unmarshal(body, &server); err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+		return []apiv0.ServerResponse{server}, nil
+	}
+	var list apiv0.ServerListResponse
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+	return list.Servers, nil
+}
+
+// setStatus changes the status of a server version, leaving the rest of it as it is
+func (c *lifecycleClient) setStatus(server apiv0.ServerJSON, status model.Status) error {
+	jsonData, err := json.Marshal(server)
+	if err != nil {
+		return fmt.Errorf("error serializing request: %w", err)
+	}
+
+	path := "/v0/servers/" + url.PathEscape(server.Name) + "/versions/" + url.PathEscape(server.Version) + "?status=" + string(status)
+	_, err = c.do(http.MethodPut, path, jsonData)
+	if errors.Is(err, errPermissionDenied) {
+		action := "edit"
+		if status == model.StatusDeleted {
+			action = "delete"
+		}
+		return fmt.Errorf("your login does not have %s permission for this server. Check with 'mcp-publisher status': %w", action, err)
+	}
+	return err
+}
+
+// do sends a request to the registry and returns the response body
+func (c *lifecycleClient) do(method, path string, body []byte) ([]byte, error) {
+	for {
+		req, err := http.NewRequestWithContext(context.Background(), method, c.registryURL+path, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errRegistryUnreachable, err)
+		}
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading response: %w", err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return respBody, nil
+		case http.StatusNotFound:
+			return nil, errServerNotFound
+		case http.StatusForbidden:
+			return nil, fmt.Errorf("%w: %s", errPermissionDenied, respBody)
+		case http.StatusUnauthorized:
+			// The saved token expired early or was revoked: renew the login once and retry
+			if c.renewed || os.Getenv(APIKeyEnvVar) != "" {
+				return nil, errTokenRejected
+			}
+			c.renewed = true
+			if c.token, err = renewSavedToken(c.profile); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return nil, registryStatusError(resp.StatusCode, respBody)
+	}
+}
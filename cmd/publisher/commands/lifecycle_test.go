@@ -0,0 +1,147 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// withStdin runs fn with input as stdin
+func withStdin(t *testing.T, input string, fn func() error) error {
+	t.Helper()
+	inputPath := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(inputPath, []byte(input), 0600); err != nil {
+		t.Fatalf("Failed to write input: %v", err)
+	}
+	stdin, err := os.Open(inputPath)
+	if err != nil {
+		t.Fatalf("Failed to open input: %v", err)
+	}
+	defer stdin.Close()
+	originalStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = originalStdin }()
+
+	return fn()
+}
+
+func TestLifecycleCommands(t *testing.T) {
+	const serverName = "io.github.octocat/weather"
+	var mu sync.Mutex
+	statuses := map[string]model.Status{}
+	reset := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses = map[string]model.Status{"1.0.0": model.StatusActive, "1.1.0": model.StatusDeprecated, "0.9.0": model.StatusDeleted}
+	}
+	response := func(version string) apiv0.ServerResponse {
+		return apiv0.ServerResponse{
+			Server: apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: serverName, Description: "Weather", Version: version},
+			Meta:   apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{Status: statuses[version]}},
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		prefix := "/v0/servers/" + serverName + "/versions"
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == prefix:
+			list := apiv0.ServerListResponse{}
+			for _, version := range []string{"0.9.0", "1.0.0", "1.1.0"} {
+				list.Servers = append(list.Servers, response(version))
+			}
+			_ = json.NewEncoder(w).Encode(list)
+		case strings.HasPrefix(r.URL.Path, prefix+"/"):
+			version := strings.TrimPrefix(r.URL.Path, prefix+"/")
+			if _, ok := statuses[version]; !ok {
+				http.NotFound(w, r)
+				return
+			}
+			if r.Method == http.MethodPut {
+				if r.Header.Get("Authorization") != "Bearer mcpr_test" {
+					http.Error(w, `{"title":"Unauthorized"}`, http.StatusUnauthorized)
+					return
+				}
+				var body apiv0.ServerJSON
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Version != version {
+					http.Error(w, `{"title":"Bad Request"}`, http.StatusBadRequest)
+					return
+				}
+				statuses[version] = model.Status(r.URL.Query().Get("status"))
+			}
+			_ = json.NewEncoder(w).Encode(response(version))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+
+	t.Run("deprecate changes every active version once confirmed", func(t *testing.T) {
+		reset()
+		if err := withStdin(t, "y\n", func() error { return commands.DeprecateCommand([]string{serverName}) }); err != nil {
+			t.Fatalf("deprecate failed: %v", err)
+		}
+		expected := map[string]model.Status{"0.9.0": model.StatusDeleted, "1.0.0": model.StatusDeprecated, "1.1.0": model.StatusDeprecated}
+		for version, status := range expected {
+			if statuses[version] != status {
+				t.Errorf("Expected %s to be %s, got %s", version, status, statuses[version])
+			}
+		}
+	})
+
+	t.Run("deprecate --undo makes deprecated versions active", func(t *testing.T) {
+		reset()
+		if err := commands.DeprecateCommand([]string{serverName, "--undo", "--yes"}); err != nil {
+			t.Fatalf("deprecate --undo failed: %v", err)
+		}
+		if statuses["1.1.0"] != model.StatusActive || statuses["0.9.0"] != model.StatusDeleted {
+			t.Errorf("Unexpected statuses: %v", statuses)
+		}
+	})
+
+	t.Run("delete of one version with --yes", func(t *testing.T) {
+		reset()
+		if err := commands.DeleteCommand([]string{"--version", "1.0.0", "-y", serverName}); err != nil {
+			t.Fatalf("delete failed: %v", err)
+		}
+		if statuses["1.0.0"] != model.StatusDeleted || statuses["1.1.0"] != model.StatusDeprecated {
+			t.Errorf("Unexpected statuses: %v", statuses)
+		}
+	})
+
+	t.Run("nothing changes without confirmation", func(t *testing.T) {
+		reset()
+		err := withStdin(t, "n\n", func() error { return commands.DeleteCommand([]string{serverName}) })
+		if err == nil || !strings.Contains(err.Error(), "cancelled") {
+			t.Errorf("Expected the delete to be cancelled, got: %v", err)
+		}
+		err = withStdin(t, "", func() error { return commands.DeleteCommand([]string{serverName}) })
+		if err == nil || !strings.Contains(err.Error(), "--yes") {
+			t.Errorf("Expected an error asking for --yes, got: %v", err)
+		}
+		if statuses["1.0.0"] != model.StatusActive {
+			t.Errorf("Expected 1.0.0 to stay active, got %s", statuses["1.0.0"])
+		}
+	})
+
+	t.Run("unknown versions are reported", func(t *testing.T) {
+		err := commands.DeleteCommand([]string{serverName, "--version", "2.0.0", "--yes"})
+		if err == nil || !strings.Contains(err.Error(), "version 2.0.0 not found") {
+			t.Errorf("Expected a not found error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListCommand lists the servers the login can publish, with their latest version, status and when
+// they were last updated
+func ListCommand(args []string) error {
+	listFlags := flag.NewFlagSet("list", flag.ExitOnError)
+	profile := addProfileFlag(listFlags)
+	addCACertFlag(listFlags)
+	addDebugFlag(listFlags)
+	addOutputFlag(listFlags)
+	jsonFlag := listFlags.Bool("json", false, "Print the servers as JSON (shorthand for --output json)")
+	if err := parseFlags(listFlags, args); err != nil {
+		return err
+	}
+	jsonOutput = jsonOutput || *jsonFlag
+
+	token, registryURL, err := loadPublishToken(*profile)
+	if err != nil {
+		return err
+	}
+
+	servers, err := listPublisherServers(registryURL, token)
+	if errors.Is(err, errTokenRejected) && os.Getenv(APIKeyEnvVar) == "" {
+		// The saved token expired early or was revoked: renew the login once and retry
+		if token, err = renewSavedToken(*profile); err == nil {
+			servers, err = listPublisherServers(registryURL, token)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		return printResult(servers)
+	}
+
+	if len(servers) == 0 {
+		_, _ = fmt.Fprintf(os.Stdout, "No servers published on %s under your namespaces\n", registryURL)
+		return nil
+	}
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "NAME\tLATEST\tSTATUS\tUPDATED")
+	for _, server := range servers {
+		status, updated := "", ""
+		if official := server.Meta.Official; official != nil {
+			status = string(official.Status)
+			updated = official.UpdatedAt.Local().Format(time.DateTime)
+		}
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", server.Server.Name, server.Server.Version, status, updated)
+	}
+	return table.Flush()
+}
+
+// listPublisherServers fetches every page of the servers the token can publish
+func listPublisherServers(registryURL, token string) ([]apiv0.ServerResponse, error) {
+	servers := []apiv0.ServerResponse{}
+	cursor := ""
+	for {
+		listURL := strings.TrimSuffix(registryURL, "/") + "/v0/publisher/servers?limit=100"
+		if cursor != "" {
+			listURL += "&cursor=" + url.QueryEscape(cursor)
+		}
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, listURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errRegistryUnreachable, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading response: %w", err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+		case http.StatusUnauthorized:
+			return nil, errTokenRejected
+		default:
+			return nil, registryStatusError(resp.StatusCode, body)
+		}
+
+		var page apiv0.ServerListResponse
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("error parsing response: %w", err)
+		}
+		servers = append(servers, page.Servers...)
+		if page.Metadata.NextCursor == "" || len(page.Servers) == 0 {
+			return servers, nil
+		}
+		cursor = page.Metadata.NextCursor
+	}
+}
@@ -0,0 +1,72 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestListCommand(t *testing.T) {
+	serverResponse := func(name, version string, status model.Status) apiv0.ServerResponse {
+		return apiv0.ServerResponse{
+			Server: apiv0.ServerJSON{Name: name, Version: version},
+			Meta: apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{
+				Status:    status,
+				UpdatedAt: time.Date(2025, 10, 17, 12, 0, 0, 0, time.UTC),
+				IsLatest:  true,
+			}},
+		}
+	}
+	pages := map[string]apiv0.ServerListResponse{
+		"": {
+			Servers:  []apiv0.ServerResponse{serverResponse("io.github.octocat/search", "1.2.0", model.StatusActive)},
+			Metadata: apiv0.Metadata{NextCursor: "io.github.octocat/search:1.2.0", Count: 1},
+		},
+		"io.github.octocat/search:1.2.0": {
+			Servers:  []apiv0.ServerResponse{serverResponse("io.github.octocat/weather", "0.3.0", model.StatusDeprecated)},
+			Metadata: apiv0.Metadata{Count: 1},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/publisher/servers" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer mcpr_test" {
+			http.Error(w, `{"title":"Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(pages[r.URL.Query().Get("cursor")])
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+
+	output, err := captureStdout(t, func() error { return commands.ListCommand([]string{}) })
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "NAME") {
+		t.Fatalf("Expected a header and a row for each page's server, got:\n%s", output)
+	}
+	for i, expected := range [][]string{{"io.github.octocat/search", "1.2.0", "active"}, {"io.github.octocat/weather", "0.3.0", "deprecated"}} {
+		if fields := strings.Fields(lines[i+1]); len(fields) < 3 || fields[0] != expected[0] || fields[1] != expected[1] || fields[2] != expected[2] {
+			t.Errorf("Expected row %v, got %q", expected, lines[i+1])
+		}
+	}
+
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_revoked")
+	if _, err := captureStdout(t, func() error { return commands.ListCommand([]string{}) }); err == nil {
+		t.Errorf("Expected a rejected API token to fail")
+	}
+}
@@ -2,12 +2,11 @@ package commands
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
+	"strings"
 
 	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
 )
@@ -15,6 +14,21 @@ import (
 const (
 	DefaultRegistryURL = "https://registry.modelcontextprotocol.io"
 	TokenFileName      = ".mcp_publisher_token" //nolint:gosec // Not a credential, just a filename
+
+	// APIKeyEnvVar holds an API token to publish with instead of a saved login, for CI
+	APIKeyEnvVar = "MCP_PUBLISHER_API_KEY" //nolint:gosec // Not a credential, just a variable name
+	// RegistryURLEnvVar overrides the registry to publish to with an API token
+	RegistryURLEnvVar = "MCP_PUBLISHER_REGISTRY_URL"
+	// PrivateKeyEnvVar holds the private key for dns and http logins, as hex or PEM, when no flag gives it
+	PrivateKeyEnvVar = "MCP_PUBLISHER_PRIVATE_KEY" //nolint:gosec // Not a credential, just a variable name
+	// SigningKeyEnvVar holds the private key to sign server.json with when publishing, as hex or PEM,
+	// when --signing-key does not give it
+	SigningKeyEnvVar = "MCP_PUBLISHER_SIGNING_KEY" //nolint:gosec // Not a credential, just a variable name
+
+	// DefaultProfile is the saved login commands use unless another is chosen with --profile
+	DefaultProfile = "default"
+	// ProfileEnvVar chooses the saved login to use when --profile is not given
+	ProfileEnvVar = "MCP_PUBLISHER_PROFILE"
 )
 
 type CryptoAlgorithm auth.CryptoAlgorithm
@@ -32,9 +46,31 @@ func (c *CryptoAlgorithm) Set(v string) error {
 	return fmt.Errorf("invalid algorithm: %q (allowed: ed25519, ecdsap384)", v)
 }
 
+// loginMethods are the ways 'mcp-publisher login' can authenticate, with what each is for
+var loginMethods = []struct {
+	Name    string
+	Summary string
+}{
+	{"github", "Interactive GitHub authentication"},
+	{"gitlab", "Interactive GitLab authentication"},
+	{"browser", "Log in in your browser with the registry's identity provider"},
+	{"github-oidc", "GitHub Actions OIDC authentication"},
+	{"gitlab-oidc", "GitLab CI OIDC authentication"},
+	{"device", "Headless login, approved with 'mcp-publisher approve' from another machine"},
+	{"dns", "DNS-based authentication (requires --domain and --private-key-file)"},
+	{"http", "HTTP-based authentication (requires --domain and --private-key-file)"},
+	{"mtls", "Mutual TLS client certificate authentication (requires --cert and --key)"},
+	{"none", "Anonymous authentication (for testing)"},
+}
+
 func LoginCommand(args []string) error {
 	if len(args) < 1 {
-		return errors.New("authentication method required\n\nUsage: mcp-publisher login <method>\n\nMethods:\n  github        Interactive GitHub authentication\n  github-oidc   GitHub Actions OIDC authentication\n  dns           DNS-based authentication (requires --domain and --private-key)\n  http          HTTP-based authentication (requires --domain and --private-key)\n  none          Anonymous authentication (for testing)")
+		var usage strings.Builder
+		usage.WriteString("authentication method required\n\nUsage: mcp-publisher login <method>\n\nMethods:")
+		for _, method := range loginMethods {
+			fmt.Fprintf(&usage, "\n  %-13s %s", method.Name, method.Summary)
+		}
+		return withExitCode(ExitUsage, errors.New(usage.String()))
 	}
 
 	method := args[0]
@@ -42,39 +78,84 @@ func LoginCommand(args []string) error {
 	// Parse remaining flags based on method
 	loginFlags := flag.NewFlagSet("login", flag.ExitOnError)
 	var domain string
-	var privateKey string
+	var privateKey, privateKeyFile string
 	var cryptoAlgorithm = CryptoAlgorithm(auth.AlgorithmEd25519)
 	var registryURL string
+	var certFile, keyFile, caFile string
+	var callbackPort int
 
-	loginFlags.StringVar(&registryURL, "registry", DefaultRegistryURL, "Registry URL")
+	loginFlags.StringVar(&registryURL, "registry", defaultRegistryURL(), "Registry URL")
+	profile := addProfileFlag(loginFlags)
+	addCACertFlag(loginFlags)
+	addDebugFlag(loginFlags)
+	insecureTokenFile := loginFlags.Bool("insecure-token-file", false, "Store the token in a plaintext file instead of the OS keychain")
+	addOutputFlag(loginFlags)
+	addNonInteractiveFlag(loginFlags)
 
 	if method == "dns" || method == "http" {
 		loginFlags.StringVar(&domain, "domain", "", "Domain name")
-		loginFlags.StringVar(&privateKey, "private-key", "", "Private key (hex)")
+		loginFlags.StringVar(&privateKey, "private-key", "", "Private key, as hex or PEM. Prefer --private-key-file or $"+PrivateKeyEnvVar+", which stay out of shell history")
+		loginFlags.StringVar(&privateKeyFile, "private-key-file", "", "File holding the private key, as hex or PEM")
 		loginFlags.Var(&cryptoAlgorithm, "algorithm", "Cryptographic algorithm (ed25519, ecdsap384)")
 	}
 
-	if err := loginFlags.Parse(args[1:]); err != nil {
+	if method == "mtls" {
+		loginFlags.StringVar(&certFile, "cert", "", "Client certificate file (PEM)")
+		loginFlags.StringVar(&keyFile, "key", "", "Client private key file (PEM)")
+		loginFlags.StringVar(&caFile, "ca", "", "CA bundle to trust for the registry's certificate (PEM)")
+	}
+
+	if method == "browser" {
+		loginFlags.IntVar(&callbackPort, "port", 0, "Localhost port to receive the login on, for identity providers that need a fixed redirect URI (default: any free port)")
+	}
+
+	if err := parseFlags(loginFlags, args[1:]); err != nil {
+		return err
+	}
+	if _, err := savedLoginPath(*profile); err != nil {
 		return err
 	}
+	if method == "dns" || method == "http" {
+		var err error
+		if privateKey, err = loadPrivateKey(privateKey, privateKeyFile); err != nil {
+			return err
+		}
+	}
+	// These methods wait for someone to authorize the login in a browser
+	if nonInteractive && (method == "github" || method == "gitlab" || method == "browser" || method == "device") {
+		return withExitCode(ExitUsage, fmt.Errorf("login %s needs someone to authorize it in a browser, so cannot be used with --non-interactive. In CI, use github-oidc, gitlab-oidc, dns, http or mtls, or set %s", method, APIKeyEnvVar))
+	}
 
 	// Create auth provider based on method
 	var authProvider auth.Provider
 	switch method {
 	case "github":
 		authProvider = auth.NewGitHubATProvider(true, registryURL)
+	case "gitlab":
+		authProvider = auth.NewGitLabATProvider(true, registryURL)
+	case "browser":
+		authProvider = auth.NewBrowserProvider(registryURL, callbackPort)
 	case "github-oidc":
 		authProvider = auth.NewGitHubOIDCProvider(registryURL)
+	case "gitlab-oidc":
+		authProvider = auth.NewGitLabOIDCProvider(registryURL)
+	case "device":
+		authProvider = auth.NewDeviceProvider(registryURL)
 	case "dns":
 		if domain == "" || privateKey == "" {
-			return errors.New("dns authentication requires --domain and --private-key")
+			return withExitCode(ExitUsage, fmt.Errorf("dns authentication requires --domain and --private-key-file, --private-key or %s", PrivateKeyEnvVar))
 		}
 		authProvider = auth.NewDNSProvider(registryURL, domain, privateKey, auth.CryptoAlgorithm(cryptoAlgorithm))
 	case "http":
 		if domain == "" || privateKey == "" {
-			return errors.New("http authentication requires --domain and --private-key")
+			return withExitCode(ExitUsage, fmt.Errorf("http authentication requires --domain and --private-key-file, --private-key or %s", PrivateKeyEnvVar))
 		}
 		authProvider = auth.NewHTTPProvider(registryURL, domain, privateKey, auth.CryptoAlgorithm(cryptoAlgorithm))
+	case "mtls":
+		if certFile == "" || keyFile == "" {
+			return errors.New("mtls authentication requires --cert and --key")
+		}
+		authProvider = auth.NewMTLSProvider(registryURL, certFile, keyFile, caFile)
 	case "none":
 		authProvider = auth.NewNoneProvider(registryURL)
 	default:
@@ -83,40 +164,71 @@ func LoginCommand(args []string) error {
 
 	// Perform login
 	ctx := context.Background()
-	_, _ = fmt.Fprintf(os.Stdout, "Logging in with %s...\n", method)
+	auth.Output = logOutput()
+	_, _ = fmt.Fprintf(logOutput(), "Logging in with %s...\n", method)
 
 	if err := authProvider.Login(ctx); err != nil {
-		return fmt.Errorf("login failed: %w", err)
+		return withExitCode(ExitAuth, fmt.Errorf("login failed: %w", err))
 	}
 
 	// Get and save token
 	token, err := authProvider.GetToken(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get token: %w", err)
+		return withExitCode(ExitAuth, fmt.Errorf("failed to get token: %w", err))
 	}
 
-	// Save token to file
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+	// Save the login, with its tokens in the OS keychain unless --insecure-token-file is set
+	login := &savedLogin{
+		Token:    token,
+		Method:   method,
+		Registry: registryURL,
+		Keychain: !*insecureTokenFile,
+		profile:  *profile,
 	}
-
-	tokenPath := filepath.Join(homeDir, TokenFileName)
-	tokenData := map[string]string{
-		"token":    token,
-		"method":   method,
-		"registry": registryURL,
+	// Keep the refresh token, if any, so the registry token can be renewed without logging in again
+	if refresher, ok := authProvider.(auth.RefreshTokenProvider); ok {
+		login.RefreshToken = refresher.RefreshToken()
 	}
 
-	jsonData, err := json.Marshal(tokenData)
-	if err != nil {
-		return fmt.Errorf("failed to marshal token data: %w", err)
+	if err := login.save(); err != nil {
+		return err
+	}
+	if *insecureTokenFile {
+		// Tokens of an earlier login in the keychain are no longer used. The keychain may well be
+		// unavailable, which is why the file is used, so failures are ignored.
+		_ = deleteKeychainTokens(*profile)
 	}
 
-	if err := os.WriteFile(tokenPath, jsonData, 0600); err != nil {
-		return fmt.Errorf("failed to save token: %w", err)
+	if *profile != DefaultProfile {
+		_, _ = fmt.Fprintf(logOutput(), "✓ Successfully logged in to %s as profile %s\n", registryURL, *profile)
+	} else {
+		_, _ = fmt.Fprintln(logOutput(), "✓ Successfully logged in")
 	}
+	return printResult(loginResult{Profile: *profile, Registry: registryURL, Method: method, Keychain: login.Keychain})
+}
+
+// loginResult is the JSON result of 'mcp-publisher login'
+type loginResult struct {
+	Profile  string `json:"profile"`
+	Registry string `json:"registry"`
+	Method   string `json:"method"`
+	Keychain bool   `json:"keychain"`
+}
 
-	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully logged in")
-	return nil
+// loadPrivateKey returns the private key for a dns or http login: given with --private-key, read from
+// --private-key-file, or else taken from MCP_PUBLISHER_PRIVATE_KEY
+func loadPrivateKey(privateKey, privateKeyFile string) (string, error) {
+	switch {
+	case privateKey != "" && privateKeyFile != "":
+		return "", withExitCode(ExitUsage, errors.New("use either --private-key or --private-key-file, not both"))
+	case privateKeyFile != "":
+		data, err := os.ReadFile(privateKeyFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read private key: %w", err)
+		}
+		return string(data), nil
+	case privateKey != "":
+		return privateKey, nil
+	}
+	return os.Getenv(PrivateKeyEnvVar), nil
 }
@@ -0,0 +1,353 @@
+package commands_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+)
+
+func TestLoginCommand_TokenStorage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/auth/none" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"registry_token": "registry-token"})
+	}))
+	defer server.Close()
+
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv(commands.ProfileEnvVar, "")
+	tokenPath := filepath.Join(homeDir, commands.TokenFileName)
+	readTokenFile := func(t *testing.T) map[string]any {
+		t.Helper()
+		tokenData, err := os.ReadFile(tokenPath)
+		if err != nil {
+			t.Fatalf("Failed to read token file: %v", err)
+		}
+		var saved map[string]any
+		if err := json.Unmarshal(tokenData, &saved); err != nil {
+			t.Fatalf("Failed to parse token file: %v", err)
+		}
+		return saved
+	}
+
+	t.Run("tokens are stored in the OS keychain", func(t *testing.T) {
+		keyring.MockInit()
+		if err := commands.LoginCommand([]string{"none", "--registry", server.URL}); err != nil {
+			t.Fatalf("Failed to log in: %v", err)
+		}
+
+		saved := readTokenFile(t)
+		if _, ok := saved["token"]; ok || saved["keychain"] != true || saved["registry"] != server.URL {
+			t.Errorf("Expected the token file to only point at the keychain, got: %v", saved)
+		}
+		token, err := keyring.Get(commands.KeychainService, commands.DefaultProfile)
+		if err != nil || token != "registry-token" {
+			t.Errorf("Expected the token in the keychain, got %q: %v", token, err)
+		}
+
+		if err := commands.LogoutCommand([]string{}); err != nil {
+			t.Fatalf("Failed to log out: %v", err)
+		}
+		if _, err := keyring.Get(commands.KeychainService, commands.DefaultProfile); !errors.Is(err, keyring.ErrNotFound) {
+			t.Errorf("Expected logout to remove the token from the keychain, got: %v", err)
+		}
+	})
+
+	t.Run("without a keychain the token file has to be asked for", func(t *testing.T) {
+		keyring.MockInitWithError(errors.New("no secret service available"))
+		err := commands.LoginCommand([]string{"none", "--registry", server.URL})
+		if err == nil || !strings.Contains(err.Error(), "--insecure-token-file") {
+			t.Fatalf("Expected an error suggesting --insecure-token-file, got: %v", err)
+		}
+
+		if err := commands.LoginCommand([]string{"none", "--registry", server.URL, "--insecure-token-file"}); err != nil {
+			t.Fatalf("Failed to log in with a token file: %v", err)
+		}
+		if saved := readTokenFile(t); saved["token"] != "registry-token" {
+			t.Errorf("Expected the token in the token file, got: %v", saved)
+		}
+		if err := commands.LogoutCommand([]string{}); err != nil {
+			t.Fatalf("Failed to log out: %v", err)
+		}
+		if _, err := os.Stat(tokenPath); !os.IsNotExist(err) {
+			t.Errorf("Expected the token file to be removed")
+		}
+	})
+}
+
+func TestLoginCommand_PrivateKey(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %v", err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate ECDSA key: %v", err)
+	}
+
+	// The registry only issues a token for a timestamp signed by the expected key
+	var verify func(message, signature []byte) bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Timestamp       string `json:"timestamp"`
+			SignedTimestamp string `json:"signed_timestamp"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		signature, err := hex.DecodeString(request.SignedTimestamp)
+		if r.URL.Path != "/v0/auth/dns" || err != nil || !verify([]byte(request.Timestamp), signature) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"registry_token": "registry-token"})
+	}))
+	defer server.Close()
+	verifyEd25519 := func(message, signature []byte) bool {
+		return ed25519.Verify(ed25519Key.Public().(ed25519.PublicKey), message, signature)
+	}
+	verifyECDSA := func(message, signature []byte) bool {
+		digest := sha512.Sum384(message)
+		return len(signature) == 96 &&
+			ecdsa.Verify(&ecdsaKey.PublicKey, digest[:], new(big.Int).SetBytes(signature[:48]), new(big.Int).SetBytes(signature[48:]))
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.ProfileEnvVar, "")
+	t.Chdir(t.TempDir())
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(ed25519Key)
+	if err != nil {
+		t.Fatalf("Failed to marshal Ed25519 key: %v", err)
+	}
+	sec1, err := x509.MarshalECPrivateKey(ecdsaKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal ECDSA key: %v", err)
+	}
+	files := map[string][]byte{
+		"ed25519.pem": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+		// As written by 'openssl ecparam -genkey', with the curve's parameters first
+		"ecdsa.pem": append(pem.EncodeToMemory(&pem.Block{Type: "EC PARAMETERS", Bytes: []byte{6, 5, 43, 129, 4, 0, 34}}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1})...),
+		"ed25519.hex": []byte(hex.EncodeToString(ed25519Key.Seed()) + "\n"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, content, 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		env       string
+		verify    func(message, signature []byte) bool
+		wantError string
+	}{
+		{name: "PEM file", args: []string{"--private-key-file", "ed25519.pem"}, verify: verifyEd25519},
+		{name: "hex file", args: []string{"--private-key-file", "ed25519.hex"}, verify: verifyEd25519},
+		{name: "ECDSA PEM file", args: []string{"--private-key-file", "ecdsa.pem", "--algorithm", "ecdsap384"}, verify: verifyECDSA},
+		{name: "environment", env: hex.EncodeToString(ed25519Key.Seed()), verify: verifyEd25519},
+		{name: "flag", args: []string{"--private-key", hex.EncodeToString(ed25519Key.Seed())}, env: "ignored", verify: verifyEd25519},
+		{name: "wrong algorithm", args: []string{"--private-key-file", "ecdsa.pem"}, wantError: "--algorithm ecdsap384"},
+		{name: "both flags", args: []string{"--private-key-file", "ed25519.pem", "--private-key", "00"}, wantError: "not both"},
+		{name: "no key", wantError: commands.PrivateKeyEnvVar},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verify = tt.verify
+			t.Setenv(commands.PrivateKeyEnvVar, tt.env)
+			args := append([]string{"dns", "--registry", server.URL, "--domain", "example.com", "--insecure-token-file"}, tt.args...)
+			err := commands.LoginCommand(args)
+			if tt.wantError == "" && err != nil {
+				t.Errorf("Expected the login to succeed, got: %v", err)
+			}
+			if tt.wantError != "" && (err == nil || !strings.Contains(err.Error(), tt.wantError)) {
+				t.Errorf("Expected an error about %s, got: %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestLoginCommand_GitLabOIDC(t *testing.T) {
+	// The registry only issues a token for the job's ID token
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			OIDCToken string `json:"oidc_token"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		if r.URL.Path != "/v0/auth/gitlab-oidc" || request.OIDCToken != "job-id-token" {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"registry_token": "registry-token"})
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.ProfileEnvVar, "")
+
+	tests := []struct {
+		name      string
+		idToken   string
+		legacy    string
+		wantError string
+	}{
+		{name: "id_tokens variable", idToken: "job-id-token", legacy: "ignored"},
+		{name: "legacy CI_JOB_JWT_V2", legacy: "job-id-token"},
+		{name: "no ID token", wantError: "MCP_ID_TOKEN"},
+		{name: "rejected ID token", idToken: "forged", wantError: "status 401"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MCP_ID_TOKEN", tt.idToken)
+			t.Setenv("CI_JOB_JWT_V2", tt.legacy)
+			err := commands.LoginCommand([]string{"gitlab-oidc", "--registry", server.URL, "--insecure-token-file", "--non-interactive"})
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("Expected an error containing %q, got: %v", tt.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to log in: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoginCommand_Browser(t *testing.T) {
+	// The identity provider redirects back with a code, which it only redeems with the PKCE verifier
+	var challenge, nonce, denied string
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			issuer := "http://" + r.Host
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"authorization_endpoint": issuer + "/authorize",
+				"token_endpoint":         issuer + "/token",
+			})
+		case "/authorize":
+			query := r.URL.Query()
+			if query.Get("client_id") != "publisher" || query.Get("code_challenge_method") != "S256" || !strings.HasPrefix(query.Get("redirect_uri"), "http://127.0.0.1:") {
+				http.Error(w, "invalid authorization request", http.StatusBadRequest)
+				return
+			}
+			challenge, nonce = query.Get("code_challenge"), query.Get("nonce")
+			callback := query.Get("redirect_uri") + "?state=" + query.Get("state")
+			if denied != "" {
+				callback += "&error=" + denied
+			} else {
+				callback += "&code=auth-code"
+			}
+			http.Redirect(w, r, callback, http.StatusFound)
+		case "/token":
+			verifier := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+			if r.FormValue("code") != "auth-code" || base64.RawURLEncoding.EncodeToString(verifier[:]) != challenge {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+				return
+			}
+			claims, _ := json.Marshal(map[string]string{"sub": "user", "nonce": nonce})
+			idToken := "e30." + base64.RawURLEncoding.EncodeToString(claims) + ".signature"
+			_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer idp.Close()
+
+	browserLogin := true
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v0/health":
+			health := map[string]string{"status": "ok"}
+			if browserLogin {
+				health["oidc_issuer"] = idp.URL
+				health["oidc_client_id"] = "publisher"
+			}
+			_ = json.NewEncoder(w).Encode(health)
+		case "/v0/auth/oidc":
+			var request struct {
+				OIDCToken string `json:"oidc_token"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			if !strings.HasPrefix(request.OIDCToken, "e30.") {
+				http.Error(w, "invalid token", http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"registry_token": "registry-token", "refresh_token": "refresh-token"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer registry.Close()
+
+	// Stand in for the browser by following the login page's redirects
+	opened := ""
+	originalOpenBrowser := auth.OpenBrowser
+	auth.OpenBrowser = func(target string) error {
+		opened = target
+		resp, err := http.Get(target) //nolint:gosec,noctx // Test server URL
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+	t.Cleanup(func() { auth.OpenBrowser = originalOpenBrowser })
+
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv(commands.ProfileEnvVar, "")
+
+	if err := commands.LoginCommand([]string{"browser", "--registry", registry.URL, "--insecure-token-file"}); err != nil {
+		t.Fatalf("Failed to log in: %v", err)
+	}
+	if !strings.HasPrefix(opened, idp.URL+"/authorize?") {
+		t.Errorf("Expected the identity provider's login page to be opened, got %q", opened)
+	}
+	tokenData, err := os.ReadFile(filepath.Join(homeDir, commands.TokenFileName))
+	if err != nil {
+		t.Fatalf("Failed to read token file: %v", err)
+	}
+	var saved map[string]any
+	if err := json.Unmarshal(tokenData, &saved); err != nil {
+		t.Fatalf("Failed to parse token file: %v", err)
+	}
+	if saved["method"] != "browser" || saved["token"] != "registry-token" || saved["refresh_token"] != "refresh-token" {
+		t.Errorf("Expected the browser login to be saved with its refresh token, got: %v", saved)
+	}
+
+	denied = "access_denied"
+	err = commands.LoginCommand([]string{"browser", "--registry", registry.URL, "--insecure-token-file"})
+	if err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("Expected the denied login to fail, got: %v", err)
+	}
+
+	browserLogin = false
+	err = commands.LoginCommand([]string{"browser", "--registry", registry.URL, "--insecure-token-file"})
+	if err == nil || !strings.Contains(err.Error(), "browser login is not enabled") {
+		t.Errorf("Expected an error as the registry has no browser login, got: %v", err)
+	}
+}
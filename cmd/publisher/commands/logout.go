@@ -1,31 +1,104 @@
 package commands
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
 )
 
-func LogoutCommand() error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+// LogoutCommand removes the saved login of a profile, or of every profile with --all, revoking its
+// tokens on the registry first
+func LogoutCommand(args []string) error {
+	logoutFlags := flag.NewFlagSet("logout", flag.ExitOnError)
+	profile := addProfileFlag(logoutFlags)
+	addCACertFlag(logoutFlags)
+	addDebugFlag(logoutFlags)
+	all := logoutFlags.Bool("all", false, "Log out of every profile")
+	addOutputFlag(logoutFlags)
+	if err := parseFlags(logoutFlags, args); err != nil {
+		return err
+	}
+
+	profiles := []string{*profile}
+	if *all {
+		var err error
+		if profiles, err = savedProfiles(); err != nil {
+			return err
+		}
+	}
+
+	loggedOut := []string{}
+	for _, profile := range profiles {
+		removed, err := removeSavedLogin(profile)
+		if err != nil {
+			return err
+		}
+		if removed && profile != DefaultProfile {
+			_, _ = fmt.Fprintf(logOutput(), "✓ Logged out of profile %s\n", profile)
+		}
+		if removed {
+			loggedOut = append(loggedOut, profile)
+		}
+	}
+
+	if *all || *profile == DefaultProfile {
+		removeLegacyTokenFiles()
+	}
+
+	if len(loggedOut) == 0 {
+		_, _ = fmt.Fprintln(logOutput(), "Not logged in")
+	} else {
+		_, _ = fmt.Fprintln(logOutput(), "✓ Successfully logged out")
 	}
+	return printResult(logoutResult{Profiles: loggedOut})
+}
 
-	tokenPath := filepath.Join(homeDir, TokenFileName)
+// logoutResult is the JSON result of 'mcp-publisher logout'
+type logoutResult struct {
+	// Profiles are the profiles that were logged out of, leaving out those without a login
+	Profiles []string `json:"profiles"`
+}
+
+// removeSavedLogin revokes the tokens of a profile's saved login and removes them from the token file
+// or OS keychain, reporting whether there was a login
+func removeSavedLogin(profile string) (bool, error) {
+	tokenPath, err := savedLoginPath(profile)
+	if err != nil {
+		return false, err
+	}
 
 	// Check if token file exists
 	if _, err := os.Stat(tokenPath); os.IsNotExist(err) {
-		_, _ = fmt.Fprintln(os.Stdout, "Not logged in")
-		return nil
+		return false, nil
+	}
+
+	login, err := readSavedLogin(profile)
+	if err == nil {
+		revokeSavedTokens(login)
+		if login.Keychain {
+			if err := deleteKeychainTokens(profile); err != nil {
+				return false, err
+			}
+		}
 	}
 
-	// Remove token file
 	if err := os.Remove(tokenPath); err != nil {
-		return fmt.Errorf("failed to remove token: %w", err)
+		return false, fmt.Errorf("failed to remove token: %w", err)
+	}
+	return true, nil
+}
+
+// removeLegacyTokenFiles cleans up the token files of earlier versions if they exist
+func removeLegacyTokenFiles() {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return
 	}
 
-	// Also clean up legacy token files if they exist
 	legacyFiles := []string{
 		".mcpregistry_github_token",
 		".mcpregistry_registry_token",
@@ -37,7 +110,18 @@ func LogoutCommand() error {
 			os.Remove(path) // Ignore errors for legacy files
 		}
 	}
+}
 
-	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully logged out")
-	return nil
+// revokeSavedTokens revokes the registry token and refresh token of a saved login on the registry, so
+// copies of them stop working too. Failures only warn, as the local credentials are removed either way.
+func revokeSavedTokens(login *savedLogin) {
+	for _, token := range []string{login.RefreshToken, login.Token} {
+		if token == "" {
+			continue
+		}
+		if err := auth.RevokeRegistryToken(context.Background(), login.Registry, token); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: failed to revoke token on the registry: %v\n", err)
+			return
+		}
+	}
 }
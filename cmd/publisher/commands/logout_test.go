@@ -0,0 +1,141 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// newProfileRegistry starts a registry that hands out token for anonymous logins, counting the
+// servers published to it and the tokens revoked on it
+func newProfileRegistry(t *testing.T, token string, publishes, revocations *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v0/auth/none":
+			_ = json.NewEncoder(w).Encode(map[string]any{"registry_token": token})
+		case "/v0/auth/revoke":
+			*revocations++
+			w.WriteHeader(http.StatusNoContent)
+		case "/v0/publish":
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				http.Error(w, `{"title":"Unauthorized"}`, http.StatusUnauthorized)
+				return
+			}
+			*publishes++
+			var serverJSON apiv0.ServerJSON
+			_ = json.NewDecoder(r.Body).Decode(&serverJSON)
+			_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{Server: serverJSON})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProfiles(t *testing.T) {
+	keyring.MockInit()
+	var stagingPublishes, stagingRevocations, prodPublishes, prodRevocations int
+	staging := newProfileRegistry(t, "staging-token", &stagingPublishes, &stagingRevocations)
+	prod := newProfileRegistry(t, "prod-token", &prodPublishes, &prodRevocations)
+
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv(commands.APIKeyEnvVar, "")
+	t.Setenv(commands.ProfileEnvVar, "")
+	t.Chdir(t.TempDir())
+
+	serverData, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal test JSON: %v", err)
+	}
+	if err := os.WriteFile("server.json", serverData, 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	if err := commands.LoginCommand([]string{"none", "--registry", staging.URL, "--profile", "staging"}); err != nil {
+		t.Fatalf("Failed to log in to staging: %v", err)
+	}
+	if err := commands.LoginCommand([]string{"none", "--registry", prod.URL}); err != nil {
+		t.Fatalf("Failed to log in to prod: %v", err)
+	}
+	defaultPath := filepath.Join(homeDir, commands.TokenFileName)
+	stagingPath := filepath.Join(homeDir, commands.TokenFileName+".staging")
+	for _, path := range []string{defaultPath, stagingPath} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("Expected a token file at %s: %v", path, err)
+		}
+	}
+
+	t.Run("each profile publishes to its own registry", func(t *testing.T) {
+		if err := commands.PublishCommand([]string{"--profile", "staging"}); err != nil {
+			t.Fatalf("Failed to publish with the staging profile: %v", err)
+		}
+		t.Setenv(commands.ProfileEnvVar, commands.DefaultProfile)
+		if err := commands.PublishCommand([]string{}); err != nil {
+			t.Fatalf("Failed to publish with the default profile: %v", err)
+		}
+		if stagingPublishes != 1 || prodPublishes != 1 {
+			t.Errorf("Expected one publish to each registry, got %d to staging and %d to prod", stagingPublishes, prodPublishes)
+		}
+	})
+
+	t.Run("unknown and invalid profiles are rejected", func(t *testing.T) {
+		err := commands.PublishCommand([]string{"--profile", "dev"})
+		if err == nil || !strings.Contains(err.Error(), "--profile dev") {
+			t.Errorf("Expected a not authenticated error for the dev profile, got: %v", err)
+		}
+		err = commands.PublishCommand([]string{"--profile", "../token"})
+		if err == nil || !strings.Contains(err.Error(), "invalid profile name") {
+			t.Errorf("Expected an invalid profile name error, got: %v", err)
+		}
+	})
+
+	t.Run("logout only removes the chosen profile", func(t *testing.T) {
+		if err := commands.LogoutCommand([]string{"--profile", "staging"}); err != nil {
+			t.Fatalf("Failed to log out of staging: %v", err)
+		}
+		if _, err := os.Stat(stagingPath); !os.IsNotExist(err) {
+			t.Errorf("Expected the staging token file to be removed")
+		}
+		if _, err := os.Stat(defaultPath); err != nil {
+			t.Errorf("Expected the default token file to be kept: %v", err)
+		}
+		if stagingRevocations != 1 || prodRevocations != 0 {
+			t.Errorf("Expected only the staging token to be revoked, got %d and %d revocations", stagingRevocations, prodRevocations)
+		}
+	})
+
+	t.Run("logout --all removes every profile", func(t *testing.T) {
+		if err := commands.LoginCommand([]string{"none", "--registry", staging.URL, "--profile", "staging"}); err != nil {
+			t.Fatalf("Failed to log in to staging: %v", err)
+		}
+		if err := commands.LogoutCommand([]string{"--all"}); err != nil {
+			t.Fatalf("Failed to log out of every profile: %v", err)
+		}
+		for _, path := range []string{defaultPath, stagingPath} {
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("Expected %s to be removed", path)
+			}
+		}
+		if prodRevocations != 1 {
+			t.Errorf("Expected the prod token to be revoked, got %d revocations", prodRevocations)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Server manifest file names looked for when no file is given, in order
+var defaultServerFiles = []string{"server.json", "server.yaml", "server.yml"}
+
+// isYAMLFile reports whether a manifest path is YAML rather than JSON, by its extension
+func isYAMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// findServerFile returns the manifest to use: the given path, or when it is the default server.json
+// and that does not exist, server.yaml or server.yml
+func findServerFile(path string) string {
+	if path != defaultServerFiles[0] {
+		return path
+	}
+	for _, candidate := range defaultServerFiles {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return path
+}
+
+// readServerFile reads a server manifest as JSON, converting it from YAML if need be
+func readServerFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !isYAMLFile(path) {
+		return data, nil
+	}
+
+	jsonData, err := yamlToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	return jsonData, nil
+}
+
+// writeServerFile writes a server manifest given as JSON, converting it to YAML if the path is YAML
+func writeServerFile(path string, jsonData []byte) error {
+	data := jsonData
+	if isYAMLFile(path) {
+		var err error
+		if data, err = jsonToYAML(jsonData); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// yamlToJSON converts a YAML document to indented JSON, keeping the order of keys
+func yamlToJSON(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	if node.Kind != yaml.DocumentNode || len(node.Content) == 0 {
+		return nil, errors.New("the document is empty")
+	}
+
+	var compact bytes.Buffer
+	if err := writeJSONNode(&compact, node.Content[0]); err != nil {
+		return nil, err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	indented.WriteByte('\n')
+	return indented.Bytes(), nil
+}
+
+// writeJSONNode writes a YAML node as JSON. Scalars are typed as YAML resolves them, so an unquoted
+// version such as 1.0 becomes a number.
+func writeJSONNode(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSONNode(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONNode(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case yaml.AliasNode:
+		return writeJSONNode(buf, node.Alias)
+	case yaml.ScalarNode:
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		buf.Write(data)
+	default:
+		return fmt.Errorf("line %d: unsupported YAML node", node.Line)
+	}
+	return nil
+}
+
+// jsonToYAML converts a JSON document to block-style YAML, keeping the order of keys
+func jsonToYAML(data []byte) ([]byte, error) {
+	if !json.Valid(data) {
+		return nil, errors.New("invalid JSON")
+	}
+
+	// JSON is valid YAML, so parsing it keeps key order and types
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	useBlockStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// useBlockStyle clears the flow and quoting styles carried over from JSON, so the output reads like
+// hand-written YAML. Strings that need quoting are still quoted by the encoder.
+func useBlockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		useBlockStyle(child)
+	}
+}
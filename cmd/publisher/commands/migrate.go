@@ -0,0 +1,294 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// legacyFieldNames are the snake_case field names of the 2025-07-09 schema, with their camelCase names
+var legacyFieldNames = map[string]string{
+	"registry_type":         "registryType",
+	"registry_base_url":     "registryBaseUrl",
+	"file_sha256":           "fileSha256",
+	"runtime_hint":          "runtimeHint",
+	"runtime_arguments":     "runtimeArguments",
+	"package_arguments":     "packageArguments",
+	"environment_variables": "environmentVariables",
+	"is_required":           "isRequired",
+	"is_secret":             "isSecret",
+	"value_hint":            "valueHint",
+	"is_repeated":           "isRepeated",
+	"website_url":           "websiteUrl",
+}
+
+// officialMetaKey is the registry's metadata, which older server.json files could include
+const officialMetaKey = "io.modelcontextprotocol.registry/official"
+
+// MigrateCommand upgrades a server.json written for an older schema to the current one, keeping the
+// order of its fields: snake_case field names become camelCase, fields the registry now manages are
+// removed, and OCI and MCPB packages are given the canonical identifiers the registry requires
+func MigrateCommand(args []string) error {
+	migrateFlags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	file := migrateFlags.String("file", "server.json", "Path to the server.json or server.yaml to migrate")
+	dryRun := migrateFlags.Bool("dry-run", false, "List the changes without writing them")
+	addOutputFlag(migrateFlags)
+	if err := parseFlags(migrateFlags, args); err != nil {
+		return err
+	}
+
+	*file = findServerFile(*file)
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found, please check the file path", *file)
+		}
+		return fmt.Errorf("failed to read %s: %w", *file, err)
+	}
+
+	// JSON is valid YAML, so both are read as YAML nodes, which keep the order of fields
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return withExitCode(ExitValidation, fmt.Errorf("invalid %s: %w", *file, err))
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return withExitCode(ExitValidation, fmt.Errorf("invalid %s: expected an object", *file))
+	}
+
+	changes := migrateServer(document.Content[0])
+	result := migrateResult{File: *file, DryRun: *dryRun, Changes: changes}
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintf(logOutput(), "%s already uses the current format\n", *file)
+		return printResult(result)
+	}
+	for _, change := range changes {
+		_, _ = fmt.Fprintf(logOutput(), "  %s\n", change)
+	}
+
+	if *dryRun {
+		_, _ = fmt.Fprintf(logOutput(), "\n%d change(s) to make. Run without --dry-run to update %s.\n", len(changes), *file)
+		return printResult(result)
+	}
+
+	migrated, err := encodeMigrated(&document, isYAMLFile(*file))
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", *file, err)
+	}
+	if err := os.WriteFile(*file, migrated, 0600); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+	result.Written = true
+	_, _ = fmt.Fprintf(logOutput(), "\n✓ Updated %s. Run 'mcp-publisher validate' to check it.\n", *file)
+	return printResult(result)
+}
+
+// migrateResult is the JSON result of 'mcp-publisher migrate'
+type migrateResult struct {
+	File    string   `json:"file"`
+	DryRun  bool     `json:"dryRun,omitempty"`
+	Written bool     `json:"written"`
+	Changes []string `json:"changes"`
+}
+
+// encodeMigrated writes a migrated document back in the format it was read in
+func encodeMigrated(document *yaml.Node, asYAML bool) ([]byte, error) {
+	if asYAML {
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(document); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var compact bytes.Buffer
+	if err := writeJSONNode(&compact, document.Content[0]); err != nil {
+		return nil, err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	indented.WriteByte('\n')
+	return indented.Bytes(), nil
+}
+
+// migrateServer upgrades a server object in place, returning what was changed
+func migrateServer(server *yaml.Node) []string {
+	changes := renameLegacyFields(server, "")
+
+	if schema := mappingValue(server, "$schema"); schema == nil {
+		key := stringNode("$schema")
+		// A comment at the top of a YAML file stays at the top
+		if len(server.Content) > 0 {
+			key.HeadComment, server.Content[0].HeadComment = server.Content[0].HeadComment, ""
+		}
+		server.Content = append([]*yaml.Node{key, stringNode(model.CurrentSchemaURL)}, server.Content...)
+		changes = append(changes, "$schema: set to "+model.CurrentSchemaURL)
+	} else if schema.Value != model.CurrentSchemaURL {
+		changes = append(changes, fmt.Sprintf("$schema: %s → %s", schema.Value, model.CurrentSchemaURL))
+		schema.Value = model.CurrentSchemaURL
+	}
+
+	if removeMappingKey(server, "status") {
+		changes = append(changes, "status: removed, as the registry manages it")
+	}
+	if meta := mappingValue(server, "_meta"); meta != nil && removeMappingKey(meta, officialMetaKey) {
+		changes = append(changes, fmt.Sprintf("_meta: removed %s, which the registry adds", officialMetaKey))
+		if len(meta.Content) == 0 {
+			removeMappingKey(server, "_meta")
+		}
+	}
+
+	if packages := mappingValue(server, "packages"); packages != nil && packages.Kind == yaml.SequenceNode {
+		for i, pkg := range packages.Content {
+			if pkg.Kind != yaml.MappingNode {
+				continue
+			}
+			for _, change := range migratePackage(pkg) {
+				changes = append(changes, fmt.Sprintf("packages[%d]: %s", i, change))
+			}
+		}
+	}
+	return changes
+}
+
+// renameLegacyFields renames snake_case fields to camelCase throughout a node
+func renameLegacyFields(node *yaml.Node, path string) []string {
+	var changes []string
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if renamed, ok := legacyFieldNames[key.Value]; ok && mappingValue(node, renamed) == nil {
+				changes = append(changes, fmt.Sprintf("%s%s → %s", path, key.Value, renamed))
+				key.Value = renamed
+			}
+			changes = append(changes, renameLegacyFields(node.Content[i+1], path+key.Value+".")...)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			changes = append(changes, renameLegacyFields(item, fmt.Sprintf("%s[%d].", strings.TrimSuffix(path, "."), i))...)
+		}
+	}
+	return changes
+}
+
+// migratePackage gives OCI and MCPB packages the canonical identifiers the registry requires: OCI
+// images carry their registry and tag in the identifier, and MCPB packages are a full download URL
+func migratePackage(pkg *yaml.Node) []string {
+	registryType := mappingValue(pkg, "registryType")
+	identifier := mappingValue(pkg, "identifier")
+	if registryType == nil || identifier == nil {
+		return nil
+	}
+
+	var changes []string
+	baseURL := ""
+	if base := mappingValue(pkg, "registryBaseUrl"); base != nil {
+		baseURL = base.Value
+	}
+
+	switch registryType.Value {
+	case model.RegistryTypeOCI:
+		reference := identifier.Value
+		if host := ociRegistryHost(baseURL); host != "" && !hasOCIRegistryHost(reference) {
+			reference = host + "/" + reference
+		}
+		if version := mappingValue(pkg, "version"); version != nil {
+			_, tag, tagged := splitImageTag(reference)
+			switch {
+			case strings.Contains(reference, "@"), tagged && tag == version.Value:
+				changes = append(changes, "version: removed, as the identifier gives it")
+			case tagged:
+				changes = append(changes, fmt.Sprintf("version: %s removed; the identifier's tag %s is kept", version.Value, tag))
+			default:
+				reference += ":" + version.Value
+				changes = append(changes, "version: moved into the identifier's tag")
+			}
+			removeMappingKey(pkg, "version")
+		}
+		if reference != identifier.Value {
+			changes = append(changes, fmt.Sprintf("identifier: %s → %s", identifier.Value, reference))
+			identifier.Value = reference
+		}
+		if removeMappingKey(pkg, "registryBaseUrl") {
+			changes = append(changes, "registryBaseUrl: removed, as the identifier names the registry")
+		}
+		if removeMappingKey(pkg, "fileSha256") {
+			changes = append(changes, "fileSha256: removed, as OCI images are pinned by digest instead")
+		}
+	case model.RegistryTypeMCPB:
+		if baseURL == "" {
+			return nil
+		}
+		if !strings.Contains(identifier.Value, "://") {
+			downloadURL := strings.TrimSuffix(baseURL, "/") + "/" + strings.TrimPrefix(identifier.Value, "/")
+			changes = append(changes, fmt.Sprintf("identifier: %s → %s", identifier.Value, downloadURL))
+			identifier.Value = downloadURL
+		}
+		removeMappingKey(pkg, "registryBaseUrl")
+		changes = append(changes, "registryBaseUrl: removed, as the identifier is the download URL")
+	}
+	return changes
+}
+
+// ociRegistryHost returns the registry host to prefix image names with for an old registryBaseUrl
+func ociRegistryHost(baseURL string) string {
+	host := strings.TrimSuffix(baseURL, "/")
+	if i := strings.Index(host, "://"); i != -1 {
+		host = host[i+3:]
+	}
+	switch host {
+	case "registry-1.docker.io", "index.docker.io", "registry.hub.docker.com":
+		return "docker.io"
+	}
+	return host
+}
+
+// hasOCIRegistryHost reports whether an image reference starts with a registry host, which, as for
+// docker, is a first component with a dot or port, or localhost
+func hasOCIRegistryHost(reference string) bool {
+	first, _, found := strings.Cut(reference, "/")
+	return found && (strings.ContainsAny(first, ".:") || first == "localhost")
+}
+
+// mappingValue returns the value of a key of a mapping node, or nil if it has none
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// removeMappingKey removes a key of a mapping node, reporting whether it had one
+func removeMappingKey(node *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// stringNode returns a YAML node holding a string
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
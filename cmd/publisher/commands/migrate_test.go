@@ -0,0 +1,147 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const legacyServerJSON = `{
+  "$schema": "https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json",
+  "name": "io.github.acme/weather",
+  "description": "Weather forecasts",
+  "status": "active",
+  "version": "1.2.0",
+  "packages": [
+    {
+      "registry_type": "oci",
+      "registry_base_url": "https://registry-1.docker.io",
+      "identifier": "acme/weather",
+      "version": "1.2.0",
+      "transport": {"type": "stdio"},
+      "environment_variables": [{"name": "API_KEY", "is_required": true, "is_secret": true}]
+    },
+    {
+      "registryType": "npm",
+      "registryBaseUrl": "https://registry.npmjs.org",
+      "identifier": "@acme/weather",
+      "version": "1.2.0",
+      "transport": {"type": "stdio"}
+    }
+  ],
+  "_meta": {
+    "io.modelcontextprotocol.registry/official": {"status": "active"}
+  }
+}
+`
+
+func TestMigrateCommand(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("server.json", []byte(legacyServerJSON), 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	// A dry run lists the changes but writes nothing
+	if err := commands.MigrateCommand([]string{"--dry-run"}); err != nil {
+		t.Fatalf("migrate --dry-run failed: %v", err)
+	}
+	if data, _ := os.ReadFile("server.json"); string(data) != legacyServerJSON {
+		t.Fatalf("Expected --dry-run to leave server.json alone")
+	}
+
+	if err := commands.MigrateCommand(nil); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	data, err := os.ReadFile("server.json")
+	if err != nil {
+		t.Fatalf("Failed to read server.json: %v", err)
+	}
+	migrated := string(data)
+
+	var server map[string]any
+	if err := json.Unmarshal(data, &server); err != nil {
+		t.Fatalf("Migrated server.json is not JSON: %v\n%s", err, migrated)
+	}
+	if server["$schema"] != model.CurrentSchemaURL {
+		t.Errorf("Expected the current schema, got %v", server["$schema"])
+	}
+	for _, removed := range []string{"status", "_meta"} {
+		if _, ok := server[removed]; ok {
+			t.Errorf("Expected %s to be removed", removed)
+		}
+	}
+	packages := server["packages"].([]any)
+	oci := packages[0].(map[string]any)
+	if oci["registryType"] != "oci" || oci["identifier"] != "docker.io/acme/weather:1.2.0" {
+		t.Errorf("Expected a canonical OCI identifier, got %v", oci)
+	}
+	for _, removed := range []string{"registryBaseUrl", "registry_base_url", "version"} {
+		if _, ok := oci[removed]; ok {
+			t.Errorf("Expected %s to be removed from the OCI package", removed)
+		}
+	}
+	env := oci["environmentVariables"].([]any)[0].(map[string]any)
+	if env["isRequired"] != true || env["isSecret"] != true {
+		t.Errorf("Expected camelCase environment variable fields, got %v", env)
+	}
+	if npm := packages[1].(map[string]any); npm["registryBaseUrl"] != "https://registry.npmjs.org" || npm["version"] != "1.2.0" {
+		t.Errorf("Expected the npm package to be left alone, got %v", npm)
+	}
+	// Fields keep their order
+	if strings.Index(migrated, `"name"`) > strings.Index(migrated, `"description"`) {
+		t.Errorf("Expected the order of fields to be kept:\n%s", migrated)
+	}
+
+	// The result passes the registry's rules, and migrating again changes nothing
+	if err := commands.ValidateCommand([]string{"--skip-packages"}); err != nil {
+		t.Errorf("Expected the migrated server.json to be valid: %v", err)
+	}
+	stdout, err := captureStdout(t, func() error { return commands.MigrateCommand([]string{"--output", "json"}) })
+	if err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	var result struct {
+		Written bool     `json:"written"`
+		Changes []string `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil || result.Written || len(result.Changes) != 0 {
+		t.Errorf("Expected no changes the second time, got %s", stdout)
+	}
+}
+
+func TestMigrateCommand_YAML(t *testing.T) {
+	t.Chdir(t.TempDir())
+	legacyYAML := `# Weather server
+name: io.github.acme/weather
+description: Weather forecasts
+version: 1.0.0
+packages:
+  - registryType: oci
+    identifier: ghcr.io/acme/weather:1.0.0
+    version: 1.0.0
+    transport:
+      type: stdio
+`
+	if err := os.WriteFile("server.yaml", []byte(legacyYAML), 0o600); err != nil {
+		t.Fatalf("Failed to write server.yaml: %v", err)
+	}
+
+	if err := commands.MigrateCommand(nil); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	data, err := os.ReadFile("server.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read server.yaml: %v", err)
+	}
+	migrated := string(data)
+	if !strings.HasPrefix(migrated, "# Weather server\n") || !strings.Contains(migrated, "$schema: "+model.CurrentSchemaURL) {
+		t.Errorf("Expected the comment kept and the schema added:\n%s", migrated)
+	}
+	if strings.Contains(migrated, "    version:") || !strings.Contains(migrated, "identifier: ghcr.io/acme/weather:1.0.0") {
+		t.Errorf("Expected the OCI version to be removed, keeping the identifier:\n%s", migrated)
+	}
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Output formats of --output
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// jsonOutput is set by --output json. The result of the command is then printed to stdout as JSON, and
+// everything else, such as progress messages and prompts, to stderr.
+var jsonOutput bool
+
+// resultPrinted records that the command printed its JSON result, which on failure already says why
+var resultPrinted bool
+
+// outputFlag is the --output flag, which sets jsonOutput
+type outputFlag struct{}
+
+func (outputFlag) String() string {
+	if jsonOutput {
+		return OutputJSON
+	}
+	return OutputText
+}
+
+func (outputFlag) Set(v string) error {
+	switch v {
+	case OutputText, OutputJSON:
+		jsonOutput = v == OutputJSON
+		return nil
+	}
+	return fmt.Errorf("invalid output format: %q (allowed: text, json)", v)
+}
+
+// addOutputFlag adds the --output flag to a command's flags, starting from the output of the
+// configuration file or else text
+func addOutputFlag(flags *flag.FlagSet) {
+	jsonOutput = config.Output == OutputJSON
+	resultPrinted = false
+	flags.Var(outputFlag{}, "output", "Output format: text, or json for the result as JSON on stdout and messages on stderr")
+}
+
+// logOutput returns where a command prints its messages for people to read
+func logOutput() io.Writer {
+	if jsonOutput {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// printResult prints the result of a command to stdout as JSON, if --output json is set
+func printResult(result any) error {
+	if !jsonOutput {
+		return nil
+	}
+	resultPrinted = true
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to print result: %w", err)
+	}
+	return nil
+}
+
+// errorResult is the JSON result of a command that failed
+type errorResult struct {
+	Error string `json:"error"`
+}
+
+// PrintError prints the error a command failed with to stdout as JSON, if --output json was set and the
+// command did not print a result itself, so CI jobs can read why it failed
+func PrintError(err error) {
+	if !jsonOutput || resultPrinted {
+		return
+	}
+	_ = printResult(errorResult{Error: err.Error()})
+}
@@ -0,0 +1,106 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestOutputJSON(t *testing.T) {
+	publishedAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var serverJSON apiv0.ServerJSON
+		_ = json.NewDecoder(r.Body).Decode(&serverJSON)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiv0.PublishResponse{ServerResponse: apiv0.ServerResponse{
+			Server: serverJSON,
+			Meta:   apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{Status: model.StatusActive, PublishedAt: publishedAt, IsLatest: true}},
+		}})
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+	t.Chdir(t.TempDir())
+	serverData := `{"$schema": "` + model.CurrentSchemaURL + `", "name": "io.github.example/test-server", "description": "A test server", "version": "1.0.0"}`
+	if err := os.WriteFile("server.json", []byte(serverData), 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	t.Run("publish prints only its result to stdout", func(t *testing.T) {
+		output, err := captureStdout(t, func() error { return commands.PublishCommand([]string{"--output", "json"}) })
+		if err != nil {
+			t.Fatalf("publish failed: %v", err)
+		}
+		var result map[string]any
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Expected JSON on stdout, got %q: %v", output, err)
+		}
+		if result["name"] != "io.github.example/test-server" || result["version"] != "1.0.0" || result["isLatest"] != true ||
+			result["url"] != server.URL+"/v0/servers/io.github.example%2Ftest-server/versions/1.0.0" ||
+			result["publishedAt"] != "2025-10-01T12:00:00Z" {
+			t.Errorf("Unexpected result: %v", result)
+		}
+	})
+
+	t.Run("a failed validation is a result", func(t *testing.T) {
+		invalid := strings.Replace(serverData, `"1.0.0"`, `"^1.0.0"`, 1)
+		if err := os.WriteFile("server.json", []byte(invalid), 0o600); err != nil {
+			t.Fatalf("Failed to write server.json: %v", err)
+		}
+		output, err := captureStdout(t, func() error {
+			err := commands.ValidateCommand([]string{"--output", "json", "--skip-packages"})
+			// As main does for every command, which prints nothing more once there is a result
+			commands.PrintError(err)
+			return err
+		})
+		if err == nil {
+			t.Fatal("Expected validate to fail")
+		}
+		var result struct {
+			Valid  bool `json:"valid"`
+			Checks []struct {
+				Name   string   `json:"name"`
+				Errors []string `json:"errors"`
+			} `json:"checks"`
+		}
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Expected a single JSON result on stdout, got %q: %v", output, err)
+		}
+		if result.Valid || len(result.Checks) != 2 || len(result.Checks[0].Errors) != 0 || len(result.Checks[1].Errors) != 1 {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("errors without a result are printed as one", func(t *testing.T) {
+		output, err := captureStdout(t, func() error {
+			err := commands.BumpCommand([]string{"patch", "--output", "json", "--file", "missing.json"})
+			commands.PrintError(err)
+			return err
+		})
+		var result map[string]string
+		if err == nil || json.Unmarshal([]byte(output), &result) != nil || result["error"] != err.Error() {
+			t.Errorf("Expected the error as JSON, got %q (%v)", output, err)
+		}
+	})
+
+	t.Run("text output is unchanged", func(t *testing.T) {
+		output, err := captureStdout(t, func() error {
+			err := commands.ValidateCommand([]string{"--skip-packages"})
+			commands.PrintError(err)
+			return err
+		})
+		if err == nil || !strings.Contains(output, "✗ Registry rules") || strings.Contains(output, "{") {
+			t.Errorf("Expected text output, got %q", output)
+		}
+	})
+}
@@ -3,110 +3,417 @@ package commands
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
+// errRegistryUnreachable is returned when a request could not be sent to the registry at all
+var errRegistryUnreachable = errors.New("could not reach the registry")
+
+// PublishCommand publishes server.json, or each of several files in turn
 func PublishCommand(args []string) error {
-	// Check for server.json file
-	serverFile := "server.json"
-	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
-		serverFile = args[0]
+	publishFlags := flag.NewFlagSet("publish", flag.ExitOnError)
+	var serverFile string
+	var opts publishOptions
+	publishFlags.StringVar(&serverFile, "file", "server.json", "Path to server.json or server.yaml")
+	publishFlags.StringVar(&opts.registryOverride, "registry", "", "Registry URL override")
+	publishFlags.BoolVar(&opts.dryRun, "dry-run", false, "Check the server with the registry without publishing it")
+	publishFlags.IntVar(&opts.maxRetries, "max-retries", defaultMaxRetries, "Times to retry when the registry is unreachable, overloaded or failing (0 to never retry)")
+	signingKeyFile := publishFlags.String("signing-key", "", "Private key file, hex or PEM, to sign server.json with (default $"+SigningKeyEnvVar+")")
+	githubActions := publishFlags.Bool("github-actions", false, "Write step outputs and a job summary for GitHub Actions")
+	profile := addProfileFlag(publishFlags)
+	addCACertFlag(publishFlags)
+	addDebugFlag(publishFlags)
+	addOutputFlag(publishFlags)
+	opts.values = templateValues{}
+	publishFlags.Var(opts.values, "set", "Value for a ${NAME} placeholder in server.json, as NAME=VALUE (repeatable)")
+
+	// The paths to server.json may also be given before the flags
+	var files []string
+	for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		files = append(files, args[0])
+		args = args[1:]
+	}
+	if err := parseFlags(publishFlags, args); err != nil {
+		return err
+	}
+	opts.profile = *profile
+	if opts.maxRetries < 0 {
+		return withExitCode(ExitUsage, errors.New("--max-retries cannot be negative"))
+	}
+	signer, err := loadSigner(*signingKeyFile)
+	if err != nil {
+		return err
+	}
+	opts.signer = signer
+	if *githubActions {
+		if err := checkGitHubActions(); err != nil {
+			return err
+		}
+	}
+
+	files = append(files, publishFlags.Args()...)
+	if len(files) == 0 {
+		files = []string{serverFile}
+	}
+	files, err = expandServerFiles(files)
+	if err != nil {
+		return err
+	}
+	if len(files) > 1 {
+		return publishFiles(files, opts, *githubActions)
+	}
+
+	if opts.dryRun {
+		// A server that would not be published still has a result, saying why
+		result, err := dryRunFile(files[0], opts)
+		if result == nil {
+			return err
+		}
+		if *githubActions {
+			if err := dryRunReport(result).write(); err != nil {
+				return err
+			}
+		}
+		if err := printResult(result); err != nil {
+			return err
+		}
+		if err == nil {
+			_, _ = fmt.Fprintln(logOutput(), "✓ The server would be published")
+		}
+		return err
+	}
+
+	result, err := publishFile(files[0], opts)
+	if err != nil {
+		if *githubActions && result != nil {
+			_ = failureReport(fmt.Sprintf("Publishing %s %s", result.Name, result.Version), err).write()
+		}
+		return err
+	}
+	if *githubActions {
+		if err := publishReport(*result).write(); err != nil {
+			return err
+		}
+	}
+	return printResult(result)
+}
+
+// publishOptions are the flags of 'mcp-publisher publish' that apply to each file published
+type publishOptions struct {
+	registryOverride string
+	profile          string
+	values           templateValues
+	dryRun           bool
+	maxRetries       int
+	signer           *auth.Signer
+}
+
+// expandServerFiles expands the glob patterns among the files to publish, for shells that do not, and
+// finds server.yaml in place of a missing server.json
+func expandServerFiles(patterns []string) ([]string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		if !strings.ContainsAny(pattern, "*?[") {
+			files = append(files, findServerFile(pattern))
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, withExitCode(ExitUsage, fmt.Errorf("invalid pattern %s: %w", pattern, err))
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %s", pattern)
+		}
+		files = append(files, matches...)
 	}
+	return files, nil
+}
 
-	// Read server.json
-	serverData, err := os.ReadFile(serverFile)
+// readServerToPublish reads a server.json, or server.yaml converted to JSON, with its placeholders
+// filled in, and checks it can be published
+func readServerToPublish(file string, values templateValues) ([]byte, *apiv0.ServerJSON, error) {
+	serverData, err := readServerFile(file)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("server.json not found. Run 'mcp-publisher init' to create one")
+			return nil, nil, fmt.Errorf("%s not found. Run 'mcp-publisher init' to create one", file)
 		}
-		return fmt.Errorf("failed to read server.json: %w", err)
+		return nil, nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	if serverData, err = substitutePlaceholders(serverData, values); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", file, err)
 	}
+	debugf("Read %s: %d bytes with placeholders filled in", file, len(serverData))
 
 	// Validate JSON
 	var serverJSON apiv0.ServerJSON
 	if err := json.Unmarshal(serverData, &serverJSON); err != nil {
-		return fmt.Errorf("invalid server.json: %w", err)
+		return nil, nil, withExitCode(ExitValidation, fmt.Errorf("invalid server.json: %w", err))
 	}
 
 	// Check for deprecated schema and recommend migration
 	// Allow empty schema (will use default) but reject old schemas
 	if serverJSON.Schema != "" && !strings.Contains(serverJSON.Schema, model.CurrentSchemaVersion) {
-		return fmt.Errorf(`deprecated schema detected: %s.
+		return nil, nil, withExitCode(ExitValidation, fmt.Errorf(`deprecated schema detected: %s.
 
 Migrate to the current schema format for new servers.
 
 📋 Migration checklist: https://github.com/modelcontextprotocol/registry/blob/main/docs/reference/server-json/CHANGELOG.md#migration-checklist-for-publishers
-📖 Full changelog with examples: https://github.com/modelcontextprotocol/registry/blob/main/docs/reference/server-json/CHANGELOG.md`, serverJSON.Schema)
+📖 Full changelog with examples: https://github.com/modelcontextprotocol/registry/blob/main/docs/reference/server-json/CHANGELOG.md`, serverJSON.Schema))
 	}
 
-	// Load saved token
-	homeDir, err := os.UserHomeDir()
+	return serverData, &serverJSON, nil
+}
+
+// publishFile publishes one server.json. When publishing fails, the result still names the server.
+func publishFile(file string, opts publishOptions) (*publishResult, error) {
+	serverData, serverJSON, err := readServerToPublish(file, opts.values)
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return nil, err
 	}
+	result := &publishResult{Name: serverJSON.Name, Version: serverJSON.Version}
 
-	tokenPath := filepath.Join(homeDir, TokenFileName)
-	tokenData, err := os.ReadFile(tokenPath)
+	token, registryURL, err := loadPublishToken(opts.profile)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return errors.New("not authenticated. Run 'mcp-publisher login <method>' first")
-		}
-		return fmt.Errorf("failed to read token: %w", err)
+		return result, err
 	}
+	if opts.registryOverride != "" {
+		registryURL = opts.registryOverride
+	}
+	result.Registry = registryURL
 
-	var tokenInfo map[string]string
-	if err := json.Unmarshal(tokenData, &tokenInfo); err != nil {
-		return fmt.Errorf("invalid token data: %w", err)
+	// Publish to registry
+	_, _ = fmt.Fprintf(logOutput(), "Publishing to %s...\n", registryURL)
+	response, err := publishWithRenewal(registryURL, serverData, token, opts)
+	if err != nil {
+		return result, fmt.Errorf("publish failed: %w", err)
 	}
 
-	token := tokenInfo["token"]
-	registryURL := tokenInfo["registry"]
-	if registryURL == "" {
-		registryURL = DefaultRegistryURL
+	_, _ = fmt.Fprintln(logOutput(), "✓ Successfully published")
+	_, _ = fmt.Fprintf(logOutput(), "✓ Server %s version %s\n", response.Server.Name, response.Server.Version)
+
+	result.Name, result.Version = response.Server.Name, response.Server.Version
+	result.URL = serverVersionURL(registryURL, response.Server.Name, response.Server.Version)
+	if official := response.Meta.Official; official != nil {
+		result.IsLatest = official.IsLatest
+		result.PublishedAt = &official.PublishedAt
+		result.Signature = official.Signature
+	}
+	if result.Signature != nil {
+		_, _ = fmt.Fprintf(logOutput(), "✓ Signed with %s key %s\n", result.Signature.Algorithm, result.Signature.PublicKey)
 	}
+	return result, nil
+}
 
-	// Publish to registry
-	_, _ = fmt.Fprintf(os.Stdout, "Publishing to %s...\n", registryURL)
-	response, err := publishToRegistry(registryURL, serverData, token)
+// dryRunFile checks one server.json with a dry run. When the server would not be published, the
+// result says why.
+func dryRunFile(file string, opts publishOptions) (*dryRunResult, error) {
+	serverData, _, err := readServerToPublish(file, opts.values)
 	if err != nil {
-		return fmt.Errorf("publish failed: %w", err)
+		return nil, err
 	}
+	return dryRunPublish(serverData, opts)
+}
 
-	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully published")
-	_, _ = fmt.Fprintf(os.Stdout, "✓ Server %s version %s\n", response.Server.Name, response.Server.Version)
+// publishFiles publishes each of several files, carrying on past failures, and sums up how each went
+func publishFiles(files []string, opts publishOptions, githubActions bool) error {
+	summary := publishFilesResult{Servers: []publishFileResult{}}
+	var failures []error
+	for _, file := range files {
+		_, _ = fmt.Fprintf(logOutput(), "\n== %s ==\n", file)
+		fileResult := publishFileResult{File: file}
+		var err error
+		if opts.dryRun {
+			fileResult.DryRun, err = dryRunFile(file, opts)
+		} else {
+			fileResult.Published, err = publishFile(file, opts)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(logOutput(), "✗ %v\n", err)
+			fileResult.Error = err.Error()
+			failures = append(failures, err)
+		}
+		summary.Servers = append(summary.Servers, fileResult)
+	}
+	summary.Failed = len(failures)
+
+	verb := "Published"
+	if opts.dryRun {
+		verb = "Would publish"
+	}
+	_, _ = fmt.Fprintf(logOutput(), "\n%s %d of %d server(s):\n", verb, len(files)-len(failures), len(files))
+	for _, fileResult := range summary.Servers {
+		if fileResult.Error != "" {
+			_, _ = fmt.Fprintf(logOutput(), "  ✗ %s: %s\n", fileResult.File, fileResult.Error)
+		} else {
+			name, version := fileResult.server()
+			_, _ = fmt.Fprintf(logOutput(), "  ✓ %s: %s %s\n", fileResult.File, name, version)
+		}
+	}
+
+	if githubActions {
+		if err := publishFilesReport(summary, opts.dryRun).write(); err != nil {
+			return err
+		}
+	}
+	if err := printResult(summary); err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		return nil
+	}
 
-	return nil
+	// The exit code is that of the failures, if they agree
+	code := ExitCode(failures[0])
+	for _, failure := range failures[1:] {
+		if ExitCode(failure) != code {
+			code = ExitFailure
+		}
+	}
+	return withExitCode(code, fmt.Errorf("%d of %d server(s) failed", len(failures), len(files)))
 }
 
-func publishToRegistry(registryURL string, serverData []byte, token string) (*apiv0.ServerResponse, error) {
-	// Parse the server JSON data
-	var serverJSON apiv0.ServerJSON
-	err := json.Unmarshal(serverData, &serverJSON)
+// publishResult is the JSON result of 'mcp-publisher publish'
+type publishResult struct {
+	Name        string     `json:"name"`
+	Version     string     `json:"version"`
+	Registry    string     `json:"registry"`
+	URL         string     `json:"url"`
+	IsLatest    bool       `json:"isLatest"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+	// Signature is the publisher's signature of server.json the registry stored, with --signing-key
+	Signature *apiv0.ServerSignature `json:"signature,omitempty"`
+}
+
+// publishFilesResult is the JSON result of 'mcp-publisher publish' with several files
+type publishFilesResult struct {
+	Servers []publishFileResult `json:"servers"`
+	Failed  int                 `json:"failed"`
+}
+
+// publishFileResult is how publishing one of several files went
+type publishFileResult struct {
+	File      string         `json:"file"`
+	Published *publishResult `json:"published,omitempty"`
+	DryRun    *dryRunResult  `json:"dryRun,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// server returns the name and version of the server in the file, where known
+func (r publishFileResult) server() (string, string) {
+	switch {
+	case r.Published != nil:
+		return r.Published.Name, r.Published.Version
+	case r.DryRun != nil:
+		return r.DryRun.Name, r.DryRun.Version
+	}
+	return "", ""
+}
+
+// dryRunResult is the JSON result of 'mcp-publisher publish --dry-run'
+type dryRunResult struct {
+	DryRun   bool   `json:"dryRun"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Registry string `json:"registry"`
+	// CheckedBy is "registry", or "local" when the registry could not be asked
+	CheckedBy string          `json:"checkedBy"`
+	Valid     bool            `json:"valid"`
+	Errors    []string        `json:"errors"`
+	Warnings  []string        `json:"warnings"`
+	Request   json.RawMessage `json:"request"`
+}
+
+// loadPublishToken returns the API token in MCP_PUBLISHER_API_KEY, which CI jobs can use instead of
+// logging in, or else the token of the profile's saved login, along with the registry to publish to
+func loadPublishToken(profile string) (string, string, error) {
+	if apiKey := os.Getenv(APIKeyEnvVar); apiKey != "" {
+		registryURL := os.Getenv(RegistryURLEnvVar)
+		if registryURL == "" {
+			registryURL = defaultRegistryURL()
+		}
+		debugf("Using the API token in %s for %s", APIKeyEnvVar, registryURL)
+		return apiKey, registryURL, nil
+	}
+
+	debugf("Using the saved login of profile %s", profile)
+	return loadSavedToken(profile)
+}
+
+// publishWithRenewal publishes server.json, or checks it for a dry run. When the registry rejects a saved
+// token, which expired early or was revoked, the login is renewed once and the request retried. Every
+// request is part of one trace, whose ID is printed when publishing fails so the registry's operators
+// can find its spans and logs.
+func publishWithRenewal(registryURL string, serverData []byte, token string, opts publishOptions) (*apiv0.PublishResponse, error) {
+	trace := newTraceContext()
+	debugf("Trace ID %s", trace.traceID)
+
+	response, err := publishWithRetry(registryURL, serverData, token, trace, opts)
+	if errors.Is(err, errTokenRejected) && os.Getenv(APIKeyEnvVar) == "" {
+		if token, err = renewSavedToken(opts.profile); err == nil {
+			response, err = publishWithRetry(registryURL, serverData, token, trace, opts)
+		}
+	}
 	if err != nil {
+		_, _ = fmt.Fprintf(logOutput(), "Trace ID: %s\n", trace.traceID)
+	}
+	return response, err
+}
+
+// publishPayload returns the request body publishing server.json sends: the server as the registry's
+// types read it, so fields the registry does not know are left out
+func publishPayload(serverData []byte) ([]byte, error) {
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(serverData, &serverJSON); err != nil {
 		return nil, fmt.Errorf("error parsing server.json file: %w", err)
 	}
 
-	// Convert to JSON
 	jsonData, err := json.Marshal(serverJSON)
 	if err != nil {
 		return nil, fmt.Errorf("error serializing request: %w", err)
 	}
+	return jsonData, nil
+}
+
+// serverVersionURL returns the URL of a version of a server in the registry's API
+func serverVersionURL(registryURL, name, version string) string {
+	return strings.TrimSuffix(registryURL, "/") + "/v0/servers/" + url.PathEscape(name) + "/versions/" + url.PathEscape(version)
+}
 
-	// Ensure URL ends with the publish endpoint
+// publishURL returns the URL of the registry's publish endpoint
+func publishURL(registryURL string) string {
 	if !strings.HasSuffix(registryURL, "/") {
 		registryURL += "/"
 	}
-	publishURL := registryURL + "v0/publish"
+	return registryURL + "v0/publish"
+}
+
+// publishToRegistry sends server.json to the registry along with headers, such as the Idempotency-Key
+// that has attempts to publish the same server.json published at most once
+func publishToRegistry(registryURL string, serverData []byte, token string, dryRun bool, headers http.Header) (*apiv0.PublishResponse, error) {
+	jsonData, err := publishPayload(serverData)
+	if err != nil {
+		return nil, err
+	}
+
+	publishURL := publishURL(registryURL)
+	if dryRun {
+		publishURL += "?dryRun=true"
+	}
 
 	// Create and send request
 	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, publishURL, bytes.NewBuffer(jsonData))
@@ -115,28 +422,136 @@ func publishToRegistry(registryURL string, serverData []byte, token string) (*ap
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
+	for name, values := range headers {
+		req.Header[name] = values
+	}
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("error sending request: %w", err)
+		err = fmt.Errorf("%w: %w", errRegistryUnreachable, err)
+		// A certificate the registry will keep presenting is not worth retrying
+		var certErr *tls.CertificateVerificationError
+		if errors.As(err, &certErr) {
+			return nil, err
+		}
+		return nil, &transientError{err: err}
 	}
 	defer resp.Body.Close()
 
 	// Read response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error reading response: %w", err)
+		return nil, &transientError{err: fmt.Errorf("error reading response: %w", err)}
 	}
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("%w: %s", errTokenRejected, body)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return nil, &transientError{err: registryStatusError(resp.StatusCode, body), retryAfter: resp.Header.Get("Retry-After")}
+	}
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("server returned status %d: %s", resp.StatusCode, body)
+		return nil, registryStatusError(resp.StatusCode, body)
 	}
 
-	var serverResponse apiv0.ServerResponse
+	var serverResponse apiv0.PublishResponse
 	if err := json.Unmarshal(body, &serverResponse); err != nil {
 		return nil, err
 	}
 
 	return &serverResponse, nil
 }
+
+// dryRunPublish prints the request publishing server.json would send and what the registry says of it,
+// without publishing. When there is no login or the registry cannot be reached, server.json is checked
+// locally instead. It fails if the server would not be published, so CI jobs can gate on it, and then
+// returns the result as well.
+func dryRunPublish(serverData []byte, opts publishOptions) (*dryRunResult, error) {
+	payload, err := publishPayload(serverData)
+	if err != nil {
+		return nil, err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, payload, "", "  "); err != nil {
+		return nil, fmt.Errorf("error formatting request: %w", err)
+	}
+
+	token, registryURL, tokenErr := loadPublishToken(opts.profile)
+	if opts.registryOverride != "" {
+		registryURL = opts.registryOverride
+	}
+	if registryURL == "" {
+		registryURL = defaultRegistryURL()
+	}
+
+	_, _ = fmt.Fprintln(logOutput(), "Dry run: nothing will be published")
+	_, _ = fmt.Fprintf(logOutput(), "\nPOST %s\n%s\n\n", publishURL(registryURL), indented.String())
+
+	var validation *apiv0.PublishValidation
+	checkedBy := "registry"
+	if tokenErr == nil {
+		var response *apiv0.PublishResponse
+		response, err = publishWithRenewal(registryURL, serverData, token, opts)
+		if err != nil && !errors.Is(err, errRegistryUnreachable) {
+			return nil, fmt.Errorf("dry run failed: %w", err)
+		}
+		if err == nil {
+			if response.Validation == nil {
+				return nil, fmt.Errorf("dry run failed: %s does not support dry runs", registryURL)
+			}
+			validation = response.Validation
+		}
+	}
+
+	if validation != nil {
+		_, _ = fmt.Fprintf(logOutput(), "Checked by %s:\n", registryURL)
+	} else {
+		// Without the registry, permissions, policies and the server's existing versions cannot be checked
+		reason := tokenErr
+		if reason == nil {
+			reason = err
+		}
+		_, _ = fmt.Fprintf(logOutput(), "Checked locally, as the registry could not be asked (%v).\n", reason)
+		_, _ = fmt.Fprintln(logOutput(), "Namespace permissions, publish policies and existing versions were not checked:")
+
+		checks, err := validateLocally(serverData, true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server.json: %w", err)
+		}
+		validation = &apiv0.PublishValidation{Errors: []string{}}
+		for _, check := range checks {
+			for _, checkErr := range check.Errors {
+				validation.Errors = append(validation.Errors, fmt.Sprintf("%s: %v", check.Name, checkErr))
+			}
+		}
+		validation.Valid = len(validation.Errors) == 0
+		checkedBy = "local"
+	}
+
+	for _, problem := range validation.Errors {
+		_, _ = fmt.Fprintf(logOutput(), "✗ %s\n", problem)
+	}
+	for _, warning := range validation.Warnings {
+		_, _ = fmt.Fprintf(logOutput(), "! %s\n", warning)
+	}
+	// Lists are printed as [] rather than null when empty
+	result := &dryRunResult{
+		DryRun:    true,
+		Registry:  registryURL,
+		CheckedBy: checkedBy,
+		Valid:     validation.Valid,
+		Errors:    append([]string{}, validation.Errors...),
+		Warnings:  append([]string{}, validation.Warnings...),
+		Request:   payload,
+	}
+	var server apiv0.ServerJSON
+	if err := json.Unmarshal(payload, &server); err == nil {
+		result.Name, result.Version = server.Name, server.Version
+	}
+
+	if !validation.Valid {
+		return result, withExitCode(ExitValidation, fmt.Errorf("dry run found %d error(s); the server would not be published", len(validation.Errors)))
+	}
+	return result, nil
+}
@@ -1,11 +1,23 @@
 package commands_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -131,3 +143,561 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishCommand_RenewsRejectedToken(t *testing.T) {
+	// A token the CLI believes is valid for another hour, which the registry has revoked
+	payload := base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, `{"exp":%d}`, time.Now().Add(time.Hour).Unix()))
+	revokedToken := "header." + payload + ".signature"
+
+	var publishAttempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v0/auth/refresh":
+			_ = json.NewEncoder(w).Encode(map[string]any{"registry_token": "renewed-token", "refresh_token": "new-refresh-token"})
+		case "/v0/publish":
+			publishAttempts++
+			if r.Header.Get("Authorization") != "Bearer renewed-token" {
+				http.Error(w, `{"title":"Unauthorized"}`, http.StatusUnauthorized)
+				return
+			}
+			var serverJSON apiv0.ServerJSON
+			_ = json.NewDecoder(r.Body).Decode(&serverJSON)
+			_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{Server: serverJSON})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv(commands.APIKeyEnvVar, "")
+	t.Chdir(t.TempDir())
+
+	tokenPath := filepath.Join(homeDir, commands.TokenFileName)
+	writeLogin := func(t *testing.T, refreshToken string) {
+		t.Helper()
+		tokenData, err := json.Marshal(map[string]string{
+			"token":         revokedToken,
+			"method":        "github",
+			"registry":      server.URL,
+			"refresh_token": refreshToken,
+		})
+		if err != nil {
+			t.Fatalf("Failed to marshal token data: %v", err)
+		}
+		if err := os.WriteFile(tokenPath, tokenData, 0o600); err != nil {
+			t.Fatalf("Failed to write token file: %v", err)
+		}
+	}
+
+	serverData, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal test JSON: %v", err)
+	}
+	if err := os.WriteFile("server.json", serverData, 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	t.Run("a rejected token is renewed with the refresh token and the publish retried", func(t *testing.T) {
+		publishAttempts = 0
+		writeLogin(t, "refresh-token")
+
+		if err := commands.PublishCommand([]string{}); err != nil {
+			t.Fatalf("Expected publish to succeed after renewing the token, got: %v", err)
+		}
+		if publishAttempts != 2 {
+			t.Errorf("Expected 2 publish attempts, got %d", publishAttempts)
+		}
+
+		tokenData, err := os.ReadFile(tokenPath)
+		if err != nil {
+			t.Fatalf("Failed to read token file: %v", err)
+		}
+		var saved map[string]string
+		if err := json.Unmarshal(tokenData, &saved); err != nil {
+			t.Fatalf("Failed to parse token file: %v", err)
+		}
+		if saved["token"] != "renewed-token" || saved["refresh_token"] != "new-refresh-token" {
+			t.Errorf("Expected the renewed tokens to be saved, got: %v", saved)
+		}
+	})
+
+	t.Run("logins without a refresh token fail after one attempt", func(t *testing.T) {
+		publishAttempts = 0
+		writeLogin(t, "")
+
+		err := commands.PublishCommand([]string{})
+		if err == nil || !strings.Contains(err.Error(), "login has expired") {
+			t.Errorf("Expected an expired login error, got: %v", err)
+		}
+		if publishAttempts != 1 {
+			t.Errorf("Expected 1 publish attempt, got %d", publishAttempts)
+		}
+	})
+}
+
+func TestPublishCommand_DryRun(t *testing.T) {
+	var validation apiv0.PublishValidation
+	var publishes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/publish" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("dryRun") != "true" {
+			publishes++
+		}
+		var serverJSON apiv0.ServerJSON
+		_ = json.NewDecoder(r.Body).Decode(&serverJSON)
+		_ = json.NewEncoder(w).Encode(apiv0.PublishResponse{ServerResponse: apiv0.ServerResponse{Server: serverJSON}, Validation: &validation})
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	writeServerJSON := func(t *testing.T, version string) {
+		t.Helper()
+		serverData, err := json.Marshal(apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.example/test-server",
+			Description: "A test server",
+			Version:     version,
+		})
+		if err != nil {
+			t.Fatalf("Failed to marshal test JSON: %v", err)
+		}
+		if err := os.WriteFile("server.json", serverData, 0o600); err != nil {
+			t.Fatalf("Failed to write server.json: %v", err)
+		}
+	}
+	writeServerJSON(t, "1.0.0")
+
+	t.Run("the registry checks the server without publishing it", func(t *testing.T) {
+		t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+		t.Setenv(commands.RegistryURLEnvVar, server.URL)
+
+		validation = apiv0.PublishValidation{Valid: true, Errors: []string{}, Warnings: []string{"version 1.0.0 will not be marked as latest"}}
+		if err := commands.PublishCommand([]string{"--dry-run"}); err != nil {
+			t.Errorf("Expected the dry run to pass, got: %v", err)
+		}
+
+		validation = apiv0.PublishValidation{Valid: false, Errors: []string{"You do not have permission to publish this server"}}
+		err := commands.PublishCommand([]string{"--dry-run"})
+		if err == nil || !strings.Contains(err.Error(), "dry run found 1 error(s)") {
+			t.Errorf("Expected the dry run to fail, got: %v", err)
+		}
+
+		if publishes != 0 {
+			t.Errorf("Expected nothing to be published, got %d publishes", publishes)
+		}
+	})
+
+	t.Run("without a login the server is checked locally", func(t *testing.T) {
+		t.Setenv(commands.APIKeyEnvVar, "")
+
+		if err := commands.PublishCommand([]string{"--dry-run"}); err != nil {
+			t.Errorf("Expected the local dry run to pass, got: %v", err)
+		}
+
+		writeServerJSON(t, "^1.0.0")
+		err := commands.PublishCommand([]string{"--dry-run", "--file", "server.json"})
+		if err == nil || !strings.Contains(err.Error(), "dry run found 1 error(s)") {
+			t.Errorf("Expected the local dry run to fail, got: %v", err)
+		}
+	})
+}
+
+func TestPublishCommand_Placeholders(t *testing.T) {
+	var published apiv0.ServerJSON
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&published)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiv0.PublishResponse{ServerResponse: apiv0.ServerResponse{Server: published}})
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+	t.Chdir(t.TempDir())
+
+	template := `{
+  "$schema": "` + model.CurrentSchemaURL + `",
+  "name": "io.github.example/test-server",
+  "description": "A test server, run with $${IMAGE}",
+  "version": "${VERSION}",
+  "packages": [
+    {
+      "registryType": "oci",
+      "identifier": "ghcr.io/example/test-server@${DIGEST}",
+      "transport": {"type": "stdio"}
+    }
+  ]
+}`
+	if err := os.WriteFile("server.json", []byte(template), 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	t.Setenv("VERSION", "1.0.0")
+	err := commands.PublishCommand(nil)
+	if err == nil || !strings.Contains(err.Error(), "no value for ${DIGEST}") {
+		t.Fatalf("Expected the missing placeholder to be reported, got: %v", err)
+	}
+
+	// --set takes precedence over the environment
+	if err := commands.PublishCommand([]string{"--set", "DIGEST=sha256:abc", "--set", "VERSION=1.2.0"}); err != nil {
+		t.Fatalf("Expected the publish to succeed, got: %v", err)
+	}
+	if published.Version != "1.2.0" || published.Packages[0].Identifier != "ghcr.io/example/test-server@sha256:abc" {
+		t.Errorf("Expected the placeholders to be replaced, got version %s and identifier %s", published.Version, published.Packages[0].Identifier)
+	}
+	if published.Description != "A test server, run with ${IMAGE}" {
+		t.Errorf("Expected the escaped placeholder to be kept literally, got %q", published.Description)
+	}
+}
+
+func TestPublishCommand_MultipleFiles(t *testing.T) {
+	var published []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var serverJSON apiv0.ServerJSON
+		_ = json.NewDecoder(r.Body).Decode(&serverJSON)
+		published = append(published, serverJSON.Name)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiv0.PublishResponse{ServerResponse: apiv0.ServerResponse{Server: serverJSON}})
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+	t.Chdir(t.TempDir())
+	if err := os.Mkdir("servers", 0o700); err != nil {
+		t.Fatalf("Failed to create servers: %v", err)
+	}
+	files := map[string]string{
+		"servers/a.json": model.CurrentSchemaURL,
+		"servers/b.json": "https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json",
+		"servers/c.json": model.CurrentSchemaURL,
+	}
+	for file, schema := range files {
+		name := "io.github.example/" + strings.TrimSuffix(filepath.Base(file), ".json")
+		serverData := `{"$schema": "` + schema + `", "name": "` + name + `", "description": "A test server", "version": "1.0.0"}`
+		if err := os.WriteFile(file, []byte(serverData), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+
+	// The pattern is expanded by mcp-publisher, as a shell would
+	output, err := captureStdout(t, func() error {
+		return commands.PublishCommand([]string{"servers/*.json", "--output", "json"})
+	})
+	if err == nil || err.Error() != "1 of 3 server(s) failed" || commands.ExitCode(err) != commands.ExitValidation {
+		t.Errorf("Expected one validation failure, got %v", err)
+	}
+	if strings.Join(published, ",") != "io.github.example/a,io.github.example/c" {
+		t.Errorf("Expected the other servers to be published, got %v", published)
+	}
+
+	var result struct {
+		Servers []struct {
+			File      string `json:"file"`
+			Published *struct {
+				Version string `json:"version"`
+			} `json:"published"`
+			Error string `json:"error"`
+		} `json:"servers"`
+		Failed int `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Expected a JSON result, got %q: %v", output, err)
+	}
+	if result.Failed != 1 || len(result.Servers) != 3 || result.Servers[0].Published == nil ||
+		result.Servers[1].File != "servers/b.json" || !strings.Contains(result.Servers[1].Error, "deprecated schema") {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestPublishCommand_Retry(t *testing.T) {
+	var statuses []int
+	requests := 0
+	idempotencyKeys := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := http.StatusCreated
+		if requests < len(statuses) {
+			status = statuses[requests]
+		}
+		requests++
+		idempotencyKeys[r.Header.Get("Idempotency-Key")] = true
+		if status != http.StatusCreated {
+			// Retry-After keeps the test from waiting out the backoff
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		var published apiv0.ServerJSON
+		_ = json.NewDecoder(r.Body).Decode(&published)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiv0.PublishResponse{ServerResponse: apiv0.ServerResponse{Server: published}})
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+	t.Chdir(t.TempDir())
+	serverData, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal test JSON: %v", err)
+	}
+	if err := os.WriteFile("server.json", serverData, 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		statuses     []int
+		args         []string
+		wantRequests int
+		wantExitCode int
+	}{
+		{
+			name:         "transient failures are retried",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			wantRequests: 3,
+		},
+		{
+			name:         "retries run out",
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			args:         []string{"--max-retries", "2"},
+			wantRequests: 3,
+			wantExitCode: commands.ExitServer,
+		},
+		{
+			name:         "retries turned off",
+			statuses:     []int{http.StatusInternalServerError},
+			args:         []string{"--max-retries", "0"},
+			wantRequests: 1,
+			wantExitCode: commands.ExitServer,
+		},
+		{
+			name:         "rejections are not retried",
+			statuses:     []int{http.StatusBadRequest},
+			wantRequests: 1,
+			wantExitCode: commands.ExitValidation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses, requests = tt.statuses, 0
+			clear(idempotencyKeys)
+			err := commands.PublishCommand(tt.args)
+			if requests != tt.wantRequests {
+				t.Errorf("Expected %d request(s), got %d", tt.wantRequests, requests)
+			}
+			// Retries are the same publish, so the registry publishes it at most once
+			if len(idempotencyKeys) != 1 || idempotencyKeys[""] {
+				t.Errorf("Expected every attempt to send the same Idempotency-Key, got %v", idempotencyKeys)
+			}
+			if tt.wantExitCode == 0 && err != nil {
+				t.Errorf("Expected the publish to succeed, got: %v", err)
+			}
+			if tt.wantExitCode != 0 && commands.ExitCode(err) != tt.wantExitCode {
+				t.Errorf("Expected exit code %d, got %d (%v)", tt.wantExitCode, commands.ExitCode(err), err)
+			}
+		})
+	}
+}
+
+func TestPublishCommand_TraceContext(t *testing.T) {
+	var traceParents []string
+	var traceState string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParents = append(traceParents, r.Header.Get("traceparent"))
+		traceState = r.Header.Get("tracestate")
+		if len(traceParents) == 1 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+		var published apiv0.ServerJSON
+		_ = json.NewDecoder(r.Body).Decode(&published)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(apiv0.PublishResponse{ServerResponse: apiv0.ServerResponse{Server: published}})
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+	t.Chdir(t.TempDir())
+	serverData, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.example/test-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal test JSON: %v", err)
+	}
+	if err := os.WriteFile("server.json", serverData, 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		traceParent string
+		traceState  string
+		wantTraceID string
+		wantFlags   string
+	}{
+		{
+			name:      "starts a sampled trace",
+			wantFlags: "01",
+		},
+		{
+			name:        "continues the CI job's trace",
+			traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			traceState:  "vendor=value",
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantFlags:   "00",
+		},
+		{
+			name:        "ignores an invalid trace context",
+			traceParent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			wantFlags:   "01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(commands.TraceParentEnvVar, tt.traceParent)
+			t.Setenv(commands.TraceStateEnvVar, tt.traceState)
+			traceParents, traceState = nil, ""
+			if err := commands.PublishCommand(nil); err != nil {
+				t.Fatalf("Expected the publish to succeed, got: %v", err)
+			}
+			if len(traceParents) != 2 {
+				t.Fatalf("Expected 2 requests, got %d", len(traceParents))
+			}
+
+			first, retry := strings.Split(traceParents[0], "-"), strings.Split(traceParents[1], "-")
+			if len(first) != 4 || len(retry) != 4 {
+				t.Fatalf("Expected traceparent headers, got %q", traceParents)
+			}
+			if first[1] != retry[1] || first[2] == retry[2] {
+				t.Errorf("Expected attempts to be spans of the same trace, got %q", traceParents)
+			}
+			if tt.wantTraceID != "" && first[1] != tt.wantTraceID {
+				t.Errorf("Expected trace ID %s, got %s", tt.wantTraceID, first[1])
+			}
+			if tt.wantTraceID == "" && (len(first[1]) != 32 || strings.Trim(first[1], "0") == "") {
+				t.Errorf("Expected a new trace ID, got %s", first[1])
+			}
+			if first[3] != tt.wantFlags {
+				t.Errorf("Expected trace flags %s, got %s", tt.wantFlags, first[3])
+			}
+			if traceState != tt.traceState {
+				t.Errorf("Expected tracestate %q, got %q", tt.traceState, traceState)
+			}
+		})
+	}
+}
+
+func TestPublishCommand_SigningKey(t *testing.T) {
+	var signatureHeader string
+	var verifyErr error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var published apiv0.ServerJSON
+		_ = json.NewDecoder(r.Body).Decode(&published)
+		signatureHeader = r.Header.Get(apiv0.ServerSignatureHeader)
+		response := apiv0.PublishResponse{ServerResponse: apiv0.ServerResponse{Server: published}}
+		if signatureHeader != "" {
+			var signature *apiv0.ServerSignature
+			signature, verifyErr = apiv0.ParseServerSignature(signatureHeader)
+			if verifyErr == nil {
+				verifyErr = signature.Verify(&published)
+			}
+			response.Meta.Official = &apiv0.RegistryExtensions{Signature: signature}
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(commands.APIKeyEnvVar, "mcpr_test")
+	t.Setenv(commands.RegistryURLEnvVar, server.URL)
+	t.Chdir(t.TempDir())
+	serverData, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/test-server",
+		Description: "A test server <with> characters HTML escaping would change",
+		Version:     "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal test JSON: %v", err)
+	}
+	if err := os.WriteFile("server.json", serverData, 0o600); err != nil {
+		t.Fatalf("Failed to write server.json: %v", err)
+	}
+
+	// An Ed25519 key as written by 'openssl genpkey', and an ECDSA P-384 key in hex
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(ed25519Key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	if err := os.WriteFile("ed25519.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		envKey        string
+		wantAlgorithm string
+	}{
+		{name: "unsigned"},
+		{name: "ed25519 key file", args: []string{"--signing-key", "ed25519.pem"}, wantAlgorithm: apiv0.SignatureAlgorithmEd25519},
+		{name: "ecdsa key from environment", envKey: hex.EncodeToString(ecdsaKey.D.FillBytes(make([]byte, 48))), wantAlgorithm: apiv0.SignatureAlgorithmECDSAP384},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(commands.SigningKeyEnvVar, tt.envKey)
+			signatureHeader, verifyErr = "", nil
+
+			if err := commands.PublishCommand(tt.args); err != nil {
+				t.Fatalf("Expected the publish to succeed, got: %v", err)
+			}
+			if tt.wantAlgorithm == "" {
+				if signatureHeader != "" {
+					t.Errorf("Expected no %s header, got %q", apiv0.ServerSignatureHeader, signatureHeader)
+				}
+				return
+			}
+			if !strings.HasPrefix(signatureHeader, "k="+tt.wantAlgorithm+";") {
+				t.Errorf("Expected a %s signature, got %q", tt.wantAlgorithm, signatureHeader)
+			}
+			if verifyErr != nil {
+				t.Errorf("Expected the signature to verify, got: %v", verifyErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// defaultMaxRetries is how many times publishing is retried by default, so one blip does not fail a release
+const defaultMaxRetries = 3
+
+// Delays between attempts to publish: the first retry waits about retryBaseDelay, each one after
+// twice as long, up to maxRetryDelay. A Retry-After from the registry is waited out instead, up to
+// maxRetryAfter.
+const (
+	retryBaseDelay = time.Second
+	maxRetryDelay  = 30 * time.Second
+	maxRetryAfter  = 5 * time.Minute
+)
+
+// transientError is a publish failure that may not happen again: the registry could not be reached,
+// was overloaded (429) or failed (5xx)
+type transientError struct {
+	err error
+	// retryAfter is the registry's Retry-After header, if any
+	retryAfter string
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+
+func (e *transientError) Unwrap() error { return e.err }
+
+// publishWithRetry publishes server.json, or checks it for a dry run, retrying transient failures
+// up to opts.maxRetries times with exponential backoff. Every attempt sends the same Idempotency-Key,
+// so a retry after a publish whose response was lost returns that publish rather than failing as a
+// duplicate version. Every attempt is a span of trace.
+func publishWithRetry(registryURL string, serverData []byte, token string, trace traceContext, opts publishOptions) (*apiv0.PublishResponse, error) {
+	headers := http.Header{}
+	if !opts.dryRun {
+		key := make([]byte, 16)
+		if _, err := cryptorand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate idempotency key: %w", err)
+		}
+		headers.Set("Idempotency-Key", hex.EncodeToString(key))
+	}
+	if opts.signer != nil {
+		signature, err := signServer(opts.signer, serverData)
+		if err != nil {
+			return nil, err
+		}
+		headers.Set(apiv0.ServerSignatureHeader, signature.String())
+	}
+
+	for attempt := 0; ; attempt++ {
+		trace.setHeaders(headers)
+		response, err := publishToRegistry(registryURL, serverData, token, opts.dryRun, headers)
+		var transient *transientError
+		if !errors.As(err, &transient) || attempt >= opts.maxRetries {
+			return response, err
+		}
+
+		delay := retryDelay(attempt, transient.retryAfter)
+		_, _ = fmt.Fprintf(logOutput(), "%v\nRetrying in %s (%d of %d)...\n", err, delay.Round(time.Millisecond), attempt+1, opts.maxRetries)
+		time.Sleep(delay)
+	}
+}
+
+// retryDelay returns how long to wait before retrying after a failed attempt, honoring the registry's
+// Retry-After, in seconds or as an HTTP date
+func retryDelay(attempt int, retryAfter string) time.Duration {
+	if retryAfter != "" {
+		var delay time.Duration
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			delay = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(retryAfter); err == nil {
+			delay = time.Until(date)
+		} else {
+			return retryDelay(attempt, "")
+		}
+		return min(max(delay, 0), maxRetryAfter)
+	}
+
+	delay := maxRetryDelay
+	if attempt < 8 {
+		delay = min(retryBaseDelay<<attempt, maxRetryDelay)
+	}
+	// Jitter keeps the CI jobs that failed together from retrying together
+	jitter := time.Duration(rand.Int64N(int64(delay / 4))) //nolint:gosec // Not used for security
+	return delay - delay/8 + jitter
+}
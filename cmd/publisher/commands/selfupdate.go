@@ -0,0 +1,368 @@
+package commands
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Version is the version of this mcp-publisher binary, set by main
+var Version = "dev"
+
+// ReleaseSigningKey is the hex Ed25519 public key that release checksums files are signed with, set by
+// main. Builds without it cannot verify releases, so do not update themselves.
+var ReleaseSigningKey = ""
+
+const (
+	// selfUpdateTimeout bounds fetching the release manifest and downloading the archive
+	selfUpdateTimeout = 5 * time.Minute
+	// maxArchiveSize is the largest release archive self-update downloads
+	maxArchiveSize = 100 << 20
+	// maxChecksumsSize is the largest checksums file or signature self-update downloads
+	maxChecksumsSize = 1 << 20
+)
+
+// SelfUpdateCommand replaces this binary with the latest release. The release manifest comes from the
+// registry, and the archive downloaded is only installed if its SHA-256 checksum matches the release's
+// checksums file, whose signature must be made with ReleaseSigningKey. Everything is fetched over HTTPS.
+func SelfUpdateCommand(args []string) error {
+	updateFlags := flag.NewFlagSet("self-update", flag.ExitOnError)
+	registryURL := updateFlags.String("registry", defaultRegistryURL(), "Registry to get the release manifest from")
+	check := updateFlags.Bool("check", false, "Only report whether an update is available")
+	force := updateFlags.Bool("force", false, "Install the latest release even if it is not newer, or this is a development build")
+	installPath := updateFlags.String("path", "", "Binary to replace (default: this mcp-publisher)")
+	addOutputFlag(updateFlags)
+	addCACertFlag(updateFlags)
+	addDebugFlag(updateFlags)
+	if err := parseFlags(updateFlags, args); err != nil {
+		return err
+	}
+
+	if err := requireHTTPS("--registry", *registryURL); err != nil {
+		return withExitCode(ExitUsage, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), selfUpdateTimeout)
+	defer cancel()
+
+	release, err := fetchPublisherRelease(ctx, *registryURL)
+	if err != nil {
+		return err
+	}
+	result := selfUpdateResult{CurrentVersion: Version, LatestVersion: release.Version, ReleaseURL: release.URL}
+
+	result.UpdateAvailable = isNewerRelease(release.Version, Version)
+	switch {
+	case !result.UpdateAvailable && (*check || !*force):
+		if semver.IsValid(canonicalVersion(Version)) {
+			_, _ = fmt.Fprintf(logOutput(), "mcp-publisher %s is up to date (latest release: %s)\n", Version, release.Version)
+		} else {
+			_, _ = fmt.Fprintf(logOutput(), "mcp-publisher %s is a development build, so is not updated. Use --force to install %s.\n", Version, release.Version)
+		}
+		return printResult(result)
+	case *check:
+		_, _ = fmt.Fprintf(logOutput(), "mcp-publisher %s is available (installed: %s). Run 'mcp-publisher self-update' to install it.\n", release.Version, Version)
+		if release.URL != "" {
+			_, _ = fmt.Fprintf(logOutput(), "Release notes: %s\n", release.URL)
+		}
+		return printResult(result)
+	}
+
+	asset, err := releaseAsset(release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	target := *installPath
+	if target == "" {
+		if target, err = os.Executable(); err != nil {
+			return fmt.Errorf("failed to find this binary: %w", err)
+		}
+	}
+	if resolved, err := filepath.EvalSymlinks(target); err == nil {
+		target = resolved
+	}
+
+	checksums, err := fetchSignedChecksums(ctx, release)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(logOutput(), "Downloading %s...\n", asset.URL)
+	archive, err := downloadVerified(ctx, asset, checksums[asset.Name])
+	if err != nil {
+		return err
+	}
+	binary, err := extractPublisherBinary(asset.Name, archive)
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", asset.Name, err)
+	}
+	if err := replaceBinary(target, binary); err != nil {
+		return fmt.Errorf("failed to replace %s: %w. Is it writable by you?", target, err)
+	}
+
+	result.Updated = true
+	result.Path = target
+	_, _ = fmt.Fprintf(logOutput(), "✓ Updated %s from %s to %s (SHA-256 %s verified)\n", target, Version, release.Version, checksums[asset.Name])
+	return printResult(result)
+}
+
+// selfUpdateResult is the JSON result of 'mcp-publisher self-update'
+type selfUpdateResult struct {
+	CurrentVersion  string `json:"currentVersion"`
+	LatestVersion   string `json:"latestVersion"`
+	ReleaseURL      string `json:"releaseUrl,omitempty"`
+	UpdateAvailable bool   `json:"updateAvailable"`
+	Updated         bool   `json:"updated"`
+	Path            string `json:"path,omitempty"`
+}
+
+// fetchPublisherRelease gets the manifest of the latest release from the registry
+func fetchPublisherRelease(ctx context.Context, registryURL string) (*apiv0.PublisherRelease, error) {
+	manifestURL := strings.TrimSuffix(registryURL, "/") + "/v0/publisher/release"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpsClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errRegistryUnreachable, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, registryStatusError(resp.StatusCode, body)
+	}
+
+	var release apiv0.PublisherRelease
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("error parsing release manifest: %w", err)
+	}
+	return &release, nil
+}
+
+// isNewerRelease reports whether latest is a newer version than current. Development builds have no
+// version to compare, so are never older.
+func isNewerRelease(latest, current string) bool {
+	latest, current = canonicalVersion(latest), canonicalVersion(current)
+	if !semver.IsValid(latest) || !semver.IsValid(current) {
+		return false
+	}
+	return semver.Compare(latest, current) > 0
+}
+
+// canonicalVersion returns a version with the "v" prefix semver expects
+func canonicalVersion(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+	return version
+}
+
+// releaseAsset returns the archive of a platform in the release
+func releaseAsset(release *apiv0.PublisherRelease, goos, goarch string) (*apiv0.PublisherReleaseAsset, error) {
+	for i, asset := range release.Assets {
+		if asset.OS == goos && asset.Arch == goarch {
+			return &release.Assets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("release %s has no mcp-publisher for %s/%s", release.Version, goos, goarch)
+}
+
+// httpsClient makes the requests of self-update, and refuses redirects away from HTTPS
+var httpsClient = &http.Client{
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return requireHTTPS("Redirect", req.URL.String())
+	},
+}
+
+// requireHTTPS refuses URLs that downloads could be tampered with on the way from
+func requireHTTPS(what, rawURL string) error {
+	if !strings.HasPrefix(rawURL, "https://") {
+		return fmt.Errorf("%s must be an HTTPS URL, got %q", what, rawURL)
+	}
+	return nil
+}
+
+// fetchSignedChecksums downloads the release's checksums file and returns its checksums by file name,
+// once its signature has been checked with ReleaseSigningKey. The registry only relays the release, so
+// the signature is what shows the checksums come from the release workflow.
+func fetchSignedChecksums(ctx context.Context, release *apiv0.PublisherRelease) (map[string]string, error) {
+	key, err := hex.DecodeString(ReleaseSigningKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("this build of mcp-publisher has no release signing key to verify releases with. Download the release from GitHub instead")
+	}
+	if release.ChecksumsURL == "" || release.ChecksumsSignatureURL == "" {
+		return nil, withExitCode(ExitValidation, fmt.Errorf("release %s has no signed checksums file, so cannot be verified", release.Version))
+	}
+
+	checksums, err := download(ctx, "the checksums file", release.ChecksumsURL, maxChecksumsSize)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := download(ctx, "the checksums signature", release.ChecksumsSignatureURL, maxChecksumsSize)
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(key, checksums, signature) {
+		return nil, withExitCode(ExitValidation, fmt.Errorf("the checksums file of release %s is not signed with the release signing key. Nothing was installed", release.Version))
+	}
+
+	byName := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && len(fields[0]) == 64 {
+			byName[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+		}
+	}
+	return byName, nil
+}
+
+// downloadVerified downloads a release archive and checks it against its checksum in the signed checksums file
+func downloadVerified(ctx context.Context, asset *apiv0.PublisherReleaseAsset, checksum string) ([]byte, error) {
+	if checksum == "" {
+		return nil, withExitCode(ExitValidation, fmt.Errorf("%s is not in the release's checksums file, so cannot be verified", asset.Name))
+	}
+	archive, err := download(ctx, asset.Name, asset.URL, maxArchiveSize)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(archive)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, checksum) {
+		return nil, withExitCode(ExitValidation, fmt.Errorf("checksum mismatch for %s: expected SHA-256 %s, got %s. The download was not installed", asset.Name, checksum, got))
+	}
+	return archive, nil
+}
+
+// download fetches a release file over HTTPS, refusing files larger than limit
+func download(ctx context.Context, name, fileURL string, limit int) ([]byte, error) {
+	if err := requireHTTPS("The download URL of "+name, fileURL); err != nil {
+		return nil, withExitCode(ExitValidation, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	resp, err := httpsClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: status %d", name, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(limit)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	if len(data) > limit {
+		return nil, fmt.Errorf("%s is larger than %d MB", name, limit>>20)
+	}
+	return data, nil
+}
+
+// extractPublisherBinary returns the mcp-publisher binary of a .tar.gz or .zip release archive
+func extractPublisherBinary(name string, archive []byte) ([]byte, error) {
+	isBinary := func(file string) bool {
+		base := path.Base(file)
+		return base == "mcp-publisher" || base == "mcp-publisher.exe"
+	}
+
+	if strings.HasSuffix(name, ".zip") {
+		reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range reader.File {
+			if !isBinary(file.Name) {
+				continue
+			}
+			rc, err := file.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return io.ReadAll(io.LimitReader(rc, maxArchiveSize))
+		}
+		return nil, errors.New("no mcp-publisher binary in the archive")
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("no mcp-publisher binary in the archive")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag == tar.TypeReg && isBinary(header.Name) {
+			return io.ReadAll(io.LimitReader(reader, maxArchiveSize))
+		}
+	}
+}
+
+// replaceBinary writes binary next to target and renames it over target, so target is never left half
+// written. A running binary cannot be replaced on Windows, so it is moved aside first.
+func replaceBinary(target string, binary []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".mcp-publisher-update-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(binary); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil { //nolint:gosec // The binary must be executable
+		return err
+	}
+
+	if runtime.GOOS == "windows" {
+		old := target + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(target, old); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), target)
+}
@@ -0,0 +1,188 @@
+package commands_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// publisherArchive returns a release archive holding an mcp-publisher binary with the given contents
+func publisherArchive(t *testing.T, binary string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, contents := range map[string]string{"README.md": "readme", "mcp-publisher": binary} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(contents)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("Failed to write archive: %v", err)
+		}
+		if _, err := tw.Write([]byte(contents)); err != nil {
+			t.Fatalf("Failed to write archive: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Failed to write archive: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to write archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestSelfUpdateCommand(t *testing.T) {
+	archive := publisherArchive(t, "new binary")
+	checksum := sha256.Sum256(archive)
+	checksums := []byte(hex.EncodeToString(checksum[:]) + "  mcp-publisher_" + runtime.GOOS + "_" + runtime.GOARCH + ".tar.gz\n")
+
+	publicKey, signingKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	signature := ed25519.Sign(signingKey, checksums)
+	served := archive
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v0/publisher/release":
+			_ = json.NewEncoder(w).Encode(apiv0.PublisherRelease{
+				Version: "v1.3.0",
+				Assets: []apiv0.PublisherReleaseAsset{{
+					OS:     runtime.GOOS,
+					Arch:   runtime.GOARCH,
+					Name:   "mcp-publisher_" + runtime.GOOS + "_" + runtime.GOARCH + ".tar.gz",
+					URL:    server.URL + "/download/mcp-publisher.tar.gz",
+					SHA256: hex.EncodeToString(checksum[:]),
+				}},
+				ChecksumsURL:          server.URL + "/download/checksums.txt",
+				ChecksumsSignatureURL: server.URL + "/download/checksums.txt.ed25519.sig",
+			})
+		case "/download/mcp-publisher.tar.gz":
+			_, _ = w.Write(served)
+		case "/download/checksums.txt":
+			_, _ = w.Write(checksums)
+		case "/download/checksums.txt.ed25519.sig":
+			_, _ = w.Write(signature)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// Trust the test server's certificate
+	transport := http.DefaultTransport.(*http.Transport)
+	originalTLSConfig := transport.TLSClientConfig
+	t.Cleanup(func() { transport.TLSClientConfig = originalTLSConfig })
+	transport.TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	t.Setenv("HOME", t.TempDir())
+	originalVersion, originalKey := commands.Version, commands.ReleaseSigningKey
+	t.Cleanup(func() { commands.Version, commands.ReleaseSigningKey = originalVersion, originalKey })
+	commands.Version = "1.2.0"
+	commands.ReleaseSigningKey = hex.EncodeToString(publicKey)
+
+	binaryPath := filepath.Join(t.TempDir(), "mcp-publisher")
+	if err := os.WriteFile(binaryPath, []byte("old binary"), 0o755); err != nil { //nolint:gosec // Test binary
+		t.Fatalf("Failed to write binary: %v", err)
+	}
+	readBinary := func() string {
+		data, err := os.ReadFile(binaryPath)
+		if err != nil {
+			t.Fatalf("Failed to read binary: %v", err)
+		}
+		return string(data)
+	}
+
+	// --check reports the update without installing it
+	stdout, err := captureStdout(t, func() error {
+		return commands.SelfUpdateCommand([]string{"--registry", server.URL, "--path", binaryPath, "--check", "--output", "json"})
+	})
+	if err != nil {
+		t.Fatalf("self-update --check failed: %v", err)
+	}
+	var result struct {
+		UpdateAvailable bool `json:"updateAvailable"`
+		Updated         bool `json:"updated"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil || !result.UpdateAvailable || result.Updated || readBinary() != "old binary" {
+		t.Errorf("Expected --check to report the update and leave the binary alone, got %s", stdout)
+	}
+
+	// Registries not reached over HTTPS are refused
+	err = commands.SelfUpdateCommand([]string{"--registry", strings.Replace(server.URL, "https://", "http://", 1), "--path", binaryPath})
+	if err == nil || !strings.Contains(err.Error(), "HTTPS") || commands.ExitCode(err) != commands.ExitUsage {
+		t.Errorf("Expected an HTTP registry to be refused, got: %v", err)
+	}
+
+	// Checksums not signed with the release signing key are not trusted
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	signature = ed25519.Sign(otherKey, checksums)
+	err = commands.SelfUpdateCommand([]string{"--registry", server.URL, "--path", binaryPath})
+	if err == nil || !strings.Contains(err.Error(), "not signed with the release signing key") || commands.ExitCode(err) != commands.ExitValidation {
+		t.Errorf("Expected a signature mismatch, got: %v", err)
+	}
+	if readBinary() != "old binary" {
+		t.Errorf("Expected the binary to be left alone after a signature mismatch")
+	}
+	signature = ed25519.Sign(signingKey, checksums)
+
+	// A download that does not match the signed checksums is not installed
+	served = publisherArchive(t, "tampered binary")
+	err = commands.SelfUpdateCommand([]string{"--registry", server.URL, "--path", binaryPath})
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") || commands.ExitCode(err) != commands.ExitValidation {
+		t.Errorf("Expected a checksum mismatch, got: %v", err)
+	}
+	if readBinary() != "old binary" {
+		t.Errorf("Expected the binary to be left alone after a checksum mismatch")
+	}
+	served = archive
+
+	// Builds without a release signing key cannot verify releases
+	commands.ReleaseSigningKey = ""
+	err = commands.SelfUpdateCommand([]string{"--registry", server.URL, "--path", binaryPath})
+	if err == nil || !strings.Contains(err.Error(), "no release signing key") {
+		t.Errorf("Expected a build without a release signing key to refuse to update, got: %v", err)
+	}
+	commands.ReleaseSigningKey = hex.EncodeToString(publicKey)
+
+	if err := commands.SelfUpdateCommand([]string{"--registry", server.URL, "--path", binaryPath}); err != nil {
+		t.Fatalf("self-update failed: %v", err)
+	}
+	if got := readBinary(); got != "new binary" {
+		t.Errorf("Expected the binary to be replaced, got %q", got)
+	}
+	if info, err := os.Stat(binaryPath); err == nil && runtime.GOOS != "windows" && info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("Expected the new binary to be executable, got mode %v", info.Mode())
+	}
+
+	// Once up to date, and for development builds, nothing is installed
+	for _, version := range []string{"v1.3.0", "dev"} {
+		commands.Version = version
+		if err := os.WriteFile(binaryPath, []byte("old binary"), 0o755); err != nil { //nolint:gosec // Test binary
+			t.Fatalf("Failed to write binary: %v", err)
+		}
+		if err := commands.SelfUpdateCommand([]string{"--registry", server.URL, "--path", binaryPath}); err != nil {
+			t.Fatalf("self-update failed: %v", err)
+		}
+		if readBinary() != "old binary" {
+			t.Errorf("Expected version %s not to be updated", version)
+		}
+	}
+}
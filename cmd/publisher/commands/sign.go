@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// loadSigner returns the signer for the private key in signingKeyFile or else MCP_PUBLISHER_SIGNING_KEY,
+// or nil when neither gives one and server.json is published unsigned
+func loadSigner(signingKeyFile string) (*auth.Signer, error) {
+	key := os.Getenv(SigningKeyEnvVar)
+	if signingKeyFile != "" {
+		data, err := os.ReadFile(signingKeyFile)
+		if err != nil {
+			return nil, withExitCode(ExitUsage, fmt.Errorf("failed to read signing key: %w", err))
+		}
+		key = string(data)
+	}
+	if key == "" {
+		return nil, nil //nolint:nilnil // No key means publishing unsigned
+	}
+
+	signer, err := auth.NewSigner(key)
+	if err != nil {
+		return nil, withExitCode(ExitUsage, fmt.Errorf("invalid signing key: %w", err))
+	}
+	return signer, nil
+}
+
+// signServer signs the canonical form of the server.json the registry is sent, which it checks the
+// signature against and stores
+func signServer(signer *auth.Signer, serverData []byte) (*apiv0.ServerSignature, error) {
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(serverData, &serverJSON); err != nil {
+		return nil, fmt.Errorf("error parsing server.json file: %w", err)
+	}
+	message, err := apiv0.CanonicalServerJSON(&serverJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := signer.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.Sign(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign server.json: %w", err)
+	}
+	return &apiv0.ServerSignature{
+		Algorithm: string(signer.Algorithm()),
+		PublicKey: publicKey,
+		Value:     base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
+)
+
+// StatusCommand shows the saved login of a profile: the registry, how it logged in, when its tokens
+// expire and the permissions they grant, read from the token's claims without contacting the registry
+func StatusCommand(args []string) error {
+	statusFlags := flag.NewFlagSet("status", flag.ExitOnError)
+	profile := addProfileFlag(statusFlags)
+	addOutputFlag(statusFlags)
+	if err := parseFlags(statusFlags, args); err != nil {
+		return err
+	}
+
+	result := statusResult{Profile: *profile}
+	usingAPIKey := os.Getenv(APIKeyEnvVar) != ""
+	if usingAPIKey {
+		registryURL := os.Getenv(RegistryURLEnvVar)
+		if registryURL == "" {
+			registryURL = defaultRegistryURL()
+		}
+		result.APIKey = &apiKeyStatus{Registry: registryURL}
+		_, _ = fmt.Fprintf(logOutput(), "Publishing with the API token in %s to %s, instead of a saved login.\n", APIKeyEnvVar, registryURL)
+		_, _ = fmt.Fprintln(logOutput(), "API tokens are opaque, so their permissions cannot be shown.")
+		_, _ = fmt.Fprintln(logOutput())
+	}
+
+	login, err := readSavedLogin(*profile)
+	if err != nil {
+		if usingAPIKey {
+			return printResult(result)
+		}
+		return err
+	}
+	tokenPath, err := savedLoginPath(*profile)
+	if err != nil {
+		return err
+	}
+
+	storage := tokenPath
+	if login.Keychain {
+		storage = "OS keychain (" + tokenPath + " says where the login is for)"
+	}
+	_, _ = fmt.Fprintf(logOutput(), "Profile:   %s\n", *profile)
+	_, _ = fmt.Fprintf(logOutput(), "Registry:  %s\n", login.Registry)
+	_, _ = fmt.Fprintf(logOutput(), "Method:    %s\n", login.Method)
+	_, _ = fmt.Fprintf(logOutput(), "Stored in: %s\n", storage)
+
+	claims, err := auth.DecodeRegistryToken(login.Token)
+	if err != nil {
+		return fmt.Errorf("the saved token cannot be read (%w). Run 'mcp-publisher login %s' again", err, login.Method)
+	}
+	result.Login = &loginStatus{
+		Registry:    login.Registry,
+		Method:      login.Method,
+		Keychain:    login.Keychain,
+		Identity:    claims.AuthMethodSubject,
+		Renewable:   login.renewable(),
+		StepUp:      claims.StepUp,
+		Permissions: append([]auth.TokenPermission{}, claims.Permissions...),
+	}
+	if expiry := claims.Expiry(); !expiry.IsZero() {
+		result.Login.ExpiresAt = &expiry
+	}
+	if claims.AuthMethodSubject != "" {
+		_, _ = fmt.Fprintf(logOutput(), "Identity:  %s\n", claims.AuthMethodSubject)
+	}
+	_, _ = fmt.Fprintf(logOutput(), "Token:     %s\n", describeExpiry(claims.Expiry()))
+
+	switch {
+	case login.RefreshToken != "":
+		renewal := "automatic, with a refresh token"
+		if refreshClaims, err := auth.DecodeRegistryToken(login.RefreshToken); err == nil {
+			renewal += " that " + describeExpiry(refreshClaims.Expiry())
+		}
+		_, _ = fmt.Fprintf(logOutput(), "Renewal:   %s\n", renewal)
+	case login.Method == "github-oidc":
+		_, _ = fmt.Fprintln(logOutput(), "Renewal:   automatic in GitHub Actions, with a new OIDC token")
+	case login.Method == "gitlab-oidc":
+		_, _ = fmt.Fprintln(logOutput(), "Renewal:   automatic in GitLab CI, with the job's ID token")
+	default:
+		_, _ = fmt.Fprintf(logOutput(), "Renewal:   none; run 'mcp-publisher login %s' again once the token expires\n", login.Method)
+	}
+	if claims.StepUp {
+		_, _ = fmt.Fprintln(logOutput(), "Passkey:   verified, so admin operations are allowed")
+	}
+
+	_, _ = fmt.Fprintln(logOutput())
+	if len(claims.Permissions) == 0 {
+		_, _ = fmt.Fprintln(logOutput(), "Permissions: none, so this login cannot publish any server")
+		return printResult(result)
+	}
+	_, _ = fmt.Fprintln(logOutput(), "Permissions:")
+	for _, permission := range claims.Permissions {
+		_, _ = fmt.Fprintf(logOutput(), "  %-8s %s\n", permission.Action, permission.Resource)
+	}
+	if !claims.Expiry().IsZero() && time.Now().After(claims.Expiry()) {
+		_, _ = fmt.Fprintln(logOutput())
+		_, _ = fmt.Fprintln(logOutput(), "The token has expired. The permissions are those it was issued with; renewing it may grant different ones.")
+	}
+	return printResult(result)
+}
+
+// statusResult is the JSON result of 'mcp-publisher status'
+type statusResult struct {
+	Profile string `json:"profile"`
+	// APIKey is set when MCP_PUBLISHER_API_KEY is used instead of the saved login
+	APIKey *apiKeyStatus `json:"apiKey,omitempty"`
+	// Login is the saved login of the profile, if there is one
+	Login *loginStatus `json:"login,omitempty"`
+}
+
+type apiKeyStatus struct {
+	Registry string `json:"registry"`
+}
+
+type loginStatus struct {
+	Registry    string                 `json:"registry"`
+	Method      string                 `json:"method"`
+	Keychain    bool                   `json:"keychain"`
+	Identity    string                 `json:"identity,omitempty"`
+	ExpiresAt   *time.Time             `json:"expiresAt,omitempty"`
+	Renewable   bool                   `json:"renewable"`
+	StepUp      bool                   `json:"stepUp"`
+	Permissions []auth.TokenPermission `json:"permissions"`
+}
+
+// describeExpiry describes when a token expires, relative to now
+func describeExpiry(expiry time.Time) string {
+	if expiry.IsZero() {
+		return "does not expire"
+	}
+	remaining := time.Until(expiry).Round(time.Second)
+	if remaining <= 0 {
+		return fmt.Sprintf("expired %s ago (%s)", -remaining, expiry.Local().Format(time.RFC3339))
+	}
+	return fmt.Sprintf("expires in %s (%s)", remaining, expiry.Local().Format(time.RFC3339))
+}
@@ -0,0 +1,96 @@
+package commands_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+)
+
+// fakeRegistryToken returns an unsigned JWT with claims, which is all the CLI reads of registry tokens
+func fakeRegistryToken(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("Failed to marshal claims: %v", err)
+	}
+	return "header." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+// captureStdout returns what fn writes to stdout
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	originalStdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = originalStdout }()
+
+	fnErr := fn()
+	_ = writer.Close()
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	return string(output), fnErr
+}
+
+func TestStatusCommand(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv(commands.APIKeyEnvVar, "")
+	t.Setenv(commands.ProfileEnvVar, "")
+
+	token := fakeRegistryToken(t, map[string]any{
+		"exp":             time.Now().Add(4*time.Minute + 30*time.Second).Unix(),
+		"auth_method":     "github-at",
+		"auth_method_sub": "octocat",
+		"permissions": []map[string]string{
+			{"action": "publish", "resource": "io.github.octocat/*"},
+			{"action": "edit", "resource": "io.github.acme/*"},
+		},
+	})
+	refreshToken := fakeRegistryToken(t, map[string]any{"exp": time.Now().Add(12*time.Hour + 30*time.Second).Unix(), "token_type": "refresh"})
+	tokenData, err := json.Marshal(map[string]string{
+		"token":         token,
+		"method":        "github",
+		"registry":      "https://staging.example.com",
+		"refresh_token": refreshToken,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal token data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(homeDir, commands.TokenFileName+".staging"), tokenData, 0o600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+
+	output, err := captureStdout(t, func() error { return commands.StatusCommand([]string{"--profile", "staging"}) })
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	for _, expected := range []string{
+		"Registry:  https://staging.example.com",
+		"Method:    github",
+		"Identity:  octocat",
+		"Token:     expires in 4m",
+		"automatic, with a refresh token that expires in 12h0m",
+		"publish  io.github.octocat/*",
+		"edit     io.github.acme/*",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected the status to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	if _, err := captureStdout(t, func() error { return commands.StatusCommand([]string{}) }); err == nil || !strings.Contains(err.Error(), "not authenticated") {
+		t.Errorf("Expected a not authenticated error for the default profile, got: %v", err)
+	}
+}
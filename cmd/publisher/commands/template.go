@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// placeholderPattern matches the ${NAME} placeholders of a server.json template, and $${NAME} to write
+// one literally
+var placeholderPattern = regexp.MustCompile(`\$(\$?)\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// templateValues holds the --set NAME=VALUE flags, which can be given more than once
+type templateValues map[string]string
+
+func (v templateValues) String() string {
+	pairs := make([]string, 0, len(v))
+	for name, value := range v {
+		pairs = append(pairs, name+"="+value)
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (v templateValues) Set(pair string) error {
+	name, value, ok := strings.Cut(pair, "=")
+	if !ok || !placeholderPattern.MatchString("${"+name+"}") {
+		return fmt.Errorf("invalid value %q: expected NAME=VALUE", pair)
+	}
+	v[name] = value
+	return nil
+}
+
+// substitutePlaceholders replaces the ${NAME} placeholders in server.json with the --set value of NAME or
+// else the environment variable NAME, so a checked-in template can be completed by a CI job. It fails if a
+// placeholder has no value rather than publishing it as is.
+func substitutePlaceholders(serverData []byte, values templateValues) ([]byte, error) {
+	var missing []string
+	substituted := placeholderPattern.ReplaceAllFunc(serverData, func(match []byte) []byte {
+		groups := placeholderPattern.FindSubmatch(match)
+		name := string(groups[2])
+		if len(groups[1]) > 0 {
+			return match[1:]
+		}
+
+		value, ok := values[name]
+		if !ok {
+			value, ok = os.LookupEnv(name)
+		}
+		if !ok {
+			if !slices.Contains(missing, name) {
+				missing = append(missing, name)
+			}
+			return match
+		}
+		// Placeholders are inside JSON strings, so the value is escaped as one
+		quoted, _ := json.Marshal(value)
+		return quoted[1 : len(quoted)-1]
+	})
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no value for ${%s}: set it with --set NAME=VALUE or an environment variable, or write $${NAME} for a literal ${NAME}",
+			strings.Join(missing, "}, ${"))
+	}
+	return substituted, nil
+}
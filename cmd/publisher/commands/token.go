@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
+)
+
+// tokenRefreshMargin is how long before it expires a saved registry token is renewed, so it does
+// not expire in the middle of a request
+const tokenRefreshMargin = time.Minute
+
+// errTokenRejected is returned when the registry answers a request with 401 Unauthorized, so the
+// caller can renew the saved login and retry
+var errTokenRejected = errors.New("the registry rejected your token")
+
+// profileNamePattern matches valid profile names, which become part of a file name
+var profileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,63}$`)
+
+// savedLogin is the login of a profile saved by 'mcp-publisher login': the default profile's in
+// TokenFileName in the home directory, and each other profile's in TokenFileName.<profile>. Unless
+// the login was saved with --insecure-token-file, its tokens are kept in the OS keychain instead of
+// the file, which only says where the login is for.
+type savedLogin struct {
+	Token        string `json:"token,omitempty"`
+	Method       string `json:"method"`
+	Registry     string `json:"registry"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Keychain     bool   `json:"keychain,omitempty"`
+
+	profile string
+}
+
+// addProfileFlag adds the --profile flag to a command's flags, defaulting to MCP_PUBLISHER_PROFILE,
+// the profile of the configuration file or else DefaultProfile
+func addProfileFlag(flags *flag.FlagSet) *string {
+	profile := os.Getenv(ProfileEnvVar)
+	if profile == "" {
+		profile = config.Profile
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	return flags.String("profile", profile, "Saved login to use, to work with several registries")
+}
+
+// savedLoginPath returns the path of a profile's token file
+func savedLoginPath(profile string) (string, error) {
+	if !profileNamePattern.MatchString(profile) {
+		return "", fmt.Errorf("invalid profile name %q: use up to 64 letters, digits, '-' and '_'", profile)
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	fileName := TokenFileName
+	if profile != DefaultProfile {
+		fileName += "." + profile
+	}
+	return filepath.Join(homeDir, fileName), nil
+}
+
+// savedProfiles returns the profiles with a saved login
+func savedProfiles() ([]string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	entries, err := os.ReadDir(homeDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read home directory: %w", err)
+	}
+
+	var profiles []string
+	for _, entry := range entries {
+		if entry.Name() == TokenFileName {
+			profiles = append(profiles, DefaultProfile)
+		} else if profile, ok := strings.CutPrefix(entry.Name(), TokenFileName+"."); ok && profileNamePattern.MatchString(profile) {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles, nil
+}
+
+// readSavedLogin reads the saved login of a profile, defaulting its registry to DefaultRegistryURL
+func readSavedLogin(profile string) (*savedLogin, error) {
+	tokenPath, err := savedLoginPath(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenData, err := os.ReadFile(tokenPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if profile != DefaultProfile {
+				return nil, fmt.Errorf("%w with profile %s. Run 'mcp-publisher login <method> --profile %s' first", errNotAuthenticated, profile, profile)
+			}
+			return nil, fmt.Errorf("%w. Run 'mcp-publisher login <method>' first", errNotAuthenticated)
+		}
+		return nil, fmt.Errorf("failed to read token: %w", err)
+	}
+
+	login := savedLogin{profile: profile}
+	if err := json.Unmarshal(tokenData, &login); err != nil {
+		return nil, fmt.Errorf("invalid token data: %w", err)
+	}
+	if login.Registry == "" {
+		login.Registry = DefaultRegistryURL
+	}
+	if login.Keychain {
+		if login.Token, login.RefreshToken, err = readKeychainTokens(profile); err != nil {
+			return nil, err
+		}
+	}
+
+	return &login, nil
+}
+
+// save writes the login to its profile's token file, readable by the current user only, and its tokens
+// to the OS keychain unless they are kept in the file
+func (l *savedLogin) save() error {
+	tokenPath, err := savedLoginPath(l.profile)
+	if err != nil {
+		return err
+	}
+
+	fileLogin := *l
+	if l.Keychain {
+		if err := writeKeychainTokens(l.profile, l.Token, l.RefreshToken); err != nil {
+			return err
+		}
+		fileLogin.Token, fileLogin.RefreshToken = "", ""
+	}
+
+	jsonData, err := json.Marshal(fileLogin)
+	if err != nil {
+		return fmt.Errorf("failed to marshal token data: %w", err)
+	}
+	if err := os.WriteFile(tokenPath, jsonData, 0600); err != nil {
+		return fmt.Errorf("failed to save token: %w", err)
+	}
+
+	return nil
+}
+
+// renewable reports whether the login can be renewed without the user logging in again: with its
+// refresh token, or in GitHub Actions and GitLab CI by exchanging the job's OIDC token again
+func (l *savedLogin) renewable() bool {
+	return l.RefreshToken != "" || l.ciProvider() != nil
+}
+
+// ciProvider returns the provider of a CI login, whose OIDC token the job can always exchange again, or
+// nil for other logins
+func (l *savedLogin) ciProvider() auth.Provider {
+	switch l.Method {
+	case "github-oidc":
+		return auth.NewGitHubOIDCProvider(l.Registry)
+	case "gitlab-oidc":
+		return auth.NewGitLabOIDCProvider(l.Registry)
+	}
+	return nil
+}
+
+// renew replaces the registry token and saves the login
+func (l *savedLogin) renew(ctx context.Context) error {
+	if !l.renewable() {
+		return fmt.Errorf("%w. Run 'mcp-publisher login <method>' again", errLoginExpired)
+	}
+
+	if l.RefreshToken != "" {
+		refreshed, err := auth.RefreshRegistryToken(ctx, l.Registry, l.RefreshToken)
+		if err != nil {
+			return fmt.Errorf("%w and could not be renewed. Run 'mcp-publisher login <method>' again: %w", errLoginExpired, err)
+		}
+		l.Token = refreshed.RegistryToken
+		l.RefreshToken = refreshed.RefreshToken
+	} else {
+		token, err := l.ciProvider().GetToken(ctx)
+		if err != nil {
+			return fmt.Errorf("%w and could not be renewed. Run 'mcp-publisher login %s' again: %w", errLoginExpired, l.Method, err)
+		}
+		l.Token = token
+	}
+
+	return l.save()
+}
+
+// loadSavedToken returns the registry token saved by 'mcp-publisher login' for a profile and the
+// registry it is for. A token that is about to expire is renewed first, if the login can be renewed.
+func loadSavedToken(profile string) (string, string, error) {
+	login, err := readSavedLogin(profile)
+	if err != nil {
+		return "", "", err
+	}
+
+	if login.renewable() && auth.TokenExpiresWithin(login.Token, tokenRefreshMargin) {
+		if err := login.renew(context.Background()); err != nil {
+			return "", "", err
+		}
+	}
+
+	return login.Token, login.Registry, nil
+}
+
+// renewSavedToken renews the saved login of a profile after the registry rejected its token, which
+// happens when the token expired early or was revoked, and returns the new registry token
+func renewSavedToken(profile string) (string, error) {
+	login, err := readSavedLogin(profile)
+	if err != nil {
+		return "", err
+	}
+	if err := login.renew(context.Background()); err != nil {
+		return "", err
+	}
+	return login.Token, nil
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// TraceParentEnvVar and TraceStateEnvVar carry the W3C trace context of a CI job, as set by tools that
+// trace pipelines, so the registry's spans for a publish join the job's trace
+const (
+	TraceParentEnvVar = "TRACEPARENT"
+	TraceStateEnvVar  = "TRACESTATE"
+)
+
+// traceContext is the W3C trace context the requests of a publish are sent with: they share the trace
+// ID, each with a span ID of its own
+type traceContext struct {
+	traceID string
+	flags   string
+	state   string
+}
+
+// newTraceContext continues the trace in TRACEPARENT, if it is valid, or else starts a sampled trace so
+// the registry records its spans for the publish
+func newTraceContext() traceContext {
+	if traceID, flags, ok := parseTraceParent(os.Getenv(TraceParentEnvVar)); ok {
+		return traceContext{traceID: traceID, flags: flags, state: os.Getenv(TraceStateEnvVar)}
+	}
+	return traceContext{traceID: randomHex(16), flags: "01"}
+}
+
+// setHeaders sets the traceparent, and any tracestate, of a request within the trace
+func (t traceContext) setHeaders(headers http.Header) {
+	headers.Set("traceparent", "00-"+t.traceID+"-"+randomHex(8)+"-"+t.flags)
+	if t.state != "" {
+		headers.Set("tracestate", t.state)
+	}
+}
+
+// parseTraceParent returns the trace ID and flags of a version 00 traceparent header
+func parseTraceParent(traceParent string) (string, string, bool) {
+	parts := strings.Split(strings.TrimSpace(traceParent), "-")
+	if len(parts) != 4 || parts[0] != "00" {
+		return "", "", false
+	}
+	traceID, parentID, flags := parts[1], parts[2], parts[3]
+	if !isTraceHex(traceID, 32) || !isTraceHex(parentID, 16) || !isTraceHex(flags, 2) {
+		return "", "", false
+	}
+	// All-zero IDs are invalid
+	if strings.Trim(traceID, "0") == "" || strings.Trim(parentID, "0") == "" {
+		return "", "", false
+	}
+	return traceID, flags, true
+}
+
+// isTraceHex reports whether s is length lowercase hex digits
+func isTraceHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
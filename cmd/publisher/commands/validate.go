@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/schema"
+)
+
+// packageValidationTimeout bounds the package registry lookups of 'mcp-publisher validate'
+const packageValidationTimeout = 2 * time.Minute
+
+// ValidateCommand checks server.json locally, the way the registry does when publishing: against the
+// bundled JSON Schema, then the registry's own rules, then each package in its package registry (for
+// example the mcpName of npm packages and the labels of OCI images)
+func ValidateCommand(args []string) error {
+	validateFlags := flag.NewFlagSet("validate", flag.ExitOnError)
+	var file string
+	var skipPackages bool
+	validateFlags.StringVar(&file, "file", "server.json", "Path to the server.json or server.yaml to validate")
+	validateFlags.BoolVar(&skipPackages, "skip-packages", false, "Do not look the packages up in their registries")
+	addOutputFlag(validateFlags)
+	addCACertFlag(validateFlags)
+	addDebugFlag(validateFlags)
+	githubActions := validateFlags.Bool("github-actions", false, "Write step outputs and a job summary for GitHub Actions")
+	values := templateValues{}
+	validateFlags.Var(values, "set", "Value for a ${NAME} placeholder in server.json, as NAME=VALUE (repeatable)")
+	if err := parseFlags(validateFlags, args); err != nil {
+		return err
+	}
+	if *githubActions {
+		if err := checkGitHubActions(); err != nil {
+			return err
+		}
+	}
+
+	file = findServerFile(file)
+	serverData, err := readServerFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s not found, please check the file path", file)
+		}
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	if serverData, err = substitutePlaceholders(serverData, values); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	checks, err := validateLocally(serverData, !skipPackages)
+	if err != nil {
+		return withExitCode(ExitValidation, fmt.Errorf("invalid %s: %w", file, err))
+	}
+
+	problems := 0
+	result := validateResult{File: file, PackagesChecked: !skipPackages, Checks: []checkResult{}}
+	for _, check := range checks {
+		checkErrors := []string{}
+		if len(check.Errors) == 0 {
+			_, _ = fmt.Fprintf(logOutput(), "✓ %s\n", check.Name)
+		} else {
+			_, _ = fmt.Fprintf(logOutput(), "✗ %s\n", check.Name)
+		}
+		for _, err := range check.Errors {
+			_, _ = fmt.Fprintf(logOutput(), "    %v\n", err)
+			checkErrors = append(checkErrors, err.Error())
+		}
+		problems += len(check.Errors)
+		result.Checks = append(result.Checks, checkResult{Name: check.Name, Errors: checkErrors})
+	}
+	result.Valid = problems == 0
+	if *githubActions {
+		if err := validateReport(result).write(); err != nil {
+			return err
+		}
+	}
+	if err := printResult(result); err != nil {
+		return err
+	}
+
+	if problems > 0 {
+		return withExitCode(ExitValidation, fmt.Errorf("%s has %d problem(s)", file, problems))
+	}
+	if skipPackages {
+		_, _ = fmt.Fprintf(logOutput(), "\n%s is valid, but its packages were not checked\n", file)
+	} else {
+		_, _ = fmt.Fprintf(logOutput(), "\n%s is valid\n", file)
+	}
+	return nil
+}
+
+// validateResult is the JSON result of 'mcp-publisher validate'
+type validateResult struct {
+	File            string        `json:"file"`
+	Valid           bool          `json:"valid"`
+	PackagesChecked bool          `json:"packagesChecked"`
+	Checks          []checkResult `json:"checks"`
+}
+
+type checkResult struct {
+	Name   string   `json:"name"`
+	Errors []string `json:"errors"`
+}
+
+// validationCheck is the outcome of one of the checks validateLocally makes
+type validationCheck struct {
+	Name   string
+	Errors []error
+}
+
+// validateLocally checks server.json against the bundled JSON Schema and the registry's rules and,
+// if checkPackages is set and those pass, each package in its package registry
+func validateLocally(serverData []byte, checkPackages bool) ([]validationCheck, error) {
+	start := time.Now()
+	violations, err := schema.ValidateServerJSON(serverData)
+	if err != nil {
+		return nil, err
+	}
+	checks := []validationCheck{{Name: "JSON Schema", Errors: violations}}
+	debugf("Checked the JSON Schema in %s: %d error(s)", time.Since(start).Round(time.Millisecond), len(violations))
+
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(serverData, &serverJSON); err != nil {
+		// The schema violations already describe what is wrong
+		return checks, nil
+	}
+	ruleErrors := validators.ServerJSONErrors(&serverJSON)
+	checks = append(checks, validationCheck{Name: "Registry rules", Errors: ruleErrors})
+	debugf("Checked the registry rules: %d error(s)", len(ruleErrors))
+
+	// Packages can only be checked against a valid server name
+	if checkPackages && len(ruleErrors) == 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), packageValidationTimeout)
+		defer cancel()
+		for _, pkg := range serverJSON.Packages {
+			check := validationCheck{Name: fmt.Sprintf("Package %s %s", pkg.RegistryType, pkg.Identifier)}
+			start := time.Now()
+			if err := validators.ValidatePackage(ctx, pkg, serverJSON.Name); err != nil {
+				check.Errors = append(check.Errors, err)
+			}
+			debugf("Checked %s in %s: %d error(s)", check.Name, time.Since(start).Round(time.Millisecond), len(check.Errors))
+			checks = append(checks, check)
+		}
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,56 @@
+package commands_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/commands"
+)
+
+func TestValidateCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		serverJSON  string
+		errorSubstr string
+	}{
+		{
+			name:       "valid server.json",
+			serverJSON: `{"$schema": "https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json", "name": "io.github.acme/weather", "description": "Weather forecasts", "version": "1.0.0", "packages": [{"registryType": "npm", "identifier": "@acme/weather", "version": "1.0.0", "transport": {"type": "stdio"}}]}`,
+		},
+		{
+			name:        "schema violations",
+			serverJSON:  `{"$schema": "https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json", "name": "weather", "version": "1.0.0"}`,
+			errorSubstr: "has 3 problem(s)",
+		},
+		{
+			name:        "registry rules",
+			serverJSON:  `{"$schema": "https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json", "name": "io.github.acme/weather", "description": "Weather forecasts", "version": "^1.0.0"}`,
+			errorSubstr: "has 1 problem(s)",
+		},
+		{
+			name:        "invalid JSON",
+			serverJSON:  `{"name": `,
+			errorSubstr: "invalid JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "server.json")
+			if err := os.WriteFile(path, []byte(tt.serverJSON), 0600); err != nil {
+				t.Fatalf("Failed to write server.json: %v", err)
+			}
+
+			err := commands.ValidateCommand([]string{"--file", path, "--skip-packages"})
+			if tt.errorSubstr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.errorSubstr) {
+				t.Errorf("Expected error containing %q, got %v", tt.errorSubstr, err)
+			}
+		})
+	}
+}
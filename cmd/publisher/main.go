@@ -19,38 +19,49 @@ var (
 
 	// GitCommit is the git commit that was compiled
 	GitCommit = "unknown"
+
+	// ReleaseSigningKey is the public key that self-update verifies releases with
+	ReleaseSigningKey = ""
 )
 
 func main() {
+	commands.Version = Version
+	commands.ReleaseSigningKey = ReleaseSigningKey
+
 	if len(os.Args) < 2 {
 		printUsage()
-		os.Exit(1)
+		os.Exit(commands.ExitUsage)
+	}
+
+	if err := commands.LoadConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(commands.ExitCode(err))
+	}
+	if err := commands.ConfigureHTTP(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(commands.ExitCode(err))
 	}
 
-	var err error
 	switch os.Args[1] {
-	case "init":
-		err = commands.InitCommand()
-	case "login":
-		err = commands.LoginCommand(os.Args[2:])
-	case "logout":
-		err = commands.LogoutCommand()
-	case "publish":
-		err = commands.PublishCommand(os.Args[2:])
 	case "--version", "-v", "version":
 		log.Printf("mcp-publisher %s (commit: %s, built: %s)", Version, GitCommit, BuildTime)
 		return
 	case "--help", "-h", "help":
 		printUsage()
-	default:
+		return
+	}
+
+	command, ok := findCommand(os.Args[1])
+	if !ok {
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", os.Args[1])
 		printUsage()
-		os.Exit(1)
+		os.Exit(commands.ExitUsage)
 	}
 
-	if err != nil {
+	if err := command.Run(os.Args[2:]); err != nil {
+		commands.PrintError(err)
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(commands.ExitCode(err))
 	}
 }
 
@@ -61,10 +72,19 @@ func printUsage() {
 	_, _ = fmt.Fprintln(os.Stdout, "  mcp-publisher <command> [arguments]")
 	_, _ = fmt.Fprintln(os.Stdout)
 	_, _ = fmt.Fprintln(os.Stdout, "Commands:")
-	_, _ = fmt.Fprintln(os.Stdout, "  init          Create a server.json file template")
-	_, _ = fmt.Fprintln(os.Stdout, "  login         Authenticate with the registry")
-	_, _ = fmt.Fprintln(os.Stdout, "  logout        Clear saved authentication")
-	_, _ = fmt.Fprintln(os.Stdout, "  publish       Publish server.json to the registry")
+	for _, command := range commands.All() {
+		_, _ = fmt.Fprintf(os.Stdout, "  %-13s %s\n", command.Name, command.Summary)
+	}
 	_, _ = fmt.Fprintln(os.Stdout)
 	_, _ = fmt.Fprintln(os.Stdout, "Use 'mcp-publisher <command> --help' for more information about a command.")
 }
+
+// findCommand returns the command with a name
+func findCommand(name string) (commands.Command, bool) {
+	for _, command := range commands.All() {
+		if command.Name == name {
+			return command, true
+		}
+	}
+	return commands.Command{}, false
+}
@@ -4,20 +4,24 @@ import (
 	"context"
 	"errors"
 	"flag"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/modelcontextprotocol/registry/internal/alerting"
 	"github.com/modelcontextprotocol/registry/internal/api"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/logging"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 )
 
 // Version info for the MCP Registry application
@@ -40,14 +44,12 @@ func main() {
 
 	// Show version information if requested
 	if *showVersion {
-		log.Printf("MCP Registry %s\n", Version)
-		log.Printf("Git commit: %s\n", GitCommit)
-		log.Printf("Build time: %s\n", BuildTime)
+		fmt.Printf("MCP Registry %s\n", Version)
+		fmt.Printf("Git commit: %s\n", GitCommit)
+		fmt.Printf("Build time: %s\n", BuildTime)
 		return
 	}
 
-	log.Printf("Starting MCP Registry Application v%s (commit: %s)", Version, GitCommit)
-
 	var (
 		registryService service.RegistryService
 		db              database.Database
@@ -57,23 +59,44 @@ func main() {
 	// Initialize configuration
 	cfg := config.NewConfig()
 
+	// Build metadata set with ldflags takes precedence over the environment
+	if Version != "dev" {
+		cfg.Version = Version
+	}
+	if GitCommit != "unknown" {
+		cfg.GitCommit = GitCommit
+	}
+	if BuildTime != "unknown" {
+		cfg.BuildTime = BuildTime
+	}
+
+	// Log as JSON in production, or as text for reading in development
+	logger, err := logging.New(os.Stderr, cfg.LogFormat, cfg.LogLevel, logging.WithThrottle(cfg.LogThrottleWindow))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to configure logging: %v\n", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(logger)
+
+	slog.Info("Starting MCP Registry Application", "version", cfg.Version, "commit", cfg.GitCommit)
+
 	// Create a context with timeout for PostgreSQL connection
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	// Connect to PostgreSQL
-	db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL)
+	db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL, database.WithSlowQueryThreshold(cfg.DatabaseSlowQueryThreshold))
 	if err != nil {
-		log.Printf("Failed to connect to PostgreSQL: %v", err)
+		slog.Error("Failed to connect to PostgreSQL", logging.Err(err))
 		return
 	}
 
 	// Store the PostgreSQL instance for later cleanup
 	defer func() {
 		if err := db.Close(); err != nil {
-			log.Printf("Error closing PostgreSQL connection: %v", err)
+			slog.Error("Error closing PostgreSQL connection", logging.Err(err))
 		} else {
-			log.Println("PostgreSQL connection closed successfully")
+			slog.Info("PostgreSQL connection closed successfully")
 		}
 	}()
 
@@ -81,42 +104,85 @@ func main() {
 
 	// Import seed data if seed source is provided
 	if cfg.SeedFrom != "" {
-		log.Printf("Importing data from %s...", cfg.SeedFrom)
+		slog.Info("Importing seed data", "source", cfg.SeedFrom)
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 
 		importerService := importer.NewService(registryService)
 		if err := importerService.ImportFromPath(ctx, cfg.SeedFrom); err != nil {
-			log.Printf("Failed to import seed data: %v", err)
+			slog.Error("Failed to import seed data", logging.Err(err))
 		}
 	}
 
 	shutdownTelemetry, metrics, err := telemetry.InitMetrics(cfg.Version)
 	if err != nil {
-		log.Printf("Failed to initialize metrics: %v", err)
+		slog.Error("Failed to initialize metrics", logging.Err(err))
 		return
 	}
 
 	defer func() {
 		if err := shutdownTelemetry(context.Background()); err != nil {
-			log.Printf("Failed to shutdown telemetry: %v", err)
+			slog.Error("Failed to shutdown telemetry", logging.Err(err))
 		}
 	}()
 
-	// Prepare version information
-	versionInfo := &v0.VersionBody{
-		Version:   Version,
-		GitCommit: GitCommit,
-		BuildTime: BuildTime,
+	if pg, ok := db.(*database.PostgreSQL); ok {
+		if err := metrics.ObserveDatabasePool(pg.PoolStat, pg.PoolMinConns()); err != nil {
+			slog.Error("Failed to observe database pool", logging.Err(err))
+		}
+	}
+	database.QueryObserver = metrics.RecordDatabaseQuery
+	validators.PackageValidationObserver = metrics.RecordPackageValidation
+	service.ServerCacheObserver = metrics.RecordServerCacheLookup
+
+	// Notify the alert webhooks when validation failures, lockouts or database pool waits spike
+	alertMonitor, err := alerting.NewMonitor(cfg, metrics)
+	if err != nil {
+		slog.Error("Failed to configure alerts", logging.Err(err))
+		return
+	}
+	alertCtx, stopAlerts := context.WithCancel(context.Background())
+	defer stopAlerts()
+	go alertMonitor.Run(alertCtx)
+
+	shutdownTracing, err := telemetry.InitTracing(context.Background(), cfg.Version, cfg.TelemetryTracingEndpoint, cfg.TelemetryTracingSampleRatio)
+	if err != nil {
+		slog.Error("Failed to initialize tracing", logging.Err(err))
+		return
 	}
 
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("Failed to shutdown tracing", logging.Err(err))
+		}
+	}()
+
+	shutdownErrorReporting, err := telemetry.InitErrorReporting(cfg.TelemetryErrorReportingDSN, cfg.TelemetryErrorReportingSampleRate, cfg.Version, cfg.GitCommit)
+	if err != nil {
+		slog.Error("Failed to initialize error reporting", logging.Err(err))
+		return
+	}
+
+	defer func() {
+		if err := shutdownErrorReporting(context.Background()); err != nil {
+			slog.Error("Failed to shutdown error reporting", logging.Err(err))
+		}
+	}()
+
+	// Track the health of package registries, the GitHub API and OIDC issuers for /v1/admin/dependencies.
+	// Clients without a transport of their own, such as http.DefaultClient, use the default transport.
+	http.DefaultTransport = telemetry.Dependencies.Transport(http.DefaultTransport)
+
+	// Prepare version information
+	versionInfo := v0.NewVersionBody(cfg)
+
 	// Initialize HTTP server
 	server := api.NewServer(cfg, registryService, metrics, versionInfo)
 
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
 		if err := server.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Printf("Failed to start server: %v", err)
+			slog.Error("Failed to start server", logging.Err(err))
 			os.Exit(1)
 		}
 	}()
@@ -126,7 +192,7 @@ func main() {
 
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	log.Println("Shutting down server...")
+	slog.Info("Shutting down server")
 
 	// Create context with timeout for shutdown
 	sctx, scancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -134,8 +200,8 @@ func main() {
 
 	// Gracefully shutdown the server
 	if err := server.Shutdown(sctx); err != nil {
-		log.Printf("Server forced to shutdown: %v", err)
+		slog.Error("Server forced to shutdown", logging.Err(err))
 	}
 
-	log.Println("Server exiting")
+	slog.Info("Server exiting")
 }
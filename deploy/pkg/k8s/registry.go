@@ -40,6 +40,12 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 		imageTag = conf.Require("imageTag") // Explicit configuration required - no fallback
 	}
 
+	// Absolute URLs, such as those in sitemap.xml, are built from the public URL rather than request headers
+	publicURL := "https://" + environment + ".registry.modelcontextprotocol.io"
+	if environment == "prod" {
+		publicURL = "https://registry.modelcontextprotocol.io"
+	}
+
 	// Create Secret with sensitive configuration
 	secret, err := corev1.NewSecret(ctx, "mcp-registry-secrets", &corev1.SecretArgs{
 		Metadata: &metav1.ObjectMetaArgs{
@@ -114,6 +120,10 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 										},
 									},
 								},
+								&corev1.EnvVarArgs{
+									Name:  pulumi.String("MCP_REGISTRY_PUBLIC_URL"),
+									Value: pulumi.String(publicURL),
+								},
 								&corev1.EnvVarArgs{
 									Name:  pulumi.String("MCP_REGISTRY_GITHUB_CLIENT_ID"),
 									Value: pulumi.String(githubClientId),
@@ -161,10 +171,24 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 									Name:  pulumi.String("MCP_REGISTRY_OIDC_PUBLISH_PERMISSIONS"),
 									Value: pulumi.String("*"),
 								},
+								// Requests reach the registry through the in-cluster NGINX ingress
+								&corev1.EnvVarArgs{
+									Name:  pulumi.String("MCP_REGISTRY_TRUSTED_PROXIES"),
+									Value: pulumi.String("10.0.0.0/8"),
+								},
+							},
+							StartupProbe: &corev1.ProbeArgs{
+								HttpGet: &corev1.HTTPGetActionArgs{
+									Path: pulumi.String("/startupz"),
+									Port: pulumi.Int(8080),
+								},
+								PeriodSeconds:    pulumi.Int(5),
+								TimeoutSeconds:   pulumi.Int(5),
+								FailureThreshold: pulumi.Int(24),
 							},
 							LivenessProbe: &corev1.ProbeArgs{
 								HttpGet: &corev1.HTTPGetActionArgs{
-									Path: pulumi.String("/v0/health"),
+									Path: pulumi.String("/healthz"),
 									Port: pulumi.Int(8080),
 								},
 								InitialDelaySeconds: pulumi.Int(30),
@@ -172,7 +196,7 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 							},
 							ReadinessProbe: &corev1.ProbeArgs{
 								HttpGet: &corev1.HTTPGetActionArgs{
-									Path: pulumi.String("/v0/health"),
+									Path: pulumi.String("/readyz"),
 									Port: pulumi.Int(8080),
 								},
 								InitialDelaySeconds: pulumi.Int(5),
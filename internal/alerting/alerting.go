@@ -0,0 +1,236 @@
+// Package alerting notifies operators through webhooks when the registry's metrics cross their
+// thresholds, such as a spike in failing package validations
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// webhookTimeout bounds sending an alert to a webhook
+const webhookTimeout = 10 * time.Second
+
+// Alert names
+const (
+	AlertValidationFailures = "package_validation_failures"
+	AlertLockoutStorm       = "auth_lockout_storm"
+	AlertDatabasePoolWaits  = "database_pool_exhausted"
+)
+
+// Collector reads the current values of the registry's metrics, such as telemetry.Metrics
+type Collector interface {
+	Collect(ctx context.Context, rm *metricdata.ResourceMetrics) error
+}
+
+// counters are the cumulative values of the metrics alerts are evaluated on
+type counters struct {
+	validations        int64
+	validationFailures int64
+	lockouts           int64
+	poolWaits          int64
+}
+
+// sub returns the increase of each counter since previous. A counter that went down was reset, so its
+// increase is its current value.
+func (c counters) sub(previous counters) counters {
+	delta := func(current, previous int64) int64 {
+		if current < previous {
+			return current
+		}
+		return current - previous
+	}
+	return counters{
+		validations:        delta(c.validations, previous.validations),
+		validationFailures: delta(c.validationFailures, previous.validationFailures),
+		lockouts:           delta(c.lockouts, previous.lockouts),
+		poolWaits:          delta(c.poolWaits, previous.poolWaits),
+	}
+}
+
+// Monitor periodically evaluates the alert thresholds on the registry's metrics and notifies the
+// webhooks of those that trip
+type Monitor struct {
+	collector Collector
+	webhooks  []string
+	client    *http.Client
+
+	interval              time.Duration
+	cooldown              time.Duration
+	validationFailureRate float64
+	validationMinimum     int64
+	lockoutThreshold      int64
+	poolWaitThreshold     int64
+
+	previous *counters
+	lastSent map[string]time.Time
+}
+
+// NewMonitor creates a monitor of the metrics read from collector with the alert thresholds and
+// webhooks of cfg
+func NewMonitor(cfg *config.Config, collector Collector) (*Monitor, error) {
+	webhooks, err := cfg.AlertWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	return &Monitor{
+		collector:             collector,
+		webhooks:              webhooks,
+		client:                &http.Client{Timeout: webhookTimeout},
+		interval:              cfg.AlertInterval,
+		cooldown:              cfg.AlertCooldown,
+		validationFailureRate: cfg.AlertValidationFailureRate,
+		validationMinimum:     int64(cfg.AlertValidationMinimum),
+		lockoutThreshold:      int64(cfg.AlertLockoutThreshold),
+		poolWaitThreshold:     int64(cfg.AlertDatabasePoolWaitThreshold),
+		lastSent:              make(map[string]time.Time),
+	}, nil
+}
+
+// Run evaluates the alerts every interval until ctx is canceled. It returns straight away if no
+// webhook is configured.
+func (m *Monitor) Run(ctx context.Context) {
+	if len(m.webhooks) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	// Read the starting values, so the first evaluation covers a full interval
+	m.Evaluate(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Evaluate(ctx)
+		}
+	}
+}
+
+// Evaluate reads the metrics and notifies the webhooks of the alerts that tripped since the last
+// evaluation. The first evaluation only reads the starting values.
+func (m *Monitor) Evaluate(ctx context.Context) {
+	var rm metricdata.ResourceMetrics
+	if err := m.collector.Collect(ctx, &rm); err != nil {
+		slog.ErrorContext(ctx, "Failed to read metrics for alerts", logging.Err(err))
+		return
+	}
+	current := readCounters(&rm)
+	previous := m.previous
+	m.previous = &current
+	if previous == nil {
+		return
+	}
+
+	for name, message := range m.trippedAlerts(current.sub(*previous)) {
+		if sent, ok := m.lastSent[name]; ok && time.Since(sent) < m.cooldown {
+			continue
+		}
+		m.lastSent[name] = time.Now()
+		slog.WarnContext(ctx, "Alert", "alert", name, "message", message)
+		m.notify(ctx, message)
+	}
+}
+
+// trippedAlerts returns the message of each alert whose threshold delta crosses
+func (m *Monitor) trippedAlerts(delta counters) map[string]string {
+	window := m.interval.String()
+	alerts := make(map[string]string)
+	if delta.validations > 0 && delta.validations >= m.validationMinimum {
+		rate := float64(delta.validationFailures) / float64(delta.validations)
+		if rate >= m.validationFailureRate {
+			alerts[AlertValidationFailures] = fmt.Sprintf(
+				"%d of %d package validations failed in the last %s (%.0f%%, threshold %.0f%%). A package registry may be down; see /v1/admin/dependencies.",
+				delta.validationFailures, delta.validations, window, rate*100, m.validationFailureRate*100)
+		}
+	}
+	if m.lockoutThreshold > 0 && delta.lockouts >= m.lockoutThreshold {
+		alerts[AlertLockoutStorm] = fmt.Sprintf(
+			"%d logins were locked out after repeated failures in the last %s (threshold %d). The registry may be under a brute force attack; see /v1/admin/auth-events.",
+			delta.lockouts, window, m.lockoutThreshold)
+	}
+	if m.poolWaitThreshold > 0 && delta.poolWaits >= m.poolWaitThreshold {
+		alerts[AlertDatabasePoolWaits] = fmt.Sprintf(
+			"%d requests waited for a database connection in the last %s (threshold %d). The connection pool is exhausted.",
+			delta.poolWaits, window, m.poolWaitThreshold)
+	}
+	return alerts
+}
+
+// notify posts message to every webhook in the format of Slack incoming webhooks
+func (m *Monitor) notify(ctx context.Context, message string) {
+	payload, err := json.Marshal(map[string]string{"text": "MCP Registry alert: " + message})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to encode alert", logging.Err(err))
+		return
+	}
+	for i, webhook := range m.webhooks {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(payload))
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to create alert request", "webhook", i, logging.Err(withoutURL(err)))
+			continue
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := m.client.Do(req)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to send alert", "webhook", i, logging.Err(withoutURL(err)))
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			slog.ErrorContext(ctx, "Alert webhook rejected alert", "webhook", i, "status", resp.StatusCode)
+		}
+	}
+}
+
+// withoutURL returns the cause of a *url.Error, whose message includes the URL. Webhook URLs often embed a
+// secret, so webhooks are identified in logs by position instead.
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// readCounters sums the metrics alerts are evaluated on
+func readCounters(rm *metricdata.ResourceMetrics) counters {
+	var c counters
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			for _, point := range sum.DataPoints {
+				switch m.Name {
+				case telemetry.Namespace + ".package.validations":
+					c.validations += point.Value
+					if outcome, _ := point.Attributes.Value(attribute.Key("outcome")); outcome.AsString() == "invalid" {
+						c.validationFailures += point.Value
+					}
+				case telemetry.Namespace + ".auth.lockouts":
+					c.lockouts += point.Value
+				case telemetry.Namespace + ".db.pool.waits":
+					c.poolWaits += point.Value
+				}
+			}
+		}
+	}
+	return c
+}
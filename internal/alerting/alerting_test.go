@@ -0,0 +1,151 @@
+package alerting_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/modelcontextprotocol/registry/internal/alerting"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// webhookRecorder collects the messages posted to a test webhook
+type webhookRecorder struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (w *webhookRecorder) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.messages = append(w.messages, payload.Text)
+}
+
+func (w *webhookRecorder) take() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	messages := w.messages
+	w.messages = nil
+	return messages
+}
+
+func TestMonitor(t *testing.T) {
+	webhook := &webhookRecorder{}
+	server := httptest.NewServer(webhook)
+	defer server.Close()
+
+	reader := sdkmetric.NewManualReader()
+	metrics, err := telemetry.NewMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	require.NoError(t, err)
+
+	monitor, err := alerting.NewMonitor(&config.Config{
+		AlertWebhookURLs:           server.URL,
+		AlertInterval:              time.Minute,
+		AlertCooldown:              time.Hour,
+		AlertValidationFailureRate: 0.5,
+		AlertValidationMinimum:     4,
+		AlertLockoutThreshold:      3,
+	}, reader)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	validations := func(valid, invalid int) {
+		for range valid {
+			metrics.RecordPackageValidation(ctx, "npm", time.Millisecond, nil)
+		}
+		for range invalid {
+			metrics.RecordPackageValidation(ctx, "npm", time.Millisecond, errors.New("not found"))
+		}
+	}
+
+	// Failures before the monitor started are not counted
+	validations(0, 10)
+	monitor.Evaluate(ctx)
+	assert.Empty(t, webhook.take())
+
+	t.Run("below thresholds", func(t *testing.T) {
+		validations(3, 1)
+		metrics.AuthLockouts.Add(ctx, 2)
+		monitor.Evaluate(ctx)
+		assert.Empty(t, webhook.take())
+	})
+
+	t.Run("too few validations to judge", func(t *testing.T) {
+		validations(0, 3)
+		monitor.Evaluate(ctx)
+		assert.Empty(t, webhook.take())
+	})
+
+	t.Run("thresholds tripped", func(t *testing.T) {
+		validations(2, 3)
+		metrics.AuthLockouts.Add(ctx, 3)
+		monitor.Evaluate(ctx)
+		messages := webhook.take()
+		require.Len(t, messages, 2)
+		assert.ElementsMatch(t, []string{
+			"MCP Registry alert: 3 of 5 package validations failed in the last 1m0s (60%, threshold 50%). A package registry may be down; see /v1/admin/dependencies.",
+			"MCP Registry alert: 3 logins were locked out after repeated failures in the last 1m0s (threshold 3). The registry may be under a brute force attack; see /v1/admin/auth-events.",
+		}, messages)
+	})
+
+	t.Run("alerts are not repeated within the cooldown", func(t *testing.T) {
+		validations(0, 5)
+		monitor.Evaluate(ctx)
+		assert.Empty(t, webhook.take())
+	})
+}
+
+func TestNewMonitor_InvalidWebhook(t *testing.T) {
+	_, err := alerting.NewMonitor(&config.Config{AlertWebhookURLs: "hooks.slack.com/services/T000/B000/secret", AlertInterval: time.Minute}, nil)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret", "webhook URLs are not logged")
+}
+
+func TestMonitor_DoesNotLogWebhookURLs(t *testing.T) {
+	// A webhook that refuses connections
+	server := httptest.NewServer(http.NotFoundHandler())
+	webhook := server.URL + "/services/T000/B000/secret"
+	server.Close()
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	reader := sdkmetric.NewManualReader()
+	metrics, err := telemetry.NewMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	require.NoError(t, err)
+	monitor, err := alerting.NewMonitor(&config.Config{
+		AlertWebhookURLs:      webhook,
+		AlertInterval:         time.Minute,
+		AlertLockoutThreshold: 1,
+	}, reader)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	monitor.Evaluate(ctx)
+	metrics.AuthLockouts.Add(ctx, 1)
+	monitor.Evaluate(ctx)
+
+	assert.Contains(t, logs.String(), "Failed to send alert")
+	assert.NotContains(t, logs.String(), "secret")
+}
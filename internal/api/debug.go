@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// RuntimeStats is the body of /debug/runtime
+type RuntimeStats struct {
+	GoVersion  string `json:"go_version"`
+	GOMAXPROCS int    `json:"gomaxprocs"`
+	NumCPU     int    `json:"num_cpu"`
+	Goroutines int    `json:"goroutines"`
+
+	HeapAllocBytes uint64 `json:"heap_alloc_bytes"`
+	HeapInuseBytes uint64 `json:"heap_inuse_bytes"`
+	HeapObjects    uint64 `json:"heap_objects"`
+	SysBytes       uint64 `json:"sys_bytes"`
+	NextGCBytes    uint64 `json:"next_gc_bytes"`
+
+	NumGC        int64      `json:"num_gc"`
+	LastGC       *time.Time `json:"last_gc,omitempty"`
+	PauseTotalNs int64      `json:"pause_total_ns"`
+	// PauseQuantilesNs are the minimum, 25th, 50th and 75th percentile, and maximum GC pauses
+	PauseQuantilesNs []time.Duration `json:"pause_quantiles_ns"`
+}
+
+// NewDebugHandler serves the net/http/pprof profiles under /debug/pprof/, including goroutine dumps at
+// /debug/pprof/goroutine?debug=2, and memory and garbage collector statistics at /debug/runtime. It is
+// only served on DEBUG_SERVER_ADDRESS, which must not be reachable from outside the deployment.
+func NewDebugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("GET /debug/runtime", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(readRuntimeStats())
+	})
+	return mux
+}
+
+func readRuntimeStats() RuntimeStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	gc := debug.GCStats{PauseQuantiles: make([]time.Duration, 5)}
+	debug.ReadGCStats(&gc)
+
+	stats := RuntimeStats{
+		GoVersion:        runtime.Version(),
+		GOMAXPROCS:       runtime.GOMAXPROCS(0),
+		NumCPU:           runtime.NumCPU(),
+		Goroutines:       runtime.NumGoroutine(),
+		HeapAllocBytes:   mem.HeapAlloc,
+		HeapInuseBytes:   mem.HeapInuse,
+		HeapObjects:      mem.HeapObjects,
+		SysBytes:         mem.Sys,
+		NextGCBytes:      mem.NextGC,
+		NumGC:            gc.NumGC,
+		PauseTotalNs:     gc.PauseTotal.Nanoseconds(),
+		PauseQuantilesNs: gc.PauseQuantiles,
+	}
+	if !gc.LastGC.IsZero() {
+		stats.LastGC = &gc.LastGC
+	}
+	return stats
+}
@@ -0,0 +1,44 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+)
+
+func TestDebugHandler(t *testing.T) {
+	handler := api.NewDebugHandler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/runtime", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("/debug/runtime returned %d", w.Code)
+	}
+	var stats api.RuntimeStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to parse /debug/runtime: %v", err)
+	}
+	if stats.GoVersion != runtime.Version() || stats.Goroutines == 0 || stats.HeapAllocBytes == 0 {
+		t.Errorf("unexpected runtime stats: %+v", stats)
+	}
+	if len(stats.PauseQuantilesNs) != 5 {
+		t.Errorf("expected 5 GC pause quantiles, got %d", len(stats.PauseQuantilesNs))
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/pprof/goroutine?debug=2", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "goroutine ") {
+		t.Errorf("goroutine dump returned %d: %.200s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "heap") {
+		t.Errorf("pprof index returned %d", w.Code)
+	}
+}
@@ -0,0 +1,229 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+const (
+	// auditExportBatchSize bounds how many audit events an NDJSON export reads at once
+	auditExportBatchSize = 500
+
+	// ndjsonContentType is the media type of newline-delimited JSON exports
+	ndjsonContentType = "application/x-ndjson"
+)
+
+// AuditEventLister reads the audit log
+type AuditEventLister interface {
+	ListAuditEvents(ctx context.Context, filter *database.AuditEventFilter, limit int) ([]*database.AuditEvent, error)
+}
+
+// AuditEventBody represents an entry in the audit log
+type AuditEventBody struct {
+	ID        int64             `json:"id" doc:"Event ID, increasing in the order events were recorded" example:"42"`
+	Action    string            `json:"action" doc:"What changed" example:"server.transfer.accepted"`
+	Actor     string            `json:"actor" doc:"Who made the change, as <auth method>:<subject>" example:"github-at:alice"`
+	Resource  string            `json:"resource" doc:"The affected server name, namespace or account" example:"io.github.alice/weather"`
+	Details   map[string]string `json:"details,omitempty" doc:"Action-specific context"`
+	CreatedAt time.Time         `json:"createdAt" doc:"When the change was made"`
+}
+
+// AuditEventListBody represents a page of the audit log
+type AuditEventListBody struct {
+	Events     []AuditEventBody `json:"events" doc:"Events, oldest first"`
+	NextCursor string           `json:"nextCursor,omitempty" doc:"ID of the last event returned, or the given cursor if there were none. Pass it as cursor to get the events recorded since." example:"42"`
+}
+
+// ListAuditEventsInput represents the input for exporting the audit log
+type ListAuditEventsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Cursor        string `query:"cursor" doc:"ID of the last event received; only later events are returned" example:"42"`
+	Action        string `query:"action" doc:"Only return events with this action" example:"server.transfer.accepted"`
+	Actor         string `query:"actor" doc:"Only return events made by this actor" example:"github-at:alice"`
+	Resource      string `query:"resource" doc:"Only return events affecting this server name, namespace or account" example:"io.github.alice/weather"`
+	Since         string `query:"since" doc:"Only return events recorded at or after this time (RFC3339 datetime)" example:"2025-08-07T13:15:04Z"`
+	Until         string `query:"until" doc:"Only return events recorded before this time (RFC3339 datetime)" example:"2025-08-08T00:00:00Z"`
+	Limit         int    `query:"limit" doc:"Maximum number of events to return as JSON. NDJSON exports return every matching event." default:"100" minimum:"1" maximum:"1000"`
+	Format        string `query:"format" doc:"json for a page of events, or ndjson to stream every matching event as newline-delimited JSON" enum:"json,ndjson" default:"json"`
+	Follow        bool   `query:"follow" doc:"With format=ndjson, keep the stream open and send events as they are recorded, for up to 10 minutes. Reconnect with the last event's ID as cursor to continue."`
+}
+
+// RegisterAuditEventAdminEndpoints registers the audit log export admin endpoint with a custom path prefix
+func RegisterAuditEventAdminEndpoints(api huma.API, pathPrefix string, events AuditEventLister, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	listSchema := api.OpenAPI().Components.Schemas.Schema(reflect.TypeOf(AuditEventListBody{}), true, "")
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-audit-events" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/audit-events",
+		Summary:     "Export audit events",
+		Description: "Read the log of ownership and administrative changes, oldest first, for shipping to a SIEM (admin only). " +
+			"Page through it by passing the nextCursor of each response as cursor, or stream it as newline-delimited JSON with format=ndjson.",
+		Tags: []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "A page of audit events, or every matching event as newline-delimited JSON",
+				Content: map[string]*huma.MediaType{
+					"application/json": {Schema: listSchema},
+					ndjsonContentType:  {Schema: &huma.Schema{Type: "string"}},
+				},
+			},
+		},
+	}, func(ctx context.Context, input *ListAuditEventsInput) (*huma.StreamResponse, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin(claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have permission to read the audit log")
+		}
+
+		filter, err := auditEventFilter(input)
+		if err != nil {
+			return nil, err
+		}
+
+		if input.Format == "ndjson" {
+			return &huma.StreamResponse{
+				Body: func(hctx huma.Context) {
+					hctx.SetHeader("Content-Type", ndjsonContentType)
+					hctx.SetHeader("Cache-Control", "no-cache")
+					// Stop nginx-style proxies from buffering the stream
+					hctx.SetHeader("X-Accel-Buffering", "no")
+					streamAuditEvents(hctx.Context(), hctx.BodyWriter(), events, filter, input.Follow)
+				},
+			}, nil
+		}
+
+		page, err := events.ListAuditEvents(ctx, filter, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list audit events", err)
+		}
+		body := AuditEventListBody{Events: make([]AuditEventBody, 0, len(page)), NextCursor: input.Cursor}
+		for _, event := range page {
+			body.Events = append(body.Events, toAuditEventBody(event))
+			body.NextCursor = strconv.FormatInt(event.ID, 10)
+		}
+
+		return &huma.StreamResponse{
+			Body: func(hctx huma.Context) {
+				hctx.SetHeader("Content-Type", "application/json")
+				if err := json.NewEncoder(hctx.BodyWriter()).Encode(body); err != nil {
+					slog.WarnContext(ctx, "Failed to write audit events", logging.Err(err))
+				}
+			},
+		}, nil
+	})
+}
+
+// auditEventFilter parses the cursor and time range of an audit log export
+func auditEventFilter(input *ListAuditEventsInput) (*database.AuditEventFilter, error) {
+	filter := &database.AuditEventFilter{
+		Action:   input.Action,
+		Actor:    input.Actor,
+		Resource: input.Resource,
+	}
+	if input.Cursor != "" {
+		after, err := strconv.ParseInt(input.Cursor, 10, 64)
+		if err != nil || after < 0 {
+			return nil, huma.Error400BadRequest("Invalid cursor: must be the ID of an audit event")
+		}
+		filter.After = after
+	}
+	for _, bound := range []struct {
+		name  string
+		value string
+		dest  **time.Time
+	}{
+		{"since", input.Since, &filter.Since},
+		{"until", input.Until, &filter.Until},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid " + bound.name + " format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04Z)")
+		}
+		*bound.dest = &t
+	}
+	return filter, nil
+}
+
+// streamAuditEvents writes the audit events matching filter to w as newline-delimited JSON. With follow,
+// it then waits for new events until the client disconnects or the stream reaches its maximum duration.
+func streamAuditEvents(ctx context.Context, w io.Writer, events AuditEventLister, filter *database.AuditEventFilter, follow bool) {
+	ctx, cancel := context.WithTimeout(ctx, eventStreamMaxDuration)
+	defer cancel()
+
+	flush := func() error { return nil }
+	if rw, ok := w.(http.ResponseWriter); ok {
+		flush = http.NewResponseController(rw).Flush
+	}
+
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+	encoder := json.NewEncoder(w)
+
+	for {
+		batch, err := events.ListAuditEvents(ctx, filter, auditExportBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.ErrorContext(ctx, "Failed to read audit log, closing export", logging.Err(err))
+			}
+			return
+		}
+
+		for _, event := range batch {
+			if err := encoder.Encode(toAuditEventBody(event)); err != nil {
+				return
+			}
+			filter.After = event.ID
+		}
+		if len(batch) > 0 && flush() != nil {
+			return
+		}
+		// Catch up on a backlog without waiting between batches
+		if len(batch) == auditExportBatchSize {
+			continue
+		}
+		if !follow {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func toAuditEventBody(event *database.AuditEvent) AuditEventBody {
+	return AuditEventBody{
+		ID:        event.ID,
+		Action:    event.Action,
+		Actor:     event.Actor,
+		Resource:  event.Resource,
+		Details:   event.Details,
+		CreatedAt: event.CreatedAt,
+	}
+}
@@ -0,0 +1,143 @@
+package v0_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// fakeAuditLog filters an in-memory audit log like the database does
+type fakeAuditLog []*database.AuditEvent
+
+func (f fakeAuditLog) ListAuditEvents(_ context.Context, filter *database.AuditEventFilter, limit int) ([]*database.AuditEvent, error) {
+	var events []*database.AuditEvent
+	for _, event := range f {
+		if event.ID <= filter.After ||
+			(filter.Actor != "" && event.Actor != filter.Actor) ||
+			(filter.Since != nil && event.CreatedAt.Before(*filter.Since)) ||
+			(filter.Until != nil && !event.CreatedAt.Before(*filter.Until)) {
+			continue
+		}
+		if len(events) == limit {
+			break
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func TestAuditEventAdminEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	jwtManager := auth.NewJWTManager(cfg)
+
+	start := time.Date(2025, 8, 7, 0, 0, 0, 0, time.UTC)
+	var log fakeAuditLog
+	for i := 1; i <= 5; i++ {
+		log = append(log, &database.AuditEvent{
+			ID:        int64(i),
+			Action:    "token.created",
+			Actor:     fmt.Sprintf("github-at:user%d", i%2),
+			Resource:  "github-at:alice",
+			CreatedAt: start.Add(time.Duration(i) * time.Hour),
+		})
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterAuditEventAdminEndpoints(api, "/v1", log, cfg)
+
+	get := func(t *testing.T, pattern, query string) *httptest.ResponseRecorder {
+		t.Helper()
+		token, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:  auth.MethodNone,
+			Permissions: []auth.Permission{{Action: auth.PermissionActionAdmin, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/audit-events"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token.RegistryToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	page := func(t *testing.T, query string) v0.AuditEventListBody {
+		t.Helper()
+		w := get(t, "*", query)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		var body v0.AuditEventListBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		return body
+	}
+	ids := func(events []v0.AuditEventBody) []int64 {
+		var ids []int64
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+		return ids
+	}
+
+	t.Run("requires admin permissions", func(t *testing.T) {
+		w := get(t, "io.github.user/*", "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("pages with a cursor", func(t *testing.T) {
+		body := page(t, "?limit=2")
+		assert.Equal(t, []int64{1, 2}, ids(body.Events))
+		assert.Equal(t, "2", body.NextCursor)
+
+		body = page(t, "?limit=2&cursor="+body.NextCursor)
+		assert.Equal(t, []int64{3, 4}, ids(body.Events))
+
+		body = page(t, "?cursor=5")
+		assert.Empty(t, body.Events)
+		assert.Equal(t, "5", body.NextCursor, "polling resumes from the same cursor")
+	})
+
+	t.Run("filters by time and actor", func(t *testing.T) {
+		body := page(t, "?since=2025-08-07T02:00:00Z&until=2025-08-07T05:00:00Z&actor=github-at:user1")
+		assert.Equal(t, []int64{3}, ids(body.Events))
+	})
+
+	t.Run("rejects invalid filters", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get(t, "*", "?cursor=abc").Code)
+		assert.Equal(t, http.StatusBadRequest, get(t, "*", "?until=yesterday").Code)
+	})
+
+	t.Run("streams NDJSON", func(t *testing.T) {
+		w := get(t, "*", "?format=ndjson&cursor=1&limit=1")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+		var events []v0.AuditEventBody
+		scanner := bufio.NewScanner(w.Body)
+		for scanner.Scan() {
+			var event v0.AuditEventBody
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+			events = append(events, event)
+		}
+		assert.Equal(t, []int64{2, 3, 4, 5}, ids(events), "exports are not limited to a page")
+		assert.Equal(t, "token.created", events[0].Action)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"crypto/sha512"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"regexp"
@@ -43,6 +44,10 @@ type SignatureTokenExchangeInput struct {
 	SignedTimestamp string `json:"signed_timestamp" doc:"Hex-encoded signature of timestamp" example:"abcdef1234567890" required:"true"`
 }
 
+// ErrSignatureMismatch is returned when the signed timestamp does not verify with any published key,
+// usually because the domain publishes a different key than the one used to sign
+var ErrSignatureMismatch = errors.New("signed timestamp does not match the published public key")
+
 // KeyFetcher defines a function type for fetching keys from external sources
 type KeyFetcher func(ctx context.Context, domain string) ([]string, error)
 
@@ -201,10 +206,10 @@ func (h *CoreAuthHandler) ExchangeToken(
 	if len(publicKeysAndErrors) == 0 {
 		switch authMethod {
 		case auth.MethodHTTP:
-			return nil, fmt.Errorf("no MCP public key found in HTTP response")
+			return nil, fmt.Errorf("no MCP public key found in HTTP response from https://%s%s; expected a record like \"v=MCPv1; k=ed25519; p=PUBLIC_KEY\"", domain, WellKnownAuthPath)
 		case auth.MethodDNS:
-			return nil, fmt.Errorf("no MCP public key found in DNS TXT records")
-		case auth.MethodGitHubAT, auth.MethodGitHubOIDC, auth.MethodOIDC, auth.MethodNone:
+			return nil, fmt.Errorf("no MCP public key found in DNS TXT records for %s; if the record was added recently, wait for it to propagate and try again", domain)
+		case auth.MethodGitHubAT, auth.MethodGitLabAT, auth.MethodGitHubOIDC, auth.MethodGitLabOIDC, auth.MethodGoogle, auth.MethodEntra, auth.MethodOIDC, auth.MethodMTLS, auth.MethodNone:
 		default:
 			return nil, fmt.Errorf("no MCP public key found using %s authentication", authMethod)
 		}
@@ -229,7 +234,7 @@ func (h *CoreAuthHandler) ExchangeToken(
 	messageBytes := []byte(timestamp)
 	err = VerifySignatureWithKeys(publicKeys, messageBytes, signature)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrSignatureMismatch, err)
 	}
 
 	permissions := BuildPermissions(domain, includeSubdomains)
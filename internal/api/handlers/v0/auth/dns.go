@@ -37,11 +37,22 @@ type DNSAuthHandler struct {
 	resolver DNSResolver
 }
 
-// NewDNSAuthHandler creates a new DNS authentication handler
+// NewDNSAuthHandler creates a new DNS authentication handler, which requires the resolvers in
+// cfg.DNSResolvers to agree, or uses the system resolver if none are configured
 func NewDNSAuthHandler(cfg *config.Config) *DNSAuthHandler {
+	addresses, err := cfg.DNSResolverAddresses()
+	if err != nil {
+		panic(fmt.Sprintf("Invalid DNS resolver configuration: %v", err))
+	}
+
+	var resolver DNSResolver = &DefaultDNSResolver{}
+	if len(addresses) > 0 {
+		resolver = NewAgreeingResolver(addresses)
+	}
+
 	return &DNSAuthHandler{
 		CoreAuthHandler: *NewCoreAuthHandler(cfg),
-		resolver:        &DefaultDNSResolver{},
+		resolver:        resolver,
 	}
 }
 
@@ -63,6 +74,9 @@ func RegisterDNSEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
 		Description: "Authenticate using DNS TXT record public key and signed timestamp",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *DNSTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
+		// Signatures cannot be guessed, so failures are only throttled per client
+		RecordLoginIdentity(ctx, domainIdentity(input.Body.Domain))
+
 		response, err := handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
 		if err != nil {
 			return nil, huma.Error401Unauthorized("DNS authentication failed", err)
@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dnsLookupTimeout bounds each resolver's lookup, so one slow resolver fails the login rather than hanging it
+const dnsLookupTimeout = 5 * time.Second
+
+// NewDNSResolver creates a resolver for an address from MCP_REGISTRY_DNS_RESOLVERS: an https URL is queried
+// with DNS-over-HTTPS, and anything else is a nameserver host[:port] queried directly
+func NewDNSResolver(address string) DNSResolver {
+	if strings.HasPrefix(address, "https://") {
+		return NewDoHResolver(address, &http.Client{Timeout: dnsLookupTimeout})
+	}
+	return NewNameserverResolver(address)
+}
+
+// NameserverResolver queries a single nameserver instead of the system's configured ones
+type NameserverResolver struct {
+	resolver *net.Resolver
+}
+
+// NewNameserverResolver creates a resolver for a nameserver host[:port], defaulting to port 53
+func NewNameserverResolver(address string) *NameserverResolver {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "53")
+	}
+	return &NameserverResolver{
+		resolver: &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				d := net.Dialer{Timeout: dnsLookupTimeout}
+				return d.DialContext(ctx, network, address)
+			},
+		},
+	}
+}
+
+// LookupTXT performs DNS TXT record lookup against the nameserver
+func (r *NameserverResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return r.resolver.LookupTXT(ctx, name)
+}
+
+// DoHResolver looks up TXT records with the JSON DNS-over-HTTPS API supported by public resolvers
+// such as https://cloudflare-dns.com/dns-query and https://dns.google/resolve
+type DoHResolver struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewDoHResolver creates a DNS-over-HTTPS resolver for the endpoint
+func NewDoHResolver(endpoint string, client *http.Client) *DoHResolver {
+	return &DoHResolver{endpoint: endpoint, client: client}
+}
+
+// dohResponse is the subset of the JSON DNS-over-HTTPS response format that TXT lookups need
+type dohResponse struct {
+	Status int `json:"Status"`
+	Answer []struct {
+		Type int    `json:"type"`
+		Data string `json:"data"`
+	} `json:"Answer"`
+}
+
+const (
+	dnsTypeTXT       = 16
+	dnsRcodeNXDomain = 3
+)
+
+// LookupTXT performs DNS TXT record lookup over HTTPS
+func (r *DoHResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	query := url.Values{"name": {name}, "type": {"TXT"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/dns-json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query DNS-over-HTTPS resolver: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DNS-over-HTTPS resolver returned HTTP %d", resp.StatusCode)
+	}
+
+	var answer dohResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&answer); err != nil {
+		return nil, fmt.Errorf("failed to decode DNS-over-HTTPS response: %w", err)
+	}
+	switch answer.Status {
+	case 0:
+	case dnsRcodeNXDomain:
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	default:
+		return nil, fmt.Errorf("DNS-over-HTTPS resolver returned DNS status %d", answer.Status)
+	}
+
+	var records []string
+	for _, rr := range answer.Answer {
+		if rr.Type == dnsTypeTXT {
+			records = append(records, joinTXTStrings(rr.Data))
+		}
+	}
+	return records, nil
+}
+
+// joinTXTStrings joins the character strings of a TXT record, which some resolvers return quoted,
+// e.g. "\"v=MCPv1; k=ed25519; \" \"p=...\"", into one value as net.Resolver.LookupTXT does
+func joinTXTStrings(data string) string {
+	if !strings.HasPrefix(data, `"`) {
+		return data
+	}
+
+	var joined strings.Builder
+	inQuotes, escaped := false, false
+	for _, c := range data {
+		switch {
+		case escaped:
+			joined.WriteRune(c)
+			escaped = false
+		case c == '\\' && inQuotes:
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+			joined.WriteRune(c)
+		}
+	}
+	return joined.String()
+}
+
+// namedResolver is a resolver with the address it was configured with, for diagnostics
+type namedResolver struct {
+	name     string
+	resolver DNSResolver
+}
+
+// AgreeingResolver queries several resolvers and only returns the TXT records they all agree on,
+// so a single compromised or stale resolver cannot decide which keys are used
+type AgreeingResolver struct {
+	resolvers []namedResolver
+}
+
+// NewAgreeingResolver creates a resolver requiring agreement between the resolvers at the addresses
+func NewAgreeingResolver(addresses []string) *AgreeingResolver {
+	r := &AgreeingResolver{}
+	for _, address := range addresses {
+		r.Add(address, NewDNSResolver(address))
+	}
+	return r
+}
+
+// Add adds a resolver that has to agree, named for diagnostics
+func (r *AgreeingResolver) Add(name string, resolver DNSResolver) {
+	r.resolvers = append(r.resolvers, namedResolver{name: name, resolver: resolver})
+}
+
+// LookupTXT performs DNS TXT record lookup on every resolver and returns the records all of them returned
+func (r *AgreeingResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if len(r.resolvers) == 0 {
+		return nil, errors.New("no DNS resolvers configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dnsLookupTimeout)
+	defer cancel()
+
+	results := make([][]string, len(r.resolvers))
+	errs := make([]error, len(r.resolvers))
+	var wg sync.WaitGroup
+	for i, nr := range r.resolvers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = nr.resolver.LookupTXT(ctx, name)
+		}()
+	}
+	wg.Wait()
+
+	var failures []string
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", r.resolvers[i].name, err))
+		}
+	}
+	if len(failures) > 0 {
+		return nil, fmt.Errorf("DNS lookup failed on %d of %d resolvers (%s)", len(failures), len(r.resolvers), strings.Join(failures, "; "))
+	}
+
+	agreed := results[0]
+	for _, records := range results[1:] {
+		agreed = slices.DeleteFunc(slices.Clone(agreed), func(record string) bool {
+			return !slices.Contains(records, record)
+		})
+	}
+
+	// Resolvers seeing different MCP keys usually means a record change has not reached all of them yet
+	if len(ParseMCPKeysFromStrings(agreed)) == 0 {
+		var counts []string
+		for i, records := range results {
+			if keys := len(ParseMCPKeysFromStrings(records)); keys > 0 {
+				counts = append(counts, fmt.Sprintf("%s returned %d", r.resolvers[i].name, keys))
+			}
+		}
+		if len(counts) > 0 {
+			return nil, fmt.Errorf("DNS resolvers disagree on the MCP key records for %s (%s, but none is common to all resolvers); "+
+				"if the record was added or changed recently, wait for it to propagate and try again", name, strings.Join(counts, ", "))
+		}
+	}
+
+	return agreed, nil
+}
@@ -0,0 +1,95 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+)
+
+func TestDoHResolver_LookupTXT(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/dns-json", r.Header.Get("Accept"))
+		assert.Equal(t, "TXT", r.URL.Query().Get("type"))
+
+		w.Header().Set("Content-Type", "application/dns-json")
+		switch r.URL.Query().Get("name") {
+		case testDomain:
+			_, _ = w.Write([]byte(`{"Status":0,"Answer":[
+				{"name":"example.com","type":16,"data":"\"v=MCPv1; k=ed25519; \" \"p=AAAA\""},
+				{"name":"example.com","type":16,"data":"v=spf1 -all"},
+				{"name":"example.com","type":5,"data":"alias.example.com."}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"Status":3}`))
+		}
+	}))
+	defer srv.Close()
+
+	resolver := auth.NewDoHResolver(srv.URL, srv.Client())
+
+	records, err := resolver.LookupTXT(context.Background(), testDomain)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v=MCPv1; k=ed25519; p=AAAA", "v=spf1 -all"}, records)
+
+	_, err = resolver.LookupTXT(context.Background(), "missing.example.com")
+	assert.ErrorContains(t, err, "no such host")
+}
+
+func TestAgreeingResolver_LookupTXT(t *testing.T) {
+	const (
+		oldKey = "v=MCPv1; k=ed25519; p=b2xka2V5"
+		newKey = "v=MCPv1; k=ed25519; p=bmV3a2V5"
+	)
+
+	resolverWith := func(records ...string) *MockDNSResolver {
+		return &MockDNSResolver{txtRecords: map[string][]string{testDomain: records}}
+	}
+
+	t.Run("returns the records all resolvers agree on", func(t *testing.T) {
+		resolver := &auth.AgreeingResolver{}
+		resolver.Add("first", resolverWith(oldKey, newKey, "v=spf1 -all"))
+		resolver.Add("second", resolverWith(newKey, oldKey))
+
+		records, err := resolver.LookupTXT(context.Background(), testDomain)
+		require.NoError(t, err)
+		assert.Equal(t, []string{oldKey, newKey}, records)
+	})
+
+	t.Run("key rotation in progress", func(t *testing.T) {
+		resolver := &auth.AgreeingResolver{}
+		resolver.Add("first", resolverWith(oldKey, newKey))
+		resolver.Add("second", resolverWith(oldKey))
+
+		records, err := resolver.LookupTXT(context.Background(), testDomain)
+		require.NoError(t, err)
+		assert.Equal(t, []string{oldKey}, records)
+	})
+
+	t.Run("no common key", func(t *testing.T) {
+		resolver := &auth.AgreeingResolver{}
+		resolver.Add("first", resolverWith(newKey))
+		resolver.Add("second", resolverWith())
+
+		_, err := resolver.LookupTXT(context.Background(), testDomain)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "disagree")
+		assert.Contains(t, err.Error(), "first returned 1")
+		assert.Contains(t, err.Error(), "propagate")
+	})
+
+	t.Run("any resolver failing fails the lookup", func(t *testing.T) {
+		resolver := &auth.AgreeingResolver{}
+		resolver.Add("first", resolverWith(newKey))
+		resolver.Add("second", &MockDNSResolver{err: errors.New("timeout")})
+
+		_, err := resolver.LookupTXT(context.Background(), testDomain)
+		assert.ErrorContains(t, err, "second: timeout")
+	})
+}
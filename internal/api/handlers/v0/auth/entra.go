@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// entraLoginURL is where Entra ID issues tokens and publishes its signing keys
+const entraLoginURL = "https://login.microsoftonline.com/"
+
+// ErrNoEntraGroupPermissions is returned when none of the account's groups are mapped to permissions
+var ErrNoEntraGroupPermissions = errors.New("account is not a member of any group with registry permissions")
+
+// EntraTokenExchangeInput represents the input for Entra ID token exchange
+type EntraTokenExchangeInput struct {
+	Body struct {
+		EntraToken string `json:"entra_token" doc:"Entra ID token issued to the registry's app registration" required:"true"`
+	}
+}
+
+// EntraHandler handles Microsoft Entra ID sign-in, granting permissions by group membership
+type EntraHandler struct {
+	jwtManager *auth.JWTManager
+	validator  GenericOIDCValidator
+	tenantID   string
+	mappings   []config.EntraGroupMapping
+}
+
+// NewEntraHandler creates a new Entra ID handler. The tenant's signing keys are fetched when the first
+// token is validated, so creating the handler does not need network access.
+func NewEntraHandler(cfg *config.Config) *EntraHandler {
+	mappings, err := cfg.EntraGroupMappings()
+	if err != nil {
+		panic(err)
+	}
+
+	tenantID := strings.ToLower(cfg.EntraTenantID)
+	keySet := oidc.NewRemoteKeySet(context.Background(), entraLoginURL+tenantID+"/discovery/v2.0/keys")
+	verifier := oidc.NewVerifier(entraLoginURL+tenantID+"/v2.0", keySet, &oidc.Config{
+		ClientID: cfg.EntraClientID,
+	})
+
+	return &EntraHandler{
+		jwtManager: auth.NewJWTManager(cfg),
+		validator:  &StandardOIDCValidator{verifier: verifier},
+		tenantID:   tenantID,
+		mappings:   mappings,
+	}
+}
+
+// SetValidator sets a custom OIDC validator (used for testing)
+func (h *EntraHandler) SetValidator(validator GenericOIDCValidator) {
+	h.validator = validator
+}
+
+// RegisterEntraEndpoint registers the Entra ID sign-in endpoint with a custom path prefix
+func RegisterEntraEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
+	if cfg.EntraClientID == "" {
+		return // Skip registration if Entra ID sign-in is not configured
+	}
+
+	handler := NewEntraHandler(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-entra-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/entra",
+		Summary:     "Exchange Microsoft Entra ID token for Registry JWT",
+		Description: "Exchange a Microsoft Entra ID token for a short-lived Registry JWT token. The token must come from the registry's tenant, " +
+			"and grants the publish and edit permissions the registry maps to the groups in its groups claim.",
+		Tags: []string{"auth"},
+	}, func(ctx context.Context, input *EntraTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
+		response, err := handler.ExchangeToken(ctx, input.Body.EntraToken)
+		if errors.Is(err, ErrNoEntraGroupPermissions) {
+			return nil, huma.Error403Forbidden("Token exchange failed", err)
+		}
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Token exchange failed", err)
+		}
+
+		return &v0.Response[auth.TokenResponse]{
+			Body: *response,
+		}, nil
+	})
+}
+
+// ExchangeToken exchanges an Entra ID token for a Registry JWT token with the permissions of the
+// account's groups
+func (h *EntraHandler) ExchangeToken(ctx context.Context, entraToken string) (*auth.TokenResponse, error) {
+	claims, err := h.validator.ValidateToken(ctx, entraToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate Entra ID token: %w", err)
+	}
+	if tenantID, _ := claims.ExtraClaims["tid"].(string); !strings.EqualFold(tenantID, h.tenantID) {
+		return nil, fmt.Errorf("token was not issued by the registry's tenant: %s", tenantID)
+	}
+
+	// The object ID identifies the account across renames, unlike its username
+	objectID, _ := claims.ExtraClaims["oid"].(string)
+	if objectID == "" {
+		return nil, errors.New("token has no oid claim")
+	}
+
+	groups, err := entraGroups(claims)
+	if err != nil {
+		return nil, err
+	}
+	permissions := h.buildPermissions(groups)
+	if len(permissions) == 0 {
+		return nil, ErrNoEntraGroupPermissions
+	}
+
+	jwtClaims := auth.JWTClaims{
+		AuthMethod:        auth.MethodEntra,
+		AuthMethodSubject: objectID,
+		Permissions:       permissions,
+	}
+
+	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, jwtClaims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+	}
+
+	return tokenResponse, nil
+}
+
+// entraGroups returns the object IDs in the groups claim, in lowercase. Accounts in more groups than fit
+// in a token get a reference to Microsoft Graph instead, which the registry does not follow.
+func entraGroups(claims *OIDCClaims) ([]string, error) {
+	if claimNames, ok := claims.ExtraClaims["_claim_names"].(map[string]any); ok && claimNames["groups"] != nil {
+		return nil, errors.New("account is in too many groups to list them in the token; configure the app registration to only emit groups assigned to the application")
+	}
+
+	values, _ := claims.ExtraClaims["groups"].([]any)
+	groups := make([]string, 0, len(values))
+	for _, value := range values {
+		if group, ok := value.(string); ok {
+			groups = append(groups, strings.ToLower(group))
+		}
+	}
+	return groups, nil
+}
+
+// buildPermissions grants the permissions mapped to any of the groups, without duplicates
+func (h *EntraHandler) buildPermissions(groups []string) []auth.Permission {
+	var permissions []auth.Permission
+	add := func(action auth.PermissionAction, patterns []string) {
+		for _, pattern := range patterns {
+			permission := auth.Permission{Action: action, ResourcePattern: pattern}
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+
+	for _, mapping := range h.mappings {
+		if slices.Contains(groups, strings.ToLower(mapping.Group)) {
+			add(auth.PermissionActionPublish, mapping.PublishPermissions)
+			add(auth.PermissionActionEdit, mapping.EditPermissions)
+		}
+	}
+	return permissions
+}
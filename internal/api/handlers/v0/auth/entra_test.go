@@ -0,0 +1,132 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEntraTenant   = "72f988bf-86f1-41af-91ab-2d7cd011db47"
+	testEntraPlatform = "0e5c2a4d-1b7f-4c3e-9a8d-6f2b1c0d9e8a"
+	testEntraTooling  = "5a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+)
+
+func TestEntraHandler_ExchangeToken(t *testing.T) {
+	cfg := &config.Config{
+		EntraTenantID: testEntraTenant,
+		EntraClientID: "test-client-id",
+		EntraGroupPermissions: `[
+			{"group": "` + testEntraPlatform + `", "publish_permissions": ["com.example/*"], "edit_permissions": ["com.example/*"]},
+			{"group": "` + testEntraTooling + `", "publish_permissions": ["com.example/*", "com.example.tools/*"]}
+		]`,
+		JWTPrivateKey: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+	}
+
+	entraClaims := func(extra map[string]any) *v0auth.OIDCClaims {
+		claims := map[string]any{"tid": testEntraTenant, "oid": "user-object-id"}
+		for key, value := range extra {
+			claims[key] = value
+		}
+		return &v0auth.OIDCClaims{Issuer: "https://login.microsoftonline.com/" + testEntraTenant + "/v2.0", ExtraClaims: claims}
+	}
+
+	tests := []struct {
+		name          string
+		claims        *v0auth.OIDCClaims
+		validateErr   error
+		expectedError string
+		expectedPerms []auth.Permission
+	}{
+		{
+			name:   "groups are mapped to permissions",
+			claims: entraClaims(map[string]any{"groups": []any{"0E5C2A4D-1B7F-4C3E-9A8D-6F2B1C0D9E8A", "11111111-2222-3333-4444-555555555555"}}),
+			expectedPerms: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"},
+				{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"},
+			},
+		},
+		{
+			name:   "permissions of several groups are combined",
+			claims: entraClaims(map[string]any{"groups": []any{testEntraPlatform, testEntraTooling}}),
+			expectedPerms: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"},
+				{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"},
+				{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.tools/*"},
+			},
+		},
+		{
+			name:          "no mapped groups",
+			claims:        entraClaims(map[string]any{"groups": []any{"11111111-2222-3333-4444-555555555555"}}),
+			expectedError: "not a member of any group",
+		},
+		{
+			name: "group overage",
+			claims: entraClaims(map[string]any{
+				"_claim_names":   map[string]any{"groups": "src1"},
+				"_claim_sources": map[string]any{"src1": map[string]any{"endpoint": "https://graph.microsoft.com/v1.0/users/x/getMemberObjects"}},
+			}),
+			expectedError: "too many groups",
+		},
+		{
+			name:          "token from another tenant",
+			claims:        entraClaims(map[string]any{"tid": "11111111-2222-3333-4444-555555555555", "groups": []any{testEntraPlatform}}),
+			expectedError: "not issued by the registry's tenant",
+		},
+		{
+			name:          "token without object ID",
+			claims:        entraClaims(map[string]any{"oid": "", "groups": []any{testEntraPlatform}}),
+			expectedError: "no oid claim",
+		},
+		{
+			name:          "invalid token",
+			validateErr:   fmt.Errorf("signature mismatch"),
+			expectedError: "failed to validate Entra ID token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := v0auth.NewEntraHandler(cfg)
+			handler.SetValidator(&MockGenericOIDCValidator{
+				validateFunc: func(_ context.Context, _ string) (*v0auth.OIDCClaims, error) {
+					return tt.claims, tt.validateErr
+				},
+			})
+
+			ctx := context.Background()
+			response, err := handler.ExchangeToken(ctx, "entra-id-token")
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			claims, err := auth.NewJWTManager(cfg).ValidateToken(ctx, response.RegistryToken)
+			require.NoError(t, err)
+			assert.Equal(t, auth.MethodEntra, claims.AuthMethod)
+			assert.Equal(t, "user-object-id", claims.AuthMethodSubject)
+			assert.Equal(t, tt.expectedPerms, claims.Permissions)
+		})
+	}
+}
+
+func TestNewEntraHandler_InvalidConfig(t *testing.T) {
+	for name, cfg := range map[string]*config.Config{
+		"tenant domain instead of ID": {EntraTenantID: "contoso.onmicrosoft.com", EntraClientID: "client", EntraGroupPermissions: `[]`},
+		"missing group permissions":   {EntraTenantID: testEntraTenant, EntraClientID: "client"},
+		"group name instead of ID":    {EntraTenantID: testEntraTenant, EntraClientID: "client", EntraGroupPermissions: `[{"group": "platform-team"}]`},
+		"invalid JSON":                {EntraTenantID: testEntraTenant, EntraClientID: "client", EntraGroupPermissions: `{`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Panics(t, func() { v0auth.NewEntraHandler(cfg) })
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// installationCacheDuration is how long the list of organizations the app is installed in is reused
+const installationCacheDuration = 5 * time.Minute
+
+// GitHubAppClient verifies organization membership with a GitHub App's installation tokens. Unlike the
+// user's own token, this sees private memberships in every organization that installed the app.
+type GitHubAppClient struct {
+	appID      int64
+	privateKey *rsa.PrivateKey
+	baseURL    string
+	client     *http.Client
+
+	mu                   sync.Mutex
+	installations        map[string]int64 // organization login -> installation ID
+	installationsFetched time.Time
+	tokens               map[int64]installationToken
+}
+
+// installationToken is a cached installation access token
+type installationToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// NewGitHubAppClient creates a client for the GitHub App with the given ID and PEM private key
+func NewGitHubAppClient(appID int64, privateKeyPEM, baseURL string) (*GitHubAppClient, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, errors.New("GitHub App private key is not valid PEM")
+	}
+
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		key, pkcs8Err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if pkcs8Err != nil || !ok {
+			return nil, fmt.Errorf("GitHub App private key must be an RSA key: %w", err)
+		}
+		privateKey = rsaKey
+	}
+
+	return &GitHubAppClient{
+		appID:      appID,
+		privateKey: privateKey,
+		baseURL:    baseURL,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		tokens:     make(map[int64]installationToken),
+	}, nil
+}
+
+// SetBaseURL sets the base URL for GitHub API (used for testing)
+func (c *GitHubAppClient) SetBaseURL(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.baseURL = url
+	c.installations = nil
+	c.tokens = make(map[int64]installationToken)
+}
+
+// MemberOrganizations returns the organizations that installed the app in which username is an active
+// member with at least the given role
+func (c *GitHubAppClient) MemberOrganizations(ctx context.Context, username, role string) ([]GitHubUserOrOrg, error) {
+	if !isValidGitHubName(username) {
+		return nil, fmt.Errorf("invalid GitHub username: %s", username)
+	}
+
+	installations, err := c.orgInstallations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgs []GitHubUserOrOrg
+	for org, installationID := range installations {
+		membership, err := c.membership(ctx, installationID, org, username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check membership of %s: %w", org, err)
+		}
+		if membership.hasRole(role) {
+			orgs = append(orgs, GitHubUserOrOrg{Login: org})
+		}
+	}
+	return orgs, nil
+}
+
+// membership gets the user's membership with the organization's installation token
+func (c *GitHubAppClient) membership(ctx context.Context, installationID int64, org, username string) (orgMembership, error) {
+	token, err := c.installationToken(ctx, installationID)
+	if err != nil {
+		return orgMembership{}, err
+	}
+
+	var membership orgMembership
+	status, err := c.do(ctx, http.MethodGet, "/orgs/"+org+"/memberships/"+username, "token "+token, &membership)
+	if err != nil {
+		return orgMembership{}, err
+	}
+	// 404 means the user is not a member, and 403 that the installation cannot read members
+	if status == http.StatusNotFound || status == http.StatusForbidden {
+		return orgMembership{}, nil
+	}
+	return membership, nil
+}
+
+// orgInstallations lists the organizations the app is installed in, cached for installationCacheDuration
+func (c *GitHubAppClient) orgInstallations(ctx context.Context) (map[string]int64, error) {
+	c.mu.Lock()
+	if c.installations != nil && time.Since(c.installationsFetched) < installationCacheDuration {
+		installations := c.installations
+		c.mu.Unlock()
+		return installations, nil
+	}
+	c.mu.Unlock()
+
+	appToken, err := c.appToken()
+	if err != nil {
+		return nil, err
+	}
+
+	installations := make(map[string]int64)
+	for page := 1; ; page++ {
+		var batch []struct {
+			ID      int64 `json:"id"`
+			Account struct {
+				Login string `json:"login"`
+				Type  string `json:"type"`
+			} `json:"account"`
+		}
+		path := "/app/installations?per_page=100&page=" + strconv.Itoa(page)
+		status, err := c.do(ctx, http.MethodGet, path, "Bearer "+appToken, &batch)
+		if err != nil {
+			return nil, err
+		}
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("failed to list GitHub App installations (status %d)", status)
+		}
+		for _, installation := range batch {
+			if installation.Account.Type == "Organization" && isValidGitHubName(installation.Account.Login) {
+				installations[installation.Account.Login] = installation.ID
+			}
+		}
+		if len(batch) < 100 {
+			break
+		}
+	}
+
+	c.mu.Lock()
+	c.installations, c.installationsFetched = installations, time.Now()
+	c.mu.Unlock()
+	return installations, nil
+}
+
+// installationToken returns an access token for the installation, reusing it until shortly before it expires
+func (c *GitHubAppClient) installationToken(ctx context.Context, installationID int64) (string, error) {
+	c.mu.Lock()
+	cached, ok := c.tokens[installationID]
+	c.mu.Unlock()
+	if ok && time.Until(cached.expiresAt) > time.Minute {
+		return cached.token, nil
+	}
+
+	appToken, err := c.appToken()
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	path := "/app/installations/" + strconv.FormatInt(installationID, 10) + "/access_tokens"
+	status, err := c.do(ctx, http.MethodPost, path, "Bearer "+appToken, &response)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusCreated {
+		return "", fmt.Errorf("failed to create GitHub App installation token (status %d)", status)
+	}
+
+	c.mu.Lock()
+	c.tokens[installationID] = installationToken{token: response.Token, expiresAt: response.ExpiresAt}
+	c.mu.Unlock()
+	return response.Token, nil
+}
+
+// appToken signs the short-lived JWT that authenticates as the app itself
+func (c *GitHubAppClient) appToken() (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		// Backdated to allow for clock drift, as GitHub recommends
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+		Issuer:    strconv.FormatInt(c.appID, 10),
+	})
+	signed, err := token.SignedString(c.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App token: %w", err)
+	}
+	return signed, nil
+}
+
+// do calls the GitHub API and decodes a successful response into out, returning the status code.
+// Only unexpected statuses are errors, so callers can interpret 403 and 404.
+func (c *GitHubAppClient) do(ctx context.Context, method, path, authorization string, out any) (int, error) {
+	c.mu.Lock()
+	baseURL := c.baseURL
+	c.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("GitHub API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated:
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return 0, fmt.Errorf("failed to decode GitHub API response: %w", err)
+		}
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden:
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, fmt.Errorf("GitHub API error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp.StatusCode, nil
+}
+
+// RepositoryPermission returns the permission username has on a repository: admin, write, read or none.
+// It fails with v0.ErrRepositoryNotVisible if the app is not installed on the repository.
+func (c *GitHubAppClient) RepositoryPermission(ctx context.Context, owner, repo, username string) (string, error) {
+	if !isValidGitHubName(owner) || !isValidGitHubRepoName(repo) || !isValidGitHubName(username) {
+		return "", fmt.Errorf("invalid GitHub repository %s/%s or user %s", owner, repo, username)
+	}
+
+	appToken, err := c.appToken()
+	if err != nil {
+		return "", err
+	}
+
+	var installation struct {
+		ID int64 `json:"id"`
+	}
+	status, err := c.do(ctx, http.MethodGet, "/repos/"+owner+"/"+repo+"/installation", "Bearer "+appToken, &installation)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", v0.ErrRepositoryNotVisible
+	}
+
+	token, err := c.installationToken(ctx, installation.ID)
+	if err != nil {
+		return "", err
+	}
+
+	var permission struct {
+		Permission string `json:"permission"`
+	}
+	path := "/repos/" + owner + "/" + repo + "/collaborators/" + username + "/permission"
+	status, err = c.do(ctx, http.MethodGet, path, "token "+token, &permission)
+	if err != nil {
+		return "", err
+	}
+	switch status {
+	case http.StatusOK:
+		return permission.Permission, nil
+	case http.StatusNotFound:
+		// Unknown users have no permission
+		return "none", nil
+	default:
+		return "", v0.ErrRepositoryNotVisible
+	}
+}
+
+// NewRepositoryPermissionChecker returns the GitHub App client that checks the repository permission required
+// by GITHUB_REPO_PERMISSION, or nil if it is not required
+func NewRepositoryPermissionChecker(cfg *config.Config) *GitHubAppClient {
+	required, err := cfg.RequiredGitHubRepoPermission()
+	if err != nil {
+		panic(fmt.Sprintf("Invalid GitHub repository permission configuration: %v", err))
+	}
+	if required == "" {
+		return nil
+	}
+
+	app, err := NewGitHubAppClient(cfg.GitHubAppID, cfg.GitHubAppPrivateKey, "https://api.github.com")
+	if err != nil {
+		panic(fmt.Sprintf("Invalid GitHub App configuration: %v", err))
+	}
+	return app
+}
+
+func isValidGitHubRepoName(name string) bool {
+	return regexp.MustCompile(`^[a-zA-Z0-9._-]+$`).MatchString(name) && name != "." && name != ".."
+}
@@ -0,0 +1,209 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestGitHubHandler_ExchangeTokenWithGitHubApp(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	appKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	appKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(appKey)})
+
+	cfg := &config.Config{
+		JWTPrivateKey:       hex.EncodeToString(testSeed),
+		GitHubAppID:         4242,
+		GitHubAppPrivateKey: string(appKeyPEM),
+	}
+
+	var tokensCreated atomic.Int32
+	checkAppToken := func(t *testing.T, r *http.Request) {
+		t.Helper()
+		claims := &jwt.RegisteredClaims{}
+		_, err := jwt.ParseWithClaims(r.Header.Get("Authorization")[len("Bearer "):], claims,
+			func(_ *jwt.Token) (interface{}, error) { return &appKey.PublicKey, nil })
+		require.NoError(t, err)
+		assert.Equal(t, "4242", claims.Issuer)
+	}
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case githubUserEndpoint:
+			_ = json.NewEncoder(w).Encode(v0auth.GitHubUserOrOrg{Login: "testuser", ID: 12345})
+		case githubOrgsEndpoint:
+			// The user's token only sees their public membership
+			_ = json.NewEncoder(w).Encode([]v0auth.GitHubUserOrOrg{{Login: "public-org", ID: 1}})
+		case "/app/installations":
+			checkAppToken(t, r)
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "account": {"login": "private-org", "type": "Organization"}},
+				{"id": 2, "account": {"login": "other-org", "type": "Organization"}},
+				{"id": 3, "account": {"login": "someone", "type": "User"}}
+			]`))
+		case "/app/installations/1/access_tokens", "/app/installations/2/access_tokens":
+			checkAppToken(t, r)
+			tokensCreated.Add(1)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"token":      "installation-" + r.URL.Path[len("/app/installations/"):len("/app/installations/")+1],
+				"expires_at": time.Now().Add(time.Hour),
+			})
+		case "/orgs/private-org/memberships/testuser":
+			assert.Equal(t, "token installation-1", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"state": "active", "role": "member"}`))
+		case githubMembershipEndpoint + "public-org":
+			_, _ = w.Write([]byte(`{"state": "active", "role": "admin"}`))
+		case "/orgs/other-org/memberships/testuser":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	handler := v0auth.NewGitHubHandler(cfg)
+	handler.SetBaseURL(mockServer.URL)
+	jwtManager := auth.NewJWTManager(cfg)
+
+	for range 2 {
+		response, err := handler.ExchangeToken(context.Background(), "valid-github-token")
+		require.NoError(t, err)
+
+		claims, err := jwtManager.ValidateToken(context.Background(), response.RegistryToken)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"},
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.public-org/*"},
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.private-org/*"},
+		}, claims.Permissions)
+	}
+
+	// Installation tokens are reused between logins
+	assert.Equal(t, int32(2), tokensCreated.Load())
+}
+
+func TestNewGitHubAppClient_InvalidKey(t *testing.T) {
+	_, err := v0auth.NewGitHubAppClient(1, "not a key", "https://api.github.com")
+	assert.Error(t, err)
+}
+
+func TestGitHubAppClient_RepositoryPermission(t *testing.T) {
+	appKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	appKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(appKey)})
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/acme/tools/installation":
+			_, _ = w.Write([]byte(`{"id": 7}`))
+		case "/app/installations/7/access_tokens":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"token": "installation-7", "expires_at": time.Now().Add(time.Hour)})
+		case "/repos/acme/tools/collaborators/maintainer/permission":
+			assert.Equal(t, "token installation-7", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"permission": "write", "role_name": "maintain"}`))
+		case "/repos/acme/tools/collaborators/member/permission":
+			_, _ = w.Write([]byte(`{"permission": "read", "role_name": "read"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client, err := v0auth.NewGitHubAppClient(4242, string(appKeyPEM), mockServer.URL)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	permission, err := client.RepositoryPermission(ctx, "acme", "tools", "maintainer")
+	require.NoError(t, err)
+	assert.Equal(t, "write", permission)
+
+	permission, err = client.RepositoryPermission(ctx, "acme", "tools", "member")
+	require.NoError(t, err)
+	assert.Equal(t, "read", permission)
+
+	permission, err = client.RepositoryPermission(ctx, "acme", "tools", "stranger")
+	require.NoError(t, err)
+	assert.Equal(t, "none", permission)
+
+	_, err = client.RepositoryPermission(ctx, "other", "repo", "maintainer")
+	assert.ErrorIs(t, err, v0.ErrRepositoryNotVisible)
+
+	_, err = client.RepositoryPermission(ctx, "acme", "../admin", "maintainer")
+	assert.Error(t, err)
+}
+
+func TestGitHubHandler_RecheckPermissions(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	appKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	appKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(appKey)})
+
+	cfg := &config.Config{
+		JWTPrivateKey:       hex.EncodeToString(testSeed),
+		GitHubAppID:         4242,
+		GitHubAppPrivateKey: string(appKeyPEM),
+	}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/app/installations":
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "account": {"login": "current-org", "type": "Organization"}},
+				{"id": 2, "account": {"login": "former-org", "type": "Organization"}}
+			]`))
+		case "/app/installations/1/access_tokens", "/app/installations/2/access_tokens":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"token": "installation", "expires_at": time.Now().Add(time.Hour)})
+		case "/orgs/current-org/memberships/testuser":
+			_, _ = w.Write([]byte(`{"state": "active", "role": "member"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	handler := v0auth.NewGitHubHandler(cfg)
+	handler.SetBaseURL(mockServer.URL)
+
+	permissions, err := handler.RecheckPermissions(context.Background(), "testuser", []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.current-org/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.former-org/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.uninstalled-org/*"},
+	})
+	require.NoError(t, err)
+	// The user left former-org, and uninstalled-org cannot be checked without the user's token
+	assert.Equal(t, []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.current-org/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.uninstalled-org/*"},
+	}, permissions)
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -26,21 +27,42 @@ type GitHubTokenExchangeInput struct {
 type GitHubHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
-	baseURL    string // Configurable for testing
+	baseURL    string           // Configurable for testing
+	app        *GitHubAppClient // Set when a GitHub App is configured
+	orgRole    string           // Organization role required to publish to io.github.<org>/*
 }
 
 // NewGitHubHandler creates a new GitHub handler
 func NewGitHubHandler(cfg *config.Config) *GitHubHandler {
-	return &GitHubHandler{
+	handler := &GitHubHandler{
 		config:     cfg,
 		jwtManager: auth.NewJWTManager(cfg),
 		baseURL:    "https://api.github.com",
 	}
+
+	orgRole, err := cfg.RequiredGitHubOrgRole()
+	if err != nil {
+		panic(fmt.Sprintf("Invalid GitHub organization role configuration: %v", err))
+	}
+	handler.orgRole = orgRole
+
+	if cfg.GitHubAppID != 0 {
+		app, err := NewGitHubAppClient(cfg.GitHubAppID, cfg.GitHubAppPrivateKey, handler.baseURL)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid GitHub App configuration: %v", err))
+		}
+		handler.app = app
+	}
+
+	return handler
 }
 
 // SetBaseURL sets the base URL for GitHub API (used for testing)
 func (h *GitHubHandler) SetBaseURL(url string) {
 	h.baseURL = url
+	if h.app != nil {
+		h.app.SetBaseURL(url)
+	}
 }
 
 // RegisterGitHubATEndpoint registers the GitHub access token authentication endpoint with a custom path prefix
@@ -81,6 +103,25 @@ func (h *GitHubHandler) ExchangeToken(ctx context.Context, githubToken string) (
 		return nil, fmt.Errorf("failed to get GitHub organizations: %w", err)
 	}
 
+	// Listing an organization is not enough: the membership must be active and have the required role
+	orgs, err = h.filterOrgsByRole(ctx, orgs, githubToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify GitHub organization memberships: %w", err)
+	}
+
+	// The user's token only shows public memberships, so add those the GitHub App can see
+	if h.app != nil {
+		appOrgs, err := h.app.MemberOrganizations(ctx, user.Login, h.orgRole)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub organizations from the GitHub App: %w", err)
+		}
+		for _, appOrg := range appOrgs {
+			if !slices.ContainsFunc(orgs, func(org GitHubUserOrOrg) bool { return strings.EqualFold(org.Login, appOrg.Login) }) {
+				orgs = append(orgs, appOrg)
+			}
+		}
+	}
+
 	// Build permissions based on user and organizations
 	permissions := h.buildPermissions(user.Login, orgs)
 
@@ -164,6 +205,119 @@ func (h *GitHubHandler) getGitHubUserOrgs(ctx context.Context, username string,
 	return orgs, nil
 }
 
+// orgMembership is the subset of a GitHub organization membership needed to check the user's role
+type orgMembership struct {
+	State string `json:"state"`
+	Role  string `json:"role"`
+}
+
+// hasRole reports whether the membership is active and has at least the given role
+func (m orgMembership) hasRole(role string) bool {
+	if m.State != "active" {
+		return false
+	}
+	if role == config.GitHubOrgRoleAdmin {
+		return m.Role == "admin"
+	}
+	return m.Role == "member" || m.Role == "admin"
+}
+
+// filterOrgsByRole keeps the organizations in which the user has the required role. Orgs with an invalid
+// name are returned unchecked, as buildPermissions then grants nothing anyway.
+func (h *GitHubHandler) filterOrgsByRole(ctx context.Context, orgs []GitHubUserOrOrg, token string) ([]GitHubUserOrOrg, error) {
+	if slices.ContainsFunc(orgs, func(org GitHubUserOrOrg) bool { return !isValidGitHubName(org.Login) }) {
+		return orgs, nil
+	}
+
+	var verified []GitHubUserOrOrg
+	for _, org := range orgs {
+		membership, err := h.getGitHubOrgMembership(ctx, org.Login, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check membership of %s: %w", org.Login, err)
+		}
+		if membership.hasRole(h.orgRole) {
+			verified = append(verified, org)
+		}
+	}
+	return verified, nil
+}
+
+// getGitHubOrgMembership gets the authenticated user's membership of an organization. A missing or
+// inaccessible membership, e.g. because the organization restricts OAuth app access, is not an error.
+func (h *GitHubHandler) getGitHubOrgMembership(ctx context.Context, org string, token string) (orgMembership, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.baseURL+"/user/memberships/orgs/"+org, nil)
+	if err != nil {
+		return orgMembership{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return orgMembership{}, fmt.Errorf("failed to get organization membership: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusForbidden:
+		return orgMembership{}, nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return orgMembership{}, fmt.Errorf("GitHub API error (status %d): %s", resp.StatusCode, body)
+	}
+
+	var membership orgMembership
+	if err := json.NewDecoder(resp.Body).Decode(&membership); err != nil {
+		return orgMembership{}, fmt.Errorf("failed to decode membership response: %w", err)
+	}
+
+	return membership, nil
+}
+
+// RecheckPermissions returns the permissions of a login by username that still hold, for refreshing its
+// token. The GitHub App, if configured, checks the user is still a member of the organizations that
+// installed it, and the namespaces of those they left are dropped. Other permissions cannot be checked
+// without the user's token, so they are kept until the refresh token expires.
+func (h *GitHubHandler) RecheckPermissions(ctx context.Context, username string, permissions []auth.Permission) ([]auth.Permission, error) {
+	if h.app == nil {
+		return permissions, nil
+	}
+
+	installations, err := h.app.orgInstallations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	memberOrgs, err := h.app.MemberOrganizations(ctx, username, h.orgRole)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []auth.Permission
+	for _, permission := range permissions {
+		org, ok := strings.CutPrefix(permission.ResourcePattern, "io.github.")
+		org, isNamespace := strings.CutSuffix(org, "/*")
+		checked := ok && isNamespace && !strings.EqualFold(org, username) && hasInstallation(installations, org)
+		if checked && !slices.ContainsFunc(memberOrgs, func(member GitHubUserOrOrg) bool { return strings.EqualFold(member.Login, org) }) {
+			continue
+		}
+		kept = append(kept, permission)
+	}
+	return kept, nil
+}
+
+// hasInstallation reports whether the GitHub App is installed in the organization
+func hasInstallation(installations map[string]int64, org string) bool {
+	for installed := range installations {
+		if strings.EqualFold(installed, org) {
+			return true
+		}
+	}
+	return false
+}
+
 // buildPermissions builds permissions based on GitHub user and their organizations
 func (h *GitHubHandler) buildPermissions(username string, orgs []GitHubUserOrOrg) []auth.Permission {
 	permissions := []auth.Permission{}
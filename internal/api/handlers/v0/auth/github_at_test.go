@@ -23,6 +23,8 @@ import (
 const (
 	githubUserEndpoint = "/user"
 	githubOrgsEndpoint = "/users/testuser/orgs"
+
+	githubMembershipEndpoint = "/user/memberships/orgs/"
 )
 
 func TestGitHubHandler_ExchangeToken(t *testing.T) {
@@ -102,6 +104,9 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 				}
 				w.Header().Set("Content-Type", "application/json")
 				json.NewEncoder(w).Encode(orgs) //nolint:errcheck
+			case githubMembershipEndpoint + "test-org-1", githubMembershipEndpoint + "test-org-2":
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"state": "active", "role": "member"}`)) //nolint:errcheck
 			default:
 				w.WriteHeader(http.StatusNotFound)
 			}
@@ -138,6 +143,63 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 		}
 	})
 
+	t.Run("organizations require an active membership with the configured role", func(t *testing.T) {
+		memberships := map[string]string{
+			"admin-org":   `{"state": "active", "role": "admin"}`,
+			"member-org":  `{"state": "active", "role": "member"}`,
+			"pending-org": `{"state": "pending", "role": "admin"}`,
+			"billing-org": `{"state": "active", "role": "billing_manager"}`,
+		}
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case githubUserEndpoint:
+				json.NewEncoder(w).Encode(v0auth.GitHubUserOrOrg{Login: "testuser", ID: 12345}) //nolint:errcheck
+			case githubOrgsEndpoint:
+				orgs := []v0auth.GitHubUserOrOrg{
+					{Login: "admin-org"}, {Login: "member-org"}, {Login: "pending-org"},
+					{Login: "billing-org"}, {Login: "restricted-org"},
+				}
+				json.NewEncoder(w).Encode(orgs) //nolint:errcheck
+			default:
+				membership, ok := memberships[r.URL.Path[len(githubMembershipEndpoint):]]
+				if !ok {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				w.Write([]byte(membership)) //nolint:errcheck
+			}
+		}))
+		defer mockServer.Close()
+
+		for role, want := range map[string][]string{
+			config.GitHubOrgRoleMember: {"io.github.testuser/*", "io.github.admin-org/*", "io.github.member-org/*"},
+			config.GitHubOrgRoleAdmin:  {"io.github.testuser/*", "io.github.admin-org/*"},
+		} {
+			roleCfg := *cfg
+			roleCfg.GitHubOrgRole = role
+			handler := v0auth.NewGitHubHandler(&roleCfg)
+			handler.SetBaseURL(mockServer.URL)
+
+			response, err := handler.ExchangeToken(context.Background(), "valid-github-token")
+			require.NoError(t, err)
+
+			claims, err := auth.NewJWTManager(cfg).ValidateToken(context.Background(), response.RegistryToken)
+			require.NoError(t, err)
+			var patterns []string
+			for _, perm := range claims.Permissions {
+				patterns = append(patterns, perm.ResourcePattern)
+			}
+			assert.Equal(t, want, patterns, "role %s", role)
+		}
+	})
+
+	t.Run("invalid organization role panics", func(t *testing.T) {
+		invalidCfg := *cfg
+		invalidCfg.GitHubOrgRole = "owner"
+		assert.Panics(t, func() { v0auth.NewGitHubHandler(&invalidCfg) })
+	})
+
 	t.Run("invalid token returns error", func(t *testing.T) {
 		// Create mock GitHub API server that returns 401
 		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -540,6 +602,9 @@ func TestValidGitHubNames(t *testing.T) {
 				case "/users/" + tc.username + "/orgs":
 					w.Header().Set("Content-Type", "application/json")
 					json.NewEncoder(w).Encode(tc.orgs) //nolint:errcheck
+				case githubMembershipEndpoint + "valid-org":
+					w.Header().Set("Content-Type", "application/json")
+					w.Write([]byte(`{"state": "active", "role": "member"}`)) //nolint:errcheck
 				}
 			}))
 			defer mockServer.Close()
@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// gitLabDeveloperAccessLevel is the lowest group access level that grants publish permissions for the group
+const gitLabDeveloperAccessLevel = 30
+
+var gitLabNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9.-]*$`)
+
+// GitLabTokenExchangeInput represents the input for GitLab token exchange
+type GitLabTokenExchangeInput struct {
+	Body struct {
+		GitLabToken string `json:"gitlab_token" doc:"GitLab OAuth access token" required:"true"`
+	}
+}
+
+// GitLabHandler handles GitLab authentication
+type GitLabHandler struct {
+	config     *config.Config
+	jwtManager *auth.JWTManager
+	baseURL    string // GitLab API URL, configurable for self-managed instances and testing
+}
+
+// NewGitLabHandler creates a new GitLab handler for the configured GitLab instance
+func NewGitLabHandler(cfg *config.Config) *GitLabHandler {
+	return &GitLabHandler{
+		config:     cfg,
+		jwtManager: auth.NewJWTManager(cfg),
+		baseURL:    strings.TrimSuffix(cfg.GitLabBaseURL, "/") + "/api/v4",
+	}
+}
+
+// SetBaseURL sets the base URL for the GitLab API (used for testing)
+func (h *GitLabHandler) SetBaseURL(url string) {
+	h.baseURL = url
+}
+
+// RegisterGitLabATEndpoint registers the GitLab access token authentication endpoint with a custom path prefix
+func RegisterGitLabATEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
+	handler := NewGitLabHandler(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-gitlab-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/gitlab-at",
+		Summary:     "Exchange GitLab OAuth access token for Registry JWT",
+		Description: "Exchange a GitLab OAuth access token for a short-lived Registry JWT token. " +
+			"The token grants publish permissions for io.gitlab.<username>/* and for io.gitlab.<group>/* for each top-level group the user is at least a Developer of.",
+		Tags: []string{"auth"},
+	}, func(ctx context.Context, input *GitLabTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
+		response, err := handler.ExchangeToken(ctx, input.Body.GitLabToken)
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Token exchange failed", err)
+		}
+
+		return &v0.Response[auth.TokenResponse]{
+			Body: *response,
+		}, nil
+	})
+}
+
+// ExchangeToken exchanges a GitLab OAuth token for a Registry JWT token
+func (h *GitLabHandler) ExchangeToken(ctx context.Context, gitlabToken string) (*auth.TokenResponse, error) {
+	user, err := h.getGitLabUser(ctx, gitlabToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab user: %w", err)
+	}
+	if user.State != "active" {
+		return nil, fmt.Errorf("GitLab user %s is not active", user.Username)
+	}
+
+	groups, err := h.getGitLabGroups(ctx, gitlabToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitLab groups: %w", err)
+	}
+
+	claims := auth.JWTClaims{
+		AuthMethod:        auth.MethodGitLabAT,
+		AuthMethodSubject: user.Username,
+		Permissions:       h.buildPermissions(user.Username, groups),
+	}
+
+	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+	}
+
+	return tokenResponse, nil
+}
+
+// GitLabUser is the subset of the GitLab user API response used for authentication
+type GitLabUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	State    string `json:"state"`
+}
+
+// GitLabGroup is the subset of the GitLab groups API response used for authentication
+type GitLabGroup struct {
+	ID       int    `json:"id"`
+	Path     string `json:"path"`
+	FullPath string `json:"full_path"`
+}
+
+// getGitLabUser gets the authenticated user's information
+func (h *GitLabHandler) getGitLabUser(ctx context.Context, token string) (*GitLabUser, error) {
+	var user GitLabUser
+	if err := h.get(ctx, token, "/user", &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// getGitLabGroups gets the top-level groups the authenticated user can publish for
+func (h *GitLabHandler) getGitLabGroups(ctx context.Context, token string) ([]GitLabGroup, error) {
+	var groups []GitLabGroup
+	path := fmt.Sprintf("/groups?top_level_only=true&min_access_level=%d&per_page=100", gitLabDeveloperAccessLevel)
+	if err := h.get(ctx, token, path, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (h *GitLabHandler) get(ctx context.Context, token, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call GitLab API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GitLab API error (status %d): %s", resp.StatusCode, body)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode GitLab API response: %w", err)
+	}
+
+	return nil
+}
+
+// buildPermissions builds permissions based on the GitLab user and their top-level groups.
+// Names that cannot appear in a server name namespace, such as those containing underscores, are skipped.
+func (h *GitLabHandler) buildPermissions(username string, groups []GitLabGroup) []auth.Permission {
+	permissions := []auth.Permission{}
+
+	if isValidGitLabName(username) {
+		permissions = append(permissions, auth.Permission{
+			Action:          auth.PermissionActionPublish,
+			ResourcePattern: fmt.Sprintf("io.gitlab.%s/*", username),
+		})
+	}
+
+	for _, group := range groups {
+		// Subgroups are filtered out by the API, but check in case an instance ignores top_level_only
+		if group.FullPath != group.Path || !isValidGitLabName(group.Path) {
+			continue
+		}
+		permissions = append(permissions, auth.Permission{
+			Action:          auth.PermissionActionPublish,
+			ResourcePattern: fmt.Sprintf("io.gitlab.%s/*", group.Path),
+		})
+	}
+
+	return permissions
+}
+
+func isValidGitLabName(name string) bool {
+	return gitLabNameRegex.MatchString(name)
+}
@@ -0,0 +1,128 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockGitLabAPI(t *testing.T, user v0auth.GitLabUser, groups []v0auth.GitLabGroup) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-gitlab-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/user":
+			json.NewEncoder(w).Encode(user) //nolint:errcheck
+		case "/groups":
+			assert.Equal(t, "true", r.URL.Query().Get("top_level_only"))
+			assert.Equal(t, "30", r.URL.Query().Get("min_access_level"))
+			json.NewEncoder(w).Encode(groups) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGitLabHandler_ExchangeToken(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		JWTPrivateKey: hex.EncodeToString(testSeed),
+		GitLabBaseURL: "https://gitlab.com",
+	}
+	activeUser := v0auth.GitLabUser{ID: 1, Username: "testuser", State: "active"}
+
+	tests := []struct {
+		name          string
+		user          v0auth.GitLabUser
+		groups        []v0auth.GitLabGroup
+		token         string
+		expectError   bool
+		expectedPerms []string
+	}{
+		{
+			name:          "user only",
+			user:          activeUser,
+			token:         "valid-gitlab-token",
+			expectedPerms: []string{"io.gitlab.testuser/*"},
+		},
+		{
+			name: "user and top-level groups",
+			user: activeUser,
+			groups: []v0auth.GitLabGroup{
+				{ID: 10, Path: "acme", FullPath: "acme"},
+				{ID: 11, Path: "platform", FullPath: "acme/platform"},
+				{ID: 12, Path: "bad_name", FullPath: "bad_name"},
+			},
+			token:         "valid-gitlab-token",
+			expectedPerms: []string{"io.gitlab.testuser/*", "io.gitlab.acme/*"},
+		},
+		{
+			name:          "username that cannot be a namespace",
+			user:          v0auth.GitLabUser{ID: 2, Username: "test_user", State: "active"},
+			groups:        []v0auth.GitLabGroup{{ID: 10, Path: "acme", FullPath: "acme"}},
+			token:         "valid-gitlab-token",
+			expectedPerms: []string{"io.gitlab.acme/*"},
+		},
+		{
+			name:        "blocked user",
+			user:        v0auth.GitLabUser{ID: 3, Username: "testuser", State: "blocked"},
+			token:       "valid-gitlab-token",
+			expectError: true,
+		},
+		{
+			name:        "invalid token",
+			user:        activeUser,
+			token:       "invalid-token",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockServer := newMockGitLabAPI(t, tt.user, tt.groups)
+			defer mockServer.Close()
+
+			handler := v0auth.NewGitLabHandler(cfg)
+			handler.SetBaseURL(mockServer.URL)
+
+			ctx := context.Background()
+			response, err := handler.ExchangeToken(ctx, tt.token)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			claims, err := auth.NewJWTManager(cfg).ValidateToken(ctx, response.RegistryToken)
+			require.NoError(t, err)
+			assert.Equal(t, auth.MethodGitLabAT, claims.AuthMethod)
+			assert.Equal(t, tt.user.Username, claims.AuthMethodSubject)
+
+			patterns := []string{}
+			for _, perm := range claims.Permissions {
+				assert.Equal(t, auth.PermissionActionPublish, perm.Action)
+				patterns = append(patterns, perm.ResourcePattern)
+			}
+			assert.Equal(t, tt.expectedPerms, patterns)
+		})
+	}
+}
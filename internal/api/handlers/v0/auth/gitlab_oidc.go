@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// gitLabOIDCAudience is the audience GitLab CI ID tokens must be issued for, set with id_tokens in .gitlab-ci.yml
+const gitLabOIDCAudience = "mcp-registry"
+
+// GitLabOIDCTokenExchangeInput represents the input for GitLab CI ID token exchange
+type GitLabOIDCTokenExchangeInput struct {
+	Body struct {
+		OIDCToken string `json:"oidc_token" doc:"GitLab CI job ID token with the mcp-registry audience" required:"true"`
+	}
+}
+
+// GitLabOIDCHandler handles GitLab CI/CD OIDC authentication
+type GitLabOIDCHandler struct {
+	jwtManager *auth.JWTManager
+	validator  GenericOIDCValidator
+}
+
+// NewGitLabOIDCHandler creates a new GitLab OIDC handler trusting ID tokens issued by the configured GitLab
+// instance. Its signing keys are fetched when the first token is validated, so creating the handler does not
+// need network access.
+func NewGitLabOIDCHandler(cfg *config.Config) *GitLabOIDCHandler {
+	issuer := strings.TrimSuffix(cfg.GitLabBaseURL, "/")
+	keySet := oidc.NewRemoteKeySet(context.Background(), issuer+"/oauth/discovery/keys")
+	verifier := oidc.NewVerifier(issuer, keySet, &oidc.Config{ClientID: gitLabOIDCAudience})
+
+	return &GitLabOIDCHandler{
+		jwtManager: auth.NewJWTManager(cfg),
+		validator:  &StandardOIDCValidator{verifier: verifier},
+	}
+}
+
+// SetValidator sets a custom OIDC validator (used for testing)
+func (h *GitLabOIDCHandler) SetValidator(validator GenericOIDCValidator) {
+	h.validator = validator
+}
+
+// RegisterGitLabOIDCEndpoint registers the GitLab CI/CD OIDC authentication endpoint with a custom path prefix
+func RegisterGitLabOIDCEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
+	handler := NewGitLabOIDCHandler(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-gitlab-oidc-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/gitlab-oidc",
+		Summary:     "Exchange GitLab CI ID token for Registry JWT",
+		Description: "Exchange a GitLab CI/CD job ID token, issued with the mcp-registry audience, for a short-lived Registry JWT token. " +
+			"The token grants publish permissions for the top-level group or user namespace of the project the job runs in, e.g. io.gitlab.<group>/*.",
+		Tags: []string{"auth"},
+	}, func(ctx context.Context, input *GitLabOIDCTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
+		response, err := handler.ExchangeToken(ctx, input.Body.OIDCToken)
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Token exchange failed", err)
+		}
+
+		return &v0.Response[auth.TokenResponse]{
+			Body: *response,
+		}, nil
+	})
+}
+
+// ExchangeToken exchanges a GitLab CI ID token for a Registry JWT token with publish permissions for the
+// namespace of the project's top-level group
+func (h *GitLabOIDCHandler) ExchangeToken(ctx context.Context, oidcToken string) (*auth.TokenResponse, error) {
+	claims, err := h.validator.ValidateToken(ctx, oidcToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate GitLab ID token: %w", err)
+	}
+
+	namespacePath, _ := claims.ExtraClaims["namespace_path"].(string)
+	if namespacePath == "" {
+		return nil, fmt.Errorf("token has no namespace_path claim")
+	}
+
+	// Like GitHub Actions tokens grant the repository owner's namespace, the job may publish any server of
+	// the project's top-level group (or user), as a monorepo or subgroup may hold several servers
+	topLevel, _, _ := strings.Cut(namespacePath, "/")
+	if !isValidGitLabName(topLevel) {
+		return nil, fmt.Errorf("invalid GitLab namespace: %s", topLevel)
+	}
+
+	jwtClaims := auth.JWTClaims{
+		AuthMethod:        auth.MethodGitLabOIDC,
+		AuthMethodSubject: claims.Subject, // e.g. "project_path:my-group/my-project:ref_type:branch:ref:main"
+		Permissions: []auth.Permission{{
+			Action:          auth.PermissionActionPublish,
+			ResourcePattern: fmt.Sprintf("io.gitlab.%s/*", topLevel),
+		}},
+	}
+
+	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, jwtClaims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+	}
+
+	return tokenResponse, nil
+}
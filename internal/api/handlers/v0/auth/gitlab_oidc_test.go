@@ -0,0 +1,95 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLabOIDCHandler_ExchangeToken(t *testing.T) {
+	cfg := &config.Config{
+		GitLabBaseURL: "https://gitlab.com",
+		JWTPrivateKey: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+	}
+
+	tests := []struct {
+		name          string
+		claims        *v0auth.OIDCClaims
+		validateErr   error
+		expectedError string
+		expectedPerms []string
+	}{
+		{
+			name: "project in a group",
+			claims: &v0auth.OIDCClaims{
+				Subject:     "project_path:acme/weather:ref_type:branch:ref:main",
+				ExtraClaims: map[string]any{"namespace_path": "acme", "project_path": "acme/weather"},
+			},
+			expectedPerms: []string{"io.gitlab.acme/*"},
+		},
+		{
+			name: "project in a subgroup gets its top-level group",
+			claims: &v0auth.OIDCClaims{
+				Subject:     "project_path:acme/tools/weather:ref_type:tag:ref:v1.0.0",
+				ExtraClaims: map[string]any{"namespace_path": "acme/tools", "project_path": "acme/tools/weather"},
+			},
+			expectedPerms: []string{"io.gitlab.acme/*"},
+		},
+		{
+			name: "namespace that cannot appear in a server name",
+			claims: &v0auth.OIDCClaims{
+				Subject:     "project_path:my_user/weather:ref_type:branch:ref:main",
+				ExtraClaims: map[string]any{"namespace_path": "my_user"},
+			},
+			expectedError: "invalid GitLab namespace",
+		},
+		{
+			name:          "token without namespace",
+			claims:        &v0auth.OIDCClaims{Subject: "user:1", ExtraClaims: map[string]any{}},
+			expectedError: "no namespace_path claim",
+		},
+		{
+			name:          "invalid token",
+			validateErr:   fmt.Errorf("oidc: expected audience \"mcp-registry\""),
+			expectedError: "failed to validate GitLab ID token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := v0auth.NewGitLabOIDCHandler(cfg)
+			handler.SetValidator(&MockGenericOIDCValidator{
+				validateFunc: func(_ context.Context, _ string) (*v0auth.OIDCClaims, error) {
+					return tt.claims, tt.validateErr
+				},
+			})
+
+			ctx := context.Background()
+			response, err := handler.ExchangeToken(ctx, "gitlab-id-token")
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			claims, err := auth.NewJWTManager(cfg).ValidateToken(ctx, response.RegistryToken)
+			require.NoError(t, err)
+			assert.Equal(t, auth.MethodGitLabOIDC, claims.AuthMethod)
+			assert.Equal(t, tt.claims.Subject, claims.AuthMethodSubject)
+
+			patterns := []string{}
+			for _, perm := range claims.Permissions {
+				assert.Equal(t, auth.PermissionActionPublish, perm.Action)
+				patterns = append(patterns, perm.ResourcePattern)
+			}
+			assert.Equal(t, tt.expectedPerms, patterns)
+		})
+	}
+}
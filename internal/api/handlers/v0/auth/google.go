@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+const (
+	// googleJWKSURL is where Google publishes the keys its ID tokens are signed with
+	googleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+)
+
+// googleIssuers are the issuers Google ID tokens may carry
+var googleIssuers = map[string]bool{
+	"https://accounts.google.com": true,
+	"accounts.google.com":         true,
+}
+
+// GoogleTokenExchangeInput represents the input for Google ID token exchange
+type GoogleTokenExchangeInput struct {
+	Body struct {
+		GoogleToken string `json:"google_token" doc:"Google ID token issued to the registry's Google client ID" required:"true"`
+	}
+}
+
+// GoogleHandler handles Google sign-in for Google Workspace domains
+type GoogleHandler struct {
+	jwtManager *auth.JWTManager
+	validator  GenericOIDCValidator
+}
+
+// NewGoogleHandler creates a new Google handler. Google's signing keys are fetched when the first
+// token is validated, so creating the handler does not need network access.
+func NewGoogleHandler(cfg *config.Config) *GoogleHandler {
+	keySet := oidc.NewRemoteKeySet(context.Background(), googleJWKSURL)
+	// Google ID tokens are issued by either form of the issuer, which is checked in ExchangeToken
+	verifier := oidc.NewVerifier("https://accounts.google.com", keySet, &oidc.Config{
+		ClientID:        cfg.GoogleClientID,
+		SkipIssuerCheck: true,
+	})
+
+	return &GoogleHandler{
+		jwtManager: auth.NewJWTManager(cfg),
+		validator:  &StandardOIDCValidator{verifier: verifier},
+	}
+}
+
+// SetValidator sets a custom OIDC validator (used for testing)
+func (h *GoogleHandler) SetValidator(validator GenericOIDCValidator) {
+	h.validator = validator
+}
+
+// RegisterGoogleEndpoint registers the Google sign-in endpoint with a custom path prefix
+func RegisterGoogleEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
+	if cfg.GoogleClientID == "" {
+		return // Skip registration if Google sign-in is not configured
+	}
+
+	handler := NewGoogleHandler(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-google-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/google",
+		Summary:     "Exchange Google ID token for Registry JWT",
+		Description: "Exchange a Google ID token for a short-lived Registry JWT token. The account must belong to a Google Workspace, and the token grants publish permissions " +
+			"for the Workspace's verified domain and its subdomains, e.g. com.example/* and com.example.* for example.com.",
+		Tags: []string{"auth"},
+	}, func(ctx context.Context, input *GoogleTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
+		response, err := handler.ExchangeToken(ctx, input.Body.GoogleToken)
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Token exchange failed", err)
+		}
+
+		return &v0.Response[auth.TokenResponse]{
+			Body: *response,
+		}, nil
+	})
+}
+
+// ExchangeToken exchanges a Google ID token for a Registry JWT token with publish permissions for
+// the namespace of the account's Google Workspace domain
+func (h *GoogleHandler) ExchangeToken(ctx context.Context, googleToken string) (*auth.TokenResponse, error) {
+	claims, err := h.validator.ValidateToken(ctx, googleToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate Google ID token: %w", err)
+	}
+	if !googleIssuers[claims.Issuer] {
+		return nil, fmt.Errorf("token was not issued by Google: %s", claims.Issuer)
+	}
+
+	domain, email, err := googleWorkspaceDomain(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtClaims := auth.JWTClaims{
+		AuthMethod:        auth.MethodGoogle,
+		AuthMethodSubject: email,
+		Permissions:       BuildPermissions(domain, true),
+	}
+
+	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, jwtClaims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+	}
+
+	return tokenResponse, nil
+}
+
+// googleWorkspaceDomain returns the verified Google Workspace domain and email address of a Google
+// account. The hd claim is only set for Workspace accounts, and only to a domain the Workspace has
+// verified ownership of; consumer accounts such as gmail.com addresses have no hd claim.
+func googleWorkspaceDomain(claims *OIDCClaims) (string, string, error) {
+	domain, _ := claims.ExtraClaims["hd"].(string)
+	if domain == "" {
+		return "", "", fmt.Errorf("account is not part of a Google Workspace")
+	}
+	domain = strings.ToLower(domain)
+	if !IsValidDomain(domain) {
+		return "", "", fmt.Errorf("invalid hosted domain: %s", domain)
+	}
+
+	if verified, _ := claims.ExtraClaims["email_verified"].(bool); !verified {
+		return "", "", fmt.Errorf("email address is not verified")
+	}
+	email, _ := claims.ExtraClaims["email"].(string)
+	if !strings.HasSuffix(strings.ToLower(email), "@"+domain) {
+		return "", "", fmt.Errorf("email address %q is not in the hosted domain %s", email, domain)
+	}
+
+	return domain, email, nil
+}
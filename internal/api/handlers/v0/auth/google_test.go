@@ -0,0 +1,137 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoogleHandler_ExchangeToken(t *testing.T) {
+	cfg := &config.Config{
+		GoogleClientID: "test-client-id",
+		JWTPrivateKey:  "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+	}
+
+	tests := []struct {
+		name          string
+		claims        *v0auth.OIDCClaims
+		validateErr   error
+		expectedError string
+		expectedPerms []string
+	}{
+		{
+			name: "workspace account gets its domain namespace",
+			claims: &v0auth.OIDCClaims{
+				Issuer: "https://accounts.google.com",
+				ExtraClaims: map[string]any{
+					"email":          "dev@Example.com",
+					"email_verified": true,
+					"hd":             "example.com",
+				},
+			},
+			expectedPerms: []string{"com.example/*", "com.example.*"},
+		},
+		{
+			name: "issuer without scheme is accepted",
+			claims: &v0auth.OIDCClaims{
+				Issuer: "accounts.google.com",
+				ExtraClaims: map[string]any{
+					"email":          "dev@tools.example.org",
+					"email_verified": true,
+					"hd":             "tools.example.org",
+				},
+			},
+			expectedPerms: []string{"org.example.tools/*", "org.example.tools.*"},
+		},
+		{
+			name: "consumer account without hosted domain",
+			claims: &v0auth.OIDCClaims{
+				Issuer: "https://accounts.google.com",
+				ExtraClaims: map[string]any{
+					"email":          "someone@gmail.com",
+					"email_verified": true,
+				},
+			},
+			expectedError: "not part of a Google Workspace",
+		},
+		{
+			name: "unverified email",
+			claims: &v0auth.OIDCClaims{
+				Issuer: "https://accounts.google.com",
+				ExtraClaims: map[string]any{
+					"email":          "dev@example.com",
+					"email_verified": false,
+					"hd":             "example.com",
+				},
+			},
+			expectedError: "not verified",
+		},
+		{
+			name: "email outside hosted domain",
+			claims: &v0auth.OIDCClaims{
+				Issuer: "https://accounts.google.com",
+				ExtraClaims: map[string]any{
+					"email":          "dev@other.com",
+					"email_verified": true,
+					"hd":             "example.com",
+				},
+			},
+			expectedError: "not in the hosted domain",
+		},
+		{
+			name: "token from another issuer",
+			claims: &v0auth.OIDCClaims{
+				Issuer: "https://login.example.com",
+				ExtraClaims: map[string]any{
+					"email":          "dev@example.com",
+					"email_verified": true,
+					"hd":             "example.com",
+				},
+			},
+			expectedError: "not issued by Google",
+		},
+		{
+			name:          "invalid token",
+			validateErr:   fmt.Errorf("signature mismatch"),
+			expectedError: "failed to validate Google ID token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := v0auth.NewGoogleHandler(cfg)
+			handler.SetValidator(&MockGenericOIDCValidator{
+				validateFunc: func(_ context.Context, _ string) (*v0auth.OIDCClaims, error) {
+					return tt.claims, tt.validateErr
+				},
+			})
+
+			ctx := context.Background()
+			response, err := handler.ExchangeToken(ctx, "google-id-token")
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			claims, err := auth.NewJWTManager(cfg).ValidateToken(ctx, response.RegistryToken)
+			require.NoError(t, err)
+			assert.Equal(t, auth.MethodGoogle, claims.AuthMethod)
+			assert.Equal(t, tt.claims.ExtraClaims["email"], claims.AuthMethodSubject)
+
+			patterns := []string{}
+			for _, perm := range claims.Permissions {
+				assert.Equal(t, auth.PermissionActionPublish, perm.Action)
+				patterns = append(patterns, perm.ResourcePattern)
+			}
+			assert.Equal(t, tt.expectedPerms, patterns)
+		})
+	}
+}
@@ -2,8 +2,10 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"strings"
 	"time"
@@ -17,6 +19,13 @@ import (
 // MaxKeyResponseSize is the maximum size of the response body from the HTTP endpoint.
 const MaxKeyResponseSize = 4096
 
+// WellKnownAuthPath is where domains publish their public key for HTTP authentication
+const WellKnownAuthPath = "/.well-known/mcp-registry-auth"
+
+// maxKeyRedirects is how many same-host redirects are followed when fetching the key,
+// e.g. for sites that redirect to a trailing slash or to a file extension
+const maxKeyRedirects = 3
+
 // HTTPTokenExchangeInput represents the input for HTTP-based authentication
 type HTTPTokenExchangeInput struct {
 	Body SignatureTokenExchangeInput
@@ -32,29 +41,26 @@ type DefaultHTTPKeyFetcher struct {
 	client *http.Client
 }
 
-// NewDefaultHTTPKeyFetcher creates a new HTTP key fetcher with timeout
+// NewDefaultHTTPKeyFetcher creates a new HTTP key fetcher with timeout.
+// Requests go through the proxy in HTTPS_PROXY, if set, like other outgoing requests.
 func NewDefaultHTTPKeyFetcher() *DefaultHTTPKeyFetcher {
 	return &DefaultHTTPKeyFetcher{
 		client: &http.Client{
 			Timeout: 10 * time.Second,
-			// Disable redirects for security purposes:
-			// Prevents people doing weird things like sending us to internal endpoints at different paths
-			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
-				return http.ErrUseLastResponse
-			},
 		},
 	}
 }
 
 // NewDefaultHTTPKeyFetcherWithClient creates a new HTTP key fetcher with a custom HTTP client.
-// This is primarily useful in tests to inject transports or TLS settings.
+// This is primarily useful in tests to inject transports or TLS settings. The client's redirect
+// policy is replaced with the fetcher's own.
 func NewDefaultHTTPKeyFetcherWithClient(client *http.Client) *DefaultHTTPKeyFetcher {
 	return &DefaultHTTPKeyFetcher{client: client}
 }
 
 // FetchKey fetches the public key from the well-known HTTP endpoint
 func (f *DefaultHTTPKeyFetcher) FetchKey(ctx context.Context, domain string) (string, error) {
-	url := fmt.Sprintf("https://%s/.well-known/mcp-registry-auth", domain)
+	url := "https://" + domain + WellKnownAuthPath
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -64,14 +70,21 @@ func (f *DefaultHTTPKeyFetcher) FetchKey(ctx context.Context, domain string) (st
 	req.Header.Set("Accept", "text/plain")
 	req.Header.Set("User-Agent", "mcp-registry/1.0")
 
-	resp, err := f.client.Do(req)
+	client := *f.client
+	client.CheckRedirect = checkKeyRedirect
+	resp, err := client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch key: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("HTTP %d: failed to fetch key from %s", resp.StatusCode, url)
+		return "", fmt.Errorf("HTTP %d: failed to fetch key from %s", resp.StatusCode, resp.Request.URL)
+	}
+
+	// Catches sites that answer every path with an HTML page, such as single-page apps
+	if contentType := resp.Header.Get("Content-Type"); !isKeyContentType(contentType) {
+		return "", fmt.Errorf("unexpected content type %q from %s: the key must be served as text/plain", contentType, resp.Request.URL)
 	}
 
 	// Limit response size to prevent DoS attacks.
@@ -88,6 +101,34 @@ func (f *DefaultHTTPKeyFetcher) FetchKey(ctx context.Context, domain string) (st
 	return strings.TrimSpace(string(body)), nil
 }
 
+// checkKeyRedirect follows a bounded number of redirects, and only to HTTPS on the same host,
+// so a domain cannot point the registry at other hosts or internal endpoints
+func checkKeyRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > maxKeyRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxKeyRedirects)
+	}
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("redirect to %s is not allowed: the key must be served over HTTPS", req.URL)
+	}
+	if !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+		return fmt.Errorf("redirect to %s is not allowed: the key must be served from %s", req.URL.Host, via[0].URL.Host)
+	}
+	return nil
+}
+
+// isKeyContentType reports whether a key response's content type can be plain text. A missing or
+// generic binary type is accepted, as static hosts often serve extensionless files that way.
+func isKeyContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "text/plain" || mediaType == "application/octet-stream"
+}
+
 // HTTPAuthHandler handles HTTP-based authentication
 type HTTPAuthHandler struct {
 	CoreAuthHandler
@@ -120,6 +161,9 @@ func RegisterHTTPEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
 		Description: "Authenticate using HTTP-hosted public key and signed timestamp",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *HTTPTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
+		// Signatures cannot be guessed, so failures are only throttled per client
+		RecordLoginIdentity(ctx, domainIdentity(input.Body.Domain))
+
 		response, err := handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
 		if err != nil {
 			return nil, huma.Error401Unauthorized("HTTP authentication failed", err)
@@ -142,5 +186,9 @@ func (h *HTTPAuthHandler) ExchangeToken(ctx context.Context, domain, timestamp,
 	}
 
 	allowSubdomains := false
-	return h.CoreAuthHandler.ExchangeToken(ctx, domain, timestamp, signedTimestamp, keyFetcher, allowSubdomains, auth.MethodHTTP)
+	response, err := h.CoreAuthHandler.ExchangeToken(ctx, domain, timestamp, signedTimestamp, keyFetcher, allowSubdomains, auth.MethodHTTP)
+	if errors.Is(err, ErrSignatureMismatch) {
+		return nil, fmt.Errorf("%w (key served at https://%s%s; check it is the public key for the private key used to log in)", err, domain, WellKnownAuthPath)
+	}
+	return response, err
 }
@@ -359,6 +359,48 @@ func TestDefaultHTTPKeyFetcher(t *testing.T) {
 			},
 			wantErrSub: "failed to read response body",
 		},
+		{
+			name: "HTML page instead of the key",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				_, _ = w.Write([]byte("<html></html>"))
+			},
+			wantErrSub: "unexpected content type",
+		},
+		{
+			name: "same-host redirect",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == wellKnownPath {
+					http.Redirect(w, r, wellKnownPath+".txt", http.StatusFound)
+					return
+				}
+				w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+				_, _ = w.Write([]byte("redirected"))
+			},
+			expectOK: true,
+			wantBody: "redirected",
+		},
+		{
+			name: "redirect to another host",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "https://internal.example.net/key", http.StatusFound)
+			},
+			wantErrSub: "must be served from example.com",
+		},
+		{
+			name: "redirect to plain HTTP",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "http://example.com"+wellKnownPath, http.StatusFound)
+			},
+			wantErrSub: "must be served over HTTPS",
+		},
+		{
+			name: "redirect loop",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, wellKnownPath, http.StatusFound)
+			},
+			wantErrSub: "stopped after 3 redirects",
+		},
 		{
 			name: "success",
 			handler: func(w http.ResponseWriter, r *http.Request) {
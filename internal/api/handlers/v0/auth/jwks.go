@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// JWKSOutput represents the JSON Web Key Set response
+type JWKSOutput struct {
+	CacheControl string `header:"Cache-Control"`
+	Body         auth.JSONWebKeySet
+}
+
+// RegisterJWKSEndpoint registers the endpoint publishing the keys Registry JWTs are signed with
+func RegisterJWKSEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-jwks" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/auth/jwks",
+		Summary:     "Get Registry JWT signing keys",
+		Description: "JSON Web Key Set of the keys Registry JWTs can be verified with. The kid header of a token names its key; previous keys are listed until they are retired.",
+		Tags:        []string{"auth"},
+	}, func(_ context.Context, _ *struct{}) (*JWKSOutput, error) {
+		return &JWKSOutput{
+			CacheControl: "public, max-age=300",
+			Body:         jwtManager.JWKS(),
+		}, nil
+	})
+}
@@ -6,22 +6,18 @@ import (
 )
 
 // RegisterAuthEndpoints registers all authentication endpoints with a custom path prefix
-func RegisterAuthEndpoints(api huma.API, pathPrefix string, cfg *config.Config) {
-	// Register GitHub access token authentication endpoint
-	RegisterGitHubATEndpoint(api, pathPrefix, cfg)
+func RegisterAuthEndpoints(api huma.API, pathPrefix string, cfg *config.Config, revocations TokenRevocations) {
+	// Register the token exchange endpoints of the built-in and registered providers
+	for _, provider := range Providers() {
+		provider.RegisterEndpoints(api, pathPrefix, cfg)
+	}
 
-	// Register GitHub OIDC authentication endpoint
-	RegisterGitHubOIDCEndpoint(api, pathPrefix, cfg)
+	// Register Registry JWT refresh endpoint
+	RegisterRefreshEndpoint(api, pathPrefix, cfg, revocations)
 
-	// Register configurable OIDC authentication endpoints
-	RegisterOIDCEndpoints(api, pathPrefix, cfg)
+	// Register Registry JWT revocation endpoint
+	RegisterRevokeEndpoint(api, pathPrefix, cfg, revocations)
 
-	// Register DNS-based authentication endpoint
-	RegisterDNSEndpoint(api, pathPrefix, cfg)
-
-	// Register HTTP-based authentication endpoint
-	RegisterHTTPEndpoint(api, pathPrefix, cfg)
-
-	// Register anonymous authentication endpoint
-	RegisterNoneEndpoint(api, pathPrefix, cfg)
+	// Register Registry JWT signing key set endpoint
+	RegisterJWKSEndpoint(api, pathPrefix, cfg)
 }
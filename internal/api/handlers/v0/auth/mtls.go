@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// MTLSTokenExchangeInput represents the input for mutual TLS authentication.
+// The client certificate comes from the TLS handshake rather than the request body.
+type MTLSTokenExchangeInput struct {
+	tlsState *tls.ConnectionState
+}
+
+// Resolve captures the TLS connection state the client certificate was verified on
+func (i *MTLSTokenExchangeInput) Resolve(ctx huma.Context) []error {
+	i.tlsState = ctx.TLS()
+	return nil
+}
+
+// MTLSAuthHandler handles mutual TLS client certificate authentication
+type MTLSAuthHandler struct {
+	CoreAuthHandler
+}
+
+// NewMTLSAuthHandler creates a new mutual TLS authentication handler
+func NewMTLSAuthHandler(cfg *config.Config) *MTLSAuthHandler {
+	return &MTLSAuthHandler{
+		CoreAuthHandler: *NewCoreAuthHandler(cfg),
+	}
+}
+
+// RegisterMTLSEndpoint registers the mutual TLS authentication endpoint.
+// The server must be terminating TLS itself with MTLS_CA_FILE set, so the endpoint is only
+// registered when mutual TLS is configured.
+func RegisterMTLSEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
+	if cfg.MTLSCAFile == "" {
+		return
+	}
+
+	handler := NewMTLSAuthHandler(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-mtls-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/mtls",
+		Summary:     "Exchange client certificate for Registry JWT",
+		Description: "Authenticate using a TLS client certificate issued by a trusted CA. Each DNS name in the certificate grants publish rights for the matching namespace.",
+		Tags:        []string{"auth"},
+	}, func(ctx context.Context, input *MTLSTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
+		response, err := handler.ExchangeToken(ctx, input.tlsState)
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Mutual TLS authentication failed", err)
+		}
+
+		return &v0.Response[auth.TokenResponse]{
+			Body: *response,
+		}, nil
+	})
+}
+
+// ExchangeToken exchanges a verified client certificate for a Registry JWT token
+func (h *MTLSAuthHandler) ExchangeToken(ctx context.Context, state *tls.ConnectionState) (*auth.TokenResponse, error) {
+	// VerifiedChains is only set when the certificate chains to one of the configured CAs
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil, errors.New("no verified client certificate presented")
+	}
+	leaf := state.VerifiedChains[0][0]
+
+	permissions, err := BuildCertificatePermissions(leaf.DNSNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.CreateJWTClaimsAndToken(ctx, auth.MethodMTLS, leaf.DNSNames[0], permissions)
+}
+
+// BuildCertificatePermissions maps a certificate's DNS names to namespaces: example.com grants
+// com.example/*, and *.example.com grants the subdomain namespaces com.example.*
+func BuildCertificatePermissions(dnsNames []string) ([]auth.Permission, error) {
+	if len(dnsNames) == 0 {
+		return nil, errors.New("client certificate has no DNS names")
+	}
+
+	var permissions []auth.Permission
+	for _, name := range dnsNames {
+		name = strings.ToLower(name)
+		if domain, ok := strings.CutPrefix(name, "*."); ok {
+			if !IsValidDomain(domain) {
+				return nil, fmt.Errorf("invalid DNS name in client certificate: %s", name)
+			}
+			permissions = append(permissions, auth.Permission{
+				Action:          auth.PermissionActionPublish,
+				ResourcePattern: fmt.Sprintf("%s.*", ReverseString(domain)),
+			})
+			continue
+		}
+		if !IsValidDomain(name) {
+			return nil, fmt.Errorf("invalid DNS name in client certificate: %s", name)
+		}
+		permissions = append(permissions, BuildPermissions(name, false)...)
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,152 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	intauth "github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// newTestCertificate issues a certificate signed by parent, or self-signed when parent is nil
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *tls.Certificate) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	issuer, signer := template, any(key)
+	if parent != nil {
+		issuer, signer = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func TestMTLSAuthEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), MTLSCAFile: "ca.pem"}
+
+	ca := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	client := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		DNSNames:     []string{"example.com", "*.tools.example.com"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, &ca)
+	untrusted := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		DNSNames:     []string{"example.com"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, nil)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	auth.RegisterMTLSEndpoint(api, "/v0", cfg)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.Leaf)
+	srv := httptest.NewUnstartedServer(mux)
+	srv.TLS = &tls.Config{ClientAuth: tls.VerifyClientCertIfGiven, ClientCAs: clientCAs} //nolint:gosec // testing only
+	srv.StartTLS()
+	defer srv.Close()
+
+	login := func(t *testing.T, certificates ...tls.Certificate) (*http.Response, error) {
+		t.Helper()
+		httpClient := srv.Client()
+		transport := httpClient.Transport.(*http.Transport).Clone()
+		transport.TLSClientConfig.Certificates = certificates
+		httpClient.Transport = transport
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL+"/v0/auth/mtls", nil)
+		require.NoError(t, err)
+		return httpClient.Do(req)
+	}
+
+	t.Run("trusted certificate", func(t *testing.T) {
+		resp, err := login(t, client)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response intauth.TokenResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		claims, err := intauth.NewJWTManager(cfg).ValidateToken(context.Background(), response.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, intauth.MethodMTLS, claims.AuthMethod)
+		assert.Equal(t, "example.com", claims.AuthMethodSubject)
+		assert.Equal(t, []intauth.Permission{
+			{Action: intauth.PermissionActionPublish, ResourcePattern: "com.example/*"},
+			{Action: intauth.PermissionActionPublish, ResourcePattern: "com.example.tools.*"},
+		}, claims.Permissions)
+	})
+
+	t.Run("no certificate", func(t *testing.T) {
+		resp, err := login(t)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("certificate from an untrusted CA", func(t *testing.T) {
+		// The client only offers certificates issued by a CA the server asks for
+		resp, err := login(t, untrusted)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestBuildCertificatePermissions(t *testing.T) {
+	_, err := auth.BuildCertificatePermissions(nil)
+	assert.Error(t, err)
+
+	_, err = auth.BuildCertificatePermissions([]string{"not a domain"})
+	assert.Error(t, err)
+
+	permissions, err := auth.BuildCertificatePermissions([]string{"Example.COM"})
+	require.NoError(t, err)
+	assert.Equal(t, []intauth.Permission{{Action: intauth.PermissionActionPublish, ResourcePattern: "com.example/*"}}, permissions)
+}
+
+func TestMTLSEndpointRequiresConfiguration(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	auth.RegisterMTLSEndpoint(api, "/v0", &config.Config{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/auth/mtls", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
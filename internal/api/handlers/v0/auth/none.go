@@ -16,13 +16,20 @@ import (
 type NoneHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
+	namespaces []string
 }
 
 // NewNoneHandler creates a new anonymous authentication handler
 func NewNoneHandler(cfg *config.Config) *NoneHandler {
+	namespaces, err := cfg.AnonymousNamespacePatterns()
+	if err != nil {
+		panic(err)
+	}
+
 	return &NoneHandler{
 		config:     cfg,
 		jwtManager: auth.NewJWTManager(cfg),
+		namespaces: namespaces,
 	}
 }
 
@@ -42,7 +49,7 @@ func RegisterNoneEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
 		Method:      http.MethodPost,
 		Path:        pathPrefix + "/auth/none",
 		Summary:     "Get anonymous Registry JWT (Development/Testing Only)",
-		Description: "Get a short-lived Registry JWT token for publishing and editing servers in the io.modelcontextprotocol.anonymous/* namespace. This endpoint is intended for local development and automated testing only.",
+		Description: "Get a short-lived Registry JWT token for publishing and editing servers in the anonymous namespaces (io.modelcontextprotocol.anonymous/* by default). Servers there are deleted a day (by default) after they were last published. This endpoint is intended for local development and automated testing only.",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, _ *struct{}) (*v0.Response[auth.TokenResponse], error) {
 		response, err := handler.GetAnonymousToken(ctx)
@@ -58,16 +65,14 @@ func RegisterNoneEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
 
 // GetAnonymousToken generates an anonymous Registry JWT token
 func (h *NoneHandler) GetAnonymousToken(ctx context.Context) (*auth.TokenResponse, error) {
-	// Build permissions for anonymous namespace only
-	permissions := []auth.Permission{
-		{
-			Action:          auth.PermissionActionPublish,
-			ResourcePattern: "io.modelcontextprotocol.anonymous/*",
-		},
-		{
-			Action:          auth.PermissionActionEdit,
-			ResourcePattern: "io.modelcontextprotocol.anonymous/*",
-		},
+	// Build permissions for the anonymous namespaces only
+	permissions := make([]auth.Permission, 0, 3*len(h.namespaces))
+	for _, namespace := range h.namespaces {
+		permissions = append(permissions,
+			auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: namespace},
+			auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: namespace},
+			auth.Permission{Action: auth.PermissionActionDelete, ResourcePattern: namespace},
+		)
 	}
 
 	// Create JWT claims for anonymous user
@@ -44,7 +44,7 @@ func TestNoneHandler_GetAnonymousToken(t *testing.T) {
 	assert.Equal(t, "anonymous", claims.AuthMethodSubject)
 
 	// Check permissions - should have both publish and edit permissions
-	require.Len(t, claims.Permissions, 2)
+	require.Len(t, claims.Permissions, 3)
 
 	// Check publish permission
 	assert.Equal(t, auth.PermissionActionPublish, claims.Permissions[0].Action)
@@ -53,4 +53,37 @@ func TestNoneHandler_GetAnonymousToken(t *testing.T) {
 	// Check edit permission
 	assert.Equal(t, auth.PermissionActionEdit, claims.Permissions[1].Action)
 	assert.Equal(t, "io.modelcontextprotocol.anonymous/*", claims.Permissions[1].ResourcePattern)
+
+	// Check delete permission
+	assert.Equal(t, auth.PermissionActionDelete, claims.Permissions[2].Action)
+	assert.Equal(t, "io.modelcontextprotocol.anonymous/*", claims.Permissions[2].ResourcePattern)
+}
+
+func TestNoneHandler_AnonymousNamespaces(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		JWTPrivateKey:       hex.EncodeToString(testSeed),
+		EnableAnonymousAuth: true,
+		AnonymousNamespaces: "io.example.test/*, com.example.sandbox/*",
+	}
+
+	tokenResponse, err := v0auth.NewNoneHandler(cfg).GetAnonymousToken(context.Background())
+	require.NoError(t, err)
+
+	claims, err := auth.NewJWTManager(cfg).ValidateToken(context.Background(), tokenResponse.RegistryToken)
+	require.NoError(t, err)
+	require.Len(t, claims.Permissions, 6)
+	for i, namespace := range []string{"io.example.test/*", "com.example.sandbox/*"} {
+		for j, action := range []auth.PermissionAction{auth.PermissionActionPublish, auth.PermissionActionEdit, auth.PermissionActionDelete} {
+			assert.Equal(t, auth.Permission{Action: action, ResourcePattern: namespace}, claims.Permissions[3*i+j])
+		}
+	}
+
+	for _, namespaces := range []string{"*", "io.example.test", "io.example/*/*", "/*"} {
+		cfg.AnonymousNamespaces = namespaces
+		assert.Panics(t, func() { v0auth.NewNoneHandler(cfg) }, namespaces)
+	}
 }
@@ -2,13 +2,17 @@ package auth
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/golang-jwt/jwt/v5"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
@@ -40,7 +44,8 @@ type StandardOIDCValidator struct {
 	verifier *oidc.IDTokenVerifier
 }
 
-// NewStandardOIDCValidator creates a new standard OIDC validator using go-oidc
+// NewStandardOIDCValidator creates a new standard OIDC validator using go-oidc. An empty clientID skips the
+// audience check, for callers that check the audience themselves.
 func NewStandardOIDCValidator(issuer, clientID string) (*StandardOIDCValidator, error) {
 	ctx := context.Background()
 
@@ -52,7 +57,8 @@ func NewStandardOIDCValidator(issuer, clientID string) (*StandardOIDCValidator,
 
 	// Create ID token verifier
 	verifierConfig := &oidc.Config{
-		ClientID: clientID,
+		ClientID:          clientID,
+		SkipClientIDCheck: clientID == "",
 	}
 	verifier := provider.Verifier(verifierConfig)
 
@@ -111,43 +117,116 @@ func (v *StandardOIDCValidator) ValidateToken(ctx context.Context, tokenString s
 	return oidcClaims, nil
 }
 
-// OIDCHandler handles configurable OIDC authentication
+// lazyOIDCValidator discovers its issuer's configuration when the first token is validated, so an
+// issuer that is unreachable at startup does not prevent the registry or other issuers from working
+type lazyOIDCValidator struct {
+	issuer   string
+	clientID string
+
+	mu        sync.Mutex
+	validator *StandardOIDCValidator
+}
+
+// ValidateToken validates an OIDC ID token, initializing the provider first if needed
+func (v *lazyOIDCValidator) ValidateToken(ctx context.Context, tokenString string) (*OIDCClaims, error) {
+	v.mu.Lock()
+	if v.validator == nil {
+		validator, err := NewStandardOIDCValidator(v.issuer, v.clientID)
+		if err != nil {
+			v.mu.Unlock()
+			return nil, err
+		}
+		v.validator = validator
+	}
+	validator := v.validator
+	v.mu.Unlock()
+
+	return validator.ValidateToken(ctx, tokenString)
+}
+
+// oidcIssuer is a trusted OIDC issuer along with the validator for its tokens
+type oidcIssuer struct {
+	config     config.OIDCIssuer
+	validator  GenericOIDCValidator
+	conditions []config.ClaimCondition
+	grants     []oidcGrant
+	mappings   []oidcClaimMapping
+}
+
+// oidcGrant is a grant of an issuer with its conditions parsed
+type oidcGrant struct {
+	config     config.OIDCGrant
+	conditions []config.ClaimCondition
+}
+
+// oidcClaimMapping is a claim mapping of an issuer with its regular expression compiled
+type oidcClaimMapping struct {
+	config  config.OIDCClaimMapping
+	pattern *regexp.Regexp
+}
+
+// OIDCHandler handles configurable OIDC authentication for one or more issuers
 type OIDCHandler struct {
-	config     *config.Config
 	jwtManager *auth.JWTManager
-	validator  GenericOIDCValidator
+	issuers    []*oidcIssuer
 }
 
-// NewOIDCHandler creates a new OIDC handler
+// NewOIDCHandler creates a new OIDC handler for the issuers returned by cfg.OIDCIssuerConfigs
 func NewOIDCHandler(cfg *config.Config) *OIDCHandler {
-	if !cfg.OIDCEnabled {
-		panic("OIDC is not enabled - should not create OIDC handler")
+	issuerConfigs, err := cfg.OIDCIssuerConfigs()
+	if err != nil {
+		panic(fmt.Sprintf("Invalid OIDC configuration: %v", err))
 	}
-	if cfg.OIDCIssuer == "" {
-		panic("OIDC issuer is required when OIDC is enabled")
+	if len(issuerConfigs) == 0 {
+		panic("OIDC is not enabled - should not create OIDC handler")
 	}
 
-	validator, err := NewStandardOIDCValidator(cfg.OIDCIssuer, cfg.OIDCClientID)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to initialize OIDC validator: %v", err))
+	handler := &OIDCHandler{jwtManager: auth.NewJWTManager(cfg)}
+	for _, issuerConfig := range issuerConfigs {
+		// Issuers with their own audiences are checked against them instead of the client ID
+		clientID := issuerConfig.ClientID
+		if len(issuerConfig.Audiences) > 0 {
+			clientID = ""
+		}
+		issuer := &oidcIssuer{
+			config:     issuerConfig,
+			validator:  &lazyOIDCValidator{issuer: issuerConfig.Issuer, clientID: clientID},
+			conditions: mustParseClaimConditions(issuerConfig.Conditions),
+		}
+		for _, grant := range issuerConfig.Grants {
+			issuer.grants = append(issuer.grants, oidcGrant{config: grant, conditions: mustParseClaimConditions(grant.Conditions)})
+		}
+		for _, mapping := range issuerConfig.ClaimMappings {
+			pattern, err := mapping.Regexp()
+			if err != nil {
+				panic(fmt.Sprintf("Invalid OIDC configuration: %v", err))
+			}
+			issuer.mappings = append(issuer.mappings, oidcClaimMapping{config: mapping, pattern: pattern})
+		}
+		handler.issuers = append(handler.issuers, issuer)
 	}
 
-	return &OIDCHandler{
-		config:     cfg,
-		jwtManager: auth.NewJWTManager(cfg),
-		validator:  validator,
-	}
+	return handler
 }
 
-// SetValidator sets a custom OIDC validator (used for testing)
+// SetValidator sets a custom OIDC validator for the first issuer (used for testing)
 func (h *OIDCHandler) SetValidator(validator GenericOIDCValidator) {
-	h.validator = validator
+	h.issuers[0].validator = validator
+}
+
+// SetIssuerValidator sets a custom OIDC validator for an issuer (used for testing)
+func (h *OIDCHandler) SetIssuerValidator(issuer string, validator GenericOIDCValidator) {
+	for _, i := range h.issuers {
+		if i.config.Issuer == issuer {
+			i.validator = validator
+		}
+	}
 }
 
 // RegisterOIDCEndpoints registers all OIDC authentication endpoints
 func RegisterOIDCEndpoints(api huma.API, pathPrefix string, cfg *config.Config) {
-	if !cfg.OIDCEnabled {
-		return // Skip registration if OIDC is not enabled
+	if issuers, err := cfg.OIDCIssuerConfigs(); err == nil && len(issuers) == 0 {
+		return // Skip registration if no OIDC issuers are configured
 	}
 
 	handler := NewOIDCHandler(cfg)
@@ -174,24 +253,49 @@ func RegisterOIDCEndpoints(api huma.API, pathPrefix string, cfg *config.Config)
 
 // ExchangeToken exchanges an OIDC ID token for a Registry JWT token
 func (h *OIDCHandler) ExchangeToken(ctx context.Context, oidcToken string) (*auth.TokenResponse, error) {
+	issuer, err := h.selectIssuer(oidcToken)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate OIDC token
-	claims, err := h.validator.ValidateToken(ctx, oidcToken)
+	claims, err := issuer.validator.ValidateToken(ctx, oidcToken)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate OIDC token: %w", err)
 	}
 
+	// The validator checks the audience too, but custom validators may not
+	expectedAudiences := issuer.config.ExpectedAudiences()
+	if !slices.ContainsFunc(claims.Audience, func(audience string) bool { return slices.Contains(expectedAudiences, audience) }) {
+		return nil, fmt.Errorf("token audience %v is not accepted; expected one of %v", claims.Audience, expectedAudiences)
+	}
+
 	// Validate extra claims if configured
-	if err := h.validateExtraClaims(claims); err != nil {
+	if err := validateExtraClaims(issuer.config.ExtraClaims, claims); err != nil {
 		return nil, fmt.Errorf("extra claims validation failed: %w", err)
 	}
+	for _, condition := range issuer.conditions {
+		if !condition.Matches(claimValues(claims, condition.Claim)) {
+			return nil, fmt.Errorf("claim condition not met: %s", condition)
+		}
+	}
+
+	subject := claims.Subject
+	if issuer.config.SubjectClaim != "" {
+		value, ok := lookupClaim(claims.ExtraClaims, issuer.config.SubjectClaim).(string)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("subject claim %s not found", issuer.config.SubjectClaim)
+		}
+		subject = value
+	}
 
 	// Build permissions based on claims and configuration
-	permissions := h.buildPermissions(claims)
+	permissions := buildOIDCPermissions(issuer, claims)
 
 	// Create JWT claims
 	jwtClaims := auth.JWTClaims{
 		AuthMethod:        auth.MethodOIDC,
-		AuthMethodSubject: claims.Subject,
+		AuthMethodSubject: subject,
 		Permissions:       permissions,
 	}
 
@@ -204,19 +308,28 @@ func (h *OIDCHandler) ExchangeToken(ctx context.Context, oidcToken string) (*aut
 	return tokenResponse, nil
 }
 
-// validateExtraClaims validates additional claims based on configuration
-func (h *OIDCHandler) validateExtraClaims(claims *OIDCClaims) error {
-	if h.config.OIDCExtraClaims == "" {
-		return nil // No extra validation required
+// selectIssuer finds the configured issuer of a token from its unverified iss claim. The token is
+// then verified against that issuer's keys, so a forged iss claim gains nothing.
+func (h *OIDCHandler) selectIssuer(oidcToken string) (*oidcIssuer, error) {
+	if len(h.issuers) == 1 {
+		return h.issuers[0], nil
 	}
 
-	// Parse extra claims configuration
-	var extraClaimsRules []map[string]any
-	if err := json.Unmarshal([]byte(h.config.OIDCExtraClaims), &extraClaimsRules); err != nil {
-		return fmt.Errorf("invalid extra claims configuration: %w", err)
+	var unverified jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(oidcToken, &unverified); err != nil {
+		return nil, fmt.Errorf("failed to parse OIDC token: %w", err)
+	}
+	for _, issuer := range h.issuers {
+		if issuer.config.Issuer == unverified.Issuer {
+			return issuer, nil
+		}
 	}
 
-	// Validate each rule
+	return nil, fmt.Errorf("untrusted OIDC issuer: %s", unverified.Issuer)
+}
+
+// validateExtraClaims checks that the token has every claim value required by the issuer's configuration
+func validateExtraClaims(extraClaimsRules []map[string]any, claims *OIDCClaims) error {
 	for _, rule := range extraClaimsRules {
 		for key, expectedValue := range rule {
 			actualValue, exists := claims.ExtraClaims[key]
@@ -233,34 +346,188 @@ func (h *OIDCHandler) validateExtraClaims(claims *OIDCClaims) error {
 	return nil
 }
 
-// buildPermissions builds permissions based on OIDC claims and configuration
-func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
+var (
+	claimPlaceholderPattern = regexp.MustCompile(`\{([a-zA-Z0-9_.-]+)\}`)
+	// Claim values substituted into permission patterns must not be able to widen them
+	claimValuePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+)
+
+// buildOIDCPermissions builds permissions from the issuer's configured patterns, those of the grants
+// whose conditions the token meets and those of the claim mappings that match, filling in {claim}
+// placeholders from the token. Patterns whose claims are missing or unsafe are left out, as are duplicates.
+func buildOIDCPermissions(issuer *oidcIssuer, claims *OIDCClaims) []auth.Permission {
 	var permissions []auth.Permission
 
-	// Parse permission patterns from configuration
-	if h.config.OIDCPublishPerms != "" {
-		for _, pattern := range strings.Split(h.config.OIDCPublishPerms, ",") {
-			pattern = strings.TrimSpace(pattern)
-			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionPublish,
-					ResourcePattern: pattern,
-				})
+	add := func(action auth.PermissionAction, patterns []string) {
+		for _, pattern := range patterns {
+			resolved, ok := resolveClaimPlaceholders(pattern, claims)
+			if !ok {
+				continue
+			}
+			permission := auth.Permission{Action: action, ResourcePattern: resolved}
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
 			}
 		}
 	}
+	add(auth.PermissionActionPublish, issuer.config.PublishPermissions)
+	add(auth.PermissionActionEdit, issuer.config.EditPermissions)
+	add(auth.PermissionActionDelete, issuer.config.DeletePermissions)
+	add(auth.PermissionActionAdmin, issuer.config.AdminPermissions)
+
+	for _, grant := range issuer.grants {
+		if !slices.ContainsFunc(grant.conditions, func(condition config.ClaimCondition) bool {
+			return !condition.Matches(claimValues(claims, condition.Claim))
+		}) {
+			add(auth.PermissionActionPublish, grant.config.PublishPermissions)
+			add(auth.PermissionActionEdit, grant.config.EditPermissions)
+			add(auth.PermissionActionDelete, grant.config.DeletePermissions)
+			add(auth.PermissionActionAdmin, grant.config.AdminPermissions)
+		}
+	}
 
-	if h.config.OIDCEditPerms != "" {
-		for _, pattern := range strings.Split(h.config.OIDCEditPerms, ",") {
-			pattern = strings.TrimSpace(pattern)
-			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionEdit,
-					ResourcePattern: pattern,
-				})
-			}
+	for _, mapping := range issuer.mappings {
+		namespaces := mapping.namespaces(claims)
+		for _, action := range mapping.config.Actions {
+			add(auth.PermissionAction(action), namespaces)
 		}
 	}
 
 	return permissions
 }
+
+// namespaces returns the namespace patterns the mapping grants to a token, none if its claim does not
+// match. Regex matches grant the patterns once for each matching value, with the groups captured from it
+// filled in; values whose groups are empty or unsafe grant nothing.
+func (m oidcClaimMapping) namespaces(claims *OIDCClaims) []string {
+	values := claimValues(claims, m.config.Claim)
+
+	switch m.config.MatchType() {
+	case config.ClaimMatchPresent:
+		// Claims holding objects have no values to compare but are present all the same
+		if len(values) > 0 || lookupClaim(claims.ExtraClaims, m.config.Claim) != nil {
+			return m.config.Namespaces
+		}
+	case config.ClaimMatchEquals:
+		if slices.Contains(values, m.config.Value) {
+			return m.config.Namespaces
+		}
+	case config.ClaimMatchPrefix:
+		if slices.ContainsFunc(values, func(value string) bool { return strings.HasPrefix(value, m.config.Value) }) {
+			return m.config.Namespaces
+		}
+	case config.ClaimMatchRegex:
+		var namespaces []string
+		for _, value := range values {
+			match := m.pattern.FindStringSubmatchIndex(value)
+			if match == nil || !safeCaptures(value, match) {
+				continue
+			}
+			for _, namespace := range m.config.Namespaces {
+				namespaces = append(namespaces, string(m.pattern.ExpandString(nil, namespace, value, match)))
+			}
+		}
+		return namespaces
+	}
+	return nil
+}
+
+// safeCaptures reports whether every group of a regular expression match captured a value that can be
+// substituted into a permission pattern
+func safeCaptures(value string, match []int) bool {
+	for i := 2; i < len(match); i += 2 {
+		if match[i] < 0 || !claimValuePattern.MatchString(value[match[i]:match[i+1]]) {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupClaim returns the value of a claim by its path, or nil if the token does not have it. Dots in the
+// path separate the names of nested objects, such as realm_access.roles in Keycloak tokens, unless the
+// token has a claim with the dotted name itself.
+func lookupClaim(claims map[string]any, path string) any {
+	if value, ok := claims[path]; ok {
+		return value
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] != '.' {
+			continue
+		}
+		if nested, ok := claims[path[:i]].(map[string]any); ok {
+			if value := lookupClaim(nested, path[i+1:]); value != nil {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+// claimValues returns the values of a claim for testing claim conditions: one for strings, numbers and
+// booleans, each element of lists, and none if the token does not have the claim
+func claimValues(claims *OIDCClaims, name string) []string {
+	var value any
+	switch name {
+	case "sub":
+		value = claims.Subject
+	case "iss":
+		value = claims.Issuer
+	case "aud":
+		return claims.Audience
+	default:
+		value = lookupClaim(claims.ExtraClaims, name)
+	}
+
+	format := func(value any) (string, bool) {
+		switch v := value.(type) {
+		case string:
+			return v, true
+		case bool:
+			return strconv.FormatBool(v), true
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), true
+		default:
+			return "", false
+		}
+	}
+
+	if list, ok := value.([]any); ok {
+		var values []string
+		for _, element := range list {
+			if formatted, ok := format(element); ok {
+				values = append(values, formatted)
+			}
+		}
+		return values
+	}
+	if formatted, ok := format(value); ok {
+		return []string{formatted}
+	}
+	return nil
+}
+
+// mustParseClaimConditions parses claim conditions already validated by cfg.OIDCIssuerConfigs
+func mustParseClaimConditions(expressions []string) []config.ClaimCondition {
+	conditions, err := config.ParseClaimConditions(expressions)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid OIDC configuration: %v", err))
+	}
+	return conditions
+}
+
+func resolveClaimPlaceholders(pattern string, claims *OIDCClaims) (string, bool) {
+	ok := true
+	resolved := claimPlaceholderPattern.ReplaceAllStringFunc(pattern, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		value, isString := lookupClaim(claims.ExtraClaims, name).(string)
+		if name == "sub" {
+			value, isString = claims.Subject, true
+		}
+		if !isString || !claimValuePattern.MatchString(value) {
+			ok = false
+			return ""
+		}
+		return value
+	})
+	return resolved, ok
+}
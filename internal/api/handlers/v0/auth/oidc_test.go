@@ -2,10 +2,13 @@ package auth_test
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	intauth "github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -44,6 +47,7 @@ func TestOIDCHandler_ExchangeToken(t *testing.T) {
 			mockValidator: &MockGenericOIDCValidator{
 				validateFunc: func(_ context.Context, _ string) (*auth.OIDCClaims, error) {
 					return &auth.OIDCClaims{
+						Audience: []string{"test-client-id"},
 						ExtraClaims: map[string]any{
 							"email":              "admin@modelcontextprotocol.io",
 							"email_verified":     true,
@@ -69,6 +73,7 @@ func TestOIDCHandler_ExchangeToken(t *testing.T) {
 			mockValidator: &MockGenericOIDCValidator{
 				validateFunc: func(_ context.Context, _ string) (*auth.OIDCClaims, error) {
 					return &auth.OIDCClaims{
+						Audience: []string{"test-client-id"},
 						ExtraClaims: map[string]any{
 							"email":              "user@example.com",
 							"email_verified":     true,
@@ -105,3 +110,345 @@ func TestOIDCHandler_ExchangeToken(t *testing.T) {
 		})
 	}
 }
+
+// unsignedTestToken builds a JWT with the given issuer; signatures are checked by the (mock) validator
+func unsignedTestToken(issuer string) string {
+	encode := func(v map[string]any) string {
+		data, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	return encode(map[string]any{"alg": "RS256", "typ": "JWT"}) + "." + encode(map[string]any{"iss": issuer}) + ".c2ln"
+}
+
+func TestOIDCHandler_MultipleIssuers(t *testing.T) {
+	cfg := &config.Config{
+		OIDCEnabled:      true,
+		OIDCIssuer:       "https://accounts.google.com",
+		OIDCClientID:     "admin-client-id",
+		OIDCExtraClaims:  `[{"hd":"modelcontextprotocol.io"}]`,
+		OIDCEditPerms:    "*",
+		OIDCPublishPerms: "*",
+		OIDCIssuers: `[
+			{
+				"issuer": "https://token.actions.githubusercontent.com",
+				"client_id": "https://registry.example.com",
+				"extra_claims": [{"repository_owner": "acme"}],
+				"subject_claim": "repository",
+				"publish_permissions": ["io.github.{repository_owner}/*"]
+			},
+			{
+				"issuer": "https://idp.corp.example.com",
+				"client_id": "registry",
+				"publish_permissions": ["com.example.{department}/*"]
+			}
+		]`,
+		JWTPrivateKey: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+	}
+
+	handler := auth.NewOIDCHandler(cfg)
+	mockIssuer := func(audience string, claims map[string]any) *MockGenericOIDCValidator {
+		return &MockGenericOIDCValidator{
+			validateFunc: func(_ context.Context, _ string) (*auth.OIDCClaims, error) {
+				return &auth.OIDCClaims{Subject: "subject-123", Audience: []string{audience}, ExtraClaims: claims}, nil
+			},
+		}
+	}
+	handler.SetIssuerValidator("https://accounts.google.com", mockIssuer("admin-client-id", map[string]any{"hd": "modelcontextprotocol.io"}))
+	handler.SetIssuerValidator("https://token.actions.githubusercontent.com", mockIssuer("https://registry.example.com", map[string]any{
+		"repository_owner": "acme",
+		"repository":       "acme/weather",
+	}))
+	handler.SetIssuerValidator("https://idp.corp.example.com", mockIssuer("registry", map[string]any{"department": "platform/../*"}))
+
+	jwtManager := intauth.NewJWTManager(cfg)
+	exchange := func(t *testing.T, issuer string) (*intauth.JWTClaims, error) {
+		t.Helper()
+		response, err := handler.ExchangeToken(context.Background(), unsignedTestToken(issuer))
+		if err != nil {
+			return nil, err
+		}
+		return jwtManager.ValidateToken(context.Background(), response.RegistryToken)
+	}
+
+	t.Run("legacy issuer keeps its permissions", func(t *testing.T) {
+		claims, err := exchange(t, "https://accounts.google.com")
+		require.NoError(t, err)
+		assert.Equal(t, "subject-123", claims.AuthMethodSubject)
+		assert.Equal(t, []intauth.Permission{
+			{Action: intauth.PermissionActionPublish, ResourcePattern: "*"},
+			{Action: intauth.PermissionActionEdit, ResourcePattern: "*"},
+		}, claims.Permissions)
+	})
+
+	t.Run("claim mappings and permission templates", func(t *testing.T) {
+		claims, err := exchange(t, "https://token.actions.githubusercontent.com")
+		require.NoError(t, err)
+		assert.Equal(t, intauth.MethodOIDC, claims.AuthMethod)
+		assert.Equal(t, "acme/weather", claims.AuthMethodSubject)
+		assert.Equal(t, []intauth.Permission{
+			{Action: intauth.PermissionActionPublish, ResourcePattern: "io.github.acme/*"},
+		}, claims.Permissions)
+	})
+
+	t.Run("unsafe claim values are not substituted", func(t *testing.T) {
+		claims, err := exchange(t, "https://idp.corp.example.com")
+		require.NoError(t, err)
+		assert.Empty(t, claims.Permissions)
+	})
+
+	t.Run("untrusted issuer", func(t *testing.T) {
+		_, err := exchange(t, "https://evil.example.com")
+		assert.ErrorContains(t, err, "untrusted OIDC issuer")
+	})
+}
+
+func TestOIDCHandler_ClaimRules(t *testing.T) {
+	cfg := &config.Config{
+		OIDCIssuers: `[{
+			"issuer": "https://token.actions.githubusercontent.com",
+			"client_id": "unused",
+			"audiences": ["https://registry.example.com", "registry"],
+			"subject_claim": "repository",
+			"conditions": ["repository_owner == acme", "ref != 'refs/heads/experimental'"],
+			"publish_permissions": ["io.github.acme/*"],
+			"grants": [
+				{"conditions": ["repository =~ acme/(weather|maps)", "ref == refs/heads/main"], "publish_permissions": ["com.acme.geo/*"]},
+				{"conditions": ["repository == acme/tools"], "publish_permissions": ["com.acme.tools/*", "io.github.acme/*"], "edit_permissions": ["com.acme.tools/*"]}
+			]
+		}]`,
+		JWTPrivateKey: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+	}
+	handler := auth.NewOIDCHandler(cfg)
+	jwtManager := intauth.NewJWTManager(cfg)
+
+	exchange := func(t *testing.T, audience string, claims map[string]any) (*intauth.JWTClaims, error) {
+		t.Helper()
+		handler.SetValidator(&MockGenericOIDCValidator{
+			validateFunc: func(_ context.Context, _ string) (*auth.OIDCClaims, error) {
+				return &auth.OIDCClaims{Subject: "repo:" + claims["repository"].(string), Audience: []string{audience}, ExtraClaims: claims}, nil
+			},
+		})
+		response, err := handler.ExchangeToken(context.Background(), "oidc-token")
+		if err != nil {
+			return nil, err
+		}
+		return jwtManager.ValidateToken(context.Background(), response.RegistryToken)
+	}
+	publish := func(pattern string) intauth.Permission {
+		return intauth.Permission{Action: intauth.PermissionActionPublish, ResourcePattern: pattern}
+	}
+
+	t.Run("grants add the permissions of the conditions met", func(t *testing.T) {
+		claims, err := exchange(t, "registry", map[string]any{"repository_owner": "acme", "repository": "acme/maps", "ref": "refs/heads/main"})
+		require.NoError(t, err)
+		assert.Equal(t, []intauth.Permission{publish("io.github.acme/*"), publish("com.acme.geo/*")}, claims.Permissions)
+
+		claims, err = exchange(t, "registry", map[string]any{"repository_owner": "acme", "repository": "acme/maps", "ref": "refs/heads/feature"})
+		require.NoError(t, err)
+		assert.Equal(t, []intauth.Permission{publish("io.github.acme/*")}, claims.Permissions)
+	})
+
+	t.Run("permissions of several grants are combined without duplicates", func(t *testing.T) {
+		claims, err := exchange(t, "https://registry.example.com", map[string]any{"repository_owner": "acme", "repository": "acme/tools", "ref": "refs/heads/main"})
+		require.NoError(t, err)
+		assert.Equal(t, []intauth.Permission{
+			publish("io.github.acme/*"),
+			publish("com.acme.tools/*"),
+			{Action: intauth.PermissionActionEdit, ResourcePattern: "com.acme.tools/*"},
+		}, claims.Permissions)
+	})
+
+	t.Run("regular expressions match the whole value", func(t *testing.T) {
+		claims, err := exchange(t, "registry", map[string]any{"repository_owner": "acme", "repository": "acme/weather-fork", "ref": "refs/heads/main"})
+		require.NoError(t, err)
+		assert.Equal(t, []intauth.Permission{publish("io.github.acme/*")}, claims.Permissions)
+	})
+
+	t.Run("issuer conditions must all be met", func(t *testing.T) {
+		_, err := exchange(t, "registry", map[string]any{"repository_owner": "evil", "repository": "evil/maps", "ref": "refs/heads/main"})
+		assert.ErrorContains(t, err, `claim condition not met: repository_owner == "acme"`)
+
+		_, err = exchange(t, "registry", map[string]any{"repository_owner": "acme", "repository": "acme/maps", "ref": "refs/heads/experimental"})
+		assert.ErrorContains(t, err, "claim condition not met: ref !=")
+
+		_, err = exchange(t, "registry", map[string]any{"repository": "acme/maps", "ref": "refs/heads/main"})
+		assert.ErrorContains(t, err, "claim condition not met")
+	})
+
+	t.Run("audience must be expected", func(t *testing.T) {
+		_, err := exchange(t, "unused", map[string]any{"repository_owner": "acme", "repository": "acme/maps", "ref": "refs/heads/main"})
+		assert.ErrorContains(t, err, "audience")
+	})
+}
+
+func TestOIDCHandler_ClaimMappings(t *testing.T) {
+	exchange := func(t *testing.T, cfg *config.Config, claims *auth.OIDCClaims) (*intauth.JWTClaims, error) {
+		t.Helper()
+		handler := auth.NewOIDCHandler(cfg)
+		handler.SetValidator(&MockGenericOIDCValidator{
+			validateFunc: func(_ context.Context, _ string) (*auth.OIDCClaims, error) {
+				return claims, nil
+			},
+		})
+		response, err := handler.ExchangeToken(context.Background(), "oidc-token")
+		if err != nil {
+			return nil, err
+		}
+		return intauth.NewJWTManager(cfg).ValidateToken(context.Background(), response.RegistryToken)
+	}
+	permission := func(action intauth.PermissionAction, pattern string) intauth.Permission {
+		return intauth.Permission{Action: action, ResourcePattern: pattern}
+	}
+
+	t.Run("Keycloak realm and client roles and group paths", func(t *testing.T) {
+		cfg := &config.Config{
+			OIDCEnabled:  true,
+			OIDCIssuer:   "https://keycloak.example.com/realms/acme",
+			OIDCClientID: "mcp-registry",
+			OIDCClaimMappings: `[
+				{"claim": "realm_access.roles", "value": "registry-admin", "actions": ["edit", "delete", "admin"], "namespaces": ["*"]},
+				{"claim": "resource_access.mcp-registry.roles", "match": "prefix", "value": "publish", "actions": ["publish"], "namespaces": ["com.acme/*"]},
+				{"claim": "groups", "match": "regex", "value": "/teams/([a-z]+)/publishers", "actions": ["publish", "edit"], "namespaces": ["com.acme.${1}/*"]}
+			]`,
+			JWTPrivateKey: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		}
+		token := func(realmRoles, clientRoles, groups []any) *auth.OIDCClaims {
+			return &auth.OIDCClaims{
+				Subject:  "f3c5e6a0-9d8e-4a1b-b2c3-d4e5f6a7b8c9",
+				Audience: []string{"mcp-registry"},
+				ExtraClaims: map[string]any{
+					"preferred_username": "alice",
+					"realm_access":       map[string]any{"roles": realmRoles},
+					"resource_access": map[string]any{
+						"mcp-registry": map[string]any{"roles": clientRoles},
+						"account":      map[string]any{"roles": []any{"manage-account"}},
+					},
+					"groups": groups,
+				},
+			}
+		}
+
+		claims, err := exchange(t, cfg, token(
+			[]any{"offline_access", "uma_authorization"},
+			[]any{"publisher"},
+			[]any{"/teams/maps/publishers", "/teams/weather/publishers", "/teams/Ops/publishers", "/everyone"},
+		))
+		require.NoError(t, err)
+		assert.Equal(t, []intauth.Permission{
+			permission(intauth.PermissionActionPublish, "com.acme/*"),
+			permission(intauth.PermissionActionPublish, "com.acme.maps/*"),
+			permission(intauth.PermissionActionPublish, "com.acme.weather/*"),
+			permission(intauth.PermissionActionEdit, "com.acme.maps/*"),
+			permission(intauth.PermissionActionEdit, "com.acme.weather/*"),
+		}, claims.Permissions)
+
+		claims, err = exchange(t, cfg, token([]any{"registry-admin"}, nil, nil))
+		require.NoError(t, err)
+		assert.Equal(t, []intauth.Permission{
+			permission(intauth.PermissionActionEdit, "*"),
+			permission(intauth.PermissionActionDelete, "*"),
+			permission(intauth.PermissionActionAdmin, "*"),
+		}, claims.Permissions)
+	})
+
+	t.Run("Okta groups and custom claims", func(t *testing.T) {
+		cfg := &config.Config{
+			OIDCIssuers: `[{
+				"issuer": "https://acme.okta.com/oauth2/default",
+				"client_id": "0oa1b2c3d4e5f6g7h8i9",
+				"subject_claim": "email",
+				"claim_mappings": [
+					{"claim": "groups", "match": "regex", "value": "mcp-(?P<team>.+)-publishers", "actions": ["publish"], "namespaces": ["com.acme.${team}/*"]},
+					{"claim": "groups", "value": "MCP Admins", "actions": ["admin"], "namespaces": ["*"]},
+					{"claim": "registry_namespace", "match": "present", "actions": ["publish"], "namespaces": ["com.acme.{registry_namespace}/*"]}
+				]
+			}]`,
+			JWTPrivateKey: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		}
+		token := func(extraClaims map[string]any) *auth.OIDCClaims {
+			extraClaims["email"] = "alice@acme.com"
+			return &auth.OIDCClaims{Subject: "00u1a2b3c4d5e6f7g8h9", Audience: []string{"0oa1b2c3d4e5f6g7h8i9"}, ExtraClaims: extraClaims}
+		}
+
+		claims, err := exchange(t, cfg, token(map[string]any{
+			"groups":             []any{"Everyone", "mcp-tools-publishers", "mcp-../*-publishers"},
+			"registry_namespace": "maps",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "alice@acme.com", claims.AuthMethodSubject)
+		assert.Equal(t, []intauth.Permission{
+			permission(intauth.PermissionActionPublish, "com.acme.tools/*"),
+			permission(intauth.PermissionActionPublish, "com.acme.maps/*"),
+		}, claims.Permissions)
+
+		claims, err = exchange(t, cfg, token(map[string]any{"groups": []any{"Everyone", "MCP Admins"}}))
+		require.NoError(t, err)
+		assert.Equal(t, []intauth.Permission{permission(intauth.PermissionActionAdmin, "*")}, claims.Permissions)
+
+		claims, err = exchange(t, cfg, token(map[string]any{"groups": []any{"Everyone"}, "registry_namespace": "*"}))
+		require.NoError(t, err)
+		assert.Empty(t, claims.Permissions)
+	})
+}
+
+func TestParseClaimCondition(t *testing.T) {
+	for expression, expected := range map[string]config.ClaimCondition{
+		"repository_owner == acme":          {Claim: "repository_owner", Operator: "==", Value: "acme"},
+		`environment!="production"`:         {Claim: "environment", Operator: "!=", Value: "production"},
+		"job_workflow_ref =~ 'acme/.+@.*'":  {Claim: "job_workflow_ref", Operator: "=~", Value: "acme/.+@.*"},
+		"https://example.com/groups == ops": {Claim: "https://example.com/groups", Operator: "==", Value: "ops"},
+	} {
+		condition, err := config.ParseClaimCondition(expression)
+		require.NoError(t, err, expression)
+		assert.Equal(t, expected.Claim, condition.Claim, expression)
+		assert.Equal(t, expected.Operator, condition.Operator, expression)
+		assert.Equal(t, expected.Value, condition.Value, expression)
+	}
+
+	for _, expression := range []string{"repository_owner", "== acme", "repo =~ (", "bad name == x"} {
+		_, err := config.ParseClaimCondition(expression)
+		assert.Error(t, err, expression)
+	}
+
+	groups, err := config.ParseClaimCondition("groups == ops")
+	require.NoError(t, err)
+	assert.True(t, groups.Matches([]string{"dev", "ops"}))
+	assert.False(t, groups.Matches(nil))
+}
+
+func TestOIDCIssuerConfigs(t *testing.T) {
+	cfg := &config.Config{OIDCIssuers: `[{"issuer":"https://idp.example.com","client_id":"a"},{"issuer":"https://idp.example.com","client_id":"b"}]`}
+	_, err := cfg.OIDCIssuerConfigs()
+	assert.ErrorContains(t, err, "more than once")
+
+	cfg = &config.Config{OIDCEnabled: true, OIDCClientID: "client"}
+	_, err = cfg.OIDCIssuerConfigs()
+	assert.Error(t, err)
+
+	cfg = &config.Config{OIDCIssuers: `[{"issuer":"https://idp.example.com","client_id":"a","conditions":["group = admins"]}]`}
+	_, err = cfg.OIDCIssuerConfigs()
+	assert.ErrorContains(t, err, "invalid claim condition")
+
+	cfg = &config.Config{OIDCIssuers: `[{"issuer":"https://idp.example.com","client_id":"a","grants":[{"publish_permissions":["com.example/*"]}]}]`}
+	_, err = cfg.OIDCIssuerConfigs()
+	assert.ErrorContains(t, err, "grant without conditions")
+
+	for mappings, expected := range map[string]string{
+		`[{"claim":"groups","actions":["publish"],"namespaces":["com.example/*"]}]`:                                 "a value to match is required",
+		`[{"claim":"groups","match":"contains","value":"x","actions":["publish"],"namespaces":["com.example/*"]}]`:  "unknown match type",
+		`[{"claim":"groups","match":"regex","value":"(","actions":["publish"],"namespaces":["com.example/*"]}]`:     "invalid claim mapping for groups",
+		`[{"claim":"groups","value":"ops","actions":["own"],"namespaces":["com.example/*"]}]`:                       "unknown action",
+		`[{"claim":"groups","value":"ops","actions":["publish"]}]`:                                                  "no namespaces",
+		`[{"claim":"groups","value":"ops","actions":["publish"],"namespaces":["com.${1}/*"]}]`:                      "only regex matches have",
+		`[{"claim":"bad claim","match":"present","actions":["publish"],"namespaces":["com.example/*"]}]`:            "invalid claim path",
+		`[{"claim":"groups","match":"present","value":"ops","actions":["publish"],"namespaces":["com.example/*"]}]`: "take no value",
+	} {
+		cfg = &config.Config{OIDCEnabled: true, OIDCIssuer: "https://idp.example.com", OIDCClientID: "a", OIDCClaimMappings: mappings}
+		_, err = cfg.OIDCIssuerConfigs()
+		assert.ErrorContains(t, err, expected, mappings)
+	}
+
+	issuers, err := (&config.Config{}).OIDCIssuerConfigs()
+	require.NoError(t, err)
+	assert.Empty(t, issuers)
+}
@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// Provider is a way of logging in to the registry: it verifies some proof of who the caller is and
+// exchanges it for a Registry JWT with the permissions its namespace policy grants
+type Provider interface {
+	// Name is the auth method of the tokens the provider issues, e.g. "github-at"
+	Name() auth.Method
+	// RegisterEndpoints registers the provider's token exchange endpoints under pathPrefix, or nothing if
+	// the provider is not configured
+	RegisterEndpoints(api huma.API, pathPrefix string, cfg *config.Config)
+}
+
+// providerFunc adapts the registration function of a built-in provider
+type providerFunc struct {
+	name     auth.Method
+	register func(api huma.API, pathPrefix string, cfg *config.Config)
+}
+
+func (p providerFunc) Name() auth.Method { return p.name }
+
+func (p providerFunc) RegisterEndpoints(api huma.API, pathPrefix string, cfg *config.Config) {
+	p.register(api, pathPrefix, cfg)
+}
+
+// builtinProviders are the login methods of every registry, in the order their endpoints are registered
+var builtinProviders = []Provider{
+	providerFunc{auth.MethodGitHubAT, RegisterGitHubATEndpoint},
+	providerFunc{auth.MethodGitLabAT, RegisterGitLabATEndpoint},
+	providerFunc{auth.MethodGitHubOIDC, RegisterGitHubOIDCEndpoint},
+	providerFunc{auth.MethodGitLabOIDC, RegisterGitLabOIDCEndpoint},
+	providerFunc{auth.MethodOIDC, RegisterOIDCEndpoints},
+	providerFunc{auth.MethodGoogle, RegisterGoogleEndpoint},
+	providerFunc{auth.MethodEntra, RegisterEntraEndpoint},
+	providerFunc{auth.MethodDNS, RegisterDNSEndpoint},
+	providerFunc{auth.MethodHTTP, RegisterHTTPEndpoint},
+	providerFunc{auth.MethodMTLS, RegisterMTLSEndpoint},
+	providerFunc{auth.MethodNone, RegisterNoneEndpoint},
+}
+
+// reservedProviderNames are used by auth endpoints that are not providers, or by auth methods whose
+// tokens are issued elsewhere
+var reservedProviderNames = []auth.Method{auth.MethodAPIToken, "refresh", "revoke", "jwks", "device", "session"}
+
+// providerNamePattern is what provider names must look like, as they appear in endpoint paths
+var providerNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+var (
+	providersMu     sync.RWMutex
+	customProviders []Provider
+)
+
+// RegisterProvider adds a login method to the auth endpoints of every registry created afterwards, so that
+// embedders can offer logins such as an internal SSO without changing the built-in providers. It is meant to
+// be called before the server starts, typically from an init function, and panics if the name is invalid or
+// already taken.
+func RegisterProvider(provider Provider) {
+	name := provider.Name()
+	if !providerNamePattern.MatchString(string(name)) {
+		panic(fmt.Sprintf("invalid auth provider name %q", name))
+	}
+	if slices.Contains(reservedProviderNames, name) {
+		panic(fmt.Sprintf("auth provider name %q is reserved", name))
+	}
+
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	for _, existing := range slices.Concat(builtinProviders, customProviders) {
+		if existing.Name() == name {
+			panic(fmt.Sprintf("auth provider %q is already registered", name))
+		}
+	}
+	customProviders = append(customProviders, provider)
+}
+
+// Providers returns the built-in login methods followed by the registered ones
+func Providers() []Provider {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	return slices.Concat(builtinProviders, customProviders)
+}
+
+// Identity is who a provider verified the caller to be
+type Identity struct {
+	// Subject identifies the caller among the provider's users, e.g. a username
+	Subject string
+	// Attributes the namespace policy can use, such as the caller's groups
+	Attributes map[string][]string
+}
+
+// NamespacePolicy decides the permissions a verified identity is granted
+type NamespacePolicy func(ctx context.Context, identity *Identity) ([]auth.Permission, error)
+
+// TokenExchange is a Provider made of a check of the credentials in a request body of type I and a namespace
+// policy. Its endpoint is POST {prefix}/auth/{Method}, taking I as the JSON body.
+type TokenExchange[I any] struct {
+	Method      auth.Method
+	Summary     string
+	Description string
+	// Verify checks the credentials and returns who they belong to. It may call CheckLoginIdentity with the
+	// identity being logged in as before checking, so that failures are throttled per identity too.
+	Verify func(ctx context.Context, credentials *I) (*Identity, error)
+	Policy NamespacePolicy
+}
+
+// Name returns the auth method of the tokens the exchange issues
+func (p *TokenExchange[I]) Name() auth.Method {
+	return p.Method
+}
+
+// RegisterEndpoints registers the token exchange endpoint
+func (p *TokenExchange[I]) RegisterEndpoints(api huma.API, pathPrefix string, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-" + string(p.Method) + "-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/" + string(p.Method),
+		Summary:     p.Summary,
+		Description: p.Description,
+		Tags:        []string{"auth"},
+	}, func(ctx context.Context, input *struct{ Body I }) (*v0.Response[auth.TokenResponse], error) {
+		identity, err := p.Verify(ctx, &input.Body)
+		var statusErr huma.StatusError
+		switch {
+		case errors.As(err, &statusErr):
+			return nil, statusErr
+		case err != nil:
+			return nil, huma.Error401Unauthorized("Token exchange failed", err)
+		case identity == nil || identity.Subject == "":
+			return nil, huma.Error500InternalServerError(fmt.Sprintf("Auth provider %s verified no subject", p.Method))
+		}
+
+		permissions, err := p.Policy(ctx, identity)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to determine permissions", err)
+		}
+		for _, permission := range permissions {
+			if !auth.IsValidPermissionAction(permission.Action) || permission.ResourcePattern == "" {
+				return nil, huma.Error500InternalServerError(fmt.Sprintf("Auth provider %s granted an invalid permission", p.Method))
+			}
+		}
+		if len(permissions) == 0 {
+			return nil, huma.Error403Forbidden("You do not have permission to publish in any namespace")
+		}
+
+		response, err := jwtManager.GenerateTokenResponse(ctx, auth.JWTClaims{
+			AuthMethod:        p.Method,
+			AuthMethodSubject: identity.Subject,
+			Permissions:       permissions,
+		})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to generate token", err)
+		}
+
+		return &v0.Response[auth.TokenResponse]{
+			Body: *response,
+		}, nil
+	})
+}
@@ -0,0 +1,108 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type directoryCredentials struct {
+	Username string `json:"username" required:"true"`
+	Password string `json:"password" required:"true"`
+}
+
+// directoryProvider logs in against a fixed user directory, granting each group's namespace
+var directoryProvider = &v0auth.TokenExchange[directoryCredentials]{
+	Method:  "test-directory",
+	Summary: "Exchange directory credentials for Registry JWT",
+	Verify: func(_ context.Context, credentials *directoryCredentials) (*v0auth.Identity, error) {
+		if credentials.Username == "alice" && credentials.Password == "secret" {
+			return &v0auth.Identity{Subject: "alice", Attributes: map[string][]string{"groups": {"platform"}}}, nil
+		}
+		if credentials.Username == "bob" && credentials.Password == "secret" {
+			return &v0auth.Identity{Subject: "bob"}, nil
+		}
+		return nil, errors.New("invalid username or password")
+	},
+	Policy: func(_ context.Context, identity *v0auth.Identity) ([]auth.Permission, error) {
+		var permissions []auth.Permission
+		for _, group := range identity.Attributes["groups"] {
+			permissions = append(permissions, auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.example." + group + "/*"})
+		}
+		return permissions, nil
+	},
+}
+
+func TestRegisterProvider(t *testing.T) {
+	v0auth.RegisterProvider(directoryProvider)
+
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0auth.RegisterAuthEndpoints(api, "/v0", cfg, newMemoryRevocations())
+
+	login := func(t *testing.T, username, password string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(directoryCredentials{Username: username, Password: password})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/auth/test-directory", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("valid credentials get the policy's permissions", func(t *testing.T) {
+		w := login(t, "alice", "secret")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response auth.TokenResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		claims, err := auth.NewJWTManager(cfg).ValidateToken(context.Background(), response.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, auth.Method("test-directory"), claims.AuthMethod)
+		assert.Equal(t, "alice", claims.AuthMethodSubject)
+		assert.Equal(t, []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.platform/*"}}, claims.Permissions)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, login(t, "alice", "wrong").Code)
+	})
+
+	t.Run("identity without namespaces", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, login(t, "bob", "secret").Code)
+	})
+
+	t.Run("listed after the built-in providers", func(t *testing.T) {
+		providers := v0auth.Providers()
+		assert.Equal(t, auth.MethodGitHubAT, providers[0].Name())
+		assert.Equal(t, auth.Method("test-directory"), providers[len(providers)-1].Name())
+	})
+
+	t.Run("names must be valid and unused", func(t *testing.T) {
+		for _, name := range []auth.Method{"test-directory", "dns", "refresh", "api-token", "", "LDAP", "ldap/v2"} {
+			assert.Panics(t, func() {
+				v0auth.RegisterProvider(&v0auth.TokenExchange[directoryCredentials]{Method: name})
+			}, name)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// RefreshTokenInput represents the input for refreshing a Registry JWT
+type RefreshTokenInput struct {
+	Body struct {
+		RefreshToken string `json:"refresh_token" doc:"Refresh token returned along with a Registry JWT" required:"true"`
+	}
+}
+
+// RegisterRefreshEndpoint registers the Registry JWT refresh endpoint
+func RegisterRefreshEndpoint(api huma.API, pathPrefix string, cfg *config.Config, revocations TokenRevocations) {
+	if cfg.RefreshTokenDuration <= 0 {
+		return // Skip registration if refresh tokens are disabled
+	}
+
+	jwtManager := auth.NewJWTManager(cfg)
+	github := NewGitHubHandler(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "refresh-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/refresh",
+		Summary:     "Refresh Registry JWT",
+		Description: "Exchange a refresh token for a new Registry JWT with the same identity and permissions, along with a new refresh token. " +
+			"Permissions that can be checked without the original credentials, such as GitHub organization memberships seen by the registry's GitHub App, are checked again. " +
+			"Refresh tokens are issued with interactive logins, can be used only once, and expire a fixed time after the login, however often they are refreshed.",
+		Tags: []string{"auth"},
+	}, func(ctx context.Context, input *RefreshTokenInput) (*v0.Response[auth.TokenResponse], error) {
+		claims, err := jwtManager.ValidateRefreshToken(ctx, input.Body.RefreshToken)
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Invalid or expired refresh token. Log in again", err)
+		}
+
+		// Refresh tokens are single use, so a stolen one stops working as soon as either party refreshes
+		if err := revocations.ConsumeRefreshToken(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+			if errors.Is(err, database.ErrAlreadyExists) {
+				return nil, huma.Error401Unauthorized("Refresh token has already been used or was revoked. Log in again")
+			}
+			return nil, huma.Error500InternalServerError("Failed to refresh Registry JWT", err)
+		}
+
+		permissions, err := recheckPermissions(ctx, github, claims)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to check permissions", err)
+		}
+		claims.Permissions = permissions
+
+		response, err := jwtManager.RefreshTokenResponse(ctx, claims)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to refresh Registry JWT", err)
+		}
+
+		return &v0.Response[auth.TokenResponse]{
+			Body: *response,
+		}, nil
+	})
+}
+
+// recheckPermissions returns the permissions granted at login that still hold, for the auth methods whose
+// permissions can be checked without the caller's credentials
+func recheckPermissions(ctx context.Context, github *GitHubHandler, claims *auth.JWTClaims) ([]auth.Permission, error) {
+	if claims.AuthMethod == auth.MethodGitHubAT {
+		return github.RecheckPermissions(ctx, claims.AuthMethodSubject, claims.Permissions)
+	}
+	return claims.Permissions, nil
+}
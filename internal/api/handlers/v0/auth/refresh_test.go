@@ -0,0 +1,81 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		JWTPrivateKey:        hex.EncodeToString(testSeed),
+		RefreshTokenDuration: time.Hour,
+	}
+	jwtManager := auth.NewJWTManager(cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0auth.RegisterRefreshEndpoint(api, "/v0", cfg, newMemoryRevocations())
+
+	login, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "testuser",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"}},
+	})
+	require.NoError(t, err)
+
+	refresh := func(t *testing.T, refreshToken string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(map[string]string{"refresh_token": refreshToken})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/auth/refresh", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("valid refresh token", func(t *testing.T) {
+		w := refresh(t, login.RefreshToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response auth.TokenResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.NotEmpty(t, response.RefreshToken)
+
+		claims, err := jwtManager.ValidateToken(context.Background(), response.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, "testuser", claims.AuthMethodSubject)
+	})
+
+	t.Run("refresh token cannot be reused", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, refresh(t, login.RefreshToken).Code)
+	})
+
+	t.Run("Registry JWT is not a refresh token", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, refresh(t, login.RegistryToken).Code)
+	})
+
+	t.Run("invalid refresh token", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, refresh(t, "not-a-token").Code)
+	})
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// TokenRevocations is the revocation list consulted when refreshing and revoking Registry JWTs
+type TokenRevocations interface {
+	RevokeJWT(ctx context.Context, jti, subject string, expiresAt time.Time, actor string) error
+	ConsumeRefreshToken(ctx context.Context, jti string, expiresAt time.Time) error
+}
+
+// RevokeTokenInput represents the input for revoking a Registry JWT
+type RevokeTokenInput struct {
+	Body struct {
+		Token string `json:"token" doc:"Registry JWT or refresh token to revoke" required:"true"`
+	}
+}
+
+// RegisterRevokeEndpoint registers the Registry JWT revocation endpoint
+func RegisterRevokeEndpoint(api huma.API, pathPrefix string, cfg *config.Config, revocations TokenRevocations) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/revoke",
+		Summary:     "Revoke Registry JWT",
+		Description: "Revoke a Registry JWT or refresh token before it expires, for example after it has leaked, along with the refresh token or Registry JWT issued with it. Holding the token is enough to revoke it. " +
+			"As in RFC 7009, tokens that are invalid, expired or already revoked are accepted without error.",
+		Tags: []string{"auth"},
+	}, func(ctx context.Context, input *RevokeTokenInput) (*struct{}, error) {
+		claims, err := jwtManager.ParseAnyToken(ctx, input.Body.Token)
+		if err != nil {
+			return nil, nil // Nothing to revoke
+		}
+		if claims.ID == "" || claims.ExpiresAt == nil {
+			return nil, huma.Error400BadRequest("Token was issued without an ID and cannot be revoked. It expires on its own shortly")
+		}
+
+		actor := string(claims.AuthMethod) + ":" + claims.AuthMethodSubject
+		err = revocations.RevokeJWT(ctx, claims.ID, claims.AuthMethodSubject, claims.ExpiresAt.Time, actor)
+		if err != nil && !errors.Is(err, database.ErrAlreadyExists) {
+			return nil, huma.Error500InternalServerError("Failed to revoke token", err)
+		}
+
+		// The token issued with it goes too, as a refresh token could otherwise get a new Registry JWT. A
+		// Registry JWT expires before its refresh token, and a refresh token at most a refresh token duration
+		// from now, which is how long they stay on the revocation list.
+		if claims.PairedID != "" {
+			pairedExpiresAt := claims.ExpiresAt.Time
+			if claims.TokenType != auth.TokenTypeRefresh {
+				pairedExpiresAt = time.Now().Add(cfg.RefreshTokenDuration)
+			}
+			err = revocations.RevokeJWT(ctx, claims.PairedID, claims.AuthMethodSubject, pairedExpiresAt, actor)
+			if err != nil && !errors.Is(err, database.ErrAlreadyExists) {
+				return nil, huma.Error500InternalServerError("Failed to revoke token", err)
+			}
+		}
+
+		return nil, nil
+	})
+}
@@ -0,0 +1,135 @@
+package auth_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRevocations is an in-memory revocation list
+type memoryRevocations struct {
+	mu      sync.Mutex
+	revoked map[string]string
+}
+
+func newMemoryRevocations() *memoryRevocations {
+	return &memoryRevocations{revoked: make(map[string]string)}
+}
+
+func (m *memoryRevocations) RevokeJWT(_ context.Context, jti, _ string, _ time.Time, actor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.revoked[jti]; ok {
+		return database.ErrAlreadyExists
+	}
+	m.revoked[jti] = actor
+	return nil
+}
+
+func (m *memoryRevocations) ConsumeRefreshToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	return m.RevokeJWT(ctx, jti, "", expiresAt, "refresh")
+}
+
+func (m *memoryRevocations) revokedBy(jti string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.revoked[jti]
+}
+
+func TestRevokeEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		JWTPrivateKey:        hex.EncodeToString(testSeed),
+		RefreshTokenDuration: time.Hour,
+	}
+	jwtManager := auth.NewJWTManager(cfg)
+	revocations := newMemoryRevocations()
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0auth.RegisterRefreshEndpoint(api, "/v0", cfg, revocations)
+	v0auth.RegisterRevokeEndpoint(api, "/v0", cfg, revocations)
+
+	login, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "testuser",
+	})
+	require.NoError(t, err)
+
+	post := func(t *testing.T, path string, body map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("revoke Registry JWT", func(t *testing.T) {
+		w := post(t, "/v0/auth/revoke", map[string]string{"token": login.RegistryToken})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		claims, err := jwtManager.ValidateToken(context.Background(), login.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, "github-at:testuser", revocations.revokedBy(claims.ID))
+	})
+
+	t.Run("revoking twice is accepted", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, post(t, "/v0/auth/revoke", map[string]string{"token": login.RegistryToken}).Code)
+	})
+
+	t.Run("invalid token is accepted", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, post(t, "/v0/auth/revoke", map[string]string{"token": "not-a-token"}).Code)
+	})
+
+	t.Run("revoking a Registry JWT revokes its refresh token", func(t *testing.T) {
+		login, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "testuser",
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusNoContent, post(t, "/v0/auth/revoke", map[string]string{"token": login.RegistryToken}).Code)
+		assert.Equal(t, http.StatusUnauthorized, post(t, "/v0/auth/refresh", map[string]string{"refresh_token": login.RefreshToken}).Code)
+	})
+
+	t.Run("revoking a refresh token revokes its Registry JWT", func(t *testing.T) {
+		login, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "testuser",
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, http.StatusNoContent, post(t, "/v0/auth/revoke", map[string]string{"token": login.RefreshToken}).Code)
+		claims, err := jwtManager.ValidateToken(context.Background(), login.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, "github-at:testuser", revocations.revokedBy(claims.ID))
+	})
+
+	t.Run("revoked refresh token cannot be used", func(t *testing.T) {
+		require.Equal(t, http.StatusNoContent, post(t, "/v0/auth/revoke", map[string]string{"token": login.RefreshToken}).Code)
+		assert.Equal(t, http.StatusUnauthorized, post(t, "/v0/auth/refresh", map[string]string{"refresh_token": login.RefreshToken}).Code)
+	})
+}
@@ -0,0 +1,211 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// loginFreeFailures is how many failed attempts a key may make before each further attempt is delayed
+const loginFreeFailures = 3
+
+// maxLoginFailureKeys bounds the memory used by the throttle when failures come from many clients at once
+const maxLoginFailureKeys = 100_000
+
+// loginEvictionCandidates is how many keys are looked at to find one to evict when the throttle is full
+const loginEvictionCandidates = 16
+
+// LoginThrottle counts failed logins per client and per identity. After loginFreeFailures failures a key
+// must wait before trying again, doubling from one second with each failure, and once it reaches the
+// threshold it is locked out. Failures are forgotten after a lockout duration without any. State is kept
+// in memory, so each replica enforces its own limits, and at most maxLoginFailureKeys keys are tracked.
+type LoginThrottle struct {
+	threshold int
+	lockout   time.Duration
+
+	mu         sync.Mutex
+	failures   map[string]*loginFailures
+	lastPruned time.Time
+}
+
+type loginFailures struct {
+	count        int
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+// NewLoginThrottle creates a throttle from AUTH_LOCKOUT_THRESHOLD and AUTH_LOCKOUT_DURATION. A threshold
+// of zero or less disables it.
+func NewLoginThrottle(cfg *config.Config) *LoginThrottle {
+	return &LoginThrottle{
+		threshold: cfg.AuthLockoutThreshold,
+		lockout:   cfg.AuthLockoutDuration,
+		failures:  make(map[string]*loginFailures),
+	}
+}
+
+// Check returns how long key has to wait before its next attempt, or zero if it may try now
+func (t *LoginThrottle) Check(key string) time.Duration {
+	if t.threshold <= 0 {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f, ok := t.failures[key]
+	if !ok {
+		return 0
+	}
+	return max(time.Until(f.blockedUntil), 0)
+}
+
+// RecordFailure counts a failed attempt by key. It returns the number of failures so far and, if this
+// failure locked the key out, until when.
+func (t *LoginThrottle) RecordFailure(key string) (int, time.Time) {
+	if t.threshold <= 0 {
+		return 0, time.Time{}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	f, ok := t.failures[key]
+	if !ok || now.Sub(f.lastFailure) >= t.lockout {
+		t.prune(now)
+		if !ok && len(t.failures) >= maxLoginFailureKeys {
+			t.evict(now)
+		}
+		f = &loginFailures{}
+		t.failures[key] = f
+	}
+	f.count++
+	f.lastFailure = now
+
+	switch {
+	case f.count >= t.threshold:
+		f.blockedUntil = now.Add(t.lockout)
+		return f.count, f.blockedUntil
+	case f.count > loginFreeFailures:
+		// The shift is capped so that large thresholds cannot overflow the delay
+		delay := time.Second << min(f.count-loginFreeFailures-1, 30)
+		f.blockedUntil = now.Add(min(delay, t.lockout))
+	}
+	return f.count, time.Time{}
+}
+
+// RecordSuccess forgets the failures of key
+func (t *LoginThrottle) RecordSuccess(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.failures, key)
+}
+
+// prune drops failures that have expired, at most once per lockout duration
+func (t *LoginThrottle) prune(now time.Time) {
+	if now.Sub(t.lastPruned) < t.lockout {
+		return
+	}
+	t.lastPruned = now
+	for key, f := range t.failures {
+		if now.Sub(f.lastFailure) >= t.lockout && now.After(f.blockedUntil) {
+			delete(t.failures, key)
+		}
+	}
+}
+
+// evict makes room for a new key by dropping one that is not blocked. Only a few keys, picked at random
+// by map iteration, are looked at; if all of them are blocked the last one is dropped.
+func (t *LoginThrottle) evict(now time.Time) {
+	evicted, looked := "", 0
+	for key, f := range t.failures {
+		evicted = key
+		looked++
+		if !now.Before(f.blockedUntil) || looked == loginEvictionCandidates {
+			break
+		}
+	}
+	delete(t.failures, evicted)
+}
+
+// LoginAttempt is a login in progress. Endpoints that know which identity is being logged in as record it
+// with CheckLoginIdentity, so that failures are counted against the identity as well as the client, or
+// with RecordLoginIdentity if failures say nothing about the identity.
+type LoginAttempt struct {
+	Identity string
+	// ThrottleIdentity is whether failures are counted against Identity
+	ThrottleIdentity bool
+}
+
+type (
+	loginAttemptKey  struct{}
+	loginThrottleKey struct{}
+)
+
+// WithLoginAttempt returns a context carrying a login attempt, reusing the one already in ctx if any
+func WithLoginAttempt(ctx context.Context) (context.Context, *LoginAttempt) {
+	if attempt, ok := ctx.Value(loginAttemptKey{}).(*LoginAttempt); ok {
+		return ctx, attempt
+	}
+	attempt := &LoginAttempt{}
+	return context.WithValue(ctx, loginAttemptKey{}, attempt), attempt
+}
+
+// WithAttempt returns a context carrying a login attempt that is throttled by t
+func (t *LoginThrottle) WithAttempt(ctx context.Context) (context.Context, *LoginAttempt) {
+	ctx, attempt := WithLoginAttempt(ctx)
+	return context.WithValue(ctx, loginThrottleKey{}, t), attempt
+}
+
+// CheckLoginIdentity records which identity the login in ctx is for, and fails with 429 if that identity
+// is being throttled. It does nothing outside of a login attempt.
+func CheckLoginIdentity(ctx context.Context, identity string) error {
+	attempt, ok := ctx.Value(loginAttemptKey{}).(*LoginAttempt)
+	if !ok {
+		return nil
+	}
+	attempt.Identity = identity
+	attempt.ThrottleIdentity = true
+
+	throttle, ok := ctx.Value(loginThrottleKey{}).(*LoginThrottle)
+	if !ok {
+		return nil
+	}
+	if retryAfter := throttle.Check(identity); retryAfter > 0 {
+		return huma.ErrorWithHeaders(
+			huma.Error429TooManyRequests(TooManyLoginAttemptsMessage),
+			http.Header{"Retry-After": []string{RetryAfterSeconds(retryAfter)}},
+		)
+	}
+	return nil
+}
+
+// RecordLoginIdentity records which identity the login in ctx is for, for the auth event log, without
+// throttling it. It is for credentials that cannot be guessed, such as signatures: anyone can fail to log
+// in as an identity with them, so counting those failures would let anyone lock the identity out.
+func RecordLoginIdentity(ctx context.Context, identity string) {
+	if attempt, ok := ctx.Value(loginAttemptKey{}).(*LoginAttempt); ok {
+		attempt.Identity = identity
+	}
+}
+
+// TooManyLoginAttemptsMessage is the error detail of throttled logins
+const TooManyLoginAttemptsMessage = "Too many failed login attempts. Please try again later."
+
+// RetryAfterSeconds formats a delay for the Retry-After header, rounding up
+func RetryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(d.Seconds()) + 1)
+}
+
+// domainIdentity is the identity of DNS and HTTP logins, which share the domain they prove
+func domainIdentity(domain string) string {
+	return "domain:" + strings.ToLower(domain)
+}
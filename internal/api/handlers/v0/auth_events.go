@@ -0,0 +1,107 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// AuthEventBody represents an entry in the auth event log
+type AuthEventBody struct {
+	ID         int64             `json:"id" doc:"Event ID" example:"42"`
+	Event      string            `json:"event" doc:"A login or token exchange at an auth endpoint, or a request to another endpoint that was denied" enum:"login,access"`
+	Outcome    string            `json:"outcome" doc:"What happened" enum:"success,failure,throttled,denied"`
+	AuthMethod string            `json:"authMethod,omitempty" doc:"Auth method of the caller, when known" example:"github-at"`
+	Subject    string            `json:"subject,omitempty" doc:"Who the caller is, or the domain a failed DNS or HTTP login was for" example:"alice"`
+	ClientIP   string            `json:"clientIp,omitempty" doc:"IP address of the client" example:"192.0.2.1"`
+	Endpoint   string            `json:"endpoint" doc:"Method and route of the request" example:"POST /v0/auth/github-at"`
+	Status     int               `json:"status" doc:"HTTP status of the response" example:"200"`
+	Details    map[string]string `json:"details,omitempty" doc:"Event-specific context, such as the ID, expiry and permissions of an issued token"`
+	CreatedAt  time.Time         `json:"createdAt" doc:"When the event happened"`
+}
+
+// AuthEventListBody represents a page of the auth event log
+type AuthEventListBody struct {
+	Events []AuthEventBody `json:"events" doc:"Events, newest first"`
+}
+
+// ListAuthEventsInput represents the input for searching the auth event log
+type ListAuthEventsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Event         string `query:"event" doc:"Only return events of this type" enum:"login,access"`
+	Outcome       string `query:"outcome" doc:"Only return events with this outcome" enum:"success,failure,throttled,denied"`
+	Subject       string `query:"subject" doc:"Only return events for this subject" example:"alice"`
+	ClientIP      string `query:"ip" doc:"Only return events from this client IP address" example:"192.0.2.1"`
+	Since         string `query:"since" doc:"Only return events since this time (RFC3339 datetime)" example:"2025-08-07T13:15:04Z"`
+	Limit         int    `query:"limit" doc:"Maximum number of events to return" default:"100" minimum:"1" maximum:"500"`
+}
+
+// RegisterAuthEventAdminEndpoints registers the auth event log admin endpoint with a custom path prefix
+func RegisterAuthEventAdminEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-auth-events" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/auth-events",
+		Summary:     "List auth events",
+		Description: "Search the log of logins, token exchanges and permission denials, newest first (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListAuthEventsInput) (*Response[AuthEventListBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin(claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have permission to read the auth event log")
+		}
+
+		filter := &database.AuthEventFilter{
+			Event:    input.Event,
+			Outcome:  input.Outcome,
+			Subject:  input.Subject,
+			ClientIP: input.ClientIP,
+		}
+		if input.Since != "" {
+			since, err := time.Parse(time.RFC3339, input.Since)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04Z)")
+			}
+			filter.Since = &since
+		}
+
+		events, err := registry.ListAuthEvents(ctx, filter, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list auth events", err)
+		}
+
+		body := AuthEventListBody{Events: make([]AuthEventBody, 0, len(events))}
+		for _, event := range events {
+			body.Events = append(body.Events, AuthEventBody{
+				ID:         event.ID,
+				Event:      event.Event,
+				Outcome:    event.Outcome,
+				AuthMethod: event.AuthMethod,
+				Subject:    event.Subject,
+				ClientIP:   event.ClientIP,
+				Endpoint:   event.Endpoint,
+				Status:     event.Status,
+				Details:    event.Details,
+				CreatedAt:  event.CreatedAt,
+			})
+		}
+
+		return &Response[AuthEventListBody]{Body: body}, nil
+	})
+}
@@ -0,0 +1,130 @@
+package v0
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	badgeLabel        = "mcp registry"
+	badgeCacheControl = "public, max-age=300"
+
+	badgeColorActive     = "#4c1"
+	badgeColorDeprecated = "#fe7d37"
+	badgeColorDeleted    = "#e05d44"
+	badgeColorNotFound   = "#9f9f9f"
+)
+
+// BadgeInput represents the input for the server badge endpoint
+type BadgeInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// BadgeOutput is an SVG image response
+type BadgeOutput struct {
+	ContentType  string `header:"Content-Type"`
+	CacheControl string `header:"Cache-Control"`
+	Body         []byte
+}
+
+// RegisterBadgeEndpoint registers the SVG badge endpoint with a custom path prefix
+func RegisterBadgeEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-badge" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/badge.svg",
+		Summary:     "Get MCP server badge",
+		Description: "Get an SVG badge showing the latest version and status of a server, for embedding in a README. Unknown servers get a 'not found' badge rather than an error so the image still renders.",
+		Tags:        []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "SVG badge",
+				Content: map[string]*huma.MediaType{
+					"image/svg+xml": {Schema: &huma.Schema{Type: "string"}},
+				},
+			},
+		},
+	}, func(ctx context.Context, input *BadgeInput) (*BadgeOutput, error) {
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		message, color := "not found", badgeColorNotFound
+		serverResponse, err := registry.GetServerByName(ctx, serverName)
+		switch {
+		case err == nil:
+			status := model.StatusActive
+			if serverResponse.Meta.Official != nil {
+				status = serverResponse.Meta.Official.Status
+			}
+			message, color = badgeMessage(serverResponse.Server.Version, status)
+		case !errors.Is(err, database.ErrNotFound):
+			return nil, huma.Error500InternalServerError("Failed to get server details", err)
+		}
+
+		return &BadgeOutput{
+			ContentType:  "image/svg+xml; charset=utf-8",
+			CacheControl: badgeCacheControl,
+			Body:         renderBadge(badgeLabel, message, color),
+		}, nil
+	})
+}
+
+// badgeMessage returns the badge text and color for a server version and status
+func badgeMessage(version string, status model.Status) (string, string) {
+	message := "v" + strings.TrimPrefix(version, "v")
+	switch status {
+	case model.StatusDeprecated:
+		return message + " | deprecated", badgeColorDeprecated
+	case model.StatusDeleted:
+		return message + " | deleted", badgeColorDeleted
+	case model.StatusActive:
+		return message, badgeColorActive
+	}
+	return message, badgeColorActive
+}
+
+// badgeTextWidth approximates the rendered width of text in 11px Verdana
+func badgeTextWidth(text string) int {
+	return len([]rune(text))*7 + 10
+}
+
+// renderBadge renders a flat shields.io style badge
+func renderBadge(label, message, color string) []byte {
+	labelWidth := badgeTextWidth(label)
+	messageWidth := badgeTextWidth(message)
+	totalWidth := labelWidth + messageWidth
+	label = html.EscapeString(label)
+	message = html.EscapeString(message)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`, totalWidth, label, message)
+	fmt.Fprintf(&b, `<title>%s: %s</title>`, label, message)
+	b.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	fmt.Fprintf(&b, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, totalWidth)
+	b.WriteString(`<g clip-path="url(#r)">`)
+	fmt.Fprintf(&b, `<rect width="%d" height="20" fill="#555"/>`, labelWidth)
+	fmt.Fprintf(&b, `<rect x="%d" width="%d" height="20" fill="%s"/>`, labelWidth, messageWidth, color)
+	fmt.Fprintf(&b, `<rect width="%d" height="20" fill="url(#s)"/>`, totalWidth)
+	b.WriteString(`</g>`)
+	b.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	fmt.Fprintf(&b, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`, labelWidth/2, label, labelWidth/2, label)
+	fmt.Fprintf(&b, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`, labelWidth+messageWidth/2, message, labelWidth+messageWidth/2, message)
+	b.WriteString(`</g></svg>`)
+
+	return b.Bytes()
+}
@@ -0,0 +1,101 @@
+package v0_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestBadgeEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	// Setup test data
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/badge-active",
+		Description: "Active server",
+		Version:     "1.2.3",
+	})
+	require.NoError(t, err)
+
+	_, err = registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/badge-deprecated",
+		Description: "Deprecated server",
+		Version:     "0.1.0",
+	})
+	require.NoError(t, err)
+
+	deprecated := string(model.StatusDeprecated)
+	_, err = registryService.UpdateServer(ctx, "com.example/badge-deprecated", "0.1.0", &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/badge-deprecated",
+		Description: "Deprecated server",
+		Version:     "0.1.0",
+	}, &deprecated)
+	require.NoError(t, err)
+
+	// Create API
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterBadgeEndpoint(api, "/v0", registryService)
+
+	tests := []struct {
+		name            string
+		serverName      string
+		expectedMessage string
+		expectedColor   string
+	}{
+		{
+			name:            "active server shows latest version",
+			serverName:      "com.example/badge-active",
+			expectedMessage: "v1.2.3",
+			expectedColor:   "#4c1",
+		},
+		{
+			name:            "deprecated server is flagged",
+			serverName:      "com.example/badge-deprecated",
+			expectedMessage: "v0.1.0 | deprecated",
+			expectedColor:   "#fe7d37",
+		},
+		{
+			name:            "unknown server still renders",
+			serverName:      "com.example/non-existent",
+			expectedMessage: "not found",
+			expectedColor:   "#9f9f9f",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(tt.serverName)+"/badge.svg", nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "image/svg+xml; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+
+			body := w.Body.String()
+			assert.Contains(t, body, "<svg")
+			assert.Contains(t, body, "<title>mcp registry: "+tt.expectedMessage+"</title>")
+			assert.Contains(t, body, `fill="`+tt.expectedColor+`"`)
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// NamespaceDelegationBody represents a namespace delegation
+type NamespaceDelegationBody struct {
+	ID                int64     `json:"id" doc:"Delegation ID" example:"42"`
+	Namespace         string    `json:"namespace" doc:"Namespace the delegation is part of" example:"com.acme"`
+	PermissionPattern string    `json:"permissionPattern" doc:"Publish permission the delegate gets" example:"com.acme/tools-*"`
+	Delegate          string    `json:"delegate" doc:"Who can publish with the delegation, as <auth method>:<subject>" example:"github-at:alice"`
+	GrantedBy         string    `json:"grantedBy" doc:"Who granted the delegation" example:"dns:acme.com"`
+	CreatedAt         time.Time `json:"createdAt" doc:"When the delegation was granted"`
+	ExpiresAt         time.Time `json:"expiresAt" doc:"When the delegation stops working"`
+}
+
+// NamespaceDelegationListBody represents a list of namespace delegations
+type NamespaceDelegationListBody struct {
+	Delegations []NamespaceDelegationBody `json:"delegations" doc:"Active delegations, newest first"`
+}
+
+// CreateNamespaceDelegationInput represents the input for delegating part of a namespace
+type CreateNamespaceDelegationInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the namespace" required:"true"`
+	Namespace     string `path:"namespace" doc:"Namespace, the part of server names before the slash" example:"com.acme"`
+	Body          struct {
+		Delegate          string `json:"delegate" doc:"Account to delegate to, as <auth method>:<subject>" example:"github-at:alice"`
+		PermissionPattern string `json:"permissionPattern" doc:"Server name, or prefix of server names ending in *, within the namespace" example:"com.acme/tools-*"`
+		ExpiresInDays     int    `json:"expiresInDays,omitempty" doc:"Days until the delegation expires" minimum:"1" maximum:"365" default:"90"`
+	}
+}
+
+// NamespaceDelegationInput represents the input for revoking a namespace delegation
+type NamespaceDelegationInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Namespace     string `path:"namespace" doc:"Namespace, the part of server names before the slash" example:"com.acme"`
+	ID            int64  `path:"id" doc:"Delegation ID" example:"42"`
+}
+
+// ListDelegationsInput represents the input for listing the delegations given to the caller
+type ListDelegationsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+}
+
+// RegisterDelegationEndpoints registers the namespace delegation endpoints with a custom path prefix
+func RegisterDelegationEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-namespace-delegation" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/namespaces/{namespace}/delegations",
+		Summary:     "Delegate part of a namespace",
+		Description: "Give another account publish rights for some servers of a namespace, such as com.acme/tools-*, until the delegation expires or is revoked. " +
+			"Requires publish permissions for the whole namespace and, if it is reserved, the permission it is reserved for.",
+		Tags: []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *CreateNamespaceDelegationInput) (*Response[NamespaceDelegationBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if claims.AuthMethod == auth.MethodAPIToken {
+			return nil, huma.Error403Forbidden("API tokens cannot delegate namespaces. Log in interactively instead")
+		}
+		if err := validateAccount("Delegates", input.Body.Delegate); err != nil {
+			return nil, err
+		}
+
+		pattern, ok := namespacePermission(jwtManager, input.Namespace, claims.Permissions)
+		if !ok {
+			return nil, huma.Error403Forbidden(fmt.Sprintf("You do not have publish permissions for every server in namespace %s", input.Namespace))
+		}
+		reservation, err := registry.GetNamespaceReservation(ctx, input.Namespace)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, huma.Error500InternalServerError("Failed to check namespace reservation", err)
+		}
+		if reservation != nil {
+			if !holdsReservation(reservation, claims.Permissions) {
+				return nil, huma.Error403Forbidden(fmt.Sprintf("Namespace %s is reserved. Delegating it requires the permission '%s'", input.Namespace, reservation.PermissionPattern))
+			}
+			// Delegates then satisfy the reservation for the servers delegated to them
+			pattern = reservation.PermissionPattern
+		}
+
+		expiresInDays := input.Body.ExpiresInDays
+		if expiresInDays == 0 {
+			expiresInDays = 90
+		}
+		expiresAt := time.Now().AddDate(0, 0, expiresInDays)
+
+		delegation, err := registry.CreateNamespaceDelegation(ctx, input.Namespace, input.Body.PermissionPattern, input.Body.Delegate, pattern, auditActor(claims), expiresAt)
+		if err != nil {
+			return nil, delegationError(err, "Failed to create namespace delegation")
+		}
+
+		return &Response[NamespaceDelegationBody]{Body: toNamespaceDelegationBody(delegation)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-namespace-delegations" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces/{namespace}/delegations",
+		Summary:     "List namespace delegations",
+		Description: "List the active delegations of a namespace. Requires publish permissions for the whole namespace.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *AuthenticatedNamespaceInput) (*Response[NamespaceDelegationListBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !canManageDelegations(jwtManager, input.Namespace, claims) {
+			return nil, huma.Error403Forbidden(fmt.Sprintf("You do not have publish permissions for every server in namespace %s", input.Namespace))
+		}
+
+		delegations, err := registry.ListNamespaceDelegations(ctx, input.Namespace)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list namespace delegations", err)
+		}
+		return &Response[NamespaceDelegationListBody]{Body: toNamespaceDelegationListBody(delegations)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-namespace-delegation" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/namespaces/{namespace}/delegations/{id}",
+		Summary:     "Revoke namespace delegation",
+		Description: "Revoke a namespace delegation, after which its delegate can no longer publish with it. Requires publish permissions for the whole namespace, except for delegates giving up their own delegation.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *NamespaceDelegationInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		delegation, err := registry.GetNamespaceDelegation(ctx, input.ID)
+		if err != nil {
+			return nil, delegationError(err, "Failed to get namespace delegation")
+		}
+		if delegation.Namespace != input.Namespace || delegation.RevokedAt != nil {
+			return nil, huma.Error404NotFound("Delegation not found")
+		}
+		if delegation.Delegate != auditActor(claims) && !canManageDelegations(jwtManager, input.Namespace, claims) {
+			return nil, huma.Error403Forbidden(fmt.Sprintf("You do not have publish permissions for every server in namespace %s", input.Namespace))
+		}
+
+		if err := registry.RevokeNamespaceDelegation(ctx, input.ID, auditActor(claims)); err != nil {
+			return nil, delegationError(err, "Failed to revoke namespace delegation")
+		}
+
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-delegations" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/delegations",
+		Summary:     "List my delegations",
+		Description: "List the active namespace delegations given to the caller.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListDelegationsInput) (*Response[NamespaceDelegationListBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		delegations, err := registry.ListDelegateDelegations(ctx, auditActor(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list delegations", err)
+		}
+		return &Response[NamespaceDelegationListBody]{Body: toNamespaceDelegationListBody(delegations)}, nil
+	})
+}
+
+// addSharedPermissions gives claims the publish permissions shared with its holder through organizations
+// and namespace delegations. API tokens act with their own permissions only.
+func addSharedPermissions(ctx context.Context, registry service.RegistryService, claims *auth.JWTClaims) error {
+	if claims.AuthMethod == auth.MethodAPIToken {
+		return nil
+	}
+	if err := addOrganizationPermissions(ctx, registry, claims); err != nil {
+		return err
+	}
+
+	delegations, err := registry.ListDelegateDelegations(ctx, auditActor(claims))
+	if err != nil {
+		return huma.Error500InternalServerError("Failed to check namespace delegations", err)
+	}
+	for _, delegation := range delegations {
+		claims.Permissions = append(claims.Permissions, auth.Permission{
+			Action:          auth.PermissionActionPublish,
+			ResourcePattern: delegation.PermissionPattern,
+		})
+	}
+	return nil
+}
+
+// holdsDelegatedReservation reports whether the token holder was delegated serverName by someone
+// holding the permission its namespace is reserved for
+func holdsDelegatedReservation(ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, reservation *database.NamespaceReservation, serverName string, claims *auth.JWTClaims) (bool, error) {
+	if claims.AuthMethod == auth.MethodAPIToken {
+		return false, nil
+	}
+
+	delegations, err := registry.ListDelegateDelegations(ctx, auditActor(claims))
+	if err != nil {
+		return false, err
+	}
+	for _, delegation := range delegations {
+		delegated := []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: delegation.PermissionPattern}}
+		if delegation.Namespace == reservation.Namespace && delegation.GrantedWith == reservation.PermissionPattern &&
+			jwtManager.HasPermission(serverName, auth.PermissionActionPublish, delegated) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// canManageDelegations reports whether the token can publish every server in namespace, or is a registry admin
+func canManageDelegations(jwtManager *auth.JWTManager, namespace string, claims *auth.JWTClaims) bool {
+	if isAdmin(claims.Permissions) {
+		return true
+	}
+	_, ok := namespacePermission(jwtManager, namespace, claims.Permissions)
+	return ok
+}
+
+// delegationError maps service errors to HTTP errors
+func delegationError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Delegation not found")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	default:
+		return huma.Error500InternalServerError(msg, err)
+	}
+}
+
+func toNamespaceDelegationListBody(delegations []*database.NamespaceDelegation) NamespaceDelegationListBody {
+	body := NamespaceDelegationListBody{Delegations: make([]NamespaceDelegationBody, 0, len(delegations))}
+	for _, delegation := range delegations {
+		body.Delegations = append(body.Delegations, toNamespaceDelegationBody(delegation))
+	}
+	return body
+}
+
+func toNamespaceDelegationBody(delegation *database.NamespaceDelegation) NamespaceDelegationBody {
+	return NamespaceDelegationBody{
+		ID:                delegation.ID,
+		Namespace:         delegation.Namespace,
+		PermissionPattern: delegation.PermissionPattern,
+		Delegate:          delegation.Delegate,
+		GrantedBy:         delegation.GrantedBy,
+		CreatedAt:         delegation.CreatedAt,
+		ExpiresAt:         delegation.ExpiresAt,
+	}
+}
@@ -0,0 +1,161 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestDelegationEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterNamespaceEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterDelegationEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+
+	tokenFor := func(t *testing.T, method auth.Method, subject string, permissions ...auth.Permission) string {
+		t.Helper()
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:        method,
+			AuthMethodSubject: subject,
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return token
+	}
+	do := func(t *testing.T, method, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		reader := bytes.NewReader(nil)
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	server := func(name string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: name, Description: "Acme tool", Version: "1.0.0"}
+	}
+
+	owner := tokenFor(t, auth.MethodDNS, "acme.com",
+		auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.acme/*"})
+	alice := tokenFor(t, auth.MethodGitHubAT, "alice",
+		auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"})
+	delegation := map[string]any{"delegate": "github-at:alice", "permissionPattern": "com.acme/tools-*", "expiresInDays": 30}
+
+	// Reserve the namespace, so delegates must be given its permission to publish past it
+	require.Equal(t, http.StatusOK, do(t, http.MethodPost, "/v0/namespaces/com.acme/reservation", owner, nil).Code)
+
+	t.Run("delegating requires permissions for the namespace", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/namespaces/com.acme/delegations", alice, delegation)
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("patterns must be within the namespace", func(t *testing.T) {
+		for _, pattern := range []string{"com.other/tools-*", "com.acme/*", "com.acme.tools/*", "com.acme/tools-*-x"} {
+			w := do(t, http.MethodPost, "/v0/namespaces/com.acme/delegations", owner,
+				map[string]any{"delegate": "github-at:alice", "permissionPattern": pattern})
+			assert.Equal(t, http.StatusBadRequest, w.Code, pattern)
+		}
+	})
+
+	t.Run("delegates must be identified by a login method", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/namespaces/com.acme/delegations", owner,
+			map[string]any{"delegate": "alice", "permissionPattern": "com.acme/tools-*"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	w := do(t, http.MethodPost, "/v0/namespaces/com.acme/delegations", owner, delegation)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var created v0.NamespaceDelegationBody
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	assert.Equal(t, "github-at:alice", created.Delegate)
+	assert.Equal(t, "dns:acme.com", created.GrantedBy)
+	assert.WithinDuration(t, created.CreatedAt.AddDate(0, 0, 30), created.ExpiresAt, time.Minute)
+	delegationPath := "/v0/namespaces/com.acme/delegations/" + strconv.FormatInt(created.ID, 10)
+
+	t.Run("delegates publish the delegated servers only", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/publish", alice, server("com.acme/tools-search"))
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(t, http.MethodPost, "/v0/publish", alice, server("com.acme/billing"))
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("delegations are listed for the namespace and the delegate", func(t *testing.T) {
+		var list v0.NamespaceDelegationListBody
+		w := do(t, http.MethodGet, "/v0/namespaces/com.acme/delegations", owner, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+		require.Len(t, list.Delegations, 1)
+		assert.Equal(t, created.ID, list.Delegations[0].ID)
+
+		w = do(t, http.MethodGet, "/v0/namespaces/com.acme/delegations", alice, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = do(t, http.MethodGet, "/v0/delegations", alice, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+		require.Len(t, list.Delegations, 1)
+		assert.Equal(t, "com.acme/tools-*", list.Delegations[0].PermissionPattern)
+	})
+
+	t.Run("others cannot revoke the delegation", func(t *testing.T) {
+		bob := tokenFor(t, auth.MethodGitHubAT, "bob")
+		assert.Equal(t, http.StatusForbidden, do(t, http.MethodDelete, delegationPath, bob, nil).Code)
+	})
+
+	t.Run("revoked delegations stop working", func(t *testing.T) {
+		w := do(t, http.MethodDelete, delegationPath, owner, nil)
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		w = do(t, http.MethodPost, "/v0/publish", alice, server("com.acme/tools-lookup"))
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+		assert.Equal(t, http.StatusNotFound, do(t, http.MethodDelete, delegationPath, owner, nil).Code)
+	})
+
+	t.Run("delegates can give up their delegation", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/namespaces/com.acme/delegations", owner, delegation)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var again v0.NamespaceDelegationBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&again))
+
+		w = do(t, http.MethodDelete, "/v0/namespaces/com.acme/delegations/"+strconv.FormatInt(again.ID, 10), alice, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	})
+}
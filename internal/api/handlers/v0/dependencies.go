@@ -0,0 +1,55 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// DependenciesInput represents the input for getting the health of external dependencies
+type DependenciesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+}
+
+// DependenciesBody represents the health of the external services the registry calls
+type DependenciesBody struct {
+	WindowSeconds int                         `json:"windowSeconds" example:"900" doc:"How far back the statistics reach in seconds"`
+	Dependencies  []telemetry.DependencyStats `json:"dependencies" doc:"Statistics of each dependency this replica has made requests to, ordered by name"`
+}
+
+// RegisterDependenciesEndpoint registers the endpoint reporting the rolling success rates and latencies
+// of external dependencies, such as package registries and OIDC issuers, as seen by this replica
+func RegisterDependenciesEndpoint(api huma.API, pathPrefix string, cfg *config.Config, tracker *telemetry.DependencyTracker) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-dependencies" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/dependencies",
+		Summary:     "Get external dependency health",
+		Description: "Get the success rates and latencies of requests this replica made to external services, such as Docker Hub, npm, the GitHub API and OIDC issuers, over the last 15 minutes (admin only). Use it to tell whether publish failures are caused by an upstream outage.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *DependenciesInput) (*Response[DependenciesBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin(claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have permission to view dependency health")
+		}
+
+		return &Response[DependenciesBody]{Body: DependenciesBody{
+			WindowSeconds: int(telemetry.DependencyWindow.Seconds()),
+			Dependencies:  tracker.Snapshot(),
+		}}, nil
+	})
+}
@@ -0,0 +1,76 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+func TestDependenciesEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	jwtManager := auth.NewJWTManager(cfg)
+
+	tracker := telemetry.NewDependencyTracker()
+	tracker.Record("npm", 120*time.Millisecond, "")
+	tracker.Record("npm", 80*time.Millisecond, "dial tcp: i/o timeout")
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDependenciesEndpoint(api, "/v1", cfg, tracker)
+
+	get := func(t *testing.T, pattern string) *httptest.ResponseRecorder {
+		t.Helper()
+		token, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:  auth.MethodNone,
+			Permissions: []auth.Permission{{Action: auth.PermissionActionAdmin, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/dependencies", nil)
+		req.Header.Set("Authorization", "Bearer "+token.RegistryToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("requires admin permissions", func(t *testing.T) {
+		w := get(t, "io.github.user/*")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("admin gets dependency health", func(t *testing.T) {
+		w := get(t, "*")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var body v0.DependenciesBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		assert.Equal(t, 900, body.WindowSeconds)
+		require.Len(t, body.Dependencies, 1)
+		npm := body.Dependencies[0]
+		assert.Equal(t, "npm", npm.Name)
+		assert.Equal(t, int64(2), npm.Requests)
+		assert.Equal(t, int64(1), npm.Failures)
+		assert.InDelta(t, 0.5, npm.SuccessRate, 0)
+		assert.InDelta(t, 100, npm.AvgLatencyMs, 0)
+		assert.InDelta(t, 120, npm.MaxLatencyMs, 0)
+		assert.Equal(t, "dial tcp: i/o timeout", npm.LastError)
+	})
+}
@@ -0,0 +1,232 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// DeviceCodeBody represents a new device login (RFC 8628 device authorization response)
+type DeviceCodeBody struct {
+	DeviceCode              string `json:"device_code" doc:"Code the device polls for its Registry JWT with. Keep it secret"`
+	UserCode                string `json:"user_code" doc:"Code for the user to approve the login with" example:"BCDF-GHJK"`
+	VerificationURI         string `json:"verification_uri" doc:"Page explaining how to approve the login" example:"https://registry.modelcontextprotocol.io/v0/auth/device"`
+	VerificationURIComplete string `json:"verification_uri_complete" doc:"Verification page with the user code filled in"`
+	ExpiresIn               int    `json:"expires_in" doc:"Seconds until the codes expire" example:"600"`
+	Interval                int    `json:"interval" doc:"Minimum seconds between polls" example:"5"`
+}
+
+// DeviceCodeInput represents the input for starting a device login
+type DeviceCodeInput struct {
+	ForwardedProto string `header:"X-Forwarded-Proto" hidden:"true"`
+
+	baseURL string
+}
+
+// Resolve works out the registry's URL from the request, for when PUBLIC_URL is not set
+func (i *DeviceCodeInput) Resolve(ctx huma.Context) []error {
+	scheme := "http"
+	if ctx.TLS() != nil {
+		scheme = "https"
+	}
+	if i.ForwardedProto != "" {
+		scheme = strings.TrimSpace(strings.Split(i.ForwardedProto, ",")[0])
+	}
+	i.baseURL = scheme + "://" + ctx.Host()
+	return nil
+}
+
+// DeviceTokenInput represents the input for polling a device login
+type DeviceTokenInput struct {
+	Body struct {
+		DeviceCode string `json:"device_code" doc:"Device code from the device code endpoint" required:"true"`
+	}
+}
+
+// DeviceApproveInput represents the input for approving a device login
+type DeviceApproveInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of the approving user" required:"true"`
+	Body          struct {
+		UserCode string `json:"user_code" doc:"User code shown on the device" example:"BCDF-GHJK" required:"true"`
+		Deny     bool   `json:"deny,omitempty" doc:"Deny the login instead of approving it"`
+	}
+}
+
+// DeviceVerificationInput represents the input for the device login verification page
+type DeviceVerificationInput struct {
+	UserCode string `query:"user_code" doc:"User code to show in the instructions" example:"BCDF-GHJK"`
+}
+
+// DeviceVerificationOutput is an HTML page response
+type DeviceVerificationOutput struct {
+	ContentType string `header:"Content-Type"`
+	Body        []byte
+}
+
+// DeviceTokenError is an error response from the device token endpoint, in the form of RFC 8628
+// section 3.5 so that standard OAuth clients understand it
+type DeviceTokenError struct {
+	Code        string `json:"error" doc:"OAuth error code" enum:"authorization_pending,slow_down,access_denied,expired_token,invalid_grant"`
+	Description string `json:"error_description,omitempty" doc:"Human-readable explanation"`
+}
+
+// Error implements the error interface
+func (e *DeviceTokenError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+// GetStatus implements huma.StatusError; all device token errors are 400 Bad Request
+func (e *DeviceTokenError) GetStatus() int {
+	return http.StatusBadRequest
+}
+
+// RegisterDeviceAuthEndpoints registers the device login endpoints with a custom path prefix
+func RegisterDeviceAuthEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "start-device-login" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/device/code",
+		Summary:     "Start device login",
+		Description: "Start a login for a device without a browser, such as an SSH session or container. " +
+			"The user approves the user code from a machine where they are already logged in, while the device polls the device token endpoint for its Registry JWT.",
+		Tags: []string{"auth"},
+	}, func(ctx context.Context, input *DeviceCodeInput) (*Response[DeviceCodeBody], error) {
+		authorization, deviceCode, err := registry.StartDeviceAuthorization(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to start device login", err)
+		}
+
+		baseURL := cfg.PublicURL
+		if baseURL == "" {
+			baseURL = input.baseURL
+		}
+		verificationURI := strings.TrimSuffix(baseURL, "/") + pathPrefix + "/auth/device"
+		userCode := service.FormatUserCode(authorization.UserCode)
+
+		return &Response[DeviceCodeBody]{
+			Body: DeviceCodeBody{
+				DeviceCode:              deviceCode,
+				UserCode:                userCode,
+				VerificationURI:         verificationURI,
+				VerificationURIComplete: verificationURI + "?user_code=" + url.QueryEscape(userCode),
+				ExpiresIn:               int(service.DeviceCodeTTL.Seconds()),
+				Interval:                int(service.DevicePollInterval.Seconds()),
+			},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "poll-device-login" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/device/token",
+		Summary:     "Poll device login",
+		Description: "Exchange a device code for a Registry JWT once its login has been approved. Until then this returns a 400 with an RFC 8628 error code: " +
+			"authorization_pending, slow_down (poll less often), access_denied or expired_token.",
+		Tags: []string{"auth"},
+	}, func(ctx context.Context, input *DeviceTokenInput) (*Response[auth.TokenResponse], error) {
+		authorization, err := registry.PollDeviceAuthorization(ctx, input.Body.DeviceCode)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrAuthorizationPending):
+				return nil, &DeviceTokenError{Code: "authorization_pending", Description: "The login has not been approved yet"}
+			case errors.Is(err, database.ErrSlowDown):
+				return nil, &DeviceTokenError{Code: "slow_down", Description: "Polling too often"}
+			case errors.Is(err, database.ErrAccessDenied):
+				return nil, &DeviceTokenError{Code: "access_denied", Description: "The login was denied"}
+			case errors.Is(err, database.ErrExpiredToken):
+				return nil, &DeviceTokenError{Code: "expired_token", Description: "The device code has expired"}
+			case errors.Is(err, database.ErrNotFound):
+				return nil, &DeviceTokenError{Code: "invalid_grant", Description: "Unknown or already used device code"}
+			}
+			return nil, huma.Error500InternalServerError("Failed to check device login", err)
+		}
+
+		response, err := jwtManager.GenerateTokenResponse(ctx, auth.JWTClaims{
+			AuthMethod:        authorization.AuthMethod,
+			AuthMethodSubject: authorization.Subject,
+			Permissions:       authorization.Permissions,
+		})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to generate Registry JWT", err)
+		}
+
+		return &Response[auth.TokenResponse]{Body: *response}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "approve-device-login" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/device/approve",
+		Summary:     "Approve device login",
+		Description: "Approve a device login by its user code, giving the device a Registry JWT with the same identity and permissions as the calling token. Set deny to refuse it instead.",
+		Tags:        []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *DeviceApproveInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		err = registry.ApproveDeviceAuthorization(ctx, input.Body.UserCode, claims, auditActor(claims), !input.Body.Deny)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("No pending device login with this code. It may have expired")
+			}
+			return nil, huma.Error500InternalServerError("Failed to approve device login", err)
+		}
+
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-device-login-page" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/auth/device",
+		Summary:     "Device login instructions",
+		Description: "Page explaining how to approve a device login",
+		Tags:        []string{"auth"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "HTML page",
+				Content: map[string]*huma.MediaType{
+					"text/html": {Schema: &huma.Schema{Type: "string"}},
+				},
+			},
+		},
+	}, func(_ context.Context, input *DeviceVerificationInput) (*DeviceVerificationOutput, error) {
+		userCode := "&lt;code&gt;"
+		if input.UserCode != "" {
+			userCode = html.EscapeString(service.FormatUserCode(service.NormalizeUserCode(input.UserCode)))
+		}
+
+		page := fmt.Sprintf(deviceVerificationPage, userCode)
+		return &DeviceVerificationOutput{ContentType: "text/html; charset=utf-8", Body: []byte(page)}, nil
+	})
+}
+
+const deviceVerificationPage = `<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Approve device login - MCP Registry</title></head>
+<body>
+<h1>Approve device login</h1>
+<p>A device is waiting to log in to the MCP Registry. To let it publish as you, run this on a machine where you are logged in with <code>mcp-publisher login</code>:</p>
+<pre>mcp-publisher approve %s</pre>
+<p>Only approve codes you started yourself. The device gets the same permissions as your login.</p>
+</body>
+</html>
+`
@@ -0,0 +1,142 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+func TestDeviceAuthEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), PublicURL: "https://registry.example.com"}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDeviceAuthEndpoints(api, "/v0", registryService, cfg)
+
+	approverClaims := auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "alice",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"},
+		},
+	}
+	approverToken, err := generateTestJWTToken(cfg, approverClaims)
+	require.NoError(t, err)
+
+	do := func(t *testing.T, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	start := func(t *testing.T) v0.DeviceCodeBody {
+		t.Helper()
+		w := do(t, "/v0/auth/device/code", "", struct{}{})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var code v0.DeviceCodeBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&code))
+		return code
+	}
+	poll := func(t *testing.T, deviceCode string) *httptest.ResponseRecorder {
+		t.Helper()
+		return do(t, "/v0/auth/device/token", "", map[string]string{"device_code": deviceCode})
+	}
+	pollError := func(t *testing.T, w *httptest.ResponseRecorder) string {
+		t.Helper()
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		var body v0.DeviceTokenError
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		return body.Code
+	}
+
+	t.Run("approved login gets the approver's identity once", func(t *testing.T) {
+		code := start(t)
+		assert.Regexp(t, `^[B-Z]{4}-[B-Z]{4}$`, code.UserCode)
+		assert.Equal(t, "https://registry.example.com/v0/auth/device", code.VerificationURI)
+		assert.Equal(t, code.VerificationURI+"?user_code="+code.UserCode, code.VerificationURIComplete)
+		assert.Equal(t, 600, code.ExpiresIn)
+		assert.Equal(t, 5, code.Interval)
+
+		assert.Equal(t, "authorization_pending", pollError(t, poll(t, code.DeviceCode)))
+		assert.Equal(t, "slow_down", pollError(t, poll(t, code.DeviceCode)))
+
+		// User codes are accepted in any case and without the separator
+		userCode := strings.ToLower(strings.ReplaceAll(code.UserCode, "-", ""))
+		w := do(t, "/v0/auth/device/approve", approverToken, map[string]string{"user_code": userCode})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		w = poll(t, code.DeviceCode)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var tokenResponse auth.TokenResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&tokenResponse))
+		claims, err := auth.NewJWTManager(cfg).ValidateToken(t.Context(), tokenResponse.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, auth.MethodGitHubAT, claims.AuthMethod)
+		assert.Equal(t, "alice", claims.AuthMethodSubject)
+		assert.Equal(t, approverClaims.Permissions, claims.Permissions)
+
+		assert.Equal(t, "invalid_grant", pollError(t, poll(t, code.DeviceCode)))
+
+		w = do(t, "/v0/auth/device/approve", approverToken, map[string]string{"user_code": code.UserCode})
+		assert.Equal(t, http.StatusNotFound, w.Code, "an approved code cannot be approved again")
+	})
+
+	t.Run("denied login", func(t *testing.T) {
+		code := start(t)
+		w := do(t, "/v0/auth/device/approve", approverToken, map[string]any{"user_code": code.UserCode, "deny": true})
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		assert.Equal(t, "access_denied", pollError(t, poll(t, code.DeviceCode)))
+		assert.Equal(t, "invalid_grant", pollError(t, poll(t, code.DeviceCode)))
+	})
+
+	t.Run("approval requires a login", func(t *testing.T) {
+		code := start(t)
+		w := do(t, "/v0/auth/device/approve", "", map[string]string{"user_code": code.UserCode})
+		assert.NotEqual(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "authorization_pending", pollError(t, poll(t, code.DeviceCode)))
+	})
+
+	t.Run("unknown codes", func(t *testing.T) {
+		w := do(t, "/v0/auth/device/approve", approverToken, map[string]string{"user_code": "BCDF-GHJK"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "invalid_grant", pollError(t, poll(t, "not-a-device-code")))
+	})
+
+	t.Run("verification page", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/auth/device?user_code=bcdfghjk", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, w.Body.String(), "mcp-publisher approve BCDF-GHJK")
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/policy"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
@@ -28,6 +29,10 @@ type EditServerInput struct {
 // RegisterEditEndpoints registers the edit endpoint with a custom path prefix
 func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	jwtManager := auth.NewJWTManager(cfg)
+	policies, err := policy.NewPublishPolicies(cfg)
+	if err != nil {
+		panic(err)
+	}
 
 	// Edit server endpoint
 	huma.Register(api, huma.Operation{
@@ -42,12 +47,10 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 		},
 	}, func(ctx context.Context, input *EditServerInput) (*Response[apiv0.ServerResponse], error) {
 		// Extract bearer token
-		const bearerPrefix = "Bearer "
-		authHeader := input.Authorization
-		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		token, ok := auth.BearerToken(input.Authorization)
+		if !ok {
 			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
 		}
-		token := authHeader[len(bearerPrefix):]
 
 		// Validate Registry JWT token
 		claims, err := jwtManager.ValidateToken(ctx, token)
@@ -102,11 +105,25 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 				return nil, huma.Error400BadRequest("Cannot change status of deleted server. Deleted servers cannot be undeleted.")
 			}
 
+			// Editing does not include deleting, so a token can be limited to one or the other
+			if newStatus == model.StatusDeleted &&
+				!jwtManager.HasPermission(currentServer.Server.Name, auth.PermissionActionDelete, claims.Permissions) &&
+				!isAdmin(claims.Permissions) {
+				return nil, huma.Error403Forbidden("You do not have delete permissions for this server")
+			}
+
 			// For now, only allow status changes for admins
 			// Future: Implement logic to allow server authors to change active <-> deprecated
 			// but only admins can set to deleted
 		}
 
+		// Edited servers must still satisfy the publish policies, except when they are being deleted
+		if model.Status(input.Status) != model.StatusDeleted {
+			if err := checkPublishPolicies(policies, claims, &input.Body); err != nil {
+				return nil, err
+			}
+		}
+
 		// Update the server using the service
 		var statusPtr *string
 		if input.Status != "" {
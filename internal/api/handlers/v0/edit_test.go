@@ -34,6 +34,7 @@ func TestEditServerEndpoint(t *testing.T) {
 	cfg := &config.Config{
 		JWTPrivateKey:            hex.EncodeToString(testSeed),
 		EnableRegistryValidation: false,
+		PublishPolicies:          `[{"name": "no-spam", "expression": "!server.description.contains('casino')", "message": "no gambling servers"}]`,
 	}
 
 	// Create registry service and test data
@@ -173,6 +174,26 @@ func TestEditServerEndpoint(t *testing.T) {
 				assert.Equal(t, model.StatusDeprecated, resp.Meta.Official.Status)
 			},
 		},
+		{
+			name:       "edit that violates a publish policy",
+			serverName: "io.github.testuser/editable-server",
+			version:    "1.0.0",
+			authClaims: &auth.JWTClaims{
+				AuthMethod:        auth.MethodGitHubAT,
+				AuthMethodSubject: "testuser",
+				Permissions: []auth.Permission{
+					{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.testuser/*"},
+				},
+			},
+			requestBody: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "io.github.testuser/editable-server",
+				Description: "Now with an online casino",
+				Version:     "1.0.0",
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "no gambling servers",
+		},
 		{
 			name:           "missing authorization header",
 			serverName:     "io.github.testuser/editable-server",
@@ -362,6 +383,52 @@ func TestEditServerEndpoint(t *testing.T) {
 				assert.NotNil(t, resp.Meta.Official)
 			},
 		},
+		{
+			name:       "delete without delete permissions",
+			serverName: "io.github.testuser/editable-server",
+			version:    "1.0.0",
+			authClaims: &auth.JWTClaims{
+				AuthMethod:        auth.MethodGitHubAT,
+				AuthMethodSubject: "testuser",
+				Permissions: []auth.Permission{
+					{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.testuser/*"},
+				},
+			},
+			requestBody: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "io.github.testuser/editable-server",
+				Description: "Server to delete",
+				Version:     "1.0.0",
+			},
+			statusParam:    "deleted",
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "You do not have delete permissions for this server",
+		},
+		{
+			name:       "delete with delete permissions",
+			serverName: "io.github.testuser/editable-server",
+			version:    "1.0.0",
+			authClaims: &auth.JWTClaims{
+				AuthMethod:        auth.MethodGitHubAT,
+				AuthMethodSubject: "testuser",
+				Permissions: []auth.Permission{
+					{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.testuser/*"},
+					{Action: auth.PermissionActionDelete, ResourcePattern: "io.github.testuser/*"},
+				},
+			},
+			requestBody: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "io.github.testuser/editable-server",
+				Description: "Server to delete",
+				Version:     "1.0.0",
+			},
+			statusParam:    "deleted",
+			expectedStatus: http.StatusOK,
+			checkResult: func(t *testing.T, resp *apiv0.ServerResponse) {
+				t.Helper()
+				assert.Equal(t, model.StatusDeleted, resp.Meta.Official.Status)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
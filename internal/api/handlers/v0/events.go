@@ -0,0 +1,188 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+const (
+	// eventPollInterval is how often a stream checks the change feed for new entries
+	eventPollInterval = 2 * time.Second
+
+	// eventBatchSize bounds how many changes are read from the change feed at once
+	eventBatchSize = 100
+
+	// eventKeepAliveInterval is how long a stream may be idle before a comment is sent to keep
+	// proxies from closing the connection
+	eventKeepAliveInterval = 30 * time.Second
+
+	// eventStreamMaxDuration ends streams periodically so connections are rebalanced across
+	// replicas; clients reconnect and resume from the last event they received
+	eventStreamMaxDuration = 10 * time.Minute
+
+	// eventRetryMillis is the reconnection delay suggested to clients
+	eventRetryMillis = 5000
+)
+
+// ServerChangeEvent is the data of a server change event
+type ServerChangeEvent struct {
+	Type       string    `json:"type" doc:"Kind of change" enum:"publish,update,delete"`
+	ServerName string    `json:"serverName" doc:"Server name" example:"io.github.user/weather"`
+	Version    string    `json:"version" doc:"Server version that changed" example:"1.0.0"`
+	Timestamp  time.Time `json:"timestamp" doc:"When the change was made"`
+}
+
+// ServerEventsInput represents the input for streaming server change events
+type ServerEventsInput struct {
+	LastEventID string `header:"Last-Event-ID" doc:"Id of the last event received. The stream resumes after it; EventSource clients send this automatically when reconnecting."`
+	After       string `query:"after" doc:"Id of the last event received, for clients that cannot set headers. Use 0 to replay the whole change feed. Ignored if Last-Event-ID is set." example:"0"`
+}
+
+// RegisterEventsEndpoint registers the server change event stream with a custom path prefix
+func RegisterEventsEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "stream-server-events" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/events",
+		Summary:     "Stream server changes",
+		Description: "Stream publish, update and delete events for server versions as Server-Sent Events, so mirrors and UIs can stay current without polling. " +
+			"Each event has the change feed id as its id and the kind of change as its name; status changes to deleted are delete events, and server transfers are a delete of every version under the old name followed by a publish under the new one. " +
+			"Without a resume point the stream starts with the next change. Streams end after 10 minutes; reconnect with Last-Event-ID to continue without missing events.",
+		Tags: []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Server-Sent Events stream of server changes",
+				Content: map[string]*huma.MediaType{
+					"text/event-stream": {Schema: &huma.Schema{Type: "string"}},
+				},
+			},
+		},
+	}, func(ctx context.Context, input *ServerEventsInput) (*huma.StreamResponse, error) {
+		cursor, err := eventCursor(input)
+		if err != nil {
+			return nil, err
+		}
+		if cursor < 0 {
+			cursor, err = registry.GetLatestServerChangeID(ctx)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to read change feed", err)
+			}
+		}
+
+		return &huma.StreamResponse{
+			Body: func(hctx huma.Context) {
+				hctx.SetHeader("Content-Type", "text/event-stream")
+				hctx.SetHeader("Cache-Control", "no-cache")
+				// Stop nginx-style proxies from buffering the stream
+				hctx.SetHeader("X-Accel-Buffering", "no")
+				streamServerChanges(hctx.Context(), hctx.BodyWriter(), registry, cursor)
+			},
+		}, nil
+	})
+}
+
+// eventCursor returns the id to resume after, or -1 if the stream should start with the next change
+func eventCursor(input *ServerEventsInput) (int64, error) {
+	value := input.LastEventID
+	if value == "" {
+		value = input.After
+	}
+	if value == "" {
+		return -1, nil
+	}
+
+	cursor, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || cursor < 0 {
+		return 0, huma.Error400BadRequest("Invalid event id: must be a non-negative integer")
+	}
+	return cursor, nil
+}
+
+// streamServerChanges writes change feed entries after cursor to w until the client disconnects,
+// the feed cannot be read or the stream reaches its maximum duration
+func streamServerChanges(ctx context.Context, w io.Writer, registry service.RegistryService, cursor int64) {
+	ctx, cancel := context.WithTimeout(ctx, eventStreamMaxDuration)
+	defer cancel()
+
+	flush := func() error { return nil }
+	if rw, ok := w.(http.ResponseWriter); ok {
+		flush = http.NewResponseController(rw).Flush
+	}
+
+	// Send the retry hint straight away so clients know the stream is open
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", eventRetryMillis); err != nil || flush() != nil {
+		return
+	}
+
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+	lastWrite := time.Now()
+
+	for {
+		changes, err := registry.ListServerChanges(ctx, cursor, eventBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.ErrorContext(ctx, "Failed to read change feed, closing event stream", logging.Err(err))
+			}
+			return
+		}
+
+		for _, change := range changes {
+			if err := writeServerChangeEvent(w, change); err != nil {
+				return
+			}
+			cursor = change.ID
+		}
+		if len(changes) > 0 {
+			if flush() != nil {
+				return
+			}
+			lastWrite = time.Now()
+		}
+		// Catch up on a backlog without waiting between batches
+		if len(changes) == eventBatchSize {
+			continue
+		}
+
+		if time.Since(lastWrite) >= eventKeepAliveInterval {
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil || flush() != nil {
+				return
+			}
+			lastWrite = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func writeServerChangeEvent(w io.Writer, change *database.ServerChange) error {
+	data, err := json.Marshal(ServerChangeEvent{
+		Type:       change.Type,
+		ServerName: change.ServerName,
+		Version:    change.Version,
+		Timestamp:  change.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", change.ID, change.Type, data)
+	return err
+}
@@ -0,0 +1,143 @@
+package v0_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+type sseEvent struct {
+	id    string
+	event string
+	data  v0.ServerChangeEvent
+}
+
+func TestServerEventsEndpoint(t *testing.T) {
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	ctx := context.Background()
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.alice/weather",
+			Description: "Weather server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	deleted := string(model.StatusDeleted)
+	_, err := registryService.UpdateServer(ctx, "io.github.alice/weather", "1.0.0", &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.alice/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	}, &deleted)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterEventsEndpoint(api, "/v0", registryService)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// readEvents opens a stream and reads count events from it
+	readEvents := func(t *testing.T, query, lastEventID string, count int) []sseEvent {
+		t.Helper()
+		reqCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, server.URL+"/v0/events"+query, nil)
+		require.NoError(t, err)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		var events []sseEvent
+		var current sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for len(events) < count && scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				current.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				current.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &current.data))
+			case line == "" && current.id != "":
+				events = append(events, current)
+				current = sseEvent{}
+			}
+		}
+		require.Len(t, events, count)
+		return events
+	}
+
+	t.Run("replays the change feed", func(t *testing.T) {
+		events := readEvents(t, "?after=0", "", 3)
+
+		assert.Equal(t, "publish", events[0].event)
+		assert.Equal(t, "1.0.0", events[0].data.Version)
+		assert.Equal(t, "publish", events[1].event)
+		assert.Equal(t, "1.1.0", events[1].data.Version)
+		assert.Equal(t, "delete", events[2].event)
+		assert.Equal(t, "io.github.alice/weather", events[2].data.ServerName)
+		assert.Equal(t, "1.0.0", events[2].data.Version)
+	})
+
+	t.Run("resumes after Last-Event-ID", func(t *testing.T) {
+		all := readEvents(t, "?after=0", "", 3)
+
+		// Last-Event-ID takes precedence over the query parameter
+		events := readEvents(t, "?after=0", all[0].id, 2)
+		assert.Equal(t, all[1].id, events[0].id)
+		assert.Equal(t, all[2].id, events[1].id)
+	})
+
+	t.Run("streams new changes", func(t *testing.T) {
+		go func() {
+			time.Sleep(500 * time.Millisecond)
+			_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "io.github.alice/weather",
+				Description: "Weather server",
+				Version:     "1.2.0",
+			})
+			assert.NoError(t, err)
+		}()
+
+		events := readEvents(t, "", "", 1)
+		assert.Equal(t, "publish", events[0].event)
+		assert.Equal(t, "1.2.0", events[0].data.Version)
+	})
+
+	t.Run("rejects an invalid event id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/events", nil)
+		req.Header.Set("Last-Event-ID", "abc")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,133 @@
+package v0
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// serverFields is the set of top-level server.json fields that can be selected with ?fields=
+var serverFields = jsonFieldNames(reflect.TypeOf(apiv0.ServerJSON{}))
+
+// FieldsParam is the sparse fieldset query parameter supported by server read endpoints
+type FieldsParam struct {
+	Fields string `query:"fields" required:"false" doc:"Comma-separated list of top-level server fields to include (e.g. name,version,description). Registry metadata in _meta is always included." example:"name,version,description"`
+}
+
+// parseFields validates a comma-separated field list, returning nil if no fields were requested
+func parseFields(fields string) ([]string, error) {
+	if fields == "" {
+		return nil, nil
+	}
+
+	var selected []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !serverFields[field] {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("Unknown field %q. Valid fields are: %s", field, strings.Join(sortedKeys(serverFields), ", ")))
+		}
+		selected = append(selected, field)
+	}
+	return selected, nil
+}
+
+// SparseFieldsTransformer is a Huma transformer that trims server responses down to the
+// fields requested with ?fields=. Handlers validate the parameter before this runs.
+func SparseFieldsTransformer(ctx huma.Context, status string, v any) (any, error) {
+	if status != "200" || (ctx.Method() != http.MethodGet && ctx.Method() != http.MethodHead) {
+		return v, nil
+	}
+
+	// Invalid fields have already been rejected by the handler
+	fields, _ := parseFields(ctx.Query("fields"))
+	if fields == nil {
+		return v, nil
+	}
+
+	switch body := v.(type) {
+	case apiv0.ServerResponse:
+		return selectServerFields(body, fields)
+	case *apiv0.ServerResponse:
+		return selectServerFields(*body, fields)
+	case apiv0.ServerListResponse:
+		return selectServerListFields(body, fields)
+	case *apiv0.ServerListResponse:
+		return selectServerListFields(*body, fields)
+	}
+	return v, nil
+}
+
+// sparseServerResponse is a server response with only the selected server fields
+type sparseServerResponse struct {
+	Server map[string]json.RawMessage `json:"server"`
+	Meta   apiv0.ResponseMeta         `json:"_meta"`
+}
+
+type sparseServerListResponse struct {
+	Servers  []sparseServerResponse `json:"servers"`
+	Metadata apiv0.Metadata         `json:"metadata"`
+}
+
+func selectServerFields(server apiv0.ServerResponse, fields []string) (sparseServerResponse, error) {
+	data, err := json.Marshal(server.Server)
+	if err != nil {
+		return sparseServerResponse{}, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return sparseServerResponse{}, err
+	}
+
+	selected := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			selected[field] = value
+		}
+	}
+
+	return sparseServerResponse{Server: selected, Meta: server.Meta}, nil
+}
+
+func selectServerListFields(list apiv0.ServerListResponse, fields []string) (sparseServerListResponse, error) {
+	servers := make([]sparseServerResponse, len(list.Servers))
+	for i, server := range list.Servers {
+		sparse, err := selectServerFields(server, fields)
+		if err != nil {
+			return sparseServerListResponse{}, err
+		}
+		servers[i] = sparse
+	}
+	return sparseServerListResponse{Servers: servers, Metadata: list.Metadata}, nil
+}
+
+// jsonFieldNames returns the JSON names of a struct's fields
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
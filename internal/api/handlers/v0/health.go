@@ -17,6 +17,10 @@ import (
 type HealthBody struct {
 	Status         string `json:"status" example:"ok" doc:"Health status"`
 	GitHubClientID string `json:"github_client_id,omitempty" doc:"GitHub OAuth App Client ID"`
+	GitLabClientID string `json:"gitlab_client_id,omitempty" doc:"GitLab OAuth application Client ID"`
+	GitLabURL      string `json:"gitlab_url,omitempty" doc:"GitLab instance that GitLab logins are made with, set along with the GitLab Client ID"`
+	OIDCIssuer     string `json:"oidc_issuer,omitempty" doc:"OIDC issuer that browser logins are made with, set when browser login is enabled"`
+	OIDCClientID   string `json:"oidc_client_id,omitempty" doc:"OIDC client ID of browser logins, set along with the OIDC issuer"`
 }
 
 // RegisterHealthEndpoint registers the health check endpoint with a custom path prefix
@@ -32,12 +36,20 @@ func RegisterHealthEndpoint(api huma.API, pathPrefix string, cfg *config.Config,
 		// Record the health check metrics
 		recordHealthMetrics(ctx, metrics, pathPrefix+"/health", cfg.Version)
 
-		return &Response[HealthBody]{
-			Body: HealthBody{
-				Status:         "ok",
-				GitHubClientID: cfg.GithubClientID,
-			},
-		}, nil
+		body := HealthBody{
+			Status:         "ok",
+			GitHubClientID: cfg.GithubClientID,
+		}
+		if cfg.GitLabClientID != "" {
+			body.GitLabClientID = cfg.GitLabClientID
+			body.GitLabURL = cfg.GitLabBaseURL
+		}
+		if cfg.OIDCEnabled && cfg.OIDCBrowserLogin {
+			body.OIDCIssuer = cfg.OIDCIssuer
+			body.OIDCClientID = cfg.OIDCClientID
+		}
+
+		return &Response[HealthBody]{Body: body}, nil
 	})
 }
 
@@ -45,6 +45,46 @@ func TestHealthEndpoint(t *testing.T) {
 				GitHubClientID: "",
 			},
 		},
+		{
+			name: "returns gitlab client id and instance",
+			config: &config.Config{
+				GitLabClientID: "test-gitlab-client-id",
+				GitLabBaseURL:  "https://gitlab.example.com",
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: v0.HealthBody{
+				Status:         "ok",
+				GitLabClientID: "test-gitlab-client-id",
+				GitLabURL:      "https://gitlab.example.com",
+			},
+		},
+		{
+			name: "returns oidc issuer and client id for browser login",
+			config: &config.Config{
+				OIDCEnabled:      true,
+				OIDCIssuer:       "https://idp.example.com",
+				OIDCClientID:     "test-oidc-client-id",
+				OIDCBrowserLogin: true,
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: v0.HealthBody{
+				Status:       "ok",
+				OIDCIssuer:   "https://idp.example.com",
+				OIDCClientID: "test-oidc-client-id",
+			},
+		},
+		{
+			name: "does not advertise oidc without browser login",
+			config: &config.Config{
+				OIDCEnabled:  true,
+				OIDCIssuer:   "https://idp.example.com",
+				OIDCClientID: "test-oidc-client-id",
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: v0.HealthBody{
+				Status: "ok",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -81,6 +121,22 @@ func TestHealthEndpoint(t *testing.T) {
 			} else {
 				assert.NotContains(t, body, `"github_client_id"`)
 			}
+
+			if tc.config.GitLabClientID != "" {
+				assert.Contains(t, body, `"gitlab_client_id":"test-gitlab-client-id"`)
+				assert.Contains(t, body, `"gitlab_url":"https://gitlab.example.com"`)
+			} else {
+				assert.NotContains(t, body, `"gitlab_client_id"`)
+				assert.NotContains(t, body, `"gitlab_url"`)
+			}
+
+			if tc.expectedBody.OIDCIssuer != "" {
+				assert.Contains(t, body, `"oidc_issuer":"https://idp.example.com"`)
+				assert.Contains(t, body, `"oidc_client_id":"test-oidc-client-id"`)
+			} else {
+				assert.NotContains(t, body, `"oidc_issuer"`)
+				assert.NotContains(t, body, `"oidc_client_id"`)
+			}
 		})
 	}
 }
@@ -0,0 +1,210 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	// iconImmutableCacheControl is used when the request names the current icon version, as
+	// the icon URLs in API responses do
+	iconImmutableCacheControl = "public, max-age=31536000, immutable"
+
+	// iconContentSecurityPolicy stops anything in an SVG icon from running if it is opened directly
+	iconContentSecurityPolicy = "default-src 'none'; style-src 'unsafe-inline'; sandbox"
+)
+
+// ServerIconInput represents the input for fetching a server icon
+type ServerIconInput struct {
+	ConditionalParams
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fweather"`
+	Version    string `query:"v" doc:"Icon version from the icon URL in API responses; makes the response cacheable indefinitely"`
+}
+
+// ServerIconOutput is a PNG or SVG image response
+type ServerIconOutput struct {
+	ContentType           string `header:"Content-Type"`
+	CacheControl          string `header:"Cache-Control"`
+	LastModified          string `header:"Last-Modified"`
+	ContentSecurityPolicy string `header:"Content-Security-Policy"`
+	Body                  []byte
+}
+
+// UploadServerIconInput represents the input for uploading a server icon
+type UploadServerIconInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server" required:"true"`
+	ContentType   string `header:"Content-Type" doc:"image/png or image/svg+xml" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fweather"`
+	RawBody       []byte `contentType:"image/png"`
+}
+
+// DeleteServerIconInput represents the input for removing a server icon
+type DeleteServerIconInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fweather"`
+}
+
+// RegisterIconEndpoints registers the server icon endpoints with a custom path prefix
+func RegisterIconEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-icon" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/icon",
+		Summary:     "Get server icon",
+		Description: "Get the icon uploaded for a server. Server responses link to it from the registry metadata.",
+		Tags:        []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "PNG or SVG icon",
+				Content: map[string]*huma.MediaType{
+					validators.IconMimeTypePNG: {Schema: &huma.Schema{Type: "string", Format: "binary"}},
+					validators.IconMimeTypeSVG: {Schema: &huma.Schema{Type: "string"}},
+				},
+			},
+		},
+	}, func(ctx context.Context, input *ServerIconInput) (*ServerIconOutput, error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		icon, err := registry.GetServerIcon(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Icon not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server icon", err)
+		}
+
+		if input.notModified(icon.UpdatedAt) {
+			return nil, huma.Status304NotModified()
+		}
+
+		cacheControl := readCacheControl
+		if input.Version != "" && strings.HasPrefix(icon.Checksum, input.Version) {
+			cacheControl = iconImmutableCacheControl
+		}
+
+		return &ServerIconOutput{
+			ContentType:           icon.MimeType,
+			CacheControl:          cacheControl,
+			LastModified:          icon.UpdatedAt.UTC().Format(http.TimeFormat),
+			ContentSecurityPolicy: iconContentSecurityPolicy,
+			Body:                  icon.Data,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "upload-server-icon" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/icon",
+		Summary:     "Upload server icon",
+		Description: "Upload a PNG or SVG icon for a published server, replacing any existing one, and get the icon reference that server responses now include. Requires publish permissions for the server. " +
+			"Icons are limited to 64 KiB; PNG icons must be square and between 16x16 and 512x512 pixels. SVG icons are sanitized: scripts, event handlers, external references and unsupported elements are removed.",
+		Tags:         []string{"publish"},
+		MaxBodyBytes: validators.MaxIconSize,
+		RequestBody: &huma.RequestBody{
+			Required: true,
+			Content: map[string]*huma.MediaType{
+				validators.IconMimeTypeSVG: {Schema: &huma.Schema{Type: "string"}},
+			},
+		},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *UploadServerIconInput) (*Response[model.Icon], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		if err := addSharedPermissions(ctx, registry, claims); err != nil {
+			return nil, err
+		}
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(serverName, claims.Permissions))
+		}
+
+		mimeType, _, err := mime.ParseMediaType(input.ContentType)
+		if err != nil {
+			return nil, huma.Error415UnsupportedMediaType("Invalid Content-Type", err)
+		}
+		if mimeType != validators.IconMimeTypePNG && mimeType != validators.IconMimeTypeSVG {
+			return nil, huma.Error415UnsupportedMediaType("Icons must be image/png or image/svg+xml")
+		}
+
+		// The raw body buffer is reused once the handler returns
+		data := append([]byte(nil), input.RawBody...)
+		icon, err := registry.SetServerIcon(ctx, serverName, data, mimeType, auditActor(claims))
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server not found")
+			case errors.Is(err, database.ErrInvalidInput):
+				return nil, huma.Error400BadRequest(err.Error())
+			default:
+				return nil, huma.Error500InternalServerError("Failed to upload server icon", err)
+			}
+		}
+
+		return &Response[model.Icon]{Body: *icon}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-server-icon" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/servers/{serverName}/icon",
+		Summary:     "Delete server icon",
+		Description: "Remove the icon of a server. Requires publish permissions for the server.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *DeleteServerIconInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		if err := addSharedPermissions(ctx, registry, claims); err != nil {
+			return nil, err
+		}
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(serverName, claims.Permissions))
+		}
+
+		if err := registry.DeleteServerIcon(ctx, serverName); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Icon not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete server icon", err)
+		}
+
+		return nil, nil
+	})
+}
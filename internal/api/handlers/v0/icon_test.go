@@ -0,0 +1,137 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServerIconEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), PublicURL: "https://registry.example.com"}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	_, err = registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterIconEndpoints(api, "/v0", registryService, cfg)
+
+	token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodDNS,
+		AuthMethodSubject: "example.com",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}},
+	})
+	require.NoError(t, err)
+
+	do := func(t *testing.T, method, path, contentType string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		if method != http.MethodGet {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	var pngIcon bytes.Buffer
+	require.NoError(t, png.Encode(&pngIcon, image.NewRGBA(image.Rect(0, 0, 64, 64))))
+
+	iconPath := "/v0/servers/" + url.PathEscape("com.example/weather") + "/icon"
+
+	t.Run("rejects unsupported types", func(t *testing.T) {
+		w := do(t, http.MethodPut, iconPath, "image/gif", []byte("GIF89a"))
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("rejects invalid icons", func(t *testing.T) {
+		w := do(t, http.MethodPut, iconPath, "image/png", []byte("not a png"))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("uploads a PNG icon", func(t *testing.T) {
+		w := do(t, http.MethodPut, iconPath, "image/png", pngIcon.Bytes())
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var ref model.Icon
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&ref))
+		assert.True(t, strings.HasPrefix(ref.Src, "https://registry.example.com/v1/servers/com.example%2Fweather/icon?v="))
+		assert.Equal(t, []string{"64x64"}, ref.Sizes)
+
+		w = do(t, http.MethodGet, iconPath, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		assert.Equal(t, pngIcon.Bytes(), w.Body.Bytes())
+
+		// Server responses reference the icon
+		w = do(t, http.MethodGet, "/v0/servers/"+url.PathEscape("com.example/weather")+"/versions/latest", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var server apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&server))
+		require.NotNil(t, server.Meta.Official.Icon)
+		assert.Equal(t, ref.Src, server.Meta.Official.Icon.Src)
+
+		w = do(t, http.MethodPost, "/v0/servers:batchGet", "application/json", []byte(`{"names":["com.example/weather"]}`))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var batch apiv0.BatchGetServersResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&batch))
+		require.Len(t, batch.Servers, 1)
+		require.NotNil(t, batch.Servers[0].Meta.Official.Icon)
+		assert.Equal(t, ref.Src, batch.Servers[0].Meta.Official.Icon.Src)
+	})
+
+	t.Run("sanitizes SVG icons", func(t *testing.T) {
+		svg := `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24" onload="alert(1)"><script>alert(1)</script><circle r="4"/></svg>`
+		w := do(t, http.MethodPut, iconPath, "image/svg+xml", []byte(svg))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(t, http.MethodGet, iconPath, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/svg+xml", w.Header().Get("Content-Type"))
+		assert.NotEmpty(t, w.Header().Get("Content-Security-Policy"))
+		assert.NotContains(t, w.Body.String(), "alert")
+	})
+
+	t.Run("deletes the icon", func(t *testing.T) {
+		w := do(t, http.MethodDelete, iconPath, "", nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = do(t, http.MethodGet, iconPath, "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
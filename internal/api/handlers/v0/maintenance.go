@@ -0,0 +1,187 @@
+package v0
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+const (
+	// DefaultMaintenanceMessage is returned by write endpoints when no message has been set
+	DefaultMaintenanceMessage = "The registry is undergoing maintenance and is read-only. Please try again later."
+
+	// maintenanceCacheTTL bounds how long a replica may take to notice a maintenance mode change
+	maintenanceCacheTTL = 5 * time.Second
+)
+
+// MaintenanceStore persists the maintenance mode state shared by all replicas
+type MaintenanceStore interface {
+	GetMaintenanceMode(ctx context.Context) (*database.MaintenanceMode, error)
+	SetMaintenanceMode(ctx context.Context, enabled bool, message string) (*database.MaintenanceMode, error)
+}
+
+// MaintenanceBody represents the maintenance mode state
+type MaintenanceBody struct {
+	Enabled   bool       `json:"enabled" doc:"Whether write endpoints are disabled"`
+	Message   string     `json:"message,omitempty" doc:"Message returned by write endpoints while enabled" example:"Database migration in progress"`
+	Forced    bool       `json:"forced,omitempty" doc:"Maintenance mode is enabled by configuration and cannot be disabled through the API"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty" doc:"When maintenance mode was last changed through the API"`
+}
+
+// SetMaintenanceInput represents the input for changing maintenance mode
+type SetMaintenanceInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Body          struct {
+		Enabled bool   `json:"enabled" doc:"Whether to disable write endpoints"`
+		Message string `json:"message,omitempty" doc:"Message returned by write endpoints while enabled" maxLength:"500"`
+	}
+}
+
+// Maintenance reports whether the registry is in maintenance mode. The persisted state is
+// cached briefly so that checking it does not add a database query to every write request.
+type Maintenance struct {
+	store         MaintenanceStore
+	forced        bool
+	forcedMessage string
+
+	mu        sync.Mutex
+	cached    *database.MaintenanceMode
+	fetchedAt time.Time
+}
+
+// NewMaintenance creates a maintenance mode tracker. Setting MAINTENANCE_MODE in the
+// configuration forces maintenance mode on regardless of the persisted state.
+func NewMaintenance(cfg *config.Config, store MaintenanceStore) *Maintenance {
+	return &Maintenance{
+		store:         store,
+		forced:        cfg.MaintenanceMode,
+		forcedMessage: cfg.MaintenanceMessage,
+	}
+}
+
+// Status returns the current maintenance mode state. If the persisted state cannot be read,
+// the last known state is used so reads keep working while the database is unavailable.
+func (m *Maintenance) Status(ctx context.Context) MaintenanceBody {
+	if m.forced {
+		return MaintenanceBody{Enabled: true, Message: messageOrDefault(m.forcedMessage), Forced: true}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cached == nil || time.Since(m.fetchedAt) > maintenanceCacheTTL {
+		mode, err := m.store.GetMaintenanceMode(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to get maintenance mode, using last known state", logging.Err(err))
+		} else {
+			m.cached = mode
+		}
+		// Avoid retrying on every request while the database is unavailable
+		m.fetchedAt = time.Now()
+	}
+
+	return toMaintenanceBody(m.cached)
+}
+
+// Set persists a new maintenance mode state and applies it to this replica immediately
+func (m *Maintenance) Set(ctx context.Context, enabled bool, message string) (MaintenanceBody, error) {
+	mode, err := m.store.SetMaintenanceMode(ctx, enabled, message)
+	if err != nil {
+		return MaintenanceBody{}, err
+	}
+
+	m.mu.Lock()
+	m.cached = mode
+	m.fetchedAt = time.Now()
+	m.mu.Unlock()
+
+	return m.Status(ctx), nil
+}
+
+func toMaintenanceBody(mode *database.MaintenanceMode) MaintenanceBody {
+	if mode == nil || !mode.Enabled {
+		return MaintenanceBody{}
+	}
+	updatedAt := mode.UpdatedAt
+	return MaintenanceBody{Enabled: true, Message: messageOrDefault(mode.Message), UpdatedAt: &updatedAt}
+}
+
+func messageOrDefault(message string) string {
+	if message == "" {
+		return DefaultMaintenanceMessage
+	}
+	return message
+}
+
+// RegisterMaintenanceEndpoints registers the maintenance mode admin endpoints with a custom path prefix
+func RegisterMaintenanceEndpoints(api huma.API, pathPrefix string, cfg *config.Config, maintenance *Maintenance) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-maintenance" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/maintenance",
+		Summary:     "Get maintenance mode",
+		Description: "Get whether the registry is in maintenance mode. While enabled, write endpoints return 503 and reads continue to work.",
+		Tags:        []string{"admin"},
+	}, func(ctx context.Context, _ *struct{}) (*Response[MaintenanceBody], error) {
+		return &Response[MaintenanceBody]{Body: maintenance.Status(ctx)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-maintenance" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/admin/maintenance",
+		Summary:     "Set maintenance mode",
+		Description: "Enable or disable maintenance mode for all replicas (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetMaintenanceInput) (*Response[MaintenanceBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only admins can change maintenance mode
+		if !isAdmin(claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have permission to change maintenance mode")
+		}
+
+		body, err := maintenance.Set(ctx, input.Body.Enabled, input.Body.Message)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to set maintenance mode", err)
+		}
+		slog.InfoContext(ctx, "Maintenance mode set", "enabled", input.Body.Enabled, logging.ActorKey, auditActor(claims))
+
+		return &Response[MaintenanceBody]{Body: body}, nil
+	})
+}
+
+// hasGlobalPermission reports whether permissions grant action on every resource, as given to admins
+func hasGlobalPermission(action auth.PermissionAction, permissions []auth.Permission) bool {
+	for _, perm := range permissions {
+		if perm.Grants(action) && perm.ResourcePattern == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// isAdmin reports whether permissions allow registry administration. Global edit permission predates
+// the admin action and is still accepted, so existing admin logins keep working.
+func isAdmin(permissions []auth.Permission) bool {
+	return hasGlobalPermission(auth.PermissionActionAdmin, permissions) ||
+		hasGlobalPermission(auth.PermissionActionEdit, permissions)
+}
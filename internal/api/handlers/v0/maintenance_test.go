@@ -0,0 +1,122 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// fakeMaintenanceStore keeps maintenance mode in memory
+type fakeMaintenanceStore struct {
+	mode database.MaintenanceMode
+}
+
+func (f *fakeMaintenanceStore) GetMaintenanceMode(_ context.Context) (*database.MaintenanceMode, error) {
+	mode := f.mode
+	return &mode, nil
+}
+
+func (f *fakeMaintenanceStore) SetMaintenanceMode(_ context.Context, enabled bool, message string) (*database.MaintenanceMode, error) {
+	f.mode = database.MaintenanceMode{Enabled: enabled, Message: message, UpdatedAt: time.Now()}
+	mode := f.mode
+	return &mode, nil
+}
+
+func TestMaintenanceEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	jwtManager := auth.NewJWTManager(cfg)
+
+	store := &fakeMaintenanceStore{}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterMaintenanceEndpoints(api, "/v1", cfg, v0.NewMaintenance(cfg, store))
+
+	tokenFor := func(t *testing.T, pattern string) string {
+		t.Helper()
+		token, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:  auth.MethodNone,
+			Permissions: []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		return token.RegistryToken
+	}
+	status := func(t *testing.T) v0.MaintenanceBody {
+		t.Helper()
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/admin/maintenance", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var body v0.MaintenanceBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		return body
+	}
+	set := func(t *testing.T, token string, enabled bool, message string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(map[string]any{"enabled": enabled, "message": message})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, "/v1/admin/maintenance", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.False(t, status(t).Enabled)
+
+	t.Run("requires a token", func(t *testing.T) {
+		w := set(t, "", true, "")
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("requires global edit permissions", func(t *testing.T) {
+		w := set(t, tokenFor(t, "io.github.user/*"), true, "")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.False(t, store.mode.Enabled)
+	})
+
+	t.Run("admin enables and disables", func(t *testing.T) {
+		w := set(t, tokenFor(t, "*"), true, "Database migration in progress")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, store.mode.Enabled)
+
+		body := status(t)
+		assert.True(t, body.Enabled)
+		assert.Equal(t, "Database migration in progress", body.Message)
+		assert.NotNil(t, body.UpdatedAt)
+
+		w = set(t, tokenFor(t, "*"), false, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.False(t, status(t).Enabled)
+	})
+}
+
+func TestMaintenanceForcedByConfig(t *testing.T) {
+	maintenance := v0.NewMaintenance(&config.Config{MaintenanceMode: true}, &fakeMaintenanceStore{})
+
+	body := maintenance.Status(context.Background())
+	assert.True(t, body.Enabled)
+	assert.True(t, body.Forced)
+	assert.Equal(t, v0.DefaultMaintenanceMessage, body.Message)
+}
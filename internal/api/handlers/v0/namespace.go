@@ -0,0 +1,351 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// NamespaceReservationBody represents a namespace reservation
+type NamespaceReservationBody struct {
+	Namespace         string    `json:"namespace" doc:"Reserved namespace" example:"com.example"`
+	Owner             string    `json:"owner" doc:"Who reserved the namespace" example:"dns:example.com"`
+	PermissionPattern string    `json:"permissionPattern" doc:"Publish permission a token must carry to publish in the namespace" example:"com.example/*"`
+	Status            string    `json:"status" doc:"Reservation status" enum:"active,disputed"`
+	DisputeReason     string    `json:"disputeReason,omitempty" doc:"Why the reservation was disputed"`
+	DisputedBy        string    `json:"disputedBy,omitempty" doc:"Who disputed the reservation" example:"github-at:someone"`
+	CreatedAt         time.Time `json:"createdAt" doc:"When the namespace was reserved"`
+	UpdatedAt         time.Time `json:"updatedAt" doc:"When the reservation last changed"`
+}
+
+// NamespaceDisputeListBody represents the reservations awaiting admin review
+type NamespaceDisputeListBody struct {
+	Disputes []NamespaceReservationBody `json:"disputes" doc:"Disputed reservations, oldest first"`
+}
+
+// NamespaceDisputeResolutionBody represents the outcome of an admin review
+type NamespaceDisputeResolutionBody struct {
+	Namespace   string                    `json:"namespace" doc:"Disputed namespace" example:"com.example"`
+	Decision    string                    `json:"decision" doc:"How the dispute was resolved" enum:"uphold,revoke"`
+	Reservation *NamespaceReservationBody `json:"reservation,omitempty" doc:"The upheld reservation; omitted when revoked"`
+}
+
+// NamespaceInput represents the input for reading a namespace reservation
+type NamespaceInput struct {
+	Namespace string `path:"namespace" doc:"Namespace, the part of server names before the slash" example:"com.example"`
+}
+
+// AuthenticatedNamespaceInput represents the input for reserving or releasing a namespace
+type AuthenticatedNamespaceInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the namespace" required:"true"`
+	Namespace     string `path:"namespace" doc:"Namespace, the part of server names before the slash" example:"com.example"`
+}
+
+// DisputeNamespaceInput represents the input for disputing a namespace reservation
+type DisputeNamespaceInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Namespace     string `path:"namespace" doc:"Namespace, the part of server names before the slash" example:"com.example"`
+	Body          struct {
+		Reason string `json:"reason" doc:"Why the reservation should be revoked" minLength:"1" maxLength:"1000"`
+	}
+}
+
+// ListNamespaceDisputesInput represents the input for listing disputed reservations
+type ListNamespaceDisputesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+}
+
+// ResolveNamespaceDisputeInput represents the input for resolving a disputed reservation
+type ResolveNamespaceDisputeInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Namespace     string `path:"namespace" doc:"Disputed namespace" example:"com.example"`
+	Body          struct {
+		Decision string `json:"decision" doc:"Keep the reservation with its owner, or revoke it and free the namespace" enum:"uphold,revoke"`
+	}
+}
+
+// RegisterNamespaceEndpoints registers the namespace reservation endpoints with a custom path prefix
+func RegisterNamespaceEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "reserve-namespace" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/namespaces/{namespace}/reservation",
+		Summary:     "Reserve namespace",
+		Description: "Reserve a namespace before publishing to it. Requires publish permissions for the whole namespace, as granted by verifying a domain or GitHub account. Once reserved, only tokens carrying the same permission can publish servers in the namespace.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *AuthenticatedNamespaceInput) (*Response[NamespaceReservationBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		pattern, ok := namespacePermission(jwtManager, input.Namespace, claims.Permissions)
+		if !ok {
+			return nil, huma.Error403Forbidden(fmt.Sprintf("You do not have publish permissions for every server in namespace %s", input.Namespace))
+		}
+
+		reservation, err := registry.ReserveNamespace(ctx, input.Namespace, pattern, auditActor(claims))
+		if err != nil {
+			return nil, namespaceError(err, "Failed to reserve namespace")
+		}
+
+		return &Response[NamespaceReservationBody]{Body: toNamespaceReservationBody(reservation)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-namespace-reservation" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces/{namespace}/reservation",
+		Summary:     "Get namespace reservation",
+		Description: "Get the reservation of a namespace, if any.",
+		Tags:        []string{"publish"},
+	}, func(ctx context.Context, input *NamespaceInput) (*Response[NamespaceReservationBody], error) {
+		reservation, err := registry.GetNamespaceReservation(ctx, input.Namespace)
+		if err != nil {
+			return nil, namespaceError(err, "Failed to get namespace reservation")
+		}
+
+		return &Response[NamespaceReservationBody]{Body: toNamespaceReservationBody(reservation)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "release-namespace" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/namespaces/{namespace}/reservation",
+		Summary:     "Release namespace",
+		Description: "Release a namespace reservation. Requires the permission the namespace was reserved with. Servers already published in the namespace are not affected.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *AuthenticatedNamespaceInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		reservation, err := registry.GetNamespaceReservation(ctx, input.Namespace)
+		if err != nil {
+			return nil, namespaceError(err, "Failed to get namespace reservation")
+		}
+		if !holdsReservation(reservation, claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have permission to release this namespace")
+		}
+
+		if err := registry.ReleaseNamespace(ctx, input.Namespace, auditActor(claims)); err != nil {
+			return nil, namespaceError(err, "Failed to release namespace")
+		}
+
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "dispute-namespace-reservation" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/namespaces/{namespace}/reservation/dispute",
+		Summary:     "Dispute namespace reservation",
+		Description: "Ask the registry admins to review a namespace reservation, for example because it was made by someone who no longer owns the domain. The reservation stays in force until an admin resolves the dispute.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *DisputeNamespaceInput) (*Response[NamespaceReservationBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		reservation, err := registry.DisputeNamespaceReservation(ctx, input.Namespace, input.Body.Reason, auditActor(claims))
+		if err != nil {
+			return nil, namespaceError(err, "Failed to dispute namespace reservation")
+		}
+
+		return &Response[NamespaceReservationBody]{Body: toNamespaceReservationBody(reservation)}, nil
+	})
+}
+
+// RegisterNamespaceDisputeEndpoints registers the admin endpoints for reviewing disputed
+// namespace reservations with a custom path prefix
+func RegisterNamespaceDisputeEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-namespace-disputes" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/namespace-disputes",
+		Summary:     "List namespace disputes",
+		Description: "List the namespace reservations awaiting review (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListNamespaceDisputesInput) (*Response[NamespaceDisputeListBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin(claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have permission to review namespace disputes")
+		}
+
+		reservations, err := registry.ListNamespaceDisputes(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list namespace disputes", err)
+		}
+
+		disputes := make([]NamespaceReservationBody, 0, len(reservations))
+		for _, reservation := range reservations {
+			disputes = append(disputes, toNamespaceReservationBody(reservation))
+		}
+
+		return &Response[NamespaceDisputeListBody]{Body: NamespaceDisputeListBody{Disputes: disputes}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "resolve-namespace-dispute" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/namespace-disputes/{namespace}",
+		Summary:     "Resolve namespace dispute",
+		Description: "Uphold a disputed reservation, keeping it with its owner, or revoke it to free the namespace (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ResolveNamespaceDisputeInput) (*Response[NamespaceDisputeResolutionBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin(claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have permission to review namespace disputes")
+		}
+
+		reservation, err := registry.ResolveNamespaceDispute(ctx, input.Namespace, input.Body.Decision == "uphold", auditActor(claims))
+		if err != nil {
+			return nil, namespaceError(err, "Failed to resolve namespace dispute")
+		}
+
+		body := NamespaceDisputeResolutionBody{Namespace: input.Namespace, Decision: input.Body.Decision}
+		if reservation != nil {
+			upheld := toNamespaceReservationBody(reservation)
+			body.Reservation = &upheld
+		}
+		return &Response[NamespaceDisputeResolutionBody]{Body: body}, nil
+	})
+}
+
+// namespaceReservationViolation returns why the token may not publish serverName because its
+// namespace is reserved for a permission the token does not carry, or "" if it may. Delegates may
+// publish the servers delegated to them with the permission the namespace is reserved for.
+func namespaceReservationViolation(ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, serverName string, claims *auth.JWTClaims) (string, error) {
+	namespace, _, found := strings.Cut(serverName, "/")
+	if !found {
+		// Malformed names are rejected by validation
+		return "", nil
+	}
+
+	reservation, err := registry.GetNamespaceReservation(ctx, namespace)
+	if errors.Is(err, database.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if holdsReservation(reservation, claims.Permissions) {
+		return "", nil
+	}
+	delegated, err := holdsDelegatedReservation(ctx, registry, jwtManager, reservation, serverName, claims)
+	if err != nil {
+		return "", err
+	}
+	if !delegated {
+		return fmt.Sprintf("Namespace %s is reserved. Publishing to it requires the permission '%s'", namespace, reservation.PermissionPattern), nil
+	}
+	return "", nil
+}
+
+// checkNamespaceReservation fails with 403 if the namespace of serverName is reserved for
+// a permission the token does not carry
+func checkNamespaceReservation(ctx context.Context, registry service.RegistryService, jwtManager *auth.JWTManager, serverName string, claims *auth.JWTClaims) error {
+	violation, err := namespaceReservationViolation(ctx, registry, jwtManager, serverName, claims)
+	if err != nil {
+		return huma.Error500InternalServerError("Failed to check namespace reservation", err)
+	}
+	if violation != "" {
+		return huma.Error403Forbidden(violation)
+	}
+	return nil
+}
+
+// namespacePermission returns the most specific publish permission pattern covering every
+// server in namespace. Global permissions don't count, so admins cannot pin a namespace to themselves.
+func namespacePermission(jwtManager *auth.JWTManager, namespace string, permissions []auth.Permission) (string, bool) {
+	best := ""
+	for _, perm := range permissions {
+		if perm.ResourcePattern == "*" {
+			continue
+		}
+		if jwtManager.HasPermission(namespace+"/", auth.PermissionActionPublish, []auth.Permission{perm}) &&
+			len(perm.ResourcePattern) > len(best) {
+			best = perm.ResourcePattern
+		}
+	}
+	return best, best != ""
+}
+
+// holdsReservation reports whether permissions include the permission a namespace was reserved
+// with, or global publish permissions
+func holdsReservation(reservation *database.NamespaceReservation, permissions []auth.Permission) bool {
+	if hasGlobalPermission(auth.PermissionActionPublish, permissions) {
+		return true
+	}
+	for _, perm := range permissions {
+		if perm.Grants(auth.PermissionActionPublish) && perm.ResourcePattern == reservation.PermissionPattern {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceError maps service errors to HTTP errors
+func namespaceError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Namespace reservation not found")
+	case errors.Is(err, database.ErrAlreadyExists):
+		return huma.Error409Conflict(err.Error())
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	default:
+		return huma.Error500InternalServerError(msg, err)
+	}
+}
+
+func toNamespaceReservationBody(reservation *database.NamespaceReservation) NamespaceReservationBody {
+	return NamespaceReservationBody{
+		Namespace:         reservation.Namespace,
+		Owner:             reservation.Owner,
+		PermissionPattern: reservation.PermissionPattern,
+		Status:            reservation.Status,
+		DisputeReason:     reservation.DisputeReason,
+		DisputedBy:        reservation.DisputedBy,
+		CreatedAt:         reservation.CreatedAt,
+		UpdatedAt:         reservation.UpdatedAt,
+	}
+}
@@ -0,0 +1,148 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestNamespaceReservationEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterNamespaceEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterNamespaceDisputeEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+
+	tokenFor := func(t *testing.T, method auth.Method, subject string, permissions ...auth.Permission) string {
+		t.Helper()
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:        method,
+			AuthMethodSubject: subject,
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return token
+	}
+	do := func(t *testing.T, method, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		reader := bytes.NewReader(nil)
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	server := func(name string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: name, Description: "Weather server", Version: "1.0.0"}
+	}
+
+	owner := tokenFor(t, auth.MethodDNS, "example.com",
+		auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"},
+		auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.*"})
+	// A broader pattern that also covers the namespace, but is not the one it was reserved with
+	squatter := tokenFor(t, auth.MethodGitHubAT, "squatter",
+		auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.example*"})
+	singleServer := tokenFor(t, auth.MethodGitHubAT, "single",
+		auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/one"})
+	admin := tokenFor(t, auth.MethodNone, "admin",
+		auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+
+	reservationPath := "/v0/namespaces/com.example/reservation"
+
+	t.Run("requires permission for the whole namespace", func(t *testing.T) {
+		w := do(t, http.MethodPost, reservationPath, singleServer, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("owner reserves", func(t *testing.T) {
+		w := do(t, http.MethodPost, reservationPath, owner, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var reservation v0.NamespaceReservationBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&reservation))
+		assert.Equal(t, "com.example/*", reservation.PermissionPattern)
+		assert.Equal(t, "dns:example.com", reservation.Owner)
+		assert.Equal(t, database.ReservationStatusActive, reservation.Status)
+
+		// Reserving again is a no-op
+		w = do(t, http.MethodPost, reservationPath, owner, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = do(t, http.MethodPost, reservationPath, squatter, nil)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = do(t, http.MethodGet, reservationPath, "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("blocks publishing without the reserved permission", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/publish", squatter, server("com.example/weather"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "reserved")
+
+		w = do(t, http.MethodPost, "/v0/publish?dryRun=true", squatter, server("com.example/weather"))
+		require.Equal(t, http.StatusOK, w.Code)
+		var result apiv0.PublishResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+		assert.False(t, result.Validation.Valid)
+
+		w = do(t, http.MethodPost, "/v0/publish", owner, server("com.example/weather"))
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("disputes are reviewed by admins", func(t *testing.T) {
+		w := do(t, http.MethodPost, reservationPath+"/dispute", squatter, map[string]string{"reason": "We own example.com now"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(t, http.MethodPost, reservationPath+"/dispute", squatter, map[string]string{"reason": "Again"})
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		w = do(t, http.MethodGet, "/v0/admin/namespace-disputes", squatter, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = do(t, http.MethodGet, "/v0/admin/namespace-disputes", admin, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var disputes v0.NamespaceDisputeListBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&disputes))
+		require.Len(t, disputes.Disputes, 1)
+		assert.Equal(t, "github-at:squatter", disputes.Disputes[0].DisputedBy)
+
+		w = do(t, http.MethodPost, "/v0/admin/namespace-disputes/com.example", admin, map[string]string{"decision": "revoke"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(t, http.MethodGet, reservationPath, "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
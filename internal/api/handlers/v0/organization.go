@@ -0,0 +1,333 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// OrganizationMemberBody represents a member of an organization
+type OrganizationMemberBody struct {
+	Member  string    `json:"member" doc:"The member, as <auth method>:<subject>" example:"github-at:alice"`
+	Role    string    `json:"role" doc:"Admins can manage the organization and its members" enum:"admin,member"`
+	AddedBy string    `json:"addedBy" doc:"Who added the member" example:"github-at:bob"`
+	AddedAt time.Time `json:"addedAt" doc:"When the member was added"`
+}
+
+// OrganizationBody represents an organization
+type OrganizationBody struct {
+	Namespace         string                   `json:"namespace" doc:"Namespace the organization publishes in" example:"com.example"`
+	PermissionPattern string                   `json:"permissionPattern" doc:"Publish permission members get" example:"com.example/*"`
+	CreatedBy         string                   `json:"createdBy" doc:"Who created the organization" example:"dns:example.com"`
+	CreatedAt         time.Time                `json:"createdAt" doc:"When the organization was created"`
+	Members           []OrganizationMemberBody `json:"members,omitempty" doc:"Members, admins first"`
+}
+
+// OrganizationListBody represents the organizations of the caller
+type OrganizationListBody struct {
+	Organizations []OrganizationBody `json:"organizations" doc:"Organizations the caller is a member of"`
+}
+
+// OrganizationMemberInput represents the input for adding, changing or removing a member
+type OrganizationMemberInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token of an organization admin" required:"true"`
+	Namespace     string `path:"namespace" doc:"Namespace of the organization" example:"com.example"`
+	Member        string `path:"member" doc:"The member, as <auth method>:<subject>" example:"github-at:alice"`
+}
+
+// SetOrganizationMemberInput represents the input for adding a member or changing their role
+type SetOrganizationMemberInput struct {
+	OrganizationMemberInput
+	Body struct {
+		Role string `json:"role" doc:"Role to give the member" enum:"admin,member" default:"member"`
+	}
+}
+
+// ListOrganizationsInput represents the input for listing the caller's organizations
+type ListOrganizationsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+}
+
+// accountMethods are the auth methods that identify a person or domain, and so can be organization
+// members or namespace delegates
+var accountMethods = []auth.Method{
+	auth.MethodGitHubAT, auth.MethodGitLabAT, auth.MethodGitHubOIDC, auth.MethodGitLabOIDC, auth.MethodGoogle,
+	auth.MethodOIDC, auth.MethodDNS, auth.MethodHTTP, auth.MethodMTLS,
+}
+
+// RegisterOrganizationEndpoints registers the organization endpoints with a custom path prefix
+func RegisterOrganizationEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-organization" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/namespaces/{namespace}/organization",
+		Summary:     "Create organization",
+		Description: "Create an organization for a namespace so several people can publish in it. Requires publish permissions for the whole namespace, which members then get too. The caller becomes the organization's first admin.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *AuthenticatedNamespaceInput) (*Response[OrganizationBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if claims.AuthMethod == auth.MethodAPIToken {
+			return nil, huma.Error403Forbidden("API tokens cannot create organizations. Log in interactively instead")
+		}
+
+		pattern, ok := namespacePermission(jwtManager, input.Namespace, claims.Permissions)
+		if !ok {
+			return nil, huma.Error403Forbidden(fmt.Sprintf("You do not have publish permissions for every server in namespace %s", input.Namespace))
+		}
+
+		organization, err := registry.CreateOrganization(ctx, input.Namespace, pattern, auditActor(claims))
+		if err != nil {
+			if errors.Is(err, database.ErrAlreadyExists) {
+				return nil, huma.Error409Conflict(fmt.Sprintf("Namespace %s already has an organization", input.Namespace))
+			}
+			return nil, organizationError(err, "Failed to create organization")
+		}
+
+		return organizationResponse(ctx, registry, organization)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-organization" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces/{namespace}/organization",
+		Summary:     "Get organization",
+		Description: "Get the organization of a namespace and its members.",
+		Tags:        []string{"publish"},
+	}, func(ctx context.Context, input *NamespaceInput) (*Response[OrganizationBody], error) {
+		organization, err := registry.GetOrganization(ctx, input.Namespace)
+		if err != nil {
+			return nil, organizationError(err, "Failed to get organization")
+		}
+
+		return organizationResponse(ctx, registry, organization)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-organization" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/namespaces/{namespace}/organization",
+		Summary:     "Delete organization",
+		Description: "Delete an organization. Its members can no longer publish through it. Requires being an admin of the organization.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *AuthenticatedNamespaceInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if err := requireOrganizationAdmin(ctx, registry, input.Namespace, claims); err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteOrganization(ctx, input.Namespace, auditActor(claims)); err != nil {
+			return nil, organizationError(err, "Failed to delete organization")
+		}
+
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-organization-member" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/namespaces/{namespace}/organization/members/{member}",
+		Summary:     "Add or update organization member",
+		Description: "Add a member to an organization, or change their role. Members are identified the way they log in, such as github-at:alice for the GitHub user alice or dns:example.com for a domain. Requires being an admin of the organization.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetOrganizationMemberInput) (*Response[OrganizationMemberBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateAccount("Members", input.Member); err != nil {
+			return nil, err
+		}
+		if err := requireOrganizationAdmin(ctx, registry, input.Namespace, claims); err != nil {
+			return nil, err
+		}
+
+		member, err := registry.SetOrganizationMember(ctx, input.Namespace, input.Member, input.Body.Role, auditActor(claims))
+		if err != nil {
+			return nil, organizationError(err, "Failed to set organization member")
+		}
+
+		return &Response[OrganizationMemberBody]{Body: toOrganizationMemberBody(member)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "remove-organization-member" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/namespaces/{namespace}/organization/members/{member}",
+		Summary:     "Remove organization member",
+		Description: "Remove a member from an organization. Requires being an admin of the organization, except to leave it yourself. The last admin cannot be removed.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *OrganizationMemberInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if input.Member != auditActor(claims) {
+			if err := requireOrganizationAdmin(ctx, registry, input.Namespace, claims); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := registry.RemoveOrganizationMember(ctx, input.Namespace, input.Member, auditActor(claims)); err != nil {
+			return nil, organizationError(err, "Failed to remove organization member")
+		}
+
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-organizations" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/organizations",
+		Summary:     "List my organizations",
+		Description: "List the organizations the caller is a member of.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListOrganizationsInput) (*Response[OrganizationListBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		organizations, err := registry.ListMemberOrganizations(ctx, auditActor(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list organizations", err)
+		}
+
+		body := OrganizationListBody{Organizations: make([]OrganizationBody, 0, len(organizations))}
+		for _, organization := range organizations {
+			body.Organizations = append(body.Organizations, toOrganizationBody(organization, nil))
+		}
+		return &Response[OrganizationListBody]{Body: body}, nil
+	})
+}
+
+// addOrganizationPermissions gives claims the publish permissions of the organizations its holder is
+// a member of. API tokens act with their own permissions only.
+func addOrganizationPermissions(ctx context.Context, registry service.RegistryService, claims *auth.JWTClaims) error {
+	if claims.AuthMethod == auth.MethodAPIToken {
+		return nil
+	}
+
+	organizations, err := registry.ListMemberOrganizations(ctx, auditActor(claims))
+	if err != nil {
+		return huma.Error500InternalServerError("Failed to check organization membership", err)
+	}
+	for _, organization := range organizations {
+		claims.Permissions = append(claims.Permissions, auth.Permission{
+			Action:          auth.PermissionActionPublish,
+			ResourcePattern: organization.PermissionPattern,
+		})
+	}
+	return nil
+}
+
+// requireOrganizationAdmin fails unless the token holder is an admin of the organization of
+// namespace, or a registry admin
+func requireOrganizationAdmin(ctx context.Context, registry service.RegistryService, namespace string, claims *auth.JWTClaims) error {
+	if _, err := registry.GetOrganization(ctx, namespace); err != nil {
+		return organizationError(err, "Failed to get organization")
+	}
+	if isAdmin(claims.Permissions) {
+		return nil
+	}
+
+	member, err := registry.GetOrganizationMember(ctx, namespace, auditActor(claims))
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return huma.Error500InternalServerError("Failed to check organization membership", err)
+	}
+	if member == nil || member.Role != database.OrganizationRoleAdmin {
+		return huma.Error403Forbidden("You are not an admin of this organization")
+	}
+	return nil
+}
+
+// validateAccount checks that an account, such as an organization member, is given as <auth method>:<subject>.
+// kind names what the account is for in error messages.
+func validateAccount(kind, account string) error {
+	method, subject, found := strings.Cut(account, ":")
+	if !found || subject == "" {
+		return huma.Error400BadRequest(kind + " are given as <auth method>:<subject>, such as github-at:alice")
+	}
+	for _, allowed := range accountMethods {
+		if auth.Method(method) == allowed {
+			return nil
+		}
+	}
+	return huma.Error400BadRequest(fmt.Sprintf("%s cannot be identified by auth method %q", kind, method))
+}
+
+func organizationResponse(ctx context.Context, registry service.RegistryService, organization *database.Organization) (*Response[OrganizationBody], error) {
+	members, err := registry.ListOrganizationMembers(ctx, organization.Namespace)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to list organization members", err)
+	}
+	return &Response[OrganizationBody]{Body: toOrganizationBody(organization, members)}, nil
+}
+
+// organizationError maps service errors to HTTP errors
+func organizationError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Organization or member not found")
+	case errors.Is(err, database.ErrAlreadyExists):
+		return huma.Error409Conflict(err.Error())
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	default:
+		return huma.Error500InternalServerError(msg, err)
+	}
+}
+
+func toOrganizationBody(organization *database.Organization, members []*database.OrganizationMember) OrganizationBody {
+	body := OrganizationBody{
+		Namespace:         organization.Namespace,
+		PermissionPattern: organization.PermissionPattern,
+		CreatedBy:         organization.CreatedBy,
+		CreatedAt:         organization.CreatedAt,
+	}
+	for _, member := range members {
+		body.Members = append(body.Members, toOrganizationMemberBody(member))
+	}
+	return body
+}
+
+func toOrganizationMemberBody(member *database.OrganizationMember) OrganizationMemberBody {
+	return OrganizationMemberBody{
+		Member:  member.Member,
+		Role:    member.Role,
+		AddedBy: member.AddedBy,
+		AddedAt: member.AddedAt,
+	}
+}
@@ -0,0 +1,147 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestOrganizationEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterNamespaceEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterOrganizationEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+
+	tokenFor := func(t *testing.T, method auth.Method, subject string, permissions ...auth.Permission) string {
+		t.Helper()
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:        method,
+			AuthMethodSubject: subject,
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return token
+	}
+	do := func(t *testing.T, method, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		reader := bytes.NewReader(nil)
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	server := func(name string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{Schema: model.CurrentSchemaURL, Name: name, Description: "Weather server", Version: "1.0.0"}
+	}
+
+	owner := tokenFor(t, auth.MethodDNS, "example.com",
+		auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"})
+	alice := tokenFor(t, auth.MethodGitHubAT, "alice",
+		auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"})
+	bob := tokenFor(t, auth.MethodGitHubAT, "bob",
+		auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.bob/*"})
+
+	t.Run("creating requires permissions for the namespace", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/namespaces/com.example/organization", alice, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	w := do(t, http.MethodPost, "/v0/namespaces/com.example/organization", owner, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var organization v0.OrganizationBody
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&organization))
+	assert.Equal(t, "com.example/*", organization.PermissionPattern)
+	require.Len(t, organization.Members, 1)
+	assert.Equal(t, v0.OrganizationMemberBody{Member: "dns:example.com", Role: "admin", AddedBy: "dns:example.com", AddedAt: organization.Members[0].AddedAt}, organization.Members[0])
+
+	// Reserve the namespace, so members must publish with the organization's permission
+	require.Equal(t, http.StatusOK, do(t, http.MethodPost, "/v0/namespaces/com.example/reservation", owner, nil).Code)
+
+	t.Run("non-members cannot publish", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, do(t, http.MethodPost, "/v0/publish", alice, server("com.example/weather")).Code)
+	})
+
+	t.Run("only admins manage members", func(t *testing.T) {
+		w := do(t, http.MethodPut, "/v0/namespaces/com.example/organization/members/github-at:bob", alice, map[string]string{"role": "member"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("members must be identified by a login method", func(t *testing.T) {
+		w := do(t, http.MethodPut, "/v0/namespaces/com.example/organization/members/api-token:x", owner, map[string]string{"role": "member"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("members can publish", func(t *testing.T) {
+		w := do(t, http.MethodPut, "/v0/namespaces/com.example/organization/members/github-at:alice", owner, map[string]string{"role": "member"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(t, http.MethodPost, "/v0/publish", alice, server("com.example/weather"))
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(t, http.MethodGet, "/v0/organizations", alice, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var list v0.OrganizationListBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+		require.Len(t, list.Organizations, 1)
+		assert.Equal(t, "com.example", list.Organizations[0].Namespace)
+	})
+
+	t.Run("admins can promote members", func(t *testing.T) {
+		w := do(t, http.MethodPut, "/v0/namespaces/com.example/organization/members/github-at:alice", owner, map[string]string{"role": "admin"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(t, http.MethodPut, "/v0/namespaces/com.example/organization/members/github-at:bob", alice, map[string]string{"role": "member"})
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("members can leave", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, do(t, http.MethodDelete, "/v0/namespaces/com.example/organization/members/github-at:bob", bob, nil).Code)
+		assert.Equal(t, http.StatusForbidden, do(t, http.MethodPost, "/v0/publish", bob, server("com.example/other")).Code)
+	})
+
+	t.Run("the last admin cannot leave", func(t *testing.T) {
+		require.Equal(t, http.StatusNoContent, do(t, http.MethodDelete, "/v0/namespaces/com.example/organization/members/dns:example.com", owner, nil).Code)
+		assert.Equal(t, http.StatusBadRequest, do(t, http.MethodDelete, "/v0/namespaces/com.example/organization/members/github-at:alice", alice, nil).Code)
+	})
+
+	t.Run("deleting the organization removes membership", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, do(t, http.MethodDelete, "/v0/namespaces/com.example/organization", alice, nil).Code)
+		assert.Equal(t, http.StatusNotFound, do(t, http.MethodGet, "/v0/namespaces/com.example/organization", "", nil).Code)
+		assert.Equal(t, http.StatusForbidden, do(t, http.MethodPost, "/v0/publish", alice, server("com.example/other")).Code)
+	})
+}
@@ -0,0 +1,53 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// LookupPackageInput represents the input for looking up the servers that claim a package
+type LookupPackageInput struct {
+	Identifier   string `query:"identifier" required:"true" minLength:"1" doc:"Package identifier, such as an OCI image reference or an npm package name. OCI references match every tag and digest of the repository" example:"docker.io/acme/foo"`
+	RegistryType string `query:"registryType" doc:"Only match packages of this registry type" enum:"npm,pypi,oci,nuget,mcpb" example:"oci"`
+}
+
+// RegisterPackageEndpoints registers the package lookup endpoint with a custom path prefix
+func RegisterPackageEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "lookup-package" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/packages/lookup",
+		Summary:     "Find servers by package",
+		Description: "List every server version whose server.json lists a package, to map deployed artifacts such as container images back to registry listings. " +
+			"Identifiers are compared case insensitively, and OCI image references match every tag and digest of the repository. Deleted versions are included, with their status.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *LookupPackageInput) (*Response[apiv0.PackageLookupResponse], error) {
+		claims, err := registry.LookupPackage(ctx, input.Identifier, input.RegistryType)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to look up package", err)
+		}
+
+		servers := make([]apiv0.PackageClaim, len(claims))
+		for i, claim := range claims {
+			servers[i] = *claim
+		}
+
+		return &Response[apiv0.PackageLookupResponse]{
+			Body: apiv0.PackageLookupResponse{
+				Servers:  servers,
+				Metadata: apiv0.Metadata{Count: len(servers)},
+			},
+		}, nil
+	})
+}
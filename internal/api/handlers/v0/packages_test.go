@@ -0,0 +1,119 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestLookupPackageEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	publish := func(name, version string, packages ...model.Package) {
+		t.Helper()
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Test server",
+			Version:     version,
+			Packages:    packages,
+		})
+		require.NoError(t, err)
+	}
+	stdio := model.Transport{Type: "stdio"}
+	publish("io.github.acme/foo", "1.0.0", model.Package{RegistryType: "oci", Identifier: "docker.io/acme/foo:1.0.0", Transport: stdio})
+	publish("io.github.acme/foo", "2.0.0", model.Package{RegistryType: "oci", Identifier: "docker.io/acme/foo:2.0.0", Transport: stdio})
+	publish("io.github.acme/foo-npm", "1.0.0", model.Package{RegistryType: "npm", Identifier: "@acme/foo", Version: "1.0.0", Transport: stdio})
+	publish("io.github.acme/redis", "1.0.0", model.Package{RegistryType: "oci", Identifier: "docker.io/library/redis:7", Transport: stdio})
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPackageEndpoints(api, "/v0", registryService)
+
+	tests := []struct {
+		name         string
+		query        url.Values
+		expectedCode int
+		expected     []string
+	}{
+		{
+			name:         "OCI repository matches every tag",
+			query:        url.Values{"identifier": {"docker.io/acme/foo"}},
+			expectedCode: http.StatusOK,
+			expected:     []string{"io.github.acme/foo@1.0.0", "io.github.acme/foo@2.0.0"},
+		},
+		{
+			name:         "OCI reference with a tag matches the repository",
+			query:        url.Values{"identifier": {"acme/foo:3.0.0"}},
+			expectedCode: http.StatusOK,
+			expected:     []string{"io.github.acme/foo@1.0.0", "io.github.acme/foo@2.0.0"},
+		},
+		{
+			name:         "Docker Hub official image short form",
+			query:        url.Values{"identifier": {"redis"}},
+			expectedCode: http.StatusOK,
+			expected:     []string{"io.github.acme/redis@1.0.0"},
+		},
+		{
+			name:         "npm package",
+			query:        url.Values{"identifier": {"@ACME/foo"}},
+			expectedCode: http.StatusOK,
+			expected:     []string{"io.github.acme/foo-npm@1.0.0"},
+		},
+		{
+			name:         "registry type filter",
+			query:        url.Values{"identifier": {"docker.io/acme/foo"}, "registryType": {"npm"}},
+			expectedCode: http.StatusOK,
+			expected:     []string{},
+		},
+		{
+			name:         "unclaimed package",
+			query:        url.Values{"identifier": {"ghcr.io/acme/foo"}},
+			expectedCode: http.StatusOK,
+			expected:     []string{},
+		},
+		{
+			name:         "missing identifier",
+			query:        url.Values{},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/packages/lookup?"+tt.query.Encode(), nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedCode, w.Code, w.Body.String())
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+
+			var resp apiv0.PackageLookupResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			found := []string{}
+			for _, claim := range resp.Servers {
+				found = append(found, claim.ServerName+"@"+claim.Version)
+			}
+			assert.Equal(t, tt.expected, found)
+			assert.Equal(t, len(tt.expected), resp.Metadata.Count)
+		})
+	}
+}
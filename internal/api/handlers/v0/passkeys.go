@@ -0,0 +1,331 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// stepUpTokenDuration is the lifetime of the Registry JWTs issued after verifying with a passkey. They get
+// no refresh token, so admin operations need a fresh verification every few minutes.
+const stepUpTokenDuration = 5 * time.Minute
+
+// PasskeyLister looks up the passkeys of an account
+type PasskeyLister interface {
+	ListPasskeys(ctx context.Context, owner string) ([]*database.Passkey, error)
+}
+
+// PasskeyBody represents a passkey, without its key material
+type PasskeyBody struct {
+	ID         int64      `json:"id" doc:"Passkey ID" example:"7"`
+	Name       string     `json:"name" doc:"Name given to the passkey" example:"YubiKey 5C"`
+	CreatedAt  time.Time  `json:"createdAt" doc:"When the passkey was registered"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" doc:"When the passkey was last used to verify"`
+}
+
+// PasskeyListBody represents the passkeys of the caller
+type PasskeyListBody struct {
+	Passkeys []PasskeyBody `json:"passkeys" doc:"Registered passkeys, oldest first"`
+}
+
+// PasskeyOptionsBody represents the options of a WebAuthn ceremony
+type PasskeyOptionsBody struct {
+	PublicKey any `json:"publicKey" doc:"Options to pass as publicKey to navigator.credentials.create (registration) or navigator.credentials.get (verification)"`
+}
+
+// PasskeyInput represents the input of the passkey endpoints taking only a token
+type PasskeyInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token from an interactive login" required:"true"`
+}
+
+// RegisterPasskeyInput represents the input for registering a passkey
+type RegisterPasskeyInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token from an interactive login" required:"true"`
+	Body          struct {
+		Name       string         `json:"name" doc:"Name to recognize the passkey by" minLength:"1" maxLength:"100" example:"YubiKey 5C"`
+		Credential map[string]any `json:"credential" doc:"The PublicKeyCredential returned by navigator.credentials.create, as serialized by its toJSON method" required:"true"`
+	}
+}
+
+// VerifyPasskeyInput represents the input for verifying with a passkey
+type VerifyPasskeyInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token from an interactive login" required:"true"`
+	Body          struct {
+		Credential map[string]any `json:"credential" doc:"The PublicKeyCredential returned by navigator.credentials.get, as serialized by its toJSON method" required:"true"`
+	}
+}
+
+// DeletePasskeyInput represents the input for removing a passkey
+type DeletePasskeyInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token from a passkey verification" required:"true"`
+	ID            int64  `path:"id" doc:"Passkey ID" example:"7"`
+}
+
+// RegisterPasskeyEndpoints registers the passkey endpoints with a custom path prefix. Passkeys are a
+// second factor that admin operations require of the accounts that register one.
+func RegisterPasskeyEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	if cfg.WebAuthnRPID == "" {
+		return // Skip registration if passkeys are disabled
+	}
+
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-passkeys" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/auth/passkeys",
+		Summary:     "List passkeys",
+		Description: "List the passkeys registered for your account.",
+		Tags:        []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *PasskeyInput) (*Response[PasskeyListBody], error) {
+		claims, err := validatePasskeyOwner(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		passkeys, err := registry.ListPasskeys(ctx, auditActor(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list passkeys", err)
+		}
+
+		body := PasskeyListBody{Passkeys: make([]PasskeyBody, 0, len(passkeys))}
+		for _, passkey := range passkeys {
+			body.Passkeys = append(body.Passkeys, toPasskeyBody(passkey))
+		}
+		return &Response[PasskeyListBody]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "start-passkey-registration" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/passkeys/registration-options",
+		Summary:     "Start passkey registration",
+		Description: "Get the options for navigator.credentials.create to register a passkey, which must be completed within 5 minutes. " +
+			"Once your account has a passkey, adding another requires a token from verifying with an existing one.",
+		Tags: []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *PasskeyInput) (*Response[PasskeyOptionsBody], error) {
+		claims, err := validatePasskeyOwner(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if err := RequireStepUp(ctx, registry, cfg, claims); err != nil {
+			return nil, err
+		}
+
+		creation, err := registry.BeginPasskeyRegistration(ctx, auditActor(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to start passkey registration", err)
+		}
+		return &Response[PasskeyOptionsBody]{Body: PasskeyOptionsBody{PublicKey: creation.Response}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "register-passkey" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/passkeys",
+		Summary:     "Register passkey",
+		Description: "Complete a passkey registration with the credential created by navigator.credentials.create.",
+		Tags:        []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RegisterPasskeyInput) (*Response[PasskeyBody], error) {
+		claims, err := validatePasskeyOwner(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if err := RequireStepUp(ctx, registry, cfg, claims); err != nil {
+			return nil, err
+		}
+
+		credential, err := json.Marshal(input.Body.Credential)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid credential", err)
+		}
+		passkey, err := registry.FinishPasskeyRegistration(ctx, auditActor(claims), input.Body.Name, credential)
+		if err != nil {
+			return nil, passkeyError(err, "Failed to register passkey")
+		}
+
+		return &Response[PasskeyBody]{Body: toPasskeyBody(passkey)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-passkey" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/auth/passkeys/{id}",
+		Summary:     "Remove passkey",
+		Description: "Remove one of your passkeys. This requires a token from verifying with a passkey. Removing the last one turns off passkey verification for your account.",
+		Tags:        []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *DeletePasskeyInput) (*struct{}, error) {
+		claims, err := validatePasskeyOwner(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if err := RequireStepUp(ctx, registry, cfg, claims); err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeletePasskey(ctx, auditActor(claims), input.ID); err != nil {
+			return nil, passkeyError(err, "Failed to remove passkey")
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "start-passkey-verification" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/passkeys/verification-options",
+		Summary:     "Start passkey verification",
+		Description: "Get the options for navigator.credentials.get to verify with one of your passkeys, which must be completed within 5 minutes.",
+		Tags:        []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *PasskeyInput) (*Response[PasskeyOptionsBody], error) {
+		claims, err := validatePasskeyOwner(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		assertion, err := registry.BeginPasskeyVerification(ctx, auditActor(claims))
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("You have no passkeys to verify with")
+			}
+			return nil, huma.Error500InternalServerError("Failed to start passkey verification", err)
+		}
+		return &Response[PasskeyOptionsBody]{Body: PasskeyOptionsBody{PublicKey: assertion.Response}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "verify-passkey" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/passkeys/verify",
+		Summary:     "Verify with passkey",
+		Description: "Complete a passkey verification with the credential returned by navigator.credentials.get, and get a Registry JWT with the same identity and permissions that can perform admin operations. " +
+			"It expires after 5 minutes and comes without a refresh token.",
+		Tags: []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *VerifyPasskeyInput) (*Response[auth.TokenResponse], error) {
+		claims, err := validatePasskeyOwner(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		credential, err := json.Marshal(input.Body.Credential)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid credential", err)
+		}
+		if err := registry.FinishPasskeyVerification(ctx, auditActor(claims), credential); err != nil {
+			return nil, passkeyError(err, "Failed to verify passkey")
+		}
+
+		response, err := jwtManager.GenerateTokenResponse(ctx, auth.JWTClaims{
+			RegisteredClaims:  jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(stepUpTokenDuration))},
+			AuthMethod:        claims.AuthMethod,
+			AuthMethodSubject: claims.AuthMethodSubject,
+			Permissions:       claims.Permissions,
+			StepUp:            true,
+		})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to generate Registry JWT", err)
+		}
+		return &Response[auth.TokenResponse]{Body: *response}, nil
+	})
+}
+
+// validatePasskeyOwner validates the Registry JWT of a request to manage or verify with passkeys. Tokens
+// obtained from an API token cannot, as passkeys are verified in a browser.
+func validatePasskeyOwner(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	claims, err := validateBearerToken(ctx, jwtManager, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	if claims.AuthMethod == auth.MethodAPIToken {
+		return nil, huma.Error403Forbidden("API tokens cannot be used with passkeys. Log in interactively instead")
+	}
+	return claims, nil
+}
+
+// NeedsStepUp reports whether permissions include a global or admin one, which accounts with passkeys may
+// only use with a token from verifying with one
+func NeedsStepUp(permissions []auth.Permission) bool {
+	return slices.ContainsFunc(permissions, func(permission auth.Permission) bool {
+		return permission.ResourcePattern == "*" || permission.Action == auth.PermissionActionAdmin
+	})
+}
+
+// RequireStepUp returns an error unless the token was issued after verifying with a passkey or its account
+// has no passkeys, so a stolen token alone cannot be used for admin operations. Accounts are identified as
+// in the audit log; tokens obtained from an API token belong to the API token's owner.
+func RequireStepUp(ctx context.Context, passkeys PasskeyLister, cfg *config.Config, claims *auth.JWTClaims) error {
+	if cfg.WebAuthnRPID == "" || claims.StepUp {
+		return nil
+	}
+
+	owner := auditActor(claims)
+	if claims.AuthMethod == auth.MethodAPIToken {
+		owner = claims.AuthMethodSubject
+	}
+	registered, err := passkeys.ListPasskeys(ctx, owner)
+	if err != nil {
+		return huma.Error500InternalServerError("Failed to check passkeys", err)
+	}
+	if len(registered) == 0 {
+		return nil
+	}
+
+	if claims.AuthMethod == auth.MethodAPIToken {
+		return huma.Error403Forbidden("Your account requires passkey verification for this operation, which API tokens cannot do. Log in interactively instead")
+	}
+	return huma.Error403Forbidden("Your account requires passkey verification for this operation. Verify with one of your passkeys at /auth/passkeys/verify and retry with the token it returns")
+}
+
+// passkeyError maps service errors to HTTP errors
+func passkeyError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Passkey not found")
+	case errors.Is(err, database.ErrAlreadyExists):
+		return huma.Error409Conflict("This passkey is already registered")
+	case errors.Is(err, database.ErrPasskeyNotVerified):
+		return huma.Error401Unauthorized(err.Error())
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	default:
+		return huma.Error500InternalServerError(msg, err)
+	}
+}
+
+func toPasskeyBody(passkey *database.Passkey) PasskeyBody {
+	return PasskeyBody{
+		ID:         passkey.ID,
+		Name:       passkey.Name,
+		CreatedAt:  passkey.CreatedAt,
+		LastUsedAt: passkey.LastUsedAt,
+	}
+}
@@ -0,0 +1,98 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+func TestPasskeyEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey: hex.EncodeToString(testSeed),
+		WebAuthnRPID:  "registry.example.com",
+		PublicURL:     "https://registry.example.com",
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPasskeyEndpoints(api, "/v0", registryService, cfg)
+
+	alice, err := generateTestJWTToken(cfg, auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "alice"})
+	require.NoError(t, err)
+	do := func(t *testing.T, method, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		reader := bytes.NewReader(nil)
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("registration options are for the relying party", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/auth/passkeys/registration-options", alice, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body struct {
+			PublicKey struct {
+				RP        struct{ ID string } `json:"rp"`
+				Challenge string              `json:"challenge"`
+			} `json:"publicKey"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+		assert.Equal(t, "registry.example.com", body.PublicKey.RP.ID)
+		assert.NotEmpty(t, body.PublicKey.Challenge)
+	})
+
+	t.Run("responses must answer an outstanding challenge", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/auth/passkeys", alice, map[string]any{"name": "YubiKey", "credential": map[string]any{"id": "x"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = do(t, http.MethodPost, "/v0/auth/passkeys/verify", alice, map[string]any{"credential": map[string]any{"id": "x"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("accounts without passkeys have nothing to verify with", func(t *testing.T) {
+		w := do(t, http.MethodGet, "/v0/auth/passkeys", alice, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.PasskeyListBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+		assert.Empty(t, list.Passkeys)
+
+		w = do(t, http.MethodPost, "/v0/auth/passkeys/verification-options", alice, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("API tokens cannot use passkeys", func(t *testing.T) {
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{AuthMethod: auth.MethodAPIToken, AuthMethodSubject: "github-at:alice"})
+		require.NoError(t, err)
+		w := do(t, http.MethodPost, "/v0/auth/passkeys/registration-options", token, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,344 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	"github.com/modelcontextprotocol/registry/internal/policy"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+const (
+	probeStatusOK       = "ok"
+	probeStatusDegraded = "degraded"
+	probeStatusFail     = "fail"
+
+	probeCheckTimeout = 3 * time.Second
+)
+
+// DependencyChecker reports on the state of the registry's backing services
+type DependencyChecker interface {
+	Ping(ctx context.Context) error
+	PendingMigrations(ctx context.Context) ([]string, error)
+}
+
+// ProbeCheck represents the status of a single dependency
+type ProbeCheck struct {
+	Status      string     `json:"status" enum:"ok,degraded,fail" doc:"Dependency status. External services are degraded rather than failed, as the registry can serve reads without them." example:"ok"`
+	Error       string     `json:"error,omitempty" doc:"Reason the check failed"`
+	LatencyMs   float64    `json:"latencyMs,omitempty" doc:"How long the check took, or for external services the mean latency of recent requests, in milliseconds" example:"1.2"`
+	LastError   string     `json:"lastError,omitempty" doc:"Most recent failure of the dependency, which may have since recovered"`
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty" doc:"When the dependency last failed"`
+}
+
+// ProbeBody represents the response body of the liveness, readiness and startup probes
+type ProbeBody struct {
+	Status string                `json:"status" enum:"ok,degraded,fail" doc:"Overall probe status. Degraded when an external service is failing; the registry stays ready." example:"ok"`
+	Checks map[string]ProbeCheck `json:"checks,omitempty" doc:"Status of each dependency"`
+}
+
+// probeFailure is the last failure of a check
+type probeFailure struct {
+	err string
+	at  time.Time
+}
+
+// probeHistory remembers the last failure of each check, so a recovered dependency still shows
+// what went wrong
+type probeHistory struct {
+	mu       sync.Mutex
+	failures map[string]probeFailure
+}
+
+// record adds the last failure of name to check, after remembering check's own failure
+func (h *probeHistory) record(name string, check ProbeCheck) ProbeCheck {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if check.Error != "" {
+		h.failures[name] = probeFailure{err: check.Error, at: time.Now().UTC()}
+	}
+	if failure, ok := h.failures[name]; ok {
+		check.LastError = failure.err
+		check.LastErrorAt = &failure.at
+	}
+	return check
+}
+
+// ProbeOutput allows probes to return 503 along with the dependency statuses
+type ProbeOutput struct {
+	Status int
+	Body   ProbeBody
+}
+
+// RegisterProbeEndpoints registers the Kubernetes liveness, readiness and startup probes. When dependencies
+// is set, the readiness checks also report the recent health of the auth issuers and package registries
+// the registry calls.
+func RegisterProbeEndpoints(api huma.API, cfg *config.Config, checker DependencyChecker, dependencies *telemetry.DependencyTracker) {
+	probes := &probeRunner{
+		cfg:          cfg,
+		checker:      checker,
+		dependencies: dependencies,
+		authIssuers:  authIssuerDependencies(cfg),
+		history:      &probeHistory{failures: make(map[string]probeFailure)},
+	}
+
+	// Liveness: the process is up and serving requests
+	huma.Register(api, huma.Operation{
+		OperationID: "get-healthz",
+		Method:      http.MethodGet,
+		Path:        "/healthz",
+		Summary:     "Liveness probe",
+		Description: "Reports whether the registry process is alive. Does not check dependencies.",
+		Tags:        []string{"health"},
+	}, func(_ context.Context, _ *struct{}) (*ProbeOutput, error) {
+		return &ProbeOutput{
+			Status: http.StatusOK,
+			Body:   ProbeBody{Status: probeStatusOK},
+		}, nil
+	})
+
+	// Readiness: all dependencies are available, so traffic can be routed here
+	huma.Register(api, huma.Operation{
+		OperationID: "get-readyz",
+		Method:      http.MethodGet,
+		Path:        "/readyz",
+		Summary:     "Readiness probe",
+		Description: "Reports whether the registry can serve traffic: the database is reachable, all migrations are applied and auth providers are configured. Returns 503 if any check fails. " +
+			"Also reports the recent health of auth issuers and package registries, which degrade the status without failing it, with the latency and last error of each dependency.",
+		Tags: []string{"health"},
+	}, func(ctx context.Context, _ *struct{}) (*ProbeOutput, error) {
+		return probes.run(ctx), nil
+	})
+
+	// Startup: runs the readiness checks until they pass once, then always succeeds
+	var started atomic.Bool
+	huma.Register(api, huma.Operation{
+		OperationID: "get-startupz",
+		Method:      http.MethodGet,
+		Path:        "/startupz",
+		Summary:     "Startup probe",
+		Description: "Reports whether the registry has finished starting up. Runs the readiness checks until they succeed once.",
+		Tags:        []string{"health"},
+	}, func(ctx context.Context, _ *struct{}) (*ProbeOutput, error) {
+		if started.Load() {
+			return &ProbeOutput{
+				Status: http.StatusOK,
+				Body:   ProbeBody{Status: probeStatusOK},
+			}, nil
+		}
+
+		output := probes.run(ctx)
+		if output.Status == http.StatusOK {
+			started.Store(true)
+		}
+		return output, nil
+	})
+}
+
+// probeRunner runs the readiness checks
+type probeRunner struct {
+	cfg          *config.Config
+	checker      DependencyChecker
+	dependencies *telemetry.DependencyTracker
+	authIssuers  []string
+	history      *probeHistory
+}
+
+// run checks every dependency and builds the probe response
+func (p *probeRunner) run(ctx context.Context) *ProbeOutput {
+	ctx, cancel := context.WithTimeout(ctx, probeCheckTimeout)
+	defer cancel()
+
+	checks := map[string]ProbeCheck{
+		"database":   timeProbeCheck(func() error { return checkDatabase(ctx, p.checker) }),
+		"migrations": timeProbeCheck(func() error { return checkMigrations(ctx, p.checker) }),
+		"auth":       toProbeCheck(checkAuthConfig(p.cfg)),
+	}
+	for name, check := range checks {
+		checks[name] = p.history.record(name, check)
+	}
+	if p.dependencies != nil {
+		snapshot := p.dependencies.Snapshot()
+		checks["auth_issuers"] = checkExternalServices(snapshot, p.authIssuers)
+		checks["package_registries"] = checkExternalServices(snapshot, telemetry.PackageRegistryDependencies)
+	}
+
+	output := &ProbeOutput{
+		Status: http.StatusOK,
+		Body:   ProbeBody{Status: probeStatusOK, Checks: checks},
+	}
+	for _, check := range checks {
+		switch check.Status {
+		case probeStatusFail:
+			output.Status = http.StatusServiceUnavailable
+			output.Body.Status = probeStatusFail
+		case probeStatusDegraded:
+			if output.Body.Status == probeStatusOK {
+				output.Body.Status = probeStatusDegraded
+			}
+		}
+	}
+
+	return output
+}
+
+// authIssuerDependencies returns the names of the dependencies that logins are verified with: the
+// GitHub API, GitHub Actions, the GitLab instance and the configured OIDC issuers
+func authIssuerDependencies(cfg *config.Config) []string {
+	names := []string{telemetry.DependencyName("api.github.com"), telemetry.DependencyName("token.actions.githubusercontent.com")}
+	urls := []string{cfg.GitLabBaseURL}
+	// An invalid issuer configuration is reported by the auth check
+	if issuers, err := cfg.OIDCIssuerConfigs(); err == nil {
+		for _, issuer := range issuers {
+			urls = append(urls, issuer.Issuer)
+		}
+	}
+	for _, rawURL := range urls {
+		if u, err := url.Parse(rawURL); err == nil && u.Hostname() != "" {
+			names = append(names, telemetry.DependencyName(u.Hostname()))
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// checkExternalServices summarizes the recent requests to the named dependencies. It is degraded when
+// the last request to any of them failed: the registry can still serve reads, but logins or publishes
+// that need the dependency may fail.
+func checkExternalServices(snapshot []telemetry.DependencyStats, names []string) ProbeCheck {
+	check := ProbeCheck{Status: probeStatusOK}
+	var requests int64
+	var latency float64
+	var failing []string
+	for _, stats := range snapshot {
+		if !slices.Contains(names, stats.Name) {
+			continue
+		}
+		requests += stats.Requests
+		latency += stats.AvgLatencyMs * float64(stats.Requests)
+		if stats.LastFailureAt == nil {
+			continue
+		}
+		if stats.LastSuccessAt == nil || stats.LastFailureAt.After(*stats.LastSuccessAt) {
+			failing = append(failing, stats.Name)
+		}
+		if check.LastErrorAt == nil || stats.LastFailureAt.After(*check.LastErrorAt) {
+			check.LastError = stats.Name + ": " + stats.LastError
+			check.LastErrorAt = stats.LastFailureAt
+		}
+	}
+	if requests > 0 {
+		check.LatencyMs = latency / float64(requests)
+	}
+	if len(failing) > 0 {
+		check.Status = probeStatusDegraded
+		check.Error = "failing: " + strings.Join(failing, ", ")
+	}
+	return check
+}
+
+// checkDatabase fails if the database cannot be reached, logging the underlying error
+// so connection details are not exposed in the response
+func checkDatabase(ctx context.Context, checker DependencyChecker) error {
+	if err := checker.Ping(ctx); err != nil {
+		slog.ErrorContext(ctx, "Readiness check failed to reach database", logging.Err(err))
+		return errors.New("database is unreachable")
+	}
+	return nil
+}
+
+// checkMigrations fails if any database migration has not been applied
+func checkMigrations(ctx context.Context, checker DependencyChecker) error {
+	pending, err := checker.PendingMigrations(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Readiness check failed to query migrations", logging.Err(err))
+		return errors.New("unable to determine migration status")
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("pending migrations: %s", strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// checkAuthConfig fails if an enabled auth provider is missing required configuration
+func checkAuthConfig(cfg *config.Config) error {
+	if err := auth.CheckSigningKeys(cfg); err != nil {
+		return err
+	}
+
+	if cfg.GithubClientID != "" && cfg.GithubClientSecret == "" {
+		return errors.New("GitHub client ID is set but client secret is missing")
+	}
+
+	if cfg.GitHubAppID != 0 && cfg.GitHubAppPrivateKey == "" {
+		return errors.New("GitHub App ID is set but private key is missing")
+	}
+
+	if _, err := cfg.RequiredGitHubOrgRole(); err != nil {
+		return err
+	}
+
+	if _, err := cfg.RequiredGitHubRepoPermission(); err != nil {
+		return err
+	}
+
+	if _, err := cfg.PasskeyOrigins(); err != nil {
+		return err
+	}
+
+	if _, err := cfg.AnonymousNamespacePatterns(); err != nil {
+		return err
+	}
+
+	if _, err := cfg.EntraGroupMappings(); err != nil {
+		return err
+	}
+
+	if _, err := cfg.OIDCIssuerConfigs(); err != nil {
+		return err
+	}
+
+	if _, err := policy.NewPublishPolicies(cfg); err != nil {
+		return err
+	}
+
+	if _, err := cfg.MTLSClientCAs(); err != nil {
+		return err
+	}
+
+	if _, err := cfg.DNSResolverAddresses(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// timeProbeCheck runs check and records how long it took
+func timeProbeCheck(check func() error) ProbeCheck {
+	start := time.Now()
+	result := toProbeCheck(check())
+	result.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	return result
+}
+
+func toProbeCheck(err error) ProbeCheck {
+	if err != nil {
+		return ProbeCheck{Status: probeStatusFail, Error: err.Error()}
+	}
+	return ProbeCheck{Status: probeStatusOK}
+}
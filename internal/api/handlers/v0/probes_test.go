@@ -0,0 +1,198 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+const testJWTPrivateKey = "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c"
+
+type fakeDependencyChecker struct {
+	pingErr error
+	pending []string
+}
+
+func (f *fakeDependencyChecker) Ping(_ context.Context) error {
+	return f.pingErr
+}
+
+func (f *fakeDependencyChecker) PendingMigrations(_ context.Context) ([]string, error) {
+	return f.pending, nil
+}
+
+func serveProbe(t *testing.T, mux *http.ServeMux, path string) (int, v0.ProbeBody) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	var body v0.ProbeBody
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return w.Code, body
+}
+
+func TestProbeEndpoints(t *testing.T) {
+	testCases := []struct {
+		name           string
+		config         *config.Config
+		checker        *fakeDependencyChecker
+		expectedStatus int
+		failingCheck   string
+	}{
+		{
+			name:           "all dependencies healthy",
+			config:         &config.Config{JWTPrivateKey: testJWTPrivateKey},
+			checker:        &fakeDependencyChecker{},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "database unreachable",
+			config:         &config.Config{JWTPrivateKey: testJWTPrivateKey},
+			checker:        &fakeDependencyChecker{pingErr: errors.New("connection refused")},
+			expectedStatus: http.StatusServiceUnavailable,
+			failingCheck:   "database",
+		},
+		{
+			name:           "pending migrations",
+			config:         &config.Config{JWTPrivateKey: testJWTPrivateKey},
+			checker:        &fakeDependencyChecker{pending: []string{"011_add_server_fetch_stats"}},
+			expectedStatus: http.StatusServiceUnavailable,
+			failingCheck:   "migrations",
+		},
+		{
+			name:           "missing JWT key",
+			config:         &config.Config{},
+			checker:        &fakeDependencyChecker{},
+			expectedStatus: http.StatusServiceUnavailable,
+			failingCheck:   "auth",
+		},
+		{
+			name: "OIDC enabled without issuer",
+			config: &config.Config{
+				JWTPrivateKey: testJWTPrivateKey,
+				OIDCEnabled:   true,
+				OIDCClientID:  "client",
+			},
+			checker:        &fakeDependencyChecker{},
+			expectedStatus: http.StatusServiceUnavailable,
+			failingCheck:   "auth",
+		},
+		{
+			name: "invalid additional OIDC issuers",
+			config: &config.Config{
+				JWTPrivateKey: testJWTPrivateKey,
+				OIDCIssuers:   `[{"issuer":"https://idp.example.com"}]`,
+			},
+			checker:        &fakeDependencyChecker{},
+			expectedStatus: http.StatusServiceUnavailable,
+			failingCheck:   "auth",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterProbeEndpoints(api, tc.config, tc.checker, nil)
+
+			// Liveness never depends on other services
+			status, body := serveProbe(t, mux, "/healthz")
+			assert.Equal(t, http.StatusOK, status)
+			assert.Equal(t, "ok", body.Status)
+
+			for _, path := range []string{"/readyz", "/startupz"} {
+				status, body := serveProbe(t, mux, path)
+				assert.Equal(t, tc.expectedStatus, status, path)
+				assert.Len(t, body.Checks, 3, path)
+				if tc.failingCheck != "" {
+					assert.Equal(t, "fail", body.Status, path)
+					assert.Equal(t, "fail", body.Checks[tc.failingCheck].Status, path)
+					assert.NotEmpty(t, body.Checks[tc.failingCheck].Error, path)
+				}
+			}
+		})
+	}
+}
+
+func TestStartupProbeStaysHealthyOnceStarted(t *testing.T) {
+	checker := &fakeDependencyChecker{}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterProbeEndpoints(api, &config.Config{JWTPrivateKey: testJWTPrivateKey}, checker, nil)
+
+	status, _ := serveProbe(t, mux, "/startupz")
+	require.Equal(t, http.StatusOK, status)
+
+	// A later dependency failure affects readiness but not startup
+	checker.pingErr = errors.New("connection refused")
+
+	status, _ = serveProbe(t, mux, "/startupz")
+	assert.Equal(t, http.StatusOK, status)
+
+	status, _ = serveProbe(t, mux, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+func TestReadinessProbeReportsDependencyHealth(t *testing.T) {
+	tracker := telemetry.NewDependencyTracker()
+	tracker.Record("npm", 40*time.Millisecond, "")
+	tracker.Record("npm", 60*time.Millisecond, "503 Service Unavailable")
+	tracker.Record("github-api", 100*time.Millisecond, "429 Too Many Requests")
+	tracker.Record("github-api", 80*time.Millisecond, "")
+	tracker.Record("idp.example.com", 30*time.Millisecond, "")
+
+	checker := &fakeDependencyChecker{}
+	cfg := &config.Config{
+		JWTPrivateKey: testJWTPrivateKey,
+		OIDCIssuers:   `[{"issuer":"https://idp.example.com","client_id":"registry","admin_permissions":["*"]}]`,
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterProbeEndpoints(api, cfg, checker, tracker)
+
+	status, body := serveProbe(t, mux, "/readyz")
+	require.Equal(t, http.StatusOK, status, "failing external services do not take the registry out of service")
+	assert.Equal(t, "degraded", body.Status)
+	assert.Len(t, body.Checks, 5)
+
+	registries := body.Checks["package_registries"]
+	assert.Equal(t, "degraded", registries.Status)
+	assert.Equal(t, "failing: npm", registries.Error)
+	assert.Equal(t, "npm: 503 Service Unavailable", registries.LastError)
+	assert.InDelta(t, 50, registries.LatencyMs, 0)
+
+	issuers := body.Checks["auth_issuers"]
+	assert.Equal(t, "ok", issuers.Status, "GitHub has recovered")
+	assert.Equal(t, "github-api: 429 Too Many Requests", issuers.LastError)
+	assert.InDelta(t, 70, issuers.LatencyMs, 0, "requests to configured OIDC issuers are included")
+
+	// A recovered database still shows its last failure
+	checker.pingErr = errors.New("connection refused")
+	status, body = serveProbe(t, mux, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "fail", body.Status)
+
+	checker.pingErr = nil
+	status, body = serveProbe(t, mux, "/readyz")
+	assert.Equal(t, http.StatusOK, status)
+	database := body.Checks["database"]
+	assert.Equal(t, "ok", database.Status)
+	assert.Empty(t, database.Error)
+	assert.Equal(t, "database is unreachable", database.LastError)
+	assert.NotNil(t, database.LastErrorAt)
+}
@@ -2,70 +2,211 @@ package v0
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	"github.com/modelcontextprotocol/registry/internal/policy"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 // PublishServerInput represents the input for publishing a server
 type PublishServerInput struct {
-	Authorization string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"true"`
-	Body          apiv0.ServerJSON `body:""`
+	Authorization  string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"true"`
+	DryRun         bool             `query:"dryRun" doc:"Run all publish checks and report every error and warning without publishing" default:"false"`
+	IdempotencyKey string           `header:"Idempotency-Key" doc:"Unique key for this publish, such as a UUID. Retries with the same key and body within 24 hours return the original response instead of publishing again" maxLength:"255"`
+	Signature      string           `header:"Server-Signature" doc:"Publisher's signature of the canonical server.json, as k=<ed25519|ecdsap384>; p=<base64 public key>; s=<base64 signature>. Served in the registry metadata so clients can check it against the namespace's key" maxLength:"1024"`
+	Body           apiv0.ServerJSON `body:""`
+}
+
+// PublishServerOutput represents the publish response
+type PublishServerOutput struct {
+	IdempotentReplayed string `header:"Idempotent-Replayed" doc:"Set to true when the response is replayed from an earlier request with the same Idempotency-Key"`
+	Body               apiv0.PublishResponse
 }
 
 // RegisterPublishEndpoint registers the publish endpoint with a custom path prefix
-func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config, opts ...PublishOption) {
 	// Create JWT manager for token validation
 	jwtManager := auth.NewJWTManager(cfg)
 
+	policies, err := policy.NewPublishPolicies(cfg)
+	if err != nil {
+		panic(err)
+	}
+	requiredRepoPermission, err := cfg.RequiredGitHubRepoPermission()
+	if err != nil {
+		panic(err)
+	}
+	options := &publishOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	huma.Register(api, huma.Operation{
 		OperationID: "publish-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
 		Method:      http.MethodPost,
 		Path:        pathPrefix + "/publish",
 		Summary:     "Publish MCP server",
-		Description: "Publish a new MCP server to the registry or update an existing one",
+		Description: "Publish a new MCP server to the registry or update an existing one. With dryRun=true, nothing is persisted: the response always has status 200 and shows the server as it would be published along with a validation block listing every error and warning.",
 		Tags:        []string{"publish"},
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
-	}, func(ctx context.Context, input *PublishServerInput) (*Response[apiv0.ServerResponse], error) {
+	}, func(ctx context.Context, input *PublishServerInput) (*PublishServerOutput, error) {
 		// Extract bearer token
-		const bearerPrefix = "Bearer "
-		authHeader := input.Authorization
-		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		token, ok := auth.BearerToken(input.Authorization)
+		if !ok {
 			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
 		}
-		token := authHeader[len(bearerPrefix):]
 
 		// Validate Registry JWT token
 		claims, err := jwtManager.ValidateToken(ctx, token)
 		if err != nil {
 			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
 		}
+		ctx = logging.With(ctx, logging.ServerNameKey, input.Body.Name)
+
+		// Organization members and delegates publish with the permissions shared with them
+		if err := addSharedPermissions(ctx, registry, claims); err != nil {
+			return nil, err
+		}
+
+		// A signature that does not match the server.json being published is never stored
+		var signature *apiv0.ServerSignature
+		var signatureErr error
+		if input.Signature != "" {
+			signature, signatureErr = verifyPublishSignature(input.Signature, &input.Body)
+		}
 
 		// Verify that the token has permission to publish the server
-		if !jwtManager.HasPermission(input.Body.Name, auth.PermissionActionPublish, claims.Permissions) {
+		hasPermission := jwtManager.HasPermission(input.Body.Name, auth.PermissionActionPublish, claims.Permissions)
+
+		// Report every problem without publishing
+		if input.DryRun {
+			result, err := registry.ValidatePublish(ctx, &input.Body)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to validate server", err)
+			}
+			var authErrors []string
+			if !hasPermission {
+				authErrors = append(authErrors, buildPermissionErrorMessage(input.Body.Name, claims.Permissions))
+			}
+			violation, err := namespaceReservationViolation(ctx, registry, jwtManager, input.Body.Name, claims)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to check namespace reservation", err)
+			}
+			if violation != "" {
+				authErrors = append(authErrors, violation)
+			}
+			violation, err = repositoryPermissionViolation(ctx, options.repoPermissions, requiredRepoPermission, claims, &input.Body)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to check GitHub repository permission", err)
+			}
+			if violation != "" {
+				authErrors = append(authErrors, violation)
+			}
+			policyViolations, err := policies.Violations(claims, &input.Body)
+			if err != nil {
+				return nil, huma.Error500InternalServerError("Failed to evaluate publish policies", err)
+			}
+			authErrors = append(authErrors, policyViolations...)
+			if signatureErr != nil {
+				authErrors = append(authErrors, signatureErr.Error())
+			}
+			if len(authErrors) > 0 {
+				result.Validation.Errors = append(authErrors, result.Validation.Errors...)
+				result.Validation.Valid = false
+			}
+			return &PublishServerOutput{
+				Body: *result,
+			}, nil
+		}
+
+		if !hasPermission {
 			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(input.Body.Name, claims.Permissions))
 		}
+		if err := checkNamespaceReservation(ctx, registry, jwtManager, input.Body.Name, claims); err != nil {
+			return nil, err
+		}
+		violation, err := repositoryPermissionViolation(ctx, options.repoPermissions, requiredRepoPermission, claims, &input.Body)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to check GitHub repository permission", err)
+		}
+		if violation != "" {
+			return nil, huma.Error403Forbidden(violation)
+		}
+		if err := checkPublishPolicies(policies, claims, &input.Body); err != nil {
+			return nil, err
+		}
+		if signatureErr != nil {
+			return nil, huma.Error400BadRequest(signatureErr.Error())
+		}
 
-		// Publish the server with extensions
-		publishedServer, err := registry.CreateServer(ctx, &input.Body)
+		// Publish the server with extensions, at most once per idempotency key
+		if input.IdempotencyKey != "" {
+			publishedServer, replayed, err := registry.CreateServerIdempotent(ctx, &input.Body, signature, auditActor(claims), input.IdempotencyKey)
+			switch {
+			case errors.Is(err, database.ErrIdempotencyKeyInUse):
+				return nil, huma.Error409Conflict("A request with this Idempotency-Key is still in progress. Retry once it has finished.")
+			case errors.Is(err, database.ErrIdempotencyKeyMismatch):
+				return nil, huma.Error422UnprocessableEntity("This Idempotency-Key has already been used for a different request")
+			case err != nil:
+				return nil, huma.Error400BadRequest("Failed to publish server", err)
+			}
+
+			output := &PublishServerOutput{Body: apiv0.PublishResponse{ServerResponse: *publishedServer}}
+			if replayed {
+				output.IdempotentReplayed = "true"
+			}
+			return output, nil
+		}
+
+		publishedServer, err := registry.CreateSignedServer(ctx, &input.Body, signature)
 		if err != nil {
 			return nil, huma.Error400BadRequest("Failed to publish server", err)
 		}
 
 		// Return the published server response with metadata
-		return &Response[apiv0.ServerResponse]{
-			Body: *publishedServer,
+		return &PublishServerOutput{
+			Body: apiv0.PublishResponse{ServerResponse: *publishedServer},
 		}, nil
 	})
 }
 
+// checkPublishPolicies fails with 403 if a publish policy does not allow the token to publish server.
+// Edits and transfers are checked too, so that they cannot produce a server that could not be published.
+func checkPublishPolicies(policies *policy.PublishPolicies, claims *auth.JWTClaims, server *apiv0.ServerJSON) error {
+	violations, err := policies.Violations(claims, server)
+	if err != nil {
+		return huma.Error500InternalServerError("Failed to evaluate publish policies", err)
+	}
+	if len(violations) > 0 {
+		return huma.Error403Forbidden(strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// verifyPublishSignature parses the Server-Signature header of a publish request and checks it is a
+// signature of the server.json being published
+func verifyPublishSignature(header string, server *apiv0.ServerJSON) (*apiv0.ServerSignature, error) {
+	signature, err := apiv0.ParseServerSignature(header)
+	if err != nil {
+		return nil, err
+	}
+	if err := signature.Verify(server); err != nil {
+		return nil, fmt.Errorf("invalid %s header: %w", apiv0.ServerSignatureHeader, err)
+	}
+	return signature, nil
+}
+
 // buildPermissionErrorMessage creates a detailed error message showing what permissions
 // the user has and what they're trying to publish
 func buildPermissionErrorMessage(attemptedResource string, permissions []auth.Permission) string {
@@ -5,10 +5,12 @@ import (
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -422,7 +424,350 @@ func TestPublishEndpoint(t *testing.T) {
 	}
 }
 
+func TestPublishEndpointDryRun(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false, // Disable for unit tests
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	_, err = registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.example/existing",
+		Description: "Already published",
+		Version:     "2.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodGitHubAT,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.example/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	dryRun := func(t *testing.T, server apiv0.ServerJSON) apiv0.PublishResponse {
+		t.Helper()
+		body, err := json.Marshal(server)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish?dryRun=true", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var resp apiv0.PublishResponse
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		require.NotNil(t, resp.Validation)
+		return resp
+	}
+
+	t.Run("valid server is not persisted", func(t *testing.T) {
+		resp := dryRun(t, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.example/new-server",
+			Description: "A new server",
+			Version:     "1.0.0",
+		})
+		assert.True(t, resp.Validation.Valid)
+		assert.Empty(t, resp.Validation.Errors)
+		assert.True(t, resp.Meta.Official.IsLatest)
+
+		_, err := registryService.GetServerByName(context.Background(), "io.github.example/new-server")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("reports every error", func(t *testing.T) {
+		resp := dryRun(t, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.other/server",
+			Description: "Invalid server",
+			Version:     "^1.0.0",
+			WebsiteURL:  "not a url",
+		})
+		assert.False(t, resp.Validation.Valid)
+		require.Len(t, resp.Validation.Errors, 4)
+		assert.Contains(t, resp.Validation.Errors[0], "You do not have permission to publish this server")
+	})
+
+	t.Run("warns when the version will not be latest", func(t *testing.T) {
+		resp := dryRun(t, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.example/existing",
+			Description: "Older version",
+			Version:     "1.0.0",
+		})
+		assert.True(t, resp.Validation.Valid)
+		assert.False(t, resp.Meta.Official.IsLatest)
+		assert.NotEmpty(t, resp.Validation.Warnings)
+	})
+
+	t.Run("reports duplicate versions", func(t *testing.T) {
+		resp := dryRun(t, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.example/existing",
+			Description: "Same version",
+			Version:     "2.0.0",
+		})
+		assert.False(t, resp.Validation.Valid)
+		assert.Contains(t, resp.Validation.Errors, database.ErrInvalidVersion.Error())
+	})
+}
+
 // TestPublishEndpoint_MultipleSlashesEdgeCases tests additional edge cases for multi-slash validation
+func TestPublishEndpointIdempotencyKey(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false, // Disable for unit tests
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+
+	tokenFor := func(t *testing.T, subject string) string {
+		t.Helper()
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: subject,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.example/*"},
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	publish := func(t *testing.T, token, key, version string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.example/weather",
+			Description: "Weather server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Idempotency-Key", key)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	alice, bob := tokenFor(t, "alice"), tokenFor(t, "bob")
+
+	first := publish(t, alice, "ci-run-1", "1.0.0")
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	t.Run("retry replays the original response", func(t *testing.T) {
+		retry := publish(t, alice, "ci-run-1", "1.0.0")
+		require.Equal(t, http.StatusOK, retry.Code, retry.Body.String())
+		assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+		assert.JSONEq(t, first.Body.String(), retry.Body.String())
+
+		versions, err := registryService.GetAllVersionsByServerName(context.Background(), "io.github.example/weather")
+		require.NoError(t, err)
+		assert.Len(t, versions, 1)
+	})
+
+	t.Run("key reused for a different request", func(t *testing.T) {
+		rr := publish(t, alice, "ci-run-1", "1.0.1")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("keys are scoped to the token holder", func(t *testing.T) {
+		// Bob's request is not a replay of Alice's, so it fails as a duplicate version
+		rr := publish(t, bob, "ci-run-1", "1.0.0")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("failed publish releases the key", func(t *testing.T) {
+		rr := publish(t, alice, "ci-run-2", "1.0.0")
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = publish(t, alice, "ci-run-2", "1.1.0")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Empty(t, rr.Header().Get("Idempotent-Replayed"))
+	})
+}
+
+func TestPublishEndpointSignature(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false, // Disable for unit tests
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	serverJSON := func(version string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/signed",
+			Description: "Signed server",
+			Version:     version,
+		}
+	}
+	sign := func(t *testing.T, server apiv0.ServerJSON) string {
+		t.Helper()
+		message, err := apiv0.CanonicalServerJSON(&server)
+		require.NoError(t, err)
+		signature := &apiv0.ServerSignature{
+			Algorithm: apiv0.SignatureAlgorithmEd25519,
+			PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+			Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, message)),
+		}
+		return signature.String()
+	}
+	publish := func(t *testing.T, path string, server apiv0.ServerJSON, signature string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(server)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set(apiv0.ServerSignatureHeader, signature)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("valid signature is stored and served", func(t *testing.T) {
+		signature := sign(t, serverJSON("1.0.0"))
+		rr := publish(t, "/v0/publish", serverJSON("1.0.0"), signature)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response apiv0.PublishResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.NotNil(t, response.Meta.Official.Signature)
+		assert.Equal(t, signature, response.Meta.Official.Signature.String())
+
+		stored, err := registryService.GetServerByNameAndVersion(context.Background(), "com.example/signed", "1.0.0")
+		require.NoError(t, err)
+		require.NotNil(t, stored.Meta.Official.Signature)
+		assert.Equal(t, signature, stored.Meta.Official.Signature.String())
+	})
+
+	t.Run("signature of another server.json is rejected", func(t *testing.T) {
+		rr := publish(t, "/v0/publish", serverJSON("1.0.1"), sign(t, serverJSON("1.0.2")))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "does not match server.json")
+	})
+
+	t.Run("malformed signature is rejected", func(t *testing.T) {
+		rr := publish(t, "/v0/publish", serverJSON("1.0.1"), "not a signature")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("dry run reports an invalid signature", func(t *testing.T) {
+		rr := publish(t, "/v0/publish?dryRun=true", serverJSON("1.0.1"), sign(t, serverJSON("1.0.2")))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response apiv0.PublishResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.NotNil(t, response.Validation)
+		assert.False(t, response.Validation.Valid)
+		assert.Contains(t, strings.Join(response.Validation.Errors, "\n"), "does not match server.json")
+	})
+}
+
+// staticRepoPermissions knows the permissions of users on GitHub repositories, keyed by owner/repo/user
+type staticRepoPermissions map[string]string
+
+func (p staticRepoPermissions) RepositoryPermission(_ context.Context, owner, repo, username string) (string, error) {
+	permission, ok := p[owner+"/"+repo+"/"+username]
+	if !ok {
+		return "", v0.ErrRepositoryNotVisible
+	}
+	return permission, nil
+}
+
+func TestPublishEndpointRepositoryPermission(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:        hex.EncodeToString(testSeed),
+		GitHubAppID:          4242,
+		GitHubRepoPermission: config.GitHubRepoPermissionWrite,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, testConfig, v0.WithRepositoryPermissions(staticRepoPermissions{
+		"acme/tools/maintainer": "write",
+		"acme/tools/member":     "read",
+	}))
+
+	orgPermissions := []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.acme/*"}}
+	tests := []struct {
+		name           string
+		claims         auth.JWTClaims
+		serverName     string
+		repositoryURL  string
+		expectedStatus int
+	}{
+		{name: "collaborator with write", claims: auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "maintainer", Permissions: orgPermissions}, serverName: "io.github.acme/tools", repositoryURL: "https://github.com/acme/tools", expectedStatus: http.StatusOK},
+		{name: "org member with read only", claims: auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "member", Permissions: orgPermissions}, serverName: "io.github.acme/tools-2", repositoryURL: "https://github.com/acme/tools", expectedStatus: http.StatusForbidden},
+		{name: "repository the app cannot see", claims: auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "maintainer", Permissions: orgPermissions}, serverName: "io.github.acme/other", repositoryURL: "https://github.com/acme/other", expectedStatus: http.StatusForbidden},
+		{name: "API token of a read-only member", claims: auth.JWTClaims{AuthMethod: auth.MethodAPIToken, AuthMethodSubject: "github-at:member", Permissions: orgPermissions}, serverName: "io.github.acme/tools-3", repositoryURL: "https://github.com/acme/tools", expectedStatus: http.StatusForbidden},
+		{name: "workflow of the repository", claims: auth.JWTClaims{AuthMethod: auth.MethodGitHubOIDC, AuthMethodSubject: "repo:acme/tools:ref:refs/heads/main", Permissions: orgPermissions}, serverName: "io.github.acme/tools-4", repositoryURL: "https://github.com/acme/tools", expectedStatus: http.StatusOK},
+		{name: "workflow of another repository", claims: auth.JWTClaims{AuthMethod: auth.MethodGitHubOIDC, AuthMethodSubject: "repo:acme/website:ref:refs/heads/main", Permissions: orgPermissions}, serverName: "io.github.acme/tools-5", repositoryURL: "https://github.com/acme/tools", expectedStatus: http.StatusForbidden},
+		{name: "own repository", claims: auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "alice", Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}}}, serverName: "io.github.alice/tools", repositoryURL: "https://github.com/Alice/tools", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := generateTestJWTToken(testConfig, tt.claims)
+			require.NoError(t, err)
+			body, err := json.Marshal(apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        tt.serverName,
+				Description: "A test server",
+				Version:     "1.0.0",
+				Repository:  model.Repository{URL: tt.repositoryURL, Source: "github"},
+			})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+			assert.Equal(t, tt.expectedStatus, rr.Code, rr.Body.String())
+		})
+	}
+}
+
 func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 	testSeed := make([]byte, ed25519.SeedSize)
 	_, err := rand.Read(testSeed)
@@ -0,0 +1,227 @@
+package v0
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// publisherReleaseCacheTTL is how long the latest release is served before GitHub is asked again
+const publisherReleaseCacheTTL = 10 * time.Minute
+
+// checksumsSignatureSuffix ends the name of the release signing key's signature of a checksums file
+const checksumsSignatureSuffix = ".ed25519.sig"
+
+// publisherArchivePattern matches the names goreleaser gives the mcp-publisher archives
+var publisherArchivePattern = regexp.MustCompile(`^mcp-publisher_([a-z0-9]+)_([a-z0-9]+)\.(?:tar\.gz|zip)$`)
+
+// gitHubRelease holds the fields of a GitHub release that the manifest is made from
+type gitHubRelease struct {
+	TagName     string    `json:"tag_name"`
+	HTMLURL     string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// PublisherReleases serves the manifest of the latest mcp-publisher release, made from the GitHub release
+// and the checksums goreleaser publishes and signs with it. It is cached so that update checks do not each call GitHub.
+type PublisherReleases struct {
+	repository string
+	baseURL    string
+	client     *http.Client
+
+	mu        sync.Mutex
+	cached    *apiv0.PublisherRelease
+	fetchedAt time.Time
+}
+
+// NewPublisherReleases creates the release manifest source for PUBLISHER_RELEASE_REPOSITORY, or returns nil
+// if it is not set
+func NewPublisherReleases(cfg *config.Config) *PublisherReleases {
+	if cfg.PublisherReleaseRepository == "" {
+		return nil
+	}
+	return &PublisherReleases{
+		repository: cfg.PublisherReleaseRepository,
+		baseURL:    "https://api.github.com",
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// SetBaseURL sets the GitHub API URL (used for testing)
+func (p *PublisherReleases) SetBaseURL(url string) {
+	p.baseURL = url
+}
+
+// SetClient sets the HTTP client GitHub is called with (used for testing)
+func (p *PublisherReleases) SetClient(client *http.Client) {
+	p.client = client
+}
+
+// Latest returns the manifest of the latest release. If GitHub cannot be reached, the last manifest
+// fetched is returned, so update checks keep working through GitHub outages.
+func (p *PublisherReleases) Latest(ctx context.Context) (*apiv0.PublisherRelease, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cached != nil && time.Since(p.fetchedAt) < publisherReleaseCacheTTL {
+		return p.cached, nil
+	}
+	release, err := p.fetch(ctx)
+	if err != nil {
+		if p.cached != nil {
+			slog.WarnContext(ctx, "Failed to get the latest mcp-publisher release, using the last one fetched", logging.Err(err))
+			// Avoid calling GitHub on every request while it is unavailable
+			p.fetchedAt = time.Now()
+			return p.cached, nil
+		}
+		return nil, err
+	}
+	p.cached = release
+	p.fetchedAt = time.Now()
+	return release, nil
+}
+
+// fetch reads the latest GitHub release and its checksums file
+func (p *PublisherReleases) fetch(ctx context.Context) (*apiv0.PublisherRelease, error) {
+	var release gitHubRelease
+	releaseURL := fmt.Sprintf("%s/repos/%s/releases/latest", p.baseURL, p.repository)
+	if err := p.get(ctx, releaseURL, func(body io.Reader) error {
+		return json.NewDecoder(body).Decode(&release)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get the latest release: %w", err)
+	}
+
+	manifest := &apiv0.PublisherRelease{
+		Version:     release.TagName,
+		URL:         release.HTMLURL,
+		PublishedAt: release.PublishedAt,
+		Assets:      []apiv0.PublisherReleaseAsset{},
+	}
+
+	// Downloads are only offered over HTTPS, so they cannot be swapped on the way
+	downloadURLs := map[string]string{}
+	for _, asset := range release.Assets {
+		if strings.HasPrefix(asset.BrowserDownloadURL, "https://") {
+			downloadURLs[asset.Name] = asset.BrowserDownloadURL
+		}
+	}
+
+	checksums := map[string]string{}
+	for _, asset := range release.Assets {
+		if !strings.HasSuffix(asset.Name, "checksums.txt") || downloadURLs[asset.Name] == "" {
+			continue
+		}
+		if err := p.get(ctx, asset.BrowserDownloadURL, func(body io.Reader) error {
+			return parseChecksums(body, checksums)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to get the checksums of %s: %w", release.TagName, err)
+		}
+		manifest.ChecksumsURL = asset.BrowserDownloadURL
+		manifest.ChecksumsSignatureURL = downloadURLs[asset.Name+checksumsSignatureSuffix]
+		break
+	}
+
+	for _, asset := range release.Assets {
+		match := publisherArchivePattern.FindStringSubmatch(asset.Name)
+		if match == nil || downloadURLs[asset.Name] == "" {
+			continue
+		}
+		// Archives without a checksum cannot be verified, so they are not offered
+		checksum, ok := checksums[asset.Name]
+		if !ok {
+			continue
+		}
+		manifest.Assets = append(manifest.Assets, apiv0.PublisherReleaseAsset{
+			OS:     match[1],
+			Arch:   match[2],
+			Name:   asset.Name,
+			URL:    asset.BrowserDownloadURL,
+			SHA256: checksum,
+		})
+	}
+	if len(manifest.Assets) == 0 {
+		return nil, fmt.Errorf("release %s has no mcp-publisher archives with checksums", release.TagName)
+	}
+	return manifest, nil
+}
+
+// get makes a GET request and reads its response body with read
+func (p *PublisherReleases) get(ctx context.Context, url string, read func(io.Reader) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	return read(io.LimitReader(resp.Body, 1<<20))
+}
+
+// parseChecksums reads a sha256sum file, of "<hex>  <file name>" lines, into checksums
+func parseChecksums(body io.Reader, checksums map[string]string) error {
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || len(fields[0]) != 64 {
+			continue
+		}
+		checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(checksums) == 0 {
+		return errors.New("no checksums found")
+	}
+	return nil
+}
+
+// RegisterPublisherReleaseEndpoint registers the mcp-publisher release manifest endpoint with a custom path
+// prefix, unless releases is nil
+func RegisterPublisherReleaseEndpoint(api huma.API, pathPrefix string, releases *PublisherReleases) {
+	if releases == nil {
+		return
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-publisher-release" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/publisher/release",
+		Summary:     "Get the latest mcp-publisher release",
+		Description: "Returns the version of the latest mcp-publisher release with the download URL and SHA-256 checksum of the archive of each platform, and the URLs of the release's checksums file and its signature, which 'mcp-publisher self-update' verifies downloads against.",
+		Tags:        []string{"version"},
+	}, func(ctx context.Context, _ *struct{}) (*Response[apiv0.PublisherRelease], error) {
+		release, err := releases.Latest(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get the latest mcp-publisher release", logging.Err(err))
+			return nil, huma.Error503ServiceUnavailable("The latest mcp-publisher release is unavailable")
+		}
+		return &Response[apiv0.PublisherRelease]{Body: *release}, nil
+	})
+}
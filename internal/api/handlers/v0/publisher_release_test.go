@@ -0,0 +1,120 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestPublisherReleaseEndpoint(t *testing.T) {
+	linuxChecksum := strings.Repeat("a", 64)
+	darwinChecksum := strings.Repeat("B", 64)
+
+	var releaseRequests atomic.Int32
+	failing := false
+	var github *httptest.Server
+	github = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/registry/releases/latest":
+			releaseRequests.Add(1)
+			if failing {
+				http.Error(w, "unavailable", http.StatusBadGateway)
+				return
+			}
+			asset := func(name string) map[string]string {
+				return map[string]string{"name": name, "browser_download_url": github.URL + "/download/" + name}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"tag_name":     "v1.3.0",
+				"html_url":     "https://github.com/acme/registry/releases/tag/v1.3.0",
+				"published_at": "2026-10-01T12:00:00Z",
+				"assets": []map[string]string{
+					asset("mcp-publisher_linux_amd64.tar.gz"),
+					asset("mcp-publisher_darwin_arm64.tar.gz"),
+					asset("mcp-publisher_windows_amd64.zip"), // not in the checksums file
+					{"name": "mcp-publisher_linux_arm64.tar.gz", "browser_download_url": "http://example.com/mcp-publisher_linux_arm64.tar.gz"},
+					asset("registry_linux_amd64.tar.gz"),
+					asset("registry_1.3.0_checksums.txt"),
+					asset("registry_1.3.0_checksums.txt.ed25519.sig"),
+				},
+			})
+		case "/download/registry_1.3.0_checksums.txt":
+			_, _ = fmt.Fprintf(w, "%s  mcp-publisher_linux_amd64.tar.gz\n%s  mcp-publisher_darwin_arm64.tar.gz\n%s  mcp-publisher_linux_arm64.tar.gz\n%s  registry_linux_amd64.tar.gz\n",
+				linuxChecksum, darwinChecksum, strings.Repeat("d", 64), strings.Repeat("c", 64))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer github.Close()
+
+	releases := v0.NewPublisherReleases(&config.Config{PublisherReleaseRepository: "acme/registry"})
+	releases.SetBaseURL(github.URL)
+	releases.SetClient(github.Client())
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublisherReleaseEndpoint(api, "/v0", releases)
+
+	getRelease := func() (int, apiv0.PublisherRelease) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/publisher/release", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		var release apiv0.PublisherRelease
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &release))
+		}
+		return w.Code, release
+	}
+
+	status, release := getRelease()
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "v1.3.0", release.Version)
+	assert.Equal(t, "https://github.com/acme/registry/releases/tag/v1.3.0", release.URL)
+	assert.Equal(t, []apiv0.PublisherReleaseAsset{
+		{OS: "linux", Arch: "amd64", Name: "mcp-publisher_linux_amd64.tar.gz", URL: github.URL + "/download/mcp-publisher_linux_amd64.tar.gz", SHA256: linuxChecksum},
+		{OS: "darwin", Arch: "arm64", Name: "mcp-publisher_darwin_arm64.tar.gz", URL: github.URL + "/download/mcp-publisher_darwin_arm64.tar.gz", SHA256: strings.ToLower(darwinChecksum)},
+	}, release.Assets, "archives without a checksum or an HTTPS URL and registry archives should be left out")
+	assert.Equal(t, github.URL+"/download/registry_1.3.0_checksums.txt", release.ChecksumsURL)
+	assert.Equal(t, github.URL+"/download/registry_1.3.0_checksums.txt.ed25519.sig", release.ChecksumsSignatureURL)
+
+	// The release is cached, and kept if GitHub fails
+	failing = true
+	status, release = getRelease()
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "v1.3.0", release.Version)
+	assert.Equal(t, int32(1), releaseRequests.Load())
+}
+
+func TestPublisherReleaseEndpoint_Unavailable(t *testing.T) {
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusBadGateway)
+	}))
+	defer github.Close()
+
+	releases := v0.NewPublisherReleases(&config.Config{PublisherReleaseRepository: "acme/registry"})
+	releases.SetBaseURL(github.URL)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublisherReleaseEndpoint(api, "/v0", releases)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/publisher/release", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	assert.Nil(t, v0.NewPublisherReleases(&config.Config{}), "no repository should disable the endpoint")
+}
@@ -0,0 +1,76 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListPublisherServersInput represents the input for listing the servers the caller can publish
+type ListPublisherServersInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Cursor        string `query:"cursor" doc:"Pagination cursor" required:"false" example:"server-cursor-123"`
+	Limit         int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+}
+
+// RegisterPublisherServersEndpoints registers the endpoint listing the caller's servers with a custom path prefix
+func RegisterPublisherServersEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-publisher-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/publisher/servers",
+		Summary:     "List my servers",
+		Description: "List the latest version of every server the caller can publish, including those shared through organizations and namespace delegations, " +
+			"whatever their status. Sorted by name.",
+		Tags: []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListPublisherServersInput) (*Response[apiv0.ServerListResponse], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if err := addSharedPermissions(ctx, registry, claims); err != nil {
+			return nil, err
+		}
+
+		var patterns []string
+		for _, permission := range claims.Permissions {
+			if permission.Grants(auth.PermissionActionPublish) {
+				patterns = append(patterns, permission.ResourcePattern)
+			}
+		}
+		list := apiv0.ServerListResponse{Servers: []apiv0.ServerResponse{}}
+		if len(patterns) == 0 {
+			return &Response[apiv0.ServerListResponse]{Body: list}, nil
+		}
+
+		isLatest := true
+		filter := &database.ServerFilter{IsLatest: &isLatest, NamePatterns: patterns, Sort: database.SortByName}
+		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid cursor", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to list servers", err)
+		}
+
+		for _, server := range servers {
+			list.Servers = append(list.Servers, *server)
+		}
+		list.Metadata = apiv0.Metadata{NextCursor: nextCursor, Count: len(servers)}
+		return &Response[apiv0.ServerListResponse]{Body: list}, nil
+	})
+}
@@ -0,0 +1,118 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestListPublisherServersEndpoint(t *testing.T) {
+	ctx := context.Background()
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	for _, server := range []struct{ name, version string }{
+		{"io.github.alice/search", "1.0.0"},
+		{"io.github.alice/search", "1.1.0"},
+		{"io.github.alice/weather", "0.1.0"},
+		{"io.github.alice-other/tool", "1.0.0"},
+		{"com.acme/billing", "2.0.0"},
+		{"com.acme/tools-search", "1.0.0"},
+	} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        server.name,
+			Description: "Test server",
+			Version:     server.version,
+		})
+		require.NoError(t, err)
+	}
+	deprecated := string(model.StatusDeprecated)
+	_, err = registryService.UpdateServer(ctx, "io.github.alice/weather", "0.1.0", &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.alice/weather",
+		Description: "Test server",
+		Version:     "0.1.0",
+	}, &deprecated)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublisherServersEndpoints(api, "/v0", registryService, cfg)
+
+	list := func(t *testing.T, path string, permissions ...auth.Permission) apiv0.ServerListResponse {
+		t.Helper()
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "alice",
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		return response
+	}
+	names := func(response apiv0.ServerListResponse) []string {
+		var names []string
+		for _, server := range response.Servers {
+			names = append(names, server.Server.Name+"@"+server.Server.Version)
+		}
+		return names
+	}
+
+	t.Run("latest versions of the caller's servers, whatever their status", func(t *testing.T) {
+		response := list(t, "/v0/publisher/servers",
+			auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"},
+			auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.acme/tools-search"})
+		assert.Equal(t, []string{"com.acme/tools-search@1.0.0", "io.github.alice/search@1.1.0", "io.github.alice/weather@0.1.0"}, names(response))
+		assert.Equal(t, model.StatusDeprecated, response.Servers[2].Meta.Official.Status)
+	})
+
+	t.Run("pages follow the cursor", func(t *testing.T) {
+		permission := auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}
+		first := list(t, "/v0/publisher/servers?limit=1", permission)
+		require.Len(t, first.Servers, 1)
+		require.NotEmpty(t, first.Metadata.NextCursor)
+		second := list(t, "/v0/publisher/servers?limit=1&cursor="+first.Metadata.NextCursor, permission)
+		assert.Equal(t, []string{"io.github.alice/weather@0.1.0"}, names(second))
+	})
+
+	t.Run("other permissions do not list servers", func(t *testing.T) {
+		response := list(t, "/v0/publisher/servers",
+			auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "com.acme/*"})
+		assert.Empty(t, response.Servers)
+	})
+
+	t.Run("admins see every server", func(t *testing.T) {
+		response := list(t, "/v0/publisher/servers", auth.Permission{Action: auth.PermissionActionAdmin, ResourcePattern: "*"})
+		assert.Len(t, response.Servers, 5)
+	})
+}
@@ -0,0 +1,87 @@
+package v0
+
+import (
+	"sync"
+	"time"
+)
+
+// maxRateLimitKeys bounds the memory used by a rate limiter when events come from many clients at once
+const maxRateLimitKeys = 100_000
+
+// rateLimitEvictionCandidates is how many keys are looked at to find one to evict when a rate limiter is full
+const rateLimitEvictionCandidates = 16
+
+// rateLimiter allows a fixed number of events per key in each window. State is kept in
+// memory, so each replica enforces its own limit, and at most maxRateLimitKeys keys are tracked.
+type rateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu         sync.Mutex
+	windows    map[string]*rateWindow
+	lastPruned time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// newRateLimiter creates a limiter allowing limit events per key per window. A limit of zero or less disables limiting.
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{limit: limit, window: window, windows: make(map[string]*rateWindow)}
+}
+
+// Allow records an event for key. If the limit has been reached, it returns false and
+// how long until the key may try again.
+func (l *rateLimiter) Allow(key string) (bool, time.Duration) {
+	if l.limit <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		l.prune(now)
+		if !ok && len(l.windows) >= maxRateLimitKeys {
+			l.evict(now)
+		}
+		l.windows[key] = &rateWindow{start: now, count: 1}
+		return true, 0
+	}
+	if w.count >= l.limit {
+		return false, l.window - now.Sub(w.start)
+	}
+	w.count++
+	return true, 0
+}
+
+// prune drops expired windows, at most once per window, so the map doesn't grow with every client ever seen
+func (l *rateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPruned) < l.window {
+		return
+	}
+	l.lastPruned = now
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, key)
+		}
+	}
+}
+
+// evict makes room for a new key by dropping one that has not reached the limit. Only a few keys, picked
+// at random by map iteration, are looked at; if all of them have reached it the last one is dropped.
+func (l *rateLimiter) evict(now time.Time) {
+	evicted, looked := "", 0
+	for key, w := range l.windows {
+		evicted = key
+		looked++
+		if w.count < l.limit || now.Sub(w.start) >= l.window || looked == rateLimitEvictionCandidates {
+			break
+		}
+	}
+	delete(l.windows, evicted)
+}
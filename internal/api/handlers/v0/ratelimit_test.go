@@ -0,0 +1,28 @@
+package v0
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_ManyClients(t *testing.T) {
+	limiter := newRateLimiter(2, time.Hour)
+	for range 2 {
+		ok, _ := limiter.Allow("ip:192.0.2.1")
+		assert.True(t, ok)
+	}
+
+	// Once the limiter is full, new clients replace others that have not reached the limit
+	for i := range 2 * maxRateLimitKeys {
+		ok, _ := limiter.Allow("ip:2001:db8::" + strconv.FormatInt(int64(i), 16))
+		assert.True(t, ok)
+	}
+	assert.LessOrEqual(t, len(limiter.windows), maxRateLimitKeys)
+
+	ok, retryAfter := limiter.Allow("ip:192.0.2.1")
+	assert.False(t, ok, "clients at the limit are kept")
+	assert.Positive(t, retryAfter)
+}
@@ -0,0 +1,167 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ServerReadmeBody represents the README of a server
+type ServerReadmeBody struct {
+	ServerName string    `json:"serverName" doc:"Server name" example:"com.example/weather"`
+	Content    string    `json:"content" doc:"Sanitized Markdown. Raw HTML is escaped and script links are removed."`
+	UpdatedAt  time.Time `json:"updatedAt" doc:"When the README was last changed"`
+}
+
+// ServerReadmeInput represents the input for reading a server README
+type ServerReadmeInput struct {
+	ConditionalParams
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fweather"`
+}
+
+// DeleteServerReadmeInput represents the input for removing a server README
+type DeleteServerReadmeInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fweather"`
+}
+
+// SetServerReadmeInput represents the input for attaching a README to a server
+type SetServerReadmeInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fweather"`
+	Body          struct {
+		Content string `json:"content" doc:"Markdown README, at most 64 KiB" example:"# Weather\n\nGet forecasts for any city."`
+	}
+}
+
+// RegisterReadmeEndpoints registers the server README endpoints with a custom path prefix
+func RegisterReadmeEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-readme" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/readme",
+		Summary:     "Get server README",
+		Description: "Get the Markdown README of a server. The README is shared by all versions of the server.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerReadmeInput) (*CachedResponse[ServerReadmeBody], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		readme, err := registry.GetServerReadme(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("README not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server README", err)
+		}
+
+		if input.notModified(readme.UpdatedAt) {
+			return nil, huma.Status304NotModified()
+		}
+
+		return newCachedResponse(toServerReadmeBody(readme), readme.UpdatedAt), nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-readme" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/readme",
+		Summary:     "Set server README",
+		Description: "Attach a Markdown README to a published server, replacing any existing one. Requires publish permissions for the server. The README is limited to 64 KiB and is sanitized before it is stored: raw HTML is escaped and javascript:, vbscript: and data: links are removed.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetServerReadmeInput) (*Response[ServerReadmeBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		if err := addSharedPermissions(ctx, registry, claims); err != nil {
+			return nil, err
+		}
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(serverName, claims.Permissions))
+		}
+
+		readme, err := registry.SetServerReadme(ctx, serverName, input.Body.Content, auditActor(claims))
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server not found")
+			case errors.Is(err, database.ErrInvalidInput):
+				return nil, huma.Error400BadRequest(err.Error())
+			default:
+				return nil, huma.Error500InternalServerError("Failed to set server README", err)
+			}
+		}
+
+		return &Response[ServerReadmeBody]{Body: toServerReadmeBody(readme)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-server-readme" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/servers/{serverName}/readme",
+		Summary:     "Delete server README",
+		Description: "Remove the README of a server. Requires publish permissions for the server.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *DeleteServerReadmeInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		if err := addSharedPermissions(ctx, registry, claims); err != nil {
+			return nil, err
+		}
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(serverName, claims.Permissions))
+		}
+
+		if err := registry.DeleteServerReadme(ctx, serverName); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("README not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete server README", err)
+		}
+
+		return nil, nil
+	})
+}
+
+func toServerReadmeBody(readme *database.ServerReadme) ServerReadmeBody {
+	return ServerReadmeBody{
+		ServerName: readme.ServerName,
+		Content:    readme.Content,
+		UpdatedAt:  readme.UpdatedAt,
+	}
+}
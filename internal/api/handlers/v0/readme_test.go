@@ -0,0 +1,115 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServerReadmeEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	_, err = registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterReadmeEndpoints(api, "/v0", registryService, cfg)
+
+	tokenFor := func(t *testing.T, pattern string) string {
+		t.Helper()
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:        auth.MethodDNS,
+			AuthMethodSubject: "example.com",
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	do := func(t *testing.T, method, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		reader := bytes.NewReader(nil)
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	readmePath := "/v0/servers/" + url.PathEscape("com.example/weather") + "/readme"
+	owner, other := tokenFor(t, "com.example/*"), tokenFor(t, "com.other/*")
+
+	t.Run("not found before one is set", func(t *testing.T) {
+		w := do(t, http.MethodGet, readmePath, "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("requires publish permission", func(t *testing.T) {
+		w := do(t, http.MethodPut, readmePath, other, map[string]string{"content": "# Hijacked"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects oversized READMEs", func(t *testing.T) {
+		w := do(t, http.MethodPut, readmePath, owner, map[string]string{"content": strings.Repeat("a", validators.MaxReadmeSize+1)})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("stores a sanitized README", func(t *testing.T) {
+		w := do(t, http.MethodPut, readmePath, owner, map[string]string{"content": "# Weather\n\n<script>alert(1)</script>"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(t, http.MethodGet, readmePath, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Last-Modified"))
+		var readme v0.ServerReadmeBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&readme))
+		assert.Equal(t, "# Weather\n\n&lt;script>alert(1)&lt;/script>", readme.Content)
+	})
+
+	t.Run("owner deletes", func(t *testing.T) {
+		w := do(t, http.MethodDelete, readmePath, owner, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = do(t, http.MethodGet, readmePath, "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,243 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// abuseReportWindow is the period over which ABUSE_REPORT_RATE_LIMIT applies
+const abuseReportWindow = time.Hour
+
+// AbuseReportBody represents a report in the moderation queue
+type AbuseReportBody struct {
+	ID         int64      `json:"id" doc:"Report ID" example:"42"`
+	ServerName string     `json:"serverName" doc:"Reported server" example:"com.example/weather"`
+	Category   string     `json:"category" doc:"What kind of abuse was reported" enum:"malware,squatting,misleading,other"`
+	Details    string     `json:"details,omitempty" doc:"Reporter's description of the problem"`
+	Reporter   string     `json:"reporter,omitempty" doc:"Who made the report; omitted for anonymous reports" example:"github-at:someone"`
+	Status     string     `json:"status" doc:"Moderation status" enum:"open,resolved,dismissed"`
+	ResolvedBy string     `json:"resolvedBy,omitempty" doc:"Admin who closed the report"`
+	CreatedAt  time.Time  `json:"createdAt" doc:"When the report was made"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty" doc:"When the report was closed"`
+}
+
+// AbuseReportListBody represents a page of the moderation queue
+type AbuseReportListBody struct {
+	Reports []AbuseReportBody `json:"reports" doc:"Reports, oldest first"`
+}
+
+// ReportServerInput represents the input for reporting a server
+type ReportServerInput struct {
+	Authorization string `header:"Authorization" doc:"Optional Registry JWT token. Reports without one are anonymous."`
+	ForwardedFor  string `header:"X-Forwarded-For" hidden:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fweather"`
+	Body          struct {
+		Category string `json:"category" doc:"What kind of abuse is being reported" enum:"malware,squatting,misleading,other"`
+		Details  string `json:"details,omitempty" doc:"Description of the problem, with any supporting links" maxLength:"2000"`
+	}
+
+	remoteAddr string
+}
+
+// Resolve captures the client address used to rate limit anonymous reports
+func (i *ReportServerInput) Resolve(ctx huma.Context) []error {
+	i.remoteAddr = ctx.RemoteAddr()
+	return nil
+}
+
+// ListAbuseReportsInput represents the input for reading the moderation queue
+type ListAbuseReportsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Status        string `query:"status" doc:"Only return reports with this status" default:"open" enum:"open,resolved,dismissed"`
+	Limit         int    `query:"limit" doc:"Maximum number of reports to return" default:"100" minimum:"1" maximum:"500"`
+}
+
+// UpdateAbuseReportInput represents the input for closing a report
+type UpdateAbuseReportInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	ID            int64  `path:"id" doc:"Report ID" example:"42"`
+	Body          struct {
+		Status string `json:"status" doc:"Close the report as acted upon or as not warranting action" enum:"resolved,dismissed"`
+	}
+}
+
+// RegisterReportEndpoint registers the abuse report endpoint with a custom path prefix
+func RegisterReportEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	limiter := newRateLimiter(cfg.AbuseReportRateLimit, abuseReportWindow)
+	trustedProxies, err := cfg.TrustedProxyNetworks()
+	if err != nil {
+		panic(err)
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "report-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/report",
+		Summary:     "Report server",
+		Description: "Report a server for malware, namespace squatting or a misleading listing. Reports are reviewed by the registry admins. Authentication is optional; each client may make a limited number of reports per hour.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ReportServerInput) (*Response[AbuseReportBody], error) {
+		reporter := ""
+		if input.Authorization != "" {
+			claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+			if err != nil {
+				return nil, err
+			}
+			reporter = auditActor(claims)
+		}
+
+		key := reporter
+		if key == "" {
+			key = "ip:" + ClientIP(input.ForwardedFor, input.remoteAddr, trustedProxies)
+		}
+		if ok, retryAfter := limiter.Allow(key); !ok {
+			return nil, huma.ErrorWithHeaders(
+				huma.Error429TooManyRequests("Too many reports. Please try again later."),
+				http.Header{"Retry-After": []string{strconv.Itoa(int(retryAfter.Seconds()) + 1)}},
+			)
+		}
+
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		report, err := registry.ReportServer(ctx, serverName, input.Body.Category, input.Body.Details, reporter)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to record report", err)
+		}
+
+		return &Response[AbuseReportBody]{Body: toAbuseReportBody(report)}, nil
+	})
+}
+
+// RegisterReportAdminEndpoints registers the moderation queue admin endpoints with a custom path prefix
+func RegisterReportAdminEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-abuse-reports" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/reports",
+		Summary:     "List abuse reports",
+		Description: "List reports in the moderation queue, oldest first (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListAbuseReportsInput) (*Response[AbuseReportListBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin(claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have permission to review abuse reports")
+		}
+
+		reports, err := registry.ListAbuseReports(ctx, input.Status, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list abuse reports", err)
+		}
+
+		body := AbuseReportListBody{Reports: make([]AbuseReportBody, 0, len(reports))}
+		for _, report := range reports {
+			body.Reports = append(body.Reports, toAbuseReportBody(report))
+		}
+
+		return &Response[AbuseReportListBody]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-abuse-report" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/admin/reports/{id}",
+		Summary:     "Close abuse report",
+		Description: "Mark a report as resolved or dismissed (admin only). Acting on the server itself, such as deleting it, is done through the edit endpoints.",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *UpdateAbuseReportInput) (*Response[AbuseReportBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin(claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have permission to review abuse reports")
+		}
+
+		report, err := registry.UpdateAbuseReportStatus(ctx, input.ID, input.Body.Status, auditActor(claims))
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Report not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to update abuse report", err)
+		}
+
+		return &Response[AbuseReportBody]{Body: toAbuseReportBody(report)}, nil
+	})
+}
+
+// ClientIP returns the address of the client. For requests from trustedProxies, that is the
+// rightmost X-Forwarded-For entry that is not a trusted proxy itself, as entries to its left can be
+// set by the client. For requests from anywhere else, X-Forwarded-For is ignored.
+func ClientIP(forwardedFor, remoteAddr string, trustedProxies []*net.IPNet) string {
+	ip := remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		ip = host
+	}
+	if !inNetworks(ip, trustedProxies) {
+		return ip
+	}
+
+	entries := strings.Split(forwardedFor, ",")
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := strings.TrimSpace(entries[i])
+		if entry == "" {
+			continue
+		}
+		if !inNetworks(entry, trustedProxies) {
+			return entry
+		}
+		ip = entry
+	}
+	return ip
+}
+
+// inNetworks reports whether ip is an address in one of networks
+func inNetworks(ip string, networks []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && slices.ContainsFunc(networks, func(n *net.IPNet) bool { return n.Contains(parsed) })
+}
+
+func toAbuseReportBody(report *database.AbuseReport) AbuseReportBody {
+	return AbuseReportBody{
+		ID:         report.ID,
+		ServerName: report.ServerName,
+		Category:   report.Category,
+		Details:    report.Details,
+		Reporter:   report.Reporter,
+		Status:     report.Status,
+		ResolvedBy: report.ResolvedBy,
+		CreatedAt:  report.CreatedAt,
+		ResolvedAt: report.ResolvedAt,
+	}
+}
@@ -0,0 +1,156 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestReportServerEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), AbuseReportRateLimit: 2, TrustedProxies: "192.0.2.1"}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	_, err = registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterReportEndpoint(api, "/v0", registryService, cfg)
+	v0.RegisterReportAdminEndpoints(api, "/v0", registryService, cfg)
+
+	adminToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodNone,
+		AuthMethodSubject: "admin",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionEdit, ResourcePattern: "*"}},
+	})
+	require.NoError(t, err)
+
+	do := func(t *testing.T, method, path, token, clientIP string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		reader := bytes.NewReader(nil)
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if clientIP != "" {
+			req.Header.Set("X-Forwarded-For", clientIP)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	reportPath := "/v0/servers/" + url.PathEscape("com.example/weather") + "/report"
+	report := map[string]string{"category": "misleading", "details": "Claims to be the official Example server"}
+
+	t.Run("rejects unknown categories", func(t *testing.T) {
+		w := do(t, http.MethodPost, reportPath, "", "203.0.113.1", map[string]string{"category": "spam"})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("rejects reports for unknown servers", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/servers/"+url.PathEscape("com.example/missing")+"/report", "", "203.0.113.2", report)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("accepts anonymous reports up to the rate limit", func(t *testing.T) {
+		for range 2 {
+			w := do(t, http.MethodPost, reportPath, "", "203.0.113.3", report)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		}
+
+		w := do(t, http.MethodPost, reportPath, "", "203.0.113.3", report)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		// Other clients are unaffected
+		w = do(t, http.MethodPost, reportPath, "", "203.0.113.4", report)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("admins review the queue", func(t *testing.T) {
+		w := do(t, http.MethodGet, "/v0/admin/reports", "", "", nil)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+		w = do(t, http.MethodGet, "/v0/admin/reports", adminToken, "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var queue v0.AbuseReportListBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&queue))
+		require.Len(t, queue.Reports, 3)
+		assert.Empty(t, queue.Reports[0].Reporter)
+
+		w = do(t, http.MethodPut, fmt.Sprintf("/v0/admin/reports/%d", queue.Reports[0].ID), adminToken, "", map[string]string{"status": "dismissed"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var closed v0.AbuseReportBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&closed))
+		assert.Equal(t, database.ReportStatusDismissed, closed.Status)
+		assert.Equal(t, "none:admin", closed.ResolvedBy)
+
+		w = do(t, http.MethodGet, "/v0/admin/reports?status=open", adminToken, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&queue))
+		assert.Len(t, queue.Reports, 2)
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	_, proxies, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+	trustedProxies := []*net.IPNet{proxies}
+
+	tests := []struct {
+		name         string
+		forwardedFor string
+		remoteAddr   string
+		expected     string
+	}{
+		{name: "direct client", remoteAddr: "203.0.113.1:1234", expected: "203.0.113.1"},
+		{name: "untrusted client sets X-Forwarded-For", forwardedFor: "198.51.100.1", remoteAddr: "203.0.113.1:1234", expected: "203.0.113.1"},
+		{name: "client behind proxy", forwardedFor: "198.51.100.1", remoteAddr: "10.0.0.2:1234", expected: "198.51.100.1"},
+		{name: "client behind proxy sets X-Forwarded-For", forwardedFor: "192.0.2.9, 198.51.100.1", remoteAddr: "10.0.0.2:1234", expected: "198.51.100.1"},
+		{name: "client behind two proxies", forwardedFor: "198.51.100.1, 10.0.0.3", remoteAddr: "10.0.0.2:1234", expected: "198.51.100.1"},
+		{name: "proxy without X-Forwarded-For", remoteAddr: "10.0.0.2:1234", expected: "10.0.0.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, v0.ClientIP(tt.forwardedFor, tt.remoteAddr, trustedProxies))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ErrRepositoryNotVisible is returned by a RepositoryPermissionChecker that cannot see a repository,
+// typically because the GitHub App is not installed on it
+var ErrRepositoryNotVisible = errors.New("repository is not visible to the GitHub App")
+
+// RepositoryPermissionChecker looks up the permission a GitHub user has on a repository: admin, write,
+// read or none
+type RepositoryPermissionChecker interface {
+	RepositoryPermission(ctx context.Context, owner, repo, username string) (string, error)
+}
+
+// PublishOption configures the publish endpoint
+type PublishOption func(*publishOptions)
+
+type publishOptions struct {
+	repoPermissions RepositoryPermissionChecker
+}
+
+// WithRepositoryPermissions makes publishing a server linked to a GitHub repository require the permission
+// set in GITHUB_REPO_PERMISSION on the repository, looked up with checker
+func WithRepositoryPermissions(checker RepositoryPermissionChecker) PublishOption {
+	return func(o *publishOptions) {
+		o.repoPermissions = checker
+	}
+}
+
+// repositoryPermissionViolation returns why the token may not publish a server linked to its GitHub
+// repository, or "" if it may. Only GitHub identities are checked: GitHub users, their API tokens, and
+// GitHub Actions, which may only publish the repository running the workflow.
+func repositoryPermissionViolation(ctx context.Context, checker RepositoryPermissionChecker, required string, claims *auth.JWTClaims, server *apiv0.ServerJSON) (string, error) {
+	if checker == nil || required == "" || hasGlobalPermission(auth.PermissionActionPublish, claims.Permissions) {
+		return "", nil
+	}
+	owner, repo, ok := gitHubRepository(server)
+	if !ok {
+		return "", nil
+	}
+	fullName := owner + "/" + repo
+
+	var username string
+	switch claims.AuthMethod {
+	case auth.MethodGitHubOIDC:
+		// Subjects look like repo:octo-org/octo-repo:environment:prod
+		workflowRepo, _, _ := strings.Cut(strings.TrimPrefix(claims.AuthMethodSubject, "repo:"), ":")
+		if !strings.EqualFold(workflowRepo, fullName) {
+			return fmt.Sprintf("GitHub Actions can only publish servers whose repository runs the workflow; this server's repository is %s", fullName), nil
+		}
+		return "", nil
+	case auth.MethodGitHubAT:
+		username = claims.AuthMethodSubject
+	case auth.MethodAPIToken:
+		method, subject, _ := strings.Cut(claims.AuthMethodSubject, ":")
+		if auth.Method(method) != auth.MethodGitHubAT {
+			return "", nil
+		}
+		username = subject
+	default:
+		return "", nil
+	}
+
+	// Users have admin on their own repositories
+	if strings.EqualFold(owner, username) {
+		return "", nil
+	}
+
+	permission, err := checker.RepositoryPermission(ctx, owner, repo, username)
+	if errors.Is(err, ErrRepositoryNotVisible) {
+		return fmt.Sprintf("Could not verify your permission on GitHub repository %s. Install the registry's GitHub App on %s to publish servers linked to it", fullName, owner), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if !meetsRepositoryPermission(permission, required) {
+		return fmt.Sprintf("You need %s permission on GitHub repository %s to publish a server linked to it", required, fullName), nil
+	}
+	return "", nil
+}
+
+// gitHubRepository returns the owner and name of the server's GitHub repository, if it has one
+func gitHubRepository(server *apiv0.ServerJSON) (string, string, bool) {
+	if server.Repository.Source != "github" {
+		return "", "", false
+	}
+	parsed, err := url.Parse(server.Repository.URL)
+	if err != nil {
+		return "", "", false
+	}
+	owner, repo, found := strings.Cut(strings.Trim(parsed.Path, "/"), "/")
+	repo = strings.TrimSuffix(repo, ".git")
+	if !found || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", false
+	}
+	return owner, repo, true
+}
+
+// meetsRepositoryPermission reports whether a GitHub repository permission is at least the required one
+func meetsRepositoryPermission(permission, required string) bool {
+	switch required {
+	case config.GitHubRepoPermissionAdmin:
+		return permission == "admin"
+	case config.GitHubRepoPermissionWrite:
+		return permission == "admin" || permission == "write"
+	}
+	return false
+}
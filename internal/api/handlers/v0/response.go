@@ -1,11 +1,103 @@
 package v0
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
 // Response is a generic wrapper for Huma responses
 // Usage: Response[HealthBody] instead of HealthOutput
 type Response[T any] struct {
 	Body T
 }
 
+// readCacheControl is the Cache-Control policy for cacheable read endpoints
+const readCacheControl = "public, max-age=60"
+
+// CachedResponse is a Huma response with caching headers for CDNs and conditional clients
+type CachedResponse[T any] struct {
+	LastModified string   `header:"Last-Modified" doc:"When the returned resource(s) last changed"`
+	ETag         string   `header:"ETag" doc:"Validator of the returned page, on list endpoints"`
+	CacheControl string   `header:"Cache-Control"`
+	Link         []string `header:"Link" doc:"RFC 8288 pagination links (rel=first, rel=next) on paginated endpoints"`
+	Body         T
+}
+
+// ConditionalParams are the conditional request headers supported by read endpoints
+type ConditionalParams struct {
+	IfModifiedSince time.Time `header:"If-Modified-Since" required:"false" doc:"Return 304 Not Modified if the resource has not changed since this time"`
+}
+
+// ListConditionalParams are the conditional request headers supported by list endpoints. Their pages
+// are validated by ETag, as a page's Last-Modified time stays the same when a server is deleted or
+// moves to another page.
+type ListConditionalParams struct {
+	IfNoneMatch string `header:"If-None-Match" required:"false" doc:"Return 304 Not Modified if the page still has one of these ETags"`
+}
+
+// notModified reports whether the resource last modified at lastModified is unchanged
+// since the time given by the client. HTTP dates have second precision.
+func (p *ConditionalParams) notModified(lastModified time.Time) bool {
+	if p.IfModifiedSince.IsZero() || lastModified.IsZero() {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(p.IfModifiedSince)
+}
+
+// etagMatches reports whether the client's If-None-Match header lists etag, using the weak comparison
+// of RFC 9110 section 8.8.3.2
+func (p *ListConditionalParams) etagMatches(etag string) bool {
+	if p.IfNoneMatch == "" || etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(p.IfNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// contentETag returns a weak ETag of body's JSON encoding
+func contentETag(body any) string {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// newCachedResponse wraps body with Last-Modified and Cache-Control headers
+func newCachedResponse[T any](body T, lastModified time.Time) *CachedResponse[T] {
+	resp := &CachedResponse[T]{
+		CacheControl: readCacheControl,
+		Body:         body,
+	}
+	if !lastModified.IsZero() {
+		resp.LastModified = lastModified.UTC().Format(http.TimeFormat)
+	}
+	return resp
+}
+
+// lastModifiedOf returns the most recent update time among servers
+func lastModifiedOf(servers []*apiv0.ServerResponse) time.Time {
+	var latest time.Time
+	for _, server := range servers {
+		if server.Meta.Official != nil && server.Meta.Official.UpdatedAt.After(latest) {
+			latest = server.Meta.Official.UpdatedAt
+		}
+	}
+	return latest
+}
+
 // Example usage:
 // Instead of:
 //   type HealthOutput struct {
@@ -0,0 +1,69 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// RevalidateServerInput represents the input for re-running package validation
+type RevalidateServerInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"io.github.user%2Fweather"`
+	Version       string `query:"version" doc:"Server version to validate; defaults to the latest version" example:"1.0.0"`
+}
+
+// RegisterRevalidateEndpoint registers the package re-validation admin endpoint with a custom path prefix
+func RegisterRevalidateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revalidate-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/servers/{serverName}/revalidate",
+		Summary:     "Re-validate server packages",
+		Description: "Re-run the package registry validators for the latest version of a server, or the version given, and return the result for each package (admin only). " +
+			"Use this to confirm a problem has been fixed upstream, for example after a maintainer adds a missing OCI label. The server itself is not changed.",
+		Tags: []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RevalidateServerInput) (*Response[apiv0.ServerRevalidation], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isAdmin(claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have permission to re-validate servers")
+		}
+
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		result, err := registry.RevalidateServer(ctx, serverName, input.Version, auditActor(claims))
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to re-validate server", err)
+		}
+		slog.InfoContext(ctx, "Server re-validated", logging.ServerNameKey, result.ServerName, "version", result.Version, logging.ActorKey, auditActor(claims), "valid", result.Valid)
+
+		return &Response[apiv0.ServerRevalidation]{Body: *result}, nil
+	})
+}
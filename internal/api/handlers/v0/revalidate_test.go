@@ -0,0 +1,117 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestRevalidateServerEndpoint(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	// Registry validation is off for publishing, so the invalid package below can be published
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	_, err = registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	_, err = registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/weather",
+		Description: "Weather server",
+		Version:     "1.1.0",
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeMCPB,
+				Identifier:   "https://github.com/example/weather-mcp/releases/download/v1.1.0/weather.mcpb",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterRevalidateEndpoint(api, "/v1", registryService, cfg)
+
+	tokenWith := func(t *testing.T, perm auth.Permission) string {
+		t.Helper()
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:        auth.MethodNone,
+			AuthMethodSubject: "admin",
+			Permissions:       []auth.Permission{perm},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	adminToken := tokenWith(t, auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	publisherToken := tokenWith(t, auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"})
+
+	revalidate := func(t *testing.T, serverName, query, token string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/servers/"+url.PathEscape(serverName)+"/revalidate"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := revalidate(t, "com.example/weather", "", publisherToken)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("reports failing packages of the latest version", func(t *testing.T) {
+		w := revalidate(t, "com.example/weather", "", adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var result apiv0.ServerRevalidation
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+		assert.Equal(t, "1.1.0", result.Version)
+		assert.False(t, result.Valid)
+		require.Len(t, result.Packages, 1)
+		assert.False(t, result.Packages[0].Valid)
+		assert.Contains(t, result.Packages[0].Error, "fileSha256")
+	})
+
+	t.Run("validates the requested version", func(t *testing.T) {
+		w := revalidate(t, "com.example/weather", "?version=1.0.0", adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var result apiv0.ServerRevalidation
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+		assert.Equal(t, "1.0.0", result.Version)
+		assert.True(t, result.Valid)
+		assert.Empty(t, result.Packages)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		w := revalidate(t, "com.example/missing", "", adminToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -3,8 +3,10 @@ package v0
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,6 +25,10 @@ type ListServersInput struct {
 	UpdatedSince string `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
 	Search       string `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
 	Version      string `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Sort         string `query:"sort" doc:"Sort order of results. 'relevance' ranks by closeness to the search term and sorts by name without one" required:"false" enum:"name,updated,created,relevance" example:"updated"`
+	Order        string `query:"order" doc:"Sort direction (defaults to 'desc' for 'updated' and 'created', 'asc' otherwise)" required:"false" enum:"asc,desc" example:"desc"`
+	FieldsParam
+	ListConditionalParams
 }
 
 // ServerDetailInput represents the input for getting server details
@@ -34,11 +40,20 @@ type ServerDetailInput struct {
 type ServerVersionDetailInput struct {
 	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
 	Version    string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	FieldsParam
+	ConditionalParams
 }
 
 // ServerVersionsInput represents the input for listing all versions of a server
 type ServerVersionsInput struct {
 	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	FieldsParam
+	ListConditionalParams
+}
+
+// BatchGetServersInput represents the input for fetching multiple servers at once
+type BatchGetServersInput struct {
+	Body apiv0.BatchGetServersRequest
 }
 
 // RegisterServersEndpoints registers all server-related endpoints with a custom path prefix
@@ -51,7 +66,11 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Summary:     "List MCP servers",
 		Description: "Get a paginated list of MCP servers from the registry",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ListServersInput) (*Response[apiv0.ServerListResponse], error) {
+	}, func(ctx context.Context, input *ListServersInput) (*CachedResponse[apiv0.ServerListResponse], error) {
+		if _, err := parseFields(input.Fields); err != nil {
+			return nil, err
+		}
+
 		// Build filter from input parameters
 		filter := &database.ServerFilter{}
 
@@ -82,9 +101,23 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			}
 		}
 
+		// Handle sort parameters, newest first by default for time-based sorts
+		if input.Sort != "" {
+			filter.Sort = database.SortField(input.Sort)
+		}
+		switch input.Order {
+		case "desc":
+			filter.SortDesc = true
+		case "":
+			filter.SortDesc = filter.Sort == database.SortByUpdated || filter.Sort == database.SortByCreated
+		}
+
 		// Get paginated results with filtering
 		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
 		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid cursor for the requested sort order", err)
+			}
 			return nil, huma.Error500InternalServerError("Failed to get registry list", err)
 		}
 
@@ -94,13 +127,58 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			serverValues[i] = *server
 		}
 
-		return &Response[apiv0.ServerListResponse]{
-			Body: apiv0.ServerListResponse{
-				Servers: serverValues,
-				Metadata: apiv0.Metadata{
-					NextCursor: nextCursor,
-					Count:      len(servers),
-				},
+		body := apiv0.ServerListResponse{
+			Servers: serverValues,
+			Metadata: apiv0.Metadata{
+				NextCursor: nextCursor,
+				Count:      len(servers),
+			},
+		}
+		etag := contentETag(body)
+		if input.etagMatches(etag) {
+			return nil, huma.Status304NotModified()
+		}
+
+		resp := newCachedResponse(body, lastModifiedOf(servers))
+		resp.ETag = etag
+		resp.Link = listServersLinks(pathPrefix+"/servers", input, nextCursor)
+
+		return resp, nil
+	})
+
+	// Batch get servers endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "batch-get-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers:batchGet",
+		Summary:     "Get multiple MCP servers",
+		Description: "Get the latest version of up to 100 MCP servers by name in a single request. Names that don't exist are listed in notFound.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *BatchGetServersInput) (*Response[apiv0.BatchGetServersResponse], error) {
+		servers, err := registry.GetServersByNames(ctx, input.Body.Names)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get servers", err)
+		}
+
+		found := make(map[string]bool, len(servers))
+		serverValues := make([]apiv0.ServerResponse, len(servers))
+		for i, server := range servers {
+			serverValues[i] = *server
+			found[server.Server.Name] = true
+		}
+
+		notFound := []string{}
+		for _, name := range input.Body.Names {
+			if !found[name] {
+				notFound = append(notFound, name)
+				found[name] = true
+			}
+		}
+
+		return &Response[apiv0.BatchGetServersResponse]{
+			Body: apiv0.BatchGetServersResponse{
+				Servers:  serverValues,
+				NotFound: notFound,
 			},
 		}, nil
 	})
@@ -113,7 +191,11 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Summary:     "Get specific MCP server version",
 		Description: "Get detailed information about a specific version of an MCP server. Use the special version 'latest' to get the latest version.",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[apiv0.ServerResponse], error) {
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*CachedResponse[apiv0.ServerResponse], error) {
+		if _, err := parseFields(input.Fields); err != nil {
+			return nil, err
+		}
+
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -141,9 +223,12 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			return nil, huma.Error500InternalServerError("Failed to get server details", err)
 		}
 
-		return &Response[apiv0.ServerResponse]{
-			Body: *serverResponse,
-		}, nil
+		lastModified := lastModifiedOf([]*apiv0.ServerResponse{serverResponse})
+		if input.notModified(lastModified) {
+			return nil, huma.Status304NotModified()
+		}
+
+		return newCachedResponse(*serverResponse, lastModified), nil
 	})
 
 	// Get server versions endpoint
@@ -154,7 +239,11 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Summary:     "Get all versions of an MCP server",
 		Description: "Get all available versions for a specific MCP server",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ServerVersionsInput) (*Response[apiv0.ServerListResponse], error) {
+	}, func(ctx context.Context, input *ServerVersionsInput) (*CachedResponse[apiv0.ServerListResponse], error) {
+		if _, err := parseFields(input.Fields); err != nil {
+			return nil, err
+		}
+
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -176,13 +265,61 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			serverValues[i] = *server
 		}
 
-		return &Response[apiv0.ServerListResponse]{
-			Body: apiv0.ServerListResponse{
-				Servers: serverValues,
-				Metadata: apiv0.Metadata{
-					Count: len(servers),
-				},
+		body := apiv0.ServerListResponse{
+			Servers: serverValues,
+			Metadata: apiv0.Metadata{
+				Count: len(servers),
 			},
-		}, nil
+		}
+		etag := contentETag(body)
+		if input.etagMatches(etag) {
+			return nil, huma.Status304NotModified()
+		}
+
+		resp := newCachedResponse(body, lastModifiedOf(servers))
+		resp.ETag = etag
+		return resp, nil
 	})
 }
+
+// listServersLinks builds RFC 8288 pagination links for the list servers endpoint,
+// preserving the caller's filters. Cursors only page forwards, so there is no rel="prev".
+func listServersLinks(path string, input *ListServersInput, nextCursor string) []string {
+	query := url.Values{}
+	if input.Limit > 0 {
+		query.Set("limit", strconv.Itoa(input.Limit))
+	}
+	if input.UpdatedSince != "" {
+		query.Set("updated_since", input.UpdatedSince)
+	}
+	if input.Search != "" {
+		query.Set("search", input.Search)
+	}
+	if input.Version != "" {
+		query.Set("version", input.Version)
+	}
+	if input.Sort != "" {
+		query.Set("sort", input.Sort)
+	}
+	if input.Order != "" {
+		query.Set("order", input.Order)
+	}
+	if input.Fields != "" {
+		query.Set("fields", input.Fields)
+	}
+
+	links := []string{formatLink(path, query, "first")}
+	if nextCursor != "" {
+		query.Set("cursor", nextCursor)
+		links = append(links, formatLink(path, query, "next"))
+	}
+	return links
+}
+
+func formatLink(path string, query url.Values, rel string) string {
+	target := path
+	if encoded := query.Encode(); encoded != "" {
+		target += "?" + encoded
+	}
+	return fmt.Sprintf("<%s>; rel=%q", target, rel)
+}
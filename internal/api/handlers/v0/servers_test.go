@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
@@ -114,6 +116,141 @@ func TestListServersEndpoint(t *testing.T) {
 	}
 }
 
+func TestListServersEndpointLinkHeaders(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	for _, name := range []string{"com.example/link-a", "com.example/link-b"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Server for pagination link tests",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	// Create API
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	// First page has first and next links that preserve filters
+	req := httptest.NewRequest(http.MethodGet, "/v0/servers?limit=1&search=link", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp apiv0.ServerListResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.NotEmpty(t, resp.Metadata.NextCursor)
+
+	links := w.Header().Values("Link")
+	assert.Contains(t, links, `</v0/servers?limit=1&search=link>; rel="first"`)
+	assert.Contains(t, links, `</v0/servers?cursor=`+url.QueryEscape(resp.Metadata.NextCursor)+`&limit=1&search=link>; rel="next"`)
+
+	// Last page has no next link
+	req = httptest.NewRequest(http.MethodGet, "/v0/servers?limit=10&search=link", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	for _, link := range w.Header().Values("Link") {
+		assert.NotContains(t, link, `rel="next"`)
+	}
+}
+
+func TestListServersEndpointSort(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	// Publish order differs from name order
+	for _, name := range []string{"com.example/sort-a", "com.example/sort", "com.example/sort-b"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Server for sort tests",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	// Create API
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	list := func(t *testing.T, query string) (int, apiv0.ServerListResponse) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers?"+query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		var resp apiv0.ServerListResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		}
+		return w.Code, resp
+	}
+	names := func(resp apiv0.ServerListResponse) []string {
+		result := make([]string, len(resp.Servers))
+		for i, server := range resp.Servers {
+			result[i] = server.Server.Name
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{"name by default", "search=sort", []string{"com.example/sort", "com.example/sort-a", "com.example/sort-b"}},
+		{"name descending", "search=sort&sort=name&order=desc", []string{"com.example/sort-b", "com.example/sort-a", "com.example/sort"}},
+		{"created newest first by default", "search=sort&sort=created", []string{"com.example/sort-b", "com.example/sort", "com.example/sort-a"}},
+		{"created ascending", "search=sort&sort=created&order=asc", []string{"com.example/sort-a", "com.example/sort", "com.example/sort-b"}},
+		{"updated newest first by default", "search=sort&sort=updated", []string{"com.example/sort-b", "com.example/sort", "com.example/sort-a"}},
+		{"relevance ranks exact match first", "search=sort-b&sort=relevance", []string{"com.example/sort-b"}},
+		{"relevance ranks exact match before prefix matches", "search=SORT&sort=relevance", []string{"com.example/sort", "com.example/sort-a", "com.example/sort-b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, resp := list(t, tt.query)
+			require.Equal(t, http.StatusOK, status)
+			assert.Equal(t, tt.expected, names(resp))
+		})
+	}
+
+	t.Run("pages through a non-name sort", func(t *testing.T) {
+		var got []string
+		cursor := ""
+		for range 4 {
+			status, resp := list(t, "search=sort&sort=created&limit=1&cursor="+url.QueryEscape(cursor))
+			require.Equal(t, http.StatusOK, status)
+			got = append(got, names(resp)...)
+			cursor = resp.Metadata.NextCursor
+			if cursor == "" {
+				break
+			}
+		}
+		assert.Equal(t, []string{"com.example/sort-b", "com.example/sort", "com.example/sort-a"}, got)
+	})
+
+	t.Run("rejects a cursor from a different sort", func(t *testing.T) {
+		status, resp := list(t, "search=sort&sort=created&limit=1")
+		require.Equal(t, http.StatusOK, status)
+		require.NotEmpty(t, resp.Metadata.NextCursor)
+
+		status, _ = list(t, "search=sort&sort=updated&limit=1&cursor="+url.QueryEscape(resp.Metadata.NextCursor))
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("rejects an unknown sort", func(t *testing.T) {
+		status, _ := list(t, "sort=popularity")
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+	})
+}
+
 func TestGetLatestServerVersionEndpoint(t *testing.T) {
 	ctx := context.Background()
 	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
@@ -175,6 +312,85 @@ func TestGetLatestServerVersionEndpoint(t *testing.T) {
 	}
 }
 
+func TestBatchGetServersEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	// Setup test data with two versions of one server
+	for _, server := range []apiv0.ServerJSON{
+		{Schema: model.CurrentSchemaURL, Name: "com.example/batch-alpha", Description: "Alpha", Version: "1.0.0"},
+		{Schema: model.CurrentSchemaURL, Name: "com.example/batch-alpha", Description: "Alpha", Version: "1.1.0"},
+		{Schema: model.CurrentSchemaURL, Name: "com.example/batch-beta", Description: "Beta", Version: "2.0.0"},
+	} {
+		_, err := registryService.CreateServer(ctx, &server)
+		require.NoError(t, err)
+	}
+
+	// Create API
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	tests := []struct {
+		name             string
+		body             string
+		expectedStatus   int
+		expectedServers  []string
+		expectedVersions []string
+		expectedNotFound []string
+	}{
+		{
+			name:             "returns latest versions in request order",
+			body:             `{"names":["com.example/batch-beta","com.example/batch-alpha"]}`,
+			expectedStatus:   http.StatusOK,
+			expectedServers:  []string{"com.example/batch-beta", "com.example/batch-alpha"},
+			expectedVersions: []string{"2.0.0", "1.1.0"},
+			expectedNotFound: []string{},
+		},
+		{
+			name:             "reports missing and deduplicates names",
+			body:             `{"names":["com.example/batch-alpha","com.example/missing","com.example/batch-alpha","com.example/missing"]}`,
+			expectedStatus:   http.StatusOK,
+			expectedServers:  []string{"com.example/batch-alpha"},
+			expectedVersions: []string{"1.1.0"},
+			expectedNotFound: []string{"com.example/missing"},
+		},
+		{
+			name:           "rejects empty list",
+			body:           `{"names":[]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v0/servers:batchGet", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp apiv0.BatchGetServersResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+			names := make([]string, len(resp.Servers))
+			versions := make([]string, len(resp.Servers))
+			for i, server := range resp.Servers {
+				names[i] = server.Server.Name
+				versions[i] = server.Server.Version
+			}
+			assert.Equal(t, tt.expectedServers, names)
+			assert.Equal(t, tt.expectedVersions, versions)
+			assert.Equal(t, tt.expectedNotFound, resp.NotFound)
+		})
+	}
+}
+
 func TestGetServerVersionEndpoint(t *testing.T) {
 	ctx := context.Background()
 	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
@@ -392,6 +608,208 @@ func TestGetAllVersionsEndpoint(t *testing.T) {
 	}
 }
 
+func TestServersEndpointCachingHeaders(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	created, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/cached-server",
+		Description: "Server for caching tests",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	lastModified := created.Meta.Official.UpdatedAt.UTC().Format(http.TimeFormat)
+
+	// Create API
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	paths := []struct {
+		path string
+		list bool
+	}{
+		{path: "/v0/servers", list: true},
+		{path: "/v0/servers/" + url.PathEscape("com.example/cached-server") + "/versions", list: true},
+		{path: "/v0/servers/" + url.PathEscape("com.example/cached-server") + "/versions/latest"},
+	}
+
+	for _, tt := range paths {
+		t.Run(tt.path, func(t *testing.T) {
+			// GET returns caching headers
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, lastModified, w.Header().Get("Last-Modified"))
+			assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+			etag := w.Header().Get("ETag")
+
+			// HEAD is supported with the same headers
+			req = httptest.NewRequest(http.MethodHead, tt.path, nil)
+			w = httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, lastModified, w.Header().Get("Last-Modified"))
+
+			if tt.list {
+				// List pages are validated by their ETag rather than their Last-Modified time
+				require.NotEmpty(t, etag)
+
+				req = httptest.NewRequest(http.MethodGet, tt.path, nil)
+				req.Header.Set("If-None-Match", etag)
+				w = httptest.NewRecorder()
+				mux.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusNotModified, w.Code)
+
+				req = httptest.NewRequest(http.MethodGet, tt.path, nil)
+				req.Header.Set("If-None-Match", `W/"0123456789abcdef"`)
+				w = httptest.NewRecorder()
+				mux.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusOK, w.Code)
+
+				req = httptest.NewRequest(http.MethodGet, tt.path, nil)
+				req.Header.Set("If-Modified-Since", lastModified)
+				w = httptest.NewRecorder()
+				mux.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusOK, w.Code)
+				return
+			}
+
+			// Unchanged resources return 304
+			req = httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("If-Modified-Since", lastModified)
+			w = httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotModified, w.Code)
+
+			// Resources changed since the given time are returned in full
+			req = httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("If-Modified-Since", created.Meta.Official.UpdatedAt.Add(-time.Hour).UTC().Format(http.TimeFormat))
+			w = httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+
+	t.Run("list pages change when a server is deleted", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		etag := w.Header().Get("ETag")
+
+		deleted := string(model.StatusDeleted)
+		_, err := registryService.UpdateServer(ctx, "com.example/cached-server", "1.0.0", &created.Server, &deleted)
+		require.NoError(t, err)
+
+		req = httptest.NewRequest(http.MethodGet, "/v0/servers", nil)
+		req.Header.Set("If-None-Match", etag)
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	})
+}
+
+func TestServersEndpointSparseFields(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/sparse-server",
+		Description: "Server for sparse fieldset tests",
+		Title:       "Sparse",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	// Create API with the sparse fieldset transformer
+	mux := http.NewServeMux()
+	humaConfig := huma.DefaultConfig("Test API", "1.0.0")
+	humaConfig.Transformers = append(humaConfig.Transformers, v0.SparseFieldsTransformer)
+	api := humago.New(mux, humaConfig)
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	encodedName := url.PathEscape("com.example/sparse-server")
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedFields []string
+	}{
+		{
+			name:           "list servers",
+			path:           "/v0/servers?fields=name,version",
+			expectedStatus: http.StatusOK,
+			expectedFields: []string{"name", "version"},
+		},
+		{
+			name:           "server versions",
+			path:           "/v0/servers/" + encodedName + "/versions?fields=description",
+			expectedStatus: http.StatusOK,
+			expectedFields: []string{"description"},
+		},
+		{
+			name:           "server detail",
+			path:           "/v0/servers/" + encodedName + "/versions/latest?fields=name,title",
+			expectedStatus: http.StatusOK,
+			expectedFields: []string{"name", "title"},
+		},
+		{
+			name:           "unknown field",
+			path:           "/v0/servers?fields=name,bogus",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+			var servers []map[string]json.RawMessage
+			if raw, ok := body["servers"]; ok {
+				require.NoError(t, json.Unmarshal(raw, &servers))
+			} else {
+				servers = []map[string]json.RawMessage{body}
+			}
+			require.NotEmpty(t, servers)
+
+			for _, server := range servers {
+				assert.Contains(t, server, "_meta", "registry metadata is always included")
+
+				var fields map[string]json.RawMessage
+				require.NoError(t, json.Unmarshal(server["server"], &fields))
+				keys := make([]string, 0, len(fields))
+				for key := range fields {
+					keys = append(keys, key)
+				}
+				assert.ElementsMatch(t, tt.expectedFields, keys)
+			}
+		})
+	}
+}
+
 func TestServersEndpointEdgeCases(t *testing.T) {
 	ctx := context.Background()
 	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
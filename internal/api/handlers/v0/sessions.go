@@ -0,0 +1,306 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+const (
+	// SessionCookieName is the cookie a browser session is held in
+	SessionCookieName = "mcp_registry_session"
+
+	// CSRFTokenHeader carries the CSRF token of the session on state-changing requests
+	CSRFTokenHeader = "X-CSRF-Token"
+)
+
+// WebSessionBody represents a browser session
+type WebSessionBody struct {
+	ID          int64             `json:"id" doc:"Session ID" example:"42"`
+	AuthMethod  auth.Method       `json:"authMethod" doc:"How the session's login was made" example:"github-at"`
+	Permissions []auth.Permission `json:"permissions" doc:"Permissions of the Registry JWTs the session is exchanged for"`
+	UserAgent   string            `json:"userAgent,omitempty" doc:"Browser the session was started from"`
+	ClientIP    string            `json:"clientIp,omitempty" doc:"Address the session was started from"`
+	CreatedAt   time.Time         `json:"createdAt" doc:"When the session was started"`
+	ExpiresAt   time.Time         `json:"expiresAt" doc:"When the session ends"`
+	LastUsedAt  *time.Time        `json:"lastUsedAt,omitempty" doc:"When the session was last used"`
+}
+
+// CurrentWebSessionBody represents the session of the calling browser, with the CSRF token it must send
+type CurrentWebSessionBody struct {
+	WebSessionBody
+	CSRFToken string `json:"csrfToken" doc:"Send in the X-CSRF-Token header on every request other than GET, HEAD and OPTIONS"`
+}
+
+// WebSessionListBody represents the browser sessions of the caller
+type WebSessionListBody struct {
+	Sessions []WebSessionBody `json:"sessions" doc:"Active sessions, newest first"`
+}
+
+// WebSessionOutput represents a response that sets or clears the session cookie
+type WebSessionOutput struct {
+	SetCookie http.Cookie `header:"Set-Cookie"`
+	Body      CurrentWebSessionBody
+}
+
+// ClearWebSessionOutput represents a response that clears the session cookie
+type ClearWebSessionOutput struct {
+	SetCookie http.Cookie `header:"Set-Cookie"`
+}
+
+// CreateWebSessionInput represents the input for starting a browser session
+type CreateWebSessionInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token from an interactive login" required:"true"`
+	ForwardedFor  string `header:"X-Forwarded-For" doc:"Set by the load balancer"`
+	UserAgent     string `header:"User-Agent" doc:"Recorded to help recognize the session"`
+
+	remoteAddr string
+}
+
+// Resolve captures the client address recorded with the session
+func (i *CreateWebSessionInput) Resolve(ctx huma.Context) []error {
+	i.remoteAddr = ctx.RemoteAddr()
+	return nil
+}
+
+// ListWebSessionsInput represents the input for listing browser sessions
+type ListWebSessionsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token, or the session cookie" required:"true"`
+}
+
+// RevokeWebSessionInput represents the input for revoking a browser session
+type RevokeWebSessionInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token, or the session cookie" required:"true"`
+	ID            int64  `path:"id" doc:"Session ID" example:"42"`
+}
+
+type webSessionKey struct{}
+
+// WithWebSession returns a context for a request authenticated with a browser session
+func WithWebSession(ctx context.Context, session *database.WebSession) context.Context {
+	return context.WithValue(ctx, webSessionKey{}, session)
+}
+
+// WebSessionFromContext returns the browser session a request was authenticated with, if any
+func WebSessionFromContext(ctx context.Context) *database.WebSession {
+	session, _ := ctx.Value(webSessionKey{}).(*database.WebSession)
+	return session
+}
+
+// RegisterSessionEndpoints registers the browser session endpoints with a custom path prefix
+func RegisterSessionEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	if cfg.SessionDuration <= 0 {
+		return // Skip registration if browser sessions are disabled
+	}
+
+	jwtManager := auth.NewJWTManager(cfg)
+	trustedProxies, err := cfg.TrustedProxyNetworks()
+	if err != nil {
+		panic(err)
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-web-session" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/session",
+		Summary:     "Start browser session",
+		Description: "Exchange a Registry JWT from an interactive login for a session cookie, for browser-based clients. The cookie is HttpOnly and " +
+			"is accepted in place of a bearer token. Requests other than GET, HEAD and OPTIONS made with it must send the returned CSRF token " +
+			"in the X-CSRF-Token header.",
+		Tags: []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *CreateWebSessionInput) (*WebSessionOutput, error) {
+		if WebSessionFromContext(ctx) != nil {
+			return nil, huma.Error400BadRequest("A session cannot be started from another session. Log in again instead")
+		}
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if claims.AuthMethod == auth.MethodAPIToken {
+			return nil, huma.Error403Forbidden("API tokens cannot be used to start browser sessions. Log in interactively instead")
+		}
+
+		expiresAt := time.Now().Add(cfg.SessionDuration)
+		clientIP := ClientIP(input.ForwardedFor, input.remoteAddr, trustedProxies)
+		session, secret, err := registry.CreateWebSession(ctx, claims, input.UserAgent, clientIP, expiresAt)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to start session", err)
+		}
+
+		return &WebSessionOutput{
+			SetCookie: sessionCookie(cfg, secret, expiresAt),
+			Body:      toCurrentWebSessionBody(session),
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-web-session" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/auth/session",
+		Summary:     "Get current browser session",
+		Description: "Get the session of the session cookie, including its CSRF token, for instance after the page is reloaded.",
+		Tags:        []string{"auth"},
+	}, func(ctx context.Context, _ *struct{}) (*Response[CurrentWebSessionBody], error) {
+		session := WebSessionFromContext(ctx)
+		if session == nil {
+			return nil, huma.Error401Unauthorized("No active session")
+		}
+
+		return &Response[CurrentWebSessionBody]{Body: toCurrentWebSessionBody(session)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-web-session" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/auth/session",
+		Summary:     "End browser session",
+		Description: "Log out: revoke the session of the session cookie and clear the cookie.",
+		Tags:        []string{"auth"},
+	}, func(ctx context.Context, _ *struct{}) (*ClearWebSessionOutput, error) {
+		session := WebSessionFromContext(ctx)
+		if session == nil {
+			return nil, huma.Error401Unauthorized("No active session")
+		}
+
+		if err := registry.RevokeWebSession(ctx, session.ID, session.Owner, false); err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, huma.Error500InternalServerError("Failed to end session", err)
+		}
+
+		return &ClearWebSessionOutput{SetCookie: ClearSessionCookie(cfg)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-web-sessions" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/sessions",
+		Summary:     "List browser sessions",
+		Description: "List your active browser sessions, including where they were started and when each was last used.",
+		Tags:        []string{"sessions"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListWebSessionsInput) (*Response[WebSessionListBody], error) {
+		claims, err := validateSessionManager(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions, err := registry.ListWebSessions(ctx, auditActor(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list sessions", err)
+		}
+
+		body := WebSessionListBody{Sessions: make([]WebSessionBody, 0, len(sessions))}
+		for _, session := range sessions {
+			body.Sessions = append(body.Sessions, toWebSessionBody(session))
+		}
+		return &Response[WebSessionListBody]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-web-session" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/sessions/{id}",
+		Summary:     "Revoke browser session",
+		Description: "End one of your browser sessions, for instance one left open on another computer. Admins can revoke any session.",
+		Tags:        []string{"sessions"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RevokeWebSessionInput) (*struct{}, error) {
+		claims, err := validateSessionManager(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		asAdmin := isAdmin(claims.Permissions)
+		if err := registry.RevokeWebSession(ctx, input.ID, auditActor(claims), asAdmin); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Session not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to revoke session", err)
+		}
+
+		return nil, nil
+	})
+}
+
+// WebSessionClaims returns the claims of a Registry JWT for a request made with a browser session. The
+// token has the identity and permissions of the login the session was started from, never outlives the
+// session, and comes without a refresh token since the session itself keeps the login alive.
+func WebSessionClaims(session *database.WebSession) auth.JWTClaims {
+	expiresAt := time.Now().Add(apiTokenJWTDuration)
+	if session.ExpiresAt.Before(expiresAt) {
+		expiresAt = session.ExpiresAt
+	}
+	return auth.JWTClaims{
+		RegisteredClaims:  jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expiresAt)},
+		AuthMethod:        session.AuthMethod,
+		AuthMethodSubject: session.Subject,
+		Permissions:       session.Permissions,
+	}
+}
+
+// ClearSessionCookie returns a cookie that removes the session cookie from the browser
+func ClearSessionCookie(cfg *config.Config) http.Cookie {
+	cookie := sessionCookie(cfg, "", time.Time{})
+	cookie.MaxAge = -1
+	return cookie
+}
+
+// sessionCookie returns the session cookie. It is unreadable from scripts and not sent with cross-site
+// requests other than top-level navigations, which only ever read.
+func sessionCookie(cfg *config.Config, secret string, expiresAt time.Time) http.Cookie {
+	return http.Cookie{
+		Name:     SessionCookieName,
+		Value:    secret,
+		Path:     "/",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   cfg.SessionCookieSecure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// validateSessionManager validates the Registry JWT of a request to manage browser sessions. Tokens
+// obtained from an API token cannot manage sessions, so a leaked CI token cannot end a user's logins.
+func validateSessionManager(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	claims, err := validateBearerToken(ctx, jwtManager, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	if claims.AuthMethod == auth.MethodAPIToken {
+		return nil, huma.Error403Forbidden("API tokens cannot be used to manage browser sessions. Log in interactively instead")
+	}
+	return claims, nil
+}
+
+func toWebSessionBody(session *database.WebSession) WebSessionBody {
+	return WebSessionBody{
+		ID:          session.ID,
+		AuthMethod:  session.AuthMethod,
+		Permissions: session.Permissions,
+		UserAgent:   session.UserAgent,
+		ClientIP:    session.ClientIP,
+		CreatedAt:   session.CreatedAt,
+		ExpiresAt:   session.ExpiresAt,
+		LastUsedAt:  session.LastUsedAt,
+	}
+}
+
+func toCurrentWebSessionBody(session *database.WebSession) CurrentWebSessionBody {
+	return CurrentWebSessionBody{WebSessionBody: toWebSessionBody(session), CSRFToken: session.CSRFToken}
+}
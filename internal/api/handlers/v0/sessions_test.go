@@ -0,0 +1,136 @@
+package v0_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+func TestWebSessionEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), SessionDuration: time.Hour, SessionCookieSecure: true}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.SessionMiddleware(api, cfg, registryService))
+	v0.RegisterSessionEndpoints(api, "/v0", registryService, cfg)
+
+	permissions := []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}}
+	loginToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "alice",
+		Permissions:       permissions,
+	})
+	require.NoError(t, err)
+	otherToken, err := generateTestJWTToken(cfg, auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "mallory"})
+	require.NoError(t, err)
+
+	do := func(t *testing.T, method, path, token string, cookie *http.Cookie, csrfToken string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		if csrfToken != "" {
+			req.Header.Set(v0.CSRFTokenHeader, csrfToken)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(t, http.MethodPost, "/v0/auth/session", loginToken, nil, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var created v0.CurrentWebSessionBody
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	assert.Equal(t, permissions, created.Permissions)
+	assert.NotEmpty(t, created.CSRFToken)
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	cookie := cookies[0]
+	assert.Equal(t, v0.SessionCookieName, cookie.Name)
+	assert.True(t, cookie.HttpOnly)
+	assert.True(t, cookie.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+
+	t.Run("current session returns the CSRF token", func(t *testing.T) {
+		w := do(t, http.MethodGet, "/v0/auth/session", "", cookie, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var current v0.CurrentWebSessionBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&current))
+		assert.Equal(t, created.ID, current.ID)
+		assert.Equal(t, created.CSRFToken, current.CSRFToken)
+	})
+
+	t.Run("sessions are listed for their owner", func(t *testing.T) {
+		w := do(t, http.MethodGet, "/v0/sessions", "", cookie, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.WebSessionListBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+		require.Len(t, list.Sessions, 1)
+		assert.Equal(t, created.ID, list.Sessions[0].ID)
+
+		w = do(t, http.MethodGet, "/v0/sessions", otherToken, nil, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+		assert.Empty(t, list.Sessions)
+	})
+
+	t.Run("sessions cannot start other sessions", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/auth/session", "", cookie, created.CSRFToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("other users cannot revoke the session", func(t *testing.T) {
+		w := do(t, http.MethodDelete, "/v0/sessions/"+strconv.FormatInt(created.ID, 10), otherToken, nil, "")
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("logging out requires the CSRF token", func(t *testing.T) {
+		w := do(t, http.MethodDelete, "/v0/auth/session", "", cookie, "")
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("logging out ends the session", func(t *testing.T) {
+		w := do(t, http.MethodDelete, "/v0/auth/session", "", cookie, created.CSRFToken)
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		cleared := w.Result().Cookies()
+		require.Len(t, cleared, 1)
+		assert.Equal(t, -1, cleared[0].MaxAge)
+
+		w = do(t, http.MethodGet, "/v0/auth/session", "", cookie, "")
+		assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+	})
+
+	t.Run("API tokens cannot start sessions", func(t *testing.T) {
+		apiToken, err := generateTestJWTToken(cfg, auth.JWTClaims{AuthMethod: auth.MethodAPIToken, AuthMethodSubject: "github-at:alice"})
+		require.NoError(t, err)
+		w := do(t, http.MethodPost, "/v0/auth/session", apiToken, nil, "")
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,153 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+const topFetchedServersLimit = 10
+
+// StatsInput represents the input for the registry-wide statistics endpoint
+type StatsInput struct {
+	Days int `query:"days" doc:"Number of days of history to include" default:"30" minimum:"1" maximum:"365" example:"30"`
+}
+
+// ServerStatsInput represents the input for the per-server statistics endpoint
+type ServerStatsInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Days       int    `query:"days" doc:"Number of days of history to include" default:"30" minimum:"1" maximum:"365" example:"30"`
+}
+
+// DailyFetches represents the number of fetches on a single day (UTC)
+type DailyFetches struct {
+	Date    string `json:"date" doc:"Day in YYYY-MM-DD format (UTC)" example:"2025-09-01"`
+	Fetches int64  `json:"fetches" doc:"Number of API fetches on this day" example:"42"`
+}
+
+// ServerFetches represents the total number of fetches for a server
+type ServerFetches struct {
+	Name    string `json:"name" doc:"Server name" example:"com.example/my-server"`
+	Fetches int64  `json:"fetches" doc:"Number of API fetches in the period" example:"1200"`
+}
+
+// ServerStatsBody represents the per-server statistics response body
+type ServerStatsBody struct {
+	Name         string         `json:"name" doc:"Server name" example:"com.example/my-server"`
+	Days         int            `json:"days" doc:"Number of days covered" example:"30"`
+	TotalFetches int64          `json:"total_fetches" doc:"Total API fetches in the period" example:"1200"`
+	Daily        []DailyFetches `json:"daily" doc:"Fetch counts per day, omitting days without fetches"`
+}
+
+// RegistryStatsBody represents the registry-wide statistics response body
+type RegistryStatsBody struct {
+	Days         int             `json:"days" doc:"Number of days covered" example:"30"`
+	TotalFetches int64           `json:"total_fetches" doc:"Total API fetches in the period" example:"50000"`
+	Daily        []DailyFetches  `json:"daily" doc:"Fetch counts per day, omitting days without fetches"`
+	TopServers   []ServerFetches `json:"top_servers" doc:"Most fetched servers in the period"`
+}
+
+// RegisterStatsEndpoints registers the usage statistics endpoints with a custom path prefix
+func RegisterStatsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	// Per-server statistics endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-stats" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/stats",
+		Summary:     "Get MCP server usage statistics",
+		Description: "Get daily API fetch counts for a server. Counts are aggregated per day and contain no information about the clients that made the requests.",
+		Tags:        []string{"stats"},
+	}, func(ctx context.Context, input *ServerStatsInput) (*Response[ServerStatsBody], error) {
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		// Check the server exists so unknown names return 404 rather than empty stats
+		if _, err := registry.GetServerByName(ctx, serverName); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server details", err)
+		}
+
+		counts, err := registry.GetServerFetchStats(ctx, serverName, statsSince(input.Days))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get server statistics", err)
+		}
+
+		daily, total := toDailyFetches(counts)
+
+		return &Response[ServerStatsBody]{
+			Body: ServerStatsBody{
+				Name:         serverName,
+				Days:         input.Days,
+				TotalFetches: total,
+				Daily:        daily,
+			},
+		}, nil
+	})
+
+	// Registry-wide statistics endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-registry-stats" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/stats",
+		Summary:     "Get registry usage statistics",
+		Description: "Get registry-wide daily API fetch counts and the most fetched servers. Counts are aggregated per day and contain no information about the clients that made the requests.",
+		Tags:        []string{"stats"},
+	}, func(ctx context.Context, input *StatsInput) (*Response[RegistryStatsBody], error) {
+		since := statsSince(input.Days)
+
+		counts, err := registry.GetDailyFetchTotals(ctx, since)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get registry statistics", err)
+		}
+
+		top, err := registry.GetTopFetchedServers(ctx, since, topFetchedServersLimit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get registry statistics", err)
+		}
+
+		daily, total := toDailyFetches(counts)
+
+		topServers := make([]ServerFetches, len(top))
+		for i, server := range top {
+			topServers[i] = ServerFetches{Name: server.ServerName, Fetches: server.Count}
+		}
+
+		return &Response[RegistryStatsBody]{
+			Body: RegistryStatsBody{
+				Days:         input.Days,
+				TotalFetches: total,
+				Daily:        daily,
+				TopServers:   topServers,
+			},
+		}, nil
+	})
+}
+
+// statsSince returns the first UTC day included in a window of the given number of days
+func statsSince(days int) time.Time {
+	year, month, day := time.Now().UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -(days - 1))
+}
+
+// toDailyFetches converts database counts into response entries and sums them
+func toDailyFetches(counts []database.DailyFetchCount) ([]DailyFetches, int64) {
+	daily := make([]DailyFetches, len(counts))
+	var total int64
+	for i, count := range counts {
+		daily[i] = DailyFetches{Date: count.Day.Format(time.DateOnly), Fetches: count.Count}
+		total += count.Count
+	}
+	return daily, total
+}
@@ -0,0 +1,259 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// APITokenBody represents an API token, without the token itself
+type APITokenBody struct {
+	ID          int64             `json:"id" doc:"Token ID" example:"42"`
+	Name        string            `json:"name" doc:"Name given to the token" example:"github-actions-release"`
+	Prefix      string            `json:"prefix" doc:"Start of the token, to help recognize it" example:"mcpr_AbC123"`
+	Permissions []auth.Permission `json:"permissions" doc:"Permissions of the Registry JWTs the token can be exchanged for"`
+	CreatedAt   time.Time         `json:"createdAt" doc:"When the token was created"`
+	ExpiresAt   time.Time         `json:"expiresAt" doc:"When the token stops working"`
+	LastUsedAt  *time.Time        `json:"lastUsedAt,omitempty" doc:"When the token was last exchanged for a Registry JWT"`
+}
+
+// CreatedAPITokenBody represents a newly created API token
+type CreatedAPITokenBody struct {
+	APITokenBody
+	Token string `json:"token" doc:"The API token. It is only shown once; store it as a secret" example:"mcpr_AbC123..."`
+}
+
+// APITokenListBody represents the API tokens of the caller
+type APITokenListBody struct {
+	Tokens []APITokenBody `json:"tokens" doc:"Unrevoked tokens, newest first"`
+}
+
+// CreateAPITokenInput represents the input for creating an API token
+type CreateAPITokenInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token from an interactive login" required:"true"`
+	Body          struct {
+		Name          string            `json:"name" doc:"Name to recognize the token by" minLength:"1" maxLength:"100" example:"github-actions-release"`
+		Permissions   []auth.Permission `json:"permissions,omitempty" doc:"Permissions to grant, each covered by the calling token's permissions. Defaults to the publish permissions of the calling token"`
+		ExpiresInDays int               `json:"expiresInDays,omitempty" doc:"Days until the token expires" minimum:"1" maximum:"365" default:"90"`
+	}
+}
+
+// ListAPITokensInput represents the input for listing API tokens
+type ListAPITokensInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token from an interactive login" required:"true"`
+}
+
+// RevokeAPITokenInput represents the input for revoking an API token
+type RevokeAPITokenInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token from an interactive login" required:"true"`
+	ID            int64  `path:"id" doc:"Token ID" example:"42"`
+}
+
+// ExchangeAPITokenInput represents the input for exchanging an API token for a Registry JWT
+type ExchangeAPITokenInput struct {
+	Body struct {
+		Token string `json:"token" doc:"API token" required:"true"`
+	}
+}
+
+// RegisterTokenEndpoints registers the API token management and exchange endpoints with a custom path prefix
+func RegisterTokenEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-api-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/tokens",
+		Summary:     "Create API token",
+		Description: "Create a named, expiring API token for CI. The token can be exchanged for Registry JWTs with the permissions it was created with, which must be covered by the calling token's permissions. " +
+			"The token is only returned once; the registry stores a hash of it.",
+		Tags: []string{"tokens"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *CreateAPITokenInput) (*Response[CreatedAPITokenBody], error) {
+		claims, err := validateTokenManager(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		permissions := input.Body.Permissions
+		if len(permissions) == 0 {
+			for _, perm := range claims.Permissions {
+				if perm.Action == auth.PermissionActionPublish {
+					permissions = append(permissions, perm)
+				}
+			}
+			if len(permissions) == 0 {
+				return nil, huma.Error400BadRequest("Your token has no publish permissions to grant")
+			}
+		}
+		for _, perm := range permissions {
+			if !auth.IsValidPermissionAction(perm.Action) {
+				return nil, huma.Error400BadRequest("Unknown permission action: " + string(perm.Action))
+			}
+			if !permissionCovered(perm, claims.Permissions) {
+				return nil, huma.Error403Forbidden("You cannot grant " + string(perm.Action) + " permission for " + perm.ResourcePattern)
+			}
+		}
+
+		expiresAt := time.Now().AddDate(0, 0, input.Body.ExpiresInDays)
+		token, secret, err := registry.CreateAPIToken(ctx, auditActor(claims), input.Body.Name, permissions, expiresAt)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to create API token", err)
+		}
+
+		return &Response[CreatedAPITokenBody]{Body: CreatedAPITokenBody{APITokenBody: toAPITokenBody(token), Token: secret}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-api-tokens" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/tokens",
+		Summary:     "List API tokens",
+		Description: "List your unrevoked API tokens, including when each was last used.",
+		Tags:        []string{"tokens"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListAPITokensInput) (*Response[APITokenListBody], error) {
+		claims, err := validateTokenManager(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens, err := registry.ListAPITokens(ctx, auditActor(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list API tokens", err)
+		}
+
+		body := APITokenListBody{Tokens: make([]APITokenBody, 0, len(tokens))}
+		for _, token := range tokens {
+			body.Tokens = append(body.Tokens, toAPITokenBody(token))
+		}
+		return &Response[APITokenListBody]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-api-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/tokens/{id}",
+		Summary:     "Revoke API token",
+		Description: "Revoke one of your API tokens. Registry JWTs already obtained with it keep working until they expire, within minutes. Admins can revoke any token.",
+		Tags:        []string{"tokens"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RevokeAPITokenInput) (*struct{}, error) {
+		claims, err := validateTokenManager(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		asAdmin := isAdmin(claims.Permissions)
+		if err := registry.RevokeAPIToken(ctx, input.ID, auditActor(claims), asAdmin); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("API token not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to revoke API token", err)
+		}
+
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-api-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/api-token",
+		Summary:     "Exchange API token for Registry JWT",
+		Description: "Exchange an API token for a short-lived Registry JWT with the permissions the token was created with",
+		Tags:        []string{"auth"},
+	}, func(ctx context.Context, input *ExchangeAPITokenInput) (*Response[auth.TokenResponse], error) {
+		token, err := registry.AuthenticateAPIToken(ctx, input.Body.Token)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error401Unauthorized("Invalid, expired or revoked API token")
+			}
+			return nil, huma.Error500InternalServerError("Failed to check API token", err)
+		}
+
+		response, err := jwtManager.GenerateTokenResponse(ctx, APITokenClaims(token))
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Token exchange failed", err)
+		}
+
+		return &Response[auth.TokenResponse]{Body: *response}, nil
+	})
+}
+
+// apiTokenJWTDuration is the default lifetime of Registry JWTs, which tokens exchanged from an
+// API token expiring sooner are shortened to match
+const apiTokenJWTDuration = 5 * time.Minute
+
+// APITokenClaims returns the claims of a Registry JWT obtained with an API token
+func APITokenClaims(token *database.APIToken) auth.JWTClaims {
+	claims := auth.JWTClaims{
+		AuthMethod:        auth.MethodAPIToken,
+		AuthMethodSubject: token.Owner,
+		Permissions:       token.Permissions,
+	}
+	// Registry JWTs must not outlive the API token they were obtained with
+	if time.Until(token.ExpiresAt) < apiTokenJWTDuration {
+		claims.ExpiresAt = jwt.NewNumericDate(token.ExpiresAt)
+	}
+	return claims
+}
+
+// validateTokenManager validates the Registry JWT of a request to manage API tokens. Tokens
+// obtained from an API token cannot manage API tokens, so a leaked token cannot mint more.
+func validateTokenManager(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	claims, err := validateBearerToken(ctx, jwtManager, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	if claims.AuthMethod == auth.MethodAPIToken {
+		return nil, huma.Error403Forbidden("API tokens cannot be used to manage API tokens. Log in interactively instead")
+	}
+	return claims, nil
+}
+
+// permissionCovered reports whether granted permissions allow the action on every resource matched by requested
+func permissionCovered(requested auth.Permission, granted []auth.Permission) bool {
+	for _, perm := range granted {
+		if !perm.Grants(requested.Action) {
+			continue
+		}
+		if perm.ResourcePattern == "*" || perm.ResourcePattern == requested.ResourcePattern {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(perm.ResourcePattern, "*"); ok && strings.HasPrefix(requested.ResourcePattern, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func toAPITokenBody(token *database.APIToken) APITokenBody {
+	return APITokenBody{
+		ID:          token.ID,
+		Name:        token.Name,
+		Prefix:      token.Prefix,
+		Permissions: token.Permissions,
+		CreatedAt:   token.CreatedAt,
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+	}
+}
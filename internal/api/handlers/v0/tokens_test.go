@@ -0,0 +1,153 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+func TestAPITokenEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterTokenEndpoints(api, "/v0", registryService, cfg)
+
+	loginToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "alice",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	do := func(t *testing.T, method, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		reader := bytes.NewReader(nil)
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	exchange := func(t *testing.T, apiToken string) *httptest.ResponseRecorder {
+		t.Helper()
+		return do(t, http.MethodPost, "/v0/auth/api-token", "", map[string]string{"token": apiToken})
+	}
+
+	w := do(t, http.MethodPost, "/v0/tokens", loginToken, map[string]any{"name": "release", "expiresInDays": 30})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var created v0.CreatedAPITokenBody
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	assert.True(t, strings.HasPrefix(created.Token, "mcpr_"))
+	assert.True(t, strings.HasPrefix(created.Token, created.Prefix))
+	assert.Equal(t, []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}}, created.Permissions)
+
+	t.Run("cannot grant permissions the caller lacks", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/tokens", loginToken, map[string]any{
+			"name":        "too-broad",
+			"permissions": []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.*"}},
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("publish permissions do not cover delete", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/tokens", loginToken, map[string]any{
+			"name":        "cleanup",
+			"permissions": []auth.Permission{{Action: auth.PermissionActionDelete, ResourcePattern: "io.github.alice/*"}},
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("narrower permissions can be granted", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/v0/tokens", loginToken, map[string]any{
+			"name":        "weather-only",
+			"permissions": []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/weather"}},
+		})
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("exchange for a registry JWT", func(t *testing.T) {
+		w := exchange(t, created.Token)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response auth.TokenResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+
+		claims, err := auth.NewJWTManager(cfg).ValidateToken(context.Background(), response.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, auth.MethodAPIToken, claims.AuthMethod)
+		assert.Equal(t, "github-at:alice", claims.AuthMethodSubject)
+		assert.Equal(t, created.Permissions, claims.Permissions)
+
+		// Tokens from an API token cannot mint more API tokens
+		w = do(t, http.MethodPost, "/v0/tokens", response.RegistryToken, map[string]any{"name": "nested"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("list shows last use but not the token", func(t *testing.T) {
+		w := do(t, http.MethodGet, "/v0/tokens", loginToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.NotContains(t, w.Body.String(), created.Token)
+
+		var list v0.APITokenListBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+		require.Len(t, list.Tokens, 2)
+		release := list.Tokens[1]
+		assert.Equal(t, "release", release.Name)
+		assert.NotNil(t, release.LastUsedAt)
+	})
+
+	t.Run("revoked tokens stop working", func(t *testing.T) {
+		otherToken, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "mallory",
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.mallory/*"}},
+		})
+		require.NoError(t, err)
+		tokenPath := "/v0/tokens/" + strconv.FormatInt(created.ID, 10)
+
+		w := do(t, http.MethodDelete, tokenPath, otherToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = do(t, http.MethodDelete, tokenPath, loginToken, nil)
+		require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		assert.Equal(t, http.StatusUnauthorized, exchange(t, created.Token).Code)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, exchange(t, "mcpr_not-a-real-token").Code)
+	})
+}
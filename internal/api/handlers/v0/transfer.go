@@ -0,0 +1,257 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/policy"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ServerTransferBody represents a pending server transfer
+type ServerTransferBody struct {
+	ServerName  string    `json:"serverName" doc:"Current server name" example:"io.github.olduser/weather"`
+	NewName     string    `json:"newName" doc:"Name the server will have once the transfer is accepted" example:"io.github.newuser/weather"`
+	InitiatedBy string    `json:"initiatedBy" doc:"Who started the transfer" example:"github-at:olduser"`
+	CreatedAt   time.Time `json:"createdAt" doc:"When the transfer was started"`
+	ExpiresAt   time.Time `json:"expiresAt" doc:"When the transfer expires if not accepted"`
+}
+
+// ServerTransferInput represents the input for reading a server transfer
+type ServerTransferInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"io.github.olduser%2Fweather"`
+}
+
+// AuthenticatedServerTransferInput represents the input for accepting or cancelling a server transfer
+type AuthenticatedServerTransferInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"io.github.olduser%2Fweather"`
+}
+
+// InitiateServerTransferInput represents the input for starting a server transfer
+type InitiateServerTransferInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permissions for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"io.github.olduser%2Fweather"`
+	Body          struct {
+		NewName string `json:"newName" doc:"New server name, in the namespace of the new owner" example:"io.github.newuser/weather"`
+	}
+}
+
+// RegisterTransferEndpoints registers the server ownership transfer endpoints with a custom path prefix
+func RegisterTransferEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	policies, err := policy.NewPublishPolicies(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "initiate-server-transfer" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/transfer",
+		Summary:     "Start server transfer",
+		Description: "Start transferring a server to a new name owned by a different namespace. Requires publish permissions for the server. The transfer takes effect once the owner of the new name accepts it, and expires after 7 days.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *InitiateServerTransferInput) (*Response[ServerTransferBody], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(serverName, claims.Permissions))
+		}
+
+		transfer, err := registry.InitiateServerTransfer(ctx, serverName, input.Body.NewName, auditActor(claims))
+		if err != nil {
+			return nil, transferError(err, "Failed to start server transfer")
+		}
+
+		return &Response[ServerTransferBody]{Body: toServerTransferBody(transfer)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-transfer" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/transfer",
+		Summary:     "Get pending server transfer",
+		Description: "Get the pending transfer of a server, if any.",
+		Tags:        []string{"publish"},
+	}, func(ctx context.Context, input *ServerTransferInput) (*Response[ServerTransferBody], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		transfer, err := registry.GetServerTransfer(ctx, serverName)
+		if err != nil {
+			return nil, transferError(err, "Failed to get server transfer")
+		}
+
+		return &Response[ServerTransferBody]{Body: toServerTransferBody(transfer)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "cancel-server-transfer" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/servers/{serverName}/transfer",
+		Summary:     "Cancel server transfer",
+		Description: "Cancel or decline the pending transfer of a server. Requires publish permissions for either the current or the new name.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *AuthenticatedServerTransferInput) (*struct{}, error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		transfer, err := registry.GetServerTransfer(ctx, serverName)
+		if err != nil {
+			return nil, transferError(err, "Failed to get server transfer")
+		}
+
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) &&
+			!jwtManager.HasPermission(transfer.NewName, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have permission to cancel this transfer")
+		}
+
+		if err := registry.CancelServerTransfer(ctx, serverName, auditActor(claims)); err != nil {
+			return nil, transferError(err, "Failed to cancel server transfer")
+		}
+
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "accept-server-transfer" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/transfer/accept",
+		Summary:     "Accept server transfer",
+		Description: "Accept the pending transfer of a server. Requires publish permissions for the new name. All versions move to the new name with their history intact.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *AuthenticatedServerTransferInput) (*Response[apiv0.ServerResponse], error) {
+		claims, err := validateBearerToken(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		transfer, err := registry.GetServerTransfer(ctx, serverName)
+		if err != nil {
+			return nil, transferError(err, "Failed to get server transfer")
+		}
+
+		if !jwtManager.HasPermission(transfer.NewName, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(transfer.NewName, claims.Permissions))
+		}
+		if err := checkNamespaceReservation(ctx, registry, jwtManager, transfer.NewName, claims); err != nil {
+			return nil, err
+		}
+		if err := checkTransferPolicies(ctx, registry, policies, claims, serverName, transfer.NewName); err != nil {
+			return nil, err
+		}
+
+		server, err := registry.AcceptServerTransfer(ctx, serverName, auditActor(claims))
+		if err != nil {
+			return nil, transferError(err, "Failed to accept server transfer")
+		}
+
+		return &Response[apiv0.ServerResponse]{Body: *server}, nil
+	})
+}
+
+// checkTransferPolicies fails with 403 if a publish policy does not allow the token accepting a transfer
+// to publish one of the server's versions under its new name. Deleted versions are not checked.
+func checkTransferPolicies(ctx context.Context, registry service.RegistryService, policies *policy.PublishPolicies, claims *auth.JWTClaims, serverName, newName string) error {
+	versions, err := registry.GetAllVersionsByServerName(ctx, serverName)
+	if err != nil {
+		return transferError(err, "Failed to get server versions")
+	}
+	for _, version := range versions {
+		if version.Meta.Official != nil && version.Meta.Official.Status == model.StatusDeleted {
+			continue
+		}
+		server := version.Server
+		server.Name = newName
+		if err := checkPublishPolicies(policies, claims, &server); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateBearerToken extracts and validates the Registry JWT from an Authorization header
+func validateBearerToken(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	token, ok := auth.BearerToken(authHeader)
+	if !ok {
+		return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+	return claims, nil
+}
+
+// auditActor identifies the token holder in the audit log
+func auditActor(claims *auth.JWTClaims) string {
+	return string(claims.AuthMethod) + ":" + claims.AuthMethodSubject
+}
+
+// transferError maps service errors to HTTP errors
+func transferError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Server or pending transfer not found")
+	case errors.Is(err, database.ErrAlreadyExists):
+		return huma.Error409Conflict(err.Error())
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	default:
+		return huma.Error500InternalServerError(msg, err)
+	}
+}
+
+func toServerTransferBody(transfer *database.ServerTransfer) ServerTransferBody {
+	return ServerTransferBody{
+		ServerName:  transfer.ServerName,
+		NewName:     transfer.NewName,
+		InitiatedBy: transfer.InitiatedBy,
+		CreatedAt:   transfer.CreatedAt,
+		ExpiresAt:   transfer.ExpiresAt,
+	}
+}
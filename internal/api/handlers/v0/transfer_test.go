@@ -0,0 +1,154 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServerTransferEndpoints(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:   hex.EncodeToString(testSeed),
+		PublishPolicies: `[{"name": "no-mallory", "expression": "!server.name.startsWith('io.github.mallory/')", "message": "mallory is suspended"}]`,
+	}
+
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "io.github.alice/weather",
+			Description: "Weather server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterTransferEndpoints(api, "/v0", registryService, cfg)
+
+	tokenFor := func(t *testing.T, owner string) string {
+		t.Helper()
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: owner,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + owner + "/*"},
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	do := func(t *testing.T, method, path, token string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		var reader *bytes.Reader
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		} else {
+			reader = bytes.NewReader(nil)
+		}
+		req := httptest.NewRequest(method, path, reader)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	transferPath := "/v0/servers/" + url.PathEscape("io.github.alice/weather") + "/transfer"
+	alice, bob, mallory := tokenFor(t, "alice"), tokenFor(t, "bob"), tokenFor(t, "mallory")
+
+	t.Run("only the owner can initiate", func(t *testing.T) {
+		w := do(t, http.MethodPost, transferPath, mallory, map[string]string{"newName": "io.github.mallory/weather"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects an invalid new name", func(t *testing.T) {
+		w := do(t, http.MethodPost, transferPath, alice, map[string]string{"newName": "not-a-server-name"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("owner initiates and can cancel", func(t *testing.T) {
+		w := do(t, http.MethodPost, transferPath, alice, map[string]string{"newName": "io.github.bob/weather"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(t, http.MethodDelete, transferPath, mallory, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = do(t, http.MethodDelete, transferPath, alice, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = do(t, http.MethodGet, transferPath, "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("publish policies apply to the new name", func(t *testing.T) {
+		w := do(t, http.MethodPost, transferPath, alice, map[string]string{"newName": "io.github.mallory/weather"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(t, http.MethodPost, transferPath+"/accept", mallory, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "mallory is suspended")
+
+		w = do(t, http.MethodDelete, transferPath, alice, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("new owner accepts", func(t *testing.T) {
+		w := do(t, http.MethodPost, transferPath, alice, map[string]string{"newName": "io.github.bob/weather"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = do(t, http.MethodGet, transferPath, "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var transfer v0.ServerTransferBody
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&transfer))
+		assert.Equal(t, "io.github.bob/weather", transfer.NewName)
+		assert.Equal(t, "github-at:alice", transfer.InitiatedBy)
+
+		// The current owner cannot accept on behalf of the new owner
+		w = do(t, http.MethodPost, transferPath+"/accept", alice, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = do(t, http.MethodPost, transferPath+"/accept", bob, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var server apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&server))
+		assert.Equal(t, "io.github.bob/weather", server.Server.Name)
+		assert.Equal(t, "1.1.0", server.Server.Version)
+
+		// Version history moves with the server
+		versions, err := registryService.GetAllVersionsByServerName(context.Background(), "io.github.bob/weather")
+		require.NoError(t, err)
+		assert.Len(t, versions, 2)
+
+		_, err = registryService.GetServerByName(context.Background(), "io.github.alice/weather")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
@@ -3,16 +3,52 @@ package v0
 import (
 	"context"
 	"net/http"
+	"runtime"
+	"runtime/debug"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
 )
 
 // VersionBody represents the version information
 type VersionBody struct {
-	Version   string `json:"version" example:"v1.0.0" doc:"Application version"`
-	GitCommit string `json:"git_commit" example:"abc123d" doc:"Git commit SHA"`
-	BuildTime string `json:"build_time" example:"2025-10-14T12:00:00Z" doc:"Build timestamp"`
+	Version   string          `json:"version" example:"v1.0.0" doc:"Application version"`
+	GitCommit string          `json:"git_commit" example:"abc123d" doc:"Git commit SHA"`
+	BuildTime string          `json:"build_time" example:"2025-10-14T12:00:00Z" doc:"Build timestamp"`
+	GoVersion string          `json:"go_version,omitempty" example:"go1.24.6" doc:"Go version the registry was built with"`
+	Features  map[string]bool `json:"features,omitempty" doc:"Optional features, such as tracing or anonymous auth, and whether they are enabled"`
+}
+
+// NewVersionBody returns the version information of the registry configured by cfg. A git commit or build
+// time the build did not set, as with go run or go build without ldflags, is read from the version control
+// information Go embeds in the binary.
+func NewVersionBody(cfg *config.Config) *VersionBody {
+	body := &VersionBody{
+		Version:   cfg.Version,
+		GitCommit: cfg.GitCommit,
+		BuildTime: cfg.BuildTime,
+		GoVersion: runtime.Version(),
+		Features:  cfg.Features(),
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return body
+	}
+	if body.Version == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		body.Version = info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && body.GitCommit == "unknown":
+			body.GitCommit = setting.Value
+		case setting.Key == "vcs.time" && body.BuildTime == "unknown":
+			body.BuildTime = setting.Value
+		}
+	}
+	return body
 }
 
 // RegisterVersionEndpoint registers the version endpoint with a custom path prefix
@@ -22,7 +58,7 @@ func RegisterVersionEndpoint(api huma.API, pathPrefix string, versionInfo *Versi
 		Method:      http.MethodGet,
 		Path:        pathPrefix + "/version",
 		Summary:     "Get version information",
-		Description: "Returns the version, git commit, and build time of the registry application",
+		Description: "Returns the version, git commit, build time and Go version of the registry application, and which optional features are enabled",
 		Tags:        []string{"version"},
 	}, func(_ context.Context, _ *struct{}) (*Response[VersionBody], error) {
 		return &Response[VersionBody]{
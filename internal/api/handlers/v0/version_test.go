@@ -1,15 +1,19 @@
 package v0_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"testing"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
 )
 
 func TestVersionEndpoint(t *testing.T) {
@@ -74,3 +78,32 @@ func TestVersionEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestNewVersionBody(t *testing.T) {
+	cfg := &config.Config{
+		Version:                  "v1.2.3",
+		GitCommit:                "abc123def456",
+		BuildTime:                "2025-10-14T12:00:00Z",
+		EnableRegistryValidation: true,
+		TelemetryTracingEndpoint: "http://otel-collector:4318",
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterVersionEndpoint(api, "/v0", v0.NewVersionBody(cfg))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/version", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body v0.VersionBody
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "v1.2.3", body.Version, "configured build metadata is kept")
+	assert.Equal(t, "abc123def456", body.GitCommit)
+	assert.Equal(t, "2025-10-14T12:00:00Z", body.BuildTime)
+	assert.Equal(t, runtime.Version(), body.GoVersion)
+	assert.True(t, body.Features["registry_validation"])
+	assert.True(t, body.Features["tracing"])
+	assert.False(t, body.Features["anonymous_auth"])
+	assert.False(t, body.Features["alerts"])
+}
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// corsMaxAge is how long browsers may cache a successful preflight response
+const corsMaxAge = 10 * time.Minute
+
+// corsExposedHeaders are response headers browser clients need beyond the CORS-safelisted ones
+var corsExposedHeaders = []string{RequestIDHeader, "ETag", "Link", "Deprecation", "Sunset", "Idempotent-Replayed"}
+
+// CORSPolicy describes which cross-origin requests are allowed for a class of routes
+type CORSPolicy struct {
+	// AllowedOrigins lists origins such as https://example.com; "*" allows any origin
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+}
+
+// ReadCORSPolicy returns the policy for public read endpoints
+func ReadCORSPolicy(origins []string) CORSPolicy {
+	return CORSPolicy{
+		AllowedOrigins: origins,
+		AllowedMethods: []string{http.MethodGet, http.MethodHead, http.MethodPost},
+		AllowedHeaders: []string{"Content-Type", "If-Modified-Since", "If-None-Match", RequestIDHeader},
+	}
+}
+
+// WriteCORSPolicy returns the policy for endpoints that publish, edit or authenticate
+func WriteCORSPolicy(origins []string) CORSPolicy {
+	return CORSPolicy{
+		AllowedOrigins: origins,
+		AllowedMethods: []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+		AllowedHeaders: []string{"Authorization", "Content-Type", "Idempotency-Key", "X-API-Key", "X-CSRF-Token", RequestIDHeader},
+	}
+}
+
+// ParseOrigins splits a comma-separated list of origins, ignoring blanks and trailing slashes
+func ParseOrigins(value string) []string {
+	var origins []string
+	for _, origin := range strings.Split(value, ",") {
+		origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+		if origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// allowsAnyOrigin reports whether the policy allows every origin
+func (p CORSPolicy) allowsAnyOrigin() bool {
+	return slices.Contains(p.AllowedOrigins, "*")
+}
+
+// allowsOrigin reports whether the policy allows requests from origin
+func (p CORSPolicy) allowsOrigin(origin string) bool {
+	if p.allowsAnyOrigin() {
+		return true
+	}
+	return slices.ContainsFunc(p.AllowedOrigins, func(allowed string) bool {
+		return strings.EqualFold(allowed, origin)
+	})
+}
+
+// allowsHeaders reports whether every header in a comma-separated Access-Control-Request-Headers value is allowed
+func (p CORSPolicy) allowsHeaders(requested string) bool {
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if !slices.ContainsFunc(p.AllowedHeaders, func(allowed string) bool {
+			return strings.EqualFold(allowed, header)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowOriginValue returns the Access-Control-Allow-Origin value for an allowed origin
+func (p CORSPolicy) allowOriginValue(origin string) string {
+	if p.allowsAnyOrigin() {
+		return "*"
+	}
+	return origin
+}
+
+// CORS applies the read policy to public read endpoints and the stricter write policy to everything
+// else. Preflight requests are answered directly: disallowed origins, methods or headers get 403.
+// Responses for disallowed origins on actual requests omit CORS headers, so browsers block them.
+func CORS(read, write CORSPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Responses differ by Origin, so shared caches must not serve one origin's response to another
+			w.Header().Add("Vary", "Origin")
+
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			requestedMethod := r.Header.Get("Access-Control-Request-Method")
+			if r.Method == http.MethodOptions && requestedMethod != "" {
+				handlePreflight(w, r, origin, requestedMethod, corsPolicyFor(requestedMethod, r.URL.Path, read, write))
+				return
+			}
+
+			policy := corsPolicyFor(r.Method, r.URL.Path, read, write)
+			if policy.allowsOrigin(origin) {
+				w.Header().Set("Access-Control-Allow-Origin", policy.allowOriginValue(origin))
+				w.Header().Set("Access-Control-Expose-Headers", strings.Join(corsExposedHeaders, ", "))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// handlePreflight answers a CORS preflight request according to policy
+func handlePreflight(w http.ResponseWriter, r *http.Request, origin, requestedMethod string, policy CORSPolicy) {
+	w.Header().Add("Vary", "Access-Control-Request-Method")
+	w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+	if !policy.allowsOrigin(origin) ||
+		!slices.Contains(policy.AllowedMethods, requestedMethod) ||
+		!policy.allowsHeaders(r.Header.Get("Access-Control-Request-Headers")) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", policy.allowOriginValue(origin))
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(policy.AllowedMethods, ", "))
+	w.Header().Set("Access-Control-Allow-Headers", strings.Join(policy.AllowedHeaders, ", "))
+	w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(corsMaxAge.Seconds())))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// corsPolicyFor selects the policy for a request. Reads, and batch lookups which only read
+// despite using POST, are public; all other requests use the write policy.
+func corsPolicyFor(method, path string, read, write CORSPolicy) CORSPolicy {
+	switch {
+	case method == http.MethodGet, method == http.MethodHead:
+		return read
+	case method == http.MethodPost && strings.HasSuffix(path, "/servers:batchGet"):
+		return read
+	default:
+		return write
+	}
+}
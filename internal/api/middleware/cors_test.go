@@ -0,0 +1,101 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/api/middleware"
+)
+
+func newCORSHandler(readOrigins, writeOrigins string) http.Handler {
+	cors := middleware.CORS(
+		middleware.ReadCORSPolicy(middleware.ParseOrigins(readOrigins)),
+		middleware.WriteCORSPolicy(middleware.ParseOrigins(writeOrigins)),
+	)
+	return cors(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestCORSActualRequests(t *testing.T) {
+	tests := []struct {
+		name         string
+		readOrigins  string
+		writeOrigins string
+		method       string
+		path         string
+		origin       string
+		expectOrigin string
+	}{
+		{name: "no origin", readOrigins: "*", method: http.MethodGet, path: "/v0/servers"},
+		{name: "any origin may read", readOrigins: "*", method: http.MethodGet, path: "/v0/servers", origin: "https://a.example", expectOrigin: "*"},
+		{name: "listed origin is echoed", readOrigins: "https://a.example, https://b.example/", method: http.MethodGet, path: "/v0/servers", origin: "https://b.example", expectOrigin: "https://b.example"},
+		{name: "unlisted origin gets no headers", readOrigins: "https://a.example", method: http.MethodGet, path: "/v0/servers", origin: "https://evil.example"},
+		{name: "batch get is a read", readOrigins: "*", method: http.MethodPost, path: "/v0/servers:batchGet", origin: "https://a.example", expectOrigin: "*"},
+		{name: "writes are same-origin by default", readOrigins: "*", method: http.MethodPost, path: "/v0/publish", origin: "https://a.example"},
+		{name: "listed origin may write", readOrigins: "*", writeOrigins: "https://admin.example", method: http.MethodPut, path: "/v0/servers/x/versions/1.0.0", origin: "https://admin.example", expectOrigin: "https://admin.example"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			newCORSHandler(tt.readOrigins, tt.writeOrigins).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Header().Values("Vary"), "Origin")
+			assert.Equal(t, tt.expectOrigin, w.Header().Get("Access-Control-Allow-Origin"))
+			if tt.expectOrigin != "" {
+				assert.Contains(t, w.Header().Get("Access-Control-Expose-Headers"), middleware.RequestIDHeader)
+			}
+		})
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		origin         string
+		method         string
+		headers        string
+		expectedStatus int
+	}{
+		{name: "read allowed", path: "/v0/servers", origin: "https://a.example", method: http.MethodGet, headers: "If-Modified-Since", expectedStatus: http.StatusNoContent},
+		{name: "read with disallowed header", path: "/v0/servers", origin: "https://a.example", method: http.MethodGet, headers: "Authorization", expectedStatus: http.StatusForbidden},
+		{name: "write from allowed origin", path: "/v0/publish", origin: "https://admin.example", method: http.MethodPost, headers: "authorization, content-type", expectedStatus: http.StatusNoContent},
+		{name: "write with API key", path: "/v0/publish", origin: "https://admin.example", method: http.MethodPost, headers: "x-api-key, content-type", expectedStatus: http.StatusNoContent},
+		{name: "write with session CSRF token", path: "/v1/tokens", origin: "https://admin.example", method: http.MethodDelete, headers: "X-CSRF-Token", expectedStatus: http.StatusNoContent},
+		{name: "write from public origin", path: "/v0/publish", origin: "https://a.example", method: http.MethodPost, headers: "Authorization", expectedStatus: http.StatusForbidden},
+		{name: "write method on read policy", path: "/v0/servers", origin: "https://a.example", method: http.MethodDelete, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", tt.method)
+			if tt.headers != "" {
+				req.Header.Set("Access-Control-Request-Headers", tt.headers)
+			}
+			w := httptest.NewRecorder()
+			newCORSHandler("*", "https://admin.example").ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Header().Values("Vary"), "Origin")
+			if tt.expectedStatus == http.StatusNoContent {
+				assert.NotEmpty(t, w.Header().Get("Access-Control-Allow-Origin"))
+				assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), tt.method)
+				assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+			} else {
+				assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// Recover turns panics in handlers into 500 responses, logging them and sending them to the error
+// reporting service, rather than letting them drop the connection
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// Handlers abort responses, such as streams, by panicking with ErrAbortHandler
+			if recovered == http.ErrAbortHandler { //nolint:errorlint // ErrAbortHandler is compared as net/http does
+				panic(recovered)
+			}
+
+			slog.ErrorContext(r.Context(), "Handler panicked", "method", r.Method, "path", r.URL.Path,
+				"panic", fmt.Sprint(recovered), "stack", string(debug.Stack()))
+			telemetry.ReportPanic(r.Context(), recovered, map[string]string{
+				"method": r.Method,
+				"path":   r.URL.Path,
+			})
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ErrorReportingTransformer is a Huma transformer that sends errors producing 5xx responses to the error
+// reporting service, with the request they failed
+func ErrorReportingTransformer(ctx huma.Context, _ string, v any) (any, error) {
+	var errModel *huma.ErrorModel
+	if err, ok := v.(error); !ok || !errors.As(err, &errModel) || errModel.Status < http.StatusInternalServerError {
+		return v, nil
+	}
+
+	route := ctx.URL().Path
+	if op := ctx.Operation(); op != nil && op.Path != "" {
+		route = op.Path
+	}
+	telemetry.ReportError(ctx.Context(), errors.New(errorMessages(errModel)), map[string]string{
+		"method": ctx.Method(),
+		"route":  route,
+		"status": strconv.Itoa(errModel.Status),
+	})
+	return v, nil
+}
@@ -0,0 +1,129 @@
+// Package middleware contains HTTP middleware shared by the registry API
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+// RequestIDHeader is the header used to accept and return request IDs
+const RequestIDHeader = "X-Request-Id"
+
+// maxRequestIDLength bounds client-supplied request IDs so they can't bloat logs
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestID assigns every request an ID, reusing a well-formed X-Request-Id from the
+// client if present. The ID is stored on the request context, included in the request's log records
+// and echoed in the response headers. The request's log records also include the ID of its trace, or
+// of the trace in the client's traceparent header while tracing is disabled, so a failed publish can
+// be found from the trace ID mcp-publisher prints.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		ctx := logging.With(WithRequestID(r.Context(), id), logging.RequestIDKey, id)
+		if traceID := requestTraceID(r); traceID != "" {
+			ctx = logging.With(ctx, logging.TraceIDKey, traceID)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requestTraceID returns the ID of the trace a request is part of, if any
+func requestTraceID(r *http.Request) string {
+	spanContext := trace.SpanContextFromContext(r.Context())
+	if !spanContext.IsValid() {
+		carrier := propagation.HeaderCarrier(r.Header)
+		spanContext = trace.SpanContextFromContext(propagation.TraceContext{}.Extract(r.Context(), carrier))
+	}
+	if !spanContext.IsValid() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// WithRequestID returns a copy of ctx carrying the given request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored on ctx, or an empty string if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// errorWithRequestID adds the request ID to Huma's RFC 9457 problem details
+type errorWithRequestID struct {
+	*huma.ErrorModel
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// RequestIDErrorTransformer is a Huma transformer that includes the request ID in error
+// responses and logs server errors along with the ID so they can be correlated with bug reports
+func RequestIDErrorTransformer(ctx huma.Context, _ string, v any) (any, error) {
+	var errModel *huma.ErrorModel
+	if err, ok := v.(error); !ok || !errors.As(err, &errModel) {
+		return v, nil
+	}
+
+	id := RequestIDFromContext(ctx.Context())
+	if errModel.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(ctx.Context(), "Request failed", "method", ctx.Method(), "path", ctx.URL().Path, "status", errModel.Status, "error", errorMessages(errModel))
+	}
+
+	if id == "" {
+		return v, nil
+	}
+	return &errorWithRequestID{ErrorModel: errModel, RequestID: id}, nil
+}
+
+// errorMessages flattens the detail and underlying errors of an error model for logging
+func errorMessages(errModel *huma.ErrorModel) string {
+	msg := errModel.Detail
+	for _, detail := range errModel.Errors {
+		if detail != nil {
+			msg += "; " + detail.Message
+		}
+	}
+	return msg
+}
+
+// isValidRequestID accepts short IDs made of characters that are safe to log and echo
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID generates a random 128-bit hex request ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,106 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/middleware"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name       string
+		incoming   string
+		expectSame bool
+	}{
+		{name: "generates ID when missing", incoming: ""},
+		{name: "accepts client ID", incoming: "client-abc_123.4:5", expectSame: true},
+		{name: "replaces ID with invalid characters", incoming: "bad id\nwith newline"},
+		{name: "replaces overly long ID", incoming: strings.Repeat("a", 200)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := middleware.RequestID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				seen = middleware.RequestIDFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers", nil)
+			if tt.incoming != "" {
+				req.Header.Set(middleware.RequestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			returned := w.Header().Get(middleware.RequestIDHeader)
+			assert.NotEmpty(t, returned)
+			assert.Equal(t, returned, seen, "context and header IDs should match")
+			if tt.expectSame {
+				assert.Equal(t, tt.incoming, returned)
+			} else {
+				assert.NotEqual(t, tt.incoming, returned)
+				assert.Len(t, returned, 32)
+			}
+		})
+	}
+}
+
+func TestRequestID_TraceID(t *testing.T) {
+	var attrs []slog.Attr
+	handler := middleware.RequestID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		attrs = logging.Attrs(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/publish", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Contains(t, attrs, slog.String(logging.TraceIDKey, "4bf92f3577b34da6a3ce929d0e0e4736"), "log records include the caller's trace ID")
+
+	req = httptest.NewRequest(http.MethodPost, "/v0/publish", nil)
+	req.Header.Set("traceparent", "not a trace")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	for _, attr := range attrs {
+		assert.NotEqual(t, logging.TraceIDKey, attr.Key)
+	}
+}
+
+func TestRequestIDErrorTransformer(t *testing.T) {
+	mux := http.NewServeMux()
+	cfg := huma.DefaultConfig("Test API", "1.0.0")
+	cfg.Transformers = append(cfg.Transformers, middleware.RequestIDErrorTransformer)
+	api := humago.New(mux, cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "fail",
+		Method:      http.MethodGet,
+		Path:        "/fail",
+	}, func(_ context.Context, _ *struct{}) (*struct{}, error) {
+		return nil, huma.Error404NotFound("Server not found")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-123")
+	w := httptest.NewRecorder()
+	middleware.RequestID(mux).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "req-123", body["request_id"])
+	assert.Equal(t, "Server not found", body["detail"])
+	assert.InDelta(t, float64(http.StatusNotFound), body["status"], 0)
+}
@@ -0,0 +1,67 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/api/middleware"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	cfg := middleware.SecurityHeadersConfig{
+		HSTSMaxAge:                365 * 24 * time.Hour,
+		HSTSIncludeSubdomains:     true,
+		ReferrerPolicy:            "no-referrer",
+		HTMLContentSecurityPolicy: middleware.DefaultHTMLContentSecurityPolicy,
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		expectCSP   bool
+	}{
+		{name: "JSON response", contentType: "application/json", expectCSP: false},
+		{name: "HTML response", contentType: "text/html; charset=utf-8", expectCSP: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := middleware.SecurityHeaders(cfg)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write([]byte("body"))
+			}))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+			assert.Equal(t, "max-age=31536000; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+			assert.Equal(t, "no-referrer", w.Header().Get("Referrer-Policy"))
+			if tt.expectCSP {
+				assert.Equal(t, middleware.DefaultHTMLContentSecurityPolicy, w.Header().Get("Content-Security-Policy"))
+			} else {
+				assert.Empty(t, w.Header().Get("Content-Security-Policy"))
+			}
+		})
+	}
+}
+
+func TestSecurityHeadersDisabledOptions(t *testing.T) {
+	handler := middleware.SecurityHeaders(middleware.SecurityHeadersConfig{})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+	assert.Empty(t, w.Header().Get("Referrer-Policy"))
+	assert.Empty(t, w.Header().Get("Content-Security-Policy"))
+}
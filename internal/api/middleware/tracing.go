@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// untracedPaths are probed or scraped too often for their spans to be worth keeping
+var untracedPaths = map[string]bool{
+	"/metrics":  true,
+	"/healthz":  true,
+	"/readyz":   true,
+	"/startupz": true,
+}
+
+// Tracing starts a server span for every request, continuing the caller's trace if it sent a
+// traceparent header. Database queries and package registry calls made for the request are recorded
+// as its children. The router renames the span after the matched route.
+func Tracing(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "HTTP request",
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return !untracedPaths[r.URL.Path]
+		}),
+		// Request metrics are recorded by the router, by route
+		otelhttp.WithMeterProvider(noop.NewMeterProvider()),
+		// Keep the name the router gave the span, unless the mux matched a pattern itself
+		otelhttp.WithSpanNameFormatter(func(operation string, r *http.Request) string {
+			if r.Pattern != "" {
+				return r.Pattern
+			}
+			return operation
+		}),
+	)
+}
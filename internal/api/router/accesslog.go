@@ -0,0 +1,102 @@
+package router
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+type accessLogKey struct{}
+
+// accessLogEntry collects what the API learns about a request for its access log record
+type accessLogEntry struct {
+	route string
+	actor string
+}
+
+// AccessLogHandler logs a record of every request next serves, with its method, route, status, latency,
+// response size, client IP and caller. Successful reads, which make up most traffic, are logged at
+// readSampleRate (from 0 to 1); writes and failed requests are always logged. Clients behind
+// trustedProxies are identified by X-Forwarded-For.
+func AccessLogHandler(next http.Handler, readSampleRate float64, trustedProxies []*net.IPNet) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		entry := &accessLogEntry{}
+		aw := &accessLogWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(aw, r.WithContext(context.WithValue(r.Context(), accessLogKey{}, entry)))
+
+		isRead := r.Method == http.MethodGet || r.Method == http.MethodHead
+		if isRead && aw.status < http.StatusBadRequest && readSampleRate < 1 && rand.Float64() >= readSampleRate { //nolint:gosec // Sampling needs no cryptographic randomness
+			return
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.EscapedPath()),
+			slog.Int("status", aw.status),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int64("bytes", aw.bytes),
+			slog.String("client_ip", v0.ClientIP(r.Header.Get("X-Forwarded-For"), r.RemoteAddr, trustedProxies)),
+		}
+		if entry.route != "" {
+			attrs = append(attrs, slog.String("route", entry.route))
+		}
+		if entry.actor != "" {
+			attrs = append(attrs, slog.String(logging.ActorKey, entry.actor))
+		}
+		slog.LogAttrs(r.Context(), slog.LevelInfo, "HTTP request", attrs...)
+	})
+}
+
+// AccessLogMiddleware records the route template of a request for its access log record
+func AccessLogMiddleware(ctx huma.Context, next func(huma.Context)) {
+	if entry, ok := ctx.Context().Value(accessLogKey{}).(*accessLogEntry); ok {
+		entry.route = getRoutePath(ctx)
+	}
+	next(ctx)
+}
+
+// setAccessLogActor records the caller of a request for its access log record
+func setAccessLogActor(ctx context.Context, actor string) {
+	if entry, ok := ctx.Value(accessLogKey{}).(*accessLogEntry); ok {
+		entry.actor = actor
+	}
+}
+
+// accessLogWriter records the status and size of a response
+type accessLogWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (w *accessLogWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *accessLogWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap allows http.ResponseController to reach the underlying writer
+func (w *accessLogWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
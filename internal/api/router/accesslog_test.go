@@ -0,0 +1,132 @@
+package router_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/middleware"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+// captureLogs sends the default logger's records to a buffer for the duration of the test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, logging.FormatJSON, "info")
+	require.NoError(t, err)
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func accessLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		if record["msg"] == "HTTP request" {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+func newAccessLogTestHandler(t *testing.T, cfg *config.Config, readSampleRate float64) http.Handler {
+	t.Helper()
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.AccessLogMiddleware)
+	api.UseMiddleware(router.BearerClaimsMiddleware(cfg))
+	api.UseMiddleware(router.LogContextMiddleware)
+	huma.Register(api, huma.Operation{OperationID: "get-server", Method: http.MethodGet, Path: "/v0/servers/{serverName}"},
+		func(_ context.Context, _ *struct {
+			ServerName string `path:"serverName"`
+		}) (*struct{ Body string }, error) {
+			return &struct{ Body string }{Body: "server"}, nil
+		})
+	huma.Register(api, huma.Operation{OperationID: "publish", Method: http.MethodPost, Path: "/v0/publish"},
+		func(_ context.Context, _ *struct{}) (*struct{}, error) {
+			return nil, nil
+		})
+	return middleware.RequestID(router.AccessLogHandler(mux, readSampleRate, httptestProxy))
+}
+
+func newAccessLogTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	return &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+}
+
+func TestAccessLogHandler(t *testing.T) {
+	cfg := newAccessLogTestConfig(t)
+	token, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "octocat",
+	})
+	require.NoError(t, err)
+
+	buf := captureLogs(t)
+	handler := newAccessLogTestHandler(t, cfg, 1)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/servers/io.github.octocat%2Fserver", nil)
+	req.Header.Set("Authorization", "Bearer "+token.RegistryToken)
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	req.Header.Set(middleware.RequestIDHeader, "access-log-test")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	records := accessLogRecords(t, buf)
+	require.Len(t, records, 1)
+	record := records[0]
+	assert.Equal(t, http.MethodGet, record["method"])
+	assert.Equal(t, "/v0/servers/{serverName}", record["route"])
+	assert.Equal(t, "/v0/servers/io.github.octocat%2Fserver", record["path"])
+	assert.InDelta(t, http.StatusOK, record["status"], 0)
+	assert.InDelta(t, w.Body.Len(), record["bytes"], 0)
+	assert.Contains(t, record, "duration_ms")
+	assert.Equal(t, "203.0.113.7", record["client_ip"])
+	assert.Equal(t, "access-log-test", record[logging.RequestIDKey])
+	assert.Equal(t, "github-at:octocat", record[logging.ActorKey])
+}
+
+func TestAccessLogHandler_Sampling(t *testing.T) {
+	buf := captureLogs(t)
+	handler := newAccessLogTestHandler(t, newAccessLogTestConfig(t), 0)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/v0/servers/com.example%2Fserver", nil),
+		httptest.NewRequest(http.MethodGet, "/v0/missing", nil),
+		httptest.NewRequest(http.MethodPost, "/v0/publish", nil),
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	var logged []string
+	for _, record := range accessLogRecords(t, buf) {
+		logged = append(logged, record["method"].(string)+" "+record["path"].(string))
+	}
+	assert.Equal(t, []string{"GET /v0/missing", "POST /v0/publish"}, logged, "successful reads are sampled out")
+}
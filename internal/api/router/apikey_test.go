@@ -0,0 +1,107 @@
+package router_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// staticAPITokens knows a single API token
+type staticAPITokens struct {
+	secret string
+	token  *database.APIToken
+}
+
+func (s staticAPITokens) AuthenticateAPIToken(_ context.Context, secret string) (*database.APIToken, error) {
+	if secret != s.secret {
+		return nil, database.ErrNotFound
+	}
+	return s.token, nil
+}
+
+type whoAmIInput struct {
+	Authorization string `header:"Authorization" required:"true"`
+}
+
+type whoAmIBody struct {
+	Method      string            `json:"method"`
+	Subject     string            `json:"subject"`
+	Permissions []auth.Permission `json:"permissions"`
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	jwtManager := auth.NewJWTManager(cfg)
+
+	permissions := []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}}
+	tokens := staticAPITokens{
+		secret: "mcpr_secret",
+		token:  &database.APIToken{Owner: "github-at:alice", Permissions: permissions, ExpiresAt: time.Now().Add(time.Hour)},
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.APIKeyMiddleware(api, cfg, tokens))
+	huma.Register(api, huma.Operation{OperationID: "whoami", Method: http.MethodGet, Path: "/whoami"},
+		func(ctx context.Context, input *whoAmIInput) (*v0.Response[whoAmIBody], error) {
+			claims, err := jwtManager.ValidateToken(ctx, strings.TrimPrefix(input.Authorization, "Bearer "))
+			if err != nil {
+				return nil, huma.Error401Unauthorized("Invalid Registry JWT", err)
+			}
+			return &v0.Response[whoAmIBody]{Body: whoAmIBody{
+				Method:      string(claims.AuthMethod),
+				Subject:     claims.AuthMethodSubject,
+				Permissions: claims.Permissions,
+			}}, nil
+		})
+
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{name: "API key header", headers: map[string]string{"X-API-Key": "mcpr_secret"}, expectedStatus: http.StatusOK},
+		{name: "API key as bearer token", headers: map[string]string{"Authorization": "Bearer mcpr_secret"}, expectedStatus: http.StatusOK},
+		{name: "unknown API key", headers: map[string]string{"X-API-Key": "mcpr_wrong"}, expectedStatus: http.StatusUnauthorized},
+		{name: "no credentials", expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedStatus == http.StatusOK {
+				var body whoAmIBody
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+				assert.Equal(t, whoAmIBody{Method: "api-token", Subject: "github-at:alice", Permissions: permissions}, body)
+			}
+		})
+	}
+}
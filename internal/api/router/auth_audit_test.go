@@ -0,0 +1,158 @@
+package router_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// recordedAuthEvents collects the events that would be written to the auth event log
+type recordedAuthEvents struct {
+	mu     sync.Mutex
+	events []*database.AuthEvent
+}
+
+func (r *recordedAuthEvents) RecordAuthEvent(_ context.Context, event *database.AuthEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordedAuthEvents) take() []*database.AuthEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := r.events
+	r.events = nil
+	return events
+}
+
+func TestAuthAuditMiddleware(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:        hex.EncodeToString(testSeed),
+		AuthLockoutThreshold: 2,
+		AuthLockoutDuration:  time.Minute,
+		TrustedProxies:       "192.0.2.1",
+	}
+	jwtManager := auth.NewJWTManager(cfg)
+	metrics, err := telemetry.NewMetrics(noop.NewMeterProvider().Meter("test"))
+	require.NoError(t, err)
+
+	events := &recordedAuthEvents{}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.BearerClaimsMiddleware(cfg))
+	api.UseMiddleware(router.AuthAuditMiddleware(cfg, events))
+	api.UseMiddleware(router.LoginThrottleMiddleware(api, v0auth.NewLoginThrottle(cfg), &recordedLockouts{}, metrics, httptestProxy))
+	huma.Register(api, huma.Operation{OperationID: "login", Method: http.MethodPost, Path: "/v0/auth/test", Tags: []string{"auth"}},
+		func(ctx context.Context, input *loginInput) (*struct{ Body auth.TokenResponse }, error) {
+			if err := v0auth.CheckLoginIdentity(ctx, "domain:"+input.Body.User); err != nil {
+				return nil, err
+			}
+			if input.Body.Password != "correct" {
+				return nil, huma.Error401Unauthorized("Wrong password")
+			}
+			response, err := jwtManager.GenerateTokenResponse(ctx, auth.JWTClaims{
+				AuthMethod:        auth.MethodDNS,
+				AuthMethodSubject: input.Body.User,
+				Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &struct{ Body auth.TokenResponse }{Body: *response}, nil
+		})
+	huma.Register(api, huma.Operation{OperationID: "admin", Method: http.MethodGet, Path: "/v1/admin/thing"},
+		func(_ context.Context, _ *struct{}) (*struct{}, error) {
+			return nil, huma.Error403Forbidden("Admins only")
+		})
+
+	login := func(clientIP, user, password string) int {
+		body := `{"user": "` + user + `", "password": "` + password + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/v0/auth/test", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", clientIP)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("successful login records the issued token", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, login("192.0.2.1", "example.com", "correct"))
+
+		recorded := events.take()
+		require.Len(t, recorded, 1)
+		event := recorded[0]
+		assert.Equal(t, database.AuthEventLogin, event.Event)
+		assert.Equal(t, database.AuthOutcomeSuccess, event.Outcome)
+		assert.Equal(t, "dns", event.AuthMethod)
+		assert.Equal(t, "example.com", event.Subject)
+		assert.Equal(t, "192.0.2.1", event.ClientIP)
+		assert.Equal(t, "POST /v0/auth/test", event.Endpoint)
+		assert.Equal(t, http.StatusOK, event.Status)
+		assert.NotEmpty(t, event.Details["jti"])
+		assert.Equal(t, "publish:com.example/*", event.Details["permissions"])
+	})
+
+	t.Run("failed and throttled logins record the identity", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, login("192.0.2.2", "example.org", "wrong"))
+		require.Equal(t, http.StatusUnauthorized, login("192.0.2.3", "example.org", "wrong"))
+		require.Equal(t, http.StatusTooManyRequests, login("192.0.2.4", "example.org", "correct"))
+
+		recorded := events.take()
+		require.Len(t, recorded, 3)
+		for i, outcome := range []string{database.AuthOutcomeFailure, database.AuthOutcomeFailure, database.AuthOutcomeThrottled} {
+			assert.Equal(t, database.AuthEventLogin, recorded[i].Event)
+			assert.Equal(t, outcome, recorded[i].Outcome)
+			assert.Equal(t, "domain:example.org", recorded[i].Subject)
+			assert.Empty(t, recorded[i].AuthMethod)
+		}
+	})
+
+	t.Run("permission denial records the caller", func(t *testing.T) {
+		response, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "mallory",
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/thing", nil)
+		req.Header.Set("Authorization", "Bearer "+response.RegistryToken)
+		req.Header.Set("X-Forwarded-For", "198.51.100.7")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusForbidden, w.Code)
+
+		recorded := events.take()
+		require.Len(t, recorded, 1)
+		assert.Equal(t, database.AuthEventAccess, recorded[0].Event)
+		assert.Equal(t, database.AuthOutcomeDenied, recorded[0].Outcome)
+		assert.Equal(t, "github-at", recorded[0].AuthMethod)
+		assert.Equal(t, "mallory", recorded[0].Subject)
+		assert.Equal(t, "198.51.100.7", recorded[0].ClientIP)
+		assert.Equal(t, "GET /v1/admin/thing", recorded[0].Endpoint)
+	})
+}
@@ -0,0 +1,80 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// staticMaintenanceStore always reports the same maintenance mode
+type staticMaintenanceStore struct {
+	enabled bool
+}
+
+func (s staticMaintenanceStore) GetMaintenanceMode(_ context.Context) (*database.MaintenanceMode, error) {
+	return &database.MaintenanceMode{Enabled: s.enabled, Message: "Migrating"}, nil
+}
+
+func (s staticMaintenanceStore) SetMaintenanceMode(_ context.Context, _ bool, _ string) (*database.MaintenanceMode, error) {
+	return &database.MaintenanceMode{Enabled: s.enabled}, nil
+}
+
+func newMaintenanceTestAPI(enabled bool) *http.ServeMux {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.MaintenanceMiddleware(api, v0.NewMaintenance(&config.Config{}, staticMaintenanceStore{enabled: enabled})))
+
+	v0.RegisterPingEndpoint(api, "/v1")
+	for _, op := range []huma.Operation{
+		{OperationID: "publish", Method: http.MethodPost, Path: "/v1/publish"},
+		{OperationID: "delete", Method: http.MethodDelete, Path: "/v1/servers/{name}"},
+		{OperationID: "token", Method: http.MethodPost, Path: "/v1/auth/none", Tags: []string{"auth"}},
+	} {
+		huma.Register(api, op, func(_ context.Context, _ *struct{}) (*struct{}, error) {
+			return nil, nil
+		})
+	}
+
+	return mux
+}
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		enabled        bool
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "writes allowed when disabled", enabled: false, method: http.MethodPost, path: "/v1/publish", expectedStatus: http.StatusNoContent},
+		{name: "writes rejected when enabled", enabled: true, method: http.MethodPost, path: "/v1/publish", expectedStatus: http.StatusServiceUnavailable},
+		{name: "publish dry runs allowed when enabled", enabled: true, method: http.MethodPost, path: "/v1/publish?dryRun=true", expectedStatus: http.StatusNoContent},
+		{name: "other writes rejected when enabled despite dryRun", enabled: true, method: http.MethodDelete, path: "/v1/servers/example?dryRun=true", expectedStatus: http.StatusServiceUnavailable},
+		{name: "reads allowed when enabled", enabled: true, method: http.MethodGet, path: "/v1/ping", expectedStatus: http.StatusOK},
+		{name: "token exchange allowed when enabled", enabled: true, method: http.MethodPost, path: "/v1/auth/none", expectedStatus: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := newMaintenanceTestAPI(tt.enabled)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				assert.Equal(t, "300", w.Header().Get("Retry-After"))
+				assert.Contains(t, w.Body.String(), "Migrating")
+			}
+		})
+	}
+}
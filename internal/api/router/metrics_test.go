@@ -0,0 +1,177 @@
+package router_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/stats"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+type publishTestInput struct {
+	DryRun bool `query:"dryRun"`
+	Status int  `query:"status"`
+}
+
+func TestPublishMetricsMiddleware(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	metrics, err := telemetry.NewMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.PublishMetricsMiddleware(metrics))
+	huma.Register(api, huma.Operation{OperationID: "publish", Method: http.MethodPost, Path: "/v0/publish"},
+		func(_ context.Context, input *publishTestInput) (*struct{}, error) {
+			if input.Status != 0 {
+				return nil, huma.NewError(input.Status, "failed")
+			}
+			return nil, nil
+		})
+	huma.Register(api, huma.Operation{OperationID: "get", Method: http.MethodGet, Path: "/v0/servers"},
+		func(_ context.Context, _ *struct{}) (*struct{}, error) {
+			return nil, nil
+		})
+
+	for _, target := range []string{"/v0/publish", "/v0/publish", "/v0/publish?dryRun=true", "/v0/publish?status=401", "/v0/publish?status=422", "/v0/publish?status=500"} {
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, target, nil))
+	}
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v0/servers", nil))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	counts := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != telemetry.Namespace+".publish.requests" {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				outcome, _ := point.Attributes.Value(attribute.Key("outcome"))
+				counts[outcome.AsString()] += point.Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"published":    2,
+		"validated":    1,
+		"unauthorized": 1,
+		"rejected":     1,
+		"error":        1,
+	}, counts)
+}
+
+type fetchCountStore map[string]int64
+
+func (f fetchCountStore) IncrementFetchCounts(_ context.Context, _ time.Time, counts map[string]int64) error {
+	for name, count := range counts {
+		f[name] += count
+	}
+	return nil
+}
+
+type fetchStatsTestInput struct {
+	ServerName string `path:"serverName"`
+}
+
+type fetchStatsTestOutput struct {
+	Body string
+}
+
+func TestFetchStatsMiddleware(t *testing.T) {
+	store := fetchCountStore{}
+	recorder := stats.NewRecorder(store)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.FetchStatsMiddleware(recorder))
+	for _, path := range []string{
+		"/v0/servers/{serverName}/versions",
+		"/v0/servers/{serverName}/versions/{version}",
+		"/v0/servers/{serverName}/readme",
+		"/v0/servers/{serverName}/icon",
+		"/v0/servers/{serverName}/badge.svg",
+		"/v0/servers/{serverName}/stats",
+	} {
+		huma.Register(api, huma.Operation{OperationID: path, Method: http.MethodGet, Path: path},
+			func(_ context.Context, _ *fetchStatsTestInput) (*fetchStatsTestOutput, error) {
+				return &fetchStatsTestOutput{Body: "ok"}, nil
+			})
+	}
+
+	for _, target := range []string{
+		"/v0/servers/com.example%2Fweather/versions",
+		"/v0/servers/com.example%2Fweather/versions/latest",
+		"/v0/servers/com.example%2Fweather/readme",
+		"/v0/servers/com.example%2Fweather/icon",
+		"/v0/servers/com.example%2Fweather/badge.svg",
+		"/v0/servers/com.example%2Fweather/stats",
+	} {
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	require.NoError(t, recorder.Flush(context.Background()))
+	assert.Equal(t, fetchCountStore{"com.example/weather": 2}, store)
+}
+
+func TestMetricsAccessHandler(t *testing.T) {
+	metricsHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("metrics"))
+	})
+	_, private, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		token         string
+		networks      []*net.IPNet
+		remoteAddr    string
+		forwardedFor  string
+		authorization string
+		expected      int
+	}{
+		{name: "open", remoteAddr: "203.0.113.1:1234", expected: http.StatusOK},
+		{name: "allowed network", networks: []*net.IPNet{private}, remoteAddr: "10.1.2.3:1234", expected: http.StatusOK},
+		{name: "other network", networks: []*net.IPNet{private}, remoteAddr: "203.0.113.1:1234", expected: http.StatusForbidden},
+		{name: "forwarded from allowed network", networks: []*net.IPNet{private}, remoteAddr: "192.0.2.1:1234", forwardedFor: "10.1.2.3", expected: http.StatusOK},
+		{name: "spoofed forwarded address", networks: []*net.IPNet{private}, remoteAddr: "192.0.2.1:1234", forwardedFor: "10.1.2.3, 203.0.113.1", expected: http.StatusForbidden},
+		{name: "forwarded by untrusted client", networks: []*net.IPNet{private}, remoteAddr: "203.0.113.1:1234", forwardedFor: "10.1.2.3", expected: http.StatusForbidden},
+		{name: "valid token", token: "secret", remoteAddr: "203.0.113.1:1234", authorization: "Bearer secret", expected: http.StatusOK},
+		{name: "wrong token", token: "secret", remoteAddr: "203.0.113.1:1234", authorization: "Bearer other", expected: http.StatusUnauthorized},
+		{name: "missing token", token: "secret", remoteAddr: "203.0.113.1:1234", expected: http.StatusUnauthorized},
+		{name: "token from other network", token: "secret", networks: []*net.IPNet{private}, remoteAddr: "203.0.113.1:1234", authorization: "Bearer secret", expected: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			router.MetricsAccessHandler(metricsHandler, tt.token, tt.networks, httptestProxy).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expected, w.Code)
+			if tt.expected == http.StatusOK {
+				assert.Equal(t, "metrics", w.Body.String())
+			}
+		})
+	}
+}
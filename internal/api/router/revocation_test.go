@@ -0,0 +1,85 @@
+package router_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// staticRevocations is a fixed set of revoked token IDs
+type staticRevocations map[string]bool
+
+func (s staticRevocations) IsTokenRevoked(_ context.Context, jti string) (bool, error) {
+	return s[jti], nil
+}
+
+func TestTokenRevocationMiddleware(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+	jwtManager := auth.NewJWTManager(cfg)
+
+	newToken := func(t *testing.T) (string, string) {
+		t.Helper()
+		response, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "alice",
+		})
+		require.NoError(t, err)
+		claims, err := jwtManager.ValidateToken(context.Background(), response.RegistryToken)
+		require.NoError(t, err)
+		require.NotEmpty(t, claims.ID)
+		return response.RegistryToken, claims.ID
+	}
+	revokedToken, revokedID := newToken(t)
+	validToken, _ := newToken(t)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.BearerClaimsMiddleware(cfg))
+	api.UseMiddleware(router.TokenRevocationMiddleware(api, staticRevocations{revokedID: true}))
+	huma.Register(api, huma.Operation{OperationID: "ping", Method: http.MethodGet, Path: "/ping"},
+		func(_ context.Context, _ *struct{}) (*struct{}, error) {
+			return nil, nil
+		})
+
+	tests := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "valid token", authorization: "Bearer " + validToken, expectedStatus: http.StatusNoContent},
+		{name: "revoked token", authorization: "Bearer " + revokedToken, expectedStatus: http.StatusUnauthorized},
+		{name: "revoked token with lowercase scheme", authorization: "bearer " + revokedToken, expectedStatus: http.StatusUnauthorized},
+		{name: "invalid token passed through", authorization: "Bearer not-a-token", expectedStatus: http.StatusNoContent},
+		{name: "API token passed through", authorization: "Bearer mcpr_secret", expectedStatus: http.StatusNoContent},
+		{name: "no credentials", expectedStatus: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
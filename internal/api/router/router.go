@@ -2,9 +2,18 @@
 package router
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"net"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,10 +21,18 @@ import (
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/api/middleware"
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/sitemap"
+	"github.com/modelcontextprotocol/registry/internal/stats"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
@@ -85,6 +102,518 @@ func MetricTelemetryMiddleware(metrics *telemetry.Metrics, options ...Middleware
 	}
 }
 
+// TracingMiddleware names the span of a request after its route and tags it with the request ID
+func TracingMiddleware(ctx huma.Context, next func(huma.Context)) {
+	if span := trace.SpanFromContext(ctx.Context()); span.IsRecording() {
+		routePath := getRoutePath(ctx)
+		span.SetName(ctx.Method() + " " + routePath)
+		span.SetAttributes(
+			attribute.String("http.route", routePath),
+			attribute.String("request.id", middleware.RequestIDFromContext(ctx.Context())),
+		)
+	}
+	next(ctx)
+}
+
+// LogContextMiddleware includes the caller and the server a request is about in the log records made
+// for it, and the caller in its access log record
+func LogContextMiddleware(ctx huma.Context, next func(huma.Context)) {
+	var fields []any
+	if claims := bearerClaims(ctx); claims != nil {
+		actor := string(claims.AuthMethod) + ":" + claims.AuthMethodSubject
+		fields = append(fields, logging.ActorKey, actor)
+		setAccessLogActor(ctx.Context(), actor)
+	}
+	if serverName, err := url.PathUnescape(ctx.Param("serverName")); err == nil && serverName != "" {
+		fields = append(fields, logging.ServerNameKey, serverName)
+	}
+	if len(fields) > 0 {
+		ctx = huma.WithContext(ctx, logging.With(ctx.Context(), fields...))
+	}
+	next(ctx)
+}
+
+// FetchStatsMiddleware counts successful fetches of individual servers, their version list or one of
+// their versions, for usage statistics
+func FetchStatsMiddleware(recorder *stats.Recorder) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		next(ctx)
+
+		routePath := getRoutePath(ctx)
+		if ctx.Method() != http.MethodGet || ctx.Status() != http.StatusOK {
+			return
+		}
+		// Only reads of the server's versions count, not its README, icon, badge or stats
+		if !strings.HasSuffix(routePath, "/servers/{serverName}/versions") && !strings.HasSuffix(routePath, "/servers/{serverName}/versions/{version}") {
+			return
+		}
+
+		serverName, err := url.PathUnescape(ctx.Param("serverName"))
+		if err != nil {
+			return
+		}
+		recorder.RecordFetch(serverName)
+	}
+}
+
+// PublishMetricsMiddleware counts publish requests by outcome
+func PublishMetricsMiddleware(metrics *telemetry.Metrics) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		next(ctx)
+
+		if ctx.Method() != http.MethodPost || !strings.HasSuffix(getRoutePath(ctx), "/publish") {
+			return
+		}
+		dryRun := ctx.Query("dryRun") == "true"
+		metrics.Publishes.Add(ctx.Context(), 1, metric.WithAttributes(
+			attribute.String("outcome", publishOutcome(ctx.Status(), dryRun)),
+			attribute.Bool("dry_run", dryRun),
+		))
+	}
+}
+
+// publishOutcome names the outcome of a publish request from its response status
+func publishOutcome(status int, dryRun bool) string {
+	switch {
+	case status < 300 && dryRun:
+		return "validated"
+	case status < 300:
+		return "published"
+	case status == http.StatusUnauthorized:
+		return "unauthorized"
+	case status == http.StatusForbidden:
+		return "forbidden"
+	case status == http.StatusConflict:
+		return "conflict"
+	case status < 500:
+		return "rejected"
+	default:
+		return "error"
+	}
+}
+
+// MetricsAccessHandler only serves /metrics to scrapers presenting bearerToken, if it is set, from
+// networks, if any are given. Scrapers behind trustedProxies are identified by X-Forwarded-For.
+func MetricsAccessHandler(next http.Handler, bearerToken string, networks, trustedProxies []*net.IPNet) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(networks) > 0 {
+			ip := net.ParseIP(v0.ClientIP(r.Header.Get("X-Forwarded-For"), r.RemoteAddr, trustedProxies))
+			if ip == nil || !slices.ContainsFunc(networks, func(n *net.IPNet) bool { return n.Contains(ip) }) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+		}
+		if bearerToken != "" {
+			token, ok := auth.BearerToken(r.Header.Get("Authorization"))
+			if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(bearerToken)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// MaintenanceMiddleware rejects write requests with 503 while the registry is in maintenance mode.
+// Reads, batch lookups, token exchange and the maintenance endpoints themselves keep working.
+func MaintenanceMiddleware(api huma.API, maintenance *v0.Maintenance) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if !isWriteOperation(ctx) {
+			next(ctx)
+			return
+		}
+
+		status := maintenance.Status(ctx.Context())
+		if !status.Enabled {
+			next(ctx)
+			return
+		}
+
+		ctx.SetHeader("Retry-After", strconv.Itoa(int(maintenanceRetryAfter.Seconds())))
+		_ = huma.WriteErr(api, ctx, http.StatusServiceUnavailable, status.Message)
+	}
+}
+
+// maintenanceRetryAfter is the suggested delay before retrying a write during maintenance
+const maintenanceRetryAfter = 5 * time.Minute
+
+// isWriteOperation reports whether the request may modify registry data
+func isWriteOperation(ctx huma.Context) bool {
+	if ctx.Method() == http.MethodGet || ctx.Method() == http.MethodHead {
+		return false
+	}
+
+	routePath := getRoutePath(ctx)
+	// Publish dry runs persist nothing. Other writes ignore the parameter, so it cannot exempt them.
+	if ctx.Method() == http.MethodPost && strings.HasSuffix(routePath, "/publish") {
+		if dryRun, _ := strconv.ParseBool(ctx.Query("dryRun")); dryRun {
+			return false
+		}
+	}
+	if strings.HasSuffix(routePath, "/servers:batchGet") || strings.HasSuffix(routePath, "/admin/maintenance") {
+		return false
+	}
+	if op := ctx.Operation(); op != nil && slices.Contains(op.Tags, "auth") {
+		return false
+	}
+	return true
+}
+
+// LoginLockoutRecorder records login lockouts in the audit log
+type LoginLockoutRecorder interface {
+	RecordLoginLockout(ctx context.Context, key, endpoint string, failures int, lockedUntil time.Time) error
+}
+
+// LoginThrottleMiddleware protects login and token exchange endpoints against brute force. Failed
+// attempts (401 and 403 responses) are counted per client IP and per identity checked by the endpoint,
+// and throttled clients are turned away with 429 before the endpoint runs. Lockouts are counted in
+// metrics and recorded in the audit log. Clients behind trustedProxies are identified by X-Forwarded-For.
+func LoginThrottleMiddleware(
+	api huma.API, throttle *v0auth.LoginThrottle, lockouts LoginLockoutRecorder, metrics *telemetry.Metrics, trustedProxies []*net.IPNet,
+) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if !isLoginOperation(ctx) {
+			next(ctx)
+			return
+		}
+
+		ipKey := "ip:" + v0.ClientIP(ctx.Header("X-Forwarded-For"), ctx.RemoteAddr(), trustedProxies)
+		if retryAfter := throttle.Check(ipKey); retryAfter > 0 {
+			ctx.SetHeader("Retry-After", v0auth.RetryAfterSeconds(retryAfter))
+			_ = huma.WriteErr(api, ctx, http.StatusTooManyRequests, v0auth.TooManyLoginAttemptsMessage)
+			return
+		}
+
+		attemptCtx, attempt := throttle.WithAttempt(ctx.Context())
+		ctx = huma.WithContext(ctx, attemptCtx)
+		next(ctx)
+
+		keys := []string{ipKey}
+		if attempt.ThrottleIdentity {
+			keys = append(keys, attempt.Identity)
+		}
+
+		switch status := ctx.Status(); {
+		case status == http.StatusUnauthorized || status == http.StatusForbidden:
+			for _, key := range keys {
+				failures, lockedUntil := throttle.RecordFailure(key)
+				if lockedUntil.IsZero() {
+					continue
+				}
+
+				endpoint := getRoutePath(ctx)
+				slog.WarnContext(ctx.Context(), "Login locked out", "key", key, "until", lockedUntil.Format(time.RFC3339), "failures", failures, "path", endpoint)
+				metrics.AuthLockouts.Add(ctx.Context(), 1, metric.WithAttributes(
+					attribute.String("path", endpoint),
+					attribute.String("kind", strings.SplitN(key, ":", 2)[0]),
+				))
+				// The response has been written, so the lockout is only logged if it cannot be audited
+				if err := lockouts.RecordLoginLockout(context.WithoutCancel(ctx.Context()), key, endpoint, failures, lockedUntil); err != nil {
+					slog.ErrorContext(ctx.Context(), "Failed to record login lockout", "key", key, logging.Err(err))
+				}
+			}
+		case status >= 200 && status < 300 && attempt.ThrottleIdentity:
+			// A successful login proves the identity, but not that the client's other attempts were benign
+			throttle.RecordSuccess(attempt.Identity)
+		}
+	}
+}
+
+// isLoginOperation reports whether the request is a login or token exchange
+func isLoginOperation(ctx huma.Context) bool {
+	op := ctx.Operation()
+	return op != nil && ctx.Method() == http.MethodPost && slices.Contains(op.Tags, "auth")
+}
+
+// AuthEventRecorder records logins and permission denials in the auth event log
+type AuthEventRecorder interface {
+	RecordAuthEvent(ctx context.Context, event *database.AuthEvent) error
+}
+
+// AuthAuditMiddleware records every login and token exchange at the auth endpoints, with the tokens
+// issued or the identity a failed login was for, and every 403 from other endpoints, with the
+// caller's identity. Events include the client IP, and are queryable by admins for investigations.
+func AuthAuditMiddleware(cfg *config.Config, events AuthEventRecorder) func(huma.Context, func(huma.Context)) {
+	trustedProxies, err := cfg.TrustedProxyNetworks()
+	if err != nil {
+		panic(fmt.Sprintf("Invalid trusted proxies configuration: %v", err))
+	}
+
+	return func(ctx huma.Context, next func(huma.Context)) {
+		clientIP := v0.ClientIP(ctx.Header("X-Forwarded-For"), ctx.RemoteAddr(), trustedProxies)
+		endpoint := ctx.Method() + " " + getRoutePath(ctx)
+
+		if !isLoginOperation(ctx) {
+			next(ctx)
+			if ctx.Status() != http.StatusForbidden {
+				return
+			}
+
+			event := &database.AuthEvent{
+				Event:    database.AuthEventAccess,
+				Outcome:  database.AuthOutcomeDenied,
+				ClientIP: clientIP,
+				Endpoint: endpoint,
+				Status:   http.StatusForbidden,
+			}
+			if claims := bearerClaims(ctx); claims != nil {
+				event.AuthMethod = string(claims.AuthMethod)
+				event.Subject = claims.AuthMethodSubject
+			}
+			recordAuthEvent(ctx, events, event)
+			return
+		}
+
+		attemptCtx, attempt := v0auth.WithLoginAttempt(ctx.Context())
+		attemptCtx, issued := auth.WithIssuedTokens(attemptCtx)
+		ctx = huma.WithContext(ctx, attemptCtx)
+		next(ctx)
+
+		status := ctx.Status()
+		if status >= 200 && status < 300 {
+			// Requests that issue no token, such as revocations, are not logins
+			for _, claims := range issued.Claims() {
+				recordAuthEvent(ctx, events, &database.AuthEvent{
+					Event:      database.AuthEventLogin,
+					Outcome:    database.AuthOutcomeSuccess,
+					AuthMethod: string(claims.AuthMethod),
+					Subject:    claims.AuthMethodSubject,
+					ClientIP:   clientIP,
+					Endpoint:   endpoint,
+					Status:     status,
+					Details: map[string]string{
+						"jti":         claims.ID,
+						"expires_at":  claims.ExpiresAt.UTC().Format(time.RFC3339),
+						"permissions": formatPermissions(claims.Permissions),
+					},
+				})
+			}
+			return
+		}
+
+		outcome := database.AuthOutcomeFailure
+		if status == http.StatusTooManyRequests {
+			outcome = database.AuthOutcomeThrottled
+		}
+		recordAuthEvent(ctx, events, &database.AuthEvent{
+			Event:    database.AuthEventLogin,
+			Outcome:  outcome,
+			Subject:  attempt.Identity,
+			ClientIP: clientIP,
+			Endpoint: endpoint,
+			Status:   status,
+		})
+	}
+}
+
+// recordAuthEvent records an auth event after the response has been written, so failures are only logged
+func recordAuthEvent(ctx huma.Context, events AuthEventRecorder, event *database.AuthEvent) {
+	if err := events.RecordAuthEvent(context.WithoutCancel(ctx.Context()), event); err != nil {
+		slog.ErrorContext(ctx.Context(), "Failed to record auth event", "endpoint", event.Endpoint, logging.Err(err))
+	}
+}
+
+// formatPermissions lists permissions as space-separated action:pattern pairs
+func formatPermissions(permissions []auth.Permission) string {
+	formatted := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		formatted = append(formatted, string(permission.Action)+":"+permission.ResourcePattern)
+	}
+	return strings.Join(formatted, " ")
+}
+
+// APITokenAuthenticator looks up API tokens by their secret
+type APITokenAuthenticator interface {
+	AuthenticateAPIToken(ctx context.Context, secret string) (*database.APIToken, error)
+}
+
+// APIKeyHeader is the header CI jobs can send an API token in instead of exchanging it for a Registry JWT
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyMiddleware lets an API token be used directly, in the X-API-Key header or as the bearer
+// token, wherever a Registry JWT is accepted. The token is swapped for a Registry JWT with its
+// permissions before the request reaches the handler, so endpoints need no changes to support it.
+func APIKeyMiddleware(api huma.API, cfg *config.Config, tokens APITokenAuthenticator) func(huma.Context, func(huma.Context)) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	return func(ctx huma.Context, next func(huma.Context)) {
+		secret := ctx.Header(APIKeyHeader)
+		if secret == "" {
+			authHeader := ctx.Header("Authorization")
+			if bearer, ok := auth.BearerToken(authHeader); ok && strings.HasPrefix(bearer, service.APITokenPrefix) {
+				secret = bearer
+			}
+		}
+		if secret == "" {
+			next(ctx)
+			return
+		}
+
+		token, err := tokens.AuthenticateAPIToken(ctx.Context(), secret)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				_ = huma.WriteErr(api, ctx, http.StatusUnauthorized, "Invalid, expired or revoked API token")
+				return
+			}
+			_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "Failed to check API token", err)
+			return
+		}
+
+		response, err := jwtManager.GenerateTokenResponse(ctx.Context(), v0.APITokenClaims(token))
+		if err != nil {
+			_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "Failed to generate Registry JWT", err)
+			return
+		}
+
+		r, _ := humago.Unwrap(ctx)
+		r.Header.Del(APIKeyHeader)
+		r.Header.Set("Authorization", "Bearer "+response.RegistryToken)
+		next(ctx)
+	}
+}
+
+// WebSessionAuthenticator looks up browser sessions by their cookie value
+type WebSessionAuthenticator interface {
+	AuthenticateWebSession(ctx context.Context, secret string) (*database.WebSession, error)
+}
+
+// SessionMiddleware lets the session cookie of a browser session be used wherever a Registry JWT is
+// accepted, when the request has no other credentials. Requests other than GET, HEAD and OPTIONS must
+// send the session's CSRF token in the X-CSRF-Token header, so other sites cannot make them on the
+// user's behalf. Cookies of ended sessions are cleared and the request continues unauthenticated.
+func SessionMiddleware(api huma.API, cfg *config.Config, sessions WebSessionAuthenticator) func(huma.Context, func(huma.Context)) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if cfg.SessionDuration <= 0 || ctx.Header("Authorization") != "" || ctx.Header(APIKeyHeader) != "" {
+			next(ctx)
+			return
+		}
+		r, _ := humago.Unwrap(ctx)
+		cookie, err := r.Cookie(v0.SessionCookieName)
+		if err != nil || cookie.Value == "" {
+			next(ctx)
+			return
+		}
+
+		session, err := sessions.AuthenticateWebSession(ctx.Context(), cookie.Value)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				clearCookie := v0.ClearSessionCookie(cfg)
+				ctx.AppendHeader("Set-Cookie", clearCookie.String())
+				next(ctx)
+				return
+			}
+			_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "Failed to check session", err)
+			return
+		}
+
+		switch ctx.Method() {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			csrfToken := ctx.Header(v0.CSRFTokenHeader)
+			if subtle.ConstantTimeCompare([]byte(csrfToken), []byte(session.CSRFToken)) != 1 {
+				_ = huma.WriteErr(api, ctx, http.StatusForbidden, "Missing or invalid CSRF token. Send the session's CSRF token in the "+v0.CSRFTokenHeader+" header")
+				return
+			}
+		}
+
+		response, err := jwtManager.GenerateTokenResponse(ctx.Context(), v0.WebSessionClaims(session))
+		if err != nil {
+			_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "Failed to generate Registry JWT", err)
+			return
+		}
+
+		r.Header.Set("Authorization", "Bearer "+response.RegistryToken)
+		next(huma.WithContext(ctx, v0.WithWebSession(ctx.Context(), session)))
+	}
+}
+
+// StepUpMiddleware requires a token from verifying with a passkey for writes made with a global or admin
+// permission, when the caller's account has passkeys, so that a stolen admin token alone cannot change
+// the registry. Auth endpoints are exempt: they issue tokens, and check passkey management themselves.
+func StepUpMiddleware(api huma.API, cfg *config.Config, passkeys v0.PasskeyLister) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		op := ctx.Operation()
+		if cfg.WebAuthnRPID == "" || op == nil || slices.Contains(op.Tags, "auth") {
+			next(ctx)
+			return
+		}
+		switch ctx.Method() {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next(ctx)
+			return
+		}
+
+		claims := bearerClaims(ctx)
+		if claims == nil || !v0.NeedsStepUp(claims.Permissions) {
+			next(ctx)
+			return
+		}
+
+		var statusErr huma.StatusError
+		if err := v0.RequireStepUp(ctx.Context(), passkeys, cfg, claims); errors.As(err, &statusErr) {
+			_ = huma.WriteErr(api, ctx, statusErr.GetStatus(), statusErr.Error())
+			return
+		}
+		next(ctx)
+	}
+}
+
+type bearerClaimsKey struct{}
+
+// BearerClaimsMiddleware validates the bearer Registry JWT of a request, once API tokens and sessions have
+// been exchanged for one, so that the middleware after it can share its claims instead of each validating
+// the token again. Requests without a valid token have no claims.
+func BearerClaimsMiddleware(cfg *config.Config) func(huma.Context, func(huma.Context)) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	return func(ctx huma.Context, next func(huma.Context)) {
+		if bearer, ok := auth.BearerToken(ctx.Header("Authorization")); ok {
+			if claims, err := jwtManager.ValidateToken(ctx.Context(), bearer); err == nil {
+				ctx = huma.WithValue(ctx, bearerClaimsKey{}, claims)
+			}
+		}
+		next(ctx)
+	}
+}
+
+// bearerClaims returns the claims of the request's Registry JWT found by BearerClaimsMiddleware, if any
+func bearerClaims(ctx huma.Context) *auth.JWTClaims {
+	claims, _ := ctx.Context().Value(bearerClaimsKey{}).(*auth.JWTClaims)
+	return claims
+}
+
+// TokenRevocationChecker looks up the Registry JWT revocation list
+type TokenRevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// TokenRevocationMiddleware rejects requests whose bearer Registry JWT has been revoked. Invalid
+// tokens are passed through for the handler to reject as usual.
+func TokenRevocationMiddleware(api huma.API, revocations TokenRevocationChecker) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		claims := bearerClaims(ctx)
+		if claims == nil || claims.ID == "" {
+			next(ctx)
+			return
+		}
+
+		revoked, err := revocations.IsTokenRevoked(ctx.Context(), claims.ID)
+		if err != nil {
+			_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "Failed to check token revocation", err)
+			return
+		}
+		if revoked {
+			_ = huma.WriteErr(api, ctx, http.StatusUnauthorized, "Registry JWT has been revoked. Log in again")
+			return
+		}
+
+		next(ctx)
+	}
+}
+
 // WithSkipPaths allows skipping instrumentation for specific paths
 func WithSkipPaths(paths ...string) MiddlewareOption {
 	return func(c *middlewareConfig) {
@@ -103,11 +632,10 @@ func handle404(w http.ResponseWriter, r *http.Request) {
 	detail := "Endpoint not found. See /docs for the API documentation."
 
 	// Provide suggestions for common API endpoint mistakes
-	if !strings.HasPrefix(path, "/v0/") && !strings.HasPrefix(path, "/v0.1/") {
+	if !strings.HasPrefix(path, "/v0/") && !strings.HasPrefix(path, "/v0.1/") && !strings.HasPrefix(path, "/v1/") {
 		detail = fmt.Sprintf(
-			"Endpoint not found. Did you mean '%s' or '%s'? See /docs for the API documentation.",
-			"/v0.1"+path,
-			"/v0"+path,
+			"Endpoint not found. Did you mean '%s'? See /docs for the API documentation.",
+			"/v1"+path,
 		)
 	}
 
@@ -116,6 +644,9 @@ func handle404(w http.ResponseWriter, r *http.Request) {
 		"status": 404,
 		"detail": detail,
 	}
+	if requestID := middleware.RequestIDFromContext(r.Context()); requestID != "" {
+		errorBody["request_id"] = requestID
+	}
 
 	// Use JSON marshal to ensure consistent formatting
 	jsonData, err := json.Marshal(errorBody)
@@ -130,12 +661,23 @@ func handle404(w http.ResponseWriter, r *http.Request) {
 }
 
 // NewHumaAPI creates a new Huma API with all routes registered
-func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.ServeMux, metrics *telemetry.Metrics, versionInfo *v0.VersionBody) huma.API {
+func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.ServeMux, metrics *telemetry.Metrics, versionInfo *v0.VersionBody, fetchStats *stats.Recorder) huma.API {
 	// Create Huma API configuration
 	humaConfig := huma.DefaultConfig("Official MCP Registry", "1.0.0")
 	humaConfig.Info.Description = "A community driven registry service for Model Context Protocol (MCP) servers.\n\n[GitHub repository](https://github.com/modelcontextprotocol/registry) | [Documentation](https://github.com/modelcontextprotocol/registry/tree/main/docs)"
 	// Disable $schema property in responses: https://github.com/danielgtaylor/huma/issues/230
 	humaConfig.CreateHooks = []func(huma.Config) huma.Config{}
+	// Include the request ID in error responses
+	humaConfig.Transformers = append(humaConfig.Transformers, middleware.RequestIDErrorTransformer)
+	// Report server errors to the error reporting service
+	humaConfig.Transformers = append(humaConfig.Transformers, middleware.ErrorReportingTransformer)
+	// Apply ?fields= sparse fieldsets to server responses
+	humaConfig.Transformers = append(humaConfig.Transformers, v0.SparseFieldsTransformer)
+	// Render responses as YAML when requested with Accept: application/yaml
+	humaConfig.Formats = maps.Clone(huma.DefaultFormats)
+	for _, mediaType := range yamlMediaTypes {
+		humaConfig.Formats[mediaType] = YAMLFormat
+	}
 
 	// Create a new API using humago adapter for standard library
 	api := humago.New(mux, humaConfig)
@@ -154,10 +696,22 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 			Name:        "auth",
 			Description: "Authentication operations for obtaining tokens to publish servers",
 		},
+		{
+			Name:        "tokens",
+			Description: "Operations for managing API tokens used to publish from CI",
+		},
+		{
+			Name:        "sessions",
+			Description: "Operations for managing browser sessions of a registry web UI",
+		},
 		{
 			Name:        "admin",
 			Description: "Administrative operations for managing servers (requires elevated permissions)",
 		},
+		{
+			Name:        "stats",
+			Description: "Aggregated usage statistics for servers in the registry",
+		},
 		{
 			Name:        "health",
 			Description: "Health check endpoint for monitoring service availability",
@@ -172,17 +726,80 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 		},
 	}
 
+	// Record the route of requests for the access log
+	api.UseMiddleware(AccessLogMiddleware)
+
 	// Add metrics middleware with options
 	api.UseMiddleware(MetricTelemetryMiddleware(metrics,
-		WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
+		WithSkipPaths("/health", "/healthz", "/readyz", "/startupz", "/metrics", "/ping", "/docs"),
 	))
 
+	// Name request spans after their route
+	api.UseMiddleware(TracingMiddleware)
+
+	// Signal deprecation of v0 endpoints in favour of v1
+	api.UseMiddleware(DeprecationMiddleware(api, cfg))
+
+	// Add fetch statistics middleware
+	api.UseMiddleware(FetchStatsMiddleware(fetchStats))
+
+	// Count publishes by outcome
+	api.UseMiddleware(PublishMetricsMiddleware(metrics))
+
+	// Reject writes while in maintenance mode
+	maintenance := v0.NewMaintenance(cfg, registry)
+	api.UseMiddleware(MaintenanceMiddleware(api, maintenance))
+
+	// Accept API tokens in place of Registry JWTs
+	api.UseMiddleware(APIKeyMiddleware(api, cfg, registry))
+
+	// Accept browser session cookies, with a CSRF token on writes, in place of Registry JWTs
+	api.UseMiddleware(SessionMiddleware(api, cfg, registry))
+
+	// Validate the Registry JWT once, for the middleware below, after API tokens and sessions have been exchanged
+	api.UseMiddleware(BearerClaimsMiddleware(cfg))
+
+	// Reject revoked Registry JWTs
+	api.UseMiddleware(TokenRevocationMiddleware(api, registry))
+
+	// Require passkey verification for writes with global or admin permissions
+	api.UseMiddleware(StepUpMiddleware(api, cfg, registry))
+
+	// Include the caller and server in log records
+	api.UseMiddleware(LogContextMiddleware)
+
+	// Record logins and permission denials, including those turned away by the login throttle
+	api.UseMiddleware(AuthAuditMiddleware(cfg, registry))
+
+	// Slow down and lock out repeated failed logins
+	trustedProxies, err := cfg.TrustedProxyNetworks()
+	if err != nil {
+		panic(fmt.Sprintf("Invalid trusted proxies configuration: %v", err))
+	}
+	api.UseMiddleware(LoginThrottleMiddleware(api, v0auth.NewLoginThrottle(cfg), registry, metrics, trustedProxies))
+
+	// Register Kubernetes probes outside of the versioned API
+	v0.RegisterProbeEndpoints(api, cfg, registry, telemetry.Dependencies)
+
 	// Register routes for all API versions
 	RegisterV0Routes(api, cfg, registry, metrics, versionInfo)
 	RegisterV0_1Routes(api, cfg, registry, metrics, versionInfo)
+	RegisterV1Routes(api, cfg, registry, metrics, versionInfo)
+	v0.RegisterMaintenanceEndpoints(api, "/v1", cfg, maintenance)
+
+	// Add /metrics for Prometheus metrics using promhttp, limited to the configured scrapers
+	if cfg.TelemetryMetricsEnabled {
+		networks, err := cfg.MetricsAllowedNetworks()
+		if err != nil {
+			panic(fmt.Sprintf("Invalid telemetry metrics configuration: %v", err))
+		}
+		mux.Handle("/metrics", MetricsAccessHandler(metrics.PrometheusHandler(), cfg.TelemetryMetricsBearerToken, networks, trustedProxies))
+	}
 
-	// Add /metrics for Prometheus metrics using promhttp
-	mux.Handle("/metrics", metrics.PrometheusHandler())
+	// Add sitemap for search engine discovery of server detail pages
+	sitemapGenerator := sitemap.NewGenerator(registry, cfg.PublicURL)
+	mux.Handle("GET /sitemap.xml", sitemapGenerator.IndexHandler())
+	mux.Handle("GET /sitemaps/{file}", sitemapGenerator.PageHandler())
 
 	// Add redirect from / to docs and 404 handler for all other routes
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,127 @@
+package router_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// staticWebSessions knows a single browser session
+type staticWebSessions struct {
+	secret  string
+	session *database.WebSession
+}
+
+func (s staticWebSessions) AuthenticateWebSession(_ context.Context, secret string) (*database.WebSession, error) {
+	if secret != s.secret {
+		return nil, database.ErrNotFound
+	}
+	return s.session, nil
+}
+
+func TestSessionMiddleware(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), SessionDuration: time.Hour}
+	jwtManager := auth.NewJWTManager(cfg)
+
+	permissions := []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.alice/*"}}
+	sessions := staticWebSessions{
+		secret: "session-secret",
+		session: &database.WebSession{
+			ID:          7,
+			Owner:       "github-at:alice",
+			AuthMethod:  auth.MethodGitHubAT,
+			Subject:     "alice",
+			Permissions: permissions,
+			CSRFToken:   "csrf-token",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		},
+	}
+	bearer, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{AuthMethod: auth.MethodNone, AuthMethodSubject: "bob"})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.SessionMiddleware(api, cfg, sessions))
+	whoAmI := func(ctx context.Context, input *whoAmIInput) (*v0.Response[whoAmIBody], error) {
+		claims, err := jwtManager.ValidateToken(ctx, strings.TrimPrefix(input.Authorization, "Bearer "))
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Invalid Registry JWT", err)
+		}
+		return &v0.Response[whoAmIBody]{Body: whoAmIBody{
+			Method:      string(claims.AuthMethod),
+			Subject:     claims.AuthMethodSubject,
+			Permissions: claims.Permissions,
+		}}, nil
+	}
+	huma.Register(api, huma.Operation{OperationID: "whoami", Method: http.MethodGet, Path: "/whoami"}, whoAmI)
+	huma.Register(api, huma.Operation{OperationID: "whoami-post", Method: http.MethodPost, Path: "/whoami"}, whoAmI)
+
+	sessionBody := whoAmIBody{Method: "github-at", Subject: "alice", Permissions: permissions}
+	tests := []struct {
+		name            string
+		method          string
+		cookie          string
+		headers         map[string]string
+		expectedStatus  int
+		expectedBody    *whoAmIBody
+		expectedCleared bool
+	}{
+		{name: "read with session", method: http.MethodGet, cookie: "session-secret", expectedStatus: http.StatusOK, expectedBody: &sessionBody},
+		{name: "write with CSRF token", method: http.MethodPost, cookie: "session-secret", headers: map[string]string{"X-CSRF-Token": "csrf-token"}, expectedStatus: http.StatusOK, expectedBody: &sessionBody},
+		{name: "write without CSRF token", method: http.MethodPost, cookie: "session-secret", expectedStatus: http.StatusForbidden},
+		{name: "write with wrong CSRF token", method: http.MethodPost, cookie: "session-secret", headers: map[string]string{"X-CSRF-Token": "other"}, expectedStatus: http.StatusForbidden},
+		{
+			name:           "bearer token takes precedence",
+			method:         http.MethodPost,
+			cookie:         "session-secret",
+			headers:        map[string]string{"Authorization": "Bearer " + bearer.RegistryToken},
+			expectedStatus: http.StatusOK,
+			expectedBody:   &whoAmIBody{Method: "none", Subject: "bob"},
+		},
+		{name: "ended session", method: http.MethodGet, cookie: "expired-secret", expectedStatus: http.StatusUnprocessableEntity, expectedCleared: true},
+		{name: "no session", method: http.MethodGet, expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/whoami", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: v0.SessionCookieName, Value: tt.cookie})
+			}
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedBody != nil {
+				var body whoAmIBody
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+				assert.Equal(t, *tt.expectedBody, body)
+			}
+			assert.Equal(t, tt.expectedCleared, strings.Contains(w.Header().Get("Set-Cookie"), v0.SessionCookieName+"=;"))
+		})
+	}
+}
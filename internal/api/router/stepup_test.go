@@ -0,0 +1,112 @@
+package router_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// fakePasskeys gives the listed accounts one passkey each
+type fakePasskeys map[string]bool
+
+func (f fakePasskeys) ListPasskeys(_ context.Context, owner string) ([]*database.Passkey, error) {
+	if !f[owner] {
+		return nil, nil
+	}
+	return []*database.Passkey{{ID: 1, Owner: owner, Name: "YubiKey"}}, nil
+}
+
+func TestStepUpMiddleware(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	cfg := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed), WebAuthnRPID: "registry.example.com"}
+	jwtManager := auth.NewJWTManager(cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.BearerClaimsMiddleware(cfg))
+	api.UseMiddleware(router.StepUpMiddleware(api, cfg, fakePasskeys{"github-at:admin": true}))
+	ok := func(_ context.Context, _ *struct{}) (*struct{}, error) { return nil, nil }
+	huma.Register(api, huma.Operation{OperationID: "publish", Method: http.MethodPost, Path: "/publish"}, ok)
+	huma.Register(api, huma.Operation{OperationID: "list", Method: http.MethodGet, Path: "/publish"}, ok)
+	huma.Register(api, huma.Operation{OperationID: "login", Method: http.MethodPost, Path: "/auth/login", Tags: []string{"auth"}}, ok)
+
+	admin := []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}}
+	do := func(t *testing.T, method, path string, claims auth.JWTClaims) *httptest.ResponseRecorder {
+		t.Helper()
+		token, err := jwtManager.GenerateTokenResponse(context.Background(), claims)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token.RegistryToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("admins with a passkey must verify with it", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/publish", auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "admin", Permissions: admin})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "passkey verification")
+
+		w = do(t, http.MethodPost, "/publish", auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "admin", Permissions: admin, StepUp: true})
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	})
+
+	t.Run("the bearer scheme is case-insensitive", func(t *testing.T) {
+		token, err := jwtManager.GenerateTokenResponse(context.Background(), auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "admin", Permissions: admin})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/publish", nil)
+		req.Header.Set("Authorization", "bearer "+token.RegistryToken)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("API tokens of admins with a passkey are refused", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/publish", auth.JWTClaims{AuthMethod: auth.MethodAPIToken, AuthMethodSubject: "github-at:admin", Permissions: admin})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "API tokens")
+	})
+
+	t.Run("admin permissions on a namespace need verifying too", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/publish", auth.JWTClaims{
+			AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "admin",
+			Permissions: []auth.Permission{{Action: auth.PermissionActionAdmin, ResourcePattern: "com.example/*"}},
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("other tokens, reads and logins do not need to verify", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/publish", auth.JWTClaims{
+			AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "admin",
+			Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.admin/*"}},
+		})
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		w = do(t, http.MethodGet, "/publish", auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "admin", Permissions: admin})
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+		w = do(t, http.MethodPost, "/auth/login", auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "admin", Permissions: admin})
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	})
+
+	t.Run("admins without a passkey do not need to verify", func(t *testing.T) {
+		w := do(t, http.MethodPost, "/publish", auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "other-admin", Permissions: admin})
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,193 @@
+package router_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// recordedLockouts collects the lockouts that would be written to the audit log
+type recordedLockouts struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (r *recordedLockouts) RecordLoginLockout(_ context.Context, key, _ string, _ int, _ time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+type loginInput struct {
+	Body struct {
+		User     string `json:"user"`
+		Password string `json:"password"`
+	}
+}
+
+// httptestProxy trusts the address of httptest requests as a proxy, so that tests can set their
+// client address in X-Forwarded-For
+var httptestProxy = []*net.IPNet{{IP: net.ParseIP("192.0.2.1").To4(), Mask: net.CIDRMask(32, 32)}}
+
+func TestLoginThrottleMiddleware(t *testing.T) {
+	metrics, err := telemetry.NewMetrics(noop.NewMeterProvider().Meter("test"))
+	require.NoError(t, err)
+
+	newAPI := func(t *testing.T) (*http.ServeMux, *recordedLockouts) {
+		t.Helper()
+		cfg := &config.Config{AuthLockoutThreshold: 3, AuthLockoutDuration: time.Minute}
+		lockouts := &recordedLockouts{}
+
+		mux := http.NewServeMux()
+		api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+		api.UseMiddleware(router.LoginThrottleMiddleware(api, v0auth.NewLoginThrottle(cfg), lockouts, metrics, httptestProxy))
+		huma.Register(api, huma.Operation{OperationID: "login", Method: http.MethodPost, Path: "/login", Tags: []string{"auth"}},
+			func(ctx context.Context, input *loginInput) (*struct{}, error) {
+				if err := v0auth.CheckLoginIdentity(ctx, "user:"+input.Body.User); err != nil {
+					return nil, err
+				}
+				if input.Body.Password != "correct" {
+					return nil, huma.Error401Unauthorized("Wrong password")
+				}
+				return nil, nil
+			})
+		huma.Register(api, huma.Operation{OperationID: "login-signed", Method: http.MethodPost, Path: "/login-signed", Tags: []string{"auth"}},
+			func(ctx context.Context, input *loginInput) (*struct{}, error) {
+				v0auth.RecordLoginIdentity(ctx, "user:"+input.Body.User)
+				if input.Body.Password != "correct" {
+					return nil, huma.Error401Unauthorized("Invalid signature")
+				}
+				return nil, nil
+			})
+		huma.Register(api, huma.Operation{OperationID: "ping", Method: http.MethodPost, Path: "/ping"},
+			func(_ context.Context, _ *struct{}) (*struct{}, error) {
+				return nil, huma.Error401Unauthorized("Not logged in")
+			})
+		return mux, lockouts
+	}
+
+	do := func(mux *http.ServeMux, path, clientIP, user, password string) *httptest.ResponseRecorder {
+		body := `{"user": "` + user + `", "password": "` + password + `"}`
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", clientIP)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("client is locked out after repeated failures", func(t *testing.T) {
+		mux, lockouts := newAPI(t)
+		for i := range 3 {
+			assert.Equal(t, http.StatusUnauthorized, do(mux, "/login", "192.0.2.1", "user"+string(rune('a'+i)), "wrong").Code)
+		}
+
+		w := do(mux, "/login", "192.0.2.1", "someone-else", "correct")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+		assert.Equal(t, []string{"ip:192.0.2.1"}, lockouts.keys)
+
+		// Other clients are unaffected
+		assert.Equal(t, http.StatusNoContent, do(mux, "/login", "192.0.2.2", "someone-else", "correct").Code)
+	})
+
+	t.Run("identity is locked out across clients", func(t *testing.T) {
+		mux, lockouts := newAPI(t)
+		for i := range 3 {
+			assert.Equal(t, http.StatusUnauthorized, do(mux, "/login", "192.0.2."+string(rune('1'+i)), "alice", "wrong").Code)
+		}
+
+		w := do(mux, "/login", "198.51.100.1", "alice", "correct")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Contains(t, w.Body.String(), "Too many failed login attempts")
+		assert.Equal(t, []string{"user:alice"}, lockouts.keys)
+	})
+
+	t.Run("identity is not locked out by failures it is not throttled for", func(t *testing.T) {
+		mux, lockouts := newAPI(t)
+		for i := range 5 {
+			assert.Equal(t, http.StatusUnauthorized, do(mux, "/login-signed", "192.0.2."+string(rune('1'+i)), "alice", "wrong").Code)
+		}
+
+		assert.Equal(t, http.StatusNoContent, do(mux, "/login-signed", "198.51.100.1", "alice", "correct").Code)
+		assert.Empty(t, lockouts.keys)
+	})
+
+	t.Run("successful login resets the identity's failures", func(t *testing.T) {
+		mux, lockouts := newAPI(t)
+		assert.Equal(t, http.StatusUnauthorized, do(mux, "/login", "192.0.2.1", "alice", "wrong").Code)
+		assert.Equal(t, http.StatusUnauthorized, do(mux, "/login", "192.0.2.2", "alice", "wrong").Code)
+		assert.Equal(t, http.StatusNoContent, do(mux, "/login", "192.0.2.3", "alice", "correct").Code)
+		assert.Equal(t, http.StatusUnauthorized, do(mux, "/login", "192.0.2.4", "alice", "wrong").Code)
+		assert.Equal(t, http.StatusUnauthorized, do(mux, "/login", "192.0.2.5", "alice", "wrong").Code)
+		assert.Empty(t, lockouts.keys)
+	})
+
+	t.Run("other operations are not throttled", func(t *testing.T) {
+		mux, lockouts := newAPI(t)
+		for range 5 {
+			assert.Equal(t, http.StatusUnauthorized, do(mux, "/ping", "192.0.2.1", "", "").Code)
+		}
+		assert.Empty(t, lockouts.keys)
+	})
+}
+
+func TestLoginThrottle_ProgressiveDelay(t *testing.T) {
+	throttle := v0auth.NewLoginThrottle(&config.Config{AuthLockoutThreshold: 10, AuthLockoutDuration: time.Hour})
+
+	for range 3 {
+		_, lockedUntil := throttle.RecordFailure("ip:192.0.2.1")
+		assert.True(t, lockedUntil.IsZero())
+		assert.Zero(t, throttle.Check("ip:192.0.2.1"), "the first failures are not delayed")
+	}
+
+	previous := time.Duration(0)
+	for range 6 {
+		_, lockedUntil := throttle.RecordFailure("ip:192.0.2.1")
+		assert.True(t, lockedUntil.IsZero())
+		delay := throttle.Check("ip:192.0.2.1")
+		assert.Greater(t, delay, previous, "each further failure is delayed longer")
+		previous = delay
+	}
+
+	failures, lockedUntil := throttle.RecordFailure("ip:192.0.2.1")
+	assert.Equal(t, 10, failures)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), lockedUntil, time.Minute)
+
+	disabled := v0auth.NewLoginThrottle(&config.Config{})
+	for range 20 {
+		disabled.RecordFailure("ip:192.0.2.1")
+	}
+	assert.Zero(t, disabled.Check("ip:192.0.2.1"))
+}
+
+func TestLoginThrottle_ManyClients(t *testing.T) {
+	throttle := v0auth.NewLoginThrottle(&config.Config{AuthLockoutThreshold: 10, AuthLockoutDuration: time.Hour})
+	for range 10 {
+		throttle.RecordFailure("ip:192.0.2.1")
+	}
+
+	// Once the throttle is full, new clients replace others that are not locked out
+	for i := range 200_000 {
+		throttle.RecordFailure("ip:2001:db8::" + strconv.FormatInt(int64(i), 16))
+	}
+	assert.NotZero(t, throttle.Check("ip:192.0.2.1"), "lockouts are kept")
+}
@@ -0,0 +1,64 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/modelcontextprotocol/registry/internal/api/middleware"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.TracingMiddleware)
+	huma.Register(api, huma.Operation{OperationID: "get-server", Method: http.MethodGet, Path: "/v0/servers/{serverName}"},
+		func(ctx context.Context, _ *struct {
+			ServerName string `path:"serverName"`
+		}) (*struct{}, error) {
+			// Work done for the request, such as database queries, is part of its trace
+			_, span := otel.Tracer("test").Start(ctx, "db SELECT")
+			span.End()
+			return nil, nil
+		})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.Tracing(middleware.RequestID(mux))
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/servers/com.example%2Fserver", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set(middleware.RequestIDHeader, "test-request")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2, "probes are not traced")
+	query, request := spans[0], spans[1]
+	assert.Equal(t, "GET /v0/servers/{serverName}", request.Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", request.SpanContext().TraceID().String(), "the caller's trace is continued")
+	assert.Contains(t, request.Attributes(), attribute.String("request.id", "test-request"))
+	assert.Equal(t, request.SpanContext().SpanID(), query.Parent().SpanID())
+}
@@ -17,10 +17,27 @@ func RegisterV0Routes(
 	v0.RegisterHealthEndpoint(api, "/v0", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0")
 	v0.RegisterVersionEndpoint(api, "/v0", versionInfo)
+	v0.RegisterPublisherReleaseEndpoint(api, "/v0", v0.NewPublisherReleases(cfg))
 	v0.RegisterServersEndpoints(api, "/v0", registry)
+	v0.RegisterPackageEndpoints(api, "/v0", registry)
+	v0.RegisterStatsEndpoints(api, "/v0", registry)
+	v0.RegisterBadgeEndpoint(api, "/v0", registry)
+	v0.RegisterEventsEndpoint(api, "/v0", registry)
 	v0.RegisterEditEndpoints(api, "/v0", registry, cfg)
-	v0auth.RegisterAuthEndpoints(api, "/v0", cfg)
-	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterTransferEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterReadmeEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterIconEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterNamespaceEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterOrganizationEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterDelegationEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterPublisherServersEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterReportEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterTokenEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterSessionEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterDeviceAuthEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterPasskeyEndpoints(api, "/v0", registry, cfg)
+	v0auth.RegisterAuthEndpoints(api, "/v0", cfg, registry)
+	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg, publishOptions(cfg)...)
 }
 
 func RegisterV0_1Routes(
@@ -29,8 +46,34 @@ func RegisterV0_1Routes(
 	v0.RegisterHealthEndpoint(api, "/v0.1", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0.1")
 	v0.RegisterVersionEndpoint(api, "/v0.1", versionInfo)
+	v0.RegisterPublisherReleaseEndpoint(api, "/v0.1", v0.NewPublisherReleases(cfg))
 	v0.RegisterServersEndpoints(api, "/v0.1", registry)
+	v0.RegisterPackageEndpoints(api, "/v0.1", registry)
+	v0.RegisterStatsEndpoints(api, "/v0.1", registry)
+	v0.RegisterBadgeEndpoint(api, "/v0.1", registry)
+	v0.RegisterEventsEndpoint(api, "/v0.1", registry)
 	v0.RegisterEditEndpoints(api, "/v0.1", registry, cfg)
-	v0auth.RegisterAuthEndpoints(api, "/v0.1", cfg)
-	v0.RegisterPublishEndpoint(api, "/v0.1", registry, cfg)
+	v0.RegisterTransferEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterReadmeEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterIconEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterNamespaceEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterOrganizationEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterDelegationEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterPublisherServersEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterReportEndpoint(api, "/v0.1", registry, cfg)
+	v0.RegisterTokenEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterSessionEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterDeviceAuthEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterPasskeyEndpoints(api, "/v0.1", registry, cfg)
+	v0auth.RegisterAuthEndpoints(api, "/v0.1", cfg, registry)
+	v0.RegisterPublishEndpoint(api, "/v0.1", registry, cfg, publishOptions(cfg)...)
+}
+
+// publishOptions configures the publish endpoint from cfg
+func publishOptions(cfg *config.Config) []v0.PublishOption {
+	var opts []v0.PublishOption
+	if checker := v0auth.NewRepositoryPermissionChecker(cfg); checker != nil {
+		opts = append(opts, v0.WithRepositoryPermissions(checker))
+	}
+	return opts
 }
@@ -0,0 +1,201 @@
+package router
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// deprecatedPrefixes are the API versions superseded by /v1
+var deprecatedPrefixes = []string{"/v0", "/v0.1"}
+
+// RegisterV1Routes registers the stable v1 API. Response shapes under /v1 are frozen:
+// only additive, backward-compatible changes may be made to them. TestV1ResponseContract
+// checks them against testdata/v1_responses.json, as the handlers are shared with v0.
+func RegisterV1Routes(
+	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics, versionInfo *v0.VersionBody,
+) {
+	v0.RegisterHealthEndpoint(api, "/v1", cfg, metrics)
+	v0.RegisterPingEndpoint(api, "/v1")
+	v0.RegisterVersionEndpoint(api, "/v1", versionInfo)
+	v0.RegisterPublisherReleaseEndpoint(api, "/v1", v0.NewPublisherReleases(cfg))
+	v0.RegisterServersEndpoints(api, "/v1", registry)
+	v0.RegisterPackageEndpoints(api, "/v1", registry)
+	v0.RegisterStatsEndpoints(api, "/v1", registry)
+	v0.RegisterBadgeEndpoint(api, "/v1", registry)
+	v0.RegisterEventsEndpoint(api, "/v1", registry)
+	v0.RegisterEditEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterTransferEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterReadmeEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterIconEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterNamespaceEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterOrganizationEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterDelegationEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterPublisherServersEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterReportEndpoint(api, "/v1", registry, cfg)
+	v0.RegisterTokenEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterSessionEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterDeviceAuthEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterPasskeyEndpoints(api, "/v1", registry, cfg)
+	v0auth.RegisterAuthEndpoints(api, "/v1", cfg, registry)
+	v0.RegisterPublishEndpoint(api, "/v1", registry, cfg, publishOptions(cfg)...)
+	v0.RegisterNamespaceDisputeEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterReportAdminEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterAuthEventAdminEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterAuditEventAdminEndpoints(api, "/v1", registry, cfg)
+	v0.RegisterDependenciesEndpoint(api, "/v1", cfg, telemetry.Dependencies)
+	v0.RegisterRevalidateEndpoint(api, "/v1", registry, cfg)
+	RegisterMigrationEndpoint(api, cfg)
+}
+
+// EndpointMigration maps a deprecated endpoint to its v1 replacement
+type EndpointMigration struct {
+	Method    string `json:"method" example:"GET" doc:"HTTP method"`
+	Path      string `json:"path" example:"/v0/servers" doc:"Deprecated endpoint path"`
+	Successor string `json:"successor" example:"/v1/servers" doc:"Replacement endpoint path"`
+}
+
+// MigrationBody represents the migration map response body
+type MigrationBody struct {
+	DeprecatedVersions []string            `json:"deprecated_versions" example:"[\"v0\",\"v0.1\"]" doc:"API versions that are deprecated"`
+	DeprecationDate    string              `json:"deprecation_date,omitempty" example:"2025-10-16" doc:"Date the deprecated versions were deprecated"`
+	SunsetDate         string              `json:"sunset_date,omitempty" example:"2026-04-16" doc:"Date after which the deprecated versions may be removed"`
+	Endpoints          []EndpointMigration `json:"endpoints" doc:"Deprecated endpoints and their replacements"`
+}
+
+// RegisterMigrationEndpoint registers the endpoint describing how to migrate from v0 to v1
+func RegisterMigrationEndpoint(api huma.API, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-migration-map-v1",
+		Method:      http.MethodGet,
+		Path:        "/v1/migration",
+		Summary:     "Get v0 to v1 migration map",
+		Description: "List every deprecated v0 endpoint along with its v1 replacement and the deprecation schedule",
+		Tags:        []string{"version"},
+	}, func(_ context.Context, _ *struct{}) (*v0.Response[MigrationBody], error) {
+		return &v0.Response[MigrationBody]{
+			Body: MigrationBody{
+				DeprecatedVersions: []string{"v0", "v0.1"},
+				DeprecationDate:    cfg.V0DeprecationDate,
+				SunsetDate:         cfg.V0SunsetDate,
+				Endpoints:          buildMigrationMap(api.OpenAPI()),
+			},
+		}, nil
+	})
+}
+
+// buildMigrationMap pairs each deprecated operation with the matching /v1 operation
+func buildMigrationMap(openAPI *huma.OpenAPI) []EndpointMigration {
+	migrations := []EndpointMigration{}
+	for path, item := range openAPI.Paths {
+		for method, op := range pathOperations(item) {
+			if op == nil {
+				continue
+			}
+			if successorPath, ok := successorOf(openAPI, method, path); ok {
+				migrations = append(migrations, EndpointMigration{Method: method, Path: path, Successor: successorPath})
+			}
+		}
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		if migrations[i].Path != migrations[j].Path {
+			return migrations[i].Path < migrations[j].Path
+		}
+		return migrations[i].Method < migrations[j].Method
+	})
+
+	return migrations
+}
+
+// successorOf returns the path of the /v1 operation replacing the deprecated operation at method and path, if any
+func successorOf(openAPI *huma.OpenAPI, method, path string) (string, bool) {
+	prefix := deprecatedPrefix(path)
+	if prefix == "" {
+		return "", false
+	}
+	successorPath := "/v1" + strings.TrimPrefix(path, prefix)
+	successor, ok := openAPI.Paths[successorPath]
+	if !ok || pathOperations(successor)[method] == nil {
+		return "", false
+	}
+	return successorPath, true
+}
+
+func pathOperations(item *huma.PathItem) map[string]*huma.Operation {
+	return map[string]*huma.Operation{
+		http.MethodGet:    item.Get,
+		http.MethodPost:   item.Post,
+		http.MethodPut:    item.Put,
+		http.MethodPatch:  item.Patch,
+		http.MethodDelete: item.Delete,
+	}
+}
+
+// deprecatedPrefix returns the deprecated version prefix of path, if any
+func deprecatedPrefix(path string) string {
+	for _, prefix := range deprecatedPrefixes {
+		if strings.HasPrefix(path, prefix+"/") {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// DeprecationMiddleware adds Deprecation, Sunset and successor Link headers (RFC 9745, RFC 8594)
+// to responses from deprecated API versions. The successor link is only added for operations that
+// have a /v1 replacement in api.
+func DeprecationMiddleware(api huma.API, cfg *config.Config) func(huma.Context, func(huma.Context)) {
+	deprecation := ""
+	if cfg.V0DeprecationDate != "" {
+		if date, err := time.Parse(time.DateOnly, cfg.V0DeprecationDate); err == nil {
+			deprecation = "@" + strconv.FormatInt(date.Unix(), 10)
+		} else {
+			slog.Warn("Ignoring invalid V0_DEPRECATION_DATE", "value", cfg.V0DeprecationDate, logging.Err(err))
+		}
+	}
+
+	sunset := ""
+	if cfg.V0SunsetDate != "" {
+		if date, err := time.Parse(time.DateOnly, cfg.V0SunsetDate); err == nil {
+			sunset = date.UTC().Format(http.TimeFormat)
+		} else {
+			slog.Warn("Ignoring invalid V0_SUNSET_DATE", "value", cfg.V0SunsetDate, logging.Err(err))
+		}
+	}
+
+	return func(ctx huma.Context, next func(huma.Context)) {
+		path := ctx.URL().Path
+		prefix := deprecatedPrefix(path)
+		if prefix == "" || deprecation == "" {
+			next(ctx)
+			return
+		}
+
+		ctx.SetHeader("Deprecation", deprecation)
+		if sunset != "" {
+			ctx.SetHeader("Sunset", sunset)
+		}
+		if op := ctx.Operation(); op != nil {
+			if _, ok := successorOf(api.OpenAPI(), op.Method, op.Path); ok {
+				ctx.AppendHeader("Link", "<"+"/v1"+strings.TrimPrefix(path, prefix)+`>; rel="successor-version"`)
+			}
+		}
+		ctx.AppendHeader("Link", `</v1/migration>; rel="deprecation"; type="application/json"`)
+
+		next(ctx)
+	}
+}
@@ -0,0 +1,136 @@
+package router_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+var updateV1Contract = flag.Bool("update-v1-contract", false, "rewrite testdata/v1_responses.json from the current /v1 API")
+
+const v1ContractFile = "testdata/v1_responses.json"
+
+// TestV1ResponseContract checks that the JSON responses of /v1 only change in backward-compatible ways: every
+// operation and response field recorded in testdata/v1_responses.json must still exist with the same type.
+// After adding operations or fields, run the test with -update-v1-contract to record them.
+func TestV1ResponseContract(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	// Enable the optional endpoints, so that their responses are covered too
+	cfg := &config.Config{
+		JWTPrivateKey:        hex.EncodeToString(testSeed),
+		EnableAnonymousAuth:  true,
+		WebAuthnRPID:         "registry.example.com",
+		RefreshTokenDuration: time.Hour,
+		SessionDuration:      time.Hour,
+	}
+
+	// Like NewHumaAPI, without the $schema field huma adds to responses by default
+	humaConfig := huma.DefaultConfig("Test API", "1.0.0")
+	humaConfig.CreateHooks = []func(huma.Config) huma.Config{}
+	api := humago.New(http.NewServeMux(), humaConfig)
+	router.RegisterV1Routes(api, cfg, nil, nil, &v0.VersionBody{})
+	current := v1ResponseFields(api.OpenAPI())
+
+	if *updateV1Contract {
+		data, err := json.MarshalIndent(current, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(v1ContractFile, append(data, '\n'), 0o600))
+		return
+	}
+
+	data, err := os.ReadFile(v1ContractFile)
+	require.NoError(t, err)
+	var frozen map[string]map[string]string
+	require.NoError(t, json.Unmarshal(data, &frozen))
+
+	for operation, fields := range frozen {
+		currentFields, ok := current[operation]
+		if !assert.True(t, ok, "%s was removed from /v1", operation) {
+			continue
+		}
+		for field, fieldType := range fields {
+			currentType, ok := currentFields[field]
+			if assert.True(t, ok, "%s: response field %s was removed", operation, field) {
+				assert.Equal(t, fieldType, currentType, "%s: response field %s changed type", operation, field)
+			}
+		}
+	}
+}
+
+// v1ResponseFields lists the fields of the successful JSON responses of each /v1 operation, with their types
+func v1ResponseFields(openAPI *huma.OpenAPI) map[string]map[string]string {
+	operations := map[string]map[string]string{}
+	for path, item := range openAPI.Paths {
+		if !strings.HasPrefix(path, "/v1/") {
+			continue
+		}
+		for method, op := range map[string]*huma.Operation{
+			http.MethodGet: item.Get, http.MethodPost: item.Post, http.MethodPut: item.Put,
+			http.MethodPatch: item.Patch, http.MethodDelete: item.Delete,
+		} {
+			if op == nil {
+				continue
+			}
+			fields := map[string]string{}
+			for status, response := range op.Responses {
+				if !strings.HasPrefix(status, "2") || response.Content["application/json"] == nil {
+					continue
+				}
+				collectSchemaFields(openAPI.Components.Schemas, response.Content["application/json"].Schema, status, fields, map[string]bool{})
+			}
+			operations[method+" "+path] = fields
+		}
+	}
+	return operations
+}
+
+// collectSchemaFields records the type of schema and of each field nested in it, under dotted paths
+func collectSchemaFields(registry huma.Registry, schema *huma.Schema, path string, fields map[string]string, seen map[string]bool) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		if seen[schema.Ref] {
+			fields[path] = schema.Ref
+			return
+		}
+		seen[schema.Ref] = true
+		defer delete(seen, schema.Ref)
+		schema = registry.SchemaFromRef(schema.Ref)
+	}
+
+	fields[path] = schema.Type
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		collectSchemaFields(registry, schema.Properties[name], path+"."+name, fields, seen)
+	}
+	if schema.Items != nil {
+		collectSchemaFields(registry, schema.Items, path+"[]", fields, seen)
+	}
+	if values, ok := schema.AdditionalProperties.(*huma.Schema); ok {
+		collectSchemaFields(registry, values, path+".*", fields, seen)
+	}
+}
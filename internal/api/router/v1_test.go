@@ -0,0 +1,126 @@
+package router_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func newDeprecationTestAPI(cfg *config.Config) *http.ServeMux {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.DeprecationMiddleware(api, cfg))
+
+	for _, prefix := range []string{"/v0", "/v0.1", "/v1"} {
+		v0.RegisterPingEndpoint(api, prefix)
+	}
+	// An operation that was not carried over to /v1
+	huma.Register(api, huma.Operation{OperationID: "legacy", Method: http.MethodGet, Path: "/v0/legacy"},
+		func(_ context.Context, _ *struct{}) (*v0.Response[string], error) {
+			return &v0.Response[string]{Body: "ok"}, nil
+		})
+	router.RegisterMigrationEndpoint(api, cfg)
+
+	return mux
+}
+
+func TestDeprecationMiddleware(t *testing.T) {
+	mux := newDeprecationTestAPI(&config.Config{
+		V0DeprecationDate: "2025-10-16",
+		V0SunsetDate:      "2026-04-16",
+	})
+
+	tests := []struct {
+		path              string
+		expectDeprecation bool
+		expectedSuccessor string
+	}{
+		{path: "/v0/ping", expectDeprecation: true, expectedSuccessor: "/v1/ping"},
+		{path: "/v0.1/ping", expectDeprecation: true, expectedSuccessor: "/v1/ping"},
+		{path: "/v0/legacy", expectDeprecation: true},
+		{path: "/v1/ping", expectDeprecation: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			if !tt.expectDeprecation {
+				assert.Empty(t, w.Header().Get("Deprecation"))
+				assert.Empty(t, w.Header().Get("Sunset"))
+				for _, link := range w.Header().Values("Link") {
+					assert.NotContains(t, link, "successor-version")
+				}
+				return
+			}
+
+			assert.Equal(t, "@1760572800", w.Header().Get("Deprecation"))
+			assert.Equal(t, "Thu, 16 Apr 2026 00:00:00 GMT", w.Header().Get("Sunset"))
+			if tt.expectedSuccessor != "" {
+				assert.Contains(t, w.Header().Values("Link"), "<"+tt.expectedSuccessor+`>; rel="successor-version"`)
+			} else {
+				for _, link := range w.Header().Values("Link") {
+					assert.NotContains(t, link, "successor-version")
+				}
+			}
+			assert.Contains(t, w.Header().Values("Link"), `</v1/migration>; rel="deprecation"; type="application/json"`)
+		})
+	}
+}
+
+func TestDeprecationMiddleware_NoSunset(t *testing.T) {
+	mux := newDeprecationTestAPI(&config.Config{V0DeprecationDate: "2025-10-16"})
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/ping", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.NotEmpty(t, w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Sunset"))
+}
+
+func TestDeprecationMiddleware_NotScheduled(t *testing.T) {
+	mux := newDeprecationTestAPI(&config.Config{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/ping", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Empty(t, w.Header().Get("Deprecation"))
+	for _, link := range w.Header().Values("Link") {
+		assert.NotContains(t, link, "successor-version")
+	}
+}
+
+func TestMigrationEndpoint(t *testing.T) {
+	mux := newDeprecationTestAPI(&config.Config{V0DeprecationDate: "2025-10-16"})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/migration", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body router.MigrationBody
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, []string{"v0", "v0.1"}, body.DeprecatedVersions)
+	assert.Equal(t, "2025-10-16", body.DeprecationDate)
+	assert.Equal(t, []router.EndpointMigration{
+		{Method: http.MethodGet, Path: "/v0.1/ping", Successor: "/v1/ping"},
+		{Method: http.MethodGet, Path: "/v0/ping", Successor: "/v1/ping"},
+	}, body.Endpoints)
+}
@@ -0,0 +1,52 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFormat renders responses as YAML for clients sending Accept: application/yaml. Responses
+// are rendered through their JSON encoding, so YAML keys and omitted fields match the JSON API.
+// Request bodies must still be JSON.
+var YAMLFormat = huma.Format{
+	Marshal: func(w io.Writer, v any) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		// JSON is valid YAML, so parsing it keeps key order and types
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return fmt.Errorf("failed to convert response to YAML: %w", err)
+		}
+		useBlockStyle(&node)
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&node); err != nil {
+			return err
+		}
+		return encoder.Close()
+	},
+	Unmarshal: func(_ []byte, _ any) error {
+		return errors.New("YAML request bodies are not supported, send JSON instead")
+	},
+}
+
+// yamlMediaTypes are the media types negotiated to YAMLFormat
+var yamlMediaTypes = []string{"application/yaml", "application/x-yaml", "text/yaml", "yaml"}
+
+// useBlockStyle clears the flow and quoting styles carried over from JSON, so the output
+// reads like hand-written YAML. Strings that need quoting are still quoted by the encoder.
+func useBlockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		useBlockStyle(child)
+	}
+}
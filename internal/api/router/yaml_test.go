@@ -0,0 +1,64 @@
+package router_test
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+)
+
+func TestYAMLFormat(t *testing.T) {
+	type item struct {
+		Name    string   `json:"name"`
+		Version string   `json:"version"`
+		Tags    []string `json:"tags,omitempty"`
+		Note    string   `json:"note,omitempty"`
+	}
+
+	config := huma.DefaultConfig("Test API", "1.0.0")
+	config.CreateHooks = nil
+	config.Formats = maps.Clone(huma.DefaultFormats)
+	config.Formats["application/yaml"] = router.YAMLFormat
+	mux := http.NewServeMux()
+	api := humago.New(mux, config)
+	huma.Get(api, "/item", func(_ context.Context, _ *struct{}) (*struct{ Body item }, error) {
+		return &struct{ Body item }{Body: item{Name: "com.example/weather", Version: "1.0", Tags: []string{"a: b", "c"}}}, nil
+	})
+
+	t.Run("renders YAML when requested", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/item", nil)
+		req.Header.Set("Accept", "application/yaml")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+		// Keys keep their JSON names and order, and strings that would parse as other types stay quoted
+		assert.Equal(t, strings.Join([]string{
+			"name: com.example/weather",
+			`version: "1.0"`,
+			"tags:",
+			`  - 'a: b'`,
+			"  - c",
+			"",
+		}, "\n"), w.Body.String())
+	})
+
+	t.Run("defaults to JSON", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/item", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	})
+}
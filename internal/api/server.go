@@ -2,7 +2,10 @@ package api
 
 import (
 	"context"
-	"log"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
@@ -10,9 +13,12 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/api/middleware"
 	"github.com/modelcontextprotocol/registry/internal/api/router"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/logging"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/stats"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
@@ -34,12 +40,54 @@ func TrailingSlashMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// securityHeadersConfig builds the security header settings from the registry configuration
+func securityHeadersConfig(cfg *config.Config) middleware.SecurityHeadersConfig {
+	csp := cfg.SecurityHeadersHTMLCSP
+	if csp == "" {
+		csp = middleware.DefaultHTMLContentSecurityPolicy
+	}
+	return middleware.SecurityHeadersConfig{
+		HSTSMaxAge:                time.Duration(cfg.SecurityHeadersHSTSMaxAge) * time.Second,
+		HSTSIncludeSubdomains:     cfg.SecurityHeadersHSTSIncludeSubdomains,
+		ReferrerPolicy:            cfg.SecurityHeadersReferrerPolicy,
+		HTMLContentSecurityPolicy: csp,
+	}
+}
+
+// anonymousSweepInterval is the longest time between deletions of expired anonymous servers
+const anonymousSweepInterval = 10 * time.Minute
+
+// sweepAnonymousServers deletes expired anonymous servers every interval until ctx is cancelled
+func sweepAnonymousServers(ctx context.Context, registry service.RegistryService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := registry.DeleteExpiredAnonymousServers(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to delete expired anonymous servers", logging.Err(err))
+			} else if deleted > 0 {
+				slog.InfoContext(ctx, "Deleted expired anonymous servers", "count", deleted)
+			}
+		}
+	}
+}
+
 // Server represents the HTTP server
 type Server struct {
-	config   *config.Config
-	registry service.RegistryService
-	humaAPI  huma.API
-	server   *http.Server
+	config     *config.Config
+	registry   service.RegistryService
+	humaAPI    huma.API
+	server     *http.Server
+	fetchStats *stats.Recorder
+	stopStats  context.CancelFunc
+	stopSweep  context.CancelFunc
+	// debugServer serves profiles and runtime statistics on DEBUG_SERVER_ADDRESS, if it is set
+	debugServer *http.Server
 }
 
 // NewServer creates a new HTTP server
@@ -47,32 +95,125 @@ func NewServer(cfg *config.Config, registryService service.RegistryService, metr
 	// Create HTTP mux and Huma API
 	mux := http.NewServeMux()
 
-	api := router.NewHumaAPI(cfg, registryService, mux, metrics, versionInfo)
+	// Aggregate server fetch counts for the usage statistics endpoints
+	fetchStats := stats.NewRecorder(registryService)
+	statsCtx, stopStats := context.WithCancel(context.Background())
+	go fetchStats.Run(statsCtx)
+
+	// Delete anonymously published servers once their TTL has passed
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	if cfg.EnableAnonymousAuth && cfg.AnonymousServerTTL > 0 {
+		go sweepAnonymousServers(sweepCtx, registryService, min(cfg.AnonymousServerTTL, anonymousSweepInterval))
+	}
+
+	// Evict servers changed by any replica from the server cache
+	go registryService.WatchServerChanges(sweepCtx)
+
+	api := router.NewHumaAPI(cfg, registryService, mux, metrics, versionInfo, fetchStats)
 
-	// Wrap the mux with trailing slash middleware
-	handler := TrailingSlashMiddleware(mux)
+	// Wrap the mux with trailing slash, CORS, panic recovery, security header, access log and request ID
+	// middleware
+	cors := middleware.CORS(
+		middleware.ReadCORSPolicy(middleware.ParseOrigins(cfg.CORSAllowedOrigins)),
+		middleware.WriteCORSPolicy(middleware.ParseOrigins(cfg.CORSWriteAllowedOrigins)),
+	)
+	handler := middleware.Recover(cors(TrailingSlashMiddleware(mux)))
+	if cfg.SecurityHeadersEnabled {
+		handler = middleware.SecurityHeaders(securityHeadersConfig(cfg))(handler)
+	}
+	if cfg.AccessLogEnabled {
+		trustedProxies, err := cfg.TrustedProxyNetworks()
+		if err != nil {
+			panic(fmt.Sprintf("Invalid trusted proxies configuration: %v", err))
+		}
+		handler = router.AccessLogHandler(handler, cfg.AccessLogReadSampleRate, trustedProxies)
+	}
+	handler = middleware.RequestID(handler)
+	if cfg.TelemetryTracingEndpoint != "" {
+		handler = middleware.Tracing(handler)
+	}
 
 	server := &Server{
-		config:   cfg,
-		registry: registryService,
-		humaAPI:  api,
+		config:     cfg,
+		registry:   registryService,
+		humaAPI:    api,
+		fetchStats: fetchStats,
+		stopStats:  stopStats,
+		stopSweep:  stopSweep,
 		server: &http.Server{
 			Addr:              cfg.ServerAddress,
 			Handler:           handler,
 			ReadHeaderTimeout: 10 * time.Second,
 		},
 	}
+	if cfg.DebugServerAddress != "" {
+		server.debugServer = &http.Server{
+			Addr:              cfg.DebugServerAddress,
+			Handler:           NewDebugHandler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
 
 	return server
 }
 
 // Start begins listening for incoming HTTP requests
 func (s *Server) Start() error {
-	log.Printf("HTTP server starting on %s", s.config.ServerAddress)
-	return s.server.ListenAndServe()
+	if s.debugServer != nil {
+		go func() {
+			slog.Info("Debug server starting", "address", s.debugServer.Addr)
+			if err := s.debugServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("Debug server failed", logging.Err(err))
+			}
+		}()
+	}
+
+	if s.config.TLSCertFile == "" {
+		if s.config.MTLSCAFile != "" {
+			return errors.New("MTLS_CA_FILE requires TLS_CERT_FILE and TLS_KEY_FILE")
+		}
+		slog.Info("HTTP server starting", "address", s.config.ServerAddress)
+		return s.server.ListenAndServe()
+	}
+
+	tlsConfig, err := serverTLSConfig(s.config)
+	if err != nil {
+		return err
+	}
+	s.server.TLSConfig = tlsConfig
+	slog.Info("HTTPS server starting", "address", s.config.ServerAddress)
+	return s.server.ListenAndServeTLS(s.config.TLSCertFile, s.config.TLSKeyFile)
+}
+
+// serverTLSConfig builds the TLS settings, asking for client certificates when mutual TLS is configured.
+// Certificates are optional at the handshake so that other endpoints keep working without one.
+func serverTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	clientCAs, err := cfg.MTLSClientCAs()
+	if err != nil {
+		return nil, err
+	}
+	if clientCAs != nil {
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		tlsConfig.ClientCAs = clientCAs
+	}
+	return tlsConfig, nil
 }
 
 // Shutdown gracefully shuts down the server
 func (s *Server) Shutdown(ctx context.Context) error {
-	return s.server.Shutdown(ctx)
+	err := s.server.Shutdown(ctx)
+	if s.debugServer != nil {
+		_ = s.debugServer.Shutdown(ctx)
+	}
+
+	s.stopSweep()
+
+	// Stop periodic flushing and persist any remaining fetch counts
+	s.stopStats()
+	if flushErr := s.fetchStats.Flush(ctx); flushErr != nil {
+		slog.ErrorContext(ctx, "Failed to flush fetch statistics", logging.Err(flushErr))
+	}
+
+	return err
 }
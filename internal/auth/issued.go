@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"sync"
+)
+
+// IssuedTokens collects the claims of the Registry JWTs issued while handling a request, so that
+// logins can be audited without every auth endpoint reporting them
+type IssuedTokens struct {
+	mu     sync.Mutex
+	claims []JWTClaims
+}
+
+type issuedTokensKey struct{}
+
+// WithIssuedTokens returns a context in which issued Registry JWTs are collected
+func WithIssuedTokens(ctx context.Context) (context.Context, *IssuedTokens) {
+	issued := &IssuedTokens{}
+	return context.WithValue(ctx, issuedTokensKey{}, issued), issued
+}
+
+// Claims returns the claims of the tokens issued so far
+func (t *IssuedTokens) Claims() []JWTClaims {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]JWTClaims(nil), t.claims...)
+}
+
+// recordIssuedToken adds the claims to the collector in ctx, if any
+func recordIssuedToken(ctx context.Context, claims JWTClaims) {
+	issued, ok := ctx.Value(issuedTokensKey{}).(*IssuedTokens)
+	if !ok {
+		return
+	}
+	issued.mu.Lock()
+	defer issued.mu.Unlock()
+	issued.claims = append(issued.claims, claims)
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JSONWebKey is a public key in JWK format: Ed25519 keys (RFC 8037) use x, P-256 keys x and y,
+// and RSA keys n and e
+type JSONWebKey struct {
+	KeyType   string `json:"kty" example:"OKP"`
+	Curve     string `json:"crv,omitempty" example:"Ed25519"`
+	X         string `json:"x,omitempty" doc:"Base64url-encoded public key, or x coordinate for EC keys"`
+	Y         string `json:"y,omitempty" doc:"Base64url-encoded y coordinate for EC keys"`
+	N         string `json:"n,omitempty" doc:"Base64url-encoded RSA modulus"`
+	E         string `json:"e,omitempty" doc:"Base64url-encoded RSA exponent"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use" example:"sig"`
+	Algorithm string `json:"alg" example:"EdDSA"`
+}
+
+// JSONWebKeySet is the set of keys Registry JWTs can currently be verified with
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the current signing key followed by the previous keys that have not been retired
+func (j *JWTManager) JWKS() JSONWebKeySet {
+	var set JSONWebKeySet
+	for _, key := range j.acceptedKeys() {
+		set.Keys = append(set.Keys, newJSONWebKey(key))
+	}
+	return set
+}
+
+func newJSONWebKey(key verificationKey) JSONWebKey {
+	jwk := JSONWebKey{
+		KeyID:     key.id,
+		Use:       "sig",
+		Algorithm: key.method.Alg(),
+	}
+	switch publicKey := key.publicKey.(type) {
+	case ed25519.PublicKey:
+		jwk.KeyType, jwk.Curve = "OKP", "Ed25519"
+		jwk.X = base64URL(publicKey)
+	case *ecdsa.PublicKey:
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		jwk.KeyType, jwk.Curve = "EC", publicKey.Curve.Params().Name
+		jwk.X = base64URL(publicKey.X.FillBytes(make([]byte, size)))
+		jwk.Y = base64URL(publicKey.Y.FillBytes(make([]byte, size)))
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64URL(publicKey.N.Bytes())
+		jwk.E = base64URL(big.NewInt(int64(publicKey.E)).Bytes())
+	}
+	return jwk
+}
+
+func base64URL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
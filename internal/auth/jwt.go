@@ -2,9 +2,10 @@ package auth
 
 import (
 	"context"
-	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,13 +20,31 @@ const (
 	PermissionActionPublish PermissionAction = "publish"
 	// Intended for admins taking moderation actions only, at least for now
 	PermissionActionEdit PermissionAction = "edit"
+	// Deleting servers, which editing alone does not allow
+	PermissionActionDelete PermissionAction = "delete"
+	// Grants every other action on its resources; with the "*" pattern, registry administration
+	PermissionActionAdmin PermissionAction = "admin"
 )
 
+// IsValidPermissionAction reports whether action is one the registry knows
+func IsValidPermissionAction(action PermissionAction) bool {
+	switch action {
+	case PermissionActionPublish, PermissionActionEdit, PermissionActionDelete, PermissionActionAdmin:
+		return true
+	}
+	return false
+}
+
 type Permission struct {
-	Action          PermissionAction `json:"action"`   // The action type (publish or edit)
+	Action          PermissionAction `json:"action"`   // The action type (publish, edit, delete or admin)
 	ResourcePattern string           `json:"resource"` // e.g., "io.github.username/*"
 }
 
+// Grants reports whether the permission allows action, ignoring its resource pattern
+func (p Permission) Grants(action PermissionAction) bool {
+	return p.Action == action || p.Action == PermissionActionAdmin
+}
+
 // JWTClaims represents the claims for the Registry JWT token
 type JWTClaims struct {
 	jwt.RegisteredClaims
@@ -33,44 +52,117 @@ type JWTClaims struct {
 	AuthMethod        Method       `json:"auth_method"`
 	AuthMethodSubject string       `json:"auth_method_sub"`
 	Permissions       []Permission `json:"permissions"`
+	// Set to TokenTypeRefresh on refresh tokens, which can only be used to get new Registry JWTs
+	TokenType string `json:"token_type,omitempty"`
+	// The ID of the refresh token issued along with a Registry JWT, or of the Registry JWT issued along with
+	// a refresh token, so that revoking either revokes both
+	PairedID string `json:"paired_jti,omitempty"`
+	// Set on Registry JWTs issued after verifying with a passkey, which admin operations require of
+	// accounts that have one. These tokens come without a refresh token.
+	StepUp bool `json:"step_up,omitempty"`
+}
+
+// TokenTypeRefresh marks a refresh token
+const TokenTypeRefresh = "refresh"
+
+// BearerToken returns the token in an Authorization header of the Bearer scheme. Scheme names are
+// case-insensitive, so every check of a bearer token must find it the same way.
+func BearerToken(authHeader string) (string, bool) {
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+	return authHeader[len(bearerPrefix):], true
 }
 
 type TokenResponse struct {
-	RegistryToken string `json:"registry_token"`
-	ExpiresAt     int    `json:"expires_at"`
+	RegistryToken    string `json:"registry_token"`
+	ExpiresAt        int    `json:"expires_at"`
+	RefreshToken     string `json:"refresh_token,omitempty"`
+	RefreshExpiresAt int    `json:"refresh_expires_at,omitempty"`
 }
 
 // JWTManager handles JWT token operations
 type JWTManager struct {
-	privateKey    ed25519.PrivateKey
-	publicKey     ed25519.PublicKey
-	tokenDuration time.Duration
+	signingKey           *signingKey
+	previousKeys         map[string]previousKey
+	tokenDuration        time.Duration
+	refreshTokenDuration time.Duration
+}
+
+// previousKey is a former signing key whose tokens are accepted until retireAt, if set
+type previousKey struct {
+	verificationKey
+	retireAt time.Time
+}
+
+// retired reports whether tokens signed with the key are no longer accepted
+func (k previousKey) retired() bool {
+	return !k.retireAt.IsZero() && !time.Now().Before(k.retireAt)
 }
 
 func NewJWTManager(cfg *config.Config) *JWTManager {
-	seed, err := hex.DecodeString(cfg.JWTPrivateKey)
+	key, err := loadSigningKey(cfg)
 	if err != nil {
-		panic(fmt.Sprintf("JWTPrivateKey must be a valid hex-encoded string: %v", err))
+		panic(fmt.Sprintf("Invalid JWT private key: %v", err))
 	}
 
-	// Require a valid Ed25519 seed (32 bytes)
-	if len(seed) != ed25519.SeedSize {
-		panic(fmt.Sprintf("JWTPrivateKey seed must be exactly %d bytes for Ed25519, got %d bytes", ed25519.SeedSize, len(seed)))
+	previousKeys, err := loadPreviousKeys(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid JWT previous keys: %v", err))
 	}
 
-	// Generate the full Ed25519 key pair from the seed
-	privateKey := ed25519.NewKeyFromSeed(seed)
-	publicKey := privateKey.Public().(ed25519.PublicKey)
-
 	return &JWTManager{
-		privateKey:    privateKey,
-		publicKey:     publicKey,
-		tokenDuration: 5 * time.Minute, // 5-minute tokens as per requirements
+		signingKey:           key,
+		previousKeys:         previousKeys,
+		tokenDuration:        5 * time.Minute, // 5-minute tokens as per requirements
+		refreshTokenDuration: cfg.RefreshTokenDuration,
+	}
+}
+
+// GenerateToken generates a new Registry JWT token. Unless the claims set their own expiry, a
+// refresh token is issued with it when refresh tokens are enabled. Tokens obtained with an API
+// token get no refresh token, so they stop working soon after the API token is revoked.
+func (j *JWTManager) GenerateTokenResponse(ctx context.Context, claims JWTClaims) (*TokenResponse, error) {
+	var refreshExpiresAt time.Time
+	if claims.ExpiresAt == nil && claims.AuthMethod != MethodAPIToken && j.refreshTokenDuration > 0 {
+		refreshExpiresAt = time.Now().Add(j.refreshTokenDuration)
+	}
+	return j.generateTokenResponse(ctx, claims, refreshExpiresAt)
+}
+
+// ValidateRefreshToken validates a refresh token and returns its claims
+func (j *JWTManager) ValidateRefreshToken(_ context.Context, refreshToken string) (*JWTClaims, error) {
+	claims, err := j.parseToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenType != TokenTypeRefresh {
+		return nil, fmt.Errorf("not a refresh token")
+	}
+	return claims, nil
+}
+
+// RefreshTokenResponse issues a new Registry JWT and refresh token for a validated refresh token. The
+// new refresh token expires when the old one would have, so refreshing cannot extend a login forever.
+func (j *JWTManager) RefreshTokenResponse(ctx context.Context, refreshClaims *JWTClaims) (*TokenResponse, error) {
+	claims := JWTClaims{
+		AuthMethod:        refreshClaims.AuthMethod,
+		AuthMethodSubject: refreshClaims.AuthMethodSubject,
+		Permissions:       refreshClaims.Permissions,
 	}
+	return j.generateTokenResponse(ctx, claims, refreshClaims.ExpiresAt.Time)
 }
 
-// GenerateToken generates a new Registry JWT token
-func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims) (*TokenResponse, error) {
+// ParseAnyToken validates the signature and expiry of a Registry JWT or refresh token and returns its
+// claims, for revoking it
+func (j *JWTManager) ParseAnyToken(_ context.Context, tokenString string) (*JWTClaims, error) {
+	return j.parseToken(tokenString)
+}
+
+// generateTokenResponse signs a Registry JWT for the claims, along with a refresh token expiring at
+// refreshExpiresAt unless it is zero. The token is recorded with the IssuedTokens collector in ctx, if any.
+func (j *JWTManager) generateTokenResponse(ctx context.Context, claims JWTClaims, refreshExpiresAt time.Time) (*TokenResponse, error) {
 	// Check whether they have global permissions (used by admins)
 	hasGlobalPermissions := false
 	for _, perm := range claims.Permissions {
@@ -101,31 +193,88 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 	if claims.Issuer == "" {
 		claims.Issuer = "mcp-registry"
 	}
+	if claims.ID == "" {
+		claims.ID = newTokenID()
+	}
 
-	// Create token with claims
-	token := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, claims)
+	// A refresh token is only useful if it outlives the Registry JWT it comes with
+	withRefreshToken := refreshExpiresAt.After(claims.ExpiresAt.Time)
+	if withRefreshToken {
+		claims.PairedID = newTokenID()
+	}
 
-	// Sign token with Ed25519 private key
-	tokenString, err := token.SignedString(j.privateKey)
+	tokenString, err := j.signToken(claims)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign token: %w", err)
+		return nil, err
 	}
 
-	return &TokenResponse{
+	response := &TokenResponse{
 		RegistryToken: tokenString,
 		ExpiresAt:     int(claims.ExpiresAt.Unix()),
-	}, nil
+	}
+
+	if withRefreshToken {
+		refreshClaims := claims
+		refreshClaims.TokenType = TokenTypeRefresh
+		refreshClaims.ID, refreshClaims.PairedID = claims.PairedID, claims.ID
+		refreshClaims.ExpiresAt = jwt.NewNumericDate(refreshExpiresAt)
+
+		refreshToken, err := j.signToken(refreshClaims)
+		if err != nil {
+			return nil, err
+		}
+		response.RefreshToken = refreshToken
+		response.RefreshExpiresAt = int(refreshExpiresAt.Unix())
+	}
+
+	recordIssuedToken(ctx, claims)
+	return response, nil
+}
+
+// newTokenID returns a random JWT ID, which identifies a token on the revocation list
+func newTokenID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+func (j *JWTManager) signToken(claims JWTClaims) (string, error) {
+	// Create token with claims, naming the key it is signed with so verifiers can pick it from the JWKS
+	token := jwt.NewWithClaims(j.signingKey.method, claims)
+	token.Header["kid"] = j.signingKey.id
+
+	tokenString, err := token.SignedString(j.signingKey.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return tokenString, nil
 }
 
 // ValidateToken validates a Registry JWT token and returns the claims
 func (j *JWTManager) ValidateToken(_ context.Context, tokenString string) (*JWTClaims, error) {
+	claims, err := j.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refresh tokens cannot be used in place of the Registry JWTs they are exchanged for
+	if claims.TokenType != "" {
+		return nil, fmt.Errorf("invalid token type: %s", claims.TokenType)
+	}
+
+	return claims, nil
+}
+
+// parseToken verifies the signature and expiry of a token signed by the registry and returns its claims
+func (j *JWTManager) parseToken(tokenString string) (*JWTClaims, error) {
 	// Parse token
 	// This also validates expiry
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&JWTClaims{},
-		func(_ *jwt.Token) (interface{}, error) { return j.publicKey, nil },
-		jwt.WithValidMethods([]string{"EdDSA"}),
+		j.verificationKey,
+		jwt.WithValidMethods([]string{AlgorithmEdDSA, AlgorithmES256, AlgorithmRS256}),
 		jwt.WithExpirationRequired(),
 	)
 
@@ -146,9 +295,58 @@ func (j *JWTManager) ValidateToken(_ context.Context, tokenString string) (*JWTC
 	return claims, nil
 }
 
+// verificationKey returns the public key a token's kid names, which must be used with the token's
+// algorithm. Tokens signed before kids were added have none, and are checked against every key
+// still accepted.
+func (j *JWTManager) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		var keys jwt.VerificationKeySet
+		for _, key := range j.acceptedKeys() {
+			if key.method.Alg() == token.Method.Alg() {
+				keys.Keys = append(keys.Keys, key.publicKey)
+			}
+		}
+		return keys, nil
+	}
+
+	var key verificationKey
+	if kid == j.signingKey.id {
+		key = j.signingKey.verificationKey()
+	} else {
+		previous, ok := j.previousKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		if previous.retired() {
+			return nil, fmt.Errorf("signing key %q has been retired", kid)
+		}
+		key = previous.verificationKey
+	}
+
+	if key.method.Alg() != token.Method.Alg() {
+		return nil, fmt.Errorf("signing key %q is not used with %s", kid, token.Method.Alg())
+	}
+	return key.publicKey, nil
+}
+
+// acceptedKeys returns the current signing key followed by the previous keys that have not been
+// retired, in kid order
+func (j *JWTManager) acceptedKeys() []verificationKey {
+	var previous []verificationKey
+	for _, key := range j.previousKeys {
+		if !key.retired() {
+			previous = append(previous, key.verificationKey)
+		}
+	}
+	sort.Slice(previous, func(a, b int) bool { return previous[a].id < previous[b].id })
+
+	return append([]verificationKey{j.signingKey.verificationKey()}, previous...)
+}
+
 func (j *JWTManager) HasPermission(resource string, action PermissionAction, permissions []Permission) bool {
 	for _, perm := range permissions {
-		if perm.Action == action && isResourceMatch(resource, perm.ResourcePattern) {
+		if perm.Grants(action) && isResourceMatch(resource, perm.ResourcePattern) {
 			return true
 		}
 	}
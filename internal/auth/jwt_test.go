@@ -2,9 +2,14 @@ package auth_test
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/hex"
+	"encoding/pem"
 	"testing"
 	"time"
 
@@ -255,6 +260,33 @@ func TestJWTManager_HasPermission(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name:     "admin grants other actions",
+			resource: "io.github.testuser/server1",
+			action:   auth.PermissionActionDelete,
+			permissions: []auth.Permission{
+				{Action: auth.PermissionActionAdmin, ResourcePattern: "io.github.testuser/*"},
+			},
+			expected: true,
+		},
+		{
+			name:     "admin limited to its pattern",
+			resource: "io.github.otheruser/server1",
+			action:   auth.PermissionActionDelete,
+			permissions: []auth.Permission{
+				{Action: auth.PermissionActionAdmin, ResourcePattern: "io.github.testuser/*"},
+			},
+			expected: false,
+		},
+		{
+			name:     "edit does not grant delete",
+			resource: "io.github.testuser/server1",
+			action:   auth.PermissionActionDelete,
+			permissions: []auth.Permission{
+				{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.testuser/*"},
+			},
+			expected: false,
+		},
 		{
 			name:        "empty permissions",
 			resource:    "io.github.testuser/server1",
@@ -397,3 +429,228 @@ func TestJWTManager_BlockedNamespaces(t *testing.T) {
 		assert.NotEmpty(t, tokenResponse.RegistryToken)
 	})
 }
+
+func TestJWTManager_RefreshToken(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		JWTPrivateKey:        hex.EncodeToString(testSeed),
+		RefreshTokenDuration: time.Hour,
+	}
+	jwtManager := auth.NewJWTManager(cfg)
+	ctx := context.Background()
+
+	claims := auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "testuser",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.testuser/*"},
+		},
+	}
+
+	response, err := jwtManager.GenerateTokenResponse(ctx, claims)
+	require.NoError(t, err)
+	require.NotEmpty(t, response.RefreshToken)
+	assert.InDelta(t, time.Now().Add(time.Hour).Unix(), response.RefreshExpiresAt, 5)
+
+	t.Run("refresh token cannot be used as a Registry JWT", func(t *testing.T) {
+		_, err := jwtManager.ValidateToken(ctx, response.RefreshToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("Registry JWT cannot be used as a refresh token", func(t *testing.T) {
+		_, err := jwtManager.ValidateRefreshToken(ctx, response.RegistryToken)
+		assert.ErrorContains(t, err, "not a refresh token")
+	})
+
+	t.Run("refresh keeps the identity and the original expiry", func(t *testing.T) {
+		refreshClaims, err := jwtManager.ValidateRefreshToken(ctx, response.RefreshToken)
+		require.NoError(t, err)
+		refreshed, err := jwtManager.RefreshTokenResponse(ctx, refreshClaims)
+		require.NoError(t, err)
+		assert.Equal(t, response.RefreshExpiresAt, refreshed.RefreshExpiresAt)
+
+		refreshedClaims, err := jwtManager.ValidateToken(ctx, refreshed.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, claims.AuthMethod, refreshedClaims.AuthMethod)
+		assert.Equal(t, claims.AuthMethodSubject, refreshedClaims.AuthMethodSubject)
+		assert.Equal(t, claims.Permissions, refreshedClaims.Permissions)
+		assert.NotEqual(t, refreshClaims.ID, refreshedClaims.ID)
+	})
+
+	t.Run("no refresh token for API tokens or explicit expiry", func(t *testing.T) {
+		apiTokenClaims := claims
+		apiTokenClaims.AuthMethod = auth.MethodAPIToken
+		response, err := jwtManager.GenerateTokenResponse(ctx, apiTokenClaims)
+		require.NoError(t, err)
+		assert.Empty(t, response.RefreshToken)
+
+		expiringClaims := claims
+		expiringClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Minute))
+		response, err = jwtManager.GenerateTokenResponse(ctx, expiringClaims)
+		require.NoError(t, err)
+		assert.Empty(t, response.RefreshToken)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		response, err := auth.NewJWTManager(&config.Config{JWTPrivateKey: cfg.JWTPrivateKey}).GenerateTokenResponse(ctx, claims)
+		require.NoError(t, err)
+		assert.Empty(t, response.RefreshToken)
+	})
+}
+
+func TestJWTManager_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	newSeed := func(t *testing.T) string {
+		t.Helper()
+		seed := make([]byte, ed25519.SeedSize)
+		_, err := rand.Read(seed)
+		require.NoError(t, err)
+		return hex.EncodeToString(seed)
+	}
+	claims := auth.JWTClaims{
+		AuthMethod:        auth.MethodNone,
+		AuthMethodSubject: "rotation",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}},
+	}
+
+	oldKey, newKey := newSeed(t), newSeed(t)
+	oldManager := auth.NewJWTManager(&config.Config{JWTPrivateKey: oldKey})
+	oldToken, err := oldManager.GenerateTokenResponse(ctx, claims)
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(oldToken.RegistryToken, &auth.JWTClaims{})
+	require.NoError(t, err)
+	oldKeyID := parsed.Header["kid"]
+	require.NotEmpty(t, oldKeyID)
+
+	t.Run("unknown keys are rejected", func(t *testing.T) {
+		_, err := auth.NewJWTManager(&config.Config{JWTPrivateKey: newKey}).ValidateToken(ctx, oldToken.RegistryToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("previous keys verify until retired", func(t *testing.T) {
+		rotated := auth.NewJWTManager(&config.Config{
+			JWTPrivateKey:   newKey,
+			JWTPreviousKeys: `[{"private_key":"` + oldKey + `","retire_at":"` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}]`,
+		})
+		_, err := rotated.ValidateToken(ctx, oldToken.RegistryToken)
+		require.NoError(t, err)
+
+		newToken, err := rotated.GenerateTokenResponse(ctx, claims)
+		require.NoError(t, err)
+		_, err = rotated.ValidateToken(ctx, newToken.RegistryToken)
+		require.NoError(t, err)
+
+		jwks := rotated.JWKS()
+		require.Len(t, jwks.Keys, 2)
+		assert.NotEqual(t, oldKeyID, jwks.Keys[0].KeyID)
+		assert.Equal(t, oldKeyID, jwks.Keys[1].KeyID)
+		assert.Equal(t, "Ed25519", jwks.Keys[1].Curve)
+
+		retired := auth.NewJWTManager(&config.Config{
+			JWTPrivateKey:   newKey,
+			JWTPreviousKeys: `[{"private_key":"` + oldKey + `","retire_at":"` + time.Now().Add(-time.Minute).Format(time.RFC3339) + `"}]`,
+		})
+		_, err = retired.ValidateToken(ctx, oldToken.RegistryToken)
+		assert.ErrorContains(t, err, "retired")
+		assert.Len(t, retired.JWKS().Keys, 1)
+	})
+
+	t.Run("tokens without a kid are checked against every key", func(t *testing.T) {
+		oldClaims := claims
+		oldClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Minute))
+		seed, err := hex.DecodeString(oldKey)
+		require.NoError(t, err)
+		legacyToken, err := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, oldClaims).SignedString(ed25519.NewKeyFromSeed(seed))
+		require.NoError(t, err)
+
+		rotated := auth.NewJWTManager(&config.Config{
+			JWTPrivateKey:   newKey,
+			JWTPreviousKeys: `[{"private_key":"` + oldKey + `"}]`,
+		})
+		_, err = rotated.ValidateToken(ctx, legacyToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid previous keys", func(t *testing.T) {
+		for _, keys := range []string{`not json`, `[{}]`, `[{"public_key":"abcd"}]`, `[{"algorithm":"ES256","private_key":"` + oldKey + `"}]`} {
+			err := auth.CheckSigningKeys(&config.Config{JWTPrivateKey: newKey, JWTPreviousKeys: keys})
+			assert.Error(t, err, keys)
+		}
+	})
+}
+
+func TestJWTManager_SigningAlgorithms(t *testing.T) {
+	ctx := context.Background()
+	claims := auth.JWTClaims{
+		AuthMethod:        auth.MethodNone,
+		AuthMethodSubject: "algorithms",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}},
+	}
+	pemKey := func(t *testing.T, key any) string {
+		t.Helper()
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		algorithm string
+		key       string
+		keyType   string
+	}{
+		{algorithm: auth.AlgorithmEdDSA, key: pemKey(t, edKey), keyType: "OKP"},
+		{algorithm: auth.AlgorithmES256, key: pemKey(t, ecKey), keyType: "EC"},
+		{algorithm: auth.AlgorithmRS256, key: pemKey(t, rsaKey), keyType: "RSA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			cfg := &config.Config{JWTAlgorithm: tt.algorithm, JWTPrivateKey: tt.key}
+			require.NoError(t, auth.CheckSigningKeys(cfg))
+			jwtManager := auth.NewJWTManager(cfg)
+
+			response, err := jwtManager.GenerateTokenResponse(ctx, claims)
+			require.NoError(t, err)
+			parsed, _, err := jwt.NewParser().ParseUnverified(response.RegistryToken, &auth.JWTClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.algorithm, parsed.Method.Alg())
+
+			_, err = jwtManager.ValidateToken(ctx, response.RegistryToken)
+			require.NoError(t, err)
+
+			jwks := jwtManager.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, tt.keyType, jwks.Keys[0].KeyType)
+			assert.Equal(t, tt.algorithm, jwks.Keys[0].Algorithm)
+		})
+	}
+
+	t.Run("key must match the algorithm", func(t *testing.T) {
+		assert.Error(t, auth.CheckSigningKeys(&config.Config{JWTAlgorithm: auth.AlgorithmES256, JWTPrivateKey: pemKey(t, rsaKey)}))
+		assert.Error(t, auth.CheckSigningKeys(&config.Config{JWTAlgorithm: auth.AlgorithmRS256, JWTPrivateKey: hex.EncodeToString(make([]byte, ed25519.SeedSize))}))
+		assert.Error(t, auth.CheckSigningKeys(&config.Config{JWTAlgorithm: "HS256", JWTPrivateKey: pemKey(t, ecKey)}))
+
+		smallRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		assert.ErrorContains(t, auth.CheckSigningKeys(&config.Config{JWTAlgorithm: auth.AlgorithmRS256, JWTPrivateKey: pemKey(t, smallRSAKey)}), "2048")
+	})
+
+	t.Run("tokens cannot switch algorithm", func(t *testing.T) {
+		esManager := auth.NewJWTManager(&config.Config{JWTAlgorithm: auth.AlgorithmES256, JWTPrivateKey: pemKey(t, ecKey)})
+		edManager := auth.NewJWTManager(&config.Config{JWTPrivateKey: pemKey(t, edKey)})
+		response, err := esManager.GenerateTokenResponse(ctx, claims)
+		require.NoError(t, err)
+		_, err = edManager.ValidateToken(ctx, response.RegistryToken)
+		assert.Error(t, err)
+	})
+}
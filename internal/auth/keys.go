@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// JWT signing algorithms the registry can sign tokens with
+const (
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmES256 = "ES256"
+	AlgorithmRS256 = "RS256"
+)
+
+// minRSAKeyBits is the smallest RSA key accepted for RS256
+const minRSAKeyBits = 2048
+
+// signingKey is a key the registry signs tokens with
+type signingKey struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey crypto.Signer
+}
+
+// verificationKey returns the public half of the signing key
+func (k *signingKey) verificationKey() verificationKey {
+	return verificationKey{id: k.id, method: k.method, publicKey: k.privateKey.Public()}
+}
+
+// verificationKey is a public key tokens can be verified with
+type verificationKey struct {
+	id        string
+	method    jwt.SigningMethod
+	publicKey crypto.PublicKey
+}
+
+// signingMethod returns the JWT signing method for an algorithm name, defaulting to EdDSA
+func signingMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case "", AlgorithmEdDSA:
+		return jwt.SigningMethodEdDSA, nil
+	case AlgorithmES256:
+		return jwt.SigningMethodES256, nil
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	}
+	return nil, fmt.Errorf("unsupported JWT algorithm %q (allowed: %s, %s, %s)", algorithm, AlgorithmEdDSA, AlgorithmES256, AlgorithmRS256)
+}
+
+// CheckSigningKeys reports whether the JWT signing key and previous keys are valid for their algorithms
+func CheckSigningKeys(cfg *config.Config) error {
+	if _, err := loadSigningKey(cfg); err != nil {
+		return err
+	}
+	_, err := loadPreviousKeys(cfg)
+	return err
+}
+
+// loadSigningKey parses JWT_PRIVATE_KEY for JWT_ALGORITHM: a hex-encoded seed or PKCS#8 PEM key for
+// EdDSA, and a PEM key for ES256 and RS256
+func loadSigningKey(cfg *config.Config) (*signingKey, error) {
+	method, err := signingMethod(cfg.JWTAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	var privateKey crypto.Signer
+	if strings.Contains(cfg.JWTPrivateKey, "-----BEGIN") {
+		privateKey, err = parsePEMPrivateKey(cfg.JWTPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if method != jwt.SigningMethodEdDSA {
+			return nil, fmt.Errorf("JWT private key for %s must be PEM-encoded", method.Alg())
+		}
+		seed, err := hex.DecodeString(cfg.JWTPrivateKey)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("JWT private key must be a hex-encoded %d byte Ed25519 seed", ed25519.SeedSize)
+		}
+		privateKey = ed25519.NewKeyFromSeed(seed)
+	}
+
+	if err := checkKeyMatchesMethod(privateKey.Public(), method); err != nil {
+		return nil, err
+	}
+	id, err := keyIDFor(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &signingKey{id: id, method: method, privateKey: privateKey}, nil
+}
+
+// loadPreviousKeys parses JWT_PREVIOUS_KEYS
+func loadPreviousKeys(cfg *config.Config) (map[string]previousKey, error) {
+	keyConfigs, err := cfg.JWTPreviousKeyConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]previousKey)
+	for _, keyConfig := range keyConfigs {
+		key, err := parsePreviousKey(keyConfig)
+		if err != nil {
+			return nil, err
+		}
+		keys[key.id] = previousKey{verificationKey: *key, retireAt: keyConfig.RetireAt}
+	}
+	return keys, nil
+}
+
+// parsePreviousKey parses a previous key from its private key, in the same format as JWT_PRIVATE_KEY,
+// or its public key, as hex for Ed25519 or a PKIX PEM key
+func parsePreviousKey(keyConfig config.JWTPreviousKey) (*verificationKey, error) {
+	method, err := signingMethod(keyConfig.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if (keyConfig.PrivateKey == "") == (keyConfig.PublicKey == "") {
+		return nil, errors.New("previous JWT key must have exactly one of private_key and public_key")
+	}
+
+	var publicKey crypto.PublicKey
+	switch {
+	case keyConfig.PrivateKey != "":
+		key, err := loadSigningKey(&config.Config{JWTPrivateKey: keyConfig.PrivateKey, JWTAlgorithm: method.Alg()})
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous JWT key: %w", err)
+		}
+		publicKey = key.privateKey.Public()
+	case strings.Contains(keyConfig.PublicKey, "-----BEGIN"):
+		block, _ := pem.Decode([]byte(keyConfig.PublicKey))
+		if block == nil {
+			return nil, errors.New("invalid previous JWT key: public key is not valid PEM")
+		}
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous JWT key: %w", err)
+		}
+	default:
+		key, err := hex.DecodeString(keyConfig.PublicKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid previous JWT key: public key must be PEM or a hex-encoded %d byte Ed25519 key", ed25519.PublicKeySize)
+		}
+		publicKey = ed25519.PublicKey(key)
+	}
+
+	if err := checkKeyMatchesMethod(publicKey, method); err != nil {
+		return nil, fmt.Errorf("invalid previous JWT key: %w", err)
+	}
+	id, err := keyIDFor(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &verificationKey{id: id, method: method, publicKey: publicKey}, nil
+}
+
+// parsePEMPrivateKey parses a PKCS#8, SEC 1 (EC) or PKCS#1 (RSA) PEM private key
+func parsePEMPrivateKey(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("JWT private key is not valid PEM")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported JWT private key type")
+	}
+	return signer, nil
+}
+
+// checkKeyMatchesMethod fails unless the public key is of the type the signing method uses
+func checkKeyMatchesMethod(publicKey crypto.PublicKey, method jwt.SigningMethod) error {
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		if method == jwt.SigningMethodEdDSA {
+			return nil
+		}
+	case *ecdsa.PublicKey:
+		if method == jwt.SigningMethodES256 {
+			if key.Curve != elliptic.P256() {
+				return errors.New("ES256 requires a P-256 key")
+			}
+			return nil
+		}
+	case *rsa.PublicKey:
+		if method == jwt.SigningMethodRS256 {
+			if key.N.BitLen() < minRSAKeyBits {
+				return fmt.Errorf("RS256 requires an RSA key of at least %d bits", minRSAKeyBits)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("JWT key of type %T does not match algorithm %s", publicKey, method.Alg())
+}
+
+// keyIDFor derives the kid of a key from its public key, so a key keeps its kid when it is moved
+// from JWT_PRIVATE_KEY to JWT_PREVIOUS_KEYS
+func keyIDFor(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JWT public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:8]), nil
+}
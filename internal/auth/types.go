@@ -6,14 +6,26 @@ type Method string
 const (
 	// GitHub OAuth authentication (access token)
 	MethodGitHubAT Method = "github-at"
+	// GitLab OAuth authentication (access token)
+	MethodGitLabAT Method = "gitlab-at"
 	// GitHub Actions OIDC authentication
 	MethodGitHubOIDC Method = "github-oidc"
+	// GitLab CI/CD OIDC authentication
+	MethodGitLabOIDC Method = "gitlab-oidc"
+	// Google sign-in for Google Workspace domains
+	MethodGoogle Method = "google"
+	// Microsoft Entra ID sign-in, with permissions from group membership
+	MethodEntra Method = "entra"
 	// Generic OIDC authentication
 	MethodOIDC Method = "oidc"
 	// DNS-based public/private key authentication
 	MethodDNS Method = "dns"
 	// HTTP-based public/private key authentication
 	MethodHTTP Method = "http"
+	// Mutual TLS client certificate authentication
+	MethodMTLS Method = "mtls"
+	// API token created through the token management endpoints
+	MethodAPIToken Method = "api-token"
 	// No authentication - should only be used for local development and testing
 	MethodNone Method = "none"
 )
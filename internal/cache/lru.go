@@ -0,0 +1,109 @@
+// Package cache provides a size-bounded, least recently used in-memory cache
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU is a cache of up to size values that evicts the least recently used value to make room for a
+// new one. Values older than maxAge, if it is not zero, are treated as missing. It is safe for
+// concurrent use.
+type LRU[K comparable, V any] struct {
+	mu     sync.Mutex
+	size   int
+	maxAge time.Duration
+	items  map[K]*list.Element
+	// order lists the entries from most to least recently used
+	order *list.List
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+	added time.Time
+}
+
+// NewLRU creates a cache of up to size values, each kept for at most maxAge, or for as long as there
+// is room if maxAge is zero
+func NewLRU[K comparable, V any](size int, maxAge time.Duration) *LRU[K, V] {
+	return &LRU[K, V]{
+		size:   max(size, 1),
+		maxAge: maxAge,
+		items:  make(map[K]*list.Element),
+		order:  list.New(),
+	}
+}
+
+// Get returns the value of key, if it is cached and has not expired, and marks it as the most recently used
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	element, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+	e := element.Value.(*entry[K, V])
+	if c.maxAge > 0 && time.Since(e.added) >= c.maxAge {
+		c.removeElement(element)
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return e.value, true
+}
+
+// Add caches value as the value of key, evicting the least recently used value if the cache is full
+func (c *LRU[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		element.Value = &entry[K, V]{key: key, value: value, added: time.Now()}
+		c.order.MoveToFront(element)
+		return
+	}
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, added: time.Now()})
+	if c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// RemoveFunc removes the values whose keys match, returning how many were removed
+func (c *LRU[K, V]) RemoveFunc(match func(key K) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key, element := range c.items {
+		if match(key) {
+			c.removeElement(element)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Purge removes every value
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.items)
+	c.order.Init()
+}
+
+// Len returns the number of cached values, including those that have expired but not been removed yet
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *LRU[K, V]) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,63 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/cache"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	lru := cache.NewLRU[string, int](2, 0)
+	lru.Add("a", 1)
+	lru.Add("b", 2)
+
+	// Reading a makes b the least recently used
+	value, ok := lru.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	lru.Add("c", 3)
+	assert.Equal(t, 2, lru.Len())
+	_, ok = lru.Get("b")
+	assert.False(t, ok, "the least recently used value is evicted")
+	value, ok = lru.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	lru.Add("c", 4)
+	value, _ = lru.Get("c")
+	assert.Equal(t, 4, value, "adding a cached key replaces its value")
+	assert.Equal(t, 2, lru.Len())
+}
+
+func TestLRU_MaxAge(t *testing.T) {
+	lru := cache.NewLRU[string, int](10, 50*time.Millisecond)
+	lru.Add("a", 1)
+	_, ok := lru.Get("a")
+	assert.True(t, ok)
+
+	time.Sleep(50 * time.Millisecond)
+	_, ok = lru.Get("a")
+	assert.False(t, ok, "expired values are missing")
+	assert.Equal(t, 0, lru.Len(), "expired values are removed when read")
+}
+
+func TestLRU_RemoveFunc(t *testing.T) {
+	type key struct{ name, version string }
+	lru := cache.NewLRU[key, int](10, 0)
+	lru.Add(key{"com.example/weather", ""}, 1)
+	lru.Add(key{"com.example/weather", "1.0.0"}, 2)
+	lru.Add(key{"com.example/maps", ""}, 3)
+
+	removed := lru.RemoveFunc(func(k key) bool { return k.name == "com.example/weather" })
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, 1, lru.Len())
+	_, ok := lru.Get(key{"com.example/maps", ""})
+	assert.True(t, ok)
+
+	lru.Purge()
+	assert.Equal(t, 0, lru.Len())
+}
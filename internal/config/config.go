@@ -1,6 +1,19 @@
 package config
 
 import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	env "github.com/caarlos0/env/v11"
 )
 
@@ -11,11 +24,176 @@ type Config struct {
 	DatabaseURL              string `env:"DATABASE_URL" envDefault:"postgres://localhost:5432/mcp-registry?sslmode=disable"`
 	SeedFrom                 string `env:"SEED_FROM" envDefault:""`
 	Version                  string `env:"VERSION" envDefault:"dev"`
+	GitCommit                string `env:"GIT_COMMIT" envDefault:"unknown"`
+	BuildTime                string `env:"BUILD_TIME" envDefault:"unknown"`
 	GithubClientID           string `env:"GITHUB_CLIENT_ID" envDefault:""`
 	GithubClientSecret       string `env:"GITHUB_CLIENT_SECRET" envDefault:""`
+	GitLabBaseURL            string `env:"GITLAB_BASE_URL" envDefault:"https://gitlab.com"`
+	GitLabClientID           string `env:"GITLAB_CLIENT_ID" envDefault:""`
 	JWTPrivateKey            string `env:"JWT_PRIVATE_KEY" envDefault:""`
 	EnableAnonymousAuth      bool   `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
 	EnableRegistryValidation bool   `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
+	PublicURL                string `env:"PUBLIC_URL" envDefault:""`
+
+	// Comma-separated origins allowed to make cross-origin requests ("*" for any).
+	// Writes (publishing, editing and auth) default to same-origin only.
+	CORSAllowedOrigins      string `env:"CORS_ALLOWED_ORIGINS" envDefault:"*"`
+	CORSWriteAllowedOrigins string `env:"CORS_WRITE_ALLOWED_ORIGINS" envDefault:""`
+
+	// Security headers (HSTS, nosniff, Referrer-Policy and a CSP for HTML pages)
+	SecurityHeadersEnabled               bool   `env:"SECURITY_HEADERS_ENABLED" envDefault:"true"`
+	SecurityHeadersHSTSMaxAge            int    `env:"SECURITY_HEADERS_HSTS_MAX_AGE" envDefault:"31536000"`
+	SecurityHeadersHSTSIncludeSubdomains bool   `env:"SECURITY_HEADERS_HSTS_INCLUDE_SUBDOMAINS" envDefault:"false"`
+	SecurityHeadersReferrerPolicy        string `env:"SECURITY_HEADERS_REFERRER_POLICY" envDefault:"strict-origin-when-cross-origin"`
+	SecurityHeadersHTMLCSP               string `env:"SECURITY_HEADERS_HTML_CSP" envDefault:""`
+
+	// Logging: "json" for log collection in production, or "text" for reading in development. Records
+	// made for a request include its request ID, the caller and the server it is about. Warnings and
+	// errors repeating the same message are logged once per throttle window, the next one counting those
+	// dropped in the suppressed field. 0 disables throttling
+	LogFormat         string        `env:"LOG_FORMAT" envDefault:"json"`
+	LogLevel          string        `env:"LOG_LEVEL" envDefault:"info"`
+	LogThrottleWindow time.Duration `env:"LOG_THROTTLE_WINDOW" envDefault:"1m"`
+
+	// Access log: a record of every request. Successful GET and HEAD requests, most of the traffic, are
+	// logged at the sample rate (0 to 1); writes and failed requests are always logged
+	AccessLogEnabled        bool    `env:"ACCESS_LOG_ENABLED" envDefault:"true"`
+	AccessLogReadSampleRate float64 `env:"ACCESS_LOG_READ_SAMPLE_RATE" envDefault:"1"`
+
+	// Server cache: reads of server versions by name, such as GET /v0/servers/{serverName}/versions/latest,
+	// are served from an LRU of this many versions (0 disables it). Servers changed on any replica are
+	// evicted as the change feed reports them, and entries older than the max age are read again, which
+	// bounds how long changes outside the feed, such as new icons, take to show on other replicas
+	ServerCacheSize   int           `env:"SERVER_CACHE_SIZE" envDefault:"1000"`
+	ServerCacheMaxAge time.Duration `env:"SERVER_CACHE_MAX_AGE" envDefault:"5m"`
+
+	// Slow query log: database queries taking longer than the threshold are logged with the method that
+	// made them, their duration and row count, but never their parameters. 0 disables it
+	DatabaseSlowQueryThreshold time.Duration `env:"DATABASE_SLOW_QUERY_THRESHOLD" envDefault:"500ms"`
+
+	// Debug server: a separate listener for pprof profiles, goroutine dumps and GC statistics. Disabled
+	// while empty; bind it to an address only operators can reach, e.g. 127.0.0.1:6060
+	DebugServerAddress string `env:"DEBUG_SERVER_ADDRESS" envDefault:""`
+
+	// Client addresses: the comma-separated networks (CIDRs or IPs) of the load balancers and proxies in
+	// front of the registry. The X-Forwarded-For entries they add are trusted to identify clients for rate
+	// limits, lockouts, logs and the metrics allowlist; other requests are identified by their own address
+	TrustedProxies string `env:"TRUSTED_PROXIES" envDefault:""`
+
+	// Telemetry: Prometheus metrics at /metrics. Scrapes must present the bearer token, if one is set, and
+	// come from one of the comma-separated networks (CIDRs or IPs), if any are set
+	TelemetryMetricsEnabled      bool   `env:"TELEMETRY_METRICS_ENABLED" envDefault:"true"`
+	TelemetryMetricsBearerToken  string `env:"TELEMETRY_METRICS_BEARER_TOKEN" envDefault:""`
+	TelemetryMetricsAllowedCIDRs string `env:"TELEMETRY_METRICS_ALLOWED_CIDRS" envDefault:""`
+
+	// Telemetry: OpenTelemetry traces of requests, database queries and package validation, exported over
+	// OTLP/HTTP to the endpoint (e.g. http://otel-collector:4318). Tracing is disabled while it is empty
+	TelemetryTracingEndpoint    string  `env:"TELEMETRY_TRACING_ENDPOINT" envDefault:""`
+	TelemetryTracingSampleRatio float64 `env:"TELEMETRY_TRACING_SAMPLE_RATIO" envDefault:"1"`
+
+	// Telemetry: panics and errors producing 5xx responses are sent, with their request, to the
+	// Sentry-compatible service of the DSN. The sample rate (above 0, up to 1) is the share sent
+	TelemetryErrorReportingDSN        string  `env:"TELEMETRY_ERROR_REPORTING_DSN" envDefault:""`
+	TelemetryErrorReportingSampleRate float64 `env:"TELEMETRY_ERROR_REPORTING_SAMPLE_RATE" envDefault:"1"`
+
+	// Alerts: the comma-separated webhooks (Slack-compatible, sent {"text": ...}) are notified when, within
+	// an evaluation interval, package validations fail at the failure rate or more (once the minimum
+	// number of validations is reached), logins are locked out as often as the lockout threshold, or
+	// requests wait for a database connection as often as the pool wait threshold. An alert is not sent
+	// again until the cooldown has passed. Alerts are disabled while no webhook is set
+	AlertWebhookURLs               string        `env:"ALERT_WEBHOOK_URLS" envDefault:""`
+	AlertInterval                  time.Duration `env:"ALERT_INTERVAL" envDefault:"5m"`
+	AlertCooldown                  time.Duration `env:"ALERT_COOLDOWN" envDefault:"1h"`
+	AlertValidationFailureRate     float64       `env:"ALERT_VALIDATION_FAILURE_RATE" envDefault:"0.5"`
+	AlertValidationMinimum         int           `env:"ALERT_VALIDATION_MINIMUM" envDefault:"20"`
+	AlertLockoutThreshold          int           `env:"ALERT_LOCKOUT_THRESHOLD" envDefault:"20"`
+	AlertDatabasePoolWaitThreshold int           `env:"ALERT_DATABASE_POOL_WAIT_THRESHOLD" envDefault:"50"`
+
+	// Maintenance mode makes write endpoints return 503 while reads keep working. It can also be
+	// toggled at runtime through the admin API; enabling it here overrides that.
+	MaintenanceMode    bool   `env:"MAINTENANCE_MODE" envDefault:"false"`
+	MaintenanceMessage string `env:"MAINTENANCE_MESSAGE" envDefault:""`
+
+	// Abuse reports each client may make per hour, per replica (0 disables the limit)
+	AbuseReportRateLimit int `env:"ABUSE_REPORT_RATE_LIMIT" envDefault:"5"`
+
+	// API deprecation schedule for v0 and v0.1 (YYYY-MM-DD). Deprecation headers are only sent once a
+	// deprecation date is set.
+	V0DeprecationDate string `env:"V0_DEPRECATION_DATE" envDefault:""`
+	V0SunsetDate      string `env:"V0_SUNSET_DATE" envDefault:""`
+
+	// How long a login can be kept alive with refresh tokens (0 disables them)
+	RefreshTokenDuration time.Duration `env:"REFRESH_TOKEN_DURATION" envDefault:"12h"`
+
+	// Browser sessions for a registry web UI: how long a session cookie from /v0/auth/session lasts (0 disables
+	// sessions), and whether the cookie is only sent over HTTPS, which should only be turned off in development
+	SessionDuration     time.Duration `env:"SESSION_DURATION" envDefault:"12h"`
+	SessionCookieSecure bool          `env:"SESSION_COOKIE_SECURE" envDefault:"true"`
+
+	// Passkeys (WebAuthn) as a second factor for admin operations: the relying party ID, normally the registry's
+	// domain, and comma-separated origins passkeys are used from (default: the origin of PUBLIC_URL).
+	// Passkeys are disabled without a relying party ID
+	WebAuthnRPID      string `env:"WEBAUTHN_RP_ID" envDefault:""`
+	WebAuthnRPOrigins string `env:"WEBAUTHN_RP_ORIGINS" envDefault:""`
+
+	// Algorithm Registry JWTs are signed with: EdDSA (JWT_PRIVATE_KEY is a hex Ed25519 seed or PEM key),
+	// ES256 or RS256 (JWT_PRIVATE_KEY is a PEM key)
+	JWTAlgorithm string `env:"JWT_ALGORITHM" envDefault:"EdDSA"`
+
+	// Former JWT signing keys still accepted for verification, as a JSON array of JWTPreviousKey
+	JWTPreviousKeys string `env:"JWT_PREVIOUS_KEYS" envDefault:""`
+
+	// Serve HTTPS with this certificate and key instead of plain HTTP
+	TLSCertFile string `env:"TLS_CERT_FILE" envDefault:""`
+	TLSKeyFile  string `env:"TLS_KEY_FILE" envDefault:""`
+
+	// Mutual TLS login: PEM bundle of the CAs whose client certificates can log in at /v0/auth/mtls.
+	// Requires TLS_CERT_FILE and TLS_KEY_FILE, as the certificate is presented in the TLS handshake.
+	MTLSCAFile string `env:"MTLS_CA_FILE" envDefault:""`
+
+	// Anonymous login (ENABLE_ANONYMOUS_AUTH): comma-separated <namespace>/* patterns anonymous tokens may
+	// publish to, and how long after their last publish servers in them are deleted (0 keeps them)
+	AnonymousNamespaces string        `env:"ANONYMOUS_NAMESPACES" envDefault:"io.modelcontextprotocol.anonymous/*"`
+	AnonymousServerTTL  time.Duration `env:"ANONYMOUS_SERVER_TTL" envDefault:"24h"`
+
+	// Brute-force protection for login and token exchange: failed attempts from a client or for an identity
+	// are progressively delayed, and locked out for AUTH_LOCKOUT_DURATION once they reach the threshold.
+	// A threshold of 0 disables it
+	AuthLockoutThreshold int           `env:"AUTH_LOCKOUT_THRESHOLD" envDefault:"10"`
+	AuthLockoutDuration  time.Duration `env:"AUTH_LOCKOUT_DURATION" envDefault:"15m"`
+
+	// GitHub App whose installation tokens verify organization membership, including private memberships,
+	// in the organizations that install it. The private key is the PEM key generated for the app
+	GitHubAppID         int64  `env:"GITHUB_APP_ID" envDefault:"0"`
+	GitHubAppPrivateKey string `env:"GITHUB_APP_PRIVATE_KEY" envDefault:""`
+
+	// Organization role GitHub users need to publish to io.github.<org>/*: member (admins included) or admin
+	GitHubOrgRole string `env:"GITHUB_ORG_ROLE" envDefault:"member"`
+
+	// Permission GitHub users need on a server's GitHub repository to publish it: write (admins included),
+	// admin, or empty to not check. Checked through the GitHub App, which must be installed on the repository
+	GitHubRepoPermission string `env:"GITHUB_REPO_PERMISSION" envDefault:""`
+
+	// DNS login: comma-separated resolvers that must all agree on the domain's TXT records, each a host[:port]
+	// or a DNS-over-HTTPS URL. Empty uses the system resolver
+	DNSResolvers string `env:"DNS_RESOLVERS" envDefault:""`
+
+	// Google sign-in: Workspace accounts can publish to the namespace of their verified domain
+	GoogleClientID string `env:"GOOGLE_CLIENT_ID" envDefault:""`
+
+	// Microsoft Entra ID sign-in: ID tokens from this tenant (its ID, not a domain) issued to this app registration
+	// can be exchanged at /v0/auth/entra. Permissions come from the token's groups claim, mapped in
+	// ENTRA_GROUP_PERMISSIONS as a JSON array of EntraGroupMapping
+	EntraTenantID         string `env:"ENTRA_TENANT_ID" envDefault:""`
+	EntraClientID         string `env:"ENTRA_CLIENT_ID" envDefault:""`
+	EntraGroupPermissions string `env:"ENTRA_GROUP_PERMISSIONS" envDefault:""`
+
+	// Publish authorization policies: a JSON array of PublishPolicy, whose CEL expressions every publish must satisfy
+	PublishPolicies string `env:"PUBLISH_POLICIES" envDefault:""`
+
+	// GitHub repository whose latest release /v0/publisher/release describes for 'mcp-publisher self-update',
+	// or empty to not serve it
+	PublisherReleaseRepository string `env:"PUBLISHER_RELEASE_REPOSITORY" envDefault:"modelcontextprotocol/registry"`
 
 	// OIDC Configuration
 	OIDCEnabled      bool   `env:"OIDC_ENABLED" envDefault:"false"`
@@ -24,6 +202,605 @@ type Config struct {
 	OIDCExtraClaims  string `env:"OIDC_EXTRA_CLAIMS" envDefault:""`
 	OIDCEditPerms    string `env:"OIDC_EDIT_PERMISSIONS" envDefault:""`
 	OIDCPublishPerms string `env:"OIDC_PUBLISH_PERMISSIONS" envDefault:""`
+	OIDCDeletePerms  string `env:"OIDC_DELETE_PERMISSIONS" envDefault:""`
+	OIDCAdminPerms   string `env:"OIDC_ADMIN_PERMISSIONS" envDefault:""`
+	// Permissions granted by matching claims of the OIDC_ISSUER issuer's tokens, as a JSON array of OIDCClaimMapping
+	OIDCClaimMappings string `env:"OIDC_CLAIM_MAPPINGS" envDefault:""`
+	// Advertise the OIDC_ISSUER issuer and client in /v0/health for 'mcp-publisher login browser'. The client
+	// must be a public client that accepts loopback redirect URIs (http://127.0.0.1:<port>/callback) with PKCE
+	OIDCBrowserLogin bool `env:"OIDC_BROWSER_LOGIN" envDefault:"false"`
+
+	// Additional OIDC issuers trusted at /v0/auth/oidc, as a JSON array of OIDCIssuer
+	OIDCIssuers string `env:"OIDC_ISSUERS" envDefault:""`
+}
+
+// OIDCIssuer configures an OIDC provider whose ID tokens can be exchanged for Registry JWTs.
+// Permission patterns may contain {claim} placeholders, which are replaced with the value of
+// that claim in the ID token.
+type OIDCIssuer struct {
+	Issuer             string           `json:"issuer"`
+	ClientID           string           `json:"client_id"`
+	ExtraClaims        []map[string]any `json:"extra_claims,omitempty"`
+	SubjectClaim       string           `json:"subject_claim,omitempty"`
+	PublishPermissions []string         `json:"publish_permissions,omitempty"`
+	EditPermissions    []string         `json:"edit_permissions,omitempty"`
+	DeletePermissions  []string         `json:"delete_permissions,omitempty"`
+	AdminPermissions   []string         `json:"admin_permissions,omitempty"`
+	// Values of the aud claim that are accepted, one of which the token must have; defaults to the client ID
+	Audiences []string `json:"audiences,omitempty"`
+	// Claim conditions every token must meet, such as "repository_owner == acme"; see ClaimCondition
+	Conditions []string `json:"conditions,omitempty"`
+	// Further permissions for tokens meeting other conditions
+	Grants []OIDCGrant `json:"grants,omitempty"`
+	// Permissions for tokens whose claims match, such as the roles or groups an identity provider adds
+	ClaimMappings []OIDCClaimMapping `json:"claim_mappings,omitempty"`
+}
+
+// OIDCGrant grants permissions to the tokens of an issuer that meet all of its claim conditions, so that
+// different repositories, groups or environments can publish to different namespaces
+type OIDCGrant struct {
+	Conditions         []string `json:"conditions"`
+	PublishPermissions []string `json:"publish_permissions,omitempty"`
+	EditPermissions    []string `json:"edit_permissions,omitempty"`
+	DeletePermissions  []string `json:"delete_permissions,omitempty"`
+	AdminPermissions   []string `json:"admin_permissions,omitempty"`
+}
+
+// Claim mapping match types
+const (
+	ClaimMatchEquals  = "equals"
+	ClaimMatchPrefix  = "prefix"
+	ClaimMatchRegex   = "regex"
+	ClaimMatchPresent = "present"
+)
+
+// oidcActions are the permission actions claim mappings can grant
+var oidcActions = []string{"publish", "edit", "delete", "admin"}
+
+// OIDCClaimMapping grants actions on namespaces to the tokens of an issuer with a claim that matches. A claim
+// holding a list matches if any of its values does.
+type OIDCClaimMapping struct {
+	// Path of the claim, with dots between the names of nested objects, such as realm_access.roles
+	Claim string `json:"claim"`
+	// How the claim's values are matched: equals (the default), prefix, regex or present
+	Match string `json:"match,omitempty"`
+	// Value, prefix or regular expression to match; a regular expression must match the whole value
+	Value string `json:"value,omitempty"`
+	// Actions granted: publish, edit, delete or admin
+	Actions []string `json:"actions"`
+	// Namespace patterns the actions are granted on. They may contain {claim} placeholders and, for regex
+	// matches, ${1} or ${name} for the groups captured from each matching value.
+	Namespaces []string `json:"namespaces"`
+}
+
+// MatchType returns how the mapping matches claim values
+func (m OIDCClaimMapping) MatchType() string {
+	if m.Match == "" {
+		return ClaimMatchEquals
+	}
+	return m.Match
+}
+
+// Regexp returns the mapping's regular expression, anchored to match whole values, or nil if it
+// does not match by regular expression
+func (m OIDCClaimMapping) Regexp() (*regexp.Regexp, error) {
+	if m.MatchType() != ClaimMatchRegex {
+		return nil, nil
+	}
+	pattern, err := regexp.Compile(`^(?:` + m.Value + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid claim mapping for %s: %w", m.Claim, err)
+	}
+	return pattern, nil
+}
+
+// Validate checks that the mapping can be applied
+func (m OIDCClaimMapping) Validate() error {
+	if !claimNamePattern.MatchString(m.Claim) {
+		return fmt.Errorf("invalid claim mapping: invalid claim path %q", m.Claim)
+	}
+	switch m.MatchType() {
+	case ClaimMatchEquals, ClaimMatchPrefix, ClaimMatchRegex:
+		if m.Value == "" {
+			return fmt.Errorf("invalid claim mapping for %s: a value to match is required", m.Claim)
+		}
+	case ClaimMatchPresent:
+		if m.Value != "" {
+			return fmt.Errorf("invalid claim mapping for %s: present matches take no value", m.Claim)
+		}
+	default:
+		return fmt.Errorf("invalid claim mapping for %s: unknown match type %q; expected equals, prefix, regex or present", m.Claim, m.Match)
+	}
+	if _, err := m.Regexp(); err != nil {
+		return err
+	}
+
+	if len(m.Actions) == 0 {
+		return fmt.Errorf("invalid claim mapping for %s: no actions", m.Claim)
+	}
+	for _, action := range m.Actions {
+		if !slices.Contains(oidcActions, action) {
+			return fmt.Errorf("invalid claim mapping for %s: unknown action %q; expected publish, edit, delete or admin", m.Claim, action)
+		}
+	}
+	if len(m.Namespaces) == 0 {
+		return fmt.Errorf("invalid claim mapping for %s: no namespaces", m.Claim)
+	}
+	for _, namespace := range m.Namespaces {
+		if strings.TrimSpace(namespace) == "" {
+			return fmt.Errorf("invalid claim mapping for %s: empty namespace", m.Claim)
+		}
+		if m.MatchType() != ClaimMatchRegex && strings.Contains(namespace, "$") {
+			return fmt.Errorf("invalid claim mapping for %s: namespace %q refers to captured groups, which only regex matches have", m.Claim, namespace)
+		}
+	}
+	return nil
+}
+
+// ExpectedAudiences returns the values of the aud claim the issuer's tokens are accepted with
+func (i OIDCIssuer) ExpectedAudiences() []string {
+	if len(i.Audiences) > 0 {
+		return i.Audiences
+	}
+	return []string{i.ClientID}
+}
+
+// Claim condition operators
+const (
+	ClaimEquals    = "=="
+	ClaimNotEquals = "!="
+	ClaimMatches   = "=~"
+)
+
+// claimNamePattern matches the claim names conditions can test
+var claimNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.:/-]*$`)
+
+// ClaimCondition tests a claim of an ID token. It is written "<claim> == <value>", "<claim> != <value>" or
+// "<claim> =~ <regular expression>", where the value may be quoted and the expression must match the whole
+// value. Claims holding a list meet == and =~ if any of their values does, and != if none equals the value.
+type ClaimCondition struct {
+	Claim    string
+	Operator string
+	Value    string
+	pattern  *regexp.Regexp
+}
+
+// ParseClaimCondition parses a claim condition expression
+func ParseClaimCondition(expression string) (ClaimCondition, error) {
+	index, operator := -1, ""
+	for _, candidate := range []string{ClaimEquals, ClaimNotEquals, ClaimMatches} {
+		if i := strings.Index(expression, candidate); i >= 0 && (index < 0 || i < index) {
+			index, operator = i, candidate
+		}
+	}
+	if index < 0 {
+		return ClaimCondition{}, fmt.Errorf("invalid claim condition %q: expected <claim> == <value>, <claim> != <value> or <claim> =~ <regexp>", expression)
+	}
+
+	condition := ClaimCondition{
+		Claim:    strings.TrimSpace(expression[:index]),
+		Operator: operator,
+		Value:    strings.TrimSpace(expression[index+len(operator):]),
+	}
+	if !claimNamePattern.MatchString(condition.Claim) {
+		return ClaimCondition{}, fmt.Errorf("invalid claim condition %q: invalid claim name %q", expression, condition.Claim)
+	}
+	if len(condition.Value) >= 2 && (condition.Value[0] == '"' || condition.Value[0] == '\'') && condition.Value[len(condition.Value)-1] == condition.Value[0] {
+		condition.Value = condition.Value[1 : len(condition.Value)-1]
+	}
+	if operator == ClaimMatches {
+		pattern, err := regexp.Compile(`^(?:` + condition.Value + `)$`)
+		if err != nil {
+			return ClaimCondition{}, fmt.Errorf("invalid claim condition %q: %w", expression, err)
+		}
+		condition.pattern = pattern
+	}
+	return condition, nil
+}
+
+// ParseClaimConditions parses a list of claim condition expressions
+func ParseClaimConditions(expressions []string) ([]ClaimCondition, error) {
+	conditions := make([]ClaimCondition, 0, len(expressions))
+	for _, expression := range expressions {
+		condition, err := ParseClaimCondition(expression)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// Matches reports whether a claim with the given values meets the condition. A missing claim has no values.
+func (c ClaimCondition) Matches(values []string) bool {
+	switch c.Operator {
+	case ClaimEquals:
+		return slices.Contains(values, c.Value)
+	case ClaimNotEquals:
+		return !slices.Contains(values, c.Value)
+	case ClaimMatches:
+		return slices.ContainsFunc(values, c.pattern.MatchString)
+	default:
+		return false
+	}
+}
+
+// String returns the condition as it is written in the configuration
+func (c ClaimCondition) String() string {
+	return c.Claim + " " + c.Operator + " " + strconv.Quote(c.Value)
+}
+
+// OIDCIssuerConfigs returns the configured OIDC issuers: the one set with OIDC_ISSUER when OIDC is
+// enabled, followed by those in OIDC_ISSUERS
+func (c *Config) OIDCIssuerConfigs() ([]OIDCIssuer, error) {
+	var issuers []OIDCIssuer
+
+	if c.OIDCEnabled {
+		issuer := OIDCIssuer{
+			Issuer:             c.OIDCIssuer,
+			ClientID:           c.OIDCClientID,
+			PublishPermissions: splitPatterns(c.OIDCPublishPerms),
+			EditPermissions:    splitPatterns(c.OIDCEditPerms),
+			DeletePermissions:  splitPatterns(c.OIDCDeletePerms),
+			AdminPermissions:   splitPatterns(c.OIDCAdminPerms),
+		}
+		if c.OIDCExtraClaims != "" {
+			if err := json.Unmarshal([]byte(c.OIDCExtraClaims), &issuer.ExtraClaims); err != nil {
+				return nil, fmt.Errorf("invalid OIDC extra claims: %w", err)
+			}
+		}
+		if c.OIDCClaimMappings != "" {
+			if err := json.Unmarshal([]byte(c.OIDCClaimMappings), &issuer.ClaimMappings); err != nil {
+				return nil, fmt.Errorf("invalid OIDC claim mappings: %w", err)
+			}
+		}
+		issuers = append(issuers, issuer)
+	}
+
+	if c.OIDCIssuers != "" {
+		var additional []OIDCIssuer
+		if err := json.Unmarshal([]byte(c.OIDCIssuers), &additional); err != nil {
+			return nil, fmt.Errorf("invalid OIDC issuers: %w", err)
+		}
+		issuers = append(issuers, additional...)
+	}
+
+	seen := make(map[string]bool)
+	for _, issuer := range issuers {
+		if issuer.Issuer == "" || issuer.ClientID == "" {
+			return nil, errors.New("OIDC issuer and client ID are required")
+		}
+		if seen[issuer.Issuer] {
+			return nil, fmt.Errorf("OIDC issuer %s is configured more than once", issuer.Issuer)
+		}
+		seen[issuer.Issuer] = true
+
+		if slices.Contains(issuer.Audiences, "") {
+			return nil, fmt.Errorf("OIDC issuer %s has an empty audience", issuer.Issuer)
+		}
+		if _, err := ParseClaimConditions(issuer.Conditions); err != nil {
+			return nil, fmt.Errorf("OIDC issuer %s: %w", issuer.Issuer, err)
+		}
+		for _, grant := range issuer.Grants {
+			if len(grant.Conditions) == 0 {
+				return nil, fmt.Errorf("OIDC issuer %s has a grant without conditions; use its own permissions instead", issuer.Issuer)
+			}
+			if _, err := ParseClaimConditions(grant.Conditions); err != nil {
+				return nil, fmt.Errorf("OIDC issuer %s: %w", issuer.Issuer, err)
+			}
+		}
+		for _, mapping := range issuer.ClaimMappings {
+			if err := mapping.Validate(); err != nil {
+				return nil, fmt.Errorf("OIDC issuer %s: %w", issuer.Issuer, err)
+			}
+		}
+	}
+
+	return issuers, nil
+}
+
+// EntraGroupMapping grants the members of an Entra ID group permissions for namespace patterns
+type EntraGroupMapping struct {
+	// Object ID of the group, as it appears in the groups claim
+	Group              string   `json:"group"`
+	PublishPermissions []string `json:"publish_permissions,omitempty"`
+	EditPermissions    []string `json:"edit_permissions,omitempty"`
+}
+
+// entraIDPattern matches the GUIDs Entra ID identifies tenants and groups by
+var entraIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// EntraGroupMappings returns the group permissions configured in ENTRA_GROUP_PERMISSIONS, or nil if Entra ID
+// sign-in is not configured
+func (c *Config) EntraGroupMappings() ([]EntraGroupMapping, error) {
+	if c.EntraClientID == "" {
+		return nil, nil
+	}
+	if !entraIDPattern.MatchString(c.EntraTenantID) {
+		return nil, fmt.Errorf("invalid Entra tenant ID %q: must be the tenant's GUID", c.EntraTenantID)
+	}
+	if c.EntraGroupPermissions == "" {
+		return nil, errors.New("Entra group permissions are required when Entra ID sign-in is enabled")
+	}
+
+	var mappings []EntraGroupMapping
+	if err := json.Unmarshal([]byte(c.EntraGroupPermissions), &mappings); err != nil {
+		return nil, fmt.Errorf("invalid Entra group permissions: %w", err)
+	}
+	for _, mapping := range mappings {
+		if !entraIDPattern.MatchString(mapping.Group) {
+			return nil, fmt.Errorf("invalid Entra group %q: must be the group's object ID", mapping.Group)
+		}
+		for _, pattern := range slices.Concat(mapping.PublishPermissions, mapping.EditPermissions) {
+			if pattern == "" {
+				return nil, fmt.Errorf("Entra group %s has an empty permission pattern", mapping.Group)
+			}
+		}
+	}
+	return mappings, nil
+}
+
+// PublishPolicy is a rule every publish must satisfy, written as a CEL expression over the publishing token's
+// claims and the server being published that evaluates to true when the publish is allowed
+type PublishPolicy struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	// Explanation returned to publishers the policy turns away
+	Message string `json:"message,omitempty"`
+}
+
+// PublishPolicyConfigs returns the publish policies configured in PUBLISH_POLICIES. Their expressions are
+// compiled by the policy package.
+func (c *Config) PublishPolicyConfigs() ([]PublishPolicy, error) {
+	if c.PublishPolicies == "" {
+		return nil, nil
+	}
+
+	var policies []PublishPolicy
+	if err := json.Unmarshal([]byte(c.PublishPolicies), &policies); err != nil {
+		return nil, fmt.Errorf("invalid publish policies: %w", err)
+	}
+	seen := make(map[string]bool)
+	for _, policy := range policies {
+		if policy.Name == "" || policy.Expression == "" {
+			return nil, errors.New("publish policy name and expression are required")
+		}
+		if seen[policy.Name] {
+			return nil, fmt.Errorf("publish policy %s is configured more than once", policy.Name)
+		}
+		seen[policy.Name] = true
+	}
+	return policies, nil
+}
+
+// JWTPreviousKey is a former JWT signing key. Tokens it signed are accepted until RetireAt, or
+// until it is removed from the configuration if RetireAt is not set.
+type JWTPreviousKey struct {
+	// Signing algorithm the key was used with (EdDSA, ES256 or RS256); defaults to EdDSA
+	Algorithm string `json:"algorithm,omitempty"`
+	// The private key, in the same format as JWT_PRIVATE_KEY, or the public key as PEM (or hex for Ed25519)
+	PrivateKey string    `json:"private_key,omitempty"`
+	PublicKey  string    `json:"public_key,omitempty"`
+	RetireAt   time.Time `json:"retire_at,omitempty"`
+}
+
+// JWTPreviousKeyConfigs returns the former JWT signing keys configured in JWT_PREVIOUS_KEYS
+func (c *Config) JWTPreviousKeyConfigs() ([]JWTPreviousKey, error) {
+	if c.JWTPreviousKeys == "" {
+		return nil, nil
+	}
+
+	var keys []JWTPreviousKey
+	if err := json.Unmarshal([]byte(c.JWTPreviousKeys), &keys); err != nil {
+		return nil, fmt.Errorf("invalid previous JWT keys: %w", err)
+	}
+	return keys, nil
+}
+
+// MTLSClientCAs loads the CAs trusted to issue client certificates for mutual TLS login, or
+// returns nil if mutual TLS is not configured
+func (c *Config) MTLSClientCAs() (*x509.CertPool, error) {
+	if c.MTLSCAFile == "" {
+		return nil, nil
+	}
+	if c.TLSCertFile == "" || c.TLSKeyFile == "" {
+		return nil, errors.New("mutual TLS requires TLS certificate and key files")
+	}
+
+	bundle, err := os.ReadFile(c.MTLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mutual TLS CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New("mutual TLS CA bundle contains no PEM certificates")
+	}
+	return pool, nil
+}
+
+// DNSResolverAddresses returns the resolvers DNS login queries, or nil to use the system resolver
+func (c *Config) DNSResolverAddresses() ([]string, error) {
+	addresses := splitPatterns(c.DNSResolvers)
+	for _, address := range addresses {
+		if strings.Contains(address, "://") {
+			parsed, err := url.Parse(address)
+			if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+				return nil, fmt.Errorf("invalid DNS-over-HTTPS resolver %q: must be an https URL", address)
+			}
+			continue
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil && net.ParseIP(address) == nil && strings.Contains(address, ":") {
+			return nil, fmt.Errorf("invalid DNS resolver %q: must be host[:port]", address)
+		}
+	}
+	return addresses, nil
+}
+
+// Features reports which optional features are enabled, for GET /v0/version. Settings that would tell
+// attackers about the deployment, such as the debug server, are left out.
+func (c *Config) Features() map[string]bool {
+	return map[string]bool{
+		"anonymous_auth":      c.EnableAnonymousAuth,
+		"registry_validation": c.EnableRegistryValidation,
+		"github_auth":         c.GithubClientID != "",
+		"gitlab_auth":         c.GitLabClientID != "",
+		"oidc":                c.OIDCEnabled || c.OIDCIssuers != "",
+		"access_log":          c.AccessLogEnabled,
+		"metrics":             c.TelemetryMetricsEnabled,
+		"tracing":             c.TelemetryTracingEndpoint != "",
+		"error_reporting":     c.TelemetryErrorReportingDSN != "",
+		"alerts":              c.AlertWebhookURLs != "",
+		"log_throttling":      c.LogThrottleWindow > 0,
+		"slow_query_log":      c.DatabaseSlowQueryThreshold > 0,
+	}
+}
+
+// AlertWebhooks returns the webhooks to notify of alerts, or nil if alerts are disabled
+func (c *Config) AlertWebhooks() ([]string, error) {
+	webhooks := splitPatterns(c.AlertWebhookURLs)
+	for _, webhook := range webhooks {
+		u, err := url.Parse(webhook)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			// The URL is left out of the error, as webhook URLs often embed a secret
+			return nil, errors.New("invalid alert webhook URL: must be an http or https URL")
+		}
+	}
+	if len(webhooks) > 0 && c.AlertInterval <= 0 {
+		return nil, fmt.Errorf("invalid alert interval %v: must be positive", c.AlertInterval)
+	}
+	return webhooks, nil
+}
+
+// MetricsAllowedNetworks returns the networks /metrics may be scraped from, or nil to allow any
+func (c *Config) MetricsAllowedNetworks() ([]*net.IPNet, error) {
+	return parseNetworks(c.TelemetryMetricsAllowedCIDRs, "metrics allowed network")
+}
+
+// TrustedProxyNetworks returns the networks of the proxies in TRUSTED_PROXIES
+func (c *Config) TrustedProxyNetworks() ([]*net.IPNet, error) {
+	return parseNetworks(c.TrustedProxies, "trusted proxy")
+}
+
+// parseNetworks parses a comma-separated list of CIDRs and IP addresses, describing invalid entries as
+// what in errors
+func parseNetworks(value, what string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, entry := range splitPatterns(value) {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid %s %q: must be a CIDR or IP address", what, entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", what, entry, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// GitHub organization roles that can be required with GITHUB_ORG_ROLE
+const (
+	GitHubOrgRoleMember = "member"
+	GitHubOrgRoleAdmin  = "admin"
+)
+
+// RequiredGitHubOrgRole returns the organization role required by GITHUB_ORG_ROLE, defaulting to member
+func (c *Config) RequiredGitHubOrgRole() (string, error) {
+	switch c.GitHubOrgRole {
+	case "", GitHubOrgRoleMember:
+		return GitHubOrgRoleMember, nil
+	case GitHubOrgRoleAdmin:
+		return GitHubOrgRoleAdmin, nil
+	}
+	return "", fmt.Errorf("invalid GitHub organization role %q (allowed: %s, %s)", c.GitHubOrgRole, GitHubOrgRoleMember, GitHubOrgRoleAdmin)
+}
+
+// GitHub repository permissions that can be required with GITHUB_REPO_PERMISSION
+const (
+	GitHubRepoPermissionWrite = "write"
+	GitHubRepoPermissionAdmin = "admin"
+)
+
+// RequiredGitHubRepoPermission returns the repository permission required by GITHUB_REPO_PERMISSION,
+// or "" if repository permissions are not checked
+func (c *Config) RequiredGitHubRepoPermission() (string, error) {
+	switch c.GitHubRepoPermission {
+	case "":
+		return "", nil
+	case GitHubRepoPermissionWrite, GitHubRepoPermissionAdmin:
+		if c.GitHubAppID == 0 {
+			return "", errors.New("GitHub repository permissions are checked through a GitHub App, but no GitHub App ID is set")
+		}
+		return c.GitHubRepoPermission, nil
+	}
+	return "", fmt.Errorf("invalid GitHub repository permission %q (allowed: %s, %s)", c.GitHubRepoPermission, GitHubRepoPermissionWrite, GitHubRepoPermissionAdmin)
+}
+
+// PasskeyOrigins returns the origins passkeys can be used from, or nil if passkeys are disabled. Browsers
+// only use a passkey on its relying party ID's domain and its subdomains, so every origin must be on it.
+func (c *Config) PasskeyOrigins() ([]string, error) {
+	if c.WebAuthnRPID == "" {
+		return nil, nil
+	}
+
+	origins := splitPatterns(c.WebAuthnRPOrigins)
+	if len(origins) == 0 && c.PublicURL != "" {
+		origins = []string{c.PublicURL}
+	}
+	if len(origins) == 0 {
+		return nil, errors.New("passkeys need the origins they are used from, or a public URL to default to")
+	}
+
+	for i, origin := range origins {
+		parsed, err := url.Parse(origin)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid passkey origin %q: must be an http or https URL", origin)
+		}
+		host := parsed.Hostname()
+		if host != c.WebAuthnRPID && !strings.HasSuffix(host, "."+c.WebAuthnRPID) {
+			return nil, fmt.Errorf("passkey origin %q is not on the relying party ID's domain %s", origin, c.WebAuthnRPID)
+		}
+		origins[i] = parsed.Scheme + "://" + parsed.Host
+	}
+	return origins, nil
+}
+
+// DefaultAnonymousNamespace is the namespace anonymous tokens publish to when ANONYMOUS_NAMESPACES is empty
+const DefaultAnonymousNamespace = "io.modelcontextprotocol.anonymous/*"
+
+// AnonymousNamespacePatterns returns the namespace patterns anonymous tokens may publish to. Each must
+// cover a single namespace, so that the cleanup of anonymous servers cannot reach other publishers'.
+func (c *Config) AnonymousNamespacePatterns() ([]string, error) {
+	patterns := splitPatterns(c.AnonymousNamespaces)
+	if len(patterns) == 0 {
+		return []string{DefaultAnonymousNamespace}, nil
+	}
+	for _, pattern := range patterns {
+		namespace, ok := strings.CutSuffix(pattern, "/*")
+		if !ok || namespace == "" || strings.ContainsAny(namespace, "/*") {
+			return nil, fmt.Errorf("invalid anonymous namespace %q: must be <namespace>/*", pattern)
+		}
+	}
+	return patterns, nil
+}
+
+func splitPatterns(patterns string) []string {
+	var result []string
+	for _, pattern := range strings.Split(patterns, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			result = append(result, pattern)
+		}
+	}
+	return result
 }
 
 // NewConfig creates a new configuration with default values
@@ -6,17 +6,35 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 // Common database errors
 var (
-	ErrNotFound          = errors.New("record not found")
-	ErrAlreadyExists     = errors.New("record already exists")
-	ErrInvalidInput      = errors.New("invalid input")
-	ErrDatabase          = errors.New("database error")
-	ErrInvalidVersion    = errors.New("invalid version: cannot publish duplicate version")
-	ErrMaxServersReached = errors.New("maximum number of versions for this server reached (10000): please reach out at https://github.com/modelcontextprotocol/registry to explain your use case")
+	ErrNotFound               = errors.New("record not found")
+	ErrAlreadyExists          = errors.New("record already exists")
+	ErrInvalidInput           = errors.New("invalid input")
+	ErrDatabase               = errors.New("database error")
+	ErrInvalidVersion         = errors.New("invalid version: cannot publish duplicate version")
+	ErrMaxServersReached      = errors.New("maximum number of versions for this server reached (10000): please reach out at https://github.com/modelcontextprotocol/registry to explain your use case")
+	ErrIdempotencyKeyInUse    = errors.New("idempotency key is in use by a request that has not finished")
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key has already been used for a different request")
+	ErrAuthorizationPending   = errors.New("device authorization is pending")
+	ErrSlowDown               = errors.New("device authorization polled too often")
+	ErrAccessDenied           = errors.New("device authorization was denied")
+	ErrExpiredToken           = errors.New("device code has expired")
+	ErrPasskeyNotVerified     = errors.New("passkey verification failed")
+)
+
+// SortField defines the ordering of server list results
+type SortField string
+
+const (
+	SortByName      SortField = "name"      // server name, then version
+	SortByUpdated   SortField = "updated"   // last update time
+	SortByCreated   SortField = "created"   // publish time
+	SortByRelevance SortField = "relevance" // closeness of the name to SubstringName, falling back to name
 )
 
 // ServerFilter defines filtering options for server queries
@@ -27,6 +45,297 @@ type ServerFilter struct {
 	SubstringName *string    // for substring search on name
 	Version       *string    // for exact version matching
 	IsLatest      *bool      // for filtering latest versions only
+	NamePatterns  []string   // for names matching a permission pattern: an exact name, a prefix ending in *, or *
+	Sort          SortField  // ordering of results (defaults to SortByName)
+	SortDesc      bool       // for reversing the ordering
+}
+
+// DailyFetchCount is the number of times server details were fetched on a given day
+type DailyFetchCount struct {
+	Day   time.Time
+	Count int64
+}
+
+// ServerFetchCount is the total number of fetches for a server over a period
+type ServerFetchCount struct {
+	ServerName string
+	Count      int64
+}
+
+// MaintenanceMode is the registry-wide maintenance mode state
+type MaintenanceMode struct {
+	Enabled   bool
+	Message   string
+	UpdatedAt time.Time
+}
+
+// ServerTransfer is a pending transfer of a server to a new name
+type ServerTransfer struct {
+	ServerName  string
+	NewName     string
+	InitiatedBy string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// ServerReadme is the Markdown README of a server, shared by all of its versions
+type ServerReadme struct {
+	ServerName string
+	Content    string
+	UpdatedBy  string
+	UpdatedAt  time.Time
+}
+
+// ServerIcon is an icon uploaded for a server, shared by all of its versions
+type ServerIcon struct {
+	ServerName string
+	MimeType   string
+	Data       []byte // not loaded by GetServerIconsByNames
+	Width      int    // zero for SVG icons
+	Height     int
+	Checksum   string // hex SHA-256 of Data
+	UpdatedBy  string
+	UpdatedAt  time.Time
+}
+
+// Namespace reservation statuses
+const (
+	ReservationStatusActive   = "active"
+	ReservationStatusDisputed = "disputed"
+)
+
+// NamespaceReservation pins a namespace to the permission pattern used to reserve it
+type NamespaceReservation struct {
+	Namespace         string
+	Owner             string // who reserved the namespace, as "<auth method>:<subject>"
+	PermissionPattern string // publish permission a token must carry to publish in the namespace
+	Status            string
+	DisputeReason     string
+	DisputedBy        string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Organization member roles
+const (
+	OrganizationRoleAdmin  = "admin"
+	OrganizationRoleMember = "member"
+)
+
+// Organization lets its members publish in a namespace
+type Organization struct {
+	Namespace         string
+	PermissionPattern string // publish permission granted to members, taken from the creator's token
+	CreatedBy         string // who created the organization, as "<auth method>:<subject>"
+	CreatedAt         time.Time
+}
+
+// OrganizationMember is a user's membership of an organization
+type OrganizationMember struct {
+	Namespace string
+	Member    string // the member, as "<auth method>:<subject>"
+	Role      string
+	AddedBy   string
+	AddedAt   time.Time
+}
+
+// NamespaceDelegation gives an account publish rights for part of a namespace
+type NamespaceDelegation struct {
+	ID                int64
+	Namespace         string
+	PermissionPattern string // publish permission the delegate gets, within the namespace
+	Delegate          string // who the rights are given to, as "<auth method>:<subject>"
+	GrantedBy         string
+	GrantedWith       string // the namespace permission of the granting token
+	CreatedAt         time.Time
+	ExpiresAt         time.Time
+	RevokedAt         *time.Time
+}
+
+// Abuse report categories and statuses
+const (
+	ReportCategoryMalware    = "malware"
+	ReportCategorySquatting  = "squatting"
+	ReportCategoryMisleading = "misleading"
+	ReportCategoryOther      = "other"
+
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// AbuseReport is a report about a server in the moderation queue
+type AbuseReport struct {
+	ID         int64
+	ServerName string
+	Category   string
+	Details    string
+	Reporter   string // "<auth method>:<subject>", or empty for anonymous reports
+	Status     string
+	ResolvedBy string
+	CreatedAt  time.Time
+	ResolvedAt *time.Time
+}
+
+// Change feed entry types
+const (
+	ChangeTypePublish = "publish"
+	ChangeTypeUpdate  = "update"
+	ChangeTypeDelete  = "delete"
+)
+
+// ServerChange is an entry in the change feed of server versions
+type ServerChange struct {
+	ID         int64
+	Type       string
+	ServerName string
+	Version    string
+	CreatedAt  time.Time
+}
+
+// IdempotencyRecord is a claimed idempotency key and, once the request has completed, its response
+type IdempotencyRecord struct {
+	Actor       string // the token holder the key belongs to, as "<auth method>:<subject>"
+	Key         string
+	RequestHash string // hex SHA-256 of the request body
+	Response    []byte // JSON response body; nil while the request is in progress
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// APIToken is a long-lived token that can be exchanged for Registry JWTs with a fixed set of permissions
+type APIToken struct {
+	ID          int64
+	Owner       string // who created the token, as "<auth method>:<subject>"
+	Name        string
+	Prefix      string // start of the token, to help owners recognize it
+	Hash        string // hex SHA-256 of the token; not loaded when reading tokens
+	Permissions []auth.Permission
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+}
+
+// WebSession is a browser login held in a session cookie, exchanged for Registry JWTs with the
+// identity and permissions of the login it was created from
+type WebSession struct {
+	ID          int64
+	Owner       string // who logged in, as "<auth method>:<subject>"
+	AuthMethod  auth.Method
+	Subject     string
+	Permissions []auth.Permission
+	Hash        string // hex SHA-256 of the session cookie; not loaded when reading sessions
+	CSRFToken   string // must accompany state-changing requests made with the session
+	UserAgent   string
+	ClientIP    string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+}
+
+// Device authorization statuses
+const (
+	DeviceAuthorizationPending  = "pending"
+	DeviceAuthorizationApproved = "approved"
+	DeviceAuthorizationDenied   = "denied"
+)
+
+// DeviceAuthorization is a device login waiting to be approved from another device, or to be
+// collected by the device once approved
+type DeviceAuthorization struct {
+	DeviceCodeHash string // hex SHA-256 of the device code held by the device
+	UserCode       string // code the user enters on the approving device, without separators
+	Status         string
+	ApprovedBy     string // who approved or denied the login, as "<auth method>:<subject>"
+	AuthMethod     auth.Method
+	Subject        string
+	Permissions    []auth.Permission
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	LastPolledAt   *time.Time
+}
+
+// Passkey challenge ceremonies
+const (
+	PasskeyCeremonyRegistration = "registration"
+	PasskeyCeremonyVerification = "verification"
+)
+
+// Passkey is a WebAuthn credential its owner verifies with before admin operations
+type Passkey struct {
+	ID           int64
+	Owner        string // whose passkey it is, as "<auth method>:<subject>"
+	Name         string
+	CredentialID []byte
+	Credential   []byte // the WebAuthn credential record, as JSON
+	CreatedAt    time.Time
+	LastUsedAt   *time.Time
+}
+
+// PasskeyChallenge is an outstanding WebAuthn challenge, along with the ceremony state needed to check
+// the response to it
+type PasskeyChallenge struct {
+	Challenge   string
+	Owner       string
+	Ceremony    string
+	SessionData []byte // JSON
+	ExpiresAt   time.Time
+}
+
+// AuditEvent records an ownership or administrative change
+type AuditEvent struct {
+	ID        int64             // set when the event is read from the audit log
+	Action    string            // e.g. "server.transfer.accepted"
+	Actor     string            // who made the change, as "<auth method>:<subject>"
+	Resource  string            // the affected server name or namespace
+	Details   map[string]string // action-specific context
+	CreatedAt time.Time         // set when the event is read from the audit log
+}
+
+// AuditEventFilter selects audit events; empty fields match everything
+type AuditEventFilter struct {
+	After    int64 // only events with a greater ID, to resume an export
+	Action   string
+	Actor    string
+	Resource string
+	Since    *time.Time
+	Until    *time.Time
+}
+
+// Auth event types and outcomes
+const (
+	AuthEventLogin  = "login"  // a login, token exchange or refresh at an auth endpoint
+	AuthEventAccess = "access" // a request to any other endpoint, recorded when permission is denied
+
+	AuthOutcomeSuccess   = "success"
+	AuthOutcomeFailure   = "failure"
+	AuthOutcomeThrottled = "throttled"
+	AuthOutcomeDenied    = "denied"
+)
+
+// AuthEvent records an authentication attempt or a permission denial
+type AuthEvent struct {
+	ID         int64
+	Event      string
+	Outcome    string
+	AuthMethod string // empty when the caller could not be identified
+	Subject    string // the auth method's subject, or the identity a failed login was for
+	ClientIP   string
+	Endpoint   string            // method and route, e.g. "POST /v0/auth/dns"
+	Status     int               // HTTP status of the response
+	Details    map[string]string // event-specific context, such as the issued token's ID
+	CreatedAt  time.Time
+}
+
+// AuthEventFilter selects auth events; empty fields match everything
+type AuthEventFilter struct {
+	Event    string
+	Outcome  string
+	Subject  string
+	ClientIP string
+	Since    *time.Time
 }
 
 // Database defines the interface for database operations
@@ -43,6 +352,8 @@ type Database interface {
 	GetServerByName(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
 	GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx, serverName string, version string) (*apiv0.ServerResponse, error)
+	// GetServersByNames retrieve the latest version of each of the named servers, skipping names that don't exist
+	GetServersByNames(ctx context.Context, tx pgx.Tx, serverNames []string) ([]*apiv0.ServerResponse, error)
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
 	GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx, serverName string) ([]*apiv0.ServerResponse, error)
 	// GetCurrentLatestVersion retrieve the current latest version of a server by server name
@@ -56,6 +367,172 @@ type Database interface {
 	// AcquirePublishLock acquires an exclusive advisory lock for publishing a server
 	// This prevents race conditions when multiple versions are published concurrently
 	AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverName string) error
+	// IncrementFetchCounts adds the given per-server fetch counts to the daily totals for day
+	IncrementFetchCounts(ctx context.Context, tx pgx.Tx, day time.Time, counts map[string]int64) error
+	// GetServerFetchStats retrieve daily fetch counts for a server since the given day
+	GetServerFetchStats(ctx context.Context, tx pgx.Tx, serverName string, since time.Time) ([]DailyFetchCount, error)
+	// GetDailyFetchTotals retrieve registry-wide daily fetch counts since the given day
+	GetDailyFetchTotals(ctx context.Context, tx pgx.Tx, since time.Time) ([]DailyFetchCount, error)
+	// GetTopFetchedServers retrieve the most fetched servers since the given day
+	GetTopFetchedServers(ctx context.Context, tx pgx.Tx, since time.Time, limit int) ([]ServerFetchCount, error)
+	// CreateServerTransfer records a pending transfer, replacing any existing pending transfer of the server
+	CreateServerTransfer(ctx context.Context, tx pgx.Tx, transfer *ServerTransfer) error
+	// GetServerTransfer retrieve the pending transfer of a server
+	GetServerTransfer(ctx context.Context, tx pgx.Tx, serverName string) (*ServerTransfer, error)
+	// DeleteServerTransfer removes the pending transfer of a server
+	DeleteServerTransfer(ctx context.Context, tx pgx.Tx, serverName string) error
+	// RenameServer moves every version of a server to a new name
+	RenameServer(ctx context.Context, tx pgx.Tx, oldName, newName string) error
+	// DeleteStaleServers removes the servers in the given namespaces last published before publishedBefore, returning their versions by server name
+	DeleteStaleServers(ctx context.Context, tx pgx.Tx, namespaces []string, publishedBefore time.Time) (map[string][]string, error)
+	// GetServerReadme retrieve the README of a server
+	GetServerReadme(ctx context.Context, tx pgx.Tx, serverName string) (*ServerReadme, error)
+	// SetServerReadme creates or replaces the README of a server
+	SetServerReadme(ctx context.Context, tx pgx.Tx, readme *ServerReadme) error
+	// DeleteServerReadme removes the README of a server
+	DeleteServerReadme(ctx context.Context, tx pgx.Tx, serverName string) error
+	// GetServerIcon retrieve the icon of a server, including its data
+	GetServerIcon(ctx context.Context, tx pgx.Tx, serverName string) (*ServerIcon, error)
+	// GetServerIconsByNames retrieve the icons of the named servers without their data, keyed by server name
+	GetServerIconsByNames(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string]*ServerIcon, error)
+	// SetServerIcon creates or replaces the icon of a server
+	SetServerIcon(ctx context.Context, tx pgx.Tx, icon *ServerIcon) error
+	// DeleteServerIcon removes the icon of a server
+	DeleteServerIcon(ctx context.Context, tx pgx.Tx, serverName string) error
+	// SetServerSignature stores the publisher's signature of a server version
+	SetServerSignature(ctx context.Context, tx pgx.Tx, serverName, version string, signature *apiv0.ServerSignature) error
+	// GetServerSignatures retrieve the signatures of server versions, given as parallel lists of names and versions, keyed by server name and then version
+	GetServerSignatures(ctx context.Context, tx pgx.Tx, serverNames, versions []string) (map[string]map[string]*apiv0.ServerSignature, error)
+	// DeleteServerSignature removes the signature of a server version, if it has one
+	DeleteServerSignature(ctx context.Context, tx pgx.Tx, serverName, version string) error
+	// CreateNamespaceReservation reserves a namespace, failing with ErrAlreadyExists if it is already reserved
+	CreateNamespaceReservation(ctx context.Context, tx pgx.Tx, reservation *NamespaceReservation) error
+	// GetNamespaceReservation retrieve the reservation of a namespace
+	GetNamespaceReservation(ctx context.Context, tx pgx.Tx, namespace string) (*NamespaceReservation, error)
+	// ListNamespaceReservations retrieve reservations with the given status
+	ListNamespaceReservations(ctx context.Context, tx pgx.Tx, status string) ([]*NamespaceReservation, error)
+	// UpdateNamespaceReservation saves the status and dispute details of a reservation
+	UpdateNamespaceReservation(ctx context.Context, tx pgx.Tx, reservation *NamespaceReservation) error
+	// DeleteNamespaceReservation releases a namespace
+	DeleteNamespaceReservation(ctx context.Context, tx pgx.Tx, namespace string) error
+	// CreateOrganization creates an organization, failing with ErrAlreadyExists if the namespace already has one
+	CreateOrganization(ctx context.Context, tx pgx.Tx, organization *Organization) error
+	// GetOrganization retrieve the organization of a namespace
+	GetOrganization(ctx context.Context, tx pgx.Tx, namespace string) (*Organization, error)
+	// DeleteOrganization removes an organization along with its members
+	DeleteOrganization(ctx context.Context, tx pgx.Tx, namespace string) error
+	// ListOrganizationMembers retrieve the members of an organization, admins first
+	ListOrganizationMembers(ctx context.Context, tx pgx.Tx, namespace string) ([]*OrganizationMember, error)
+	// GetOrganizationMember retrieve a user's membership of an organization
+	GetOrganizationMember(ctx context.Context, tx pgx.Tx, namespace, member string) (*OrganizationMember, error)
+	// SetOrganizationMember adds a member to an organization or changes their role
+	SetOrganizationMember(ctx context.Context, tx pgx.Tx, member *OrganizationMember) error
+	// DeleteOrganizationMember removes a member from an organization
+	DeleteOrganizationMember(ctx context.Context, tx pgx.Tx, namespace, member string) error
+	// ListMemberOrganizations retrieve the organizations a user is a member of
+	ListMemberOrganizations(ctx context.Context, tx pgx.Tx, member string) ([]*Organization, error)
+	// CreateNamespaceDelegation stores a new namespace delegation
+	CreateNamespaceDelegation(ctx context.Context, tx pgx.Tx, delegation *NamespaceDelegation) error
+	// GetNamespaceDelegation retrieve a namespace delegation by ID
+	GetNamespaceDelegation(ctx context.Context, tx pgx.Tx, id int64) (*NamespaceDelegation, error)
+	// ListNamespaceDelegations retrieve the unrevoked, unexpired delegations of a namespace, newest first
+	ListNamespaceDelegations(ctx context.Context, tx pgx.Tx, namespace string) ([]*NamespaceDelegation, error)
+	// ListDelegateDelegations retrieve the unrevoked, unexpired delegations given to an account, newest first
+	ListDelegateDelegations(ctx context.Context, tx pgx.Tx, delegate string) ([]*NamespaceDelegation, error)
+	// RevokeNamespaceDelegation marks a namespace delegation as revoked
+	RevokeNamespaceDelegation(ctx context.Context, tx pgx.Tx, id int64) error
+	// CreateAbuseReport adds a report to the moderation queue
+	CreateAbuseReport(ctx context.Context, tx pgx.Tx, report *AbuseReport) error
+	// ListAbuseReports retrieve up to limit reports with the given status, oldest first
+	ListAbuseReports(ctx context.Context, tx pgx.Tx, status string, limit int) ([]*AbuseReport, error)
+	// UpdateAbuseReportStatus closes a report as resolved or dismissed
+	UpdateAbuseReportStatus(ctx context.Context, tx pgx.Tx, id int64, status, resolvedBy string) (*AbuseReport, error)
+	// FindPackageClaims retrieve every server version listing a package with one of the given
+	// lowercase identifiers, optionally of one registry type
+	FindPackageClaims(ctx context.Context, tx pgx.Tx, identifiers []string, registryType string) ([]*apiv0.PackageClaim, error)
+	// RecordServerChanges appends changes to the change feed
+	RecordServerChanges(ctx context.Context, tx pgx.Tx, changes []*ServerChange) error
+	// ListServerChanges retrieve up to limit changes recorded after the given id, oldest first
+	ListServerChanges(ctx context.Context, tx pgx.Tx, afterID int64, limit int) ([]*ServerChange, error)
+	// GetLatestServerChangeID returns the id of the most recent change, or zero if there are none
+	GetLatestServerChangeID(ctx context.Context, tx pgx.Tx) (int64, error)
+	// ClaimIdempotencyKey claims a key for a new request, returning false if it is already held
+	ClaimIdempotencyKey(ctx context.Context, tx pgx.Tx, record *IdempotencyRecord, staleAfter time.Duration) (bool, error)
+	// GetIdempotencyKey retrieve an unexpired idempotency record
+	GetIdempotencyKey(ctx context.Context, tx pgx.Tx, actor, key string) (*IdempotencyRecord, error)
+	// CompleteIdempotencyKey stores the response of the request that claimed a key
+	CompleteIdempotencyKey(ctx context.Context, tx pgx.Tx, actor, key string, response []byte) error
+	// DeleteIdempotencyKey releases a key so the request can be retried
+	DeleteIdempotencyKey(ctx context.Context, tx pgx.Tx, actor, key string) error
+	// CreateAPIToken stores a new API token
+	CreateAPIToken(ctx context.Context, tx pgx.Tx, token *APIToken) error
+	// ListAPITokens retrieve the unrevoked API tokens of an owner, newest first
+	ListAPITokens(ctx context.Context, tx pgx.Tx, owner string) ([]*APIToken, error)
+	// GetAPIToken retrieve an API token by ID
+	GetAPIToken(ctx context.Context, tx pgx.Tx, id int64) (*APIToken, error)
+	// GetAPITokenByHash retrieve an API token by the hash of its secret
+	GetAPITokenByHash(ctx context.Context, tx pgx.Tx, hash string) (*APIToken, error)
+	// CountAPITokens counts the unrevoked API tokens of an owner
+	CountAPITokens(ctx context.Context, tx pgx.Tx, owner string) (int, error)
+	// RevokeAPIToken marks an API token as revoked
+	RevokeAPIToken(ctx context.Context, tx pgx.Tx, id int64) error
+	// TouchAPIToken records that an API token has just been used
+	TouchAPIToken(ctx context.Context, tx pgx.Tx, id int64) error
+	// CreateWebSession stores a new browser session
+	CreateWebSession(ctx context.Context, tx pgx.Tx, session *WebSession) error
+	// ListWebSessions retrieve the unrevoked, unexpired browser sessions of an owner, newest first
+	ListWebSessions(ctx context.Context, tx pgx.Tx, owner string) ([]*WebSession, error)
+	// GetWebSession retrieve a browser session by ID
+	GetWebSession(ctx context.Context, tx pgx.Tx, id int64) (*WebSession, error)
+	// GetWebSessionByHash retrieve a browser session by the hash of its cookie
+	GetWebSessionByHash(ctx context.Context, tx pgx.Tx, hash string) (*WebSession, error)
+	// RevokeWebSession marks a browser session as revoked
+	RevokeWebSession(ctx context.Context, tx pgx.Tx, id int64) error
+	// TouchWebSession records that a browser session has just been used
+	TouchWebSession(ctx context.Context, tx pgx.Tx, id int64) error
+	// CreatePasskey stores a new passkey, failing with ErrAlreadyExists if its credential is already registered
+	CreatePasskey(ctx context.Context, tx pgx.Tx, passkey *Passkey) error
+	// ListPasskeys retrieve the passkeys of an owner, oldest first
+	ListPasskeys(ctx context.Context, tx pgx.Tx, owner string) ([]*Passkey, error)
+	// UpdatePasskeyCredential saves a passkey's credential record after it was used, and records the use
+	UpdatePasskeyCredential(ctx context.Context, tx pgx.Tx, id int64, credential []byte) error
+	// DeletePasskey removes a passkey of an owner
+	DeletePasskey(ctx context.Context, tx pgx.Tx, owner string, id int64) error
+	// CreatePasskeyChallenge stores an outstanding WebAuthn challenge. Expired challenges are removed at the same time.
+	CreatePasskeyChallenge(ctx context.Context, tx pgx.Tx, challenge *PasskeyChallenge) error
+	// ConsumePasskeyChallenge removes and returns an unexpired challenge issued to owner for ceremony
+	ConsumePasskeyChallenge(ctx context.Context, tx pgx.Tx, challenge, owner, ceremony string) (*PasskeyChallenge, error)
+	// CreateDeviceAuthorization stores a new device login, failing with ErrAlreadyExists if its user code is taken.
+	// Expired device logins are removed at the same time.
+	CreateDeviceAuthorization(ctx context.Context, tx pgx.Tx, authorization *DeviceAuthorization) error
+	// GetDeviceAuthorizationByUserCode retrieve and lock a device login by its user code
+	GetDeviceAuthorizationByUserCode(ctx context.Context, tx pgx.Tx, userCode string) (*DeviceAuthorization, error)
+	// GetDeviceAuthorizationByDeviceCode retrieve and lock a device login by the hash of its device code
+	GetDeviceAuthorizationByDeviceCode(ctx context.Context, tx pgx.Tx, deviceCodeHash string) (*DeviceAuthorization, error)
+	// UpdateDeviceAuthorization saves the status, approval and last poll time of a device login
+	UpdateDeviceAuthorization(ctx context.Context, tx pgx.Tx, authorization *DeviceAuthorization) error
+	// DeleteDeviceAuthorization removes a device login
+	DeleteDeviceAuthorization(ctx context.Context, tx pgx.Tx, deviceCodeHash string) error
+	// RevokeToken adds a token to the revocation list until it expires, failing with ErrAlreadyExists if it is already revoked
+	RevokeToken(ctx context.Context, tx pgx.Tx, jti string, expiresAt time.Time, revokedBy string) error
+	// IsTokenRevoked reports whether a token is on the revocation list
+	IsTokenRevoked(ctx context.Context, tx pgx.Tx, jti string) (bool, error)
+	// RecordAuditEvent appends an event to the audit log
+	RecordAuditEvent(ctx context.Context, tx pgx.Tx, event *AuditEvent) error
+	// ListAuditEvents retrieve up to limit audit events matching the filter, oldest first
+	ListAuditEvents(ctx context.Context, tx pgx.Tx, filter *AuditEventFilter, limit int) ([]*AuditEvent, error)
+	// RecordAuthEvent appends an authentication attempt or permission denial to the auth event log
+	RecordAuthEvent(ctx context.Context, tx pgx.Tx, event *AuthEvent) error
+	// ListAuthEvents retrieve up to limit auth events matching the filter, newest first
+	ListAuthEvents(ctx context.Context, tx pgx.Tx, filter *AuthEventFilter, limit int) ([]*AuthEvent, error)
+	// GetMaintenanceMode retrieve the current maintenance mode state
+	GetMaintenanceMode(ctx context.Context, tx pgx.Tx) (*MaintenanceMode, error)
+	// SetMaintenanceMode enables or disables maintenance mode with the given message
+	SetMaintenanceMode(ctx context.Context, tx pgx.Tx, enabled bool, message string) (*MaintenanceMode, error)
+	// Ping verifies that the database is reachable
+	Ping(ctx context.Context) error
+	// PendingMigrations returns the names of embedded migrations that have not been applied
+	PendingMigrations(ctx context.Context) ([]string, error)
 	// InTransaction executes a function within a database transaction
 	InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error
 	// Close closes the database connection
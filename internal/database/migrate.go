@@ -5,13 +5,15 @@ import (
 	"embed"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"path"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/logging"
 )
 
 //go:embed migrations/*.sql
@@ -69,7 +71,7 @@ func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[int]bool, erro
 }
 
 // loadMigrations loads all migration files from the embedded filesystem
-func (m *Migrator) loadMigrations() ([]Migration, error) {
+func loadMigrations() ([]Migration, error) {
 	entries, err := migrationFiles.ReadDir("migrations")
 	if err != nil {
 		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
@@ -85,13 +87,13 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 		name := entry.Name()
 		parts := strings.SplitN(name, "_", 2)
 		if len(parts) != 2 {
-			log.Printf("Skipping migration file with invalid name format: %s", name)
+			slog.Warn("Skipping migration file with invalid name format", "file", name)
 			continue
 		}
 
 		version, err := strconv.Atoi(parts[0])
 		if err != nil {
-			log.Printf("Skipping migration file with invalid version: %s", name)
+			slog.Warn("Skipping migration file with invalid version", "file", name)
 			continue
 		}
 
@@ -130,7 +132,7 @@ func (m *Migrator) Migrate(ctx context.Context) error {
 	}
 
 	// Load all migration files
-	migrations, err := m.loadMigrations()
+	migrations, err := loadMigrations()
 	if err != nil {
 		return fmt.Errorf("failed to load migrations: %w", err)
 	}
@@ -144,21 +146,21 @@ func (m *Migrator) Migrate(ctx context.Context) error {
 	}
 
 	if len(pending) == 0 {
-		log.Println("No pending migrations")
+		slog.InfoContext(ctx, "No pending migrations")
 		return nil
 	}
 
-	log.Printf("Applying %d pending migrations", len(pending))
+	slog.InfoContext(ctx, "Applying pending migrations", "count", len(pending))
 
 	// Apply each pending migration in a transaction
 	for _, migration := range pending {
 		if err := m.applyMigration(ctx, migration); err != nil {
 			return fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Name, err)
 		}
-		log.Printf("Applied migration %d: %s", migration.Version, migration.Name)
+		slog.InfoContext(ctx, "Applied migration", "version", migration.Version, "name", migration.Name)
 	}
 
-	log.Println("All migrations applied successfully")
+	slog.InfoContext(ctx, "All migrations applied successfully")
 	return nil
 }
 
@@ -170,7 +172,7 @@ func (m *Migrator) applyMigration(ctx context.Context, migration Migration) erro
 	}
 	defer func() {
 		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
-			log.Printf("Failed to rollback migration transaction: %v", err)
+			slog.ErrorContext(ctx, "Failed to rollback migration transaction", logging.Err(err))
 		}
 	}()
 
@@ -0,0 +1,12 @@
+-- Daily aggregated fetch counts per server
+-- Only counts are stored (no client identifiers) to keep usage statistics privacy-preserving
+
+CREATE TABLE server_fetch_stats (
+    server_name VARCHAR(255) NOT NULL,
+    day DATE NOT NULL,
+    fetch_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (server_name, day)
+);
+
+-- Support registry-wide aggregation over a date range
+CREATE INDEX idx_server_fetch_stats_day ON server_fetch_stats (day);
@@ -0,0 +1,5 @@
+-- Composite indexes supporting keyset pagination when sorting servers by update or publish time.
+-- The trailing server_name, version columns match the tie-breakers used in ORDER BY, and
+-- B-tree indexes can be scanned in either direction for ascending and descending sorts.
+CREATE INDEX IF NOT EXISTS idx_servers_updated_at_name_version ON servers (updated_at, server_name, version);
+CREATE INDEX IF NOT EXISTS idx_servers_published_at_name_version ON servers (published_at, server_name, version);
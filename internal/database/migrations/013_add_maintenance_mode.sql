@@ -0,0 +1,11 @@
+-- Registry-wide maintenance mode, stored in the database so every replica sees the same state
+-- The boolean primary key restricts the table to a single row
+
+CREATE TABLE maintenance_mode (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    message TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO maintenance_mode (id) VALUES (TRUE);
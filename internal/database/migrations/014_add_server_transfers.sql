@@ -0,0 +1,22 @@
+-- Pending transfers of a server to a new name (and therefore a new owning namespace)
+-- At most one transfer can be pending per server
+
+CREATE TABLE server_transfers (
+    server_name VARCHAR(255) PRIMARY KEY,
+    new_name VARCHAR(255) NOT NULL,
+    initiated_by TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Append-only record of ownership and administrative changes
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    action VARCHAR(100) NOT NULL,
+    actor TEXT NOT NULL,
+    resource VARCHAR(255) NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_resource ON audit_log (resource, created_at);
@@ -0,0 +1,15 @@
+-- Namespace reservations pin a namespace to the permission pattern (and so the proof of ownership)
+-- used to reserve it, so nobody else can publish under it. Disputes are resolved by admins.
+
+CREATE TABLE namespace_reservations (
+    namespace VARCHAR(255) PRIMARY KEY,
+    owner TEXT NOT NULL,
+    permission_pattern TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'disputed')),
+    dispute_reason TEXT NOT NULL DEFAULT '',
+    disputed_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_namespace_reservations_status ON namespace_reservations (status);
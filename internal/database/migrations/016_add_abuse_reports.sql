@@ -0,0 +1,17 @@
+-- Abuse reports form the moderation queue reviewed by admins.
+-- Reporters are identified only when they were authenticated; no IP addresses are stored.
+
+CREATE TABLE abuse_reports (
+    id BIGSERIAL PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('malware', 'squatting', 'misleading', 'other')),
+    details TEXT NOT NULL DEFAULT '',
+    reporter TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved', 'dismissed')),
+    resolved_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_abuse_reports_status_created ON abuse_reports (status, created_at);
+CREATE INDEX idx_abuse_reports_server_name ON abuse_reports (server_name);
@@ -0,0 +1,9 @@
+-- Markdown READMEs, stored apart from server.json so they don't bloat list responses.
+-- A server has one README shared by all of its versions.
+
+CREATE TABLE server_readmes (
+    server_name VARCHAR(255) PRIMARY KEY,
+    content TEXT NOT NULL,
+    updated_by TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
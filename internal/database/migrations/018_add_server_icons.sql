@@ -0,0 +1,13 @@
+-- Icons uploaded to the registry, one per server, shared by all of its versions.
+-- Icons are capped at 64 KiB by the API, so they are stored inline rather than in object storage.
+
+CREATE TABLE server_icons (
+    server_name VARCHAR(255) PRIMARY KEY,
+    mime_type VARCHAR(50) NOT NULL CHECK (mime_type IN ('image/png', 'image/svg+xml')),
+    data BYTEA NOT NULL,
+    width INTEGER NOT NULL DEFAULT 0,
+    height INTEGER NOT NULL DEFAULT 0,
+    checksum CHAR(64) NOT NULL,
+    updated_by TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,11 @@
+-- Change feed of published, updated and deleted server versions, streamed to mirrors by the events endpoint.
+-- Rows are never renamed by server transfers; a transfer is recorded as a delete of every version under
+-- the old name and a publish of every version under the new one.
+
+CREATE TABLE server_changes (
+    id BIGSERIAL PRIMARY KEY,
+    change_type VARCHAR(20) NOT NULL CHECK (change_type IN ('publish', 'update', 'delete')),
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,14 @@
+-- Idempotency keys let publish requests be retried safely: the first request with a key claims it,
+-- and retries with the same key and body replay the stored response instead of publishing again.
+-- Keys are scoped to the token holder so they cannot collide between publishers.
+
+CREATE TABLE idempotency_keys (
+    actor TEXT NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    -- NULL until the request completes successfully
+    response JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (actor, key)
+);
@@ -0,0 +1,17 @@
+-- Named, scoped and expiring API tokens for CI, exchanged for short-lived Registry JWTs.
+-- Only a SHA-256 hash of each token is stored; the token itself is shown once when it is created.
+
+CREATE TABLE api_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    owner TEXT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    token_prefix VARCHAR(20) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    permissions JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_api_tokens_owner ON api_tokens (owner) WHERE revoked_at IS NULL;
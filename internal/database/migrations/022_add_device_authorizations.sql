@@ -0,0 +1,18 @@
+-- Pending device logins (RFC 8628). A headless client holds the device code while the user approves
+-- the short user code from another device that is already logged in. Only a SHA-256 hash of the
+-- device code is stored, and rows are deleted once the client has collected its token.
+
+CREATE TABLE device_authorizations (
+    device_code_hash VARCHAR(64) PRIMARY KEY,
+    user_code VARCHAR(16) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'denied')),
+    approved_by TEXT,
+    auth_method VARCHAR(50),
+    subject TEXT,
+    permissions JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_polled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_device_authorizations_expires_at ON device_authorizations (expires_at);
@@ -0,0 +1,11 @@
+-- Registry JWTs and refresh tokens revoked before they expire, by JWT ID. Rows are only needed until
+-- the token would have expired anyway, and are deleted after that.
+
+CREATE TABLE revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    revoked_by TEXT NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
@@ -0,0 +1,20 @@
+-- Organizations let a team publish in a namespace. Members publish with the permission pattern
+-- the organization was created with, as if their own tokens carried it. Admins manage membership.
+
+CREATE TABLE organizations (
+    namespace VARCHAR(255) PRIMARY KEY,
+    permission_pattern TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE organization_members (
+    namespace VARCHAR(255) NOT NULL REFERENCES organizations (namespace) ON DELETE CASCADE,
+    member TEXT NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'member')),
+    added_by TEXT NOT NULL,
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (namespace, member)
+);
+
+CREATE INDEX idx_organization_members_member ON organization_members (member);
@@ -0,0 +1,19 @@
+-- Logins, token exchanges and permission denials, for investigating incidents. Unlike audit_log, which
+-- records changes, this records every authentication attempt along with the client's IP address.
+
+CREATE TABLE auth_events (
+    id BIGSERIAL PRIMARY KEY,
+    event VARCHAR(20) NOT NULL,
+    outcome VARCHAR(20) NOT NULL,
+    auth_method VARCHAR(50) NOT NULL DEFAULT '',
+    subject TEXT NOT NULL DEFAULT '',
+    client_ip VARCHAR(64) NOT NULL DEFAULT '',
+    endpoint VARCHAR(255) NOT NULL,
+    status INTEGER NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_auth_events_created_at ON auth_events (created_at);
+CREATE INDEX idx_auth_events_subject ON auth_events (subject, created_at);
+CREATE INDEX idx_auth_events_client_ip ON auth_events (client_ip, created_at);
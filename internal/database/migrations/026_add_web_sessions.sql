@@ -0,0 +1,21 @@
+-- Browser sessions for a registry web UI, held in an HttpOnly cookie and exchanged for a Registry JWT on
+-- each request. Only a SHA-256 hash of the session cookie is stored. The CSRF token is stored as is: it
+-- is useless without the cookie, and the UI fetches it again after a page reload.
+
+CREATE TABLE web_sessions (
+    id BIGSERIAL PRIMARY KEY,
+    owner TEXT NOT NULL,
+    auth_method VARCHAR(50) NOT NULL,
+    subject TEXT NOT NULL,
+    permissions JSONB NOT NULL,
+    session_hash VARCHAR(64) NOT NULL UNIQUE,
+    csrf_token VARCHAR(64) NOT NULL,
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    client_ip VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_web_sessions_owner ON web_sessions (owner) WHERE revoked_at IS NULL;
@@ -0,0 +1,18 @@
+-- Namespace delegations let whoever can publish in a whole namespace give another account publish rights
+-- for part of it, such as com.acme/tools-*, until they expire or are revoked. granted_with is the namespace
+-- permission the grant was made with, so delegates can publish past a reservation made with the same one.
+
+CREATE TABLE namespace_delegations (
+    id BIGSERIAL PRIMARY KEY,
+    namespace VARCHAR(255) NOT NULL,
+    permission_pattern TEXT NOT NULL,
+    delegate TEXT NOT NULL,
+    granted_by TEXT NOT NULL,
+    granted_with TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_namespace_delegations_namespace ON namespace_delegations (namespace) WHERE revoked_at IS NULL;
+CREATE INDEX idx_namespace_delegations_delegate ON namespace_delegations (delegate) WHERE revoked_at IS NULL;
@@ -0,0 +1,25 @@
+-- Passkeys (WebAuthn credentials) accounts verify with before admin operations, so a stolen Registry JWT
+-- is not enough to take them. credential holds the credential record as JSON: its public key, signature
+-- counter and flags. Challenges are kept only until the ceremony they were issued for completes.
+
+CREATE TABLE passkeys (
+    id BIGSERIAL PRIMARY KEY,
+    owner TEXT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    credential_id BYTEA NOT NULL UNIQUE,
+    credential JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_passkeys_owner ON passkeys (owner);
+
+CREATE TABLE passkey_challenges (
+    challenge VARCHAR(128) PRIMARY KEY,
+    owner TEXT NOT NULL,
+    ceremony VARCHAR(20) NOT NULL CHECK (ceremony IN ('registration', 'verification')),
+    session_data JSONB NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_passkey_challenges_expires_at ON passkey_challenges (expires_at);
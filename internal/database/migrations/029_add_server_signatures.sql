@@ -0,0 +1,14 @@
+-- Publishers' signatures of the canonical server.json of server versions, checked by the registry when
+-- publishing and served for clients to verify. A signature no longer holds once the server.json it
+-- signed is edited or renamed, so it is removed then.
+
+CREATE TABLE server_signatures (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    algorithm VARCHAR(20) NOT NULL CHECK (algorithm IN ('ed25519', 'ecdsap384')),
+    public_key TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, version),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON DELETE CASCADE
+);
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/modelcontextprotocol/registry/internal/logging"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -37,20 +40,48 @@ func (db *PostgreSQL) getExecutor(tx pgx.Tx) Executor {
 	return db.pool
 }
 
+// PostgreSQLOption configures optional behaviour of the PostgreSQL database
+type PostgreSQLOption func(*postgresOptions)
+
+type postgresOptions struct {
+	slowQueryThreshold time.Duration
+}
+
+// WithSlowQueryThreshold logs queries that take longer than threshold, with the method that made them,
+// their duration and row count but not their parameters. Zero disables slow query logging.
+func WithSlowQueryThreshold(threshold time.Duration) PostgreSQLOption {
+	return func(o *postgresOptions) {
+		o.slowQueryThreshold = threshold
+	}
+}
+
 // NewPostgreSQL creates a new instance of the PostgreSQL database
-func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, error) {
+func NewPostgreSQL(ctx context.Context, connectionURI string, opts ...PostgreSQLOption) (*PostgreSQL, error) {
+	var options postgresOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// Parse connection config for pool settings
 	config, err := pgxpool.ParseConfig(connectionURI)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse PostgreSQL config: %w", err)
 	}
 
-	// Configure pool for stability-focused defaults
-	config.MaxConns = 30                      // Handle good concurrent load
-	config.MinConns = 5                       // Keep connections warm for fast response
+	// Configure pool for stability-focused defaults. The pool size can be tuned with the pool_max_conns
+	// and pool_min_conns parameters of the connection URI.
+	if !hasURIParameter(connectionURI, "pool_max_conns") {
+		config.MaxConns = 30 // Handle good concurrent load
+	}
+	if !hasURIParameter(connectionURI, "pool_min_conns") {
+		config.MinConns = 5 // Keep connections warm for fast response
+	}
 	config.MaxConnIdleTime = 30 * time.Minute // Keep connections available for bursts
 	config.MaxConnLifetime = 2 * time.Hour    // Refresh connections regularly for stability
 
+	// Trace queries as part of the request that made them, logging slow ones
+	config.ConnConfig.Tracer = queryTracer{slowQueryThreshold: options.slowQueryThreshold}
+
 	// Create connection pool with configured settings
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -79,6 +110,19 @@ func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, erro
 	}, nil
 }
 
+// hasURIParameter reports whether a connection URI, as a URL or in key=value form, sets the parameter name
+func hasURIParameter(connectionURI, name string) bool {
+	if u, err := url.Parse(connectionURI); err == nil && u.Scheme != "" {
+		return u.Query().Has(name)
+	}
+	for _, field := range strings.Fields(connectionURI) {
+		if key, _, _ := strings.Cut(field, "="); strings.TrimSpace(key) == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (db *PostgreSQL) ListServers(
 	ctx context.Context,
 	tx pgx.Tx,
@@ -98,6 +142,7 @@ func (db *PostgreSQL) ListServers(
 	var whereConditions []string
 	args := []any{}
 	argIndex := 1
+	searchArg := ""
 
 	// Add filters using dedicated columns for better performance
 	if filter != nil {
@@ -120,6 +165,10 @@ func (db *PostgreSQL) ListServers(
 			whereConditions = append(whereConditions, fmt.Sprintf("server_name ILIKE $%d", argIndex))
 			args = append(args, "%"+*filter.SubstringName+"%")
 			argIndex++
+			// The raw term is also needed to rank results by relevance
+			searchArg = fmt.Sprintf("$%d", argIndex)
+			args = append(args, *filter.SubstringName)
+			argIndex++
 		}
 		if filter.Version != nil {
 			whereConditions = append(whereConditions, fmt.Sprintf("version = $%d", argIndex))
@@ -131,10 +180,41 @@ func (db *PostgreSQL) ListServers(
 			args = append(args, *filter.IsLatest)
 			argIndex++
 		}
+		if len(filter.NamePatterns) > 0 && !slices.Contains(filter.NamePatterns, "*") {
+			names, prefixes := []string{}, []string{}
+			for _, pattern := range filter.NamePatterns {
+				if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+					prefixes = append(prefixes, prefix)
+				} else {
+					names = append(names, pattern)
+				}
+			}
+			whereConditions = append(whereConditions, fmt.Sprintf(
+				"(server_name = ANY($%d) OR EXISTS (SELECT 1 FROM unnest($%d::text[]) AS prefix WHERE starts_with(server_name, prefix)))",
+				argIndex, argIndex+1))
+			args = append(args, names, prefixes)
+			argIndex += 2
+		}
+	}
+
+	sort, err := newServerSort(filter, searchArg)
+	if err != nil {
+		return nil, "", err
 	}
+	_, op := sort.direction()
 
-	// Add cursor pagination using compound serverName:version cursor
-	if cursor != "" {
+	switch {
+	case cursor == "":
+	case sort.key != "":
+		// Keyset pagination over (sort key, server_name, version), served by the matching composite index
+		c, err := decodeSortCursor(cursor, sort)
+		if err != nil {
+			return nil, "", err
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("(%s, server_name, version) %s ($%d, $%d, $%d)", sort.key, op, argIndex, argIndex+1, argIndex+2))
+		args = append(args, c.keyValue(), c.Name, c.Version)
+		argIndex += 3
+	default:
 		// Parse cursor format: "serverName:version"
 		parts := strings.SplitN(cursor, ":", 2)
 		if len(parts) == 2 {
@@ -142,12 +222,12 @@ func (db *PostgreSQL) ListServers(
 			cursorVersion := parts[1]
 
 			// Use compound condition: (server_name > cursor_name) OR (server_name = cursor_name AND version > cursor_version)
-			whereConditions = append(whereConditions, fmt.Sprintf("(server_name > $%d OR (server_name = $%d AND version > $%d))", argIndex, argIndex+1, argIndex+2))
+			whereConditions = append(whereConditions, fmt.Sprintf("(server_name %s $%d OR (server_name = $%d AND version %s $%d))", op, argIndex, argIndex+1, op, argIndex+2))
 			args = append(args, cursorServerName, cursorServerName, cursorVersion)
 			argIndex += 3
 		} else {
 			// Fallback for malformed cursor - treat as server name only for backwards compatibility
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name > $%d", argIndex))
+			whereConditions = append(whereConditions, fmt.Sprintf("server_name %s $%d", op, argIndex))
 			args = append(args, cursor)
 			argIndex++
 		}
@@ -159,14 +239,20 @@ func (db *PostgreSQL) ListServers(
 		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
+	// The relevance rank is selected so it can be carried in the next cursor
+	rankColumn := "0"
+	if sort.field == SortByRelevance {
+		rankColumn = sort.key
+	}
+
 	// Query servers table with hybrid column/JSON data
 	query := fmt.Sprintf(`
-        SELECT server_name, version, status, published_at, updated_at, is_latest, value
+        SELECT server_name, version, status, published_at, updated_at, is_latest, value, %s
         FROM servers
         %s
-        ORDER BY server_name, version
+        %s
         LIMIT $%d
-    `, whereClause, argIndex)
+    `, rankColumn, whereClause, sort.orderBy(), argIndex)
 	args = append(args, limit)
 
 	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
@@ -176,13 +262,14 @@ func (db *PostgreSQL) ListServers(
 	defer rows.Close()
 
 	var results []*apiv0.ServerResponse
+	var lastRank int
 	for rows.Next() {
 		var serverName, version, status string
 		var publishedAt, updatedAt time.Time
 		var isLatest bool
 		var valueJSON []byte
 
-		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON, &lastRank)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to scan server row: %w", err)
 		}
@@ -213,11 +300,25 @@ func (db *PostgreSQL) ListServers(
 		return nil, "", fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	// Determine next cursor using compound serverName:version format
+	// Determine next cursor using compound serverName:version format, or an opaque
+	// cursor carrying the sort key for other sorts
 	nextCursor := ""
 	if len(results) > 0 && len(results) >= limit {
 		lastResult := results[len(results)-1]
 		nextCursor = lastResult.Server.Name + ":" + lastResult.Server.Version
+		if sort.key != "" {
+			c := sortCursor{Sort: sort.field, Desc: sort.desc, Name: lastResult.Server.Name, Version: lastResult.Server.Version}
+			switch sort.field {
+			case SortByUpdated:
+				c.Time = &lastResult.Meta.Official.UpdatedAt
+			case SortByCreated:
+				c.Time = &lastResult.Meta.Official.PublishedAt
+			case SortByRelevance:
+				c.Rank = &lastRank
+			case SortByName:
+			}
+			nextCursor = encodeSortCursor(c)
+		}
 	}
 
 	return results, nextCursor, nil
@@ -320,6 +421,62 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	return serverResponse, nil
 }
 
+// GetServersByNames retrieves the latest version of each of the named servers, skipping names that don't exist
+func (db *PostgreSQL) GetServersByNames(ctx context.Context, tx pgx.Tx, serverNames []string) ([]*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		FROM servers
+		WHERE server_name = ANY($1) AND is_latest = true
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query servers by names: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*apiv0.ServerResponse{}
+	for rows.Next() {
+		var name, version, status string
+		var publishedAt, updatedAt time.Time
+		var isLatest bool
+		var valueJSON []byte
+
+		err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server row: %w", err)
+		}
+
+		// Parse the ServerJSON from JSONB
+		var serverJSON apiv0.ServerJSON
+		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+
+		results = append(results, &apiv0.ServerResponse{
+			Server: serverJSON,
+			Meta: apiv0.ResponseMeta{
+				Official: &apiv0.RegistryExtensions{
+					Status:      model.Status(status),
+					PublishedAt: publishedAt,
+					UpdatedAt:   updatedAt,
+					IsLatest:    isLatest,
+				},
+			},
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return results, nil
+}
+
 // GetAllVersionsByServerName retrieves all versions of a server by server name
 func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx, serverName string) ([]*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
@@ -558,7 +715,7 @@ func (db *PostgreSQL) InTransaction(ctx context.Context, fn func(ctx context.Con
 		rollbackCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 		defer cancel()
 		if rbErr := tx.Rollback(rollbackCtx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
-			log.Printf("failed to rollback transaction: %v", rbErr)
+			slog.ErrorContext(ctx, "Failed to rollback transaction", logging.Err(rbErr))
 		}
 	}()
 
@@ -712,6 +869,63 @@ func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName
 	return nil
 }
 
+// Ping verifies that the database is reachable
+func (db *PostgreSQL) Ping(ctx context.Context) error {
+	if err := db.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	}
+	return nil
+}
+
+// PendingMigrations returns the names of embedded migrations that have not been applied
+func (db *PostgreSQL) PendingMigrations(ctx context.Context) ([]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.pool.Query(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to scan migration version: %w", err)
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []string{}
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration.Name)
+		}
+	}
+
+	return pending, nil
+}
+
+// PoolStat returns the usage of the connection pool
+func (db *PostgreSQL) PoolStat() *pgxpool.Stat {
+	return db.pool.Stat()
+}
+
+// PoolMinConns returns the number of connections the pool keeps open when idle
+func (db *PostgreSQL) PoolMinConns() int32 {
+	return db.pool.Config().MinConns
+}
+
 // Close closes the database connection
 func (db *PostgreSQL) Close() error {
 	db.pool.Close()
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RecordAuthEvent appends an authentication attempt or permission denial to the auth event log
+func (db *PostgreSQL) RecordAuthEvent(ctx context.Context, tx pgx.Tx, event *AuthEvent) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	details := event.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to marshal auth event details: %w", err)
+	}
+
+	query := `
+		INSERT INTO auth_events (event, outcome, auth_method, subject, client_ip, endpoint, status, details)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+	err = db.getExecutor(tx).QueryRow(ctx, query, event.Event, event.Outcome, event.AuthMethod, event.Subject,
+		event.ClientIP, event.Endpoint, event.Status, detailsJSON).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record auth event: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuthEvents retrieves up to limit auth events matching the filter, newest first
+func (db *PostgreSQL) ListAuthEvents(ctx context.Context, tx pgx.Tx, filter *AuthEventFilter, limit int) ([]*AuthEvent, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var whereConditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		whereConditions = append(whereConditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter != nil {
+		if filter.Event != "" {
+			addCondition("event = $%d", filter.Event)
+		}
+		if filter.Outcome != "" {
+			addCondition("outcome = $%d", filter.Outcome)
+		}
+		if filter.Subject != "" {
+			addCondition("subject = $%d", filter.Subject)
+		}
+		if filter.ClientIP != "" {
+			addCondition("client_ip = $%d", filter.ClientIP)
+		}
+		if filter.Since != nil {
+			addCondition("created_at >= $%d", *filter.Since)
+		}
+	}
+
+	query := `SELECT id, event, outcome, auth_method, subject, client_ip, endpoint, status, details, created_at FROM auth_events`
+	if len(whereConditions) > 0 {
+		query += " WHERE " + strings.Join(whereConditions, " AND ")
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auth events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*AuthEvent
+	for rows.Next() {
+		var event AuthEvent
+		var detailsJSON []byte
+		if err := rows.Scan(&event.ID, &event.Event, &event.Outcome, &event.AuthMethod, &event.Subject,
+			&event.ClientIP, &event.Endpoint, &event.Status, &detailsJSON, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan auth event: %w", err)
+		}
+		if err := json.Unmarshal(detailsJSON, &event.Details); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal auth event details: %w", err)
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating auth events: %w", err)
+	}
+
+	return events, nil
+}
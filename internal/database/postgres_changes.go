@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RecordServerChanges appends changes to the change feed. The table is locked until the transaction
+// ends so that ids become visible in order, letting readers resume from the last id they saw.
+func (db *PostgreSQL) RecordServerChanges(ctx context.Context, tx pgx.Tx, changes []*ServerChange) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	executor := db.getExecutor(tx)
+	if _, err := executor.Exec(ctx, `LOCK TABLE server_changes IN EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("failed to lock change feed: %w", err)
+	}
+
+	query := `
+		INSERT INTO server_changes (change_type, server_name, version)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	for _, change := range changes {
+		if err := executor.QueryRow(ctx, query, change.Type, change.ServerName, change.Version).
+			Scan(&change.ID, &change.CreatedAt); err != nil {
+			return fmt.Errorf("failed to record server change: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListServerChanges retrieves up to limit changes recorded after the given id, oldest first
+func (db *PostgreSQL) ListServerChanges(ctx context.Context, tx pgx.Tx, afterID int64, limit int) ([]*ServerChange, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT id, change_type, server_name, version, created_at
+		FROM server_changes
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list server changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*ServerChange
+	for rows.Next() {
+		var change ServerChange
+		if err := rows.Scan(&change.ID, &change.Type, &change.ServerName, &change.Version, &change.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan server change: %w", err)
+		}
+		changes = append(changes, &change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// GetLatestServerChangeID returns the id of the most recent change, or zero if none have been recorded
+func (db *PostgreSQL) GetLatestServerChangeID(ctx context.Context, tx pgx.Tx) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	var id int64
+	if err := db.getExecutor(tx).QueryRow(ctx, `SELECT COALESCE(MAX(id), 0) FROM server_changes`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get latest server change: %w", err)
+	}
+
+	return id, nil
+}
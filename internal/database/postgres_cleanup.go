@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// likeEscaper escapes the LIKE wildcards in a literal prefix
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// DeleteStaleServers removes every version, README, icon and pending transfer of the servers in the given
+// namespaces that have not been published to since publishedBefore. It returns the removed versions
+// keyed by server name.
+func (db *PostgreSQL) DeleteStaleServers(ctx context.Context, tx pgx.Tx, namespaces []string, publishedBefore time.Time) (map[string][]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if len(namespaces) == 0 {
+		return nil, nil
+	}
+
+	executor := db.getExecutor(tx)
+
+	prefixes := make([]string, len(namespaces))
+	for i, namespace := range namespaces {
+		prefixes[i] = likeEscaper.Replace(namespace) + "/%"
+	}
+
+	query := `
+		DELETE FROM servers
+		WHERE server_name IN (
+			SELECT server_name FROM servers
+			WHERE server_name LIKE ANY($1)
+			GROUP BY server_name
+			HAVING MAX(published_at) < $2
+		)
+		RETURNING server_name, version
+	`
+	rows, err := executor.Query(ctx, query, prefixes, publishedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete stale servers: %w", err)
+	}
+	defer rows.Close()
+
+	deleted := make(map[string][]string)
+	for rows.Next() {
+		var name, version string
+		if err := rows.Scan(&name, &version); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted server: %w", err)
+		}
+		deleted[name] = append(deleted[name], version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deleted servers: %w", err)
+	}
+	if len(deleted) == 0 {
+		return deleted, nil
+	}
+
+	names := make([]string, 0, len(deleted))
+	for name := range deleted {
+		names = append(names, name)
+	}
+	if _, err := executor.Exec(ctx, `DELETE FROM server_readmes WHERE server_name = ANY($1)`, names); err != nil {
+		return nil, fmt.Errorf("failed to delete stale server READMEs: %w", err)
+	}
+	if _, err := executor.Exec(ctx, `DELETE FROM server_icons WHERE server_name = ANY($1)`, names); err != nil {
+		return nil, fmt.Errorf("failed to delete stale server icons: %w", err)
+	}
+	if _, err := executor.Exec(ctx, `DELETE FROM server_transfers WHERE server_name = ANY($1)`, names); err != nil {
+		return nil, fmt.Errorf("failed to delete stale server transfers: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const namespaceDelegationColumns = `id, namespace, permission_pattern, delegate, granted_by, granted_with, created_at, expires_at, revoked_at`
+
+// CreateNamespaceDelegation stores a new namespace delegation
+func (db *PostgreSQL) CreateNamespaceDelegation(ctx context.Context, tx pgx.Tx, delegation *NamespaceDelegation) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO namespace_delegations (namespace, permission_pattern, delegate, granted_by, granted_with, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	err := db.getExecutor(tx).QueryRow(ctx, query, delegation.Namespace, delegation.PermissionPattern, delegation.Delegate,
+		delegation.GrantedBy, delegation.GrantedWith, delegation.ExpiresAt).
+		Scan(&delegation.ID, &delegation.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create namespace delegation: %w", err)
+	}
+
+	return nil
+}
+
+// GetNamespaceDelegation retrieves a namespace delegation by ID
+func (db *PostgreSQL) GetNamespaceDelegation(ctx context.Context, tx pgx.Tx, id int64) (*NamespaceDelegation, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + namespaceDelegationColumns + ` FROM namespace_delegations WHERE id = $1`
+	delegation, err := scanNamespaceDelegation(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get namespace delegation: %w", err)
+	}
+
+	return delegation, nil
+}
+
+// ListNamespaceDelegations retrieves the unrevoked, unexpired delegations of a namespace, newest first
+func (db *PostgreSQL) ListNamespaceDelegations(ctx context.Context, tx pgx.Tx, namespace string) ([]*NamespaceDelegation, error) {
+	return db.listNamespaceDelegations(ctx, tx, "namespace", namespace)
+}
+
+// ListDelegateDelegations retrieves the unrevoked, unexpired delegations given to an account, newest first
+func (db *PostgreSQL) ListDelegateDelegations(ctx context.Context, tx pgx.Tx, delegate string) ([]*NamespaceDelegation, error) {
+	return db.listNamespaceDelegations(ctx, tx, "delegate", delegate)
+}
+
+// listNamespaceDelegations lists the active delegations whose column equals value. column is never user input.
+func (db *PostgreSQL) listNamespaceDelegations(ctx context.Context, tx pgx.Tx, column, value string) ([]*NamespaceDelegation, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + namespaceDelegationColumns + ` FROM namespace_delegations
+		WHERE ` + column + ` = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC, id DESC`
+	rows, err := db.getExecutor(tx).Query(ctx, query, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespace delegations: %w", err)
+	}
+	defer rows.Close()
+
+	var delegations []*NamespaceDelegation
+	for rows.Next() {
+		delegation, err := scanNamespaceDelegation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan namespace delegation: %w", err)
+		}
+		delegations = append(delegations, delegation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating namespace delegations: %w", err)
+	}
+
+	return delegations, nil
+}
+
+// RevokeNamespaceDelegation marks a namespace delegation as revoked
+func (db *PostgreSQL) RevokeNamespaceDelegation(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `UPDATE namespace_delegations SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`
+	result, err := db.getExecutor(tx).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke namespace delegation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func scanNamespaceDelegation(row pgx.Row) (*NamespaceDelegation, error) {
+	var delegation NamespaceDelegation
+	if err := row.Scan(&delegation.ID, &delegation.Namespace, &delegation.PermissionPattern, &delegation.Delegate,
+		&delegation.GrantedBy, &delegation.GrantedWith, &delegation.CreatedAt, &delegation.ExpiresAt, &delegation.RevokedAt); err != nil {
+		return nil, err
+	}
+	return &delegation, nil
+}
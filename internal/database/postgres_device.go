@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+)
+
+const deviceAuthorizationColumns = `device_code_hash, user_code, status, approved_by, auth_method, subject, permissions, created_at, expires_at, last_polled_at`
+
+// CreateDeviceAuthorization stores a new device login, failing with ErrAlreadyExists if its user code
+// is taken. Expired device logins are removed first so their user codes can be reused.
+func (db *PostgreSQL) CreateDeviceAuthorization(ctx context.Context, tx pgx.Tx, authorization *DeviceAuthorization) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+	if _, err := executor.Exec(ctx, `DELETE FROM device_authorizations WHERE expires_at <= NOW()`); err != nil {
+		return fmt.Errorf("failed to delete expired device authorizations: %w", err)
+	}
+
+	query := `
+		INSERT INTO device_authorizations (device_code_hash, user_code, status, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+	err := executor.QueryRow(ctx, query, authorization.DeviceCodeHash, authorization.UserCode, authorization.Status, authorization.ExpiresAt).
+		Scan(&authorization.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create device authorization: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeviceAuthorizationByUserCode retrieves and locks a device login by its user code
+func (db *PostgreSQL) GetDeviceAuthorizationByUserCode(ctx context.Context, tx pgx.Tx, userCode string) (*DeviceAuthorization, error) {
+	return db.getDeviceAuthorization(ctx, tx, "user_code", userCode)
+}
+
+// GetDeviceAuthorizationByDeviceCode retrieves and locks a device login by the hash of its device code
+func (db *PostgreSQL) GetDeviceAuthorizationByDeviceCode(ctx context.Context, tx pgx.Tx, deviceCodeHash string) (*DeviceAuthorization, error) {
+	return db.getDeviceAuthorization(ctx, tx, "device_code_hash", deviceCodeHash)
+}
+
+func (db *PostgreSQL) getDeviceAuthorization(ctx context.Context, tx pgx.Tx, column, value string) (*DeviceAuthorization, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + deviceAuthorizationColumns + ` FROM device_authorizations WHERE ` + column + ` = $1 FOR UPDATE`
+	authorization, err := scanDeviceAuthorization(db.getExecutor(tx).QueryRow(ctx, query, value))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get device authorization: %w", err)
+	}
+
+	return authorization, nil
+}
+
+// UpdateDeviceAuthorization saves the status, approval and last poll time of a device login
+func (db *PostgreSQL) UpdateDeviceAuthorization(ctx context.Context, tx pgx.Tx, authorization *DeviceAuthorization) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var permissionsJSON []byte
+	if authorization.Permissions != nil {
+		var err error
+		if permissionsJSON, err = json.Marshal(authorization.Permissions); err != nil {
+			return fmt.Errorf("failed to marshal device authorization permissions: %w", err)
+		}
+	}
+
+	query := `
+		UPDATE device_authorizations
+		SET status = $2, approved_by = NULLIF($3, ''), auth_method = NULLIF($4, ''), subject = NULLIF($5, ''), permissions = $6, last_polled_at = $7
+		WHERE device_code_hash = $1
+	`
+	result, err := db.getExecutor(tx).Exec(ctx, query, authorization.DeviceCodeHash, authorization.Status, authorization.ApprovedBy,
+		string(authorization.AuthMethod), authorization.Subject, permissionsJSON, authorization.LastPolledAt)
+	if err != nil {
+		return fmt.Errorf("failed to update device authorization: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteDeviceAuthorization removes a device login
+func (db *PostgreSQL) DeleteDeviceAuthorization(ctx context.Context, tx pgx.Tx, deviceCodeHash string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM device_authorizations WHERE device_code_hash = $1`, deviceCodeHash); err != nil {
+		return fmt.Errorf("failed to delete device authorization: %w", err)
+	}
+
+	return nil
+}
+
+func scanDeviceAuthorization(row pgx.Row) (*DeviceAuthorization, error) {
+	var authorization DeviceAuthorization
+	var approvedBy, authMethod, subject *string
+	var permissionsJSON []byte
+	if err := row.Scan(&authorization.DeviceCodeHash, &authorization.UserCode, &authorization.Status, &approvedBy, &authMethod, &subject,
+		&permissionsJSON, &authorization.CreatedAt, &authorization.ExpiresAt, &authorization.LastPolledAt); err != nil {
+		return nil, err
+	}
+	if approvedBy != nil {
+		authorization.ApprovedBy = *approvedBy
+	}
+	if authMethod != nil {
+		authorization.AuthMethod = auth.Method(*authMethod)
+	}
+	if subject != nil {
+		authorization.Subject = *subject
+	}
+	if permissionsJSON != nil {
+		if err := json.Unmarshal(permissionsJSON, &authorization.Permissions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal device authorization permissions: %w", err)
+		}
+	}
+	return &authorization, nil
+}
@@ -0,0 +1,122 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetServerIcon retrieves the icon of a server, including its data
+func (db *PostgreSQL) GetServerIcon(ctx context.Context, tx pgx.Tx, serverName string) (*ServerIcon, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT server_name, mime_type, data, width, height, checksum, updated_by, updated_at
+		FROM server_icons
+		WHERE server_name = $1
+	`
+	var icon ServerIcon
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).
+		Scan(&icon.ServerName, &icon.MimeType, &icon.Data, &icon.Width, &icon.Height, &icon.Checksum, &icon.UpdatedBy, &icon.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server icon: %w", err)
+	}
+
+	return &icon, nil
+}
+
+// GetServerIconsByNames retrieves the icons of the named servers without their data, keyed by server name
+func (db *PostgreSQL) GetServerIconsByNames(ctx context.Context, tx pgx.Tx, serverNames []string) (map[string]*ServerIcon, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	icons := make(map[string]*ServerIcon)
+	if len(serverNames) == 0 {
+		return icons, nil
+	}
+
+	query := `
+		SELECT server_name, mime_type, width, height, checksum, updated_by, updated_at
+		FROM server_icons
+		WHERE server_name = ANY($1)
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server icons: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var icon ServerIcon
+		if err := rows.Scan(&icon.ServerName, &icon.MimeType, &icon.Width, &icon.Height, &icon.Checksum, &icon.UpdatedBy, &icon.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan server icon: %w", err)
+		}
+		icons[icon.ServerName] = &icon
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server icons: %w", err)
+	}
+
+	return icons, nil
+}
+
+// SetServerIcon creates or replaces the icon of a server. The server's versions are marked as
+// updated, since their API responses reference the icon.
+func (db *PostgreSQL) SetServerIcon(ctx context.Context, tx pgx.Tx, icon *ServerIcon) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+
+	query := `
+		INSERT INTO server_icons (server_name, mime_type, data, width, height, checksum, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (server_name) DO UPDATE
+		SET mime_type = EXCLUDED.mime_type, data = EXCLUDED.data, width = EXCLUDED.width, height = EXCLUDED.height,
+			checksum = EXCLUDED.checksum, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`
+	err := executor.QueryRow(ctx, query, icon.ServerName, icon.MimeType, icon.Data, icon.Width, icon.Height, icon.Checksum, icon.UpdatedBy).
+		Scan(&icon.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set server icon: %w", err)
+	}
+
+	if _, err := executor.Exec(ctx, `UPDATE servers SET updated_at = NOW() WHERE server_name = $1`, icon.ServerName); err != nil {
+		return fmt.Errorf("failed to mark server updated: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteServerIcon removes the icon of a server, marking the server's versions as updated
+func (db *PostgreSQL) DeleteServerIcon(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+
+	result, err := executor.Exec(ctx, `DELETE FROM server_icons WHERE server_name = $1`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to delete server icon: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := executor.Exec(ctx, `UPDATE servers SET updated_at = NOW() WHERE server_name = $1`, serverName); err != nil {
+		return fmt.Errorf("failed to mark server updated: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ClaimIdempotencyKey claims a key for a new request. It returns false if the key is held by an
+// unexpired record, unless that record is for a request that never completed and was claimed
+// longer ago than staleAfter. The actor's expired records are removed.
+func (db *PostgreSQL) ClaimIdempotencyKey(ctx context.Context, tx pgx.Tx, record *IdempotencyRecord, staleAfter time.Duration) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+	if _, err := executor.Exec(ctx, `DELETE FROM idempotency_keys WHERE actor = $1 AND expires_at < NOW()`, record.Actor); err != nil {
+		return false, fmt.Errorf("failed to remove expired idempotency keys: %w", err)
+	}
+
+	query := `
+		INSERT INTO idempotency_keys (actor, key, request_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (actor, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, response = NULL, created_at = NOW(), expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.response IS NULL AND idempotency_keys.created_at < NOW() - make_interval(secs => $5)
+		RETURNING created_at
+	`
+	err := executor.QueryRow(ctx, query, record.Actor, record.Key, record.RequestHash, record.ExpiresAt, staleAfter.Seconds()).
+		Scan(&record.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetIdempotencyKey retrieves an unexpired idempotency record
+func (db *PostgreSQL) GetIdempotencyKey(ctx context.Context, tx pgx.Tx, actor, key string) (*IdempotencyRecord, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT actor, key, request_hash, response, created_at, expires_at
+		FROM idempotency_keys
+		WHERE actor = $1 AND key = $2 AND expires_at >= NOW()
+	`
+	var record IdempotencyRecord
+	err := db.getExecutor(tx).QueryRow(ctx, query, actor, key).
+		Scan(&record.Actor, &record.Key, &record.RequestHash, &record.Response, &record.CreatedAt, &record.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return &record, nil
+}
+
+// CompleteIdempotencyKey stores the response of the request that claimed a key
+func (db *PostgreSQL) CompleteIdempotencyKey(ctx context.Context, tx pgx.Tx, actor, key string, response []byte) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `UPDATE idempotency_keys SET response = $3 WHERE actor = $1 AND key = $2`
+	result, err := db.getExecutor(tx).Exec(ctx, query, actor, key, response)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteIdempotencyKey releases a key so the request can be retried
+func (db *PostgreSQL) DeleteIdempotencyKey(ctx context.Context, tx pgx.Tx, actor, key string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM idempotency_keys WHERE actor = $1 AND key = $2`, actor, key); err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetMaintenanceMode retrieves the current maintenance mode state
+func (db *PostgreSQL) GetMaintenanceMode(ctx context.Context, tx pgx.Tx) (*MaintenanceMode, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var mode MaintenanceMode
+	query := `SELECT enabled, message, updated_at FROM maintenance_mode`
+	if err := db.getExecutor(tx).QueryRow(ctx, query).Scan(&mode.Enabled, &mode.Message, &mode.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to get maintenance mode: %w", err)
+	}
+
+	return &mode, nil
+}
+
+// SetMaintenanceMode enables or disables maintenance mode with the given message
+func (db *PostgreSQL) SetMaintenanceMode(ctx context.Context, tx pgx.Tx, enabled bool, message string) (*MaintenanceMode, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var mode MaintenanceMode
+	query := `
+		UPDATE maintenance_mode
+		SET enabled = $1, message = $2, updated_at = NOW()
+		RETURNING enabled, message, updated_at
+	`
+	if err := db.getExecutor(tx).QueryRow(ctx, query, enabled, message).Scan(&mode.Enabled, &mode.Message, &mode.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to set maintenance mode: %w", err)
+	}
+
+	return &mode, nil
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const namespaceReservationColumns = `namespace, owner, permission_pattern, status, dispute_reason, disputed_by, created_at, updated_at`
+
+// CreateNamespaceReservation reserves a namespace, failing with ErrAlreadyExists if it is already reserved
+func (db *PostgreSQL) CreateNamespaceReservation(ctx context.Context, tx pgx.Tx, reservation *NamespaceReservation) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO namespace_reservations (namespace, owner, permission_pattern, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at, updated_at
+	`
+	err := db.getExecutor(tx).QueryRow(ctx, query, reservation.Namespace, reservation.Owner, reservation.PermissionPattern, reservation.Status).
+		Scan(&reservation.CreatedAt, &reservation.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create namespace reservation: %w", err)
+	}
+
+	return nil
+}
+
+// GetNamespaceReservation retrieves the reservation of a namespace
+func (db *PostgreSQL) GetNamespaceReservation(ctx context.Context, tx pgx.Tx, namespace string) (*NamespaceReservation, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + namespaceReservationColumns + ` FROM namespace_reservations WHERE namespace = $1`
+	reservation, err := scanNamespaceReservation(db.getExecutor(tx).QueryRow(ctx, query, namespace))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get namespace reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// ListNamespaceReservations retrieves reservations with the given status, oldest first
+func (db *PostgreSQL) ListNamespaceReservations(ctx context.Context, tx pgx.Tx, status string) ([]*NamespaceReservation, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + namespaceReservationColumns + ` FROM namespace_reservations WHERE status = $1 ORDER BY updated_at, namespace`
+	rows, err := db.getExecutor(tx).Query(ctx, query, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespace reservations: %w", err)
+	}
+	defer rows.Close()
+
+	var reservations []*NamespaceReservation
+	for rows.Next() {
+		reservation, err := scanNamespaceReservation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan namespace reservation: %w", err)
+		}
+		reservations = append(reservations, reservation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating namespace reservations: %w", err)
+	}
+
+	return reservations, nil
+}
+
+// UpdateNamespaceReservation saves the status and dispute details of a reservation
+func (db *PostgreSQL) UpdateNamespaceReservation(ctx context.Context, tx pgx.Tx, reservation *NamespaceReservation) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE namespace_reservations
+		SET status = $2, dispute_reason = $3, disputed_by = $4, updated_at = NOW()
+		WHERE namespace = $1
+		RETURNING updated_at
+	`
+	err := db.getExecutor(tx).QueryRow(ctx, query, reservation.Namespace, reservation.Status, reservation.DisputeReason, reservation.DisputedBy).
+		Scan(&reservation.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update namespace reservation: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteNamespaceReservation releases a namespace
+func (db *PostgreSQL) DeleteNamespaceReservation(ctx context.Context, tx pgx.Tx, namespace string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM namespace_reservations WHERE namespace = $1`, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to delete namespace reservation: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func scanNamespaceReservation(row pgx.Row) (*NamespaceReservation, error) {
+	var r NamespaceReservation
+	if err := row.Scan(&r.Namespace, &r.Owner, &r.PermissionPattern, &r.Status, &r.DisputeReason, &r.DisputedBy, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
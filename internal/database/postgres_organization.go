@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	organizationColumns       = `namespace, permission_pattern, created_by, created_at`
+	organizationMemberColumns = `namespace, member, role, added_by, added_at`
+)
+
+// CreateOrganization creates an organization, failing with ErrAlreadyExists if the namespace already has one
+func (db *PostgreSQL) CreateOrganization(ctx context.Context, tx pgx.Tx, organization *Organization) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO organizations (namespace, permission_pattern, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING created_at
+	`
+	err := db.getExecutor(tx).QueryRow(ctx, query, organization.Namespace, organization.PermissionPattern, organization.CreatedBy).
+		Scan(&organization.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrganization retrieves the organization of a namespace
+func (db *PostgreSQL) GetOrganization(ctx context.Context, tx pgx.Tx, namespace string) (*Organization, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + organizationColumns + ` FROM organizations WHERE namespace = $1`
+	organization, err := scanOrganization(db.getExecutor(tx).QueryRow(ctx, query, namespace))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return organization, nil
+}
+
+// DeleteOrganization removes an organization along with its members
+func (db *PostgreSQL) DeleteOrganization(ctx context.Context, tx pgx.Tx, namespace string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM organizations WHERE namespace = $1`, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListOrganizationMembers retrieves the members of an organization, admins first
+func (db *PostgreSQL) ListOrganizationMembers(ctx context.Context, tx pgx.Tx, namespace string) ([]*OrganizationMember, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + organizationMemberColumns + ` FROM organization_members WHERE namespace = $1 ORDER BY role = 'admin' DESC, member`
+	rows, err := db.getExecutor(tx).Query(ctx, query, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*OrganizationMember
+	for rows.Next() {
+		member, err := scanOrganizationMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating organization members: %w", err)
+	}
+
+	return members, nil
+}
+
+// GetOrganizationMember retrieves a user's membership of an organization
+func (db *PostgreSQL) GetOrganizationMember(ctx context.Context, tx pgx.Tx, namespace, member string) (*OrganizationMember, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + organizationMemberColumns + ` FROM organization_members WHERE namespace = $1 AND member = $2`
+	m, err := scanOrganizationMember(db.getExecutor(tx).QueryRow(ctx, query, namespace, member))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+
+	return m, nil
+}
+
+// SetOrganizationMember adds a member to an organization or changes their role
+func (db *PostgreSQL) SetOrganizationMember(ctx context.Context, tx pgx.Tx, member *OrganizationMember) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO organization_members (namespace, member, role, added_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (namespace, member) DO UPDATE SET role = EXCLUDED.role
+		RETURNING added_by, added_at
+	`
+	err := db.getExecutor(tx).QueryRow(ctx, query, member.Namespace, member.Member, member.Role, member.AddedBy).
+		Scan(&member.AddedBy, &member.AddedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to set organization member: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOrganizationMember removes a member from an organization
+func (db *PostgreSQL) DeleteOrganizationMember(ctx context.Context, tx pgx.Tx, namespace, member string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM organization_members WHERE namespace = $1 AND member = $2`, namespace, member)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListMemberOrganizations retrieves the organizations a user is a member of
+func (db *PostgreSQL) ListMemberOrganizations(ctx context.Context, tx pgx.Tx, member string) ([]*Organization, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT o.namespace, o.permission_pattern, o.created_by, o.created_at
+		FROM organizations o
+		JOIN organization_members m ON m.namespace = o.namespace
+		WHERE m.member = $1
+		ORDER BY o.namespace
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, member)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list member organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var organizations []*Organization
+	for rows.Next() {
+		organization, err := scanOrganization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		organizations = append(organizations, organization)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating organizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+func scanOrganization(row pgx.Row) (*Organization, error) {
+	var o Organization
+	if err := row.Scan(&o.Namespace, &o.PermissionPattern, &o.CreatedBy, &o.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func scanOrganizationMember(row pgx.Row) (*OrganizationMember, error) {
+	var m OrganizationMember
+	if err := row.Scan(&m.Namespace, &m.Member, &m.Role, &m.AddedBy, &m.AddedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// FindPackageClaims retrieves every server version with a package whose lowercased identifier is one
+// of identifiers. OCI identifiers are also compared with their tag and digest removed, so a
+// repository matches every image of it. An empty registryType matches packages of any type.
+func (db *PostgreSQL) FindPackageClaims(ctx context.Context, tx pgx.Tx, identifiers []string, registryType string) ([]*apiv0.PackageClaim, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT s.server_name, s.version, s.status, s.is_latest, pkg->>'registryType', pkg->>'identifier'
+		FROM servers s, jsonb_path_query(s.value, '$.packages[*]') AS pkg
+		WHERE (
+			lower(pkg->>'identifier') = ANY($1)
+			OR (pkg->>'registryType' = 'oci'
+				AND lower(regexp_replace(pkg->>'identifier', '(:[^/:@]+)?(@[^/]+)?$', '')) = ANY($1))
+		)
+		AND ($2 = '' OR pkg->>'registryType' = $2)
+		ORDER BY s.server_name, s.published_at, s.version
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, identifiers, registryType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find package claims: %w", err)
+	}
+	defer rows.Close()
+
+	var claims []*apiv0.PackageClaim
+	for rows.Next() {
+		var claim apiv0.PackageClaim
+		if err := rows.Scan(&claim.ServerName, &claim.Version, &claim.Status, &claim.IsLatest, &claim.RegistryType, &claim.Identifier); err != nil {
+			return nil, fmt.Errorf("failed to scan package claim: %w", err)
+		}
+		claims = append(claims, &claim)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating package claims: %w", err)
+	}
+
+	return claims, nil
+}
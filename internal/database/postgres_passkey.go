@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CreatePasskey stores a new passkey, failing with ErrAlreadyExists if its credential is already registered
+func (db *PostgreSQL) CreatePasskey(ctx context.Context, tx pgx.Tx, passkey *Passkey) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO passkeys (owner, name, credential_id, credential)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	err := db.getExecutor(tx).QueryRow(ctx, query, passkey.Owner, passkey.Name, passkey.CredentialID, passkey.Credential).
+		Scan(&passkey.ID, &passkey.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create passkey: %w", err)
+	}
+
+	return nil
+}
+
+// ListPasskeys retrieves the passkeys of an owner, oldest first
+func (db *PostgreSQL) ListPasskeys(ctx context.Context, tx pgx.Tx, owner string) ([]*Passkey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT id, owner, name, credential_id, credential, created_at, last_used_at
+		FROM passkeys
+		WHERE owner = $1
+		ORDER BY created_at, id
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+	defer rows.Close()
+
+	var passkeys []*Passkey
+	for rows.Next() {
+		var passkey Passkey
+		if err := rows.Scan(&passkey.ID, &passkey.Owner, &passkey.Name, &passkey.CredentialID, &passkey.Credential,
+			&passkey.CreatedAt, &passkey.LastUsedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan passkey: %w", err)
+		}
+		passkeys = append(passkeys, &passkey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating passkeys: %w", err)
+	}
+
+	return passkeys, nil
+}
+
+// UpdatePasskeyCredential saves a passkey's credential record after it was used, and records the use
+func (db *PostgreSQL) UpdatePasskeyCredential(ctx context.Context, tx pgx.Tx, id int64, credential []byte) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `UPDATE passkeys SET credential = $2, last_used_at = NOW() WHERE id = $1`
+	result, err := db.getExecutor(tx).Exec(ctx, query, id, credential)
+	if err != nil {
+		return fmt.Errorf("failed to update passkey: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeletePasskey removes a passkey of an owner
+func (db *PostgreSQL) DeletePasskey(ctx context.Context, tx pgx.Tx, owner string, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM passkeys WHERE id = $1 AND owner = $2`, id, owner)
+	if err != nil {
+		return fmt.Errorf("failed to delete passkey: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CreatePasskeyChallenge stores an outstanding WebAuthn challenge. Expired challenges are removed first,
+// as challenges that are never answered are not otherwise cleaned up.
+func (db *PostgreSQL) CreatePasskeyChallenge(ctx context.Context, tx pgx.Tx, challenge *PasskeyChallenge) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+	if _, err := executor.Exec(ctx, `DELETE FROM passkey_challenges WHERE expires_at <= NOW()`); err != nil {
+		return fmt.Errorf("failed to delete expired passkey challenges: %w", err)
+	}
+
+	query := `
+		INSERT INTO passkey_challenges (challenge, owner, ceremony, session_data, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	if _, err := executor.Exec(ctx, query, challenge.Challenge, challenge.Owner, challenge.Ceremony,
+		challenge.SessionData, challenge.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to create passkey challenge: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumePasskeyChallenge removes and returns an unexpired challenge issued to owner for ceremony, so
+// each challenge can only be answered once
+func (db *PostgreSQL) ConsumePasskeyChallenge(ctx context.Context, tx pgx.Tx, challenge, owner, ceremony string) (*PasskeyChallenge, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		DELETE FROM passkey_challenges
+		WHERE challenge = $1 AND owner = $2 AND ceremony = $3 AND expires_at > NOW()
+		RETURNING challenge, owner, ceremony, session_data, expires_at
+	`
+	var result PasskeyChallenge
+	err := db.getExecutor(tx).QueryRow(ctx, query, challenge, owner, ceremony).
+		Scan(&result.Challenge, &result.Owner, &result.Ceremony, &result.SessionData, &result.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to consume passkey challenge: %w", err)
+	}
+
+	return &result, nil
+}
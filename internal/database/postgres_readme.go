@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetServerReadme retrieves the README of a server
+func (db *PostgreSQL) GetServerReadme(ctx context.Context, tx pgx.Tx, serverName string) (*ServerReadme, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT server_name, content, updated_by, updated_at FROM server_readmes WHERE server_name = $1`
+	var readme ServerReadme
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).
+		Scan(&readme.ServerName, &readme.Content, &readme.UpdatedBy, &readme.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server README: %w", err)
+	}
+
+	return &readme, nil
+}
+
+// SetServerReadme creates or replaces the README of a server
+func (db *PostgreSQL) SetServerReadme(ctx context.Context, tx pgx.Tx, readme *ServerReadme) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_readmes (server_name, content, updated_by, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (server_name) DO UPDATE
+		SET content = EXCLUDED.content, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`
+	if err := db.getExecutor(tx).QueryRow(ctx, query, readme.ServerName, readme.Content, readme.UpdatedBy).Scan(&readme.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to set server README: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteServerReadme removes the README of a server
+func (db *PostgreSQL) DeleteServerReadme(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM server_readmes WHERE server_name = $1`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to delete server README: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const abuseReportColumns = `id, server_name, category, details, reporter, status, resolved_by, created_at, resolved_at`
+
+// CreateAbuseReport adds a report to the moderation queue
+func (db *PostgreSQL) CreateAbuseReport(ctx context.Context, tx pgx.Tx, report *AbuseReport) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO abuse_reports (server_name, category, details, reporter)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, status, created_at
+	`
+	err := db.getExecutor(tx).QueryRow(ctx, query, report.ServerName, report.Category, report.Details, report.Reporter).
+		Scan(&report.ID, &report.Status, &report.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create abuse report: %w", err)
+	}
+
+	return nil
+}
+
+// ListAbuseReports retrieves up to limit reports with the given status, oldest first
+func (db *PostgreSQL) ListAbuseReports(ctx context.Context, tx pgx.Tx, status string, limit int) ([]*AbuseReport, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + abuseReportColumns + ` FROM abuse_reports WHERE status = $1 ORDER BY created_at, id LIMIT $2`
+	rows, err := db.getExecutor(tx).Query(ctx, query, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list abuse reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []*AbuseReport
+	for rows.Next() {
+		report, err := scanAbuseReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan abuse report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating abuse reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// UpdateAbuseReportStatus closes a report as resolved or dismissed
+func (db *PostgreSQL) UpdateAbuseReportStatus(ctx context.Context, tx pgx.Tx, id int64, status, resolvedBy string) (*AbuseReport, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE abuse_reports
+		SET status = $2, resolved_by = $3, resolved_at = NOW()
+		WHERE id = $1
+		RETURNING ` + abuseReportColumns
+	report, err := scanAbuseReport(db.getExecutor(tx).QueryRow(ctx, query, id, status, resolvedBy))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update abuse report: %w", err)
+	}
+
+	return report, nil
+}
+
+func scanAbuseReport(row pgx.Row) (*AbuseReport, error) {
+	var r AbuseReport
+	if err := row.Scan(&r.ID, &r.ServerName, &r.Category, &r.Details, &r.Reporter, &r.Status, &r.ResolvedBy, &r.CreatedAt, &r.ResolvedAt); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RevokeToken adds a token to the revocation list until it expires, failing with ErrAlreadyExists if
+// it is already revoked. Entries for tokens that have since expired are removed first.
+func (db *PostgreSQL) RevokeToken(ctx context.Context, tx pgx.Tx, jti string, expiresAt time.Time, revokedBy string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+	if _, err := executor.Exec(ctx, `DELETE FROM revoked_tokens WHERE expires_at <= NOW()`); err != nil {
+		return fmt.Errorf("failed to delete expired token revocations: %w", err)
+	}
+
+	query := `
+		INSERT INTO revoked_tokens (jti, revoked_by, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING
+	`
+	result, err := executor.Exec(ctx, query, jti, revokedBy, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAlreadyExists
+	}
+
+	return nil
+}
+
+// IsTokenRevoked reports whether a token is on the revocation list
+func (db *PostgreSQL) IsTokenRevoked(ctx context.Context, tx pgx.Tx, jti string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	var revoked bool
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)`
+	if err := db.getExecutor(tx).QueryRow(ctx, query, jti).Scan(&revoked); err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+
+	return revoked, nil
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+)
+
+const webSessionColumns = `id, owner, auth_method, subject, permissions, csrf_token, user_agent, client_ip, created_at, expires_at, last_used_at, revoked_at`
+
+// CreateWebSession stores a new browser session
+func (db *PostgreSQL) CreateWebSession(ctx context.Context, tx pgx.Tx, session *WebSession) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	permissionsJSON, err := json.Marshal(session.Permissions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session permissions: %w", err)
+	}
+
+	query := `
+		INSERT INTO web_sessions (owner, auth_method, subject, permissions, session_hash, csrf_token, user_agent, client_ip, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+	err = db.getExecutor(tx).QueryRow(ctx, query, session.Owner, string(session.AuthMethod), session.Subject, permissionsJSON,
+		session.Hash, session.CSRFToken, session.UserAgent, session.ClientIP, session.ExpiresAt).
+		Scan(&session.ID, &session.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// ListWebSessions retrieves the unrevoked, unexpired browser sessions of an owner, newest first
+func (db *PostgreSQL) ListWebSessions(ctx context.Context, tx pgx.Tx, owner string) ([]*WebSession, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + webSessionColumns + ` FROM web_sessions
+		WHERE owner = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC, id DESC`
+	rows, err := db.getExecutor(tx).Query(ctx, query, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*WebSession
+	for rows.Next() {
+		session, err := scanWebSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// GetWebSession retrieves a browser session by ID
+func (db *PostgreSQL) GetWebSession(ctx context.Context, tx pgx.Tx, id int64) (*WebSession, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + webSessionColumns + ` FROM web_sessions WHERE id = $1`
+	session, err := scanWebSession(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// GetWebSessionByHash retrieves a browser session by the hash of its cookie
+func (db *PostgreSQL) GetWebSessionByHash(ctx context.Context, tx pgx.Tx, hash string) (*WebSession, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + webSessionColumns + ` FROM web_sessions WHERE session_hash = $1`
+	session, err := scanWebSession(db.getExecutor(tx).QueryRow(ctx, query, hash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// RevokeWebSession marks a browser session as revoked
+func (db *PostgreSQL) RevokeWebSession(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `UPDATE web_sessions SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`
+	result, err := db.getExecutor(tx).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// TouchWebSession records that a browser session has just been used
+func (db *PostgreSQL) TouchWebSession(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.getExecutor(tx).Exec(ctx, `UPDATE web_sessions SET last_used_at = NOW() WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to update session last use: %w", err)
+	}
+
+	return nil
+}
+
+func scanWebSession(row pgx.Row) (*WebSession, error) {
+	var session WebSession
+	var authMethod string
+	var permissionsJSON []byte
+	if err := row.Scan(&session.ID, &session.Owner, &authMethod, &session.Subject, &permissionsJSON,
+		&session.CSRFToken, &session.UserAgent, &session.ClientIP,
+		&session.CreatedAt, &session.ExpiresAt, &session.LastUsedAt, &session.RevokedAt); err != nil {
+		return nil, err
+	}
+	session.AuthMethod = auth.Method(authMethod)
+	if err := json.Unmarshal(permissionsJSON, &session.Permissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session permissions: %w", err)
+	}
+	return &session, nil
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// SetServerSignature stores the publisher's signature of a server version
+func (db *PostgreSQL) SetServerSignature(ctx context.Context, tx pgx.Tx, serverName, version string, signature *apiv0.ServerSignature) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_signatures (server_name, version, algorithm, public_key, signature)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (server_name, version)
+		DO UPDATE SET algorithm = EXCLUDED.algorithm, public_key = EXCLUDED.public_key,
+			signature = EXCLUDED.signature, created_at = NOW()
+	`
+	_, err := db.getExecutor(tx).Exec(ctx, query, serverName, version, signature.Algorithm, signature.PublicKey, signature.Value)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to set server signature: %w", err)
+	}
+
+	return nil
+}
+
+// GetServerSignatures retrieves the signatures of server versions, given as parallel lists of names
+// and versions, keyed by server name and then version
+func (db *PostgreSQL) GetServerSignatures(ctx context.Context, tx pgx.Tx, serverNames, versions []string) (map[string]map[string]*apiv0.ServerSignature, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	signatures := make(map[string]map[string]*apiv0.ServerSignature)
+	if len(serverNames) == 0 {
+		return signatures, nil
+	}
+
+	query := `
+		SELECT server_name, version, algorithm, public_key, signature
+		FROM server_signatures
+		WHERE (server_name, version) IN (SELECT * FROM unnest($1::text[], $2::text[]))
+	`
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverNames, versions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server signatures: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var serverName, version string
+		var signature apiv0.ServerSignature
+		if err := rows.Scan(&serverName, &version, &signature.Algorithm, &signature.PublicKey, &signature.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan server signature: %w", err)
+		}
+		if signatures[serverName] == nil {
+			signatures[serverName] = make(map[string]*apiv0.ServerSignature)
+		}
+		signatures[serverName][version] = &signature
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server signatures: %w", err)
+	}
+
+	return signatures, nil
+}
+
+// DeleteServerSignature removes the signature of a server version, if it has one
+func (db *PostgreSQL) DeleteServerSignature(ctx context.Context, tx pgx.Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM server_signatures WHERE server_name = $1 AND version = $2`, serverName, version); err != nil {
+		return fmt.Errorf("failed to delete server signature: %w", err)
+	}
+
+	return nil
+}
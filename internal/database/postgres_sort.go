@@ -0,0 +1,119 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// serverSort describes how ListServers orders and paginates results. Results are always
+// ordered by (key, server_name, version) so that pagination is stable when keys tie.
+type serverSort struct {
+	field SortField
+	desc  bool
+	// key is the SQL expression sorted on before server_name and version, empty for name sorts
+	key string
+}
+
+// newServerSort resolves the sort requested by filter. Relevance sorting needs a search
+// term to rank against, so it falls back to sorting by name without one. searchArg is the
+// placeholder holding the raw search term.
+func newServerSort(filter *ServerFilter, searchArg string) (serverSort, error) {
+	if filter == nil {
+		return serverSort{field: SortByName}, nil
+	}
+
+	s := serverSort{field: filter.Sort, desc: filter.SortDesc}
+	switch filter.Sort {
+	case SortByUpdated:
+		s.key = "updated_at"
+	case SortByCreated:
+		s.key = "published_at"
+	case SortByRelevance:
+		if filter.SubstringName == nil || searchArg == "" {
+			s.field = SortByName
+			break
+		}
+		s.key = relevanceRank(searchArg)
+	case SortByName, "":
+		s.field = SortByName
+	default:
+		return serverSort{}, fmt.Errorf("%w: unknown sort field %q", ErrInvalidInput, filter.Sort)
+	}
+	return s, nil
+}
+
+// relevanceRank ranks server names against a search term, lower being more relevant:
+// exact matches of the full name or the part after the namespace first, then prefix
+// matches of the part after the namespace, then prefix matches of the full name.
+func relevanceRank(searchArg string) string {
+	return fmt.Sprintf(`CASE
+            WHEN lower(server_name) = lower(%[1]s) OR lower(split_part(server_name, '/', 2)) = lower(%[1]s) THEN 0
+            WHEN starts_with(lower(split_part(server_name, '/', 2)), lower(%[1]s)) THEN 1
+            WHEN starts_with(lower(server_name), lower(%[1]s)) THEN 2
+            ELSE 3
+        END`, searchArg)
+}
+
+// direction returns the ORDER BY direction and the keyset comparison operator
+func (s serverSort) direction() (string, string) {
+	if s.desc {
+		return "DESC", "<"
+	}
+	return "ASC", ">"
+}
+
+// orderBy returns the ORDER BY clause for the sort
+func (s serverSort) orderBy() string {
+	dir, _ := s.direction()
+	columns := []string{"server_name " + dir, "version " + dir}
+	if s.key != "" {
+		columns = append([]string{s.key + " " + dir}, columns...)
+	}
+	return "ORDER BY " + strings.Join(columns, ", ")
+}
+
+// sortCursor is the position of the last returned row for sorts other than by name.
+// It is encoded as opaque base64url JSON so clients treat it as a token.
+type sortCursor struct {
+	Sort    SortField  `json:"s"`
+	Desc    bool       `json:"d,omitempty"`
+	Time    *time.Time `json:"t,omitempty"`
+	Rank    *int       `json:"r,omitempty"`
+	Name    string     `json:"n"`
+	Version string     `json:"v"`
+}
+
+// keyValue returns the cursor's value for the sort key
+func (c sortCursor) keyValue() any {
+	if c.Rank != nil {
+		return *c.Rank
+	}
+	if c.Time != nil {
+		return *c.Time
+	}
+	return nil
+}
+
+func encodeSortCursor(c sortCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeSortCursor parses a cursor and checks it was issued for the same sort
+func decodeSortCursor(cursor string, s serverSort) (sortCursor, error) {
+	var c sortCursor
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return c, fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.keyValue() == nil {
+		return c, fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+	if c.Sort != s.field || c.Desc != s.desc {
+		return c, fmt.Errorf("%w: cursor was issued for a different sort order", ErrInvalidInput)
+	}
+	return c, nil
+}
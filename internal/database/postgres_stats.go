@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// IncrementFetchCounts adds the given per-server fetch counts to the daily totals for day
+func (db *PostgreSQL) IncrementFetchCounts(ctx context.Context, tx pgx.Tx, day time.Time, counts map[string]int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if len(counts) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(counts))
+	values := make([]int64, 0, len(counts))
+	for name, count := range counts {
+		names = append(names, name)
+		values = append(values, count)
+	}
+
+	query := `
+		INSERT INTO server_fetch_stats (server_name, day, fetch_count)
+		SELECT name, $1::date, cnt
+		FROM unnest($2::text[], $3::bigint[]) AS t(name, cnt)
+		ON CONFLICT (server_name, day)
+		DO UPDATE SET fetch_count = server_fetch_stats.fetch_count + EXCLUDED.fetch_count
+	`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, day.UTC(), names, values); err != nil {
+		return fmt.Errorf("failed to increment fetch counts: %w", err)
+	}
+
+	return nil
+}
+
+// GetServerFetchStats retrieves daily fetch counts for a server since the given day
+func (db *PostgreSQL) GetServerFetchStats(ctx context.Context, tx pgx.Tx, serverName string, since time.Time) ([]DailyFetchCount, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT day, fetch_count
+		FROM server_fetch_stats
+		WHERE server_name = $1 AND day >= $2::date
+		ORDER BY day
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverName, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server fetch stats: %w", err)
+	}
+	defer rows.Close()
+
+	return scanDailyFetchCounts(rows)
+}
+
+// GetDailyFetchTotals retrieves registry-wide daily fetch counts since the given day
+func (db *PostgreSQL) GetDailyFetchTotals(ctx context.Context, tx pgx.Tx, since time.Time) ([]DailyFetchCount, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT day, SUM(fetch_count)::BIGINT
+		FROM server_fetch_stats
+		WHERE day >= $1::date
+		GROUP BY day
+		ORDER BY day
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily fetch totals: %w", err)
+	}
+	defer rows.Close()
+
+	return scanDailyFetchCounts(rows)
+}
+
+// GetTopFetchedServers retrieves the most fetched servers since the given day
+func (db *PostgreSQL) GetTopFetchedServers(ctx context.Context, tx pgx.Tx, since time.Time, limit int) ([]ServerFetchCount, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT server_name, SUM(fetch_count)::BIGINT AS total
+		FROM server_fetch_stats
+		WHERE day >= $1::date
+		GROUP BY server_name
+		ORDER BY total DESC, server_name
+		LIMIT $2
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, since.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top fetched servers: %w", err)
+	}
+	defer rows.Close()
+
+	results := []ServerFetchCount{}
+	for rows.Next() {
+		var result ServerFetchCount
+		if err := rows.Scan(&result.ServerName, &result.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan fetch count row: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// scanDailyFetchCounts reads (day, count) rows
+func scanDailyFetchCounts(rows pgx.Rows) ([]DailyFetchCount, error) {
+	results := []DailyFetchCount{}
+	for rows.Next() {
+		var result DailyFetchCount
+		if err := rows.Scan(&result.Day, &result.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan fetch count row: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return results, nil
+}
@@ -1,13 +1,17 @@
 package database_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
@@ -955,6 +959,39 @@ func TestPostgreSQL_PerformanceScenarios(t *testing.T) {
 	})
 }
 
+func TestPostgreSQL_SlowQueryLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, logging.FormatJSON, "info")
+	require.NoError(t, err)
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	var methods []string
+	database.QueryObserver = func(_ context.Context, method, _ string, _ time.Duration, _ error) {
+		methods = append(methods, method)
+	}
+	t.Cleanup(func() { database.QueryObserver = nil })
+
+	db := database.NewTestDB(t, database.WithSlowQueryThreshold(time.Nanosecond))
+	buf.Reset()
+	methods = nil
+
+	_, err = db.GetServerByName(context.Background(), nil, "com.example/secret-server")
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	assert.Contains(t, methods, "GetServerByName", "queries are attributed to the method that made them")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(bytes.SplitN(buf.Bytes(), []byte("\n"), 2)[0], &record), buf.String())
+	assert.Equal(t, "Slow database query", record["msg"])
+	assert.Equal(t, "GetServerByName", record["method"])
+	assert.Equal(t, "SELECT", record["operation"])
+	assert.Contains(t, record, "duration_ms")
+	assert.Contains(t, record, "rows")
+	assert.NotContains(t, buf.String(), "secret-server", "query parameters are not logged")
+}
+
 // Helper functions for creating pointers to basic types
 func stringPtr(s string) *string {
 	return &s
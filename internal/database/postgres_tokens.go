@@ -0,0 +1,160 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const apiTokenColumns = `id, owner, name, token_prefix, permissions, created_at, expires_at, last_used_at, revoked_at`
+
+// CreateAPIToken stores a new API token
+func (db *PostgreSQL) CreateAPIToken(ctx context.Context, tx pgx.Tx, token *APIToken) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	permissionsJSON, err := json.Marshal(token.Permissions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal API token permissions: %w", err)
+	}
+
+	query := `
+		INSERT INTO api_tokens (owner, name, token_prefix, token_hash, permissions, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	err = db.getExecutor(tx).QueryRow(ctx, query, token.Owner, token.Name, token.Prefix, token.Hash, permissionsJSON, token.ExpiresAt).
+		Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	return nil
+}
+
+// ListAPITokens retrieves the unrevoked API tokens of an owner, newest first
+func (db *PostgreSQL) ListAPITokens(ctx context.Context, tx pgx.Tx, owner string) ([]*APIToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE owner = $1 AND revoked_at IS NULL ORDER BY created_at DESC, id DESC`
+	rows, err := db.getExecutor(tx).Query(ctx, query, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating API tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// GetAPIToken retrieves an API token by ID
+func (db *PostgreSQL) GetAPIToken(ctx context.Context, tx pgx.Tx, id int64) (*APIToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE id = $1`
+	token, err := scanAPIToken(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get API token: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetAPITokenByHash retrieves an API token by the hash of its secret
+func (db *PostgreSQL) GetAPITokenByHash(ctx context.Context, tx pgx.Tx, hash string) (*APIToken, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE token_hash = $1`
+	token, err := scanAPIToken(db.getExecutor(tx).QueryRow(ctx, query, hash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get API token: %w", err)
+	}
+
+	return token, nil
+}
+
+// CountAPITokens counts the unrevoked API tokens of an owner
+func (db *PostgreSQL) CountAPITokens(ctx context.Context, tx pgx.Tx, owner string) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	var count int
+	query := `SELECT COUNT(*) FROM api_tokens WHERE owner = $1 AND revoked_at IS NULL`
+	if err := db.getExecutor(tx).QueryRow(ctx, query, owner).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count API tokens: %w", err)
+	}
+
+	return count, nil
+}
+
+// RevokeAPIToken marks an API token as revoked
+func (db *PostgreSQL) RevokeAPIToken(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `UPDATE api_tokens SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`
+	result, err := db.getExecutor(tx).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// TouchAPIToken records that an API token has just been used
+func (db *PostgreSQL) TouchAPIToken(ctx context.Context, tx pgx.Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.getExecutor(tx).Exec(ctx, `UPDATE api_tokens SET last_used_at = NOW() WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to update API token last use: %w", err)
+	}
+
+	return nil
+}
+
+func scanAPIToken(row pgx.Row) (*APIToken, error) {
+	var token APIToken
+	var permissionsJSON []byte
+	if err := row.Scan(&token.ID, &token.Owner, &token.Name, &token.Prefix, &permissionsJSON,
+		&token.CreatedAt, &token.ExpiresAt, &token.LastUsedAt, &token.RevokedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(permissionsJSON, &token.Permissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal API token permissions: %w", err)
+	}
+	return &token, nil
+}
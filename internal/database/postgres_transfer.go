@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateServerTransfer records a pending transfer, replacing any existing pending transfer of the server
+func (db *PostgreSQL) CreateServerTransfer(ctx context.Context, tx pgx.Tx, transfer *ServerTransfer) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_transfers (server_name, new_name, initiated_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (server_name)
+		DO UPDATE SET new_name = EXCLUDED.new_name, initiated_by = EXCLUDED.initiated_by,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+	`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, transfer.ServerName, transfer.NewName, transfer.InitiatedBy, transfer.CreatedAt, transfer.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to create server transfer: %w", err)
+	}
+
+	return nil
+}
+
+// GetServerTransfer retrieves the pending transfer of a server, including expired transfers
+func (db *PostgreSQL) GetServerTransfer(ctx context.Context, tx pgx.Tx, serverName string) (*ServerTransfer, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT server_name, new_name, initiated_by, created_at, expires_at
+		FROM server_transfers
+		WHERE server_name = $1
+	`
+
+	var transfer ServerTransfer
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).Scan(
+		&transfer.ServerName, &transfer.NewName, &transfer.InitiatedBy, &transfer.CreatedAt, &transfer.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server transfer: %w", err)
+	}
+
+	return &transfer, nil
+}
+
+// DeleteServerTransfer removes the pending transfer of a server
+func (db *PostgreSQL) DeleteServerTransfer(ctx context.Context, tx pgx.Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM server_transfers WHERE server_name = $1`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to delete server transfer: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RenameServer moves every version of a server, its README, icon and usage statistics to a new name.
+// The versions' signatures are removed, as they signed the old name.
+func (db *PostgreSQL) RenameServer(ctx context.Context, tx pgx.Tx, oldName, newName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+
+	if _, err := executor.Exec(ctx, `DELETE FROM server_signatures WHERE server_name = $1`, oldName); err != nil {
+		return fmt.Errorf("failed to delete server signatures: %w", err)
+	}
+
+	query := `
+		UPDATE servers
+		SET server_name = $2, value = jsonb_set(value, '{name}', to_jsonb($2::text)), updated_at = NOW()
+		WHERE server_name = $1
+	`
+	result, err := executor.Exec(ctx, query, oldName, newName)
+	if err != nil {
+		return fmt.Errorf("failed to rename server: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := executor.Exec(ctx, `UPDATE server_fetch_stats SET server_name = $2 WHERE server_name = $1`, oldName, newName); err != nil {
+		return fmt.Errorf("failed to rename server fetch stats: %w", err)
+	}
+	if _, err := executor.Exec(ctx, `UPDATE server_readmes SET server_name = $2 WHERE server_name = $1`, oldName, newName); err != nil {
+		return fmt.Errorf("failed to rename server README: %w", err)
+	}
+	if _, err := executor.Exec(ctx, `UPDATE server_icons SET server_name = $2 WHERE server_name = $1`, oldName, newName); err != nil {
+		return fmt.Errorf("failed to rename server icon: %w", err)
+	}
+
+	return nil
+}
+
+// RecordAuditEvent appends an event to the audit log
+func (db *PostgreSQL) RecordAuditEvent(ctx context.Context, tx pgx.Tx, event *AuditEvent) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	details := event.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event details: %w", err)
+	}
+
+	query := `INSERT INTO audit_log (action, actor, resource, details) VALUES ($1, $2, $3, $4)`
+	if _, err := db.getExecutor(tx).Exec(ctx, query, event.Action, event.Actor, event.Resource, detailsJSON); err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditEvents retrieve up to limit audit events matching the filter, oldest first
+func (db *PostgreSQL) ListAuditEvents(ctx context.Context, tx pgx.Tx, filter *AuditEventFilter, limit int) ([]*AuditEvent, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var whereConditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		whereConditions = append(whereConditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter != nil {
+		if filter.After > 0 {
+			addCondition("id > $%d", filter.After)
+		}
+		if filter.Action != "" {
+			addCondition("action = $%d", filter.Action)
+		}
+		if filter.Actor != "" {
+			addCondition("actor = $%d", filter.Actor)
+		}
+		if filter.Resource != "" {
+			addCondition("resource = $%d", filter.Resource)
+		}
+		if filter.Since != nil {
+			addCondition("created_at >= $%d", *filter.Since)
+		}
+		if filter.Until != nil {
+			addCondition("created_at < $%d", *filter.Until)
+		}
+	}
+
+	query := `SELECT id, action, actor, resource, details, created_at FROM audit_log`
+	if len(whereConditions) > 0 {
+		query += " WHERE " + strings.Join(whereConditions, " AND ")
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY id LIMIT $%d", len(args))
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*AuditEvent
+	for rows.Next() {
+		var event AuditEvent
+		var detailsJSON []byte
+		if err := rows.Scan(&event.ID, &event.Action, &event.Actor, &event.Resource, &detailsJSON, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		if err := json.Unmarshal(detailsJSON, &event.Details); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal audit event details: %w", err)
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/modelcontextprotocol/registry/internal/database")
+
+// QueryObserver, if set, is called with the duration of each query, for metrics. method is the
+// PostgreSQL method that made the query, such as GetServerByName, and operation its SQL command.
+var QueryObserver func(ctx context.Context, method, operation string, duration time.Duration, err error)
+
+// postgresMethodPrefix is how the functions of PostgreSQL methods appear in stack traces
+const postgresMethodPrefix = "github.com/modelcontextprotocol/registry/internal/database.(*PostgreSQL)."
+
+type queryKey struct{}
+
+// queryInfo carries what TraceQueryEnd needs to know about a query from TraceQueryStart
+type queryInfo struct {
+	method    string
+	operation string
+	start     time.Time
+}
+
+// queryTracer records a span for each query, as a child of the span of the request that made it,
+// and its duration by the method that made it. Queries slower than slowQueryThreshold, if set, are logged.
+type queryTracer struct {
+	slowQueryThreshold time.Duration
+}
+
+var _ pgx.QueryTracer = queryTracer{}
+
+// TraceQueryStart starts the span of a query, named after its SQL operation
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	info := &queryInfo{method: queryMethod(), operation: queryOperation(data.SQL), start: time.Now()}
+	ctx, _ = tracer.Start(ctx, "db "+info.operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.statement", data.SQL),
+			attribute.String("code.function", info.method),
+		),
+	)
+	return context.WithValue(ctx, queryKey{}, info)
+}
+
+// TraceQueryEnd ends the span of a query, recording its error if it failed
+func (t queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	if info, ok := ctx.Value(queryKey{}).(*queryInfo); ok {
+		duration := time.Since(info.start)
+		if observe := QueryObserver; observe != nil {
+			observe(ctx, info.method, info.operation, duration, data.Err)
+		}
+		if t.slowQueryThreshold > 0 && duration > t.slowQueryThreshold {
+			logSlowQuery(ctx, info, duration, data)
+		}
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	} else {
+		span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	span.End()
+}
+
+// logSlowQuery logs a query that exceeded the slow query threshold. The statement and its arguments are
+// left out, as they may contain user data; the method and operation identify the query.
+func logSlowQuery(ctx context.Context, info *queryInfo, duration time.Duration, data pgx.TraceQueryEndData) {
+	attrs := []slog.Attr{
+		slog.String("method", info.method),
+		slog.String("operation", info.operation),
+		slog.Float64("duration_ms", float64(duration.Microseconds())/1000),
+	}
+	if data.Err != nil {
+		attrs = append(attrs, slog.Bool("failed", true))
+	} else {
+		attrs = append(attrs, slog.Int64("rows", data.CommandTag.RowsAffected()))
+	}
+	slog.LogAttrs(ctx, slog.LevelWarn, "Slow database query", attrs...)
+}
+
+// queryOperation returns the first keyword of a query, such as SELECT
+func queryOperation(sql string) string {
+	if fields := strings.Fields(sql); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "query"
+}
+
+// queryMethod returns the name of the PostgreSQL method that is making a query, found in the stack of
+// its caller, or "other" for queries made elsewhere, such as by migrations
+func queryMethod() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if method, ok := strings.CutPrefix(frame.Function, postgresMethodPrefix); ok {
+			// Closures appear as Method.func1
+			method, _, _ = strings.Cut(method, ".")
+			return method
+		}
+		if !more {
+			return "other"
+		}
+	}
+}
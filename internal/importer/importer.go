@@ -5,11 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"strings"
 
+	"github.com/modelcontextprotocol/registry/internal/logging"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -43,7 +44,7 @@ func (s *Service) ImportFromPath(ctx context.Context, path string) error {
 		_, err := s.registry.CreateServer(ctx, server)
 		if err != nil {
 			failedCreations = append(failedCreations, fmt.Sprintf("%s: %v", server.Name, err))
-			log.Printf("Failed to create server %s: %v", server.Name, err)
+			slog.ErrorContext(ctx, "Failed to create server", logging.ServerNameKey, server.Name, logging.Err(err))
 		} else {
 			successfullyCreated = append(successfullyCreated, server.Name)
 		}
@@ -51,13 +52,12 @@ func (s *Service) ImportFromPath(ctx context.Context, path string) error {
 
 	// Report import results after actual creation attempts
 	if len(failedCreations) > 0 {
-		log.Printf("Import completed with errors: %d servers created successfully, %d servers failed",
-			len(successfullyCreated), len(failedCreations))
-		log.Printf("Failed servers: %v", failedCreations)
+		slog.ErrorContext(ctx, "Import completed with errors",
+			"created", len(successfullyCreated), "failed", len(failedCreations), "failures", failedCreations)
 		return fmt.Errorf("failed to import %d servers", len(failedCreations))
 	}
 
-	log.Printf("Import completed successfully: all %d servers created", len(successfullyCreated))
+	slog.InfoContext(ctx, "Import completed successfully", "created", len(successfullyCreated))
 	return nil
 }
 
@@ -96,14 +96,12 @@ func readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error)
 	// Validate servers and collect warnings instead of failing the whole batch
 	var validRecords []*apiv0.ServerJSON
 	var invalidServers []string
-	var validationFailures []string
 
 	for _, response := range serverResponses {
 		if err := validators.ValidateServerJSON(&response); err != nil {
 			// Log warning and track invalid server instead of failing
 			invalidServers = append(invalidServers, response.Name)
-			validationFailures = append(validationFailures, fmt.Sprintf("Server '%s': %v", response.Name, err))
-			log.Printf("Warning: Skipping invalid server '%s': %v", response.Name, err)
+			slog.WarnContext(ctx, "Skipping invalid server", logging.ServerNameKey, response.Name, logging.Err(err))
 			continue
 		}
 
@@ -113,13 +111,9 @@ func readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error)
 
 	// Print summary of validation results
 	if len(invalidServers) > 0 {
-		log.Printf("Validation summary: %d servers passed validation, %d invalid servers skipped", len(validRecords), len(invalidServers))
-		log.Printf("Invalid servers: %v", invalidServers)
-		for _, failure := range validationFailures {
-			log.Printf("  - %s", failure)
-		}
+		slog.WarnContext(ctx, "Validation summary", "valid", len(validRecords), "invalid", len(invalidServers), "invalid_servers", invalidServers)
 	} else {
-		log.Printf("Validation summary: All %d servers passed validation", len(validRecords))
+		slog.InfoContext(ctx, "Validation summary: all servers passed validation", "valid", len(validRecords))
 	}
 
 	return validRecords, nil
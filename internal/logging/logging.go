@@ -0,0 +1,124 @@
+// Package logging configures the registry's structured logger and carries request fields, such as the
+// request ID and the caller, in contexts so every log line of a request includes them
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Log formats
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Fields carried in request contexts
+const (
+	RequestIDKey  = "request_id"
+	ActorKey      = "actor"
+	ServerNameKey = "server_name"
+	TraceIDKey    = "trace_id"
+)
+
+type attrsKey struct{}
+
+// With returns a copy of ctx whose log records include args, given as key-value pairs or slog.Attrs
+// as for slog.Logger.With. Fields already in ctx are kept, and replaced if given again.
+func With(ctx context.Context, args ...any) context.Context {
+	attrs := append([]slog.Attr(nil), attrsFromContext(ctx)...)
+	for _, attr := range argsToAttrs(args) {
+		attrs = setAttr(attrs, attr)
+	}
+	return context.WithValue(ctx, attrsKey{}, attrs)
+}
+
+// Attrs returns the fields carried in ctx
+func Attrs(ctx context.Context) []slog.Attr {
+	return append([]slog.Attr(nil), attrsFromContext(ctx)...)
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// setAttr replaces the attr of the same key in attrs, or else appends attr
+func setAttr(attrs []slog.Attr, attr slog.Attr) []slog.Attr {
+	for i := range attrs {
+		if attrs[i].Key == attr.Key {
+			attrs[i] = attr
+			return attrs
+		}
+	}
+	return append(attrs, attr)
+}
+
+// argsToAttrs converts key-value pairs and slog.Attrs to slog.Attrs the way slog.Logger.With does
+func argsToAttrs(args []any) []slog.Attr {
+	record := slog.Record{}
+	record.Add(args...)
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	return attrs
+}
+
+// contextHandler adds the fields carried in the context to each record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		record.AddAttrs(attrsFromContext(ctx)...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// New creates a logger writing to w in format, "json" for log collection in production or "text" for
+// reading in development, that includes the request fields of the contexts it is given
+func New(w io.Writer, format, level string, opts ...Option) (*slog.Logger, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	options := &slog.HandlerOptions{Level: lvl}
+
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q: must be %s or %s", format, FormatJSON, FormatText)
+	}
+	if o.throttleWindow > 0 {
+		handler = newThrottleHandler(handler, o.throttleWindow)
+	}
+	return slog.New(contextHandler{handler}), nil
+}
+
+// Err returns the attribute logging an error
+func Err(err error) slog.Attr {
+	return slog.Any("error", err)
+}
@@ -0,0 +1,103 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+func TestNew_JSONIncludesContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, logging.FormatJSON, "info")
+	require.NoError(t, err)
+
+	ctx := logging.With(context.Background(), logging.RequestIDKey, "req-1", logging.ActorKey, "github-at:octocat")
+	ctx = logging.With(ctx, logging.ServerNameKey, "io.github.octocat/server", logging.ActorKey, "github-at:hubot")
+	logger.ErrorContext(ctx, "Publish failed", logging.Err(errors.New("boom")))
+	logger.DebugContext(ctx, "Not logged below the level")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record), buf.String())
+	assert.Equal(t, "Publish failed", record["msg"])
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, "boom", record["error"])
+	assert.Equal(t, "req-1", record[logging.RequestIDKey])
+	assert.Equal(t, "github-at:hubot", record[logging.ActorKey], "later fields replace earlier ones")
+	assert.Equal(t, "io.github.octocat/server", record[logging.ServerNameKey])
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+}
+
+func TestNew_Text(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "TEXT", "debug")
+	require.NoError(t, err)
+
+	logger.With("component", "importer").DebugContext(logging.With(context.Background(), logging.RequestIDKey, "req-2"), "Importing")
+	assert.Contains(t, buf.String(), "level=DEBUG msg=Importing component=importer request_id=req-2")
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, err := logging.New(&bytes.Buffer{}, "xml", "info")
+	assert.ErrorContains(t, err, "invalid log format")
+
+	_, err = logging.New(&bytes.Buffer{}, logging.FormatJSON, "loud")
+	assert.ErrorContains(t, err, "invalid log level")
+}
+
+func TestWith_DoesNotModifyParent(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, logging.FormatJSON, "info")
+	require.NoError(t, err)
+
+	parent := logging.With(context.Background(), logging.RequestIDKey, "req-3")
+	_ = logging.With(parent, slog.String(logging.ServerNameKey, "com.example/server"))
+	logger.InfoContext(parent, "Parent")
+
+	assert.NotContains(t, buf.String(), logging.ServerNameKey)
+}
+
+func TestWithThrottle(t *testing.T) {
+	var buf bytes.Buffer
+	window := 200 * time.Millisecond
+	logger, err := logging.New(&buf, logging.FormatJSON, "info", logging.WithThrottle(window))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for i := range 5 {
+		logger.With("registry", "docker-hub").ErrorContext(ctx, "Package validation failed", "attempt", i)
+		logger.InfoContext(ctx, "Info records are not throttled")
+	}
+	logger.WarnContext(ctx, "Package validation failed", "attempt", "warn")
+	logger.ErrorContext(ctx, "Another error")
+
+	time.Sleep(window)
+	logger.ErrorContext(ctx, "Package validation failed", "attempt", 5)
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record), line)
+		records = append(records, record)
+	}
+	require.Len(t, records, 9)
+	assert.Equal(t, "Package validation failed", records[0]["msg"])
+	assert.Equal(t, float64(0), records[0]["attempt"])
+	assert.NotContains(t, records[0], logging.SuppressedKey)
+	for _, record := range records[1:6] {
+		assert.Equal(t, "Info records are not throttled", record["msg"])
+	}
+	assert.Equal(t, "WARN", records[6]["level"], "levels are throttled separately")
+	assert.Equal(t, "Another error", records[7]["msg"])
+	assert.Equal(t, float64(5), records[8]["attempt"])
+	assert.Equal(t, float64(4), records[8][logging.SuppressedKey])
+}
@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// SuppressedKey is the field counting the identical records dropped before a throttled record
+const SuppressedKey = "suppressed"
+
+// maxThrottledMessages bounds how many distinct messages the throttle remembers before forgetting
+// those whose window has ended
+const maxThrottledMessages = 10000
+
+// Option configures optional behaviour of the logger
+type Option func(*options)
+
+type options struct {
+	throttleWindow time.Duration
+}
+
+// WithThrottle logs warnings and errors with the same level and message at most once per window, so an
+// outage of a service the registry calls does not flood the logs with identical lines. The first record
+// logged after a window includes the number of records dropped in it as the suppressed field. Zero
+// disables throttling.
+func WithThrottle(window time.Duration) Option {
+	return func(o *options) {
+		o.throttleWindow = window
+	}
+}
+
+type throttleKey struct {
+	level   slog.Level
+	message string
+}
+
+type throttleEntry struct {
+	start      time.Time
+	suppressed int
+}
+
+// throttle is shared by a throttleHandler and the handlers derived from it with WithAttrs and WithGroup
+type throttle struct {
+	mu      sync.Mutex
+	window  time.Duration
+	entries map[throttleKey]*throttleEntry
+}
+
+// allow reports whether a record of key logged at now is written, and how many records of key were
+// suppressed before it
+func (t *throttle) allow(key throttleKey, now time.Time) (bool, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.entries[key]
+	if ok && now.Sub(entry.start) < t.window {
+		entry.suppressed++
+		return false, 0
+	}
+	if !ok {
+		if len(t.entries) >= maxThrottledMessages {
+			t.forgetExpired(now)
+		}
+		entry = &throttleEntry{}
+		t.entries[key] = entry
+	}
+	suppressed := entry.suppressed
+	*entry = throttleEntry{start: now}
+	return true, suppressed
+}
+
+func (t *throttle) forgetExpired(now time.Time) {
+	for key, entry := range t.entries {
+		if now.Sub(entry.start) >= t.window {
+			delete(t.entries, key)
+		}
+	}
+}
+
+// throttleHandler drops warnings and errors repeating a record written within the throttle window
+type throttleHandler struct {
+	slog.Handler
+	throttle *throttle
+}
+
+func newThrottleHandler(handler slog.Handler, window time.Duration) throttleHandler {
+	return throttleHandler{
+		Handler:  handler,
+		throttle: &throttle{window: window, entries: make(map[throttleKey]*throttleEntry)},
+	}
+}
+
+func (h throttleHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level < slog.LevelWarn {
+		return h.Handler.Handle(ctx, record)
+	}
+
+	now := record.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	allowed, suppressed := h.throttle.allow(throttleKey{level: record.Level, message: record.Message}, now)
+	if !allowed {
+		return nil
+	}
+	if suppressed > 0 {
+		record = record.Clone()
+		record.AddAttrs(slog.Int(SuppressedKey, suppressed))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h throttleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return throttleHandler{h.Handler.WithAttrs(attrs), h.throttle}
+}
+
+func (h throttleHandler) WithGroup(name string) slog.Handler {
+	return throttleHandler{h.Handler.WithGroup(name), h.throttle}
+}
@@ -0,0 +1,127 @@
+// Package policy evaluates the operator-defined CEL policies that publishes must satisfy
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// publishClaims is what publish policy expressions can refer to as the claims variable
+type publishClaims struct {
+	AuthMethod  auth.Method       `json:"auth_method"`
+	Subject     string            `json:"subject"`
+	Permissions []auth.Permission `json:"permissions"`
+}
+
+// compiledPolicy is a publish policy ready to be evaluated
+type compiledPolicy struct {
+	config  config.PublishPolicy
+	program cel.Program
+}
+
+// PublishPolicies are the policies configured in PUBLISH_POLICIES. Expressions see two variables:
+// claims, with the auth_method, subject and permissions of the publishing token, and server, the
+// server.json being published with the same field names. The CEL string extensions are available.
+type PublishPolicies struct {
+	policies []compiledPolicy
+}
+
+// NewPublishPolicies compiles the publish policies of cfg, failing if any expression is invalid or
+// does not evaluate to a boolean
+func NewPublishPolicies(cfg *config.Config) (*PublishPolicies, error) {
+	policyConfigs, err := cfg.PublishPolicyConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable("claims", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("server", cel.MapType(cel.StringType, cel.DynType)),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy environment: %w", err)
+	}
+
+	policies := &PublishPolicies{}
+	for _, policyConfig := range policyConfigs {
+		ast, issues := env.Compile(policyConfig.Expression)
+		if issues.Err() != nil {
+			return nil, fmt.Errorf("invalid publish policy %s: %w", policyConfig.Name, issues.Err())
+		}
+		if outputType := ast.OutputType(); !outputType.IsExactType(cel.BoolType) && !outputType.IsExactType(cel.DynType) {
+			return nil, fmt.Errorf("invalid publish policy %s: expression must evaluate to a bool, not %s", policyConfig.Name, outputType)
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("invalid publish policy %s: %w", policyConfig.Name, err)
+		}
+		policies.policies = append(policies.policies, compiledPolicy{config: policyConfig, program: program})
+	}
+
+	return policies, nil
+}
+
+// Violations evaluates every policy against a publish and explains each one that does not allow it. A
+// policy that fails to evaluate, for instance by reading a field the server does not have without checking
+// for it with has(), does not allow the publish either.
+func (p *PublishPolicies) Violations(claims *auth.JWTClaims, server *apiv0.ServerJSON) ([]string, error) {
+	if len(p.policies) == 0 {
+		return nil, nil
+	}
+
+	claimsValue, err := toMap(publishClaims{
+		AuthMethod:  claims.AuthMethod,
+		Subject:     claims.AuthMethodSubject,
+		Permissions: claims.Permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	serverValue, err := toMap(server)
+	if err != nil {
+		return nil, err
+	}
+	activation := map[string]any{"claims": claimsValue, "server": serverValue}
+
+	var violations []string
+	for _, policy := range p.policies {
+		out, _, err := policy.program.Eval(activation)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("Publish policy %s could not be evaluated for this server: %v", policy.config.Name, err))
+			continue
+		}
+		if allowed, ok := out.Value().(bool); !ok || !allowed {
+			violations = append(violations, violationMessage(policy.config))
+		}
+	}
+	return violations, nil
+}
+
+func violationMessage(policy config.PublishPolicy) string {
+	message := "Publish policy " + policy.Name + " does not allow this server"
+	if policy.Message != "" {
+		message += ": " + policy.Message
+	}
+	return message
+}
+
+// toMap converts a value to the map of its JSON representation, so expressions use the JSON field names
+func toMap(value any) (map[string]any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare policy input: %w", err)
+	}
+	var result map[string]any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to prepare policy input: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/policy"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestPublishPolicies_Violations(t *testing.T) {
+	cfg := &config.Config{PublishPolicies: `[
+		{
+			"name": "bank-images-pinned",
+			"expression": "!server.name.startsWith('com.bank.') || (has(server.packages) && server.packages.all(p, p.registryType == 'oci' && p.identifier.contains('@sha256:')))",
+			"message": "servers in com.bank.* must be published as OCI images pinned by digest"
+		},
+		{
+			"name": "no-anonymous-remotes",
+			"expression": "claims.auth_method != 'none' || !has(server.remotes)"
+		}
+	]`}
+	policies, err := policy.NewPublishPolicies(cfg)
+	require.NoError(t, err)
+
+	github := &auth.JWTClaims{AuthMethod: auth.MethodGitHubAT, AuthMethodSubject: "alice"}
+	ociPackage := func(identifier string) model.Package {
+		return model.Package{RegistryType: model.RegistryTypeOCI, Identifier: identifier}
+	}
+
+	tests := []struct {
+		name       string
+		claims     *auth.JWTClaims
+		server     apiv0.ServerJSON
+		violations []string
+	}{
+		{
+			name:   "pinned image in a restricted namespace",
+			claims: github,
+			server: apiv0.ServerJSON{Name: "com.bank.payments/mcp", Packages: []model.Package{ociPackage("ghcr.io/bank/mcp@sha256:abc123")}},
+		},
+		{
+			name:       "unpinned image in a restricted namespace",
+			claims:     github,
+			server:     apiv0.ServerJSON{Name: "com.bank.payments/mcp", Packages: []model.Package{ociPackage("ghcr.io/bank/mcp:latest")}},
+			violations: []string{"Publish policy bank-images-pinned does not allow this server: servers in com.bank.* must be published as OCI images pinned by digest"},
+		},
+		{
+			name:       "no packages in a restricted namespace",
+			claims:     github,
+			server:     apiv0.ServerJSON{Name: "com.bank.payments/mcp"},
+			violations: []string{"Publish policy bank-images-pinned does not allow this server: servers in com.bank.* must be published as OCI images pinned by digest"},
+		},
+		{
+			name:   "other namespaces are unaffected",
+			claims: github,
+			server: apiv0.ServerJSON{Name: "io.github.alice/weather", Packages: []model.Package{{RegistryType: model.RegistryTypeNPM, Identifier: "weather"}}},
+		},
+		{
+			name:       "policies over claims",
+			claims:     &auth.JWTClaims{AuthMethod: auth.MethodNone},
+			server:     apiv0.ServerJSON{Name: "io.modelcontextprotocol.anonymous/test", Remotes: []model.Transport{{Type: "streamable-http", URL: "https://example.com/mcp"}}},
+			violations: []string{"Publish policy no-anonymous-remotes does not allow this server"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := policies.Violations(tt.claims, &tt.server)
+			require.NoError(t, err)
+			assert.Equal(t, tt.violations, violations)
+		})
+	}
+}
+
+func TestPublishPolicies_EvaluationErrorsDeny(t *testing.T) {
+	policies, err := policy.NewPublishPolicies(&config.Config{PublishPolicies: `[
+		{"name": "packages-from-npm", "expression": "server.packages.all(p, p.registryType == 'npm')"}
+	]`})
+	require.NoError(t, err)
+
+	violations, err := policies.Violations(&auth.JWTClaims{}, &apiv0.ServerJSON{Name: "io.github.alice/remote-only"})
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0], "Publish policy packages-from-npm could not be evaluated")
+}
+
+func TestNewPublishPolicies_Invalid(t *testing.T) {
+	for name, policies := range map[string]string{
+		"syntax error":       `[{"name": "broken", "expression": "server.name =="}]`,
+		"unknown variable":   `[{"name": "typo", "expression": "sever.name == 'x'"}]`,
+		"not a boolean":      `[{"name": "string", "expression": "'allowed'"}]`,
+		"missing expression": `[{"name": "empty"}]`,
+		"duplicate name":     `[{"name": "a", "expression": "true"}, {"name": "a", "expression": "false"}]`,
+		"invalid JSON":       `{`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := policy.NewPublishPolicies(&config.Config{PublishPolicies: policies})
+			assert.Error(t, err)
+		})
+	}
+}
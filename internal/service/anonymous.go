@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// DeleteExpiredAnonymousServers deletes the servers in the anonymous namespaces that have not been published
+// to within ANONYMOUS_SERVER_TTL, recording each version in the change feed. It returns how many servers
+// were deleted, and does nothing unless anonymous auth is enabled with a TTL.
+func (s *registryServiceImpl) DeleteExpiredAnonymousServers(ctx context.Context) (int, error) {
+	if !s.cfg.EnableAnonymousAuth || s.cfg.AnonymousServerTTL <= 0 {
+		return 0, nil
+	}
+
+	patterns, err := s.cfg.AnonymousNamespacePatterns()
+	if err != nil {
+		return 0, err
+	}
+	namespaces := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		namespaces[i] = strings.TrimSuffix(pattern, "/*")
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (int, error) {
+		deleted, err := s.db.DeleteStaleServers(ctx, tx, namespaces, time.Now().Add(-s.cfg.AnonymousServerTTL))
+		if err != nil {
+			return 0, err
+		}
+		if len(deleted) == 0 {
+			return 0, nil
+		}
+
+		var changes []*database.ServerChange
+		for _, name := range slices.Sorted(maps.Keys(deleted)) {
+			for _, version := range deleted[name] {
+				changes = append(changes, &database.ServerChange{Type: database.ChangeTypeDelete, ServerName: name, Version: version})
+			}
+		}
+		if err := s.recordServerChanges(ctx, tx, changes); err != nil {
+			return 0, err
+		}
+
+		return len(deleted), nil
+	})
+}
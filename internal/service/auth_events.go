@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// RecordAuthEvent records a login, token exchange or permission denial in the auth event log
+func (s *registryServiceImpl) RecordAuthEvent(ctx context.Context, event *database.AuthEvent) error {
+	return s.db.RecordAuthEvent(ctx, nil, event)
+}
+
+// ListAuthEvents retrieves up to limit auth events matching the filter, newest first
+func (s *registryServiceImpl) ListAuthEvents(ctx context.Context, filter *database.AuthEventFilter, limit int) ([]*database.AuthEvent, error) {
+	return s.db.ListAuthEvents(ctx, nil, filter, limit)
+}
+
+// ListAuditEvents retrieves up to limit audit events matching the filter, oldest first
+func (s *registryServiceImpl) ListAuditEvents(ctx context.Context, filter *database.AuditEventFilter, limit int) ([]*database.AuditEvent, error) {
+	return s.db.ListAuditEvents(ctx, nil, filter, limit)
+}
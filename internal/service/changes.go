@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// ListServerChanges retrieves up to limit changes recorded after the given id, oldest first
+func (s *registryServiceImpl) ListServerChanges(ctx context.Context, afterID int64, limit int) ([]*database.ServerChange, error) {
+	return s.db.ListServerChanges(ctx, nil, afterID, limit)
+}
+
+// GetLatestServerChangeID returns the id of the most recent change, or zero if there are none
+func (s *registryServiceImpl) GetLatestServerChangeID(ctx context.Context) (int64, error) {
+	return s.db.GetLatestServerChangeID(ctx, nil)
+}
+
+// recordServerChange adds a change to a server version to the change feed
+func (s *registryServiceImpl) recordServerChange(ctx context.Context, tx pgx.Tx, changeType, serverName, version string) error {
+	return s.recordServerChanges(ctx, tx, []*database.ServerChange{
+		{Type: changeType, ServerName: serverName, Version: version},
+	})
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+// Audit log actions for namespace delegations
+const (
+	AuditActionDelegationGranted = "delegation.granted"
+	AuditActionDelegationRevoked = "delegation.revoked"
+)
+
+// CreateNamespaceDelegation gives delegate publish rights for the servers of namespace matching
+// permissionPattern until expiresAt. grantedWith is the granting token's permission for the whole namespace.
+func (s *registryServiceImpl) CreateNamespaceDelegation(ctx context.Context, namespace, permissionPattern, delegate, grantedWith, actor string, expiresAt time.Time) (*database.NamespaceDelegation, error) {
+	if err := validators.ValidateNamespacePattern(namespace, permissionPattern); err != nil {
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
+	}
+	if delegate == actor {
+		return nil, fmt.Errorf("%w: you cannot delegate to yourself", database.ErrInvalidInput)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.NamespaceDelegation, error) {
+		delegation := &database.NamespaceDelegation{
+			Namespace:         namespace,
+			PermissionPattern: permissionPattern,
+			Delegate:          delegate,
+			GrantedBy:         actor,
+			GrantedWith:       grantedWith,
+			ExpiresAt:         expiresAt,
+		}
+		if err := s.db.CreateNamespaceDelegation(ctx, tx, delegation); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionDelegationGranted,
+			Actor:    actor,
+			Resource: namespace,
+			Details: map[string]string{
+				"delegationId":      strconv.FormatInt(delegation.ID, 10),
+				"delegate":          delegate,
+				"permissionPattern": permissionPattern,
+				"expiresAt":         expiresAt.UTC().Format(time.RFC3339),
+			},
+		}); err != nil {
+			return nil, err
+		}
+
+		return delegation, nil
+	})
+}
+
+// GetNamespaceDelegation retrieves a namespace delegation, including revoked and expired ones
+func (s *registryServiceImpl) GetNamespaceDelegation(ctx context.Context, id int64) (*database.NamespaceDelegation, error) {
+	return s.db.GetNamespaceDelegation(ctx, nil, id)
+}
+
+// ListNamespaceDelegations retrieves the active delegations of a namespace, newest first
+func (s *registryServiceImpl) ListNamespaceDelegations(ctx context.Context, namespace string) ([]*database.NamespaceDelegation, error) {
+	return s.db.ListNamespaceDelegations(ctx, nil, namespace)
+}
+
+// ListDelegateDelegations retrieves the active delegations given to an account, newest first
+func (s *registryServiceImpl) ListDelegateDelegations(ctx context.Context, delegate string) ([]*database.NamespaceDelegation, error) {
+	return s.db.ListDelegateDelegations(ctx, nil, delegate)
+}
+
+// RevokeNamespaceDelegation revokes a namespace delegation, after which its delegate can no longer publish with it
+func (s *registryServiceImpl) RevokeNamespaceDelegation(ctx context.Context, id int64, actor string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		delegation, err := s.db.GetNamespaceDelegation(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.db.RevokeNamespaceDelegation(ctx, tx, id); err != nil {
+			return err
+		}
+
+		return s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionDelegationRevoked,
+			Actor:    actor,
+			Resource: delegation.Namespace,
+			Details: map[string]string{
+				"delegationId": strconv.FormatInt(delegation.ID, 10),
+				"delegate":     delegation.Delegate,
+			},
+		})
+	})
+}
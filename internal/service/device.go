@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+const (
+	// DeviceCodeTTL is how long a device login can wait to be approved and collected
+	DeviceCodeTTL = 10 * time.Minute
+
+	// DevicePollInterval is the minimum time between a device's checks on its login
+	DevicePollInterval = 5 * time.Second
+
+	// userCodeAlphabet leaves out vowels, to avoid spelling words, and characters that are easily confused
+	userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength   = 8
+)
+
+// Audit log actions for device logins
+const (
+	AuditActionDeviceApproved = "device.approved"
+	AuditActionDeviceDenied   = "device.denied"
+)
+
+// StartDeviceAuthorization creates a device login and returns it along with the device code, which
+// is not stored and is only known to the device
+func (s *registryServiceImpl) StartDeviceAuthorization(ctx context.Context) (*database.DeviceAuthorization, string, error) {
+	codeBytes := make([]byte, 32)
+	if _, err := rand.Read(codeBytes); err != nil {
+		return nil, "", fmt.Errorf("failed to generate device code: %w", err)
+	}
+	deviceCode := base64.RawURLEncoding.EncodeToString(codeBytes)
+
+	// User codes are short, so retry the rare collision with a code that is still in use
+	for range 3 {
+		userCode, err := generateUserCode()
+		if err != nil {
+			return nil, "", err
+		}
+
+		authorization := &database.DeviceAuthorization{
+			DeviceCodeHash: hashAPIToken(deviceCode),
+			UserCode:       userCode,
+			Status:         database.DeviceAuthorizationPending,
+			ExpiresAt:      time.Now().Add(DeviceCodeTTL),
+		}
+		err = s.db.CreateDeviceAuthorization(ctx, nil, authorization)
+		if errors.Is(err, database.ErrAlreadyExists) {
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return authorization, deviceCode, nil
+	}
+
+	return nil, "", fmt.Errorf("failed to generate a unique user code")
+}
+
+// ApproveDeviceAuthorization approves a pending device login with the identity and permissions of
+// the approving user, or denies it. Unknown, expired and already approved logins return ErrNotFound.
+func (s *registryServiceImpl) ApproveDeviceAuthorization(ctx context.Context, userCode string, claims *auth.JWTClaims, actor string, approve bool) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		authorization, err := s.db.GetDeviceAuthorizationByUserCode(ctx, tx, NormalizeUserCode(userCode))
+		if err != nil {
+			return err
+		}
+		if authorization.Status != database.DeviceAuthorizationPending || !time.Now().Before(authorization.ExpiresAt) {
+			return database.ErrNotFound
+		}
+
+		action := AuditActionDeviceDenied
+		authorization.Status = database.DeviceAuthorizationDenied
+		authorization.ApprovedBy = actor
+		if approve {
+			action = AuditActionDeviceApproved
+			authorization.Status = database.DeviceAuthorizationApproved
+			authorization.AuthMethod = claims.AuthMethod
+			authorization.Subject = claims.AuthMethodSubject
+			authorization.Permissions = claims.Permissions
+		}
+		if err := s.db.UpdateDeviceAuthorization(ctx, tx, authorization); err != nil {
+			return err
+		}
+
+		return s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   action,
+			Actor:    actor,
+			Resource: actor,
+			Details:  map[string]string{"userCode": authorization.UserCode},
+		})
+	})
+}
+
+// PollDeviceAuthorization checks on a device login for the device holding its device code. Once the
+// login has been approved it is returned and removed, so each approval can only be collected once.
+// Otherwise the errors are those of RFC 8628: ErrAuthorizationPending, ErrSlowDown, ErrAccessDenied
+// and ErrExpiredToken, or ErrNotFound for unknown device codes.
+func (s *registryServiceImpl) PollDeviceAuthorization(ctx context.Context, deviceCode string) (*database.DeviceAuthorization, error) {
+	var result *database.DeviceAuthorization
+	var pollErr error
+
+	err := s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		authorization, err := s.db.GetDeviceAuthorizationByDeviceCode(ctx, tx, hashAPIToken(deviceCode))
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		switch {
+		case !now.Before(authorization.ExpiresAt):
+			pollErr = database.ErrExpiredToken
+			return s.db.DeleteDeviceAuthorization(ctx, tx, authorization.DeviceCodeHash)
+		case authorization.Status == database.DeviceAuthorizationDenied:
+			pollErr = database.ErrAccessDenied
+			return s.db.DeleteDeviceAuthorization(ctx, tx, authorization.DeviceCodeHash)
+		case authorization.Status == database.DeviceAuthorizationApproved:
+			result = authorization
+			return s.db.DeleteDeviceAuthorization(ctx, tx, authorization.DeviceCodeHash)
+		}
+
+		// Allow a little jitter so clients polling at exactly the interval are not told to slow down
+		if authorization.LastPolledAt != nil && now.Sub(*authorization.LastPolledAt) < DevicePollInterval-time.Second {
+			pollErr = database.ErrSlowDown
+		} else {
+			pollErr = database.ErrAuthorizationPending
+		}
+		authorization.LastPolledAt = &now
+		return s.db.UpdateDeviceAuthorization(ctx, tx, authorization)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pollErr != nil {
+		return nil, pollErr
+	}
+
+	return result, nil
+}
+
+// NormalizeUserCode converts a user code as typed by a user, in any case and with or without the
+// separator, to the form it is stored in
+func NormalizeUserCode(userCode string) string {
+	userCode = strings.ToUpper(userCode)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, userCode)
+}
+
+// FormatUserCode formats a stored user code for display, e.g. BCDF-GHJK
+func FormatUserCode(userCode string) string {
+	if len(userCode) != userCodeLength {
+		return userCode
+	}
+	return userCode[:userCodeLength/2] + "-" + userCode[userCodeLength/2:]
+}
+
+func generateUserCode() (string, error) {
+	randomBytes := make([]byte, userCodeLength)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to generate user code: %w", err)
+	}
+
+	code := make([]byte, userCodeLength)
+	for i, b := range randomBytes {
+		// 256 is not a multiple of the alphabet size; the bias this introduces is negligible for a
+		// code that expires in minutes and can only be guessed by a logged-in user
+		code[i] = userCodeAlphabet[int(b)%len(userCodeAlphabet)]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// GetServerIcon retrieves the icon of a server, including its data
+func (s *registryServiceImpl) GetServerIcon(ctx context.Context, serverName string) (*database.ServerIcon, error) {
+	return s.db.GetServerIcon(ctx, nil, serverName)
+}
+
+// SetServerIcon validates and stores the icon of an existing server, returning how server responses
+// reference it. SVG icons are sanitized first.
+func (s *registryServiceImpl) SetServerIcon(ctx context.Context, serverName string, data []byte, mimeType, actor string) (*model.Icon, error) {
+	data, info, err := validators.ValidateIcon(data, mimeType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*model.Icon, error) {
+		if _, err := s.db.GetServerByName(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		checksum := sha256.Sum256(data)
+		icon := &database.ServerIcon{
+			ServerName: serverName,
+			MimeType:   info.MimeType,
+			Data:       data,
+			Width:      info.Width,
+			Height:     info.Height,
+			Checksum:   hex.EncodeToString(checksum[:]),
+			UpdatedBy:  actor,
+		}
+		if err := s.db.SetServerIcon(ctx, tx, icon); err != nil {
+			return nil, err
+		}
+		if err := s.recordIconChanges(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+		return s.iconReference(icon), nil
+	})
+}
+
+// DeleteServerIcon removes the icon of a server
+func (s *registryServiceImpl) DeleteServerIcon(ctx context.Context, serverName string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.db.DeleteServerIcon(ctx, tx, serverName); err != nil {
+			return err
+		}
+		return s.recordIconChanges(ctx, tx, serverName)
+	})
+}
+
+// recordIconChanges records an icon change in the change feed as an update of every version of the server,
+// as every version references the icon. Replicas evict the server from their caches as they read it.
+func (s *registryServiceImpl) recordIconChanges(ctx context.Context, tx pgx.Tx, serverName string) error {
+	versions, err := s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*database.ServerChange, 0, len(versions))
+	for _, server := range versions {
+		changes = append(changes, &database.ServerChange{Type: database.ChangeTypeUpdate, ServerName: serverName, Version: server.Server.Version})
+	}
+	return s.recordServerChanges(ctx, tx, changes)
+}
+
+// attachIcons references the uploaded icon, if any, in the registry metadata of each server
+func (s *registryServiceImpl) attachIcons(ctx context.Context, servers []*apiv0.ServerResponse) error {
+	names := make([]string, 0, len(servers))
+	for _, server := range servers {
+		names = append(names, server.Server.Name)
+	}
+
+	icons, err := s.db.GetServerIconsByNames(ctx, nil, names)
+	if err != nil {
+		return err
+	}
+
+	for _, server := range servers {
+		if icon, ok := icons[server.Server.Name]; ok && server.Meta.Official != nil {
+			server.Meta.Official.Icon = s.iconReference(icon)
+		}
+	}
+	return nil
+}
+
+// iconReference describes where the registry serves an icon. The URL includes the checksum so
+// clients and CDNs can cache icons indefinitely.
+func (s *registryServiceImpl) iconReference(icon *database.ServerIcon) *model.Icon {
+	size := "any"
+	if icon.Width > 0 {
+		size = strconv.Itoa(icon.Width) + "x" + strconv.Itoa(icon.Height)
+	}
+	mimeType := icon.MimeType
+
+	return &model.Icon{
+		Src:      strings.TrimSuffix(s.cfg.PublicURL, "/") + "/v1/servers/" + url.PathEscape(icon.ServerName) + "/icon?v=" + icon.Checksum[:12],
+		MimeType: &mimeType,
+		Sizes:    []string{size},
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// IdempotencyKeyTTL is how long the response to a request with an idempotency key is replayed
+	IdempotencyKeyTTL = 24 * time.Hour
+
+	// idempotencyStaleAfter is when a key whose request never completed, e.g. because the replica
+	// handling it crashed, may be claimed again
+	idempotencyStaleAfter = 5 * time.Minute
+)
+
+// CreateServerIdempotent creates a new server version, signed if signature is set, unless the actor has
+// already done so with the same idempotency key, in which case the original response is returned and
+// replayed is true. The key is released if publishing fails, since a failed publish has no side effects
+// to protect.
+func (s *registryServiceImpl) CreateServerIdempotent(ctx context.Context, req *apiv0.ServerJSON, signature *apiv0.ServerSignature, actor, key string) (*apiv0.ServerResponse, bool, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal publish request: %w", err)
+	}
+	// The same server.json signed differently is a different request
+	if signature != nil {
+		body = append(body, signature.String()...)
+	}
+	sum := sha256.Sum256(body)
+	requestHash := hex.EncodeToString(sum[:])
+
+	claimed, err := s.db.ClaimIdempotencyKey(ctx, nil, &database.IdempotencyRecord{
+		Actor:       actor,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   time.Now().Add(IdempotencyKeyTTL),
+	}, idempotencyStaleAfter)
+	if err != nil {
+		return nil, false, err
+	}
+	if !claimed {
+		server, err := s.replayIdempotentResponse(ctx, actor, key, requestHash)
+		return server, err == nil, err
+	}
+
+	server, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		server, err := s.createServerInTransaction(ctx, tx, req, signature)
+		if err != nil {
+			return nil, err
+		}
+
+		// Store the response in the same transaction so a retry can never publish twice
+		response, err := json.Marshal(server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal publish response: %w", err)
+		}
+		if err := s.db.CompleteIdempotencyKey(ctx, tx, actor, key, response); err != nil {
+			return nil, err
+		}
+		return server, nil
+	})
+	if err != nil {
+		if deleteErr := s.db.DeleteIdempotencyKey(context.WithoutCancel(ctx), nil, actor, key); deleteErr != nil {
+			slog.ErrorContext(ctx, "Failed to release idempotency key after failed publish", logging.Err(deleteErr))
+		}
+		return nil, false, err
+	}
+
+	return server, false, nil
+}
+
+// replayIdempotentResponse returns the stored response for a key that is already held
+func (s *registryServiceImpl) replayIdempotentResponse(ctx context.Context, actor, key, requestHash string) (*apiv0.ServerResponse, error) {
+	record, err := s.db.GetIdempotencyKey(ctx, nil, actor, key)
+	if err != nil {
+		// The request holding the key failed and released it in the meantime
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, database.ErrIdempotencyKeyInUse
+		}
+		return nil, err
+	}
+
+	if record.RequestHash != requestHash {
+		return nil, database.ErrIdempotencyKeyMismatch
+	}
+	if record.Response == nil {
+		return nil, database.ErrIdempotencyKeyInUse
+	}
+
+	var server apiv0.ServerResponse
+	if err := json.Unmarshal(record.Response, &server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stored publish response: %w", err)
+	}
+	return &server, nil
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// AuditActionLoginLockedOut is the audit log action for locking out a client or identity after failed logins
+const AuditActionLoginLockedOut = "auth.locked_out"
+
+// RecordLoginLockout records that key, an "ip:" client or an identity such as "domain:example.com", was
+// locked out after failures at endpoint
+func (s *registryServiceImpl) RecordLoginLockout(ctx context.Context, key, endpoint string, failures int, lockedUntil time.Time) error {
+	return s.db.RecordAuditEvent(ctx, nil, &database.AuditEvent{
+		Action:   AuditActionLoginLockedOut,
+		Actor:    key,
+		Resource: endpoint,
+		Details: map[string]string{
+			"failures":     strconv.Itoa(failures),
+			"locked_until": lockedUntil.UTC().Format(time.RFC3339),
+		},
+	})
+}
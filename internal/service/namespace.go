@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+// Audit log actions for namespace reservations
+const (
+	AuditActionNamespaceReserved           = "namespace.reserved"
+	AuditActionNamespaceReleased           = "namespace.released"
+	AuditActionNamespaceDisputed           = "namespace.disputed"
+	AuditActionNamespaceDisputeUpheld      = "namespace.dispute.upheld"
+	AuditActionNamespaceReservationRevoked = "namespace.reservation.revoked"
+)
+
+// ReserveNamespace reserves a namespace for holders of permissionPattern. Reserving a namespace
+// again with the same pattern returns the existing reservation.
+func (s *registryServiceImpl) ReserveNamespace(ctx context.Context, namespace, permissionPattern, actor string) (*database.NamespaceReservation, error) {
+	if err := validators.ValidateNamespace(namespace); err != nil {
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.NamespaceReservation, error) {
+		existing, err := s.db.GetNamespaceReservation(ctx, tx, namespace)
+		switch {
+		case err == nil && existing.PermissionPattern == permissionPattern:
+			return existing, nil
+		case err == nil:
+			return nil, fmt.Errorf("%w: namespace %s is already reserved", database.ErrAlreadyExists, namespace)
+		case !errors.Is(err, database.ErrNotFound):
+			return nil, err
+		}
+
+		reservation := &database.NamespaceReservation{
+			Namespace:         namespace,
+			Owner:             actor,
+			PermissionPattern: permissionPattern,
+			Status:            database.ReservationStatusActive,
+		}
+		if err := s.db.CreateNamespaceReservation(ctx, tx, reservation); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionNamespaceReserved,
+			Actor:    actor,
+			Resource: namespace,
+			Details:  map[string]string{"permissionPattern": permissionPattern},
+		}); err != nil {
+			return nil, err
+		}
+
+		return reservation, nil
+	})
+}
+
+// GetNamespaceReservation retrieves the reservation of a namespace
+func (s *registryServiceImpl) GetNamespaceReservation(ctx context.Context, namespace string) (*database.NamespaceReservation, error) {
+	return s.db.GetNamespaceReservation(ctx, nil, namespace)
+}
+
+// ReleaseNamespace removes the reservation of a namespace
+func (s *registryServiceImpl) ReleaseNamespace(ctx context.Context, namespace, actor string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.db.DeleteNamespaceReservation(ctx, tx, namespace); err != nil {
+			return err
+		}
+
+		return s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionNamespaceReleased,
+			Actor:    actor,
+			Resource: namespace,
+		})
+	})
+}
+
+// DisputeNamespaceReservation flags a reservation for admin review. The reservation stays
+// in force until an admin resolves the dispute.
+func (s *registryServiceImpl) DisputeNamespaceReservation(ctx context.Context, namespace, reason, actor string) (*database.NamespaceReservation, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.NamespaceReservation, error) {
+		reservation, err := s.db.GetNamespaceReservation(ctx, tx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if reservation.Status == database.ReservationStatusDisputed {
+			return nil, fmt.Errorf("%w: the reservation of %s is already under review", database.ErrAlreadyExists, namespace)
+		}
+
+		reservation.Status = database.ReservationStatusDisputed
+		reservation.DisputeReason = reason
+		reservation.DisputedBy = actor
+		if err := s.db.UpdateNamespaceReservation(ctx, tx, reservation); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionNamespaceDisputed,
+			Actor:    actor,
+			Resource: namespace,
+			Details:  map[string]string{"reason": reason, "owner": reservation.Owner},
+		}); err != nil {
+			return nil, err
+		}
+
+		return reservation, nil
+	})
+}
+
+// ListNamespaceDisputes retrieves the reservations awaiting admin review, oldest first
+func (s *registryServiceImpl) ListNamespaceDisputes(ctx context.Context) ([]*database.NamespaceReservation, error) {
+	return s.db.ListNamespaceReservations(ctx, nil, database.ReservationStatusDisputed)
+}
+
+// ResolveNamespaceDispute settles a disputed reservation. Upholding it keeps the reservation
+// with its current owner; otherwise the reservation is revoked and the namespace freed.
+// The returned reservation is nil when revoked.
+func (s *registryServiceImpl) ResolveNamespaceDispute(ctx context.Context, namespace string, uphold bool, actor string) (*database.NamespaceReservation, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.NamespaceReservation, error) {
+		reservation, err := s.db.GetNamespaceReservation(ctx, tx, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if reservation.Status != database.ReservationStatusDisputed {
+			return nil, fmt.Errorf("%w: the reservation of %s is not disputed", database.ErrInvalidInput, namespace)
+		}
+
+		details := map[string]string{
+			"owner":      reservation.Owner,
+			"disputedBy": reservation.DisputedBy,
+			"reason":     reservation.DisputeReason,
+		}
+
+		action := AuditActionNamespaceReservationRevoked
+		if uphold {
+			action = AuditActionNamespaceDisputeUpheld
+			reservation.Status = database.ReservationStatusActive
+			reservation.DisputeReason = ""
+			reservation.DisputedBy = ""
+			if err := s.db.UpdateNamespaceReservation(ctx, tx, reservation); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := s.db.DeleteNamespaceReservation(ctx, tx, namespace); err != nil {
+				return nil, err
+			}
+			reservation = nil
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   action,
+			Actor:    actor,
+			Resource: namespace,
+			Details:  details,
+		}); err != nil {
+			return nil, err
+		}
+
+		return reservation, nil
+	})
+}
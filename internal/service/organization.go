@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+// Audit log actions for organizations
+const (
+	AuditActionOrganizationCreated       = "organization.created"
+	AuditActionOrganizationDeleted       = "organization.deleted"
+	AuditActionOrganizationMemberSet     = "organization.member.set"
+	AuditActionOrganizationMemberRemoved = "organization.member.removed"
+)
+
+// CreateOrganization creates an organization for a namespace whose members publish with
+// permissionPattern. The creator becomes its first admin.
+func (s *registryServiceImpl) CreateOrganization(ctx context.Context, namespace, permissionPattern, actor string) (*database.Organization, error) {
+	if err := validators.ValidateNamespace(namespace); err != nil {
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.Organization, error) {
+		organization := &database.Organization{
+			Namespace:         namespace,
+			PermissionPattern: permissionPattern,
+			CreatedBy:         actor,
+		}
+		if err := s.db.CreateOrganization(ctx, tx, organization); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.SetOrganizationMember(ctx, tx, &database.OrganizationMember{
+			Namespace: namespace,
+			Member:    actor,
+			Role:      database.OrganizationRoleAdmin,
+			AddedBy:   actor,
+		}); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionOrganizationCreated,
+			Actor:    actor,
+			Resource: namespace,
+			Details:  map[string]string{"permissionPattern": permissionPattern},
+		}); err != nil {
+			return nil, err
+		}
+
+		return organization, nil
+	})
+}
+
+// GetOrganization retrieves the organization of a namespace
+func (s *registryServiceImpl) GetOrganization(ctx context.Context, namespace string) (*database.Organization, error) {
+	return s.db.GetOrganization(ctx, nil, namespace)
+}
+
+// DeleteOrganization removes an organization, after which its members can no longer publish through it
+func (s *registryServiceImpl) DeleteOrganization(ctx context.Context, namespace, actor string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.db.DeleteOrganization(ctx, tx, namespace); err != nil {
+			return err
+		}
+
+		return s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionOrganizationDeleted,
+			Actor:    actor,
+			Resource: namespace,
+		})
+	})
+}
+
+// ListOrganizationMembers retrieves the members of an organization, admins first
+func (s *registryServiceImpl) ListOrganizationMembers(ctx context.Context, namespace string) ([]*database.OrganizationMember, error) {
+	return s.db.ListOrganizationMembers(ctx, nil, namespace)
+}
+
+// GetOrganizationMember retrieves a user's membership of an organization
+func (s *registryServiceImpl) GetOrganizationMember(ctx context.Context, namespace, member string) (*database.OrganizationMember, error) {
+	return s.db.GetOrganizationMember(ctx, nil, namespace, member)
+}
+
+// SetOrganizationMember adds a member to an organization or changes their role. The last admin
+// cannot be made a plain member, so the organization always has someone to manage it.
+func (s *registryServiceImpl) SetOrganizationMember(ctx context.Context, namespace, member, role, actor string) (*database.OrganizationMember, error) {
+	if role != database.OrganizationRoleAdmin && role != database.OrganizationRoleMember {
+		return nil, fmt.Errorf("%w: unknown role %q", database.ErrInvalidInput, role)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.OrganizationMember, error) {
+		if role != database.OrganizationRoleAdmin {
+			if err := s.ensureOtherAdmin(ctx, tx, namespace, member); err != nil {
+				return nil, err
+			}
+		}
+
+		membership := &database.OrganizationMember{
+			Namespace: namespace,
+			Member:    member,
+			Role:      role,
+			AddedBy:   actor,
+		}
+		if err := s.db.SetOrganizationMember(ctx, tx, membership); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionOrganizationMemberSet,
+			Actor:    actor,
+			Resource: namespace,
+			Details:  map[string]string{"member": member, "role": role},
+		}); err != nil {
+			return nil, err
+		}
+
+		return membership, nil
+	})
+}
+
+// RemoveOrganizationMember removes a member from an organization. The last admin cannot be removed;
+// delete the organization instead.
+func (s *registryServiceImpl) RemoveOrganizationMember(ctx context.Context, namespace, member, actor string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.ensureOtherAdmin(ctx, tx, namespace, member); err != nil {
+			return err
+		}
+		if err := s.db.DeleteOrganizationMember(ctx, tx, namespace, member); err != nil {
+			return err
+		}
+
+		return s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionOrganizationMemberRemoved,
+			Actor:    actor,
+			Resource: namespace,
+			Details:  map[string]string{"member": member},
+		})
+	})
+}
+
+// ListMemberOrganizations retrieves the organizations a user is a member of
+func (s *registryServiceImpl) ListMemberOrganizations(ctx context.Context, member string) ([]*database.Organization, error) {
+	return s.db.ListMemberOrganizations(ctx, nil, member)
+}
+
+// ensureOtherAdmin fails with ErrInvalidInput if member is the only admin of the organization
+func (s *registryServiceImpl) ensureOtherAdmin(ctx context.Context, tx pgx.Tx, namespace, member string) error {
+	members, err := s.db.ListOrganizationMembers(ctx, tx, namespace)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if m.Role == database.OrganizationRoleAdmin && m.Member != member {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: an organization needs at least one admin; add another admin first or delete the organization", database.ErrInvalidInput)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/distribution/reference"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// LookupPackage finds every server version that lists a package. Identifiers are compared case
+// insensitively. Identifiers that are OCI image references match every tag and digest of the
+// repository, written in full or with Docker Hub's short forms, so "acme/foo" and
+// "docker.io/acme/foo:1.0.0" both find servers listing "docker.io/acme/foo:2.0.0".
+func (s *registryServiceImpl) LookupPackage(ctx context.Context, identifier, registryType string) ([]*apiv0.PackageClaim, error) {
+	identifier = strings.TrimSpace(identifier)
+	if identifier == "" {
+		return nil, fmt.Errorf("%w: package identifier is required", database.ErrInvalidInput)
+	}
+
+	return s.db.FindPackageClaims(ctx, nil, packageIdentifierCandidates(identifier), registryType)
+}
+
+// packageIdentifierCandidates returns the lowercase forms a package identifier may be listed under
+func packageIdentifierCandidates(identifier string) []string {
+	candidates := []string{strings.ToLower(identifier)}
+
+	named, err := reference.ParseNormalizedNamed(strings.ToLower(identifier))
+	if err != nil {
+		// Not an OCI image reference, such as a scoped npm package or an MCPB download URL
+		return candidates
+	}
+
+	name := named.Name()
+	familiar := reference.FamiliarName(named)
+	candidates = append(candidates, name, familiar)
+	if domain := reference.Domain(named); domain == "docker.io" {
+		path := reference.Path(named)
+		candidates = append(candidates, "index.docker.io/"+path, "registry.docker.io/"+path)
+		if familiar != path {
+			// Official images, such as "redis" for "docker.io/library/redis"
+			candidates = append(candidates, path, "docker.io/"+familiar)
+		}
+	}
+
+	return dedupeLowercase(candidates)
+}
+
+func dedupeLowercase(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.ToLower(value)
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// PasskeyChallengeTTL is how long a passkey registration or verification can take to complete
+const PasskeyChallengeTTL = 5 * time.Minute
+
+// Audit log actions for passkeys
+const (
+	AuditActionPasskeyRegistered = "passkey.registered"
+	AuditActionPasskeyRemoved    = "passkey.removed"
+)
+
+// passkeyUser is an account as a WebAuthn user. Its user handle is derived from the account, so the
+// same authenticator keeps one passkey per account.
+type passkeyUser struct {
+	owner       string
+	credentials []webauthn.Credential
+}
+
+func (u *passkeyUser) WebAuthnID() []byte {
+	id := sha256.Sum256([]byte(u.owner))
+	return id[:]
+}
+
+func (u *passkeyUser) WebAuthnName() string {
+	return u.owner
+}
+
+func (u *passkeyUser) WebAuthnDisplayName() string {
+	return u.owner
+}
+
+func (u *passkeyUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+// BeginPasskeyRegistration starts registering a passkey for owner, returning the options to pass to
+// navigator.credentials.create. The owner's existing passkeys are excluded so they are not registered twice.
+func (s *registryServiceImpl) BeginPasskeyRegistration(ctx context.Context, owner string) (*protocol.CredentialCreation, error) {
+	relyingParty, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	user, _, err := s.loadPasskeyUser(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	creation, session, err := relyingParty.BeginRegistration(user,
+		webauthn.WithExclusions(webauthn.Credentials(user.credentials).CredentialDescriptors()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start passkey registration: %w", err)
+	}
+	if err := s.storePasskeyChallenge(ctx, owner, database.PasskeyCeremonyRegistration, session); err != nil {
+		return nil, err
+	}
+
+	return creation, nil
+}
+
+// FinishPasskeyRegistration checks the response of navigator.credentials.create to a registration
+// challenge of owner and stores the new passkey
+func (s *registryServiceImpl) FinishPasskeyRegistration(ctx context.Context, owner, name string, response []byte) (*database.Passkey, error) {
+	relyingParty, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid passkey registration response: %w", database.ErrInvalidInput, err)
+	}
+	session, err := s.consumePasskeyChallenge(ctx, owner, database.PasskeyCeremonyRegistration, parsed.Response.CollectedClientData.Challenge)
+	if err != nil {
+		return nil, err
+	}
+	user, _, err := s.loadPasskeyUser(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := relyingParty.CreateCredential(user, *session, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: passkey registration failed: %w", database.ErrInvalidInput, err)
+	}
+	credentialData, err := json.Marshal(credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal passkey credential: %w", err)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.Passkey, error) {
+		passkey := &database.Passkey{
+			Owner:        owner,
+			Name:         name,
+			CredentialID: credential.ID,
+			Credential:   credentialData,
+		}
+		if err := s.db.CreatePasskey(ctx, tx, passkey); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionPasskeyRegistered,
+			Actor:    owner,
+			Resource: owner,
+			Details:  map[string]string{"passkeyId": strconv.FormatInt(passkey.ID, 10), "name": name},
+		}); err != nil {
+			return nil, err
+		}
+
+		return passkey, nil
+	})
+}
+
+// BeginPasskeyVerification starts verifying owner with one of their passkeys, returning the options to
+// pass to navigator.credentials.get. Owners without passkeys get ErrNotFound.
+func (s *registryServiceImpl) BeginPasskeyVerification(ctx context.Context, owner string) (*protocol.CredentialAssertion, error) {
+	relyingParty, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	user, _, err := s.loadPasskeyUser(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if len(user.credentials) == 0 {
+		return nil, database.ErrNotFound
+	}
+
+	assertion, session, err := relyingParty.BeginLogin(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start passkey verification: %w", err)
+	}
+	if err := s.storePasskeyChallenge(ctx, owner, database.PasskeyCeremonyVerification, session); err != nil {
+		return nil, err
+	}
+
+	return assertion, nil
+}
+
+// FinishPasskeyVerification checks the response of navigator.credentials.get to a verification challenge
+// of owner, failing with ErrPasskeyNotVerified unless it was signed by one of their passkeys
+func (s *registryServiceImpl) FinishPasskeyVerification(ctx context.Context, owner string, response []byte) error {
+	relyingParty, err := s.relyingParty()
+	if err != nil {
+		return err
+	}
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return fmt.Errorf("%w: invalid passkey verification response: %w", database.ErrInvalidInput, err)
+	}
+	// The challenge is consumed before the response is checked, so a failed attempt cannot be retried
+	session, err := s.consumePasskeyChallenge(ctx, owner, database.PasskeyCeremonyVerification, parsed.Response.CollectedClientData.Challenge)
+	if err != nil {
+		return err
+	}
+	user, passkeys, err := s.loadPasskeyUser(ctx, owner)
+	if err != nil {
+		return err
+	}
+
+	credential, err := relyingParty.ValidateLogin(user, *session, parsed)
+	if err != nil {
+		return fmt.Errorf("%w: %w", database.ErrPasskeyNotVerified, err)
+	}
+	// A signature counter that did not increase suggests the authenticator has been cloned
+	if credential.Authenticator.CloneWarning {
+		return fmt.Errorf("%w: the passkey's signature counter went backwards, so it may have been copied", database.ErrPasskeyNotVerified)
+	}
+
+	credentialData, err := json.Marshal(credential)
+	if err != nil {
+		return fmt.Errorf("failed to marshal passkey credential: %w", err)
+	}
+	for _, passkey := range passkeys {
+		if bytes.Equal(passkey.CredentialID, credential.ID) {
+			return s.db.UpdatePasskeyCredential(ctx, nil, passkey.ID, credentialData)
+		}
+	}
+	return database.ErrPasskeyNotVerified
+}
+
+// ListPasskeys retrieve the passkeys of an owner, oldest first
+func (s *registryServiceImpl) ListPasskeys(ctx context.Context, owner string) ([]*database.Passkey, error) {
+	return s.db.ListPasskeys(ctx, nil, owner)
+}
+
+// DeletePasskey removes a passkey of owner
+func (s *registryServiceImpl) DeletePasskey(ctx context.Context, owner string, id int64) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.db.DeletePasskey(ctx, tx, owner, id); err != nil {
+			return err
+		}
+
+		return s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionPasskeyRemoved,
+			Actor:    owner,
+			Resource: owner,
+			Details:  map[string]string{"passkeyId": strconv.FormatInt(id, 10)},
+		})
+	})
+}
+
+// relyingParty returns the WebAuthn relying party configured by WEBAUTHN_RP_ID
+func (s *registryServiceImpl) relyingParty() (*webauthn.WebAuthn, error) {
+	origins, err := s.cfg.PasskeyOrigins()
+	if err != nil {
+		return nil, err
+	}
+	if origins == nil {
+		return nil, errors.New("passkeys are not enabled")
+	}
+
+	return webauthn.New(&webauthn.Config{
+		RPID:          s.cfg.WebAuthnRPID,
+		RPDisplayName: "MCP Registry",
+		RPOrigins:     origins,
+	})
+}
+
+// loadPasskeyUser loads the passkeys of owner, both as stored and as WebAuthn credentials
+func (s *registryServiceImpl) loadPasskeyUser(ctx context.Context, owner string) (*passkeyUser, []*database.Passkey, error) {
+	passkeys, err := s.db.ListPasskeys(ctx, nil, owner)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := &passkeyUser{owner: owner}
+	for _, passkey := range passkeys {
+		var credential webauthn.Credential
+		if err := json.Unmarshal(passkey.Credential, &credential); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal passkey credential: %w", err)
+		}
+		user.credentials = append(user.credentials, credential)
+	}
+	return user, passkeys, nil
+}
+
+// storePasskeyChallenge keeps the state of a WebAuthn ceremony until the response to its challenge arrives
+func (s *registryServiceImpl) storePasskeyChallenge(ctx context.Context, owner, ceremony string, session *webauthn.SessionData) error {
+	sessionData, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal passkey session: %w", err)
+	}
+
+	return s.db.CreatePasskeyChallenge(ctx, nil, &database.PasskeyChallenge{
+		Challenge:   session.Challenge,
+		Owner:       owner,
+		Ceremony:    ceremony,
+		SessionData: sessionData,
+		ExpiresAt:   time.Now().Add(PasskeyChallengeTTL),
+	})
+}
+
+// consumePasskeyChallenge returns the state of the WebAuthn ceremony a response answers, which can only be used once
+func (s *registryServiceImpl) consumePasskeyChallenge(ctx context.Context, owner, ceremony, challenge string) (*webauthn.SessionData, error) {
+	stored, err := s.db.ConsumePasskeyChallenge(ctx, nil, challenge, owner, ceremony)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, fmt.Errorf("%w: the passkey challenge has expired or was already used", database.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session webauthn.SessionData
+	if err := json.Unmarshal(stored.SessionData, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal passkey session: %w", err)
+	}
+	return &session, nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+// GetServerReadme retrieves the README of a server
+func (s *registryServiceImpl) GetServerReadme(ctx context.Context, serverName string) (*database.ServerReadme, error) {
+	return s.db.GetServerReadme(ctx, nil, serverName)
+}
+
+// SetServerReadme sanitizes and stores the README of an existing server
+func (s *registryServiceImpl) SetServerReadme(ctx context.Context, serverName, content, actor string) (*database.ServerReadme, error) {
+	if err := validators.ValidateReadme(content); err != nil {
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.ServerReadme, error) {
+		if _, err := s.db.GetServerByName(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		readme := &database.ServerReadme{
+			ServerName: serverName,
+			Content:    validators.SanitizeMarkdown(content),
+			UpdatedBy:  actor,
+		}
+		if err := s.db.SetServerReadme(ctx, tx, readme); err != nil {
+			return nil, err
+		}
+		return readme, nil
+	})
+}
+
+// DeleteServerReadme removes the README of a server
+func (s *registryServiceImpl) DeleteServerReadme(ctx context.Context, serverName string) error {
+	return s.db.DeleteServerReadme(ctx, nil, serverName)
+}
@@ -20,13 +20,16 @@ const maxServerVersionsPerServer = 10000
 type registryServiceImpl struct {
 	db  database.Database
 	cfg *config.Config
+	// serverCache holds the server versions most recently read by name, or is nil if disabled
+	serverCache *serverCache
 }
 
 // NewRegistryService creates a new registry service with the provided database
 func NewRegistryService(db database.Database, cfg *config.Config) RegistryService {
 	return &registryServiceImpl{
-		db:  db,
-		cfg: cfg,
+		db:          db,
+		cfg:         cfg,
+		serverCache: newServerCache(cfg.ServerCacheSize, cfg.ServerCacheMaxAge),
 	}
 }
 
@@ -42,28 +45,68 @@ func (s *registryServiceImpl) ListServers(ctx context.Context, filter *database.
 	if err != nil {
 		return nil, "", err
 	}
+	if err := s.attachRegistryMetadata(ctx, serverRecords); err != nil {
+		return nil, "", err
+	}
 
 	return serverRecords, nextCursor, nil
 }
 
 // GetServerByName retrieves the latest version of a server by its server name
 func (s *registryServiceImpl) GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error) {
-	serverRecord, err := s.db.GetServerByName(ctx, nil, serverName)
-	if err != nil {
-		return nil, err
-	}
+	return s.serverCache.get(ctx, serverCacheKey{name: serverName}, func() (*apiv0.ServerResponse, error) {
+		serverRecord, err := s.db.GetServerByName(ctx, nil, serverName)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.attachRegistryMetadata(ctx, []*apiv0.ServerResponse{serverRecord}); err != nil {
+			return nil, err
+		}
 
-	return serverRecord, nil
+		return serverRecord, nil
+	})
 }
 
 // GetServerByNameAndVersion retrieves a specific version of a server by server name and version
 func (s *registryServiceImpl) GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (*apiv0.ServerResponse, error) {
-	serverRecord, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
+	return s.serverCache.get(ctx, serverCacheKey{name: serverName, version: version}, func() (*apiv0.ServerResponse, error) {
+		serverRecord, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.attachRegistryMetadata(ctx, []*apiv0.ServerResponse{serverRecord}); err != nil {
+			return nil, err
+		}
+
+		return serverRecord, nil
+	})
+}
+
+// GetServersByNames retrieves the latest version of each of the named servers, in request order.
+// Duplicate names are returned once and names that don't exist are skipped.
+func (s *registryServiceImpl) GetServersByNames(ctx context.Context, serverNames []string) ([]*apiv0.ServerResponse, error) {
+	serverRecords, err := s.db.GetServersByNames(ctx, nil, serverNames)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.attachRegistryMetadata(ctx, serverRecords); err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*apiv0.ServerResponse, len(serverRecords))
+	for _, record := range serverRecords {
+		byName[record.Server.Name] = record
+	}
 
-	return serverRecord, nil
+	ordered := make([]*apiv0.ServerResponse, 0, len(serverRecords))
+	for _, name := range serverNames {
+		if record, ok := byName[name]; ok {
+			ordered = append(ordered, record)
+			delete(byName, name)
+		}
+	}
+
+	return ordered, nil
 }
 
 // GetAllVersionsByServerName retrieves all versions of a server by server name
@@ -72,24 +115,37 @@ func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, se
 	if err != nil {
 		return nil, err
 	}
+	if err := s.attachRegistryMetadata(ctx, serverRecords); err != nil {
+		return nil, err
+	}
 
 	return serverRecords, nil
 }
 
 // CreateServer creates a new server version
 func (s *registryServiceImpl) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	return s.CreateSignedServer(ctx, req, nil)
+}
+
+// CreateSignedServer creates a new server version, storing the publisher's signature of it if there is one
+func (s *registryServiceImpl) CreateSignedServer(ctx context.Context, req *apiv0.ServerJSON, signature *apiv0.ServerSignature) (*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
 	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.createServerInTransaction(ctx, tx, req)
+		return s.createServerInTransaction(ctx, tx, req, signature)
 	})
 }
 
 // createServerInTransaction contains the actual CreateServer logic within a transaction
-func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON, signature *apiv0.ServerSignature) (*apiv0.ServerResponse, error) {
 	// Validate the request
 	if err := validators.ValidatePublishRequest(ctx, *req, s.cfg); err != nil {
 		return nil, err
 	}
+	if signature != nil {
+		if err := signature.Verify(req); err != nil {
+			return nil, fmt.Errorf("%w: invalid signature: %w", database.ErrInvalidInput, err)
+		}
+	}
 
 	publishTime := time.Now()
 	serverJSON := *req
@@ -129,19 +185,7 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 	}
 
 	// Determine if this version should be marked as latest
-	isNewLatest := true
-	if currentLatest != nil {
-		var existingPublishedAt time.Time
-		if currentLatest.Meta.Official != nil {
-			existingPublishedAt = currentLatest.Meta.Official.PublishedAt
-		}
-		isNewLatest = CompareVersions(
-			serverJSON.Version,
-			currentLatest.Server.Version,
-			publishTime,
-			existingPublishedAt,
-		) > 0
-	}
+	isNewLatest := isNewLatestVersion(serverJSON, currentLatest, publishTime)
 
 	// Unmark old latest version if needed
 	if isNewLatest && currentLatest != nil {
@@ -159,11 +203,119 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 	}
 
 	// Insert new server version
-	return s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	server, err := s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	if err != nil {
+		return nil, err
+	}
+	if signature != nil {
+		if err := s.db.SetServerSignature(ctx, tx, serverJSON.Name, serverJSON.Version, signature); err != nil {
+			return nil, err
+		}
+		server.Meta.Official.Signature = signature
+	}
+
+	if err := s.recordServerChange(ctx, tx, database.ChangeTypePublish, serverJSON.Name, serverJSON.Version); err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+// ValidatePublish runs every publish check against a server version without persisting it.
+// Validation failures are reported in the result; an error is only returned if the checks could not run.
+func (s *registryServiceImpl) ValidatePublish(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.PublishResponse, error) {
+	validation := &apiv0.PublishValidation{Errors: []string{}}
+	for _, err := range validators.PublishRequestErrors(ctx, *req, s.cfg) {
+		validation.Errors = append(validation.Errors, err.Error())
+	}
+	if !s.cfg.EnableRegistryValidation && len(req.Packages) > 0 {
+		validation.Warnings = append(validation.Warnings, "package registry ownership validation is disabled on this registry")
+	}
+
+	conflicts, err := s.findRemoteURLConflicts(ctx, nil, *req)
+	if err != nil {
+		return nil, err
+	}
+	validation.Errors = append(validation.Errors, conflicts...)
+
+	versionCount, err := s.db.CountServerVersions(ctx, nil, req.Name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+	if versionCount >= maxServerVersionsPerServer {
+		validation.Errors = append(validation.Errors, database.ErrMaxServersReached.Error())
+	}
+
+	versionExists, err := s.db.CheckVersionExists(ctx, nil, req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	if versionExists {
+		validation.Errors = append(validation.Errors, database.ErrInvalidVersion.Error())
+	}
+
+	currentLatest, err := s.db.GetCurrentLatestVersion(ctx, nil, req.Name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+
+	publishTime := time.Now()
+	isNewLatest := isNewLatestVersion(*req, currentLatest, publishTime)
+	if !isNewLatest && !versionExists {
+		validation.Warnings = append(validation.Warnings, fmt.Sprintf("version %s will not be marked as latest because version %s is newer", req.Version, currentLatest.Server.Version))
+	}
+	validation.Valid = len(validation.Errors) == 0
+
+	return &apiv0.PublishResponse{
+		ServerResponse: apiv0.ServerResponse{
+			Server: *req,
+			Meta: apiv0.ResponseMeta{
+				Official: &apiv0.RegistryExtensions{
+					Status:      model.StatusActive,
+					PublishedAt: publishTime,
+					UpdatedAt:   publishTime,
+					IsLatest:    isNewLatest,
+				},
+			},
+		},
+		Validation: validation,
+	}, nil
+}
+
+// isNewLatestVersion determines whether a version published at publishTime should replace currentLatest as the latest version
+func isNewLatestVersion(serverJSON apiv0.ServerJSON, currentLatest *apiv0.ServerResponse, publishTime time.Time) bool {
+	if currentLatest == nil {
+		return true
+	}
+
+	var existingPublishedAt time.Time
+	if currentLatest.Meta.Official != nil {
+		existingPublishedAt = currentLatest.Meta.Official.PublishedAt
+	}
+	return CompareVersions(
+		serverJSON.Version,
+		currentLatest.Server.Version,
+		publishTime,
+		existingPublishedAt,
+	) > 0
 }
 
 // validateNoDuplicateRemoteURLs checks that no other server is using the same remote URLs
 func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context, tx pgx.Tx, serverDetail apiv0.ServerJSON) error {
+	conflicts, err := s.findRemoteURLConflicts(ctx, tx, serverDetail)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return errors.New(conflicts[0])
+	}
+	return nil
+}
+
+// findRemoteURLConflicts describes each remote URL of the server that is already used by another server
+func (s *registryServiceImpl) findRemoteURLConflicts(ctx context.Context, tx pgx.Tx, serverDetail apiv0.ServerJSON) ([]string, error) {
+	var conflicts []string
+
 	// Check each remote URL in the new server for conflicts
 	for _, remote := range serverDetail.Remotes {
 		// Use filter to find servers with this remote URL
@@ -171,18 +323,19 @@ func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context,
 
 		conflictingServers, _, err := s.db.ListServers(ctx, tx, filter, "", 1000)
 		if err != nil {
-			return fmt.Errorf("failed to check remote URL conflict: %w", err)
+			return nil, fmt.Errorf("failed to check remote URL conflict: %w", err)
 		}
 
 		// Check if any conflicting server has a different name
 		for _, conflictingServer := range conflictingServers {
 			if conflictingServer.Server.Name != serverDetail.Name {
-				return fmt.Errorf("remote URL %s is already used by server %s", remote.URL, conflictingServer.Server.Name)
+				conflicts = append(conflicts, fmt.Sprintf("remote URL %s is already used by server %s", remote.URL, conflictingServer.Server.Name))
+				break
 			}
 		}
 	}
 
-	return nil
+	return conflicts, nil
 }
 
 // UpdateServer updates an existing server with new details
@@ -232,13 +385,25 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
+	// The publisher's signature no longer holds once server.json is edited
+	if err := s.dropStaleSignature(ctx, tx, &currentServer.Server, &updatedServer); err != nil {
+		return nil, err
+	}
+
 	// Handle status change if provided
 	if newStatus != nil {
-		updatedWithStatus, err := s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus)
+		updatedServerResponse, err = s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus)
 		if err != nil {
 			return nil, err
 		}
-		return updatedWithStatus, nil
+	}
+
+	changeType := database.ChangeTypeUpdate
+	if beingDeleted {
+		changeType = database.ChangeTypeDelete
+	}
+	if err := s.recordServerChange(ctx, tx, changeType, serverName, version); err != nil {
+		return nil, err
 	}
 
 	return updatedServerResponse, nil
@@ -265,3 +430,43 @@ func (s *registryServiceImpl) validateUpdateRequest(ctx context.Context, req api
 
 	return nil
 }
+
+// IncrementFetchCounts records aggregated server fetch counts for a day
+func (s *registryServiceImpl) IncrementFetchCounts(ctx context.Context, day time.Time, counts map[string]int64) error {
+	return s.db.IncrementFetchCounts(ctx, nil, day, counts)
+}
+
+// GetServerFetchStats retrieves daily fetch counts for a server since the given day
+func (s *registryServiceImpl) GetServerFetchStats(ctx context.Context, serverName string, since time.Time) ([]database.DailyFetchCount, error) {
+	return s.db.GetServerFetchStats(ctx, nil, serverName, since)
+}
+
+// GetDailyFetchTotals retrieves registry-wide daily fetch counts since the given day
+func (s *registryServiceImpl) GetDailyFetchTotals(ctx context.Context, since time.Time) ([]database.DailyFetchCount, error) {
+	return s.db.GetDailyFetchTotals(ctx, nil, since)
+}
+
+// GetTopFetchedServers retrieves the most fetched servers since the given day
+func (s *registryServiceImpl) GetTopFetchedServers(ctx context.Context, since time.Time, limit int) ([]database.ServerFetchCount, error) {
+	return s.db.GetTopFetchedServers(ctx, nil, since, limit)
+}
+
+// GetMaintenanceMode retrieves the current maintenance mode state
+func (s *registryServiceImpl) GetMaintenanceMode(ctx context.Context) (*database.MaintenanceMode, error) {
+	return s.db.GetMaintenanceMode(ctx, nil)
+}
+
+// SetMaintenanceMode enables or disables maintenance mode with the given message
+func (s *registryServiceImpl) SetMaintenanceMode(ctx context.Context, enabled bool, message string) (*database.MaintenanceMode, error) {
+	return s.db.SetMaintenanceMode(ctx, nil, enabled, message)
+}
+
+// Ping verifies that the backing database is reachable
+func (s *registryServiceImpl) Ping(ctx context.Context) error {
+	return s.db.Ping(ctx)
+}
+
+// PendingMigrations returns the names of database migrations that have not been applied
+func (s *registryServiceImpl) PendingMigrations(ctx context.Context) ([]string, error) {
+	return s.db.PendingMigrations(ctx)
+}
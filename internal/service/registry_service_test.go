@@ -2,8 +2,12 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"image"
+	"image/png"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -790,3 +794,113 @@ func TestVersionComparison(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestPackageIdentifierCandidates(t *testing.T) {
+	tests := []struct {
+		identifier string
+		expected   []string
+	}{
+		{"@acme/foo", []string{"@acme/foo"}},
+		{"https://example.com/foo.mcpb", []string{"https://example.com/foo.mcpb"}},
+		{"ghcr.io/Acme/foo:1.0.0", []string{"ghcr.io/acme/foo:1.0.0", "ghcr.io/acme/foo"}},
+		{"acme/foo", []string{"acme/foo", "docker.io/acme/foo", "index.docker.io/acme/foo", "registry.docker.io/acme/foo"}},
+		{"redis:7", []string{
+			"redis:7", "docker.io/library/redis", "redis", "index.docker.io/library/redis",
+			"registry.docker.io/library/redis", "library/redis", "docker.io/redis",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.identifier, func(t *testing.T) {
+			assert.Equal(t, tt.expected, packageIdentifierCandidates(tt.identifier))
+		})
+	}
+}
+
+func TestUserCodes(t *testing.T) {
+	code, err := generateUserCode()
+	require.NoError(t, err)
+	assert.Regexp(t, `^[`+userCodeAlphabet+`]{8}$`, code)
+
+	formatted := FormatUserCode(code)
+	assert.Equal(t, code[:4]+"-"+code[4:], formatted)
+	assert.Equal(t, code, NormalizeUserCode(formatted))
+	assert.Equal(t, code, NormalizeUserCode(strings.ToLower(formatted)))
+	assert.Equal(t, "BCDFGHJK", NormalizeUserCode(" bcdf ghjk "))
+}
+
+func TestDeleteExpiredAnonymousServers(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{
+		EnableAnonymousAuth: true,
+		AnonymousNamespaces: "io.modelcontextprotocol.anonymous/*",
+		AnonymousServerTTL:  time.Hour,
+	})
+
+	for _, name := range []string{"io.modelcontextprotocol.anonymous/stale", "com.example/kept"} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "A test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	// Nothing has expired yet
+	deleted, err := service.DeleteExpiredAnonymousServers(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	service.(*registryServiceImpl).cfg.AnonymousServerTTL = time.Nanosecond
+	deleted, err = service.DeleteExpiredAnonymousServers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	_, err = service.GetServerByName(ctx, "io.modelcontextprotocol.anonymous/stale")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	_, err = service.GetServerByName(ctx, "com.example/kept")
+	assert.NoError(t, err)
+
+	changes, err := service.ListServerChanges(ctx, 0, 10)
+	require.NoError(t, err)
+	last := changes[len(changes)-1]
+	assert.Equal(t, database.ChangeTypeDelete, last.Type)
+	assert.Equal(t, "io.modelcontextprotocol.anonymous/stale", last.ServerName)
+	assert.Equal(t, "1.0.0", last.Version)
+}
+
+func TestServerIconChanges(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{})
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/icon-server",
+			Description: "A test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	latest, err := service.GetLatestServerChangeID(ctx)
+	require.NoError(t, err)
+
+	var icon bytes.Buffer
+	require.NoError(t, png.Encode(&icon, image.NewRGBA(image.Rect(0, 0, 64, 64))))
+	_, err = service.SetServerIcon(ctx, "com.example/icon-server", icon.Bytes(), "image/png", "github-at:alice")
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteServerIcon(ctx, "com.example/icon-server"))
+
+	// Each icon change is an update of every version, so every replica evicts the server
+	changes, err := service.ListServerChanges(ctx, latest, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	for _, change := range changes {
+		assert.Equal(t, database.ChangeTypeUpdate, change.Type)
+		assert.Equal(t, "com.example/icon-server", change.ServerName)
+	}
+	assert.ElementsMatch(t, []string{"1.0.0", "1.1.0"}, []string{changes[0].Version, changes[1].Version})
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// AuditActionReportClosed is recorded when an admin resolves or dismisses an abuse report
+const AuditActionReportClosed = "report.closed"
+
+// ReportServer adds a report about a server to the moderation queue. Anonymous reports have an empty reporter.
+func (s *registryServiceImpl) ReportServer(ctx context.Context, serverName, category, details, reporter string) (*database.AbuseReport, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.AbuseReport, error) {
+		if _, err := s.db.GetServerByName(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		report := &database.AbuseReport{
+			ServerName: serverName,
+			Category:   category,
+			Details:    details,
+			Reporter:   reporter,
+		}
+		if err := s.db.CreateAbuseReport(ctx, tx, report); err != nil {
+			return nil, err
+		}
+		return report, nil
+	})
+}
+
+// ListAbuseReports retrieves up to limit reports with the given status, oldest first
+func (s *registryServiceImpl) ListAbuseReports(ctx context.Context, status string, limit int) ([]*database.AbuseReport, error) {
+	return s.db.ListAbuseReports(ctx, nil, status, limit)
+}
+
+// UpdateAbuseReportStatus records an admin's decision on a report
+func (s *registryServiceImpl) UpdateAbuseReportStatus(ctx context.Context, id int64, status, actor string) (*database.AbuseReport, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.AbuseReport, error) {
+		report, err := s.db.UpdateAbuseReportStatus(ctx, tx, id, status, actor)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionReportClosed,
+			Actor:    actor,
+			Resource: report.ServerName,
+			Details:  map[string]string{"reportId": strconv.FormatInt(id, 10), "status": status},
+		}); err != nil {
+			return nil, err
+		}
+
+		return report, nil
+	})
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// AuditActionServerRevalidated is recorded when an admin re-runs package validation for a server
+const AuditActionServerRevalidated = "server.revalidated"
+
+// RevalidateServer re-runs registry ownership validation for every package of a published server
+// version, or of its latest version if version is empty. Nothing about the server is changed; the
+// results are returned so admins can confirm that upstream problems have been fixed. Validation
+// runs even if it is disabled for publishing on this registry.
+func (s *registryServiceImpl) RevalidateServer(ctx context.Context, serverName, version, actor string) (*apiv0.ServerRevalidation, error) {
+	var server *apiv0.ServerResponse
+	var err error
+	if version == "" {
+		server, err = s.db.GetServerByName(ctx, nil, serverName)
+	} else {
+		server, err = s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &apiv0.ServerRevalidation{
+		ServerName: server.Server.Name,
+		Version:    server.Server.Version,
+		Valid:      true,
+		Packages:   make([]apiv0.PackageValidation, 0, len(server.Server.Packages)),
+	}
+	for _, pkg := range server.Server.Packages {
+		packageResult := apiv0.PackageValidation{
+			RegistryType: pkg.RegistryType,
+			Identifier:   pkg.Identifier,
+			Version:      pkg.Version,
+			Valid:        true,
+		}
+		if err := validators.ValidatePackage(ctx, pkg, server.Server.Name); err != nil {
+			packageResult.Valid = false
+			packageResult.Error = err.Error()
+			result.Valid = false
+		}
+		result.Packages = append(result.Packages, packageResult)
+	}
+	result.CheckedAt = time.Now()
+
+	if err := s.db.RecordAuditEvent(ctx, nil, &database.AuditEvent{
+		Action:   AuditActionServerRevalidated,
+		Actor:    actor,
+		Resource: server.Server.Name,
+		Details:  map[string]string{"version": server.Server.Version, "valid": strconv.FormatBool(result.Valid)},
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
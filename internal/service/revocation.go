@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// AuditActionJWTRevoked is the audit log action for revoking a Registry JWT or refresh token
+const AuditActionJWTRevoked = "jwt.revoked"
+
+// refreshRevokedBy marks revocations made by refresh token rotation rather than by a user
+const refreshRevokedBy = "refresh"
+
+// RevokeJWT puts a Registry JWT or refresh token on the revocation list until it expires. Revoking a
+// token twice returns ErrAlreadyExists.
+func (s *registryServiceImpl) RevokeJWT(ctx context.Context, jti, subject string, expiresAt time.Time, actor string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		if err := s.db.RevokeToken(ctx, tx, jti, expiresAt, actor); err != nil {
+			return err
+		}
+
+		return s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionJWTRevoked,
+			Actor:    actor,
+			Resource: subject,
+			Details:  map[string]string{"jti": jti},
+		})
+	})
+}
+
+// ConsumeRefreshToken revokes a refresh token as it is exchanged, so each can be used only once.
+// A refresh token that was already used or revoked returns ErrAlreadyExists.
+func (s *registryServiceImpl) ConsumeRefreshToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	return s.db.RevokeToken(ctx, nil, jti, expiresAt, refreshRevokedBy)
+}
+
+// IsTokenRevoked reports whether a Registry JWT or refresh token is on the revocation list
+func (s *registryServiceImpl) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	return s.db.IsTokenRevoked(ctx, nil, jti)
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/cache"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// serverChangePollInterval is how often the change feed is read for servers to evict from the cache
+	serverChangePollInterval = 2 * time.Second
+
+	// serverChangeBatchSize bounds how many changes are read from the change feed at once
+	serverChangeBatchSize = 500
+)
+
+// ServerCacheObserver, if set, is called with whether each read of a server version by name was served
+// from the server cache, for metrics
+var ServerCacheObserver func(ctx context.Context, hit bool)
+
+// serverCacheKey identifies a server version read by name. The latest version has no version.
+type serverCacheKey struct {
+	name    string
+	version string
+}
+
+// serverCache keeps the server versions most recently read by name, as JSON so every read gets a copy it
+// may modify
+type serverCache struct {
+	lru *cache.LRU[serverCacheKey, []byte]
+
+	mu sync.Mutex
+	// evictions counts evictions, so a read that began before one does not cache what it read
+	evictions uint64
+}
+
+func newServerCache(size int, maxAge time.Duration) *serverCache {
+	if size <= 0 {
+		return nil
+	}
+	return &serverCache{lru: cache.NewLRU[serverCacheKey, []byte](size, maxAge)}
+}
+
+// get returns the cached server version of key, or else loads and caches it. Failed loads, such as of
+// servers that do not exist, are not cached.
+func (c *serverCache) get(ctx context.Context, key serverCacheKey, load func() (*apiv0.ServerResponse, error)) (*apiv0.ServerResponse, error) {
+	if c == nil {
+		return load()
+	}
+
+	if data, ok := c.lru.Get(key); ok {
+		var server apiv0.ServerResponse
+		if err := json.Unmarshal(data, &server); err == nil {
+			observeServerCache(ctx, true)
+			return &server, nil
+		}
+	}
+	observeServerCache(ctx, false)
+
+	c.mu.Lock()
+	evictions := c.evictions
+	c.mu.Unlock()
+
+	server, err := load()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(server)
+	if err != nil {
+		return server, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.evictions == evictions {
+		c.lru.Add(key, data)
+	}
+	return server, nil
+}
+
+// evict removes every version of the named servers
+func (c *serverCache) evict(names ...string) {
+	if c == nil || len(names) == 0 {
+		return
+	}
+	evicted := make(map[string]bool, len(names))
+	for _, name := range names {
+		evicted[name] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evictions++
+	c.lru.RemoveFunc(func(key serverCacheKey) bool { return evicted[key.name] })
+}
+
+// purge removes every server version
+func (c *serverCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evictions++
+	c.lru.Purge()
+}
+
+func observeServerCache(ctx context.Context, hit bool) {
+	if observe := ServerCacheObserver; observe != nil {
+		observe(ctx, hit)
+	}
+}
+
+// WatchServerChanges evicts the servers changed by any replica from the server cache, as the change feed
+// reports them, until ctx is cancelled. Changes made by this replica are evicted as they are recorded as
+// well, so its own writes are read back straight away.
+func (s *registryServiceImpl) WatchServerChanges(ctx context.Context) {
+	if s.serverCache == nil {
+		return
+	}
+
+	ticker := time.NewTicker(serverChangePollInterval)
+	defer ticker.Stop()
+
+	// Until the end of the change feed is known, changes cannot be followed
+	afterID := int64(-1)
+	for {
+		if afterID < 0 {
+			latest, err := s.db.GetLatestServerChangeID(ctx, nil)
+			if err != nil {
+				slog.ErrorContext(ctx, "Failed to read the change feed for the server cache", logging.Err(err))
+			} else {
+				afterID = latest
+			}
+			// Servers cached before now may have changed
+			s.serverCache.purge()
+		} else {
+			afterID = s.evictServerChanges(ctx, afterID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// evictServerChanges evicts the servers changed after afterID from the server cache, returning the id of
+// the last change read
+func (s *registryServiceImpl) evictServerChanges(ctx context.Context, afterID int64) int64 {
+	for {
+		changes, err := s.db.ListServerChanges(ctx, nil, afterID, serverChangeBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.ErrorContext(ctx, "Failed to read the change feed for the server cache", logging.Err(err))
+				// Changes may be missed until the feed can be read again
+				s.serverCache.purge()
+			}
+			return afterID
+		}
+
+		names := make([]string, 0, len(changes))
+		for _, change := range changes {
+			names = append(names, change.ServerName)
+			afterID = change.ID
+		}
+		s.serverCache.evict(names...)
+		if len(changes) < serverChangeBatchSize {
+			return afterID
+		}
+	}
+}
+
+// recordServerChanges adds changes to server versions to the change feed and evicts the servers from the
+// server cache. A read racing the transaction may cache the server again, until the change is read from
+// the feed once committed.
+func (s *registryServiceImpl) recordServerChanges(ctx context.Context, tx pgx.Tx, changes []*database.ServerChange) error {
+	if err := s.db.RecordServerChanges(ctx, tx, changes); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(changes))
+	for _, change := range changes {
+		names = append(names, change.ServerName)
+	}
+	s.serverCache.evict(names...)
+	return nil
+}
@@ -0,0 +1,106 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServerCache_ReadRacingEviction(t *testing.T) {
+	ctx := context.Background()
+	cache := newServerCache(10, time.Minute)
+	key := serverCacheKey{name: "com.example/weather"}
+	loads := 0
+	load := func() (*apiv0.ServerResponse, error) {
+		loads++
+		if loads == 1 {
+			// The server changes while it is read
+			cache.evict("com.example/weather")
+		}
+		return &apiv0.ServerResponse{Server: apiv0.ServerJSON{Name: "com.example/weather", Version: "1.0.0"}}, nil
+	}
+
+	_, err := cache.get(ctx, key, load)
+	require.NoError(t, err)
+	_, err = cache.get(ctx, key, load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads, "a read racing an eviction is not cached")
+
+	server, err := cache.get(ctx, key, load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+	server.Server.Version = "2.0.0"
+	server, err = cache.get(ctx, key, load)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", server.Server.Version, "every read gets its own copy")
+
+	assert.Nil(t, newServerCache(0, time.Minute), "a size of 0 disables the cache")
+}
+
+func TestServerCache(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	cfg := &config.Config{EnableRegistryValidation: false, ServerCacheSize: 10, ServerCacheMaxAge: time.Hour}
+	service := NewRegistryService(testDB, cfg).(*registryServiceImpl)
+	// Another replica sharing the database
+	replica := NewRegistryService(testDB, cfg)
+
+	var hits, misses int
+	ServerCacheObserver = func(_ context.Context, hit bool) {
+		if hit {
+			hits++
+		} else {
+			misses++
+		}
+	}
+	t.Cleanup(func() { ServerCacheObserver = nil })
+
+	publish := func(registry RegistryService, version string) {
+		t.Helper()
+		_, err := registry.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/cached-server",
+			Description: "Test server " + version,
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	latestVersion := func() string {
+		t.Helper()
+		server, err := service.GetServerByName(ctx, "com.example/cached-server")
+		require.NoError(t, err)
+		return server.Server.Version
+	}
+
+	_, err := service.GetServerByName(ctx, "com.example/cached-server")
+	require.ErrorIs(t, err, database.ErrNotFound)
+	publish(service, "1.0.0")
+	assert.Equal(t, "1.0.0", latestVersion(), "servers that were not found are not cached")
+	assert.Equal(t, "1.0.0", latestVersion())
+	assert.Equal(t, 1, hits)
+
+	publish(service, "2.0.0")
+	assert.Equal(t, "2.0.0", latestVersion(), "changes made through this replica are evicted straight away")
+
+	afterID, err := testDB.GetLatestServerChangeID(ctx, nil)
+	require.NoError(t, err)
+	publish(replica, "3.0.0")
+	assert.Equal(t, "2.0.0", latestVersion(), "changes by other replicas show once read from the change feed")
+
+	afterID = service.evictServerChanges(ctx, afterID)
+	assert.Equal(t, "3.0.0", latestVersion())
+	latestID, err := testDB.GetLatestServerChangeID(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, latestID, afterID)
+	assert.Equal(t, 2, hits)
+	assert.Equal(t, 4, misses)
+}
@@ -2,9 +2,14 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/go-webauthn/webauthn/protocol"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 // RegistryService defines the interface for registry operations
@@ -15,10 +20,158 @@ type RegistryService interface {
 	GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
 	GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (*apiv0.ServerResponse, error)
+	// GetServersByNames retrieve the latest version of each of the named servers, in request order
+	GetServersByNames(ctx context.Context, serverNames []string) ([]*apiv0.ServerResponse, error)
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
 	GetAllVersionsByServerName(ctx context.Context, serverName string) ([]*apiv0.ServerResponse, error)
 	// CreateServer creates a new server version
 	CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
+	// CreateSignedServer creates a new server version, storing the publisher's signature of it if there is one
+	CreateSignedServer(ctx context.Context, req *apiv0.ServerJSON, signature *apiv0.ServerSignature) (*apiv0.ServerResponse, error)
+	// CreateServerIdempotent creates a new server version, signed if signature is set, replaying the original response for retries with the same idempotency key
+	CreateServerIdempotent(ctx context.Context, req *apiv0.ServerJSON, signature *apiv0.ServerSignature, actor, key string) (*apiv0.ServerResponse, bool, error)
+	// ValidatePublish runs every publish check against a server version without persisting it
+	ValidatePublish(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.PublishResponse, error)
 	// UpdateServer updates an existing server and optionally its status
 	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error)
+	// RevalidateServer re-runs package validation for a server version, or its latest version if version is empty
+	RevalidateServer(ctx context.Context, serverName, version, actor string) (*apiv0.ServerRevalidation, error)
+	// DeleteExpiredAnonymousServers deletes the servers in the anonymous namespaces whose TTL has passed, returning how many were deleted
+	DeleteExpiredAnonymousServers(ctx context.Context) (int, error)
+	// LookupPackage retrieve every server version that lists a package, optionally of one registry type
+	LookupPackage(ctx context.Context, identifier, registryType string) ([]*apiv0.PackageClaim, error)
+	// InitiateServerTransfer starts a transfer of a server to a new name, to be accepted by the new owner
+	InitiateServerTransfer(ctx context.Context, serverName, newName, actor string) (*database.ServerTransfer, error)
+	// GetServerTransfer retrieve the pending, unexpired transfer of a server
+	GetServerTransfer(ctx context.Context, serverName string) (*database.ServerTransfer, error)
+	// CancelServerTransfer cancels the pending transfer of a server
+	CancelServerTransfer(ctx context.Context, serverName, actor string) error
+	// AcceptServerTransfer completes the pending transfer of a server, renaming all of its versions
+	AcceptServerTransfer(ctx context.Context, serverName, actor string) (*apiv0.ServerResponse, error)
+	// GetServerReadme retrieve the README of a server
+	GetServerReadme(ctx context.Context, serverName string) (*database.ServerReadme, error)
+	// SetServerReadme sanitizes and stores the README of a server
+	SetServerReadme(ctx context.Context, serverName, content, actor string) (*database.ServerReadme, error)
+	// DeleteServerReadme removes the README of a server
+	DeleteServerReadme(ctx context.Context, serverName string) error
+	// GetServerIcon retrieve the icon of a server, including its data
+	GetServerIcon(ctx context.Context, serverName string) (*database.ServerIcon, error)
+	// SetServerIcon validates and stores the icon of a server, returning how server responses reference it
+	SetServerIcon(ctx context.Context, serverName string, data []byte, mimeType, actor string) (*model.Icon, error)
+	// DeleteServerIcon removes the icon of a server
+	DeleteServerIcon(ctx context.Context, serverName string) error
+	// ReserveNamespace reserves a namespace for holders of a publish permission pattern
+	ReserveNamespace(ctx context.Context, namespace, permissionPattern, actor string) (*database.NamespaceReservation, error)
+	// GetNamespaceReservation retrieve the reservation of a namespace
+	GetNamespaceReservation(ctx context.Context, namespace string) (*database.NamespaceReservation, error)
+	// ReleaseNamespace removes the reservation of a namespace
+	ReleaseNamespace(ctx context.Context, namespace, actor string) error
+	// DisputeNamespaceReservation flags a reservation for admin review
+	DisputeNamespaceReservation(ctx context.Context, namespace, reason, actor string) (*database.NamespaceReservation, error)
+	// ListNamespaceDisputes retrieve the reservations awaiting admin review
+	ListNamespaceDisputes(ctx context.Context) ([]*database.NamespaceReservation, error)
+	// ResolveNamespaceDispute upholds or revokes a disputed reservation
+	ResolveNamespaceDispute(ctx context.Context, namespace string, uphold bool, actor string) (*database.NamespaceReservation, error)
+	// CreateOrganization creates an organization whose members publish in namespace with permissionPattern
+	CreateOrganization(ctx context.Context, namespace, permissionPattern, actor string) (*database.Organization, error)
+	// GetOrganization retrieve the organization of a namespace
+	GetOrganization(ctx context.Context, namespace string) (*database.Organization, error)
+	// DeleteOrganization removes an organization and its memberships
+	DeleteOrganization(ctx context.Context, namespace, actor string) error
+	// ListOrganizationMembers retrieve the members of an organization, admins first
+	ListOrganizationMembers(ctx context.Context, namespace string) ([]*database.OrganizationMember, error)
+	// GetOrganizationMember retrieve a user's membership of an organization
+	GetOrganizationMember(ctx context.Context, namespace, member string) (*database.OrganizationMember, error)
+	// SetOrganizationMember adds a member to an organization or changes their role
+	SetOrganizationMember(ctx context.Context, namespace, member, role, actor string) (*database.OrganizationMember, error)
+	// RemoveOrganizationMember removes a member from an organization
+	RemoveOrganizationMember(ctx context.Context, namespace, member, actor string) error
+	// ListMemberOrganizations retrieve the organizations a user is a member of
+	ListMemberOrganizations(ctx context.Context, member string) ([]*database.Organization, error)
+	// CreateNamespaceDelegation gives delegate publish rights for the servers of namespace matching permissionPattern
+	CreateNamespaceDelegation(ctx context.Context, namespace, permissionPattern, delegate, grantedWith, actor string, expiresAt time.Time) (*database.NamespaceDelegation, error)
+	// GetNamespaceDelegation retrieve a namespace delegation by ID
+	GetNamespaceDelegation(ctx context.Context, id int64) (*database.NamespaceDelegation, error)
+	// ListNamespaceDelegations retrieve the active delegations of a namespace, newest first
+	ListNamespaceDelegations(ctx context.Context, namespace string) ([]*database.NamespaceDelegation, error)
+	// ListDelegateDelegations retrieve the active delegations given to an account, newest first
+	ListDelegateDelegations(ctx context.Context, delegate string) ([]*database.NamespaceDelegation, error)
+	// RevokeNamespaceDelegation revokes a namespace delegation
+	RevokeNamespaceDelegation(ctx context.Context, id int64, actor string) error
+	// ReportServer adds a report about a server to the moderation queue
+	ReportServer(ctx context.Context, serverName, category, details, reporter string) (*database.AbuseReport, error)
+	// ListAbuseReports retrieve up to limit reports with the given status, oldest first
+	ListAbuseReports(ctx context.Context, status string, limit int) ([]*database.AbuseReport, error)
+	// UpdateAbuseReportStatus records an admin's decision on a report
+	UpdateAbuseReportStatus(ctx context.Context, id int64, status, actor string) (*database.AbuseReport, error)
+	// StartDeviceAuthorization creates a device login, returning it along with the device code held by the device
+	StartDeviceAuthorization(ctx context.Context) (*database.DeviceAuthorization, string, error)
+	// ApproveDeviceAuthorization approves a pending device login with the given identity and permissions, or denies it
+	ApproveDeviceAuthorization(ctx context.Context, userCode string, claims *auth.JWTClaims, actor string, approve bool) error
+	// PollDeviceAuthorization returns an approved device login once, or why it cannot be completed yet
+	PollDeviceAuthorization(ctx context.Context, deviceCode string) (*database.DeviceAuthorization, error)
+	// ListServerChanges retrieve up to limit entries of the change feed recorded after the given id, oldest first
+	ListServerChanges(ctx context.Context, afterID int64, limit int) ([]*database.ServerChange, error)
+	// GetLatestServerChangeID returns the id of the most recent change feed entry, or zero if there are none
+	GetLatestServerChangeID(ctx context.Context) (int64, error)
+	// WatchServerChanges evicts the servers the change feed reports changed from the server cache until ctx is cancelled
+	WatchServerChanges(ctx context.Context)
+	// CreateAPIToken creates an API token, returning it along with the token itself, which is shown only once
+	CreateAPIToken(ctx context.Context, owner, name string, permissions []auth.Permission, expiresAt time.Time) (*database.APIToken, string, error)
+	// ListAPITokens retrieve the unrevoked API tokens of an owner, newest first
+	ListAPITokens(ctx context.Context, owner string) ([]*database.APIToken, error)
+	// RevokeAPIToken revokes an API token owned by actor, or any token if asAdmin is set
+	RevokeAPIToken(ctx context.Context, id int64, actor string, asAdmin bool) error
+	// AuthenticateAPIToken looks up a usable API token and records its use
+	AuthenticateAPIToken(ctx context.Context, secret string) (*database.APIToken, error)
+	// CreateWebSession creates a browser session for the identity of claims, returning it along with its cookie value
+	CreateWebSession(ctx context.Context, claims *auth.JWTClaims, userAgent, clientIP string, expiresAt time.Time) (*database.WebSession, string, error)
+	// ListWebSessions retrieve the active browser sessions of an owner, newest first
+	ListWebSessions(ctx context.Context, owner string) ([]*database.WebSession, error)
+	// RevokeWebSession revokes a browser session owned by actor, or any session if asAdmin is set
+	RevokeWebSession(ctx context.Context, id int64, actor string, asAdmin bool) error
+	// AuthenticateWebSession looks up an active browser session by its cookie value and records its use
+	AuthenticateWebSession(ctx context.Context, secret string) (*database.WebSession, error)
+	// BeginPasskeyRegistration starts registering a passkey for owner, returning the WebAuthn creation options
+	BeginPasskeyRegistration(ctx context.Context, owner string) (*protocol.CredentialCreation, error)
+	// FinishPasskeyRegistration checks the WebAuthn response to a registration challenge of owner and stores the passkey
+	FinishPasskeyRegistration(ctx context.Context, owner, name string, response []byte) (*database.Passkey, error)
+	// BeginPasskeyVerification starts verifying owner with one of their passkeys, returning the WebAuthn request options
+	BeginPasskeyVerification(ctx context.Context, owner string) (*protocol.CredentialAssertion, error)
+	// FinishPasskeyVerification checks the WebAuthn response to a verification challenge of owner
+	FinishPasskeyVerification(ctx context.Context, owner string, response []byte) error
+	// ListPasskeys retrieve the passkeys of an owner, oldest first
+	ListPasskeys(ctx context.Context, owner string) ([]*database.Passkey, error)
+	// DeletePasskey removes a passkey of owner
+	DeletePasskey(ctx context.Context, owner string, id int64) error
+	// RevokeJWT puts a Registry JWT or refresh token on the revocation list until it expires
+	RevokeJWT(ctx context.Context, jti, subject string, expiresAt time.Time, actor string) error
+	// ConsumeRefreshToken revokes a refresh token as it is exchanged, failing if it was already used
+	ConsumeRefreshToken(ctx context.Context, jti string, expiresAt time.Time) error
+	// IsTokenRevoked reports whether a Registry JWT or refresh token has been revoked
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	// RecordLoginLockout records in the audit log that a client or identity was locked out after failed logins
+	RecordLoginLockout(ctx context.Context, key, endpoint string, failures int, lockedUntil time.Time) error
+	// RecordAuthEvent records a login, token exchange or permission denial in the auth event log
+	RecordAuthEvent(ctx context.Context, event *database.AuthEvent) error
+	// ListAuthEvents retrieve up to limit auth events matching the filter, newest first
+	ListAuthEvents(ctx context.Context, filter *database.AuthEventFilter, limit int) ([]*database.AuthEvent, error)
+	// ListAuditEvents retrieve up to limit audit events matching the filter, oldest first
+	ListAuditEvents(ctx context.Context, filter *database.AuditEventFilter, limit int) ([]*database.AuditEvent, error)
+	// IncrementFetchCounts records aggregated server fetch counts for a day
+	IncrementFetchCounts(ctx context.Context, day time.Time, counts map[string]int64) error
+	// GetServerFetchStats retrieve daily fetch counts for a server since the given day
+	GetServerFetchStats(ctx context.Context, serverName string, since time.Time) ([]database.DailyFetchCount, error)
+	// GetDailyFetchTotals retrieve registry-wide daily fetch counts since the given day
+	GetDailyFetchTotals(ctx context.Context, since time.Time) ([]database.DailyFetchCount, error)
+	// GetTopFetchedServers retrieve the most fetched servers since the given day
+	GetTopFetchedServers(ctx context.Context, since time.Time, limit int) ([]database.ServerFetchCount, error)
+	// GetMaintenanceMode retrieve the current maintenance mode state
+	GetMaintenanceMode(ctx context.Context) (*database.MaintenanceMode, error)
+	// SetMaintenanceMode enables or disables maintenance mode with the given message
+	SetMaintenanceMode(ctx context.Context, enabled bool, message string) (*database.MaintenanceMode, error)
+	// Ping verifies that the backing database is reachable
+	Ping(ctx context.Context) error
+	// PendingMigrations returns the names of database migrations that have not been applied
+	PendingMigrations(ctx context.Context) ([]string, error)
 }
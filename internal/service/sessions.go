@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+// maxSessionUserAgentLength bounds the user agent kept to help owners recognize their sessions
+const maxSessionUserAgentLength = 255
+
+// Audit log actions for browser sessions
+const (
+	AuditActionSessionCreated = "session.created"
+	AuditActionSessionRevoked = "session.revoked"
+)
+
+// CreateWebSession creates a browser session with the identity and permissions of claims and returns it
+// along with the session cookie value, which is not stored and cannot be retrieved again
+func (s *registryServiceImpl) CreateWebSession(ctx context.Context, claims *auth.JWTClaims, userAgent, clientIP string, expiresAt time.Time) (*database.WebSession, string, error) {
+	secret, err := randomSessionValue()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate session: %w", err)
+	}
+	csrfToken, err := randomSessionValue()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate CSRF token: %w", err)
+	}
+	if len(userAgent) > maxSessionUserAgentLength {
+		userAgent = userAgent[:maxSessionUserAgentLength]
+	}
+
+	owner := string(claims.AuthMethod) + ":" + claims.AuthMethodSubject
+	session, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.WebSession, error) {
+		session := &database.WebSession{
+			Owner:       owner,
+			AuthMethod:  claims.AuthMethod,
+			Subject:     claims.AuthMethodSubject,
+			Permissions: claims.Permissions,
+			Hash:        hashAPIToken(secret),
+			CSRFToken:   csrfToken,
+			UserAgent:   userAgent,
+			ClientIP:    clientIP,
+			ExpiresAt:   expiresAt,
+		}
+		if err := s.db.CreateWebSession(ctx, tx, session); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionSessionCreated,
+			Actor:    owner,
+			Resource: owner,
+			Details:  map[string]string{"sessionId": strconv.FormatInt(session.ID, 10), "clientIp": clientIP},
+		}); err != nil {
+			return nil, err
+		}
+
+		return session, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return session, secret, nil
+}
+
+// ListWebSessions retrieves the active browser sessions of an owner, newest first
+func (s *registryServiceImpl) ListWebSessions(ctx context.Context, owner string) ([]*database.WebSession, error) {
+	return s.db.ListWebSessions(ctx, nil, owner)
+}
+
+// RevokeWebSession revokes a browser session. Unless asAdmin is set, sessions of other owners are treated as not found.
+func (s *registryServiceImpl) RevokeWebSession(ctx context.Context, id int64, actor string, asAdmin bool) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		session, err := s.db.GetWebSession(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if session.Owner != actor && !asAdmin {
+			return database.ErrNotFound
+		}
+
+		if err := s.db.RevokeWebSession(ctx, tx, id); err != nil {
+			return err
+		}
+
+		return s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionSessionRevoked,
+			Actor:    actor,
+			Resource: session.Owner,
+			Details:  map[string]string{"sessionId": strconv.FormatInt(session.ID, 10)},
+		})
+	})
+}
+
+// AuthenticateWebSession looks up an unrevoked, unexpired browser session by its cookie value and records
+// its use. Unknown, revoked and expired sessions all return ErrNotFound.
+func (s *registryServiceImpl) AuthenticateWebSession(ctx context.Context, secret string) (*database.WebSession, error) {
+	if secret == "" {
+		return nil, database.ErrNotFound
+	}
+
+	session, err := s.db.GetWebSessionByHash(ctx, nil, hashAPIToken(secret))
+	if err != nil {
+		return nil, err
+	}
+	if session.RevokedAt != nil || !time.Now().Before(session.ExpiresAt) {
+		return nil, database.ErrNotFound
+	}
+
+	// Failing to record the last use should not end the session
+	if err := s.db.TouchWebSession(ctx, nil, session.ID); err != nil {
+		slog.ErrorContext(ctx, "Failed to record session use", logging.Err(err))
+	}
+
+	return session, nil
+}
+
+// randomSessionValue returns 32 random bytes, URL-safe encoded for use in a cookie or header
+func randomSessionValue() (string, error) {
+	value := make([]byte, 32)
+	if _, err := rand.Read(value); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(value), nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// attachRegistryMetadata adds what the registry stores alongside each server version, its icon and
+// its publisher's signature, to the registry metadata of the servers
+func (s *registryServiceImpl) attachRegistryMetadata(ctx context.Context, servers []*apiv0.ServerResponse) error {
+	if err := s.attachIcons(ctx, servers); err != nil {
+		return err
+	}
+	return s.attachSignatures(ctx, servers)
+}
+
+// attachSignatures includes the publisher's signature, if any, in the registry metadata of each server version
+func (s *registryServiceImpl) attachSignatures(ctx context.Context, servers []*apiv0.ServerResponse) error {
+	if len(servers) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(servers))
+	versions := make([]string, 0, len(servers))
+	for _, server := range servers {
+		names = append(names, server.Server.Name)
+		versions = append(versions, server.Server.Version)
+	}
+
+	signatures, err := s.db.GetServerSignatures(ctx, nil, names, versions)
+	if err != nil {
+		return err
+	}
+
+	for _, server := range servers {
+		if signature, ok := signatures[server.Server.Name][server.Server.Version]; ok && server.Meta.Official != nil {
+			server.Meta.Official.Signature = signature
+		}
+	}
+	return nil
+}
+
+// dropStaleSignature removes the publisher's signature of a server version that is being edited, as it
+// was made over the server.json as published
+func (s *registryServiceImpl) dropStaleSignature(ctx context.Context, tx pgx.Tx, current, updated *apiv0.ServerJSON) error {
+	before, err := apiv0.CanonicalServerJSON(current)
+	if err != nil {
+		return err
+	}
+	after, err := apiv0.CanonicalServerJSON(updated)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(before, after) {
+		return nil
+	}
+	return s.db.DeleteServerSignature(ctx, tx, current.Name, current.Version)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+const (
+	// APITokenPrefix starts every API token so leaked tokens are easy to recognize and scan for
+	APITokenPrefix = "mcpr_"
+
+	// maxAPITokensPerOwner bounds how many unrevoked tokens one account can hold
+	maxAPITokensPerOwner = 50
+
+	// apiTokenDisplayLength is how much of a token is kept to help its owner recognize it
+	apiTokenDisplayLength = len(APITokenPrefix) + 6
+)
+
+// Audit log actions for API tokens
+const (
+	AuditActionTokenCreated = "token.created"
+	AuditActionTokenRevoked = "token.revoked"
+)
+
+// CreateAPIToken creates an API token for owner and returns it along with the token itself,
+// which is not stored and cannot be retrieved again
+func (s *registryServiceImpl) CreateAPIToken(ctx context.Context, owner, name string, permissions []auth.Permission, expiresAt time.Time) (*database.APIToken, string, error) {
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, "", fmt.Errorf("failed to generate API token: %w", err)
+	}
+	secret := APITokenPrefix + base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	token, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.APIToken, error) {
+		count, err := s.db.CountAPITokens(ctx, tx, owner)
+		if err != nil {
+			return nil, err
+		}
+		if count >= maxAPITokensPerOwner {
+			return nil, fmt.Errorf("%w: you can have at most %d API tokens; revoke unused tokens first", database.ErrInvalidInput, maxAPITokensPerOwner)
+		}
+
+		token := &database.APIToken{
+			Owner:       owner,
+			Name:        name,
+			Prefix:      secret[:apiTokenDisplayLength],
+			Hash:        hashAPIToken(secret),
+			Permissions: permissions,
+			ExpiresAt:   expiresAt,
+		}
+		if err := s.db.CreateAPIToken(ctx, tx, token); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionTokenCreated,
+			Actor:    owner,
+			Resource: owner,
+			Details:  map[string]string{"tokenId": strconv.FormatInt(token.ID, 10), "name": name},
+		}); err != nil {
+			return nil, err
+		}
+
+		return token, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return token, secret, nil
+}
+
+// ListAPITokens retrieves the unrevoked API tokens of an owner, newest first
+func (s *registryServiceImpl) ListAPITokens(ctx context.Context, owner string) ([]*database.APIToken, error) {
+	return s.db.ListAPITokens(ctx, nil, owner)
+}
+
+// RevokeAPIToken revokes an API token. Unless asAdmin is set, tokens of other owners are treated as not found.
+func (s *registryServiceImpl) RevokeAPIToken(ctx context.Context, id int64, actor string, asAdmin bool) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		token, err := s.db.GetAPIToken(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if token.Owner != actor && !asAdmin {
+			return database.ErrNotFound
+		}
+
+		if err := s.db.RevokeAPIToken(ctx, tx, id); err != nil {
+			return err
+		}
+
+		return s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionTokenRevoked,
+			Actor:    actor,
+			Resource: token.Owner,
+			Details:  map[string]string{"tokenId": strconv.FormatInt(token.ID, 10), "name": token.Name},
+		})
+	})
+}
+
+// AuthenticateAPIToken looks up an unrevoked, unexpired API token and records its use.
+// Unknown, revoked and expired tokens all return ErrNotFound.
+func (s *registryServiceImpl) AuthenticateAPIToken(ctx context.Context, secret string) (*database.APIToken, error) {
+	if !strings.HasPrefix(secret, APITokenPrefix) {
+		return nil, database.ErrNotFound
+	}
+
+	token, err := s.db.GetAPITokenByHash(ctx, nil, hashAPIToken(secret))
+	if err != nil {
+		return nil, err
+	}
+	if token.RevokedAt != nil || !time.Now().Before(token.ExpiresAt) {
+		return nil, database.ErrNotFound
+	}
+
+	// Failing to record the last use should not stop the token from working
+	if err := s.db.TouchAPIToken(ctx, nil, token.ID); err != nil {
+		slog.ErrorContext(ctx, "Failed to record API token use", logging.Err(err))
+	}
+
+	return token, nil
+}
+
+// hashAPIToken returns the hex SHA-256 of a token. Tokens are random, so a fast unsalted hash is enough.
+func hashAPIToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
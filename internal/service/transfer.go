@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// serverTransferTTL is how long the new owner has to accept a transfer
+const serverTransferTTL = 7 * 24 * time.Hour
+
+// Audit log actions for server transfers
+const (
+	AuditActionTransferInitiated = "server.transfer.initiated"
+	AuditActionTransferCancelled = "server.transfer.cancelled"
+	AuditActionTransferAccepted  = "server.transfer.accepted"
+)
+
+// InitiateServerTransfer starts a transfer of a server to a new name, replacing any pending transfer.
+// The transfer only takes effect once the owner of the new name accepts it.
+func (s *registryServiceImpl) InitiateServerTransfer(ctx context.Context, serverName, newName, actor string) (*database.ServerTransfer, error) {
+	if err := validators.ValidateServerName(newName); err != nil {
+		return nil, fmt.Errorf("%w: %w", database.ErrInvalidInput, err)
+	}
+	if newName == serverName {
+		return nil, fmt.Errorf("%w: new name must differ from the current name", database.ErrInvalidInput)
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*database.ServerTransfer, error) {
+		if _, err := s.db.GetServerByName(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+		if err := s.ensureServerNameAvailable(ctx, tx, newName); err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		transfer := &database.ServerTransfer{
+			ServerName:  serverName,
+			NewName:     newName,
+			InitiatedBy: actor,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(serverTransferTTL),
+		}
+		if err := s.db.CreateServerTransfer(ctx, tx, transfer); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionTransferInitiated,
+			Actor:    actor,
+			Resource: serverName,
+			Details:  map[string]string{"newName": newName},
+		}); err != nil {
+			return nil, err
+		}
+
+		return transfer, nil
+	})
+}
+
+// GetServerTransfer retrieves the pending transfer of a server, treating expired transfers as not found
+func (s *registryServiceImpl) GetServerTransfer(ctx context.Context, serverName string) (*database.ServerTransfer, error) {
+	return s.getPendingTransfer(ctx, nil, serverName)
+}
+
+// CancelServerTransfer cancels the pending transfer of a server
+func (s *registryServiceImpl) CancelServerTransfer(ctx context.Context, serverName, actor string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		transfer, err := s.db.GetServerTransfer(ctx, tx, serverName)
+		if err != nil {
+			return err
+		}
+		if err := s.db.DeleteServerTransfer(ctx, tx, serverName); err != nil {
+			return err
+		}
+
+		return s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionTransferCancelled,
+			Actor:    actor,
+			Resource: serverName,
+			Details:  map[string]string{"newName": transfer.NewName},
+		})
+	})
+}
+
+// AcceptServerTransfer completes the pending transfer of a server. Every version moves to the new
+// name with its history, status and publish dates intact.
+func (s *registryServiceImpl) AcceptServerTransfer(ctx context.Context, serverName, actor string) (*apiv0.ServerResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		transfer, err := s.getPendingTransfer(ctx, tx, serverName)
+		if err != nil {
+			return nil, err
+		}
+
+		// Prevent versions being published under either name mid-transfer
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+		if err := s.db.AcquirePublishLock(ctx, tx, transfer.NewName); err != nil {
+			return nil, err
+		}
+		if err := s.ensureServerNameAvailable(ctx, tx, transfer.NewName); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RenameServer(ctx, tx, serverName, transfer.NewName); err != nil {
+			return nil, err
+		}
+		if err := s.recordTransferChanges(ctx, tx, serverName, transfer.NewName); err != nil {
+			return nil, err
+		}
+		if err := s.db.DeleteServerTransfer(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.RecordAuditEvent(ctx, tx, &database.AuditEvent{
+			Action:   AuditActionTransferAccepted,
+			Actor:    actor,
+			Resource: serverName,
+			Details:  map[string]string{"newName": transfer.NewName, "initiatedBy": transfer.InitiatedBy},
+		}); err != nil {
+			return nil, err
+		}
+
+		return s.db.GetServerByName(ctx, tx, transfer.NewName)
+	})
+}
+
+// recordTransferChanges records a transfer in the change feed as every version being deleted under the
+// old name and published under the new one, so mirrors that know nothing of transfers stay consistent
+func (s *registryServiceImpl) recordTransferChanges(ctx context.Context, tx pgx.Tx, oldName, newName string) error {
+	versions, err := s.db.GetAllVersionsByServerName(ctx, tx, newName)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]*database.ServerChange, 0, 2*len(versions))
+	for _, server := range versions {
+		changes = append(changes, &database.ServerChange{Type: database.ChangeTypeDelete, ServerName: oldName, Version: server.Server.Version})
+	}
+	for _, server := range versions {
+		changes = append(changes, &database.ServerChange{Type: database.ChangeTypePublish, ServerName: newName, Version: server.Server.Version})
+	}
+	return s.recordServerChanges(ctx, tx, changes)
+}
+
+// getPendingTransfer retrieves the transfer of a server, treating expired transfers as not found
+func (s *registryServiceImpl) getPendingTransfer(ctx context.Context, tx pgx.Tx, serverName string) (*database.ServerTransfer, error) {
+	transfer, err := s.db.GetServerTransfer(ctx, tx, serverName)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(transfer.ExpiresAt) {
+		return nil, database.ErrNotFound
+	}
+	return transfer, nil
+}
+
+// ensureServerNameAvailable fails with ErrAlreadyExists if any version has been published under name
+func (s *registryServiceImpl) ensureServerNameAvailable(ctx context.Context, tx pgx.Tx, name string) error {
+	count, err := s.db.CountServerVersions(ctx, tx, name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: a server named %s already exists", database.ErrAlreadyExists, name)
+	}
+	return nil
+}
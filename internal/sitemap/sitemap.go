@@ -0,0 +1,258 @@
+// Package sitemap generates sitemap.xml documents listing server detail URLs
+package sitemap
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	// MaxURLsPerSitemap is the maximum number of URLs allowed in a single sitemap file by the sitemaps.org protocol
+	MaxURLsPerSitemap = 50000
+
+	// DefaultCacheTTL is how long a generated sitemap is served before being regenerated
+	DefaultCacheTTL = time.Hour
+
+	// pageSize is the number of servers fetched per database query while building the sitemap
+	pageSize = 1000
+
+	xmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+// ServerLister is the subset of the registry service needed to build a sitemap
+type ServerLister interface {
+	ListServers(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
+}
+
+// Entry is a single server detail page in the sitemap
+type Entry struct {
+	ServerName string
+	LastMod    time.Time
+}
+
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []urlTag `xml:"url"`
+}
+
+type urlTag struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapTag `xml:"sitemap"`
+}
+
+type sitemapTag struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Generator builds and caches the list of sitemap entries
+type Generator struct {
+	lister     ServerLister
+	publicURL  string
+	ttl        time.Duration
+	maxPerFile int
+
+	mu          sync.Mutex
+	entries     []Entry
+	generatedAt time.Time
+}
+
+// NewGenerator creates a new sitemap generator. URLs are built from publicURL, never from request headers,
+// so that clients cannot get other hosts into sitemaps that caches then serve to everyone. Without a
+// publicURL, no sitemap is served.
+func NewGenerator(lister ServerLister, publicURL string) *Generator {
+	return &Generator{
+		lister:     lister,
+		publicURL:  strings.TrimSuffix(publicURL, "/"),
+		ttl:        DefaultCacheTTL,
+		maxPerFile: MaxURLsPerSitemap,
+	}
+}
+
+// SetMaxURLsPerFile overrides the number of URLs per sitemap file (used for testing)
+func (g *Generator) SetMaxURLsPerFile(n int) {
+	g.maxPerFile = n
+}
+
+// Entries returns the cached sitemap entries, regenerating them if the cache has expired
+func (g *Generator) Entries(ctx context.Context) ([]Entry, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.entries != nil && time.Since(g.generatedAt) < g.ttl {
+		return g.entries, nil
+	}
+
+	entries, err := g.collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	g.entries = entries
+	g.generatedAt = time.Now()
+	return g.entries, nil
+}
+
+// collect pages through all latest server versions, skipping deleted servers
+func (g *Generator) collect(ctx context.Context) ([]Entry, error) {
+	isLatest := true
+	filter := &database.ServerFilter{IsLatest: &isLatest}
+
+	entries := []Entry{}
+	cursor := ""
+	for {
+		servers, nextCursor, err := g.lister.ListServers(ctx, filter, cursor, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+
+		for _, server := range servers {
+			lastMod := time.Time{}
+			if server.Meta.Official != nil {
+				if server.Meta.Official.Status == model.StatusDeleted {
+					continue
+				}
+				lastMod = server.Meta.Official.UpdatedAt
+			}
+			entries = append(entries, Entry{
+				ServerName: server.Server.Name,
+				LastMod:    lastMod,
+			})
+		}
+
+		if nextCursor == "" || len(servers) == 0 {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	return entries, nil
+}
+
+// ServerURL returns the public detail URL for a server
+func ServerURL(baseURL, serverName string) string {
+	return baseURL + "/v1/servers/" + url.PathEscape(serverName) + "/versions/latest"
+}
+
+// IndexHandler serves /sitemap.xml. Small catalogs get a single urlset; catalogs larger
+// than the per-file limit get a sitemap index pointing at /sitemaps/{n}.xml.
+func (g *Generator) IndexHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if g.publicURL == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		entries, err := g.Entries(r.Context())
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Failed to generate sitemap", logging.Err(err))
+			http.Error(w, "Failed to generate sitemap", http.StatusInternalServerError)
+			return
+		}
+
+		base := g.publicURL
+		if len(entries) <= g.maxPerFile {
+			writeXML(w, buildURLSet(base, entries))
+			return
+		}
+
+		index := sitemapIndex{Xmlns: xmlns}
+		for page, start := 1, 0; start < len(entries); page, start = page+1, start+g.maxPerFile {
+			end := min(start+g.maxPerFile, len(entries))
+			index.Sitemaps = append(index.Sitemaps, sitemapTag{
+				Loc:     fmt.Sprintf("%s/sitemaps/%d.xml", base, page),
+				LastMod: formatLastMod(latestLastMod(entries[start:end])),
+			})
+		}
+		writeXML(w, index)
+	})
+}
+
+// PageHandler serves /sitemaps/{file}, where file is "<page>.xml" (1-indexed)
+func (g *Generator) PageHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file := r.PathValue("file")
+		page, err := strconv.Atoi(strings.TrimSuffix(file, ".xml"))
+		if err != nil || !strings.HasSuffix(file, ".xml") || page < 1 || g.publicURL == "" {
+			http.NotFound(w, r)
+			return
+		}
+
+		entries, err := g.Entries(r.Context())
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Failed to generate sitemap", logging.Err(err))
+			http.Error(w, "Failed to generate sitemap", http.StatusInternalServerError)
+			return
+		}
+
+		start := (page - 1) * g.maxPerFile
+		if start >= len(entries) {
+			http.NotFound(w, r)
+			return
+		}
+		end := min(start+g.maxPerFile, len(entries))
+
+		writeXML(w, buildURLSet(g.publicURL, entries[start:end]))
+	})
+}
+
+func buildURLSet(base string, entries []Entry) urlSet {
+	set := urlSet{Xmlns: xmlns, URLs: make([]urlTag, 0, len(entries))}
+	for _, entry := range entries {
+		set.URLs = append(set.URLs, urlTag{
+			Loc:     ServerURL(base, entry.ServerName),
+			LastMod: formatLastMod(entry.LastMod),
+		})
+	}
+	return set
+}
+
+func latestLastMod(entries []Entry) time.Time {
+	var latest time.Time
+	for _, entry := range entries {
+		if entry.LastMod.After(latest) {
+			latest = entry.LastMod
+		}
+	}
+	return latest
+}
+
+func formatLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func writeXML(w http.ResponseWriter, v any) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,166 @@
+package sitemap_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/sitemap"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// fakeLister pages through a fixed list of servers using the index as the cursor
+type fakeLister struct {
+	servers []*apiv0.ServerResponse
+	calls   int
+}
+
+func (f *fakeLister) ListServers(_ context.Context, _ *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
+	f.calls++
+	start := 0
+	if cursor != "" {
+		_, _ = fmt.Sscanf(cursor, "%d", &start)
+	}
+	end := min(start+limit, len(f.servers))
+	next := ""
+	if end < len(f.servers) {
+		next = fmt.Sprintf("%d", end)
+	}
+	return f.servers[start:end], next, nil
+}
+
+func newServer(name string, status model.Status, updatedAt time.Time) *apiv0.ServerResponse {
+	return &apiv0.ServerResponse{
+		Server: apiv0.ServerJSON{Name: name, Version: "1.0.0"},
+		Meta: apiv0.ResponseMeta{
+			Official: &apiv0.RegistryExtensions{
+				Status:    status,
+				UpdatedAt: updatedAt,
+				IsLatest:  true,
+			},
+		},
+	}
+}
+
+func TestSitemapIndexHandler_SingleFile(t *testing.T) {
+	updated := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	lister := &fakeLister{servers: []*apiv0.ServerResponse{
+		newServer("com.example/alpha", model.StatusActive, updated),
+		newServer("com.example/deleted", model.StatusDeleted, updated),
+		newServer("io.github.user/beta", model.StatusDeprecated, updated),
+	}}
+	generator := sitemap.NewGenerator(lister, "https://registry.example.com/")
+
+	req := httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil)
+	w := httptest.NewRecorder()
+	generator.IndexHandler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+
+	body := w.Body.String()
+	assert.Contains(t, body, "<urlset")
+	assert.Contains(t, body, "<loc>https://registry.example.com/v1/servers/com.example%2Falpha/versions/latest</loc>")
+	assert.Contains(t, body, "<loc>https://registry.example.com/v1/servers/io.github.user%2Fbeta/versions/latest</loc>")
+	assert.Contains(t, body, "<lastmod>2025-09-01T12:00:00Z</lastmod>")
+	assert.NotContains(t, body, "deleted")
+}
+
+func TestSitemapIndexHandler_IgnoresRequestHost(t *testing.T) {
+	lister := &fakeLister{servers: []*apiv0.ServerResponse{
+		newServer("com.example/alpha", model.StatusActive, time.Now()),
+	}}
+	generator := sitemap.NewGenerator(lister, "https://registry.example.com")
+
+	req := httptest.NewRequest(http.MethodGet, "http://attacker.example/sitemap.xml", nil)
+	req.Header.Set("X-Forwarded-Proto", "http")
+	w := httptest.NewRecorder()
+	generator.IndexHandler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<loc>https://registry.example.com/v1/servers/com.example%2Falpha/versions/latest</loc>")
+	assert.NotContains(t, w.Body.String(), "attacker.example")
+}
+
+func TestSitemapIndexHandler_RequiresPublicURL(t *testing.T) {
+	lister := &fakeLister{servers: []*apiv0.ServerResponse{
+		newServer("com.example/alpha", model.StatusActive, time.Now()),
+	}}
+	generator := sitemap.NewGenerator(lister, "")
+
+	w := httptest.NewRecorder()
+	generator.IndexHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://localhost:8080/sitemap.xml", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSitemapIndexHandler_SplitsLargeCatalogs(t *testing.T) {
+	var servers []*apiv0.ServerResponse
+	for i := 0; i < 5; i++ {
+		servers = append(servers, newServer(fmt.Sprintf("com.example/server-%d", i), model.StatusActive, time.Now()))
+	}
+	lister := &fakeLister{servers: servers}
+	generator := sitemap.NewGenerator(lister, "https://registry.example.com")
+	generator.SetMaxURLsPerFile(2)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /sitemap.xml", generator.IndexHandler())
+	mux.Handle("GET /sitemaps/{file}", generator.PageHandler())
+
+	req := httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, "<sitemapindex")
+	assert.Equal(t, 3, strings.Count(body, "<sitemap>"))
+	assert.Contains(t, body, "<loc>https://registry.example.com/sitemaps/3.xml</loc>")
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedURLs   int
+	}{
+		{path: "/sitemaps/1.xml", expectedStatus: http.StatusOK, expectedURLs: 2},
+		{path: "/sitemaps/3.xml", expectedStatus: http.StatusOK, expectedURLs: 1},
+		{path: "/sitemaps/4.xml", expectedStatus: http.StatusNotFound},
+		{path: "/sitemaps/0.xml", expectedStatus: http.StatusNotFound},
+		{path: "/sitemaps/abc", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedURLs, strings.Count(w.Body.String(), "<url>"))
+			}
+		})
+	}
+}
+
+func TestSitemapEntries_Cached(t *testing.T) {
+	lister := &fakeLister{servers: []*apiv0.ServerResponse{
+		newServer("com.example/alpha", model.StatusActive, time.Now()),
+	}}
+	generator := sitemap.NewGenerator(lister, "https://registry.example.com")
+
+	_, err := generator.Entries(context.Background())
+	require.NoError(t, err)
+	_, err = generator.Entries(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, lister.calls, "second call should be served from cache")
+}
@@ -0,0 +1,119 @@
+// Package stats aggregates server fetch counts for usage statistics
+package stats
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+// DefaultFlushInterval is how often buffered fetch counts are written to the store
+const DefaultFlushInterval = time.Minute
+
+// Store persists aggregated fetch counts
+type Store interface {
+	IncrementFetchCounts(ctx context.Context, day time.Time, counts map[string]int64) error
+}
+
+// Recorder buffers per-server fetch counts in memory and periodically flushes them
+// to the store as daily aggregates. No information about the caller is kept, so
+// the resulting statistics are privacy-preserving by construction.
+type Recorder struct {
+	store    Store
+	interval time.Duration
+	now      func() time.Time
+
+	mu     sync.Mutex
+	counts map[time.Time]map[string]int64
+}
+
+// NewRecorder creates a new fetch count recorder
+func NewRecorder(store Store) *Recorder {
+	return &Recorder{
+		store:    store,
+		interval: DefaultFlushInterval,
+		now:      time.Now,
+		counts:   make(map[time.Time]map[string]int64),
+	}
+}
+
+// SetClock overrides the time source (used for testing)
+func (r *Recorder) SetClock(now func() time.Time) {
+	r.now = now
+}
+
+// RecordFetch counts a single fetch of the given server
+func (r *Recorder) RecordFetch(serverName string) {
+	if r == nil || serverName == "" {
+		return
+	}
+
+	day := truncateToDay(r.now())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.counts[day] == nil {
+		r.counts[day] = make(map[string]int64)
+	}
+	r.counts[day][serverName]++
+}
+
+// Flush writes all buffered counts to the store. Counts that fail to be written
+// are kept in the buffer and retried on the next flush.
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.counts
+	r.counts = make(map[time.Time]map[string]int64)
+	r.mu.Unlock()
+
+	var firstErr error
+	for day, counts := range pending {
+		if err := r.store.IncrementFetchCounts(ctx, day, counts); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			r.requeue(day, counts)
+		}
+	}
+
+	return firstErr
+}
+
+// requeue adds counts back into the buffer after a failed flush
+func (r *Recorder) requeue(day time.Time, counts map[string]int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.counts[day] == nil {
+		r.counts[day] = make(map[string]int64)
+	}
+	for name, count := range counts {
+		r.counts[day][name] += count
+	}
+}
+
+// Run flushes buffered counts every interval until ctx is cancelled
+func (r *Recorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				slog.ErrorContext(ctx, "Failed to flush fetch statistics", logging.Err(err))
+			}
+		}
+	}
+}
+
+func truncateToDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
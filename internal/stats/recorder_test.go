@@ -0,0 +1,92 @@
+package stats_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/stats"
+)
+
+type fakeStore struct {
+	mu     sync.Mutex
+	counts map[time.Time]map[string]int64
+	err    error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{counts: make(map[time.Time]map[string]int64)}
+}
+
+func (f *fakeStore) IncrementFetchCounts(_ context.Context, day time.Time, counts map[string]int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return f.err
+	}
+	if f.counts[day] == nil {
+		f.counts[day] = make(map[string]int64)
+	}
+	for name, count := range counts {
+		f.counts[day][name] += count
+	}
+	return nil
+}
+
+func TestRecorder_FlushAggregatesByDay(t *testing.T) {
+	store := newFakeStore()
+	recorder := stats.NewRecorder(store)
+
+	now := time.Date(2025, 9, 1, 23, 59, 0, 0, time.UTC)
+	recorder.SetClock(func() time.Time { return now })
+
+	recorder.RecordFetch("com.example/alpha")
+	recorder.RecordFetch("com.example/alpha")
+	recorder.RecordFetch("com.example/beta")
+	recorder.RecordFetch("")
+
+	now = now.Add(2 * time.Minute)
+	recorder.RecordFetch("com.example/alpha")
+
+	require.NoError(t, recorder.Flush(context.Background()))
+
+	day1 := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, map[string]int64{"com.example/alpha": 2, "com.example/beta": 1}, store.counts[day1])
+	assert.Equal(t, map[string]int64{"com.example/alpha": 1}, store.counts[day2])
+
+	// A second flush with nothing buffered should not change anything
+	require.NoError(t, recorder.Flush(context.Background()))
+	assert.Len(t, store.counts, 2)
+}
+
+func TestRecorder_FlushRetriesAfterError(t *testing.T) {
+	store := newFakeStore()
+	store.err = errors.New("database unavailable")
+	recorder := stats.NewRecorder(store)
+
+	recorder.RecordFetch("com.example/alpha")
+	require.Error(t, recorder.Flush(context.Background()))
+	assert.Empty(t, store.counts)
+
+	store.err = nil
+	recorder.RecordFetch("com.example/alpha")
+	require.NoError(t, recorder.Flush(context.Background()))
+
+	var total int64
+	for _, counts := range store.counts {
+		total += counts["com.example/alpha"]
+	}
+	assert.Equal(t, int64(2), total)
+}
+
+func TestRecorder_NilIsNoop(t *testing.T) {
+	var recorder *stats.Recorder
+	assert.NotPanics(t, func() { recorder.RecordFetch("com.example/alpha") })
+}
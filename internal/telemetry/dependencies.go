@@ -0,0 +1,193 @@
+package telemetry
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DependencyWindow is how far back the statistics of external dependencies reach
+	DependencyWindow = 15 * time.Minute
+
+	// dependencyBucketWidth is the granularity at which requests leave the window
+	dependencyBucketWidth = time.Minute
+
+	dependencyBuckets = int(DependencyWindow / dependencyBucketWidth)
+)
+
+// dependencyHosts names the external services the registry depends on by the hosts they are reached at.
+// Requests to other hosts, such as the issuers of OIDC tokens, are tracked under their host name.
+var dependencyHosts = map[string]string{
+	"registry-1.docker.io":                "docker-hub",
+	"auth.docker.io":                      "docker-hub",
+	"index.docker.io":                     "docker-hub",
+	"ghcr.io":                             "ghcr",
+	"registry.npmjs.org":                  "npm",
+	"pypi.org":                            "pypi",
+	"api.nuget.org":                       "nuget",
+	"api.github.com":                      "github-api",
+	"github.com":                          "github-api",
+	"token.actions.githubusercontent.com": "github-actions-oidc",
+}
+
+// PackageRegistryDependencies names the package registries that publishes are validated against
+var PackageRegistryDependencies = []string{"docker-hub", "ghcr", "npm", "nuget", "pypi"}
+
+// DependencyName returns the name that requests to host are tracked under
+func DependencyName(host string) string {
+	host = strings.ToLower(host)
+	if name, ok := dependencyHosts[host]; ok {
+		return name
+	}
+	return host
+}
+
+// DependencyStats summarizes the requests made to an external dependency within DependencyWindow
+type DependencyStats struct {
+	Name          string     `json:"name" example:"npm" doc:"Dependency name, or the host name for hosts that are not known dependencies"`
+	Requests      int64      `json:"requests" doc:"Requests made within the window"`
+	Failures      int64      `json:"failures" doc:"Requests that failed to connect or returned 429 or a 5xx status within the window"`
+	SuccessRate   float64    `json:"successRate" example:"0.98" doc:"Share of requests within the window that succeeded, or 1 without requests"`
+	AvgLatencyMs  float64    `json:"avgLatencyMs" doc:"Mean request latency within the window in milliseconds"`
+	MaxLatencyMs  float64    `json:"maxLatencyMs" doc:"Slowest request latency within the window in milliseconds"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty" doc:"When a request last succeeded"`
+	LastFailureAt *time.Time `json:"lastFailureAt,omitempty" doc:"When a request last failed"`
+	LastError     string     `json:"lastError,omitempty" example:"503 Service Unavailable" doc:"Error of the last failed request"`
+}
+
+// dependencyBucket counts the requests made to a dependency in one dependencyBucketWidth
+type dependencyBucket struct {
+	start      time.Time
+	requests   int64
+	failures   int64
+	latency    time.Duration
+	maxLatency time.Duration
+}
+
+type dependencyState struct {
+	buckets       [dependencyBuckets]dependencyBucket
+	lastSuccessAt time.Time
+	lastFailureAt time.Time
+	lastError     string
+}
+
+// DependencyTracker keeps rolling success rates and latencies of the external services the registry
+// calls, such as package registries and OIDC issuers, so operators can tell whether publish failures
+// are caused by an upstream outage
+type DependencyTracker struct {
+	mu           sync.Mutex
+	dependencies map[string]*dependencyState
+}
+
+// NewDependencyTracker creates a tracker without recorded requests
+func NewDependencyTracker() *DependencyTracker {
+	return &DependencyTracker{
+		dependencies: make(map[string]*dependencyState),
+	}
+}
+
+// Dependencies tracks the outgoing requests of the process once its transport is installed as
+// http.DefaultTransport
+var Dependencies = NewDependencyTracker()
+
+// Record records a request to dependency that took latency and failed with failure, if it is not empty
+func (t *DependencyTracker) Record(dependency string, latency time.Duration, failure string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.dependencies[dependency]
+	if !ok {
+		state = &dependencyState{}
+		t.dependencies[dependency] = state
+	}
+
+	now := time.Now()
+	start := now.Truncate(dependencyBucketWidth)
+	bucket := &state.buckets[(start.Unix()/int64(dependencyBucketWidth.Seconds()))%int64(dependencyBuckets)]
+	if !bucket.start.Equal(start) {
+		*bucket = dependencyBucket{start: start}
+	}
+	bucket.requests++
+	bucket.latency += latency
+	bucket.maxLatency = max(bucket.maxLatency, latency)
+	if failure != "" {
+		bucket.failures++
+		state.lastFailureAt = now
+		state.lastError = failure
+	} else {
+		state.lastSuccessAt = now
+	}
+}
+
+// Snapshot returns the statistics of each dependency requests have been made to, ordered by name
+func (t *DependencyTracker) Snapshot() []DependencyStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	oldest := time.Now().Truncate(dependencyBucketWidth).Add(-DependencyWindow + dependencyBucketWidth)
+	snapshot := make([]DependencyStats, 0, len(t.dependencies))
+	for name, state := range t.dependencies {
+		stats := DependencyStats{Name: name, SuccessRate: 1, LastError: state.lastError}
+		var latency, maxLatency time.Duration
+		for _, bucket := range state.buckets {
+			if bucket.start.Before(oldest) {
+				continue
+			}
+			stats.Requests += bucket.requests
+			stats.Failures += bucket.failures
+			latency += bucket.latency
+			maxLatency = max(maxLatency, bucket.maxLatency)
+		}
+		if stats.Requests > 0 {
+			stats.SuccessRate = float64(stats.Requests-stats.Failures) / float64(stats.Requests)
+			stats.AvgLatencyMs = durationMilliseconds(latency / time.Duration(stats.Requests))
+			stats.MaxLatencyMs = durationMilliseconds(maxLatency)
+		}
+		if !state.lastSuccessAt.IsZero() {
+			lastSuccessAt := state.lastSuccessAt.UTC()
+			stats.LastSuccessAt = &lastSuccessAt
+		}
+		if !state.lastFailureAt.IsZero() {
+			lastFailureAt := state.lastFailureAt.UTC()
+			stats.LastFailureAt = &lastFailureAt
+		}
+		snapshot = append(snapshot, stats)
+	}
+	slices.SortFunc(snapshot, func(a, b DependencyStats) int { return strings.Compare(a.Name, b.Name) })
+	return snapshot
+}
+
+func durationMilliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// Transport returns a transport that makes requests with base and records them by the dependency
+// of their host. Requests that fail to complete or return 429 or a 5xx status count as failures.
+func (t *DependencyTracker) Transport(base http.RoundTripper) http.RoundTripper {
+	return dependencyTransport{tracker: t, base: base}
+}
+
+type dependencyTransport struct {
+	tracker *DependencyTracker
+	base    http.RoundTripper
+}
+
+func (d dependencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := d.base.RoundTrip(req)
+	latency := time.Since(start)
+
+	var failure string
+	switch {
+	case err != nil:
+		failure = err.Error()
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+		failure = strconv.Itoa(resp.StatusCode) + " " + http.StatusText(resp.StatusCode)
+	}
+	d.tracker.Record(DependencyName(req.URL.Hostname()), latency, failure)
+	return resp, err
+}
@@ -0,0 +1,91 @@
+package telemetry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// hostRewriter sends requests to a test server, as if it served every host
+type hostRewriter struct {
+	target *url.URL
+}
+
+func (h hostRewriter) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = h.target.Scheme, h.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestDependencyTracker_Transport(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer upstream.Close()
+	target, err := url.Parse(upstream.URL)
+	require.NoError(t, err)
+
+	tracker := telemetry.NewDependencyTracker()
+	client := &http.Client{Transport: tracker.Transport(hostRewriter{target: target})}
+	for _, rawURL := range []string{
+		"https://registry.npmjs.org/ok",
+		"https://registry.npmjs.org/missing",
+		"https://registry.npmjs.org/unavailable",
+		"https://registry.npmjs.org/limited",
+		"https://auth.docker.io/token",
+		"https://registry-1.docker.io/v2/",
+		"https://issuer.example.com/.well-known/openid-configuration",
+	} {
+		resp, err := client.Get(rawURL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	unreachable := &http.Client{Transport: tracker.Transport(hostRewriter{target: &url.URL{Scheme: "http", Host: "127.0.0.1:1"}})}
+	_, err = unreachable.Get("https://ghcr.io/v2/")
+	require.Error(t, err)
+
+	snapshot := tracker.Snapshot()
+	names := make([]string, 0, len(snapshot))
+	for _, stats := range snapshot {
+		names = append(names, stats.Name)
+	}
+	require.Equal(t, []string{"docker-hub", "ghcr", "issuer.example.com", "npm"}, names)
+
+	dockerHub, ghcr, npm := snapshot[0], snapshot[1], snapshot[3]
+	assert.Equal(t, int64(2), dockerHub.Requests)
+	assert.InDelta(t, 1, dockerHub.SuccessRate, 0)
+	assert.Nil(t, dockerHub.LastFailureAt)
+
+	assert.Equal(t, int64(1), ghcr.Failures)
+	assert.InDelta(t, 0, ghcr.SuccessRate, 0)
+	assert.Nil(t, ghcr.LastSuccessAt)
+	assert.NotEmpty(t, ghcr.LastError)
+
+	assert.Equal(t, int64(4), npm.Requests)
+	assert.Equal(t, int64(2), npm.Failures, "a missing package is not an outage")
+	assert.InDelta(t, 0.5, npm.SuccessRate, 0)
+	assert.Equal(t, "429 Too Many Requests", npm.LastError)
+	assert.WithinDuration(t, time.Now(), *npm.LastSuccessAt, time.Minute)
+	assert.GreaterOrEqual(t, npm.MaxLatencyMs, npm.AvgLatencyMs)
+}
+
+func TestDependencyName(t *testing.T) {
+	assert.Equal(t, "github-api", telemetry.DependencyName("API.GitHub.com"))
+	assert.Equal(t, "pypi", telemetry.DependencyName("pypi.org"))
+	assert.Equal(t, "gitlab.com", telemetry.DependencyName("gitlab.com"))
+}
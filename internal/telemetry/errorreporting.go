@@ -0,0 +1,87 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+
+	"github.com/modelcontextprotocol/registry/internal/logging"
+)
+
+// errorReportingFlushTimeout bounds sending the error reports still queued at shutdown
+const errorReportingFlushTimeout = 5 * time.Second
+
+// InitErrorReporting sends panics and errors that produce 5xx responses to the Sentry-compatible service
+// of dsn, tagged with the release and commit of this build. sampleRate, from 0 (exclusive) to 1, is the
+// share of errors that are sent. Without a DSN, errors are not reported.
+func InitErrorReporting(dsn string, sampleRate float64, release, commit string) (ShutdownFunc, error) {
+	shutdown := func(_ context.Context) error { return nil }
+	if dsn == "" {
+		return shutdown, nil
+	}
+	// The SDK treats a sample rate of 0 as 1
+	if sampleRate <= 0 || sampleRate > 1 {
+		return shutdown, fmt.Errorf("invalid error reporting sample rate %v: must be greater than 0 and at most 1", sampleRate)
+	}
+
+	if err := sentry.Init(sentry.ClientOptions{
+		Dsn:        dsn,
+		SampleRate: sampleRate,
+		Release:    release,
+		ServerName: Namespace,
+	}); err != nil {
+		return shutdown, fmt.Errorf("failed to initialize error reporting: %w", err)
+	}
+	sentry.ConfigureScope(func(scope *sentry.Scope) {
+		scope.SetTag("commit", commit)
+	})
+
+	return func(ctx context.Context) error {
+		timeout := errorReportingFlushTimeout
+		if deadline, ok := ctx.Deadline(); ok {
+			timeout = time.Until(deadline)
+		}
+		if !sentry.Flush(timeout) {
+			return errors.New("timed out sending error reports")
+		}
+		return nil
+	}, nil
+}
+
+// ReportError sends err to the error reporting service, if one is configured, tagged with the request
+// fields carried in ctx, such as the request ID and caller, and with tags
+func ReportError(ctx context.Context, err error, tags map[string]string) {
+	hub := requestHub(ctx, tags)
+	if hub == nil {
+		return
+	}
+	hub.CaptureException(err)
+}
+
+// ReportPanic sends a recovered panic to the error reporting service, if one is configured, as for
+// ReportError
+func ReportPanic(ctx context.Context, recovered any, tags map[string]string) {
+	hub := requestHub(ctx, tags)
+	if hub == nil {
+		return
+	}
+	hub.RecoverWithContext(ctx, recovered)
+}
+
+// requestHub returns a hub for reporting an error of a request, or nil if error reporting is disabled
+func requestHub(ctx context.Context, tags map[string]string) *sentry.Hub {
+	if sentry.CurrentHub().Client() == nil {
+		return nil
+	}
+	hub := sentry.CurrentHub().Clone()
+	hub.ConfigureScope(func(scope *sentry.Scope) {
+		for _, attr := range logging.Attrs(ctx) {
+			scope.SetTag(attr.Key, attr.Value.String())
+		}
+		scope.SetTags(tags)
+	})
+	return hub
+}
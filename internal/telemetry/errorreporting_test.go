@@ -0,0 +1,67 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/logging"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+func TestInitErrorReporting(t *testing.T) {
+	t.Run("disabled without a DSN", func(t *testing.T) {
+		shutdown, err := telemetry.InitErrorReporting("", 1, "v1.0.0", "abc123")
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+		// Reporting without a service is a no-op
+		telemetry.ReportError(context.Background(), errors.New("ignored"), nil)
+	})
+
+	t.Run("invalid sample rate", func(t *testing.T) {
+		_, err := telemetry.InitErrorReporting("https://key@sentry.example.com/1", 0, "v1.0.0", "abc123")
+		assert.ErrorContains(t, err, "invalid error reporting sample rate")
+	})
+}
+
+func TestReportError(t *testing.T) {
+	var mu sync.Mutex
+	var envelopes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		envelopes = append(envelopes, string(body))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	t.Cleanup(func() { sentry.CurrentHub().BindClient(nil) })
+
+	dsn := strings.Replace(server.URL, "http://", "http://public@", 1) + "/1"
+	shutdown, err := telemetry.InitErrorReporting(dsn, 1, "v1.2.3", "abc123")
+	require.NoError(t, err)
+
+	ctx := logging.With(context.Background(), logging.RequestIDKey, "req-42", logging.ActorKey, "github-at:octocat")
+	telemetry.ReportError(ctx, errors.New("failed to publish server"), map[string]string{"route": "/v0/publish"})
+	require.NoError(t, shutdown(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, envelopes, 1)
+	event := envelopes[0]
+	assert.Contains(t, event, "failed to publish server")
+	assert.Contains(t, event, `"release":"v1.2.3"`)
+	assert.Contains(t, event, `"commit":"abc123"`)
+	assert.Contains(t, event, `"request_id":"req-42"`)
+	assert.Contains(t, event, `"actor":"github-at:octocat"`)
+	assert.Contains(t, event, `"route":"/v0/publish"`)
+}
@@ -5,13 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 )
@@ -32,6 +37,30 @@ type Metrics struct {
 
 	// Up tracks the health of the service
 	Up metric.Int64Gauge
+
+	// AuthLockouts tracks the number of clients and identities locked out after repeated failed logins
+	AuthLockouts metric.Int64Counter
+
+	// Publishes tracks publish requests by outcome
+	Publishes metric.Int64Counter
+
+	// PackageValidations tracks package ownership validations by registry type and outcome
+	PackageValidations metric.Int64Counter
+
+	// PackageValidationDuration tracks how long package ownership validations take
+	PackageValidationDuration metric.Float64Histogram
+
+	// DatabaseQueryDuration tracks how long database queries take by the database method that made them
+	DatabaseQueryDuration metric.Float64Histogram
+
+	// ServerCacheRequests tracks reads of server versions by name by whether the server cache served them
+	ServerCacheRequests metric.Int64Counter
+
+	// meter creates the observable instruments of ObserveDatabasePool
+	meter metric.Meter
+
+	// reader reads the current values of the instruments for Collect
+	reader sdkmetric.Reader
 }
 
 // ShutdownFunc is a delegate that shuts down the OpenTelemetry components.
@@ -73,22 +102,199 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		return nil, fmt.Errorf("failed to create service up gauge: %w", err)
 	}
 
+	authLockouts, err := meter.Int64Counter(
+		Namespace+".auth.lockouts",
+		metric.WithDescription("Total number of login lockouts after repeated failed attempts"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth lockout counter: %w", err)
+	}
+
+	publishes, err := meter.Int64Counter(
+		Namespace+".publish.requests",
+		metric.WithDescription("Total number of publish requests by outcome"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create publish counter: %w", err)
+	}
+
+	packageValidations, err := meter.Int64Counter(
+		Namespace+".package.validations",
+		metric.WithDescription("Total number of package ownership validations by registry type and outcome"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create package validation counter: %w", err)
+	}
+
+	packageValidationDuration, err := meter.Float64Histogram(
+		Namespace+".package.validation.duration",
+		metric.WithDescription("Duration of package ownership validations in seconds"),
+		metric.WithExplicitBucketBoundaries(
+			0.05, 0.1, 0.25, 0.5, 1.0, 2.5, 5.0, 10.0, 30.0,
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create package validation duration histogram: %w", err)
+	}
+
+	databaseQueryDuration, err := meter.Float64Histogram(
+		Namespace+".db.query.duration",
+		metric.WithDescription("Duration of database queries in seconds by database method, operation and outcome"),
+		metric.WithExplicitBucketBoundaries(
+			0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1.0, 2.5,
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database query duration histogram: %w", err)
+	}
+
+	serverCacheRequests, err := meter.Int64Counter(
+		Namespace+".server_cache.requests",
+		metric.WithDescription("Total number of reads of server versions by name by whether the server cache served them"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server cache counter: %w", err)
+	}
+
 	return &Metrics{
-		Requests:        req,
-		RequestDuration: reqDuration,
-		ErrorCount:      errCount,
-		Up:              up,
+		Requests:                  req,
+		RequestDuration:           reqDuration,
+		ErrorCount:                errCount,
+		Up:                        up,
+		AuthLockouts:              authLockouts,
+		Publishes:                 publishes,
+		PackageValidations:        packageValidations,
+		PackageValidationDuration: packageValidationDuration,
+		DatabaseQueryDuration:     databaseQueryDuration,
+		ServerCacheRequests:       serverCacheRequests,
+		meter:                     meter,
 	}, nil
 }
 
-func NewPrometheusMeterProvider(res *resource.Resource, exp *prometheus.Exporter) (*sdkmetric.MeterProvider, error) {
+// RecordPackageValidation records the outcome of validating the ownership of a package
+func (m *Metrics) RecordPackageValidation(ctx context.Context, registryType string, duration time.Duration, err error) {
+	outcome := "valid"
+	if err != nil {
+		outcome = "invalid"
+	}
+	attrs := metric.WithAttributes(
+		attribute.String("registry_type", registryType),
+		attribute.String("outcome", outcome),
+	)
+	m.PackageValidations.Add(ctx, 1, attrs)
+	m.PackageValidationDuration.Record(ctx, duration.Seconds(), attrs)
+}
+
+// RecordServerCacheLookup records a read of a server version by name, with hit telling whether the server
+// cache served it
+func (m *Metrics) RecordServerCacheLookup(ctx context.Context, hit bool) {
+	outcome := "miss"
+	if hit {
+		outcome = "hit"
+	}
+	m.ServerCacheRequests.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+}
+
+// RecordDatabaseQuery records the duration of a database query made by method, such as GetServerByName,
+// with operation being its SQL command, such as SELECT
+func (m *Metrics) RecordDatabaseQuery(ctx context.Context, method, operation string, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.DatabaseQueryDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		attribute.String("method", method),
+		attribute.String("operation", operation),
+		attribute.String("outcome", outcome),
+	))
+}
+
+// ObserveDatabasePool exports the usage of a database connection pool, read from stat at each scrape,
+// alongside its configured minimum size minConns
+func (m *Metrics) ObserveDatabasePool(stat func() *pgxpool.Stat, minConns int32) error {
+	connections, err := m.meter.Int64ObservableGauge(
+		Namespace+".db.pool.connections",
+		metric.WithDescription("Database connections in the pool by state"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool connections gauge: %w", err)
+	}
+	maxConnections, err := m.meter.Int64ObservableGauge(
+		Namespace+".db.pool.max_connections",
+		metric.WithDescription("Maximum number of database connections in the pool"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool max connections gauge: %w", err)
+	}
+	minConnections, err := m.meter.Int64ObservableGauge(
+		Namespace+".db.pool.min_connections",
+		metric.WithDescription("Minimum number of database connections kept in the pool"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool min connections gauge: %w", err)
+	}
+	acquires, err := m.meter.Int64ObservableCounter(
+		Namespace+".db.pool.acquires",
+		metric.WithDescription("Total number of connections acquired from the pool"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool acquires counter: %w", err)
+	}
+	waits, err := m.meter.Int64ObservableCounter(
+		Namespace+".db.pool.waits",
+		metric.WithDescription("Total number of acquires that waited for a connection as the pool was exhausted"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool waits counter: %w", err)
+	}
+	acquireDuration, err := m.meter.Float64ObservableCounter(
+		Namespace+".db.pool.acquire.duration",
+		metric.WithDescription("Total time spent acquiring connections from the pool in seconds"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool acquire duration counter: %w", err)
+	}
+
+	waitDuration, err := m.meter.Float64ObservableCounter(
+		Namespace+".db.pool.wait.duration",
+		metric.WithDescription("Total time spent waiting for a connection as the pool was exhausted in seconds"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create pool wait duration counter: %w", err)
+	}
+
+	_, err = m.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		s := stat()
+		o.ObserveInt64(connections, int64(s.IdleConns()), metric.WithAttributes(attribute.String("state", "idle")))
+		o.ObserveInt64(connections, int64(s.AcquiredConns()), metric.WithAttributes(attribute.String("state", "acquired")))
+		o.ObserveInt64(connections, int64(s.ConstructingConns()), metric.WithAttributes(attribute.String("state", "constructing")))
+		o.ObserveInt64(maxConnections, int64(s.MaxConns()))
+		o.ObserveInt64(minConnections, int64(minConns))
+		o.ObserveInt64(acquires, s.AcquireCount())
+		o.ObserveInt64(waits, s.EmptyAcquireCount())
+		o.ObserveFloat64(acquireDuration, s.AcquireDuration().Seconds())
+		o.ObserveFloat64(waitDuration, s.EmptyAcquireWaitTime().Seconds())
+		return nil
+	}, connections, maxConnections, minConnections, acquires, waits, acquireDuration, waitDuration)
+	if err != nil {
+		return fmt.Errorf("failed to register pool callback: %w", err)
+	}
+	return nil
+}
+
+// NewPrometheusMeterProvider creates a meter provider exporting to exp, and also read by any other readers
+func NewPrometheusMeterProvider(res *resource.Resource, exp *prometheus.Exporter, readers ...sdkmetric.Reader) (*sdkmetric.MeterProvider, error) {
 	if exp == nil {
 		return nil, errors.New("exporter cannot be nil")
 	}
-	meterProvider := sdkmetric.NewMeterProvider(
+	options := []sdkmetric.Option{
 		sdkmetric.WithResource(res),
 		sdkmetric.WithReader(exp),
-	)
+	}
+	for _, reader := range readers {
+		options = append(options, sdkmetric.WithReader(reader))
+	}
+	meterProvider := sdkmetric.NewMeterProvider(options...)
 
 	return meterProvider, nil
 }
@@ -118,7 +324,9 @@ func InitMetrics(version string) (ShutdownFunc, *Metrics, error) {
 		return shutdown, nil, fmt.Errorf("failed to create Prometheus exporter: %w", err)
 	}
 
-	mp, err := NewPrometheusMeterProvider(res, exporter)
+	// Also read the instruments in process, for the alert monitor
+	reader := sdkmetric.NewManualReader()
+	mp, err := NewPrometheusMeterProvider(res, exporter, reader)
 	if err != nil {
 		return shutdown, nil, fmt.Errorf("failed to create Prometheus meter provider: %w", err)
 	}
@@ -137,9 +345,21 @@ func InitMetrics(version string) (ShutdownFunc, *Metrics, error) {
 
 	meter := mp.Meter(Namespace, metric.WithSchemaURL(semconv.SchemaURL), metric.WithInstrumentationVersion(runtime.Version()))
 	metrics, err := NewMetrics(meter)
+	if metrics != nil {
+		metrics.reader = reader
+	}
 	return shutdown, metrics, err
 }
 
+// Collect reads the current values of the instruments into rm. It fails for metrics that were not
+// created by InitMetrics.
+func (m *Metrics) Collect(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	if m.reader == nil {
+		return errors.New("metrics are not read in process")
+	}
+	return m.reader.Collect(ctx, rm)
+}
+
 // PrometheusHandler returns the HTTP handler for Prometheus metrics
 // This handler serves the metrics endpoint for Prometheus to scrape.
 func (m *Metrics) PrometheusHandler() http.Handler {
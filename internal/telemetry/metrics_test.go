@@ -1,14 +1,19 @@
 package telemetry_test
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
@@ -41,6 +46,69 @@ func TestNewMetrics(t *testing.T) {
 	}
 }
 
+func TestRecordPackageValidation(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	metrics, err := telemetry.NewMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	metrics.RecordPackageValidation(ctx, "npm", 100*time.Millisecond, nil)
+	metrics.RecordPackageValidation(ctx, "npm", 200*time.Millisecond, errors.New("not found"))
+	metrics.RecordPackageValidation(ctx, "oci", 300*time.Millisecond, nil)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	counts := map[string]int64{}
+	var durations uint64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, point := range data.DataPoints {
+					registryType, _ := point.Attributes.Value(attribute.Key("registry_type"))
+					outcome, _ := point.Attributes.Value(attribute.Key("outcome"))
+					counts[registryType.AsString()+"/"+outcome.AsString()] += point.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, point := range data.DataPoints {
+					durations += point.Count
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{"npm/valid": 1, "npm/invalid": 1, "oci/valid": 1}, counts)
+	assert.Equal(t, uint64(3), durations)
+}
+
+func TestRecordDatabaseQuery(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	metrics, err := telemetry.NewMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	metrics.RecordDatabaseQuery(ctx, "GetServerByName", "SELECT", 2*time.Millisecond, nil)
+	metrics.RecordDatabaseQuery(ctx, "GetServerByName", "SELECT", 4*time.Millisecond, nil)
+	metrics.RecordDatabaseQuery(ctx, "CreateServer", "INSERT", 8*time.Millisecond, errors.New("duplicate key"))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	counts := map[string]uint64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != telemetry.Namespace+".db.query.duration" {
+				continue
+			}
+			for _, point := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				method, _ := point.Attributes.Value(attribute.Key("method"))
+				operation, _ := point.Attributes.Value(attribute.Key("operation"))
+				outcome, _ := point.Attributes.Value(attribute.Key("outcome"))
+				counts[method.AsString()+"/"+operation.AsString()+"/"+outcome.AsString()] += point.Count
+			}
+		}
+	}
+	assert.Equal(t, map[string]uint64{"GetServerByName/SELECT/success": 2, "CreateServer/INSERT/error": 1}, counts)
+}
+
 func TestNewPrometheusMeterProvider(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// InitTracing exports traces over OTLP/HTTP to endpoint, a URL such as http://otel-collector:4318.
+// Requests that arrive with a sampled trace context are always traced; sampleRatio of the others are.
+// Without an endpoint, tracing stays disabled and spans are not recorded.
+func InitTracing(ctx context.Context, version, endpoint string, sampleRatio float64) (ShutdownFunc, error) {
+	shutdown := func(_ context.Context) error { return nil }
+	if endpoint == "" {
+		return shutdown, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(Namespace),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return shutdown, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return shutdown, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return tp.Shutdown, nil
+}
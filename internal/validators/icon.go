@@ -0,0 +1,180 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// Limits for icons uploaded to the registry
+const (
+	MaxIconSize      = 64 * 1024
+	MinIconDimension = 16
+	MaxIconDimension = 512
+)
+
+// Icon MIME types accepted for upload
+const (
+	IconMimeTypePNG = "image/png"
+	IconMimeTypeSVG = "image/svg+xml"
+)
+
+// ErrInvalidIcon is returned for icons that cannot be stored
+var ErrInvalidIcon = errors.New("invalid icon")
+
+// IconInfo describes a validated icon
+type IconInfo struct {
+	MimeType string
+	Width    int // zero for SVG icons, which scale to any size
+	Height   int
+}
+
+// svgAllowedElements are the SVG elements kept when sanitizing. Anything else, including
+// script, foreignObject, animation and external resource elements, is removed with its content.
+var svgAllowedElements = map[string]bool{
+	"svg": true, "g": true, "defs": true, "symbol": true, "use": true, "title": true, "desc": true,
+	"path": true, "rect": true, "circle": true, "ellipse": true, "line": true, "polyline": true, "polygon": true,
+	"text": true, "tspan": true, "linearGradient": true, "radialGradient": true, "stop": true,
+	"clipPath": true, "mask": true, "pattern": true, "filter": true, "feGaussianBlur": true,
+	"feOffset": true, "feBlend": true, "feColorMatrix": true, "feFlood": true, "feComposite": true,
+	"feMerge": true, "feMergeNode": true,
+}
+
+// ValidateIcon checks that data is a PNG or SVG icon within the size and dimension limits.
+// SVG icons are sanitized, so the returned data is what should be stored.
+func ValidateIcon(data []byte, mimeType string) ([]byte, IconInfo, error) {
+	if len(data) == 0 {
+		return nil, IconInfo{}, fmt.Errorf("%w: icon is empty", ErrInvalidIcon)
+	}
+	if len(data) > MaxIconSize {
+		return nil, IconInfo{}, fmt.Errorf("%w: icon is %d bytes, larger than the %d byte limit", ErrInvalidIcon, len(data), MaxIconSize)
+	}
+
+	switch mimeType {
+	case IconMimeTypePNG:
+		cfg, err := png.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil, IconInfo{}, fmt.Errorf("%w: not a valid PNG image: %w", ErrInvalidIcon, err)
+		}
+		if cfg.Width != cfg.Height {
+			return nil, IconInfo{}, fmt.Errorf("%w: icon must be square, got %dx%d", ErrInvalidIcon, cfg.Width, cfg.Height)
+		}
+		if cfg.Width < MinIconDimension || cfg.Width > MaxIconDimension {
+			return nil, IconInfo{}, fmt.Errorf("%w: icon must be between %dx%d and %dx%d pixels, got %dx%d",
+				ErrInvalidIcon, MinIconDimension, MinIconDimension, MaxIconDimension, MaxIconDimension, cfg.Width, cfg.Height)
+		}
+		return data, IconInfo{MimeType: mimeType, Width: cfg.Width, Height: cfg.Height}, nil
+	case IconMimeTypeSVG:
+		sanitized, err := sanitizeSVG(data)
+		if err != nil {
+			return nil, IconInfo{}, fmt.Errorf("%w: %w", ErrInvalidIcon, err)
+		}
+		return sanitized, IconInfo{MimeType: mimeType}, nil
+	default:
+		return nil, IconInfo{}, fmt.Errorf("%w: unsupported type %q, must be %s or %s", ErrInvalidIcon, mimeType, IconMimeTypePNG, IconMimeTypeSVG)
+	}
+}
+
+// sanitizeSVG rewrites an SVG document keeping only allowlisted elements and safe attributes.
+// Comments, processing instructions and DOCTYPEs (and so entity definitions) are dropped.
+func sanitizeSVG(data []byte) ([]byte, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var out bytes.Buffer
+	var open []xml.Name // elements not yet closed, as RawToken doesn't check nesting
+	depth := 0
+	skipDepth := 0 // depth of the outermost element being removed, or 0
+	sawRoot := false
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not a valid SVG document: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			open = append(open, t.Name)
+			depth++
+			if skipDepth != 0 {
+				continue
+			}
+			if depth == 1 {
+				if sawRoot || t.Name.Local != "svg" {
+					return nil, fmt.Errorf("not a valid SVG document: root element must be <svg>")
+				}
+				sawRoot = true
+			}
+			if !svgAllowedElements[t.Name.Local] {
+				skipDepth = depth
+				continue
+			}
+			out.WriteString("<" + qualifiedName(t.Name))
+			for _, attr := range t.Attr {
+				if !safeSVGAttribute(attr) {
+					continue
+				}
+				out.WriteString(" " + qualifiedName(attr.Name) + `="`)
+				_ = xml.EscapeText(&out, []byte(attr.Value))
+				out.WriteString(`"`)
+			}
+			out.WriteString(">")
+		case xml.EndElement:
+			if len(open) == 0 || open[len(open)-1] != t.Name {
+				return nil, fmt.Errorf("not a valid SVG document: unexpected </%s>", qualifiedName(t.Name))
+			}
+			open = open[:len(open)-1]
+			if skipDepth == 0 {
+				out.WriteString("</" + qualifiedName(t.Name) + ">")
+			} else if depth == skipDepth {
+				skipDepth = 0
+			}
+			depth--
+		case xml.CharData:
+			if skipDepth == 0 && depth > 0 {
+				_ = xml.EscapeText(&out, t)
+			}
+		case xml.Comment, xml.ProcInst, xml.Directive:
+			// Dropped
+		}
+	}
+
+	if !sawRoot || len(open) > 0 {
+		return nil, fmt.Errorf("not a valid SVG document: missing or unclosed <svg> element")
+	}
+	return out.Bytes(), nil
+}
+
+// safeSVGAttribute rejects event handlers, links to anything but fragments of the same document,
+// and styles that can load external resources
+func safeSVGAttribute(attr xml.Attr) bool {
+	name := strings.ToLower(attr.Name.Local)
+	value := strings.ToLower(strings.Join(strings.Fields(attr.Value), ""))
+	value = strings.NewReplacer(`'`, "", `"`, "").Replace(value)
+
+	switch {
+	case strings.HasPrefix(name, "on"):
+		return false
+	case name == "href":
+		return strings.HasPrefix(attr.Value, "#")
+	case strings.Contains(value, "javascript:"), strings.Contains(value, "expression("), strings.Contains(value, "@import"):
+		return false
+	case strings.Contains(value, "url("):
+		// Only references to elements in the same document, such as gradients
+		return !strings.Contains(strings.ReplaceAll(value, "url(#", ""), "url(")
+	}
+	return true
+}
+
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
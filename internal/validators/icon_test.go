@@ -0,0 +1,73 @@
+package validators_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func TestValidateIconPNG(t *testing.T) {
+	data, info, err := validators.ValidateIcon(encodePNG(t, 64, 64), validators.IconMimeTypePNG)
+	require.NoError(t, err)
+	assert.Equal(t, 64, info.Width)
+	assert.NotEmpty(t, data)
+
+	for name, icon := range map[string][]byte{
+		"not square": encodePNG(t, 64, 32),
+		"too small":  encodePNG(t, 8, 8),
+		"too large":  encodePNG(t, 1024, 1024),
+		"not a PNG":  []byte("GIF89a"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := validators.ValidateIcon(icon, validators.IconMimeTypePNG)
+			assert.ErrorIs(t, err, validators.ErrInvalidIcon)
+		})
+	}
+
+	_, _, err = validators.ValidateIcon(encodePNG(t, 64, 64), "image/gif")
+	assert.ErrorIs(t, err, validators.ErrInvalidIcon)
+}
+
+func TestValidateIconSVG(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<!DOCTYPE svg [<!ENTITY x "boom">]>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 24 24" onload="alert(1)">
+<!-- comment -->
+<script>alert(1)</script>
+<defs><linearGradient id="g"><stop offset="0" stop-color="#fff"/></linearGradient></defs>
+<a href="javascript:alert(1)"><rect width="24" height="24"/></a>
+<circle r="4" fill="url(#g)" style="background:url(https://evil.example/x)"/>
+<use xlink:href="#g"/><use href="https://evil.example/sprite.svg#x"/>
+<foreignObject><div>html</div></foreignObject>
+</svg>`
+
+	data, info, err := validators.ValidateIcon([]byte(input), validators.IconMimeTypeSVG)
+	require.NoError(t, err)
+	assert.Equal(t, validators.IconMimeTypeSVG, info.MimeType)
+
+	svg := string(data)
+	assert.Contains(t, svg, `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 24 24">`)
+	assert.Contains(t, svg, `<circle r="4" fill="url(#g)">`)
+	assert.Contains(t, svg, `<use xlink:href="#g">`)
+	for _, removed := range []string{"script", "alert", "onload", "DOCTYPE", "comment", "evil.example", "foreignObject", "<a", "<rect"} {
+		assert.NotContains(t, svg, removed)
+	}
+
+	_, _, err = validators.ValidateIcon([]byte(`<html><body/></html>`), validators.IconMimeTypeSVG)
+	assert.ErrorIs(t, err, validators.ErrInvalidIcon)
+	_, _, err = validators.ValidateIcon([]byte(`<svg>`), validators.IconMimeTypeSVG)
+	assert.ErrorIs(t, err, validators.ErrInvalidIcon)
+}
@@ -0,0 +1,124 @@
+package validators
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxReadmeSize is the largest README, in bytes, a server may have
+const MaxReadmeSize = 64 * 1024
+
+var (
+	// Autolinks such as <https://example.com> or <someone@example.com> are Markdown, not HTML
+	urlAutolinkRegex   = regexp.MustCompile(`^<(?i:https?|mailto):[^\s<>]*>`)
+	emailAutolinkRegex = regexp.MustCompile(`^<[^\s<>@]+@[^\s<>@]+>`)
+
+	// Link destinations using schemes that run code when followed, in inline links and reference definitions
+	unsafeLinkRegex = regexp.MustCompile(`(?i)(\]\(\s*<?|^\s{0,3}\[[^\]]+\]:\s*<?)(javascript|vbscript|data):`)
+
+	codeFenceRegex = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+)
+
+// ValidateReadme checks that a README is valid UTF-8 and within MaxReadmeSize
+func ValidateReadme(content string) error {
+	if len(content) > MaxReadmeSize {
+		return fmt.Errorf("README is %d bytes, larger than the %d byte limit", len(content), MaxReadmeSize)
+	}
+	if !utf8.ValidString(content) {
+		return fmt.Errorf("README must be valid UTF-8")
+	}
+	return nil
+}
+
+// SanitizeMarkdown makes Markdown safe to render in a browser. Raw HTML is escaped so that it
+// is shown as text, and links to javascript:, vbscript: and data: URLs are neutralized. Code
+// blocks and code spans are left as they are, since renderers already show their content as text.
+func SanitizeMarkdown(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\x00", "")
+
+	lines := strings.Split(content, "\n")
+	fence := ""
+	prevBlank := true
+	inIndentedCode := false
+	for i, line := range lines {
+		blank := strings.TrimSpace(line) == ""
+
+		if fence != "" {
+			if m := codeFenceRegex.FindStringSubmatch(line); m != nil && m[1][0] == fence[0] && len(m[1]) >= len(fence) {
+				fence = ""
+			}
+			prevBlank = blank
+			continue
+		}
+		if m := codeFenceRegex.FindStringSubmatch(line); m != nil {
+			fence = m[1]
+			prevBlank = false
+			continue
+		}
+
+		indented := strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+		inIndentedCode = indented && (prevBlank || inIndentedCode) || (blank && inIndentedCode)
+		prevBlank = blank
+		if inIndentedCode {
+			continue
+		}
+
+		lines[i] = unsafeLinkRegex.ReplaceAllString(escapeHTML(line), "${1}#")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// escapeHTML escapes the start of HTML tags, comments and declarations in a line of Markdown,
+// skipping code spans and autolinks
+func escapeHTML(line string) string {
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		switch line[i] {
+		case '`':
+			// Copy a code span through its matching run of backticks
+			run := 1
+			for i+run < len(line) && line[i+run] == '`' {
+				run++
+			}
+			delimiter := line[i : i+run]
+			if end := strings.Index(line[i+run:], delimiter); end >= 0 {
+				spanEnd := i + run + end + run
+				b.WriteString(line[i:spanEnd])
+				i = spanEnd
+			} else {
+				b.WriteString(delimiter)
+				i += run
+			}
+		case '<':
+			if m := urlAutolinkRegex.FindString(line[i:]); m != "" {
+				b.WriteString(m)
+				i += len(m)
+				continue
+			}
+			if m := emailAutolinkRegex.FindString(line[i:]); m != "" {
+				b.WriteString(m)
+				i += len(m)
+				continue
+			}
+			if i+1 < len(line) && isHTMLStart(line[i+1]) {
+				b.WriteString("&lt;")
+			} else {
+				b.WriteByte('<')
+			}
+			i++
+		default:
+			b.WriteByte(line[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+// isHTMLStart reports whether c can follow '<' at the start of an HTML tag, comment or declaration
+func isHTMLStart(c byte) bool {
+	return c == '/' || c == '!' || c == '?' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
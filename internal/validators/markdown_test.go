@@ -0,0 +1,61 @@
+package validators_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+func TestSanitizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain markdown is unchanged",
+			input:    "# Weather\n\nGet **forecasts** for [any city](https://example.com).",
+			expected: "# Weather\n\nGet **forecasts** for [any city](https://example.com).",
+		},
+		{
+			name:     "escapes raw HTML",
+			input:    "Hello <script>alert(1)</script>\n<img src=x onerror=alert(1)>\n<!-- hidden -->",
+			expected: "Hello &lt;script>alert(1)&lt;/script>\n&lt;img src=x onerror=alert(1)>\n&lt;!-- hidden -->",
+		},
+		{
+			name:     "keeps autolinks and comparisons",
+			input:    "See <https://example.com> or <help@example.com>, and note 1 < 2",
+			expected: "See <https://example.com> or <help@example.com>, and note 1 < 2",
+		},
+		{
+			name:     "leaves code spans and blocks alone",
+			input:    "Use `<T>` here\n\n```html\n<div>\n```\n\n    <b>indented</b>\n\nAfter <b>",
+			expected: "Use `<T>` here\n\n```html\n<div>\n```\n\n    <b>indented</b>\n\nAfter &lt;b>",
+		},
+		{
+			name:     "neutralizes script links",
+			input:    "[click](javascript:alert(1)) [img]( DATA:text/html,x)\n[ref]: vbscript:msgbox",
+			expected: "[click](#alert(1)) [img]( #text/html,x)\n[ref]: #msgbox",
+		},
+		{
+			name:     "normalizes line endings",
+			input:    "a\r\nb",
+			expected: "a\nb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validators.SanitizeMarkdown(tt.input))
+		})
+	}
+}
+
+func TestValidateReadme(t *testing.T) {
+	assert.NoError(t, validators.ValidateReadme("# Weather"))
+	assert.Error(t, validators.ValidateReadme(strings.Repeat("a", validators.MaxReadmeSize+1)))
+	assert.Error(t, validators.ValidateReadme("invalid \xff utf-8"))
+}
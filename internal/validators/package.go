@@ -3,15 +3,45 @@ package validators
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
+var tracer = otel.Tracer("github.com/modelcontextprotocol/registry/internal/validators")
+
+// PackageValidationObserver, if set, is called with the outcome of each package validation, for metrics
+var PackageValidationObserver func(ctx context.Context, registryType string, duration time.Duration, err error)
+
 // ValidatePackage validates that the package referenced in the server configuration is:
 // 1. allowed on the official registry (based on registry base url); and
 // 2. owned by the publisher, by checking for a matching server name in the package metadata
 func ValidatePackage(ctx context.Context, pkg model.Package, serverName string) error {
+	ctx, span := tracer.Start(ctx, "validate package", trace.WithAttributes(
+		attribute.String("package.registry_type", pkg.RegistryType),
+		attribute.String("package.identifier", pkg.Identifier),
+	))
+	defer span.End()
+
+	start := time.Now()
+	err := validatePackage(ctx, pkg, serverName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	if observe := PackageValidationObserver; observe != nil {
+		observe(ctx, pkg.RegistryType, time.Since(start), err)
+	}
+	return err
+}
+
+func validatePackage(ctx context.Context, pkg model.Package, serverName string) error {
 	switch pkg.RegistryType {
 	case model.RegistryTypeNPM:
 		return registries.ValidateNPM(ctx, pkg, serverName)
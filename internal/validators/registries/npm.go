@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -52,7 +51,7 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 			pkg.RegistryBaseURL, model.RegistryTypeNPM, model.RegistryURLNPM)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := newHTTPClient()
 
 	requestURL := pkg.RegistryBaseURL + "/" + url.PathEscape(pkg.Identifier) + "/" + url.PathEscape(pkg.Version)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
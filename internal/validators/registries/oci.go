@@ -5,9 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
-	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -22,6 +21,9 @@ const (
 	ghcrAPIBaseURL     = "https://ghcr.io"
 )
 
+// ServerNameLabel is the label of an OCI image that names the MCP server it belongs to
+const ServerNameLabel = "io.modelcontextprotocol.server.name"
+
 // ErrRateLimited is returned when a registry rate limits our requests
 var ErrRateLimited = errors.New("rate limited by registry")
 
@@ -105,18 +107,52 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 		return fmt.Errorf("invalid OCI reference: %w", err)
 	}
 
+	labels, err := fetchImageLabels(ctx, ociRef)
+	if err != nil {
+		// Handle rate limiting explicitly - skip validation
+		if errors.Is(err, ErrRateLimited) {
+			slog.WarnContext(ctx, "Skipping OCI validation due to rate limiting", "image", ociRef.String())
+			return nil
+		}
+		return err
+	}
+
+	// Validate server name annotation
+	mcpName, exists := labels[ServerNameLabel]
+	if !exists {
+		return fmt.Errorf("OCI image '%s/%s:%s' is missing required annotation. Add this to your Dockerfile: LABEL %s=\"%s\"", ociRef.Namespace, ociRef.Image, ociRef.Tag, ServerNameLabel, serverName)
+	}
+	if mcpName != serverName {
+		return fmt.Errorf("OCI image ownership validation failed. Expected annotation '%s' = '%s', got '%s'", ServerNameLabel, serverName, mcpName)
+	}
+
+	return nil
+}
+
+// FetchOCIImageLabels returns the labels of an image in Docker Hub or GHCR, read the way ValidateOCI reads
+// them: from the config of the image, or of the first image of a multi-arch index
+func FetchOCIImageLabels(ctx context.Context, identifier string) (map[string]string, error) {
+	ociRef, err := ParseOCIReference(identifier)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI reference: %w", err)
+	}
+	return fetchImageLabels(ctx, ociRef)
+}
+
+// fetchImageLabels fetches the manifest and then the config of an image, returning its labels
+func fetchImageLabels(ctx context.Context, ociRef *OCIReference) (map[string]string, error) {
 	// Validate that the registry is supported
 	registryBaseURL := ociRef.GetRegistryBaseURL()
 	if err := validateRegistryURL(registryBaseURL); err != nil {
-		return err
+		return nil, err
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := newHTTPClient()
 
 	// Get registry configuration
 	registryConfig := getRegistryConfig(registryBaseURL, ociRef.Namespace, ociRef.Image)
 	if registryConfig == nil {
-		return fmt.Errorf("unsupported registry: %s", registryBaseURL)
+		return nil, fmt.Errorf("unsupported registry: %s", registryBaseURL)
 	}
 
 	// Determine what to use for manifest lookup: digest if available (most secure), otherwise tag
@@ -128,22 +164,21 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	// Get the image manifest
 	manifest, err := fetchImageManifest(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, manifestRef)
 	if err != nil {
-		// Handle rate limiting explicitly - skip validation
-		if errors.Is(err, ErrRateLimited) {
-			log.Printf("Skipping OCI validation for %s due to rate limiting", ociRef.String())
-			return nil
-		}
-		return err
+		return nil, err
 	}
 
 	// Get config digest from manifest
 	configDigest, err := getConfigDigestFromManifest(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, manifest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Validate server name annotation
-	return validateServerNameAnnotation(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, ociRef.Tag, configDigest, serverName)
+	// Get image config (contains labels)
+	config, err := getImageConfig(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, configDigest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image config: %w", err)
+	}
+	return config.Config.Labels, nil
 }
 
 // validateRegistryURL validates that the registry base URL is supported
@@ -186,7 +221,7 @@ func fetchImageManifest(ctx context.Context, client *http.Client, registryConfig
 	}
 	if resp.StatusCode == http.StatusTooManyRequests {
 		// Rate limited, return explicit error
-		log.Printf("Rate limited when accessing OCI image '%s/%s:%s'", namespace, repo, tag)
+		slog.WarnContext(ctx, "Rate limited when accessing OCI image", "image", namespace+"/"+repo+":"+tag)
 		return nil, fmt.Errorf("%w: %s/%s:%s", ErrRateLimited, namespace, repo, tag)
 	}
 	if resp.StatusCode != http.StatusOK {
@@ -221,26 +256,6 @@ func getConfigDigestFromManifest(ctx context.Context, client *http.Client, regis
 	return manifest.Config.Digest, nil
 }
 
-// validateServerNameAnnotation validates the MCP server name annotation in the image config
-func validateServerNameAnnotation(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo, tag, configDigest, serverName string) error {
-	// Get image config (contains labels)
-	config, err := getImageConfig(ctx, client, registryConfig, namespace, repo, configDigest)
-	if err != nil {
-		return fmt.Errorf("failed to get image config: %w", err)
-	}
-
-	mcpName, exists := config.Config.Labels["io.modelcontextprotocol.server.name"]
-	if !exists {
-		return fmt.Errorf("OCI image '%s/%s:%s' is missing required annotation. Add this to your Dockerfile: LABEL io.modelcontextprotocol.server.name=\"%s\"", namespace, repo, tag, serverName)
-	}
-
-	if mcpName != serverName {
-		return fmt.Errorf("OCI image ownership validation failed. Expected annotation 'io.modelcontextprotocol.server.name' = '%s', got '%s'", serverName, mcpName)
-	}
-
-	return nil
-}
-
 // getRegistryAuthToken retrieves an authentication token from a registry
 func getRegistryAuthToken(ctx context.Context, client *http.Client, config *RegistryConfig) (string, error) {
 	if config.AuthURL == "" {
@@ -24,6 +24,7 @@ var (
 	namespaceRegex  = regexp.MustCompile(`^` + namespacePattern + `$`)
 	namePartRegex   = regexp.MustCompile(`^` + namePartPattern + `$`)
 	serverNameRegex = regexp.MustCompile(`^` + namespacePattern + `/` + namePartPattern + `$`)
+	namePrefixRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*\*$`)
 )
 
 // Regexes to detect semver range syntaxes
@@ -53,72 +54,81 @@ var (
 )
 
 func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
+	if errs := serverJSONErrors(serverJSON, false); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// ServerJSONErrors runs every server.json check and returns all failures rather than just the first
+func ServerJSONErrors(serverJSON *apiv0.ServerJSON) []error {
+	return serverJSONErrors(serverJSON, true)
+}
+
+// serverJSONErrors runs the server.json checks in order, stopping at the first failure unless all is set.
+// Checks that depend on a supported schema and a valid server name are always skipped if those fail.
+func serverJSONErrors(serverJSON *apiv0.ServerJSON, all bool) []error {
 	// Validate schema version is provided and supported
 	// Note: Schema field is also marked as required in the ServerJSON struct definition
 	// for API-level validation and documentation
 	if serverJSON.Schema == "" {
-		return fmt.Errorf("$schema field is required")
+		return []error{fmt.Errorf("$schema field is required")}
 	}
 	if !strings.Contains(serverJSON.Schema, model.CurrentSchemaVersion) {
-		return fmt.Errorf("schema version %s is not supported. Please use schema version %s", serverJSON.Schema, model.CurrentSchemaVersion)
+		return []error{fmt.Errorf("schema version %s is not supported. Please use schema version %s", serverJSON.Schema, model.CurrentSchemaVersion)}
 	}
 
 	// Validate server name exists and format
 	if _, err := parseServerName(*serverJSON); err != nil {
-		return err
-	}
-
-	// Validate top-level server version is a specific version (not a range) & not "latest"
-	if err := validateVersion(serverJSON.Version); err != nil {
-		return err
-	}
-
-	// Validate repository
-	if err := validateRepository(&serverJSON.Repository); err != nil {
-		return err
-	}
-
-	// Validate website URL if provided
-	if err := validateWebsiteURL(serverJSON.WebsiteURL); err != nil {
-		return err
+		return []error{err}
 	}
 
-	// Validate title if provided
-	if err := validateTitle(serverJSON.Title); err != nil {
-		return err
-	}
-
-	// Validate icons if provided
-	if err := validateIcons(serverJSON.Icons); err != nil {
-		return err
+	checks := []func() error{
+		// Validate top-level server version is a specific version (not a range) & not "latest"
+		func() error { return validateVersion(serverJSON.Version) },
+		// Validate repository
+		func() error { return validateRepository(&serverJSON.Repository) },
+		// Validate website URL if provided
+		func() error { return validateWebsiteURL(serverJSON.WebsiteURL) },
+		// Validate title if provided
+		func() error { return validateTitle(serverJSON.Title) },
+		// Validate icons if provided
+		func() error { return validateIcons(serverJSON.Icons) },
 	}
 
 	// Validate all packages (basic field validation)
 	// Detailed package validation (including registry checks) is done during publish
 	for _, pkg := range serverJSON.Packages {
-		if err := validatePackageField(&pkg); err != nil {
-			return err
-		}
+		checks = append(checks, func() error { return validatePackageField(&pkg) })
 	}
 
 	// Validate all remotes
 	for _, remote := range serverJSON.Remotes {
-		if err := validateRemoteTransport(&remote); err != nil {
-			return err
-		}
+		checks = append(checks, func() error { return validateRemoteTransport(&remote) })
 	}
 
-	// Validate reverse-DNS namespace matching for remote URLs
-	if err := validateRemoteNamespaceMatch(*serverJSON); err != nil {
-		return err
-	}
+	checks = append(checks,
+		// Validate reverse-DNS namespace matching for remote URLs
+		func() error { return validateRemoteNamespaceMatch(*serverJSON) },
+		// Validate reverse-DNS namespace matching for website URL
+		func() error { return validateWebsiteURLNamespaceMatch(*serverJSON) },
+	)
 
-	// Validate reverse-DNS namespace matching for website URL
-	if err := validateWebsiteURLNamespaceMatch(*serverJSON); err != nil {
-		return err
-	}
+	return runChecks(checks, all)
+}
 
-	return nil
+// runChecks runs checks in order and collects their failures, stopping at the first unless all is set
+func runChecks(checks []func() error, all bool) []error {
+	var errs []error
+	for _, check := range checks {
+		if err := check(); err != nil {
+			errs = append(errs, err)
+			if !all {
+				break
+			}
+		}
+	}
+	return errs
 }
 
 func validateRepository(obj *model.Repository) error {
@@ -426,26 +436,48 @@ func validateRemoteTransport(obj *model.Transport) error {
 
 // ValidatePublishRequest validates a complete publish request including extensions
 func ValidatePublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config) error {
+	if errs := publishRequestErrors(ctx, req, cfg, false); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// PublishRequestErrors runs every publish check, including package registry checks if enabled,
+// and returns all failures rather than just the first
+func PublishRequestErrors(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config) []error {
+	return publishRequestErrors(ctx, req, cfg, true)
+}
+
+func publishRequestErrors(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config, all bool) []error {
 	// Validate publisher extensions in _meta
-	if err := validatePublisherExtensions(req); err != nil {
-		return err
+	errs := runChecks([]func() error{func() error { return validatePublisherExtensions(req) }}, all)
+	if len(errs) > 0 && !all {
+		return errs
 	}
 
 	// Validate the server detail (includes all nested validation)
-	if err := ValidateServerJSON(&req); err != nil {
-		return err
+	_, span := tracer.Start(ctx, "validate server.json")
+	errs = append(errs, serverJSONErrors(&req, all)...)
+	span.End()
+	if len(errs) > 0 && !all {
+		return errs
 	}
 
 	// Validate registry ownership for all packages if validation is enabled
 	if cfg.EnableRegistryValidation {
+		var checks []func() error
 		for i, pkg := range req.Packages {
-			if err := ValidatePackage(ctx, pkg, req.Name); err != nil {
-				return fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
-			}
+			checks = append(checks, func() error {
+				if err := ValidatePackage(ctx, pkg, req.Name); err != nil {
+					return fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
+				}
+				return nil
+			})
 		}
+		errs = append(errs, runChecks(checks, all)...)
 	}
 
-	return nil
+	return errs
 }
 
 func validatePublisherExtensions(req apiv0.ServerJSON) error {
@@ -468,6 +500,33 @@ func validatePublisherExtensions(req apiv0.ServerJSON) error {
 	return nil
 }
 
+// ValidateServerName checks that name is a well-formed 'dns-namespace/name' server name
+func ValidateServerName(name string) error {
+	_, err := parseServerName(apiv0.ServerJSON{Name: name})
+	return err
+}
+
+// ValidateNamespace checks that namespace is a well-formed server name namespace, such as 'com.example'
+func ValidateNamespace(namespace string) error {
+	if !namespaceRegex.MatchString(namespace) {
+		return fmt.Errorf("%w: namespace '%s' is invalid. Namespace must start and end with alphanumeric characters, and may contain dots and hyphens in the middle", ErrInvalidServerNameFormat, namespace)
+	}
+	return nil
+}
+
+// ValidateNamespacePattern checks that pattern is a publish permission for part of namespace: either a
+// server name in it, such as 'com.example/tools', or a prefix of server names, such as 'com.example/tools-*'
+func ValidateNamespacePattern(namespace, pattern string) error {
+	if err := ValidateNamespace(namespace); err != nil {
+		return err
+	}
+	name, found := strings.CutPrefix(pattern, namespace+"/")
+	if !found || (!namePartRegex.MatchString(name) && !namePrefixRegex.MatchString(name)) {
+		return fmt.Errorf("pattern '%s' must be a server name in namespace '%s' or a prefix of server names ending in '*', such as '%s/tools-*'", pattern, namespace, namespace)
+	}
+	return nil
+}
+
 func parseServerName(serverJSON apiv0.ServerJSON) (string, error) {
 	name := serverJSON.Name
 	if name == "" {
@@ -2032,3 +2032,65 @@ func TestValidateTitle(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestServerJSONErrors(t *testing.T) {
+	t.Run("collects every failure", func(t *testing.T) {
+		errs := validators.ServerJSONErrors(&apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/server",
+			Description: "Invalid server",
+			Version:     "^1.0.0",
+			Title:       "   ",
+		})
+		assert.Len(t, errs, 2)
+	})
+
+	t.Run("skips dependent checks for an invalid name", func(t *testing.T) {
+		errs := validators.ServerJSONErrors(&apiv0.ServerJSON{
+			Schema:  model.CurrentSchemaURL,
+			Name:    "invalid",
+			Version: "^1.0.0",
+		})
+		assert.Len(t, errs, 1)
+	})
+
+	t.Run("first failure matches ValidateServerJSON", func(t *testing.T) {
+		server := apiv0.ServerJSON{
+			Schema:     model.CurrentSchemaURL,
+			Name:       "com.example/server",
+			Version:    "latest",
+			WebsiteURL: "not a url",
+		}
+		errs := validators.ServerJSONErrors(&server)
+		assert.NotEmpty(t, errs)
+		assert.EqualError(t, validators.ValidateServerJSON(&server), errs[0].Error())
+	})
+}
+
+func TestValidateNamespacePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   bool
+	}{
+		{pattern: "com.acme/tools", valid: true},
+		{pattern: "com.acme/tools-*", valid: true},
+		{pattern: "com.acme/t*", valid: true},
+		{pattern: "com.acme/*", valid: false},
+		{pattern: "com.acme/tools-*-x", valid: false},
+		{pattern: "com.acme/tools/*", valid: false},
+		{pattern: "com.acme.tools/*", valid: false},
+		{pattern: "com.other/tools-*", valid: false},
+		{pattern: "tools-*", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			err := validators.ValidateNamespacePattern("com.acme", tt.pattern)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
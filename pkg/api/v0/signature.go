@@ -0,0 +1,116 @@
+package v0
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+)
+
+// ServerSignatureHeader is the request header that carries a ServerSignature when publishing
+const ServerSignatureHeader = "Server-Signature"
+
+// Algorithms a server.json can be signed with, the same as for DNS and HTTP authentication
+const (
+	SignatureAlgorithmEd25519   = "ed25519"
+	SignatureAlgorithmECDSAP384 = "ecdsap384"
+)
+
+// ServerSignature is a publisher's signature of the canonical form of a server version's server.json
+type ServerSignature struct {
+	Algorithm string `json:"algorithm" enum:"ed25519,ecdsap384" doc:"Signature algorithm"`
+	PublicKey string `json:"publicKey" doc:"Base64 public key that verifies the signature, in the form of the DNS and HTTP authentication key records: the raw key for Ed25519, or the compressed point for ECDSA P-384"`
+	Value     string `json:"value" doc:"Base64 signature of the canonical server.json: Ed25519, or R || S for ECDSA P-384 over its SHA-384 digest"`
+}
+
+// serverSignaturePattern matches the Server-Signature header, as k=<algorithm>; p=<public key>; s=<signature>
+var serverSignaturePattern = regexp.MustCompile(`^\s*k=([a-z0-9]+);\s*p=([A-Za-z0-9+/=]+);\s*s=([A-Za-z0-9+/=]+)\s*$`)
+
+// ParseServerSignature reads a Server-Signature header
+func ParseServerSignature(header string) (*ServerSignature, error) {
+	match := serverSignaturePattern.FindStringSubmatch(header)
+	if match == nil {
+		return nil, errors.New("invalid " + ServerSignatureHeader + " header: expected k=<algorithm>; p=<public key>; s=<signature>")
+	}
+	return &ServerSignature{Algorithm: match[1], PublicKey: match[2], Value: match[3]}, nil
+}
+
+// String returns the signature as a Server-Signature header
+func (s *ServerSignature) String() string {
+	return fmt.Sprintf("k=%s; p=%s; s=%s", s.Algorithm, s.PublicKey, s.Value)
+}
+
+// CanonicalServerJSON returns the bytes a ServerSignature signs: the server as JSON with object keys
+// sorted, no insignificant whitespace and no HTML escaping. Fields ServerJSON does not define are left
+// out, as the registry does not store them.
+func CanonicalServerJSON(server *ServerJSON) ([]byte, error) {
+	data, err := json.Marshal(server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server.json: %w", err)
+	}
+	// Decoded objects are maps, which encoding/json writes with sorted keys
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to canonicalize server.json: %w", err)
+	}
+
+	var canonical bytes.Buffer
+	encoder := json.NewEncoder(&canonical)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to canonicalize server.json: %w", err)
+	}
+	return bytes.TrimSuffix(canonical.Bytes(), []byte("\n")), nil
+}
+
+// Verify checks the signature is of the canonical form of server, made with the private key of its public key
+func (s *ServerSignature) Verify(server *ServerJSON) error {
+	message, err := CanonicalServerJSON(server)
+	if err != nil {
+		return err
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(s.PublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid signature public key: %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(s.Value)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	switch s.Algorithm {
+	case SignatureAlgorithmEd25519:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return errors.New("invalid Ed25519 public key size")
+		}
+		if !ed25519.Verify(ed25519.PublicKey(publicKey), message, signature) {
+			return errors.New("the signature does not match server.json")
+		}
+	case SignatureAlgorithmECDSAP384:
+		curve := elliptic.P384()
+		x, y := elliptic.UnmarshalCompressed(curve, publicKey)
+		if x == nil {
+			return errors.New("invalid ECDSA P-384 public key: expected a compressed point")
+		}
+		if len(signature) != 96 {
+			return errors.New("invalid ECDSA P-384 signature size")
+		}
+		digest := sha512.Sum384(message)
+		r, sigS := new(big.Int).SetBytes(signature[:48]), new(big.Int).SetBytes(signature[48:])
+		if !ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, digest[:], r, sigS) {
+			return errors.New("the signature does not match server.json")
+		}
+	default:
+		return fmt.Errorf("unsupported signature algorithm %q: use %s or %s", s.Algorithm, SignatureAlgorithmEd25519, SignatureAlgorithmECDSAP384)
+	}
+	return nil
+}
@@ -11,6 +11,9 @@ type RegistryExtensions struct {
 	PublishedAt time.Time    `json:"publishedAt" format:"date-time" doc:"Timestamp when the server was first published to the registry"`
 	UpdatedAt   time.Time    `json:"updatedAt,omitempty" format:"date-time" doc:"Timestamp when the server entry was last updated"`
 	IsLatest    bool         `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	Icon        *model.Icon  `json:"icon,omitempty" doc:"Icon uploaded to and served by the registry, if any"`
+	// Signature is verified by the registry when publishing, but clients should check that they trust its public key
+	Signature *ServerSignature `json:"signature,omitempty" doc:"The publisher's signature of server.json, if it was signed when published"`
 }
 
 type ResponseMeta struct {
@@ -27,6 +30,85 @@ type ServerListResponse struct {
 	Metadata Metadata         `json:"metadata" doc:"Pagination metadata"`
 }
 
+// PublishValidation reports the outcome of a publish dry run
+type PublishValidation struct {
+	Valid    bool     `json:"valid" doc:"Whether the server would be published"`
+	Errors   []string `json:"errors" doc:"Problems that would cause the publish to be rejected"`
+	Warnings []string `json:"warnings,omitempty" doc:"Problems that would not prevent publishing"`
+}
+
+// PublishResponse is the published server, or for a dry run the server as it would be published
+// along with the validation results
+type PublishResponse struct {
+	ServerResponse
+	Validation *PublishValidation `json:"validation,omitempty" doc:"Validation results (dry runs only)"`
+}
+
+// PackageValidation reports whether a package passed its registry ownership validation
+type PackageValidation struct {
+	RegistryType string `json:"registryType" doc:"Package registry type" example:"oci"`
+	Identifier   string `json:"identifier" doc:"Package identifier" example:"ghcr.io/user/weather"`
+	Version      string `json:"version,omitempty" doc:"Package version" example:"1.0.0"`
+	Valid        bool   `json:"valid" doc:"Whether the package passed validation"`
+	Error        string `json:"error,omitempty" doc:"Why the package failed validation"`
+}
+
+// ServerRevalidation reports the outcome of re-running package validation for a published server version
+type ServerRevalidation struct {
+	ServerName string              `json:"serverName" doc:"Server name" example:"io.github.user/weather"`
+	Version    string              `json:"version" doc:"Server version that was validated" example:"1.0.0"`
+	Valid      bool                `json:"valid" doc:"Whether every package passed validation"`
+	Packages   []PackageValidation `json:"packages" doc:"Validation result for each package, in server.json order"`
+	CheckedAt  time.Time           `json:"checkedAt" format:"date-time" doc:"When validation was run"`
+}
+
+// PackageClaim is a server version that lists a package
+type PackageClaim struct {
+	ServerName   string       `json:"serverName" doc:"Server name" example:"io.github.acme/foo"`
+	Version      string       `json:"version" doc:"Server version that lists the package" example:"1.0.0"`
+	Status       model.Status `json:"status" enum:"active,deprecated,deleted" doc:"Lifecycle status of the server version"`
+	IsLatest     bool         `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	RegistryType string       `json:"registryType" doc:"Package registry type" example:"oci"`
+	Identifier   string       `json:"identifier" doc:"Package identifier as listed in server.json" example:"docker.io/acme/foo:1.0.0"`
+}
+
+// PackageLookupResponse lists the server versions that claim a package
+type PackageLookupResponse struct {
+	Servers  []PackageClaim `json:"servers" doc:"Server versions listing the package, by server name and then publish date"`
+	Metadata Metadata       `json:"metadata" doc:"Result metadata"`
+}
+
+// PublisherRelease is the manifest of the latest mcp-publisher release, which 'mcp-publisher self-update'
+// checks downloads against
+type PublisherRelease struct {
+	Version     string                  `json:"version" doc:"Release version" example:"v1.3.0"`
+	URL         string                  `json:"url,omitempty" format:"uri" doc:"Release notes" example:"https://github.com/modelcontextprotocol/registry/releases/tag/v1.3.0"`
+	PublishedAt time.Time               `json:"publishedAt" format:"date-time" doc:"When the release was published"`
+	Assets      []PublisherReleaseAsset `json:"assets" doc:"The mcp-publisher archive of each platform"`
+	// The checksums file is what mcp-publisher verifies archives against, once it has checked the file's
+	// signature with the release signing key it was built with
+	ChecksumsURL          string `json:"checksumsUrl,omitempty" format:"uri" doc:"Download URL of the release's checksums file"`
+	ChecksumsSignatureURL string `json:"checksumsSignatureUrl,omitempty" format:"uri" doc:"Download URL of the Ed25519 signature of the checksums file, made with the release signing key"`
+}
+
+// PublisherReleaseAsset is the mcp-publisher archive of one platform
+type PublisherReleaseAsset struct {
+	OS     string `json:"os" doc:"Operating system, as in GOOS" example:"linux"`
+	Arch   string `json:"arch" doc:"Architecture, as in GOARCH" example:"amd64"`
+	Name   string `json:"name" doc:"Archive file name" example:"mcp-publisher_linux_amd64.tar.gz"`
+	URL    string `json:"url" format:"uri" doc:"Download URL of the archive"`
+	SHA256 string `json:"sha256" doc:"SHA-256 checksum of the archive, in hex"`
+}
+
+type BatchGetServersRequest struct {
+	Names []string `json:"names" minItems:"1" maxItems:"100" doc:"Server names to fetch (latest version of each)" example:"[\"io.github.user/weather\"]"`
+}
+
+type BatchGetServersResponse struct {
+	Servers  []ServerResponse `json:"servers" doc:"Latest version of each server that was found, in request order"`
+	NotFound []string         `json:"notFound" doc:"Requested server names that do not exist in the registry"`
+}
+
 type ServerMeta struct {
 	PublisherProvided map[string]interface{} `json:"io.modelcontextprotocol.registry/publisher-provided,omitempty" doc:"Publisher-provided metadata for downstream registries"`
 }
@@ -36,7 +118,7 @@ type ServerJSON struct {
 	Name        string            `json:"name" minLength:"3" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"Server name in reverse-DNS format. Must contain exactly one forward slash separating namespace from server name." example:"io.github.user/weather"`
 	Description string            `json:"description" minLength:"1" maxLength:"100" doc:"Clear human-readable explanation of server functionality." example:"MCP server providing weather data and forecasts via OpenWeatherMap API"`
 	Title       string            `json:"title,omitempty" minLength:"1" maxLength:"100" doc:"Optional human-readable title or display name for the MCP server." example:"Weather API"`
-	Repository  model.Repository  `json:"repository,omitempty" doc:"Optional repository metadata for the MCP server source code."`
+	Repository  model.Repository  `json:"repository,omitzero" doc:"Optional repository metadata for the MCP server source code."`
 	Version     string            `json:"version" doc:"Version string for this server. SHOULD follow semantic versioning." example:"1.0.2"`
 	WebsiteURL  string            `json:"websiteUrl,omitempty" format:"uri" doc:"Optional URL to the server's homepage, documentation, or project website." example:"https://modelcontextprotocol.io/examples"`
 	Icons       []model.Icon      `json:"icons,omitempty" doc:"Optional set of sized icons that the client can display in a user interface."`
@@ -0,0 +1,72 @@
+// Package schema bundles the server.json JSON Schema, so that server.json files can be checked
+// without fetching it. The bundled copy is generated along with
+// docs/reference/server-json/server.schema.json by 'make generate-schema'.
+package schema
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	jsonschema "github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ServerSchema is the current server.json JSON Schema, published at model.CurrentSchemaURL
+//
+//go:embed server.schema.json
+var ServerSchema []byte
+
+var compileServerSchema = sync.OnceValues(func() (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+	if err := compiler.AddResource(model.CurrentSchemaURL, bytes.NewReader(ServerSchema)); err != nil {
+		return nil, fmt.Errorf("failed to load server.json schema: %w", err)
+	}
+	return compiler.Compile(model.CurrentSchemaURL)
+})
+
+// ValidateServerJSON checks a server.json document against ServerSchema and returns each
+// violation, prefixed with the JSON pointer of the value it is about
+func ValidateServerJSON(data []byte) ([]error, error) {
+	serverSchema, err := compileServerSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	// Numbers are kept as written, so the schema checks them exactly
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	err = serverSchema.Validate(document)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	var violations []error
+	var collect func(*jsonschema.ValidationError)
+	collect = func(ve *jsonschema.ValidationError) {
+		if len(ve.Causes) == 0 {
+			location := ve.InstanceLocation
+			if location == "" {
+				location = "/"
+			}
+			violations = append(violations, fmt.Errorf("%s: %s", location, ve.Message))
+			return
+		}
+		for _, cause := range ve.Causes {
+			collect(cause)
+		}
+	}
+	collect(validationErr)
+	return violations, nil
+}
@@ -0,0 +1,37 @@
+package schema_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/pkg/schema"
+)
+
+func TestValidateServerJSON(t *testing.T) {
+	valid := `{
+		"$schema": "https://static.modelcontextprotocol.io/schemas/2025-10-17/server.schema.json",
+		"name": "io.github.acme/weather",
+		"description": "Weather forecasts for anywhere",
+		"version": "1.0.0",
+		"packages": [{"registryType": "npm", "identifier": "@acme/weather", "version": "1.0.0", "transport": {"type": "stdio"}}]
+	}`
+	violations, err := schema.ValidateServerJSON([]byte(valid))
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	invalid := strings.NewReplacer(
+		`"io.github.acme/weather"`, `"weather"`,
+		`"identifier": "@acme/weather", `, ``,
+	).Replace(valid)
+	violations, err = schema.ValidateServerJSON([]byte(invalid))
+	require.NoError(t, err)
+	require.Len(t, violations, 2)
+	assert.Contains(t, violations[0].Error()+violations[1].Error(), "/name: ")
+	assert.Contains(t, violations[0].Error()+violations[1].Error(), "/packages/0: missing properties: 'identifier'")
+
+	_, err = schema.ValidateServerJSON([]byte(`{"name": `))
+	assert.ErrorContains(t, err, "invalid JSON")
+}
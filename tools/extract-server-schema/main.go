@@ -14,6 +14,8 @@ import (
 const (
 	openAPIPath     = "docs/reference/api/openapi.yaml"
 	schemaOutputDir = "docs/reference/server-json"
+	// The copy bundled into Go programs, such as mcp-publisher validate
+	bundledSchemaPath = "pkg/schema/server.schema.json"
 )
 
 func main() {
@@ -111,32 +113,36 @@ func main() {
 	// Append newline at end
 	jsonStr := string(jsonData) + "\n"
 
-	outputPath := schemaOutputDir + "/server.schema.json"
+	outputPaths := []string{schemaOutputDir + "/server.schema.json", bundledSchemaPath}
 
 	if check {
-		// Check mode: compare with existing file
-		existingData, err := os.ReadFile(outputPath)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading existing schema: %v\n", err)
-			os.Exit(1)
-		}
+		// Check mode: compare with existing files
+		for _, outputPath := range outputPaths {
+			existingData, err := os.ReadFile(outputPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading existing schema: %v\n", err)
+				os.Exit(1)
+			}
 
-		if string(existingData) != jsonStr {
-			fmt.Fprintf(os.Stderr, "ERROR: server.schema.json is out of sync with openapi.yaml\n")
-			fmt.Fprintf(os.Stderr, "Run 'make generate-schema' to update it.\n")
-			os.Exit(1)
+			if string(existingData) != jsonStr {
+				fmt.Fprintf(os.Stderr, "ERROR: %s is out of sync with openapi.yaml\n", outputPath)
+				fmt.Fprintf(os.Stderr, "Run 'make generate-schema' to update it.\n")
+				os.Exit(1)
+			}
 		}
 
 		log.Println("✓ server.schema.json is in sync with openapi.yaml")
 		return
 	}
 
-	// Write mode: update the file
-	if err := os.WriteFile(outputPath, []byte(jsonStr), 0644); err != nil { //nolint:gosec // This is a documentation file that should be world-readable
-		log.Fatalf("Failed to write schema file: %v", err)
-	}
+	// Write mode: update the files
+	for _, outputPath := range outputPaths {
+		if err := os.WriteFile(outputPath, []byte(jsonStr), 0644); err != nil { //nolint:gosec // This is a documentation file that should be world-readable
+			log.Fatalf("Failed to write schema file: %v", err)
+		}
 
-	log.Printf("✓ Generated %s from %s\n", outputPath, openAPIPath)
+		log.Printf("✓ Generated %s from %s\n", outputPath, openAPIPath)
+	}
 }
 
 // findReferencedSchemas recursively finds all schema names referenced via $ref